@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/dirtybirdnj/clood/internal/commands"
+	"github.com/dirtybirdnj/clood/internal/config"
 	"github.com/dirtybirdnj/clood/internal/output"
 	"github.com/dirtybirdnj/clood/internal/tui"
 	"github.com/spf13/cobra"
@@ -49,8 +50,28 @@ func main() {
 	rootCmd.Version = version
 	rootCmd.SetVersionTemplate(tui.RenderBanner() + "\nVersion: {{.Version}}\n")
 
-	// Global --json flag for machine-readable output (MCP/agent friendly)
-	rootCmd.PersistentFlags().BoolVarP(&output.JSONMode, "json", "j", false, "Output in JSON format (for agents/MCP)")
+	// Global --json flag for machine-readable output (MCP/agent friendly).
+	// Bare --json is pretty; --json=ndjson or --json-stream writes one
+	// compact JSON value per line for pipelines.
+	rootCmd.PersistentFlags().VarP(&output.JSONMode, "json", "j", "Output in JSON format (for agents/MCP): pretty, ndjson or off")
+	rootCmd.PersistentFlags().Lookup("json").NoOptDefVal = "pretty"
+	rootCmd.PersistentFlags().Var(output.StreamFlag{}, "json-stream", "Output newline-delimited JSON, one compact value per line (same as --json=ndjson)")
+	rootCmd.PersistentFlags().Lookup("json-stream").NoOptDefVal = "true"
+
+	// Global --dry-run flag: destructive or expensive commands (memory forget,
+	// sd paint, MCP write tools) describe what they'd do and stop. Commands
+	// that don't support it refuse to run rather than ignore it.
+	rootCmd.PersistentFlags().BoolVar(&output.DryRun, "dry-run", false, "Print what destructive or expensive commands would do without doing it")
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		return commands.CheckDryRun(cmd)
+	}
+
+	// Global --profile flag to swap in ~/.config/clood/profiles/<name>.yaml
+	rootCmd.PersistentFlags().StringVar(&config.ActiveProfile, "profile", "", "Config profile to use (see 'clood profile list')")
+
+	// Global --record flag. main handles it before cobra runs, by running
+	// the command as a child and saving its output for 'clood replay'
+	rootCmd.PersistentFlags().String("record", "", "Record the command's input, output and timing to a file for 'clood replay'")
 
 	// Define command groups (order matters - this is display order)
 	rootCmd.AddGroup(
@@ -74,7 +95,10 @@ func main() {
 	addWithGroup(rootCmd, commands.SetupCmd(), GroupStart)
 	addWithGroup(rootCmd, commands.VerifyCmd(), GroupStart)
 	addWithGroup(rootCmd, commands.DoctorCmd(), GroupStart)
+	addWithGroup(rootCmd, commands.ConfigCmd(), GroupStart)
+	addWithGroup(rootCmd, commands.ProfileCmd(), GroupStart)
 	addWithGroup(rootCmd, commands.UpdateCmd(), GroupStart)
+	addWithGroup(rootCmd, commands.VersionCmd(), GroupStart)
 	addWithGroup(rootCmd, completionCmd(), GroupStart)
 
 	// ═══════════════════════════════════════════════════════════════
@@ -109,6 +133,7 @@ func main() {
 	addWithGroup(rootCmd, commands.TriageCmd(), GroupCompare)
 	addWithGroup(rootCmd, commands.WatchCmd(), GroupCompare)
 	addWithGroup(rootCmd, commands.BenchCmd(), GroupCompare)
+	addWithGroup(rootCmd, commands.DiffModelsCmd(), GroupCompare)
 
 	// ═══════════════════════════════════════════════════════════════
 	// 🔍 CODEBASE ANALYSIS (zero network)
@@ -127,6 +152,7 @@ func main() {
 	addWithGroup(rootCmd, commands.DiagnoseCmd(), GroupAIPowered)
 	addWithGroup(rootCmd, commands.DocumentCmd(), GroupAIPowered)
 	addWithGroup(rootCmd, commands.CommitMsgCmd(), GroupAIPowered)
+	addWithGroup(rootCmd, commands.GitCmd(), GroupAIPowered)
 	addWithGroup(rootCmd, commands.ReviewPRCmd(), GroupAIPowered)
 	addWithGroup(rootCmd, commands.GenerateTestsCmd(), GroupAIPowered)
 	addWithGroup(rootCmd, commands.ExtractCmd(), GroupAIPowered)
@@ -139,6 +165,8 @@ func main() {
 	addWithGroup(rootCmd, commands.CheckpointCmd(), GroupSession)
 	addWithGroup(rootCmd, commands.FocusCmd(), GroupSession)
 	addWithGroup(rootCmd, commands.BeansCmd(), GroupSession)
+	addWithGroup(rootCmd, commands.MemoryCmd(), GroupSession)
+	addWithGroup(rootCmd, commands.ReplayCmd(), GroupSession)
 
 	// ═══════════════════════════════════════════════════════════════
 	// 🕵️  AGENTS & DELEGATION
@@ -161,7 +189,9 @@ func main() {
 	addWithGroup(rootCmd, commands.BcbcCmd(), GroupMeta)
 	addWithGroup(rootCmd, commands.BuildCheckCmd(), GroupMeta)
 	addWithGroup(rootCmd, commands.SettingsAuditCmd(), GroupMeta)
+	addWithGroup(rootCmd, commands.AuditCmd(), GroupMeta)
 	addWithGroup(rootCmd, commands.IssuesCmd(), GroupMeta)
+	addWithGroup(rootCmd, commands.IssueCmd(), GroupMeta)
 
 	// ═══════════════════════════════════════════════════════════════
 	// 🧪 EXPERIMENTAL
@@ -172,10 +202,23 @@ func main() {
 	addWithGroup(rootCmd, commands.InceptionCmd(), GroupExperimental)
 	addWithGroup(rootCmd, commands.SnakewayProtoCmd(), GroupExperimental)
 	addWithGroup(rootCmd, commands.OutputMapCmd(), GroupExperimental)
+	addWithGroup(rootCmd, commands.SdCmd(), GroupExperimental)
+
+	// User plugins: clood-* executables on $PATH. Built-ins take precedence.
+	for _, cmd := range commands.PluginCmds(func(name string) bool { return hasCommand(rootCmd, name) }) {
+		addWithGroup(rootCmd, cmd, GroupExperimental)
+	}
 
 	// Set current version for update command
 	commands.CurrentVersion = version
 
+	if path, args, ok, err := commands.RecordArgs(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render(err.Error()))
+		os.Exit(1)
+	} else if ok {
+		os.Exit(commands.RecordSession(path, args))
+	}
+
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render(err.Error()))
 		os.Exit(1)
@@ -188,6 +231,16 @@ func addWithGroup(root *cobra.Command, cmd *cobra.Command, groupID string) {
 	root.AddCommand(cmd)
 }
 
+// hasCommand reports whether root already has a subcommand called name
+func hasCommand(root *cobra.Command, name string) bool {
+	for _, cmd := range root.Commands() {
+		if cmd.Name() == name || cmd.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
+
 func initCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "init",
@@ -250,6 +303,11 @@ func completionCmd() *cobra.Command {
 		Short: "Generate shell completion scripts",
 		Long: `Generate shell completion scripts for clood.
 
+Model names are completed live: 'clood ask --model', 'clood catfight
+--models' and the sd commands' --checkpoint ask clood for the models on
+the online hosts (or the checkpoints on the ComfyUI --url) as you press
+Tab, so the list follows whatever is installed.
+
 To load completions:
 
 Bash:
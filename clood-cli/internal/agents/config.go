@@ -11,14 +11,15 @@ import (
 
 // Agent represents a configured agent role
 type Agent struct {
-	Name        string  `yaml:"-"` // Set from map key
-	Description string  `yaml:"description,omitempty"`
-	Model       string  `yaml:"model,omitempty"`
-	Host        string  `yaml:"host,omitempty"`
-	System      string  `yaml:"system,omitempty"`
-	Temperature float64 `yaml:"temperature,omitempty"`
-	MaxTokens   int     `yaml:"max_tokens,omitempty"`
-	Timeout     string  `yaml:"timeout,omitempty"`
+	Name          string   `yaml:"-"` // Set from map key
+	Description   string   `yaml:"description,omitempty"`
+	Model         string   `yaml:"model,omitempty"`
+	Host          string   `yaml:"host,omitempty"`
+	System        string   `yaml:"system,omitempty"`
+	Temperature   *float64 `yaml:"temperature,omitempty"`    // Unset means 0.7; 0 is kept
+	MaxTokens     int      `yaml:"max_tokens,omitempty"`     // Sent as num_predict
+	StopSequences []string `yaml:"stop_sequences,omitempty"` // Generation ends at any of these
+	Timeout       string   `yaml:"timeout,omitempty"`
 }
 
 // Defaults contains default settings for all agents
@@ -47,12 +48,27 @@ func (a *Agent) ParsedTimeout() time.Duration {
 
 // GetEffectiveTemperature returns temperature or default
 func (a *Agent) GetEffectiveTemperature() float64 {
-	if a.Temperature > 0 {
-		return a.Temperature
+	if a.Temperature != nil {
+		return *a.Temperature
 	}
 	return 0.7 // sensible default
 }
 
+// Options returns the Ollama request options for the agent's sampling
+// settings: temperature always, num_predict and stop when set
+func (a *Agent) Options() map[string]interface{} {
+	opts := map[string]interface{}{
+		"temperature": a.GetEffectiveTemperature(),
+	}
+	if a.MaxTokens > 0 {
+		opts["num_predict"] = a.MaxTokens
+	}
+	if len(a.StopSequences) > 0 {
+		opts["stop"] = a.StopSequences
+	}
+	return opts
+}
+
 // LoadConfig loads agent configuration from project or global location
 func LoadConfig() (*AgentConfig, error) {
 	// Search order:
@@ -141,6 +157,11 @@ func (c *AgentConfig) ListAgents() []string {
 	return names
 }
 
+// temperature returns a pointer for Agent.Temperature
+func temperature(t float64) *float64 {
+	return &t
+}
+
 // defaultAgents returns built-in default agent configurations
 func defaultAgents() map[string]*Agent {
 	return map[string]*Agent{
@@ -153,7 +174,7 @@ func defaultAgents() map[string]*Agent {
 - Performance issues
 - Style and readability
 Return findings as structured bullet points.`,
-			Temperature: 0.3,
+			Temperature: temperature(0.3),
 		},
 		"coder": {
 			Name:        "coder",
@@ -161,7 +182,7 @@ Return findings as structured bullet points.`,
 			System: `You are a coding assistant. Write clean, well-documented code.
 Follow existing patterns in the codebase.
 Include error handling.`,
-			Temperature: 0.7,
+			Temperature: temperature(0.7),
 		},
 		"documenter": {
 			Name:        "documenter",
@@ -169,7 +190,7 @@ Include error handling.`,
 			System: `You write clear, concise documentation.
 Use examples where helpful.
 Match the project's documentation style.`,
-			Temperature: 0.5,
+			Temperature: temperature(0.5),
 		},
 		"analyst": {
 			Name:        "analyst",
@@ -177,11 +198,83 @@ Match the project's documentation style.`,
 			System: `You analyze code structure and architecture.
 Explain how components interact.
 Identify potential improvements.`,
-			Temperature: 0.4,
+			Temperature: temperature(0.4),
 		},
 	}
 }
 
+// GlobalConfigPath is where 'clood agents create' saves agents
+func GlobalConfigPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".config", "clood", "agents.yaml"), nil
+}
+
+// LoadGlobalConfig loads ~/.config/clood/agents.yaml for editing. When the
+// file doesn't exist yet it starts from the built-in agents, so saving the
+// first custom agent doesn't hide them.
+func LoadGlobalConfig() (*AgentConfig, error) {
+	path, err := GlobalConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := loadFromPath(path)
+	if os.IsNotExist(err) {
+		return &AgentConfig{Agents: defaultAgents()}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Agents == nil {
+		cfg.Agents = make(map[string]*Agent)
+	}
+	return cfg, nil
+}
+
+// Save writes the config to path, creating its directory
+func (c *AgentConfig) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// SetAgent adds or replaces an agent, keyed by its name
+func (c *AgentConfig) SetAgent(agent *Agent) {
+	if c.Agents == nil {
+		c.Agents = make(map[string]*Agent)
+	}
+	c.Agents[agent.Name] = agent
+}
+
+// RemoveAgent deletes an agent and reports whether it existed
+func (c *AgentConfig) RemoveAgent(name string) bool {
+	if _, ok := c.Agents[name]; !ok {
+		return false
+	}
+	delete(c.Agents, name)
+	return true
+}
+
+// ValidateName checks that name works as a YAML key and a CLI argument
+func ValidateName(name string) error {
+	if name == "" {
+		return fmt.Errorf("agent name is required")
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return fmt.Errorf("agent name %q may only contain letters, digits, '-' and '_'", name)
+		}
+	}
+	return nil
+}
+
 // ConfigPaths returns the search paths for agent configs
 func ConfigPaths() []string {
 	paths := []string{".clood/agents.yaml"}
@@ -14,6 +14,7 @@ import (
 // CodebaseAnalysis holds all static analysis results
 type CodebaseAnalysis struct {
 	ProjectRoot   string
+	Language      Language
 	Timestamp     time.Time
 	LineCount     LineCount
 	GoVet         VetResult
@@ -23,9 +24,11 @@ type CodebaseAnalysis struct {
 	RecentCommits []string
 	ChangedFiles  []string
 	Symbols       SymbolSummary
+	Duplicates    []DuplicateGroup // only with CheckDuplicates
 }
 
-// LineCount tracks lines of code by type
+// LineCount tracks lines of code by type. The Go* fields hold source
+// counts for whichever language was analyzed.
 type LineCount struct {
 	GoLines    int
 	GoFiles    int
@@ -35,8 +38,9 @@ type LineCount struct {
 	TotalFiles int
 }
 
-// VetResult holds go vet output
+// VetResult holds go vet (or Python linter) output
 type VetResult struct {
+	Linter   string // "go vet", "ruff", "pylint"
 	Clean    bool
 	Issues   []string
 	Duration time.Duration
@@ -74,10 +78,24 @@ type SymbolSummary struct {
 	Constants int
 }
 
-// RunAnalysis performs full static analysis on a Go project
+// RunAnalysis performs full static analysis, detecting the project language
 func RunAnalysis(projectRoot string, runTests bool) (*CodebaseAnalysis, error) {
+	return RunAnalysisLang(projectRoot, LangAuto, runTests)
+}
+
+// RunAnalysisLang performs full static analysis for the given language.
+// LangAuto detects it from go.mod / pyproject.toml / setup.py / requirements.txt.
+func RunAnalysisLang(projectRoot string, lang Language, runTests bool) (*CodebaseAnalysis, error) {
+	if lang == "" || lang == LangAuto {
+		lang = DetectLanguage(projectRoot)
+	}
+	if lang == LangPython {
+		return runPythonAnalysis(projectRoot, runTests), nil
+	}
+
 	analysis := &CodebaseAnalysis{
 		ProjectRoot: projectRoot,
+		Language:    LangGo,
 		Timestamp:   time.Now(),
 	}
 
@@ -115,7 +133,7 @@ func RunAnalysis(projectRoot string, runTests bool) (*CodebaseAnalysis, error) {
 	// Git history
 	go func() {
 		analysis.RecentCommits = getRecentCommits(projectRoot, 10)
-		analysis.ChangedFiles = getChangedFiles(projectRoot, 5)
+		analysis.ChangedFiles = getChangedFiles(projectRoot, 5, ".go")
 		results <- result{"git", nil}
 	}()
 
@@ -138,6 +156,49 @@ func RunAnalysis(projectRoot string, runTests bool) (*CodebaseAnalysis, error) {
 	return analysis, nil
 }
 
+// runPythonAnalysis mirrors the Go pipeline: compileall stands in for
+// go build, ruff/pylint for go vet, pytest for go test.
+func runPythonAnalysis(projectRoot string, runTests bool) *CodebaseAnalysis {
+	analysis := &CodebaseAnalysis{
+		ProjectRoot: projectRoot,
+		Language:    LangPython,
+		Timestamp:   time.Now(),
+	}
+
+	done := make(chan struct{}, 6)
+	go func() { analysis.LineCount = countPythonLines(projectRoot); done <- struct{}{} }()
+	go func() { analysis.GoVet = runPythonLint(projectRoot); done <- struct{}{} }()
+	go func() { analysis.BuildStatus = runPythonCompile(projectRoot); done <- struct{}{} }()
+	go func() { analysis.TodoItems = findPythonTodos(projectRoot); done <- struct{}{} }()
+	go func() {
+		analysis.RecentCommits = getRecentCommits(projectRoot, 10)
+		analysis.ChangedFiles = getChangedFiles(projectRoot, 5, ".py")
+		done <- struct{}{}
+	}()
+	go func() { analysis.Symbols = countPythonSymbols(projectRoot); done <- struct{}{} }()
+	for i := 0; i < 6; i++ {
+		<-done
+	}
+
+	if runTests {
+		analysis.TestStatus = runPytest(projectRoot)
+	}
+
+	return analysis
+}
+
+// langLabels returns display names for the source files and linter
+func (a *CodebaseAnalysis) langLabels() (files, linter string) {
+	if a.Language == LangPython {
+		linter = a.GoVet.Linter
+		if linter == "" || linter == "none" {
+			linter = "lint"
+		}
+		return "Python", strings.ToUpper(linter)
+	}
+	return "Go", "GO VET"
+}
+
 // FormatForClaude produces a context string optimized for Claude ingestion
 func (a *CodebaseAnalysis) FormatForClaude() string {
 	var sb strings.Builder
@@ -146,9 +207,11 @@ func (a *CodebaseAnalysis) FormatForClaude() string {
 	sb.WriteString(fmt.Sprintf("Project: %s\n", a.ProjectRoot))
 	sb.WriteString(fmt.Sprintf("Analyzed: %s\n\n", a.Timestamp.Format("2006-01-02 15:04:05")))
 
+	filesLabel, linterLabel := a.langLabels()
+
 	// Scope
 	sb.WriteString("## PROJECT SCOPE\n")
-	sb.WriteString(fmt.Sprintf("- Go files: %d (%d lines)\n", a.LineCount.GoFiles, a.LineCount.GoLines))
+	sb.WriteString(fmt.Sprintf("- %s files: %d (%d lines)\n", filesLabel, a.LineCount.GoFiles, a.LineCount.GoLines))
 	sb.WriteString(fmt.Sprintf("- Test files: %d (%d lines)\n", a.LineCount.TestFiles, a.LineCount.TestLines))
 	sb.WriteString(fmt.Sprintf("- Symbols: %d funcs, %d types, %d methods\n\n",
 		a.Symbols.Functions, a.Symbols.Types, a.Symbols.Methods))
@@ -165,8 +228,8 @@ func (a *CodebaseAnalysis) FormatForClaude() string {
 	}
 	sb.WriteString("\n")
 
-	// Go vet
-	sb.WriteString("## GO VET\n")
+	// Go vet / Python lint
+	sb.WriteString(fmt.Sprintf("## %s\n", linterLabel))
 	if a.GoVet.Clean {
 		sb.WriteString("- VET: CLEAN\n")
 	} else {
@@ -216,6 +279,12 @@ func (a *CodebaseAnalysis) FormatForClaude() string {
 		sb.WriteString("\n")
 	}
 
+	if len(a.Duplicates) > 0 {
+		sb.WriteString("## DUPLICATE CODE (refactoring candidates)\n")
+		sb.WriteString(a.FormatDuplicates())
+		sb.WriteString("\n")
+	}
+
 	return sb.String()
 }
 
@@ -231,6 +300,25 @@ func (a *CodebaseAnalysis) FormatSummary() string {
 		status, a.LineCount.GoFiles, a.LineCount.GoLines, len(a.TodoItems), a.Timestamp.Format("15:04:05"))
 }
 
+// HasFindings reports whether there is anything worth filing: a failed
+// build, linter issues, failing tests, or TODO items
+func (a *CodebaseAnalysis) HasFindings() bool {
+	return !a.BuildStatus.Success || !a.GoVet.Clean || a.TestStatus.Failed > 0 || len(a.TodoItems) > 0
+}
+
+// SuggestedLabels proposes GitHub labels for an issue filed from the
+// analysis: bug for build, lint and test failures, enhancement for TODOs
+func (a *CodebaseAnalysis) SuggestedLabels() []string {
+	var labels []string
+	if !a.BuildStatus.Success || !a.GoVet.Clean || a.TestStatus.Failed > 0 {
+		labels = append(labels, "bug")
+	}
+	if len(a.TodoItems) > 0 {
+		labels = append(labels, "enhancement")
+	}
+	return labels
+}
+
 // --- Internal analysis functions ---
 
 func countLines(root string) LineCount {
@@ -274,7 +362,7 @@ func runGoVet(root string) VetResult {
 	output, err := cmd.CombinedOutput()
 	duration := time.Since(start)
 
-	result := VetResult{Duration: duration}
+	result := VetResult{Linter: "go vet", Duration: duration}
 	if err == nil && len(output) == 0 {
 		result.Clean = true
 		return result
@@ -397,7 +485,7 @@ func getRecentCommits(root string, n int) []string {
 	return lines
 }
 
-func getChangedFiles(root string, commits int) []string {
+func getChangedFiles(root string, commits int, ext string) []string {
 	cmd := exec.Command("git", "diff", "--name-only", fmt.Sprintf("HEAD~%d", commits))
 	cmd.Dir = root
 	output, err := cmd.Output()
@@ -406,14 +494,14 @@ func getChangedFiles(root string, commits int) []string {
 	}
 
 	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-	// Filter to Go files
-	var goFiles []string
+	// Filter to source files
+	var srcFiles []string
 	for _, f := range lines {
-		if strings.HasSuffix(f, ".go") {
-			goFiles = append(goFiles, f)
+		if strings.HasSuffix(f, ext) {
+			srcFiles = append(srcFiles, f)
 		}
 	}
-	return goFiles
+	return srcFiles
 }
 
 func countSymbols(root string) SymbolSummary {
@@ -0,0 +1,81 @@
+package analyze
+
+import (
+	"sort"
+	"strings"
+)
+
+// ImportCycles finds import cycles in graph (package -> packages it
+// imports) with a depth-first search. Each cycle is returned once, rotated
+// to start at its smallest package and closed, e.g. [a b c a].
+func ImportCycles(graph map[string][]string) [][]string {
+	const (
+		unvisited = iota
+		onStack
+		done
+	)
+
+	state := make(map[string]int)
+	var stack []string
+	seen := make(map[string]bool)
+	var cycles [][]string
+
+	var visit func(pkg string)
+	visit = func(pkg string) {
+		state[pkg] = onStack
+		stack = append(stack, pkg)
+
+		deps := append([]string(nil), graph[pkg]...)
+		sort.Strings(deps)
+		for _, dep := range deps {
+			switch state[dep] {
+			case unvisited:
+				visit(dep)
+			case onStack:
+				for i := len(stack) - 1; i >= 0; i-- {
+					if stack[i] == dep {
+						cycle := canonicalCycle(stack[i:])
+						key := strings.Join(cycle, "\x00")
+						if !seen[key] {
+							seen[key] = true
+							cycles = append(cycles, cycle)
+						}
+						break
+					}
+				}
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		state[pkg] = done
+	}
+
+	var pkgs []string
+	for pkg := range graph {
+		pkgs = append(pkgs, pkg)
+	}
+	sort.Strings(pkgs)
+	for _, pkg := range pkgs {
+		if state[pkg] == unvisited {
+			visit(pkg)
+		}
+	}
+
+	return cycles
+}
+
+// canonicalCycle rotates path so the smallest package comes first, then
+// repeats it at the end to close the loop
+func canonicalCycle(path []string) []string {
+	start := 0
+	for i, pkg := range path {
+		if pkg < path[start] {
+			start = i
+		}
+	}
+
+	cycle := make([]string, 0, len(path)+1)
+	cycle = append(cycle, path[start:]...)
+	cycle = append(cycle, path[:start]...)
+	return append(cycle, cycle[0])
+}
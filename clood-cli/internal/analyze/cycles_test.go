@@ -0,0 +1,36 @@
+package analyze
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestImportCycles(t *testing.T) {
+	graph := map[string][]string{
+		"app/a": {"app/b", "fmt"},
+		"app/b": {"app/c"},
+		"app/c": {"app/a"},
+		"app/d": {"app/d"},
+		"app/e": {"app/a"},
+	}
+
+	got := ImportCycles(graph)
+	want := [][]string{
+		{"app/a", "app/b", "app/c", "app/a"},
+		{"app/d", "app/d"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ImportCycles() = %v, want %v", got, want)
+	}
+}
+
+func TestImportCyclesAcyclic(t *testing.T) {
+	graph := map[string][]string{
+		"app/cmd":  {"app/core", "app/util"},
+		"app/core": {"app/util"},
+		"app/util": nil,
+	}
+	if got := ImportCycles(graph); len(got) != 0 {
+		t.Errorf("ImportCycles() = %v, want none", got)
+	}
+}
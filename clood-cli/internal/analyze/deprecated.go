@@ -0,0 +1,170 @@
+package analyze
+
+import (
+	_ "embed"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed deprecated_functions.yaml
+var bundledDeprecations []byte
+
+// Deprecation is one deprecated_functions.yaml entry
+type Deprecation struct {
+	Func        string `yaml:"func" json:"func"` // import path and name: io/ioutil.ReadAll
+	Since       string `yaml:"since" json:"since,omitempty"`
+	Replacement string `yaml:"replacement" json:"replacement"`
+}
+
+// DeprecatedUse is a reference to a deprecated function in a .go file
+type DeprecatedUse struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+	Deprecation
+}
+
+// LoadDeprecations reads a deprecation list, or the bundled one when path
+// is empty
+func LoadDeprecations(path string) ([]Deprecation, error) {
+	data := bundledDeprecations
+	if path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	var list []Deprecation
+	if err := yaml.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("parsing deprecation list: %w", err)
+	}
+	for _, d := range list {
+		if _, _, ok := splitFuncPath(d.Func); !ok {
+			return nil, fmt.Errorf("deprecation list: %q is not importpath.Name", d.Func)
+		}
+	}
+	return list, nil
+}
+
+// splitFuncPath splits "crypto/x509.ParseCRL" into its import path and name
+func splitFuncPath(s string) (string, string, bool) {
+	slash := strings.LastIndex(s, "/")
+	dot := strings.Index(s[slash+1:], ".")
+	if dot < 0 {
+		return "", "", false
+	}
+	dot += slash + 1
+	if dot == 0 || dot == len(s)-1 {
+		return "", "", false
+	}
+	return s[:dot], s[dot+1:], true
+}
+
+// FindDeprecated parses the .go files under root, tests included, and
+// reports every pkg.Name reference to a listed function, ordered by file
+// and line. Imports are matched by path, so renamed imports are caught
+// and a local variable that happens to be called rand is not.
+func FindDeprecated(root string, list []Deprecation) []DeprecatedUse {
+	byPath := map[string]map[string]Deprecation{}
+	for _, d := range list {
+		pkg, name, _ := splitFuncPath(d.Func)
+		if byPath[pkg] == nil {
+			byPath[pkg] = map[string]Deprecation{}
+		}
+		byPath[pkg][name] = d
+	}
+
+	var uses []DeprecatedUse
+	filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() {
+			if name := info.Name(); p != root && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(p, ".go") {
+			return nil
+		}
+
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, p, nil, 0)
+		if err != nil || ast.IsGenerated(file) {
+			return nil
+		}
+
+		// local package name -> deprecated names in that package
+		imported := map[string]map[string]Deprecation{}
+		for _, imp := range file.Imports {
+			ipath, err := strconv.Unquote(imp.Path.Value)
+			if err != nil || byPath[ipath] == nil {
+				continue
+			}
+			local := path.Base(ipath)
+			if imp.Name != nil {
+				local = imp.Name.Name
+			}
+			if local != "_" && local != "." {
+				imported[local] = byPath[ipath]
+			}
+		}
+		if len(imported) == 0 {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			rel = p
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			sel, ok := n.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			// The parser resolves locals, so an Obj means x is a variable
+			// shadowing the import
+			x, ok := sel.X.(*ast.Ident)
+			if !ok || x.Obj != nil {
+				return true
+			}
+			if d, ok := imported[x.Name][sel.Sel.Name]; ok {
+				uses = append(uses, DeprecatedUse{File: rel, Line: fset.Position(sel.Pos()).Line, Deprecation: d})
+			}
+			return true
+		})
+		return nil
+	})
+
+	sort.SliceStable(uses, func(i, j int) bool {
+		if uses[i].File != uses[j].File {
+			return uses[i].File < uses[j].File
+		}
+		return uses[i].Line < uses[j].Line
+	})
+	return uses
+}
+
+// FormatDeprecated lists the uses, one line each with the replacement
+func FormatDeprecated(uses []DeprecatedUse) string {
+	var sb strings.Builder
+	for _, u := range uses {
+		since := ""
+		if u.Since != "" {
+			since = " (deprecated in Go " + u.Since + ")"
+		}
+		fmt.Fprintf(&sb, "  %s:%d %s%s\n", u.File, u.Line, u.Func, since)
+		fmt.Fprintf(&sb, "      use %s\n", u.Replacement)
+	}
+	return sb.String()
+}
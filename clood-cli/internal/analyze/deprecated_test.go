@@ -0,0 +1,101 @@
+package analyze
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const deprecatedSrc = `package p
+
+import (
+	"io/ioutil"
+	mrand "math/rand"
+	"strings"
+)
+
+func load(path string) ([]byte, error) {
+	mrand.Seed(1)
+	return ioutil.ReadFile(path)
+}
+
+func shadowed() string {
+	strings := struct{ Title func(string) string }{nil}
+	return strings.Title("x")
+}
+
+func title(s string) string {
+	return strings.Title(s) + strings.ToUpper(s)
+}
+`
+
+func TestLoadDeprecationsBundled(t *testing.T) {
+	list, err := LoadDeprecations("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, d := range list {
+		if d.Func == "math/rand.Seed" && d.Since == "1.20" && d.Replacement != "" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("bundled list has no math/rand.Seed entry: %+v", list)
+	}
+}
+
+func TestSplitFuncPath(t *testing.T) {
+	for in, want := range map[string][2]string{
+		"io/ioutil.ReadAll":    {"io/ioutil", "ReadAll"},
+		"strings.Title":        {"strings", "Title"},
+		"crypto/x509.ParseCRL": {"crypto/x509", "ParseCRL"},
+	} {
+		pkg, name, ok := splitFuncPath(in)
+		if !ok || pkg != want[0] || name != want[1] {
+			t.Errorf("splitFuncPath(%q) = %q, %q, %v", in, pkg, name, ok)
+		}
+	}
+	for _, bad := range []string{"ReadAll", "io/ioutil", "strings."} {
+		if _, _, ok := splitFuncPath(bad); ok {
+			t.Errorf("splitFuncPath(%q) should fail", bad)
+		}
+	}
+}
+
+func TestFindDeprecated(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "p.go"), []byte(deprecatedSrc), 0644); err != nil {
+		t.Fatal(err)
+	}
+	list, err := LoadDeprecations("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uses := FindDeprecated(dir, list)
+	want := []struct {
+		line int
+		fn   string
+	}{
+		{10, "math/rand.Seed"},
+		{11, "io/ioutil.ReadFile"},
+		{20, "strings.Title"},
+	}
+	if len(uses) != len(want) {
+		t.Fatalf("got %d uses, want %d: %+v", len(uses), len(want), uses)
+	}
+	for i, w := range want {
+		if uses[i].Line != w.line || uses[i].Func != w.fn || uses[i].File != "p.go" {
+			t.Errorf("use %d = %s:%d %s, want p.go:%d %s", i, uses[i].File, uses[i].Line, uses[i].Func, w.line, w.fn)
+		}
+	}
+}
+
+func TestLoadDeprecationsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "list.yaml")
+	os.WriteFile(path, []byte("- func: Seed\n  replacement: x\n"), 0644)
+	if _, err := LoadDeprecations(path); err == nil {
+		t.Error("an entry without an import path should be rejected")
+	}
+}
@@ -0,0 +1,182 @@
+package analyze
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultDupeMinLines is the shortest function FindDuplicates considers;
+// below it, one-line getters and the like match each other everywhere
+const DefaultDupeMinLines = 6
+
+// DuplicateGroup is a set of functions whose bodies are the same once
+// whitespace, comments and local variable names are normalized away
+type DuplicateGroup struct {
+	Hash  string         `json:"hash"`
+	Lines int            `json:"lines"` // length of the first function
+	Funcs []FuncLocation `json:"funcs"`
+}
+
+// FuncLocation is where a function is declared
+type FuncLocation struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+	Name string `json:"name"` // Recv.Method for methods
+}
+
+// CheckDuplicates fills a.Duplicates. It is opt-in (--check-dupes) as it
+// parses every file, and only applies to Go projects.
+func (a *CodebaseAnalysis) CheckDuplicates(minLines int) {
+	if a.Language == LangGo {
+		a.Duplicates = FindDuplicates(a.ProjectRoot, minLines)
+	}
+}
+
+// FindDuplicates parses the non-test .go files under root and groups
+// functions of at least minLines lines that are near-identical copies.
+// Largest groups by line count come first.
+func FindDuplicates(root string, minLines int) []DuplicateGroup {
+	if minLines <= 0 {
+		minLines = DefaultDupeMinLines
+	}
+
+	byHash := map[string]*DuplicateGroup{}
+	var order []string
+
+	filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() {
+			if name := info.Name(); path != root && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil || ast.IsGenerated(file) {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			rel = path
+		}
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+			start := fset.Position(fn.Pos()).Line
+			lines := fset.Position(fn.End()).Line - start + 1
+			if lines < minLines {
+				continue
+			}
+
+			hash := normalizedBodyHash(fset, fn)
+			g, ok := byHash[hash]
+			if !ok {
+				g = &DuplicateGroup{Hash: hash[:12], Lines: lines}
+				byHash[hash] = g
+				order = append(order, hash)
+			}
+			g.Funcs = append(g.Funcs, FuncLocation{File: rel, Line: start, Name: funcDeclName(fn)})
+		}
+		return nil
+	})
+
+	var groups []DuplicateGroup
+	for _, h := range order {
+		if g := byHash[h]; len(g.Funcs) > 1 {
+			groups = append(groups, *g)
+		}
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Lines*len(groups[i].Funcs) > groups[j].Lines*len(groups[j].Funcs)
+	})
+	return groups
+}
+
+// normalizedBodyHash hashes fn's signature and body after renaming its
+// parameters and locals to v0, v1, ... in order of appearance. Calls,
+// field names, types and literals are kept, so bodies that do different
+// things don't collide just by having the same shape. fn is modified in
+// place.
+func normalizedBodyHash(fset *token.FileSet, fn *ast.FuncDecl) string {
+	names := map[*ast.Object]string{}
+	isLocal := func(id *ast.Ident) bool {
+		obj := id.Obj
+		return obj != nil && obj.Kind == ast.Var && obj.Pos() >= fn.Pos() && obj.Pos() < fn.End()
+	}
+
+	rename := func(n ast.Node) bool {
+		x, ok := n.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		// Look the alias up first: Object.Pos finds the declaration by
+		// name, which stops working once it's been renamed
+		if alias, ok := names[x.Obj]; ok {
+			x.Name = alias
+		} else if isLocal(x) {
+			alias = "v" + strconv.Itoa(len(names))
+			names[x.Obj] = alias
+			x.Name = alias
+		}
+		return true
+	}
+	ast.Inspect(fn.Type, rename)
+	ast.Inspect(fn.Body, rename)
+
+	var buf bytes.Buffer
+	printer.Fprint(&buf, fset, fn.Type)
+	printer.Fprint(&buf, fset, fn.Body)
+
+	sum := sha256.Sum256(buf.Bytes())
+	return hex.EncodeToString(sum[:])
+}
+
+func funcDeclName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+	recv := fn.Recv.List[0].Type
+	if star, ok := recv.(*ast.StarExpr); ok {
+		recv = star.X
+	}
+	if index, ok := recv.(*ast.IndexExpr); ok { // generic receiver
+		recv = index.X
+	}
+	if id, ok := recv.(*ast.Ident); ok {
+		return id.Name + "." + fn.Name.Name
+	}
+	return fn.Name.Name
+}
+
+// FormatDuplicates lists the duplicate groups, one line per copy
+func (a *CodebaseAnalysis) FormatDuplicates() string {
+	var sb strings.Builder
+	for _, g := range a.Duplicates {
+		sb.WriteString(fmt.Sprintf("- %d copies, %d lines each:\n", len(g.Funcs), g.Lines))
+		for _, f := range g.Funcs {
+			sb.WriteString(fmt.Sprintf("  - %s:%d %s\n", f.File, f.Line, f.Name))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,83 @@
+package analyze
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const dupeSrcA = `package p
+
+import "strings"
+
+func cleanNames(names []string) []string {
+	var out []string
+	for _, n := range names {
+		n = strings.TrimSpace(n)
+		if n != "" {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+// Different work, same shape: must not match cleanNames
+func upperNames(names []string) []string {
+	var out []string
+	for _, n := range names {
+		n = strings.ToUpper(n)
+		if n != "" {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+`
+
+const dupeSrcB = `package p
+
+import "strings"
+
+type lister struct{}
+
+// A copy of cleanNames with new variable names and comments
+func (l *lister) tidy(items []string) []string {
+	var kept []string
+	for _, item := range items {
+		item = strings.TrimSpace(item) // trim first
+		if item != "" {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+func short(a int) int { return a }
+func small(b int) int { return b }
+`
+
+func TestFindDuplicates(t *testing.T) {
+	dir := t.TempDir()
+	for name, src := range map[string]string{"a.go": dupeSrcA, "b.go": dupeSrcB} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	groups := FindDuplicates(dir, DefaultDupeMinLines)
+	if len(groups) != 1 {
+		t.Fatalf("got %d groups, want 1: %+v", len(groups), groups)
+	}
+	g := groups[0]
+	if len(g.Funcs) != 2 || g.Funcs[0].Name != "cleanNames" || g.Funcs[1].Name != "lister.tidy" {
+		t.Errorf("group = %+v", g)
+	}
+	if g.Funcs[1].File != "b.go" || g.Funcs[1].Line != 8 || g.Lines != 10 {
+		t.Errorf("location = %+v, lines %d", g.Funcs[1], g.Lines)
+	}
+
+	// The one-liners only match once the size floor is dropped
+	if groups := FindDuplicates(dir, 1); len(groups) != 2 {
+		t.Errorf("minLines 1: got %d groups, want 2", len(groups))
+	}
+}
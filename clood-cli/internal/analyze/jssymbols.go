@@ -0,0 +1,51 @@
+package analyze
+
+import "regexp"
+
+// JS/TS declaration patterns, matched against untrimmed lines so only
+// top-level declarations count. Group 1 is the export keyword (if any).
+var (
+	jsFuncRe  = regexp.MustCompile(`^(export\s+)?(?:default\s+)?(?:async\s+)?function\s*\*?\s*(\w*)`)
+	jsClassRe = regexp.MustCompile(`^(export\s+)?(?:default\s+)?(?:abstract\s+)?class\s+(\w+)`)
+	jsArrowRe = regexp.MustCompile(`^(export\s+)?(?:const|let|var)\s+(\w+)\s*(?::[^=]+)?=\s*(?:async\s+)?(?:function\b|(?:<[^>]*>\s*)?(?:\([^)]*\)|\w+)\s*(?::[^=]+)?=>|\(\s*$)`)
+	jsConstRe = regexp.MustCompile(`^(export\s+)?(?:const|let|var)\s+(\w+)`)
+	jsTypeRe  = regexp.MustCompile(`^(export\s+)?(?:declare\s+)?(?:type|interface|(?:const\s+)?enum)\s+(\w+)`)
+	jsDefault = regexp.MustCompile(`^export\s+default\s+(\w+)\s*;?\s*$`)
+)
+
+// JSSymbol extracts a top-level declaration from one line of JavaScript or
+// TypeScript. kind is func, class, const, or type; arrow functions and
+// function expressions assigned to a const count as func. Anonymous default
+// exports are named "default".
+func JSSymbol(line string) (name, kind string, exported, ok bool) {
+	if m := jsDefault.FindStringSubmatch(line); m != nil {
+		switch m[1] {
+		case "function", "class", "async", "abstract":
+			// handled by the declaration patterns below
+		default:
+			return m[1], "const", true, true
+		}
+	}
+
+	for _, p := range []struct {
+		re   *regexp.Regexp
+		kind string
+	}{
+		{jsFuncRe, "func"},
+		{jsClassRe, "class"},
+		{jsTypeRe, "type"},
+		{jsArrowRe, "func"},
+		{jsConstRe, "const"},
+	} {
+		m := p.re.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		name = m[2]
+		if name == "" {
+			name = "default"
+		}
+		return name, p.kind, m[1] != "", true
+	}
+	return "", "", false, false
+}
@@ -0,0 +1,47 @@
+package analyze
+
+import "testing"
+
+func TestJSSymbol(t *testing.T) {
+	tests := []struct {
+		line     string
+		name     string
+		kind     string
+		exported bool
+		ok       bool
+	}{
+		{"export function fetchUser(id: string) {", "fetchUser", "func", true, true},
+		{"export async function load() {", "load", "func", true, true},
+		{"export default function () {", "default", "func", true, true},
+		{"export default function App() {", "App", "func", true, true},
+		{"function helper() {", "helper", "func", false, true},
+		{"export class Store {", "Store", "class", true, true},
+		{"export default class Page extends Base {", "Page", "class", true, true},
+		{"export abstract class Shape {", "Shape", "class", true, true},
+		{"export const foo = () => {}", "foo", "func", true, true},
+		{"export const bar = async (a, b) => a + b", "bar", "func", true, true},
+		{"export const handler: Handler = (req): Promise<void> => {", "handler", "func", true, true},
+		{"export const id = <T>(x: T) => x", "id", "func", true, true},
+		{"export const single = x => x", "single", "func", true, true},
+		{"export const fn = function () {", "fn", "func", true, true},
+		{"export const useThing = (", "useThing", "func", true, true},
+		{"export const MAX_RETRIES = 3", "MAX_RETRIES", "const", true, true},
+		{"export const config = { a: () => 1 }", "config", "const", true, true},
+		{"const local = 1", "local", "const", false, true},
+		{"export type UserID = string", "UserID", "type", true, true},
+		{"export interface Props {", "Props", "type", true, true},
+		{"export enum Color {", "Color", "type", true, true},
+		{"export default Router;", "Router", "const", true, true},
+		{"  const nested = 1", "", "", false, false},
+		{"import { x } from './x'", "", "", false, false},
+		{"export { a, b }", "", "", false, false},
+	}
+
+	for _, tt := range tests {
+		name, kind, exported, ok := JSSymbol(tt.line)
+		if name != tt.name || kind != tt.kind || exported != tt.exported || ok != tt.ok {
+			t.Errorf("JSSymbol(%q) = (%q, %q, %v, %v), want (%q, %q, %v, %v)",
+				tt.line, name, kind, exported, ok, tt.name, tt.kind, tt.exported, tt.ok)
+		}
+	}
+}
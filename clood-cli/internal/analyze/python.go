@@ -0,0 +1,326 @@
+package analyze
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Language identifies which toolchain RunAnalysisLang dispatches to
+type Language string
+
+const (
+	LangAuto   Language = "auto"
+	LangGo     Language = "go"
+	LangPython Language = "python"
+)
+
+// ParseLanguage validates a --lang value
+func ParseLanguage(s string) (Language, error) {
+	switch Language(strings.ToLower(s)) {
+	case "", LangAuto:
+		return LangAuto, nil
+	case LangGo:
+		return LangGo, nil
+	case LangPython, "py":
+		return LangPython, nil
+	}
+	return "", fmt.Errorf("unknown language %q (use go, python, or auto)", s)
+}
+
+// DetectLanguage picks a language from the project's marker files.
+// go.mod wins in mixed repos; use --lang python to override.
+func DetectLanguage(root string) Language {
+	if fileExists(filepath.Join(root, "go.mod")) {
+		return LangGo
+	}
+	for _, marker := range []string{"pyproject.toml", "setup.py", "setup.cfg", "requirements.txt"} {
+		if fileExists(filepath.Join(root, marker)) {
+			return LangPython
+		}
+	}
+	return LangGo
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// skipPythonDir reports directories that never hold project sources
+func skipPythonDir(name string) bool {
+	switch name {
+	case ".git", ".venv", "venv", "env", "__pycache__", ".tox", ".mypy_cache",
+		".ruff_cache", ".pytest_cache", "node_modules", "site-packages", "build", "dist":
+		return true
+	}
+	return strings.HasSuffix(name, ".egg-info")
+}
+
+// walkPython calls fn for every .py file under root
+func walkPython(root string, fn func(path string, data []byte)) {
+	filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() {
+			if path != root && skipPythonDir(info.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".py") {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		fn(path, data)
+		return nil
+	})
+}
+
+func isPythonTest(path string) bool {
+	base := filepath.Base(path)
+	return strings.HasPrefix(base, "test_") || strings.HasSuffix(base, "_test.py")
+}
+
+func countPythonLines(root string) LineCount {
+	var lc LineCount
+	walkPython(root, func(path string, data []byte) {
+		lines := bytes.Count(data, []byte("\n"))
+		if isPythonTest(path) {
+			lc.TestFiles++
+			lc.TestLines += lines
+		} else {
+			lc.GoFiles++
+			lc.GoLines += lines
+		}
+		lc.TotalFiles++
+		lc.TotalLines += lines
+	})
+	return lc
+}
+
+// runPythonLint runs ruff if installed, otherwise pylint
+func runPythonLint(root string) VetResult {
+	start := time.Now()
+
+	if _, err := exec.LookPath("ruff"); err == nil {
+		cmd := exec.Command("ruff", "check", "--output-format=json", ".")
+		cmd.Dir = root
+		out, _ := cmd.Output() // non-zero exit just means findings
+		result, err := parseRuffOutput(out)
+		if err != nil {
+			result = VetResult{Issues: []string{"ruff: " + err.Error()}}
+		}
+		result.Linter = "ruff"
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	if _, err := exec.LookPath("pylint"); err == nil {
+		cmd := exec.Command("pylint", "--output-format=json", "--recursive=y", ".")
+		cmd.Dir = root
+		out, _ := cmd.Output()
+		result, err := parsePylintOutput(out)
+		if err != nil {
+			result = VetResult{Issues: []string{"pylint: " + err.Error()}}
+		}
+		result.Linter = "pylint"
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	return VetResult{
+		Linter:   "none",
+		Issues:   []string{"no Python linter found (install ruff or pylint)"},
+		Duration: time.Since(start),
+	}
+}
+
+// parseRuffOutput converts `ruff check --output-format=json` into a VetResult
+func parseRuffOutput(out []byte) (VetResult, error) {
+	var findings []struct {
+		Code     string `json:"code"`
+		Message  string `json:"message"`
+		Filename string `json:"filename"`
+		Location struct {
+			Row    int `json:"row"`
+			Column int `json:"column"`
+		} `json:"location"`
+	}
+	if len(bytes.TrimSpace(out)) == 0 {
+		return VetResult{Clean: true}, nil
+	}
+	if err := json.Unmarshal(out, &findings); err != nil {
+		return VetResult{}, fmt.Errorf("parse output: %w", err)
+	}
+
+	result := VetResult{Clean: len(findings) == 0}
+	for _, f := range findings {
+		result.Issues = append(result.Issues, fmt.Sprintf("%s:%d:%d: %s %s",
+			f.Filename, f.Location.Row, f.Location.Column, f.Code, f.Message))
+	}
+	return result, nil
+}
+
+// parsePylintOutput converts `pylint --output-format=json` into a VetResult
+func parsePylintOutput(out []byte) (VetResult, error) {
+	var findings []struct {
+		Path      string `json:"path"`
+		Line      int    `json:"line"`
+		Column    int    `json:"column"`
+		MessageID string `json:"message-id"`
+		Symbol    string `json:"symbol"`
+		Message   string `json:"message"`
+	}
+	if len(bytes.TrimSpace(out)) == 0 {
+		return VetResult{Clean: true}, nil
+	}
+	if err := json.Unmarshal(out, &findings); err != nil {
+		return VetResult{}, fmt.Errorf("parse output: %w", err)
+	}
+
+	result := VetResult{Clean: len(findings) == 0}
+	for _, f := range findings {
+		result.Issues = append(result.Issues, fmt.Sprintf("%s:%d:%d: %s %s (%s)",
+			f.Path, f.Line, f.Column, f.MessageID, f.Message, f.Symbol))
+	}
+	return result, nil
+}
+
+func pythonCommand() string {
+	if _, err := exec.LookPath("python3"); err == nil {
+		return "python3"
+	}
+	return "python"
+}
+
+// runPythonCompile byte-compiles the tree as the Python "build" check
+func runPythonCompile(root string) BuildResult {
+	start := time.Now()
+	cmd := exec.Command(pythonCommand(), "-m", "compileall", "-q",
+		"-x", `(^|/)(\.git|\.venv|venv|env|__pycache__|\.tox|node_modules|site-packages|build|dist)/`, ".")
+	cmd.Dir = root
+	// Don't litter the project with __pycache__ directories
+	cmd.Env = append(os.Environ(), "PYTHONPYCACHEPREFIX="+os.TempDir())
+	output, err := cmd.CombinedOutput()
+
+	result := BuildResult{Duration: time.Since(start)}
+	if err == nil {
+		result.Success = true
+		return result
+	}
+	for _, line := range strings.Split(string(output), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			result.Errors = append(result.Errors, line)
+		}
+	}
+	return result
+}
+
+var pytestCountRe = regexp.MustCompile(`(\d+) (passed|failed|skipped|error|errors)`)
+
+func runPytest(root string) TestResult {
+	start := time.Now()
+	cmd := exec.Command(pythonCommand(), "-m", "pytest", "-q")
+	cmd.Dir = root
+	output, _ := cmd.CombinedOutput()
+
+	result := parsePytestOutput(string(output))
+	result.Duration = time.Since(start)
+	return result
+}
+
+// parsePytestOutput reads the counts from pytest's final summary line
+func parsePytestOutput(out string) TestResult {
+	result := TestResult{Output: out}
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) == 0 {
+		return result
+	}
+	summary := lines[len(lines)-1]
+	for _, m := range pytestCountRe.FindAllStringSubmatch(summary, -1) {
+		n, _ := strconv.Atoi(m[1])
+		switch m[2] {
+		case "passed":
+			result.Passed = n
+		case "failed", "error", "errors":
+			result.Failed += n
+		case "skipped":
+			result.Skipped = n
+		}
+	}
+	return result
+}
+
+func findPythonTodos(root string) []TodoItem {
+	var items []TodoItem
+	todoRe := regexp.MustCompile(`#\s*(TODO|FIXME|HACK|XXX)[\s:]+(.+)`)
+
+	walkPython(root, func(path string, data []byte) {
+		relPath, _ := filepath.Rel(root, path)
+		for i, line := range strings.Split(string(data), "\n") {
+			match := todoRe.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+			content := strings.TrimSpace(match[2])
+			if len(content) < 5 {
+				continue
+			}
+			items = append(items, TodoItem{
+				File:    relPath,
+				Line:    i + 1,
+				Type:    strings.ToUpper(match[1]),
+				Content: content,
+			})
+		}
+	})
+
+	if len(items) > 20 {
+		items = items[:20]
+	}
+	return items
+}
+
+// countPythonSymbols counts public (non-underscore) definitions
+func countPythonSymbols(root string) SymbolSummary {
+	var ss SymbolSummary
+
+	funcRe := regexp.MustCompile(`^(?:async\s+)?def\s+([a-zA-Z]\w*)`)
+	methodRe := regexp.MustCompile(`^\s+(?:async\s+)?def\s+([a-zA-Z]\w*)`)
+	classRe := regexp.MustCompile(`^class\s+([a-zA-Z]\w*)`)
+	constRe := regexp.MustCompile(`^([A-Z][A-Z0-9_]*)\s*(?::[^=]+)?=`)
+
+	walkPython(root, func(path string, data []byte) {
+		if isPythonTest(path) {
+			return
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			switch {
+			case funcRe.MatchString(line):
+				ss.Functions++
+			case methodRe.MatchString(line):
+				ss.Methods++
+			case classRe.MatchString(line):
+				ss.Types++
+			case constRe.MatchString(line):
+				ss.Constants++
+			}
+		}
+	})
+
+	return ss
+}
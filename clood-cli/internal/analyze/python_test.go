@@ -0,0 +1,74 @@
+package analyze
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		files []string
+		want  Language
+	}{
+		{[]string{"go.mod"}, LangGo},
+		{[]string{"pyproject.toml"}, LangPython},
+		{[]string{"setup.py"}, LangPython},
+		{[]string{"requirements.txt"}, LangPython},
+		{[]string{"go.mod", "requirements.txt"}, LangGo},
+		{nil, LangGo},
+	}
+
+	for _, tt := range tests {
+		dir := t.TempDir()
+		for _, f := range tt.files {
+			if err := os.WriteFile(filepath.Join(dir, f), nil, 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if got := DetectLanguage(dir); got != tt.want {
+			t.Errorf("DetectLanguage(%v) = %q, want %q", tt.files, got, tt.want)
+		}
+	}
+}
+
+func TestParseRuffOutput(t *testing.T) {
+	out := []byte(`[{"code":"F401","message":"` + "`os` imported but unused" + `","filename":"app/main.py","location":{"row":1,"column":8}}]`)
+
+	result, err := parseRuffOutput(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Clean {
+		t.Error("expected findings to mark result unclean")
+	}
+	if len(result.Issues) != 1 || result.Issues[0] != "app/main.py:1:8: F401 `os` imported but unused" {
+		t.Errorf("unexpected issues: %v", result.Issues)
+	}
+
+	empty, err := parseRuffOutput([]byte("[]"))
+	if err != nil || !empty.Clean {
+		t.Errorf("empty ruff output should be clean, got %+v, %v", empty, err)
+	}
+}
+
+func TestParsePylintOutput(t *testing.T) {
+	out := []byte(`[{"type":"warning","path":"pkg/util.py","line":12,"column":4,"message-id":"W0612","symbol":"unused-variable","message":"Unused variable 'x'"}]`)
+
+	result, err := parsePylintOutput(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Issues) != 1 || result.Issues[0] != "pkg/util.py:12:4: W0612 Unused variable 'x' (unused-variable)" {
+		t.Errorf("unexpected issues: %v", result.Issues)
+	}
+}
+
+func TestParsePytestOutput(t *testing.T) {
+	out := "..F.s\nFAILED tests/test_a.py::test_x\n3 passed, 1 failed, 1 skipped in 0.12s"
+
+	result := parsePytestOutput(out)
+	if result.Passed != 3 || result.Failed != 1 || result.Skipped != 1 {
+		t.Errorf("got passed=%d failed=%d skipped=%d", result.Passed, result.Failed, result.Skipped)
+	}
+}
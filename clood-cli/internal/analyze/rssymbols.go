@@ -0,0 +1,47 @@
+package analyze
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Rust item patterns, matched against trimmed lines so methods inside impl
+// blocks are found too. Group 1 is the visibility (pub, pub(crate), ...).
+var (
+	rsVis     = `^(pub(?:\s*\([^)]*\))?\s+)?`
+	rsFnRe    = regexp.MustCompile(rsVis + `(?:default\s+)?(?:const\s+)?(?:async\s+)?(?:unsafe\s+)?(?:extern\s+(?:"[^"]*"\s+)?)?fn\s+(\w+)`)
+	rsTypeRe  = regexp.MustCompile(rsVis + `(?:struct|enum|union|type)\s+(\w+)`)
+	rsTraitRe = regexp.MustCompile(rsVis + `(?:unsafe\s+)?(?:auto\s+)?trait\s+(\w+)`)
+	rsConstRe = regexp.MustCompile(rsVis + `(?:const|static(?:\s+mut)?)\s+(\w+)\s*:`)
+	rsImplRe  = regexp.MustCompile(`^(?:unsafe\s+)?impl\b(?:\s*<[^{]*?>)?\s+(?:(!?[\w:]+(?:<[^{]*?>)?)\s+for\s+)?([\w:]+)`)
+)
+
+// RustSymbol extracts an item declaration from one line of Rust. kind is
+// func, type, trait, impl, or const. Trait impls are named "Trait for Type".
+// impl blocks carry no visibility, so they are never exported.
+func RustSymbol(line string) (name, kind string, exported, ok bool) {
+	line = strings.TrimSpace(line)
+
+	if m := rsImplRe.FindStringSubmatch(line); m != nil {
+		name = m[2]
+		if m[1] != "" {
+			name = m[1] + " for " + m[2]
+		}
+		return name, "impl", false, true
+	}
+
+	for _, p := range []struct {
+		re   *regexp.Regexp
+		kind string
+	}{
+		{rsFnRe, "func"},
+		{rsTypeRe, "type"},
+		{rsTraitRe, "trait"},
+		{rsConstRe, "const"},
+	} {
+		if m := p.re.FindStringSubmatch(line); m != nil {
+			return m[2], p.kind, m[1] != "", true
+		}
+	}
+	return "", "", false, false
+}
@@ -0,0 +1,52 @@
+package analyze
+
+import "testing"
+
+func TestRustSymbol(t *testing.T) {
+	tests := []struct {
+		line     string
+		name     string
+		kind     string
+		exported bool
+	}{
+		{"pub fn parse(input: &str) -> Result<Ast> {", "parse", "func", true},
+		{"fn helper() {}", "helper", "func", false},
+		{"    pub async fn fetch(&self) {", "fetch", "func", true},
+		{"pub(crate) unsafe fn raw() {", "raw", "func", true},
+		{`pub extern "C" fn ffi_entry() {`, "ffi_entry", "func", true},
+		{"pub const fn max_len() -> usize {", "max_len", "func", true},
+		{"pub struct Config {", "Config", "type", true},
+		{"struct Inner(u32);", "Inner", "type", false},
+		{"pub enum Kind {", "Kind", "type", true},
+		{"enum State { A, B }", "State", "type", false},
+		{"pub trait Render {", "Render", "trait", true},
+		{"unsafe trait Raw {}", "Raw", "trait", false},
+		{"impl Config {", "Config", "impl", false},
+		{"impl<T: Clone> Stack<T> {", "Stack", "impl", false},
+		{"impl fmt::Display for Config {", "fmt::Display for Config", "impl", false},
+		{"impl<'a> From<&'a str> for Name<'a> {", "From<&'a str> for Name", "impl", false},
+		{"pub const MAX_SIZE: usize = 1024;", "MAX_SIZE", "const", true},
+		{"const LIMIT: u8 = 3;", "LIMIT", "const", false},
+	}
+	for _, tt := range tests {
+		name, kind, exported, ok := RustSymbol(tt.line)
+		if !ok || name != tt.name || kind != tt.kind || exported != tt.exported {
+			t.Errorf("RustSymbol(%q) = %q, %q, %v, %v; want %q, %q, %v",
+				tt.line, name, kind, exported, ok, tt.name, tt.kind, tt.exported)
+		}
+	}
+}
+
+func TestRustSymbolIgnoresNonItems(t *testing.T) {
+	for _, line := range []string{
+		"let x = fn_call();",
+		"// pub fn commented() {}",
+		"use std::fmt;",
+		"x.impl_thing();",
+		"return Config { a: 1 };",
+	} {
+		if name, kind, _, ok := RustSymbol(line); ok {
+			t.Errorf("RustSymbol(%q) = %q, %q; want no match", line, name, kind)
+		}
+	}
+}
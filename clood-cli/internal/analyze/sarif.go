@@ -0,0 +1,200 @@
+package analyze
+
+import (
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SARIF 2.1.0, the subset GitHub Code Scanning reads
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	sarifSrcRoot = "%SRCROOT%"
+)
+
+// Rule IDs for each kind of finding
+const (
+	RuleBuildError = "build-error"
+	RuleVetIssue   = "vet-issue"
+	RuleTodo       = "todo"
+)
+
+// SarifLog is the top-level SARIF document
+type SarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SarifRun `json:"runs"`
+}
+
+// SarifRun is one tool invocation's results
+type SarifRun struct {
+	Tool               SarifTool                        `json:"tool"`
+	OriginalURIBaseIDs map[string]SarifArtifactLocation `json:"originalUriBaseIds,omitempty"`
+	Results            []SarifResult                    `json:"results"`
+}
+
+// SarifTool names the analyzer and the rules it reports
+type SarifTool struct {
+	Driver SarifDriver `json:"driver"`
+}
+
+type SarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []SarifRule `json:"rules"`
+}
+
+type SarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription SarifMessage `json:"shortDescription"`
+	DefaultConfig    struct {
+		Level string `json:"level"`
+	} `json:"defaultConfiguration"`
+}
+
+// SarifResult is one finding at one location
+type SarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   SarifMessage    `json:"message"`
+	Locations []SarifLocation `json:"locations"`
+}
+
+type SarifMessage struct {
+	Text string `json:"text"`
+}
+
+// SarifLocation points at a line (and optionally column) in a file
+type SarifLocation struct {
+	PhysicalLocation struct {
+		ArtifactLocation SarifArtifactLocation `json:"artifactLocation"`
+		Region           SarifRegion           `json:"region"`
+	} `json:"physicalLocation"`
+}
+
+type SarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type SarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+var (
+	// file.go:12:3: message (go build, go vet, ruff, pylint)
+	sarifLineRe = regexp.MustCompile(`^(\S+?):(\d+)(?::(\d+))?:\s*(.+)$`)
+	// File "./pkg/mod.py", line 3 (compileall)
+	sarifPythonRe = regexp.MustCompile(`^File "([^"]+)", line (\d+)`)
+)
+
+// SARIF converts the build errors, vet/lint issues and TODOs into SARIF.
+// URIs are relative to ProjectRoot. Output lines with no file:line (package
+// headers, continuation lines) are folded into the previous finding's
+// message, since Code Scanning rejects results without a location.
+func (a *CodebaseAnalysis) SARIF(toolVersion string) *SarifLog {
+	run := SarifRun{
+		Tool: SarifTool{Driver: SarifDriver{
+			Name:           "clood",
+			Version:        toolVersion,
+			InformationURI: "https://github.com/dirtybirdnj/clood",
+			Rules: []SarifRule{
+				sarifRule(RuleBuildError, "Build failure", "error"),
+				sarifRule(RuleVetIssue, "Vet or lint finding", "warning"),
+				sarifRule(RuleTodo, "TODO/FIXME comment", "note"),
+			},
+		}},
+		Results: []SarifResult{},
+	}
+	if abs, err := filepath.Abs(a.ProjectRoot); err == nil {
+		u := url.URL{Scheme: "file", Path: filepath.ToSlash(abs) + "/"}
+		run.OriginalURIBaseIDs = map[string]SarifArtifactLocation{sarifSrcRoot: {URI: u.String()}}
+	}
+
+	if !a.BuildStatus.Success {
+		run.Results = append(run.Results, a.sarifFromOutput(RuleBuildError, "error", a.BuildStatus.Errors)...)
+	}
+	if !a.GoVet.Clean {
+		run.Results = append(run.Results, a.sarifFromOutput(RuleVetIssue, "warning", a.GoVet.Issues)...)
+	}
+	for _, item := range a.TodoItems {
+		run.Results = append(run.Results,
+			a.sarifResult(RuleTodo, "note", item.Type+": "+item.Content, item.File, item.Line, 0))
+	}
+
+	return &SarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []SarifRun{run}}
+}
+
+// WriteSARIF writes the SARIF log for the analysis to path
+func (a *CodebaseAnalysis) WriteSARIF(path, toolVersion string) (*SarifLog, error) {
+	log := a.SARIF(toolVersion)
+	data, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return log, os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+func sarifRule(id, desc, level string) SarifRule {
+	r := SarifRule{ID: id, ShortDescription: SarifMessage{Text: desc}}
+	r.DefaultConfig.Level = level
+	return r
+}
+
+// sarifFromOutput turns compiler/linter output lines into results
+func (a *CodebaseAnalysis) sarifFromOutput(rule, level string, lines []string) []SarifResult {
+	var results []SarifResult
+	for _, line := range lines {
+		// go vet reports type-check failures as "vet: file:line:col: ..."
+		line = strings.TrimPrefix(line, "vet: ")
+		if m := sarifLineRe.FindStringSubmatch(line); m != nil {
+			startLine, _ := strconv.Atoi(m[2])
+			col, _ := strconv.Atoi(m[3])
+			results = append(results, a.sarifResult(rule, level, m[4], m[1], startLine, col))
+			continue
+		}
+		if m := sarifPythonRe.FindStringSubmatch(line); m != nil {
+			startLine, _ := strconv.Atoi(m[2])
+			results = append(results, a.sarifResult(rule, level, line, m[1], startLine, 0))
+			continue
+		}
+		// "# pkg" (go build) and "*** Error compiling" (compileall) head a
+		// block whose located line follows
+		if len(results) > 0 && !strings.HasPrefix(line, "#") && !strings.HasPrefix(line, "***") {
+			last := &results[len(results)-1]
+			last.Message.Text += "\n" + line
+		}
+	}
+	return results
+}
+
+func (a *CodebaseAnalysis) sarifResult(rule, level, msg, file string, line, col int) SarifResult {
+	if line < 1 {
+		line = 1
+	}
+	r := SarifResult{RuleID: rule, Level: level, Message: SarifMessage{Text: msg}}
+	var loc SarifLocation
+	loc.PhysicalLocation.ArtifactLocation = SarifArtifactLocation{URI: a.sarifURI(file), URIBaseID: sarifSrcRoot}
+	loc.PhysicalLocation.Region = SarifRegion{StartLine: line, StartColumn: col}
+	r.Locations = []SarifLocation{loc}
+	return r
+}
+
+// sarifURI makes file a forward-slash path relative to the project root
+func (a *CodebaseAnalysis) sarifURI(file string) string {
+	if filepath.IsAbs(file) {
+		if root, err := filepath.Abs(a.ProjectRoot); err == nil {
+			if rel, err := filepath.Rel(root, file); err == nil && !strings.HasPrefix(rel, "..") {
+				file = rel
+			}
+		}
+	}
+	return strings.TrimPrefix(filepath.ToSlash(filepath.Clean(file)), "./")
+}
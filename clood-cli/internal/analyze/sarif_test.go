@@ -0,0 +1,92 @@
+package analyze
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+)
+
+func TestSARIF(t *testing.T) {
+	root := t.TempDir()
+	a := &CodebaseAnalysis{
+		ProjectRoot: root,
+		BuildStatus: BuildResult{Errors: []string{
+			"# github.com/x/pkg",
+			"./pkg/a.go:12:3: undefined: foo",
+			"\thave (int)",
+		}},
+		GoVet: VetResult{Issues: []string{
+			"vet: " + filepath.Join(root, "pkg", "b.go") + ":7:2: unreachable code",
+			"no location here",
+		}},
+		TodoItems: []TodoItem{{File: "pkg/c.go", Line: 4, Type: "FIXME", Content: "handle retries"}},
+	}
+	a.BuildStatus.Success = false
+
+	log := a.SARIF("v1.2.3")
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("bad log header: %+v", log)
+	}
+	results := log.Runs[0].Results
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3: %+v", len(results), results)
+	}
+
+	want := []struct {
+		rule, uri string
+		line, col int
+		msg       string
+	}{
+		{RuleBuildError, "pkg/a.go", 12, 3, "undefined: foo\n\thave (int)"},
+		{RuleVetIssue, "pkg/b.go", 7, 2, "unreachable code\nno location here"},
+		{RuleTodo, "pkg/c.go", 4, 0, "FIXME: handle retries"},
+	}
+	for i, w := range want {
+		r := results[i]
+		loc := r.Locations[0].PhysicalLocation
+		if r.RuleID != w.rule || loc.ArtifactLocation.URI != w.uri ||
+			loc.Region.StartLine != w.line || loc.Region.StartColumn != w.col || r.Message.Text != w.msg {
+			t.Errorf("result %d = %s %s:%d:%d %q, want %+v", i, r.RuleID,
+				loc.ArtifactLocation.URI, loc.Region.StartLine, loc.Region.StartColumn, r.Message.Text, w)
+		}
+	}
+
+	// The schema's required fields are present in the JSON
+	data, err := json.Marshal(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Runs []struct {
+			Results []struct {
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+						Region struct {
+							StartLine int `json:"startLine"`
+						} `json:"region"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if loc := doc.Runs[0].Results[0].Locations[0].PhysicalLocation; loc.ArtifactLocation.URI != "pkg/a.go" || loc.Region.StartLine != 12 {
+		t.Errorf("json location = %+v", loc)
+	}
+}
+
+func TestSARIFClean(t *testing.T) {
+	a := &CodebaseAnalysis{ProjectRoot: ".", BuildStatus: BuildResult{Success: true}, GoVet: VetResult{Clean: true}}
+	data, _ := json.Marshal(a.SARIF(""))
+	var doc map[string]interface{}
+	json.Unmarshal(data, &doc)
+	results := doc["runs"].([]interface{})[0].(map[string]interface{})["results"]
+	if r, ok := results.([]interface{}); !ok || len(r) != 0 {
+		t.Errorf("clean analysis results = %v, want []", results)
+	}
+}
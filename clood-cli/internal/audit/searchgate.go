@@ -0,0 +1,121 @@
+// Package audit keeps append-only JSONL logs of decisions clood makes on an
+// agent's behalf, so the heuristics behind them can be tuned.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// SearchGateMaxBytes is the size at which search_gate.jsonl is rotated to
+// search_gate.jsonl.1 (replacing any older rotation)
+const SearchGateMaxBytes = 10 * 1024 * 1024
+
+// SearchGateDecision is one clood_should_search_web verdict
+type SearchGateDecision struct {
+	Time    time.Time `json:"time"`
+	Query   string    `json:"query"`
+	Verdict string    `json:"verdict"`           // USE_LOCAL_TOOL, USE_LOCAL_LLM, WEB_SEARCH_APPROVED
+	Pattern string    `json:"pattern,omitempty"` // the phrase that decided it, empty for the fallthrough
+	Tool    string    `json:"tool,omitempty"`
+	Reason  string    `json:"reason,omitempty"`
+}
+
+var searchGateMu sync.Mutex
+
+// SearchGatePath returns ~/.clood/search_gate.jsonl
+func SearchGatePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".clood", "search_gate.jsonl"), nil
+}
+
+// LogSearchGate appends d to the log at path, rotating first if the log
+// has reached SearchGateMaxBytes. Time defaults to now.
+func LogSearchGate(path string, d SearchGateDecision) error {
+	if d.Time.IsZero() {
+		d.Time = time.Now()
+	}
+	line, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+
+	searchGateMu.Lock()
+	defer searchGateMu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if info, err := os.Stat(path); err == nil && info.Size() >= SearchGateMaxBytes {
+		if err := os.Rename(path, path+".1"); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// TailSearchGate returns the last n decisions, oldest first, reaching into
+// the rotated log when the current one is short. A missing log is empty.
+func TailSearchGate(path string, n int) ([]SearchGateDecision, error) {
+	current, err := readSearchGate(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(current) < n {
+		older, err := readSearchGate(path + ".1")
+		if err != nil {
+			return nil, err
+		}
+		current = append(older, current...)
+	}
+	if n > 0 && len(current) > n {
+		current = current[len(current)-n:]
+	}
+	return current, nil
+}
+
+func readSearchGate(path string) ([]SearchGateDecision, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	// ReadBytes rather than a Scanner so one oversized line (a pasted
+	// query) can't make the whole log unreadable
+	var decisions []SearchGateDecision
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		var d SearchGateDecision
+		if len(line) > 0 && json.Unmarshal(line, &d) == nil {
+			decisions = append(decisions, d)
+		}
+		if err == io.EOF {
+			return decisions, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package audit
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSearchGateLogAndTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "search_gate.jsonl")
+
+	if got, err := TailSearchGate(path, 10); err != nil || len(got) != 0 {
+		t.Fatalf("missing log: got %v, %v", got, err)
+	}
+
+	for i := 0; i < 5; i++ {
+		if err := LogSearchGate(path, SearchGateDecision{Query: fmt.Sprintf("q%d", i), Verdict: "WEB_SEARCH_APPROVED"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := TailSearchGate(path, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 || got[0].Query != "q2" || got[2].Query != "q4" {
+		t.Errorf("tail = %+v", got)
+	}
+	if got[0].Time.IsZero() {
+		t.Error("time not defaulted")
+	}
+}
+
+func TestSearchGateRotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "search_gate.jsonl")
+
+	// Pad an existing log past the limit with one real entry at the end
+	pad := strings.Repeat("x", SearchGateMaxBytes) + "\n" + `{"query":"old","verdict":"USE_LOCAL_TOOL"}` + "\n"
+	if err := os.WriteFile(path, []byte(pad), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := LogSearchGate(path, SearchGateDecision{Query: "new", Verdict: "USE_LOCAL_LLM"}); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() > 1024 {
+		t.Errorf("log not rotated, size %d", info.Size())
+	}
+
+	got, err := TailSearchGate(path, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Query != "old" || got[1].Query != "new" {
+		t.Errorf("tail across rotation = %+v", got)
+	}
+}
@@ -147,7 +147,6 @@ func AgentCmd() *cobra.Command {
 	var verbose bool
 	var host string
 	var systemPrompt string
-	var jsonOutput bool
 
 	cmd := &cobra.Command{
 		Use:   "agent [prompt]",
@@ -187,9 +186,9 @@ Examples:
 
 			result := runAgent(prompt, config)
 
-			useJSON := jsonOutput || output.IsJSON()
+			useJSON := output.IsJSON()
 			if useJSON {
-				data, _ := json.MarshalIndent(result, "", "  ")
+				data, _ := output.Marshal(result)
 				fmt.Println(string(data))
 				return
 			}
@@ -204,7 +203,6 @@ Examples:
 	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Show detailed turn-by-turn output")
 	cmd.Flags().StringVar(&host, "host", "http://localhost:11434", "Ollama host")
 	cmd.Flags().StringVar(&systemPrompt, "system", "", "Custom system prompt")
-	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 
 	return cmd
 }
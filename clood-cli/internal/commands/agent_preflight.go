@@ -61,7 +61,7 @@ Examples:
 			result := runAgentPreflight(files)
 
 			if output.IsJSON() {
-				data, _ := json.MarshalIndent(result, "", "  ")
+				data, _ := output.Marshal(result)
 				fmt.Println(string(data))
 				return
 			}
@@ -8,8 +8,10 @@ import (
 	"strings"
 
 	"github.com/dirtybirdnj/clood/internal/agents"
+	"github.com/dirtybirdnj/clood/internal/output"
 	"github.com/dirtybirdnj/clood/internal/tui"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 func AgentsCmd() *cobra.Command {
@@ -21,46 +23,215 @@ func AgentsCmd() *cobra.Command {
 Agents are defined in .clood/agents.yaml (project) or ~/.config/clood/agents.yaml (global).
 Each agent specifies a model, host, system prompt, and other settings.
 
-Use agents with: clood run --agent <name> "prompt"`,
+Create and delete global agents with 'clood agents create' and
+'clood agents delete'.
+
+Use agents with: clood run --agent <name> "prompt"
+            or: clood delegate --agent <name> "task"`,
 		Run: func(cmd *cobra.Command, args []string) {
-			cfg := agents.LoadConfigWithFallback()
+			printAgentsList()
+		},
+	}
 
-			// Check if config file exists
-			path, exists := agents.ConfigExists()
+	cmd.AddCommand(agentsListCmd())
+	cmd.AddCommand(agentsShowCmd())
+	cmd.AddCommand(agentsCreateCmd())
+	cmd.AddCommand(agentsDeleteCmd())
+	cmd.AddCommand(agentsInitCmd())
 
-			fmt.Println(tui.RenderHeader("Agent Roles"))
-			fmt.Println()
+	return cmd
+}
 
-			if exists {
-				fmt.Printf("  %s %s\n", tui.MutedStyle.Render("Config:"), path)
-			} else {
-				fmt.Printf("  %s %s\n", tui.MutedStyle.Render("Config:"), "using defaults (no config file found)")
+func agentsListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List configured agents",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			printAgentsList()
+		},
+	}
+}
+
+// printAgentsList prints every agent from the active config
+func printAgentsList() {
+	cfg := agents.LoadConfigWithFallback()
+
+	// Check if config file exists
+	path, exists := agents.ConfigExists()
+
+	fmt.Println(tui.RenderHeader("Agent Roles"))
+	fmt.Println()
+
+	if exists {
+		fmt.Printf("  %s %s\n", tui.MutedStyle.Render("Config:"), path)
+	} else {
+		fmt.Printf("  %s %s\n", tui.MutedStyle.Render("Config:"), "using defaults (no config file found)")
+	}
+	fmt.Println()
+
+	// Sort agents by name
+	names := cfg.ListAgents()
+	sort.Strings(names)
+
+	for _, name := range names {
+		agent := cfg.GetAgent(name)
+		printAgent(agent)
+	}
+
+	fmt.Println()
+	fmt.Printf("  %s %d agents available\n",
+		tui.MutedStyle.Render("Total:"), len(names))
+	fmt.Println()
+	fmt.Println(tui.MutedStyle.Render("  Usage: clood run --agent <name> \"prompt\""))
+}
+
+func agentsCreateCmd() *cobra.Command {
+	var name string
+	var systemPrompt string
+	var model string
+	var host string
+	var description string
+	var temperature float64
+	var maxTokens int
+	var stop []string
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:     "create [name]",
+		Aliases: []string{"spawn"},
+		Short:   "Save a named agent to the global config",
+		Long: `Saves an agent with its own system prompt and model to
+~/.config/clood/agents.yaml. The first agent created copies the built-in
+roles into the file so they stay available.
+
+--temp, --max-tokens and --stop are sent to Ollama with every request the
+agent makes (as temperature, num_predict and stop), so a persona can be
+terse and deterministic regardless of the model's defaults.
+
+Examples:
+  clood agents create reviewer --system-prompt "You are a code reviewer..." --model qwen2.5-coder:7b
+  clood agents create --name strict-reviewer --system-prompt "Review tersely, then write END" --temp 0.1 --stop "END" --max-tokens 500
+  clood agents spawn --name tester --system-prompt "You write table-driven Go tests" --host ubuntu25
+  clood delegate --agent tester "Write tests for internal/router"`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				if name != "" && name != args[0] {
+					return fmt.Errorf("name given twice: %s and --name %s", args[0], name)
+				}
+				name = args[0]
+			}
+			if err := agents.ValidateName(name); err != nil {
+				return err
+			}
+			if systemPrompt == "" {
+				return fmt.Errorf("--system-prompt is required")
+			}
+			if temperature < 0 || temperature > 2 {
+				return fmt.Errorf("--temperature must be between 0 and 2")
+			}
+			if maxTokens < 0 {
+				return fmt.Errorf("--max-tokens must be positive")
 			}
-			fmt.Println()
 
-			// Sort agents by name
-			names := cfg.ListAgents()
-			sort.Strings(names)
+			path, err := agents.GlobalConfigPath()
+			if err != nil {
+				return err
+			}
+			cfg, err := agents.LoadGlobalConfig()
+			if err != nil {
+				return err
+			}
+			if cfg.GetAgent(name) != nil && !force {
+				return fmt.Errorf("agent %s already exists (use --force to replace it)", name)
+			}
 
-			for _, name := range names {
-				agent := cfg.GetAgent(name)
-				printAgent(agent)
+			agent := &agents.Agent{
+				Name:          name,
+				Description:   description,
+				Model:         model,
+				Host:          host,
+				System:        systemPrompt,
+				MaxTokens:     maxTokens,
+				StopSequences: stop,
+			}
+			// An explicit --temp 0 is kept; leaving the flag out means the default
+			if cmd.Flags().Changed("temperature") {
+				agent.Temperature = &temperature
+			}
+			cfg.SetAgent(agent)
+			if err := cfg.Save(path); err != nil {
+				return fmt.Errorf("saving %s: %w", path, err)
 			}
 
-			fmt.Println()
-			fmt.Printf("  %s %d agents available\n",
-				tui.MutedStyle.Render("Total:"), len(names))
-			fmt.Println()
-			fmt.Println(tui.MutedStyle.Render("  Usage: clood run --agent <name> \"prompt\""))
+			fmt.Println(tui.SuccessStyle.Render(fmt.Sprintf("Saved agent %s to %s", name, path)))
+			warnProjectAgentsShadow()
+			fmt.Println(tui.MutedStyle.Render(fmt.Sprintf("  Usage: clood delegate --agent %s \"prompt\"", name)))
+			return nil
 		},
 	}
 
-	cmd.AddCommand(agentsShowCmd())
-	cmd.AddCommand(agentsInitCmd())
+	cmd.Flags().StringVar(&name, "name", "", "Agent name (or pass it as the argument)")
+	cmd.Flags().StringVarP(&systemPrompt, "system-prompt", "s", "", "System prompt the agent runs with")
+	cmd.Flags().StringVarP(&model, "model", "m", "", "Model to use (default: chosen at delegate time)")
+	cmd.Flags().StringVarP(&host, "host", "H", "", "Host to run on (default: any host with the model)")
+	cmd.Flags().StringVarP(&description, "description", "d", "", "One-line description shown in 'clood agents'")
+	cmd.Flags().Float64Var(&temperature, "temperature", 0, "Sampling temperature (default 0.7; also --temp)")
+	cmd.Flags().IntVar(&maxTokens, "max-tokens", 0, "Most tokens to generate per response (num_predict)")
+	cmd.Flags().StringArrayVar(&stop, "stop", nil, "Stop sequence that ends generation (repeatable)")
+	cmd.Flags().SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == "temp" {
+			name = "temperature"
+		}
+		return pflag.NormalizedName(name)
+	})
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Replace an existing agent with the same name")
 
 	return cmd
 }
 
+func agentsDeleteCmd() *cobra.Command {
+	return withDryRun(&cobra.Command{
+		Use:     "delete <name>",
+		Aliases: []string{"rm"},
+		Short:   "Remove an agent from the global config",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			path, err := agents.GlobalConfigPath()
+			if err != nil {
+				return err
+			}
+			cfg, err := agents.LoadGlobalConfig()
+			if err != nil {
+				return err
+			}
+			if !cfg.RemoveAgent(name) {
+				return fmt.Errorf("agent not found in %s: %s", path, name)
+			}
+			if output.DryRun {
+				return output.WouldHaveDone("delete agent "+name+" from "+path, nil)
+			}
+			if err := cfg.Save(path); err != nil {
+				return fmt.Errorf("saving %s: %w", path, err)
+			}
+
+			fmt.Println(tui.SuccessStyle.Render(fmt.Sprintf("Deleted agent %s from %s", name, path)))
+			warnProjectAgentsShadow()
+			return nil
+		},
+	})
+}
+
+// warnProjectAgentsShadow notes when a project agents.yaml is in effect,
+// since it takes precedence over the global file that was just changed
+func warnProjectAgentsShadow() {
+	if path, exists := agents.ConfigExists(); exists && path == agents.ConfigPaths()[0] {
+		fmt.Println(tui.WarningStyle.Render("  Note: " + path + " is used instead of the global config in this directory"))
+	}
+}
+
 func agentsShowCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "show [name]",
@@ -92,6 +263,9 @@ func agentsShowCmd() *cobra.Command {
 			if agent.MaxTokens > 0 {
 				fmt.Printf("  %s %d\n", tui.MutedStyle.Render("Max Tokens:"), agent.MaxTokens)
 			}
+			if len(agent.StopSequences) > 0 {
+				fmt.Printf("  %s %s\n", tui.MutedStyle.Render("Stop:"), quoteAll(agent.StopSequences))
+			}
 			if agent.Timeout != "" {
 				fmt.Printf("  %s %s\n", tui.MutedStyle.Render("Timeout:"), agent.Timeout)
 			}
@@ -179,6 +353,12 @@ func printAgent(agent *agents.Agent) {
 		details = append(details, fmt.Sprintf("host=%s", agent.Host))
 	}
 	details = append(details, fmt.Sprintf("temp=%.1f", agent.GetEffectiveTemperature()))
+	if agent.MaxTokens > 0 {
+		details = append(details, fmt.Sprintf("max_tokens=%d", agent.MaxTokens))
+	}
+	if len(agent.StopSequences) > 0 {
+		details = append(details, "stop="+quoteAll(agent.StopSequences))
+	}
 
 	if len(details) > 0 {
 		fmt.Printf("    %s\n", tui.MutedStyle.Render(strings.Join(details, ", ")))
@@ -187,6 +367,15 @@ func printAgent(agent *agents.Agent) {
 	fmt.Println()
 }
 
+// quoteAll formats stop sequences so whitespace and newlines show
+func quoteAll(items []string) string {
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = fmt.Sprintf("%q", item)
+	}
+	return strings.Join(quoted, " ")
+}
+
 const exampleAgentsYAML = `# Agent Role Configuration
 # Project-level: .clood/agents.yaml
 # Global: ~/.config/clood/agents.yaml
@@ -204,6 +393,9 @@ agents:
       - Style and readability
       Return findings as structured bullet points.
     temperature: 0.3
+    # Sent to Ollama as num_predict and stop
+    # max_tokens: 500
+    # stop_sequences: ["END"]
 
   coder:
     description: "Code generation specialist"
@@ -2,16 +2,18 @@ package commands
 
 import (
 	"bufio"
-	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/dirtybirdnj/clood/internal/analyze"
 	"github.com/dirtybirdnj/clood/internal/config"
 	"github.com/dirtybirdnj/clood/internal/ollama"
+	"github.com/dirtybirdnj/clood/internal/output"
 	"github.com/dirtybirdnj/clood/internal/router"
+	"github.com/dirtybirdnj/clood/internal/tui"
 	"github.com/spf13/cobra"
 )
 
@@ -26,9 +28,14 @@ type AnalysisResult struct {
 
 func AnalyzeCmd() *cobra.Command {
 	var focus string
-	var jsonOutput bool
 	var fromStdin bool
 	var model string
+	var lang string
+	var checkDupes bool
+	var dupeMinLines int
+	var sarifPath string
+	var checkDeprecated bool
+	var deprecatedList string
 
 	cmd := &cobra.Command{
 		Use:   "analyze [FILE]",
@@ -41,13 +48,61 @@ Unlike code generation, analysis focuses on understanding:
 - Explaining complex logic
 - Reviewing for best practices
 
+--check-dupes skips the model and reports Go functions that are
+near-identical copies of each other (same body once whitespace and
+variable names are normalized): refactoring candidates to look at before
+asking a model for suggestions.
+
+--sarif also skips the model: it runs the static analysis (build, vet or
+lint, TODO scan) over the directory and writes the findings as a SARIF
+2.1.0 file for GitHub Code Scanning. File URIs are relative to the
+analyzed directory, so run it from the repository root for annotations
+to land on the right files.
+
+--check-deprecated skips the model too and reports calls to deprecated
+standard library functions (ioutil.ReadFile, rand.Seed, strings.Title,
+...) with what to use instead. The list is bundled, and
+~/.config/clood/deprecated_functions.yaml or --deprecated-list replaces
+it without a new build:
+
+  - func: math/rand.Seed
+    since: "1.20"
+    replacement: rand.New(rand.NewSource(seed))
+
 Examples:
   clood analyze internal/router/router.go
   clood analyze internal/config/ --focus security
+  clood analyze services/api/ --lang python
   cat file.go | clood analyze --stdin
-  git diff | clood analyze --stdin --focus "review changes"`,
+  git diff | clood analyze --stdin --focus "review changes"
+  clood analyze ./internal --check-dupes
+  clood analyze --sarif results.sarif
+  clood analyze ./internal --check-deprecated`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput := output.IsJSON()
+			if checkDupes {
+				dir := "."
+				if len(args) > 0 {
+					dir = args[0]
+				}
+				return runDupeCheck(dir, dupeMinLines, output.IsJSON())
+			}
+			if checkDeprecated {
+				dir := "."
+				if len(args) > 0 {
+					dir = args[0]
+				}
+				return runDeprecatedCheck(dir, deprecatedList, output.IsJSON())
+			}
+			if sarifPath != "" {
+				dir := "."
+				if len(args) > 0 {
+					dir = args[0]
+				}
+				return runSarifExport(dir, lang, sarifPath, output.IsJSON())
+			}
+
 			var code string
 			var filename string
 
@@ -67,8 +122,15 @@ Examples:
 				}
 
 				if info.IsDir() {
-					// Analyze directory - concatenate all Go files
-					code, filename, err = readDirectory(target)
+					// Analyze directory - concatenate all source files
+					parsed, err := analyze.ParseLanguage(lang)
+					if err != nil {
+						return err
+					}
+					if parsed == analyze.LangAuto {
+						parsed = analyze.DetectLanguage(target)
+					}
+					code, filename, err = readDirectory(target, parsed)
 					if err != nil {
 						return err
 					}
@@ -122,13 +184,13 @@ Examples:
 
 			// Output results
 			if jsonOutput {
-				output := AnalysisResult{
+				analysis := AnalysisResult{
 					File:     filename,
 					Model:    result.Model,
 					Focus:    focus,
 					Analysis: response.Response,
 				}
-				data, _ := json.MarshalIndent(output, "", "  ")
+				data, _ := output.Marshal(analysis)
 				fmt.Println(string(data))
 			} else {
 				fmt.Println(response.Response)
@@ -139,35 +201,160 @@ Examples:
 	}
 
 	cmd.Flags().StringVarP(&focus, "focus", "f", "", "Focus area (security, performance, bugs, style)")
-	cmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Output as JSON")
 	cmd.Flags().BoolVar(&fromStdin, "stdin", false, "Read code from stdin")
 	cmd.Flags().StringVarP(&model, "model", "m", "", "Override model (default: analysis tier)")
+	cmd.Flags().StringVar(&lang, "lang", "auto", "Language for directory analysis: go, python, auto")
+	cmd.Flags().BoolVar(&checkDupes, "check-dupes", false, "Report duplicated Go functions instead of running a model")
+	cmd.Flags().IntVar(&dupeMinLines, "min-lines", analyze.DefaultDupeMinLines, "Shortest function --check-dupes considers")
+	cmd.Flags().StringVar(&sarifPath, "sarif", "", "Write build, vet and TODO findings to this SARIF file instead of running a model")
+	cmd.Flags().BoolVar(&checkDeprecated, "check-deprecated", false, "Report calls to deprecated stdlib functions instead of running a model")
+	cmd.Flags().StringVar(&deprecatedList, "deprecated-list", "", "Deprecation list for --check-deprecated (default: ~/.config/clood/deprecated_functions.yaml, else bundled)")
 
 	return cmd
 }
 
-func readDirectory(dir string) (string, string, error) {
+// runDupeCheck prints the duplicate function groups under dir
+func runDupeCheck(dir string, minLines int, jsonOut bool) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("stat %s: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("--check-dupes needs a directory, got %s", dir)
+	}
+
+	analysis := &analyze.CodebaseAnalysis{ProjectRoot: dir, Language: analyze.LangGo}
+	analysis.CheckDuplicates(minLines)
+
+	if jsonOut {
+		groups := analysis.Duplicates
+		if groups == nil {
+			groups = []analyze.DuplicateGroup{}
+		}
+		data, _ := output.Marshal(groups)
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(analysis.Duplicates) == 0 {
+		fmt.Println(tui.SuccessStyle.Render(fmt.Sprintf("No duplicated functions of %d+ lines in %s", minLines, dir)))
+		return nil
+	}
+	copies := 0
+	for _, g := range analysis.Duplicates {
+		copies += len(g.Funcs)
+	}
+	fmt.Println(tui.RenderHeader(fmt.Sprintf("Duplicate Code: %d groups, %d functions", len(analysis.Duplicates), copies)))
+	fmt.Println()
+	fmt.Print(analysis.FormatDuplicates())
+	return nil
+}
+
+// runDeprecatedCheck prints the deprecated function calls under dir. The
+// list is listPath, the user's deprecated_functions.yaml, or the bundled one.
+func runDeprecatedCheck(dir, listPath string, jsonOut bool) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("stat %s: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("--check-deprecated needs a directory, got %s", dir)
+	}
+	if listPath == "" {
+		if user := filepath.Join(config.ConfigDir(), "deprecated_functions.yaml"); fileExists(user) {
+			listPath = user
+		}
+	}
+	list, err := analyze.LoadDeprecations(listPath)
+	if err != nil {
+		return err
+	}
+
+	uses := analyze.FindDeprecated(dir, list)
+	if jsonOut {
+		if uses == nil {
+			uses = []analyze.DeprecatedUse{}
+		}
+		data, _ := output.Marshal(uses)
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(uses) == 0 {
+		fmt.Println(tui.SuccessStyle.Render(fmt.Sprintf("No deprecated function calls in %s (%d checked)", dir, len(list))))
+		return nil
+	}
+	fmt.Println(tui.RenderHeader(fmt.Sprintf("Deprecated Calls: %d", len(uses))))
+	fmt.Println()
+	fmt.Print(analyze.FormatDeprecated(uses))
+	return nil
+}
+
+// runSarifExport runs the static analysis on dir and writes it as SARIF
+func runSarifExport(dir, lang, path string, jsonOut bool) error {
+	parsed, err := analyze.ParseLanguage(lang)
+	if err != nil {
+		return err
+	}
+	analysis, err := analyze.RunAnalysisLang(dir, parsed, false)
+	if err != nil {
+		return fmt.Errorf("analysis failed: %w", err)
+	}
+	log, err := analysis.WriteSARIF(path, CurrentVersion)
+	if err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+
+	counts := map[string]int{}
+	for _, r := range log.Runs[0].Results {
+		counts[r.RuleID]++
+	}
+	if jsonOut {
+		data, _ := output.Marshal(map[string]interface{}{
+			"sarif":        path,
+			"results":      len(log.Runs[0].Results),
+			"build_errors": counts[analyze.RuleBuildError],
+			"vet_issues":   counts[analyze.RuleVetIssue],
+			"todos":        counts[analyze.RuleTodo],
+		})
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Println(tui.SuccessStyle.Render(fmt.Sprintf("Wrote %d results to %s", len(log.Runs[0].Results), path)))
+	fmt.Printf("  %s %d build errors, %d vet issues, %d TODOs\n", tui.MutedStyle.Render("Findings:"),
+		counts[analyze.RuleBuildError], counts[analyze.RuleVetIssue], counts[analyze.RuleTodo])
+	return nil
+}
+
+func readDirectory(dir string, lang analyze.Language) (string, string, error) {
 	var builder strings.Builder
 	var files []string
 
+	ext, comment := ".go", "//"
+	if lang == analyze.LangPython {
+		ext, comment = ".py", "#"
+	}
+
 	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return nil
 		}
 		if info.IsDir() {
 			name := info.Name()
-			if name == ".git" || name == "vendor" || name == "node_modules" {
+			if name == ".git" || name == "vendor" || name == "node_modules" ||
+				name == ".venv" || name == "venv" || name == "__pycache__" {
 				return filepath.SkipDir
 			}
 			return nil
 		}
 
-		// Only Go files for now
-		if !strings.HasSuffix(path, ".go") {
+		if !strings.HasSuffix(path, ext) {
 			return nil
 		}
 		// Skip test files
-		if strings.HasSuffix(path, "_test.go") {
+		base := filepath.Base(path)
+		if strings.HasSuffix(base, "_test"+ext) || (lang == analyze.LangPython && strings.HasPrefix(base, "test_")) {
 			return nil
 		}
 
@@ -177,7 +364,7 @@ func readDirectory(dir string) (string, string, error) {
 		}
 
 		relPath, _ := filepath.Rel(dir, path)
-		builder.WriteString(fmt.Sprintf("// === %s ===\n", relPath))
+		builder.WriteString(fmt.Sprintf("%s === %s ===\n", comment, relPath))
 		builder.Write(data)
 		builder.WriteString("\n\n")
 		files = append(files, relPath)
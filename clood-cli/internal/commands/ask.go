@@ -1,14 +1,17 @@
 package commands
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/dirtybirdnj/clood/internal/config"
 	"github.com/dirtybirdnj/clood/internal/context"
+	"github.com/dirtybirdnj/clood/internal/hosts"
 	"github.com/dirtybirdnj/clood/internal/ollama"
+	"github.com/dirtybirdnj/clood/internal/output"
 	"github.com/dirtybirdnj/clood/internal/router"
 	"github.com/dirtybirdnj/clood/internal/tui"
 	"github.com/spf13/cobra"
@@ -25,7 +28,12 @@ func AskCmd() *cobra.Command {
 	var maxTokens int
 	var showRoute bool
 	var verbose bool
-	var jsonOutput bool
+	var continueSession string
+	var newSession string
+	var loadBalance string
+	var contextFiles []string
+	var maxContextTokens int
+	var systemFlag string
 
 	cmd := &cobra.Command{
 		Use:   "ask [question]",
@@ -36,19 +44,82 @@ func AskCmd() *cobra.Command {
   3. Route to the best available host
   4. Stream the response
 
-Use --show-route to see routing decisions without executing.`,
+Use --show-route to see routing decisions without executing.
+
+When several online hosts have the model, --load-balance chooses between
+them (default: routing.strategy from config):
+  round-robin    rotate across hosts, remembered between runs
+  least-latency  lowest ping latency, weighted by in-flight requests
+  random         pick any
+
+Multi-turn:
+  clood ask --new-session refactor "how is routing structured?"
+  clood ask --continue refactor "where would caching fit?"
+
+Sessions live in ~/.clood/sessions/ (shared with chat's /save and /load)
+and are sent to Ollama via /api/chat with their full history.
+
+Files:
+  clood ask --context-file main.go "What does this code do?"
+  clood ask --context-file a.go --context-file b.go "How do these interact?"
+
+Each file is sent under its own header in a code fence. Together they are
+cut to --max-context-tokens, with a warning for anything truncated.
+
+System prompts:
+  clood ask --system "You are a senior Go engineer" "Review this function"
+  clood ask --system @reviewer.txt --context-file main.go "Review this"
+
+With --system the question goes through /api/chat, after a system message.
+"@file" reads the prompt from a file. In a session the system prompt is
+saved with it, so later --continue turns (and chat's /load) keep it until
+another --system replaces it.`,
 		Args: cobra.MinimumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
+			jsonOutput := output.IsJSON()
 			question := strings.Join(args, " ")
 
+			system, err := loadSystemPrompt(systemFlag)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render(err.Error()))
+				return
+			}
+
+			fileContext, warnings, err := buildContextFiles(contextFiles, maxContextTokens)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render(err.Error()))
+				return
+			}
+			for _, w := range warnings {
+				fmt.Fprintln(os.Stderr, tui.WarningStyle.Render("⚠ "+w))
+			}
+
 			cfg, err := config.Load()
 			if err != nil {
 				fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render("Error loading config: "+err.Error()))
 				return
 			}
 
+			// A bad routing.strategy in config falls back to the default;
+			// a bad --load-balance is the user's typo to fix
+			strategy, err := hosts.ParseBalanceStrategy(cfg.Routing.Strategy)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, tui.WarningStyle.Render("Ignoring routing.strategy: "+err.Error()))
+			}
+			if loadBalance != "" {
+				if strategy, err = hosts.ParseBalanceStrategy(loadBalance); err != nil {
+					fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render(err.Error()))
+					return
+				}
+			}
+
 			// Create router
-			r := router.NewRouter(cfg)
+			var opts []hosts.ManagerOption
+			if strategy == hosts.BalanceRoundRobin {
+				opts = append(opts, hosts.WithRoundRobinOffset(loadRoundRobinOffset()))
+			}
+			r := router.NewRouter(cfg, opts...)
+			r.SetBalance(strategy)
 
 			// Route the query
 			result, err := r.Route(question, forceTier, forceModel)
@@ -80,12 +151,50 @@ Use --show-route to see routing decisions without executing.`,
 				return
 			}
 
+			// Saved after --show-route so a previewed route doesn't use up its turn
+			if strategy == hosts.BalanceRoundRobin {
+				saveRoundRobinOffset(r.GetManager().RoundRobinOffset())
+			}
+			if result.Host != nil {
+				done := r.GetManager().BeginRequest(result.Host.Host.Name)
+				defer done()
+			}
+
 			// Show routing info with verbose (continue to execute)
 			if verbose {
 				printRouteInfo(result)
 				fmt.Println()
 			}
 
+			if continueSession != "" || newSession != "" {
+				var saga *Saga
+				var id string
+				if continueSession != "" {
+					id = savedSessionID(continueSession)
+					saga, err = loadSavedSession(id)
+				} else {
+					projectContext := ""
+					if !noContext {
+						projectContext = withFocusContext(getProjectContext())
+					}
+					saga, id, err = newAskSession(newSession, projectContext)
+				}
+				if err != nil {
+					fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render(err.Error()))
+					return
+				}
+				if system != "" {
+					saga.System = system
+				}
+
+				turn := question
+				if fileContext != "" {
+					turn = fileContext + "\n\nQuestion: " + question
+				}
+				executeAskSession(result, saga, id, turn, cfg.Defaults.Stream && !noStream, jsonOutput)
+				return
+			}
+
 			// Build prompt with context (needed for both JSON and normal output)
 			prompt := question
 			if withContext {
@@ -110,10 +219,19 @@ Use --show-route to see routing decisions without executing.`,
 					prompt = fmt.Sprintf("Context:\n%s\n\nQuestion: %s", ctx, question)
 				}
 			}
+			if fileContext != "" {
+				if prompt == question {
+					prompt = "Question: " + question
+				}
+				prompt = fileContext + "\n\n" + prompt
+			}
+			if !noContext {
+				prompt = withFocusContext(prompt)
+			}
 
 			// JSON output mode - clean machine-readable output, no TUI
 			if jsonOutput {
-				executeJSON(result, prompt)
+				executeJSON(result, system, prompt)
 				return
 			}
 
@@ -136,15 +254,16 @@ Use --show-route to see routing decisions without executing.`,
 			stream := cfg.Defaults.Stream && !noStream
 
 			if stream {
-				executeStreaming(result.Client, result.Model, prompt)
+				executeStreaming(result.Client, result.Model, system, prompt)
 			} else {
-				executeBlocking(result.Client, result.Model, prompt)
+				executeBlocking(result.Client, result.Model, system, prompt)
 			}
 		},
 	}
 
 	cmd.Flags().IntVarP(&forceTier, "tier", "T", 0, "Force specific tier (1=fast, 2=deep, 3=analysis, 4=writing)")
 	cmd.Flags().StringVarP(&forceModel, "model", "m", "", "Force specific model")
+	registerCompletion(cmd, "model", completeModelNames)
 	cmd.Flags().StringVarP(&forceHost, "host", "H", "", "Force specific host")
 	cmd.Flags().BoolVar(&noStream, "no-stream", false, "Disable streaming output")
 	cmd.Flags().BoolVar(&noContext, "no-context", false, "Skip project context injection")
@@ -153,11 +272,44 @@ Use --show-route to see routing decisions without executing.`,
 	cmd.Flags().IntVar(&maxTokens, "max-tokens", 4000, "Maximum tokens for context (with --with-context)")
 	cmd.Flags().BoolVar(&showRoute, "show-route", false, "Show routing decision without executing")
 	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Show routing decisions before executing")
-	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output response as JSON")
+	cmd.Flags().StringVar(&continueSession, "continue", "", "Continue a multi-turn session by ID")
+	cmd.Flags().StringVar(&newSession, "new-session", "", "Start a named multi-turn session")
+	cmd.Flags().StringVar(&loadBalance, "load-balance", "", "Spread requests across hosts: round-robin, least-latency, random")
+	cmd.Flags().StringArrayVarP(&contextFiles, "context-file", "f", nil, "Send a file's contents with the question (repeatable)")
+	cmd.Flags().IntVar(&maxContextTokens, "max-context-tokens", 4096, "Token limit for --context-file contents (0 for no limit)")
+	cmd.Flags().StringVarP(&systemFlag, "system", "s", "", "System prompt, or @file to read it from a file (sends via /api/chat)")
+	cmd.MarkFlagsMutuallyExclusive("continue", "new-session")
 
 	return cmd
 }
 
+// roundRobinPath holds the round-robin counter so consecutive asks rotate
+func roundRobinPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".clood", "round_robin")
+}
+
+func loadRoundRobinOffset() uint64 {
+	data, err := os.ReadFile(roundRobinPath())
+	if err != nil {
+		return 0
+	}
+	n, _ := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	return n
+}
+
+func saveRoundRobinOffset(n uint64) {
+	path := roundRobinPath()
+	if path == "" {
+		return
+	}
+	os.MkdirAll(filepath.Dir(path), 0755)
+	os.WriteFile(path, []byte(strconv.FormatUint(n, 10)+"\n"), 0644)
+}
+
 func printRouteInfo(result *router.RouteResult) {
 	fmt.Println(tui.RenderHeader("Routing Decision"))
 	fmt.Println()
@@ -175,10 +327,19 @@ func printRouteInfo(result *router.RouteResult) {
 	}
 }
 
-func executeStreaming(client *ollama.Client, model, prompt string) {
-	_, err := client.GenerateStream(model, prompt, func(chunk ollama.GenerateResponse) {
-		fmt.Print(chunk.Response)
-	})
+// executeStreaming prints the answer as it arrives. A system prompt sends
+// the question through /api/chat instead of /api/generate.
+func executeStreaming(client *ollama.Client, model, system, prompt string) {
+	var err error
+	if system != "" {
+		_, err = client.ChatStream(model, systemMessages(system, prompt), func(chunk ollama.ChatResponse) {
+			fmt.Print(chunk.Message.Content)
+		})
+	} else {
+		_, err = client.GenerateStream(model, prompt, func(chunk ollama.GenerateResponse) {
+			fmt.Print(chunk.Response)
+		})
+	}
 
 	if err != nil {
 		fmt.Fprintln(os.Stderr)
@@ -189,14 +350,30 @@ func executeStreaming(client *ollama.Client, model, prompt string) {
 	fmt.Println() // Final newline
 }
 
-func executeBlocking(client *ollama.Client, model, prompt string) {
-	resp, err := client.Generate(model, prompt)
+func executeBlocking(client *ollama.Client, model, system, prompt string) {
+	answer, err := askOnce(client, model, system, prompt)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render("Error: "+err.Error()))
 		return
 	}
 
-	fmt.Println(resp.Response)
+	fmt.Println(answer)
+}
+
+// askOnce gets a whole answer, via /api/chat when there's a system prompt
+func askOnce(client *ollama.Client, model, system, prompt string) (string, error) {
+	if system != "" {
+		resp, err := client.Chat(model, systemMessages(system, prompt))
+		if err != nil {
+			return "", err
+		}
+		return resp.Message.Content, nil
+	}
+	resp, err := client.Generate(model, prompt)
+	if err != nil {
+		return "", err
+	}
+	return resp.Response, nil
 }
 
 // AskResponse represents the JSON output format for ask command
@@ -209,42 +386,43 @@ type AskResponse struct {
 		Host       string  `json:"host,omitempty"`
 		URL        string  `json:"url,omitempty"`
 	} `json:"routing"`
-	Response string `json:"response"`
-	Error    string `json:"error,omitempty"`
+	Response  string `json:"response"`
+	SessionID string `json:"session_id,omitempty"`
+	Error     string `json:"error,omitempty"`
 }
 
-func executeJSON(result *router.RouteResult, prompt string) {
-	output := AskResponse{}
+func executeJSON(result *router.RouteResult, system, prompt string) {
+	out := AskResponse{}
 
 	// Fill routing info
-	output.Routing.Tier = result.Tier
-	output.Routing.TierName = router.TierName(result.Tier)
-	output.Routing.Confidence = result.Confidence
-	output.Routing.Model = result.Model
+	out.Routing.Tier = result.Tier
+	out.Routing.TierName = router.TierName(result.Tier)
+	out.Routing.Confidence = result.Confidence
+	out.Routing.Model = result.Model
 	if result.Host != nil {
-		output.Routing.Host = result.Host.Host.Name
-		output.Routing.URL = result.Host.Host.URL
+		out.Routing.Host = result.Host.Host.Name
+		out.Routing.URL = result.Host.Host.URL
 	}
 
 	// Check for host availability
 	if result.Host == nil || result.Client == nil {
-		output.Error = "no available host found"
-		data, _ := json.MarshalIndent(output, "", "  ")
+		out.Error = "no available host found"
+		data, _ := output.Marshal(out)
 		fmt.Println(string(data))
 		return
 	}
 
 	// Execute query (non-streaming for JSON)
-	resp, err := result.Client.Generate(result.Model, prompt)
+	answer, err := askOnce(result.Client, result.Model, system, prompt)
 	if err != nil {
-		output.Error = err.Error()
-		data, _ := json.MarshalIndent(output, "", "  ")
+		out.Error = err.Error()
+		data, _ := output.Marshal(out)
 		fmt.Println(string(data))
 		return
 	}
 
-	output.Response = resp.Response
-	data, _ := json.MarshalIndent(output, "", "  ")
+	out.Response = answer
+	data, _ := output.Marshal(out)
 	fmt.Println(string(data))
 }
 
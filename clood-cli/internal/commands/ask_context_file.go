@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// contextFileCharsPerToken matches the ~4 chars per token estimate the
+// context package uses
+const contextFileCharsPerToken = 4
+
+// contextFileLangs maps extensions to a code fence language and the line
+// comment used for the filename marker
+var contextFileLangs = map[string][2]string{
+	".go":   {"go", "//"},
+	".py":   {"python", "#"},
+	".js":   {"javascript", "//"},
+	".jsx":  {"jsx", "//"},
+	".ts":   {"typescript", "//"},
+	".tsx":  {"tsx", "//"},
+	".rs":   {"rust", "//"},
+	".c":    {"c", "//"},
+	".h":    {"c", "//"},
+	".cpp":  {"cpp", "//"},
+	".java": {"java", "//"},
+	".rb":   {"ruby", "#"},
+	".sh":   {"bash", "#"},
+	".yaml": {"yaml", "#"},
+	".yml":  {"yaml", "#"},
+	".toml": {"toml", "#"},
+	".sql":  {"sql", "--"},
+	".lua":  {"lua", "--"},
+	".md":   {"markdown", ""},
+	".json": {"json", ""},
+}
+
+// buildContextFiles reads each --context-file into one markdown section
+// with a fenced, filename-marked copy of its contents. maxTokens is shared
+// by all the files in order; anything past it is cut and reported in the
+// returned warnings.
+func buildContextFiles(paths []string, maxTokens int) (string, []string, error) {
+	if len(paths) == 0 {
+		return "", nil, nil
+	}
+
+	budget := maxTokens * contextFileCharsPerToken
+	var sb strings.Builder
+	var warnings []string
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", nil, fmt.Errorf("context file: %w", err)
+		}
+		content := strings.TrimRight(string(data), "\n")
+
+		if maxTokens > 0 {
+			if budget <= 0 {
+				warnings = append(warnings, fmt.Sprintf("%s skipped: --max-context-tokens %d already used", path, maxTokens))
+				continue
+			}
+			if len(content) > budget {
+				warnings = append(warnings, fmt.Sprintf("%s truncated to ~%d of ~%d tokens (--max-context-tokens %d)",
+					path, budget/contextFileCharsPerToken, len(content)/contextFileCharsPerToken, maxTokens))
+				cut := budget
+				for cut > 0 && !utf8.RuneStart(content[cut]) {
+					cut-- // never split a multi-byte character
+				}
+				content = content[:cut] + "\n... (truncated)"
+			}
+			budget -= len(content)
+		}
+
+		lang := contextFileLangs[strings.ToLower(filepath.Ext(path))]
+		if sb.Len() > 0 {
+			sb.WriteString("\n\n")
+		}
+		sb.WriteString("## " + path + "\n\n")
+		sb.WriteString("```" + lang[0] + "\n")
+		if lang[1] != "" {
+			sb.WriteString(lang[1] + " " + path + "\n")
+		}
+		sb.WriteString(content)
+		sb.WriteString("\n```")
+	}
+	if sb.Len() == 0 {
+		return "", warnings, nil
+	}
+	return "Files:\n\n" + sb.String(), warnings, nil
+}
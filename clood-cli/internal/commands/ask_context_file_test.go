@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func writeContextFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestBuildContextFiles(t *testing.T) {
+	dir := t.TempDir()
+	goFile := writeContextFile(t, dir, "main.go", "package main\n\n")
+	mdFile := writeContextFile(t, dir, "README.MD", "# Title")
+	txtFile := writeContextFile(t, dir, "notes.txt", "plain")
+
+	got, warnings, err := buildContextFiles([]string{goFile, mdFile, txtFile}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "Files:\n\n" +
+		"## " + goFile + "\n\n```go\n// " + goFile + "\npackage main\n```\n\n" +
+		"## " + mdFile + "\n\n```markdown\n# Title\n```\n\n" +
+		"## " + txtFile + "\n\n```\nplain\n```"
+	if got != want {
+		t.Errorf("buildContextFiles =\n%s\nwant\n%s", got, want)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("warnings = %q; want none without a token limit", warnings)
+	}
+
+	if got, _, err := buildContextFiles(nil, 0); got != "" || err != nil {
+		t.Errorf("no files = %q, %v", got, err)
+	}
+	if _, _, err := buildContextFiles([]string{filepath.Join(dir, "missing.go")}, 0); err == nil {
+		t.Error("a missing file should fail")
+	}
+}
+
+func TestBuildContextFilesBudget(t *testing.T) {
+	dir := t.TempDir()
+	first := writeContextFile(t, dir, "a.py", strings.Repeat("x", 30))
+	second := writeContextFile(t, dir, "b.py", "y")
+
+	// 5 tokens is 20 characters, all spent on the first file
+	got, warnings, err := buildContextFiles([]string{first, second}, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(got, strings.Repeat("x", 20)+"\n... (truncated)") || strings.Contains(got, strings.Repeat("x", 21)) {
+		t.Errorf("first file not cut at 20 characters:\n%s", got)
+	}
+	if strings.Contains(got, second) {
+		t.Errorf("second file included past the budget:\n%s", got)
+	}
+	if len(warnings) != 2 || !strings.Contains(warnings[0], "truncated to ~5 of ~7 tokens") || !strings.Contains(warnings[1], "skipped") {
+		t.Errorf("warnings = %q", warnings)
+	}
+}
+
+func TestBuildContextFilesCutsOnRuneBoundary(t *testing.T) {
+	// "é" is 2 bytes, so 4 and 8 byte budgets both fall inside one
+	path := writeContextFile(t, t.TempDir(), "accents.txt", "aéééé")
+	for _, tokens := range []int{1, 2} {
+		got, _, err := buildContextFiles([]string{path}, tokens)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !utf8.ValidString(got) {
+			t.Errorf("budget %d split a character: %q", tokens, got)
+		}
+	}
+
+	path = writeContextFile(t, t.TempDir(), "cat.txt", "a🐈🐈")
+	got, _, _ := buildContextFiles([]string{path}, 1)
+	if !utf8.ValidString(got) || !strings.Contains(got, "a\n... (truncated)") {
+		t.Errorf("4-byte rune across the cut: %q", got)
+	}
+}
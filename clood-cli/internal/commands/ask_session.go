@@ -0,0 +1,279 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dirtybirdnj/clood/internal/ollama"
+	"github.com/dirtybirdnj/clood/internal/output"
+	"github.com/dirtybirdnj/clood/internal/router"
+	"github.com/dirtybirdnj/clood/internal/tui"
+)
+
+// askSessionHistory caps how many past messages `ask --continue` resends
+// to the model. `clood chat` sends its whole history.
+const askSessionHistory = 20
+
+var savedSessionIDRe = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// savedSessionsDir returns ~/.clood/sessions, shared by `ask --continue`
+// and chat's /save and /load. Sessions are stored as sagas.
+func savedSessionsDir() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".clood", "sessions")
+}
+
+func savedSessionPath(id string) string {
+	return filepath.Join(savedSessionsDir(), id+".json")
+}
+
+// savedSessionID turns a session name into a filename-safe ID
+func savedSessionID(name string) string {
+	id := savedSessionIDRe.ReplaceAllString(strings.ToLower(strings.TrimSpace(name)), "-")
+	return strings.Trim(id, "-")
+}
+
+// newAskSession creates an empty session; it is written on first save
+func newAskSession(name, projectContext string) (*Saga, string, error) {
+	id := savedSessionID(name)
+	if id == "" {
+		return nil, "", fmt.Errorf("invalid session name %q", name)
+	}
+	if _, err := os.Stat(savedSessionPath(id)); err == nil {
+		return nil, "", fmt.Errorf("session %q already exists (use --continue %s)", id, id)
+	}
+
+	cwd, _ := os.Getwd()
+	now := time.Now()
+	return &Saga{
+		Name:        name,
+		ProjectPath: cwd,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Messages:    []Message{},
+		Context:     projectContext,
+	}, id, nil
+}
+
+func loadSavedSession(id string) (*Saga, error) {
+	data, err := os.ReadFile(savedSessionPath(id))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("session %q not found in %s", id, savedSessionsDir())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var saga Saga
+	if err := json.Unmarshal(data, &saga); err != nil {
+		return nil, fmt.Errorf("parsing session %s: %w", id, err)
+	}
+	return &saga, nil
+}
+
+// savedSessionInfo summarises a saved session for listing
+type savedSessionInfo struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Messages  int       `json:"messages"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// listSavedSessions returns saved sessions, most recently updated first
+func listSavedSessions() ([]savedSessionInfo, error) {
+	entries, err := os.ReadDir(savedSessionsDir())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []savedSessionInfo
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		id := strings.TrimSuffix(e.Name(), ".json")
+		saga, err := loadSavedSession(id)
+		if err != nil {
+			continue
+		}
+		sessions = append(sessions, savedSessionInfo{
+			ID:        id,
+			Name:      saga.Name,
+			Messages:  len(saga.Messages),
+			UpdatedAt: saga.UpdatedAt,
+		})
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].UpdatedAt.After(sessions[j].UpdatedAt)
+	})
+	return sessions, nil
+}
+
+func saveSavedSession(id string, saga *Saga) error {
+	path := savedSessionPath(id)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating sessions dir: %w", err)
+	}
+
+	saga.UpdatedAt = time.Now()
+	data, err := json.MarshalIndent(saga, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// askSessionMessages converts a session into /api/chat messages, with the
+// session's system prompt and stored project context as the system
+// message. Only the last maxHistory messages are included, or all of them
+// when maxHistory is 0. With a maxTokens budget, older messages are also
+// left out once the estimated total would pass it; the newest message is
+// always sent.
+func askSessionMessages(saga *Saga, maxHistory, maxTokens int) []ollama.Message {
+	var messages []ollama.Message
+	var system []string
+	if saga.System != "" {
+		system = append(system, saga.System)
+	}
+	if saga.Context != "" {
+		system = append(system, "Project Context:\n"+saga.Context)
+	}
+	if len(system) > 0 {
+		messages = append(messages, ollama.Message{
+			Role:    "system",
+			Content: strings.Join(system, "\n\n"),
+		})
+	}
+
+	var history []Message
+	for _, msg := range saga.Messages {
+		if msg.Role != noteRole {
+			history = append(history, msg)
+		}
+	}
+	if maxHistory > 0 && len(history) > maxHistory {
+		history = history[len(history)-maxHistory:]
+	}
+	if maxTokens > 0 {
+		used := 0
+		for _, msg := range messages {
+			used += estimateTokens(msg.Content)
+		}
+		keep := len(history)
+		for keep > 0 {
+			tokens := history[keep-1].Tokens
+			if tokens == 0 {
+				tokens = estimateTokens(history[keep-1].Content)
+			}
+			if used+tokens > maxTokens && keep < len(history) {
+				break
+			}
+			used += tokens
+			keep--
+		}
+		history = history[keep:]
+	}
+	for _, msg := range history {
+		messages = append(messages, ollama.Message{Role: msg.Role, Content: msg.Content})
+	}
+	return messages
+}
+
+// executeAskSession sends question with the session's history via /api/chat
+// and records both sides of the exchange.
+func executeAskSession(result *router.RouteResult, saga *Saga, id, question string, stream, jsonOutput bool) {
+	resp := AskResponse{SessionID: id}
+	resp.Routing.Tier = result.Tier
+	resp.Routing.TierName = router.TierName(result.Tier)
+	resp.Routing.Confidence = result.Confidence
+	resp.Routing.Model = result.Model
+	if result.Host != nil {
+		resp.Routing.Host = result.Host.Host.Name
+		resp.Routing.URL = result.Host.Host.URL
+	}
+
+	fail := func(msg string) {
+		if jsonOutput {
+			resp.Error = msg
+			data, _ := output.Marshal(resp)
+			fmt.Println(string(data))
+			return
+		}
+		fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render("Error: "+msg))
+	}
+
+	if result.Host == nil || result.Client == nil {
+		fail("no available host found")
+		return
+	}
+
+	if !jsonOutput {
+		fmt.Println(tui.RenderTier(result.Tier))
+		fmt.Printf("%s %s on %s\n", tui.MutedStyle.Render("Model:"), result.Model, result.Host.Host.Name)
+		fmt.Println()
+	}
+
+	saga.Messages = append(saga.Messages, Message{
+		Role:      "user",
+		Content:   question,
+		Timestamp: time.Now(),
+		Tokens:    estimateTokens(question),
+	})
+	messages := askSessionMessages(saga, askSessionHistory, 0)
+
+	var answer string
+	if stream && !jsonOutput {
+		var b strings.Builder
+		_, err := result.Client.ChatStream(result.Model, messages, func(chunk ollama.ChatResponse) {
+			fmt.Print(chunk.Message.Content)
+			b.WriteString(chunk.Message.Content)
+		})
+		fmt.Println()
+		if err != nil {
+			fail(err.Error())
+			return
+		}
+		answer = b.String()
+	} else {
+		chat, err := result.Client.Chat(result.Model, messages)
+		if err != nil {
+			fail(err.Error())
+			return
+		}
+		answer = chat.Message.Content
+		if !jsonOutput {
+			fmt.Println(answer)
+		}
+	}
+
+	saga.Messages = append(saga.Messages, Message{
+		Role:      "assistant",
+		Content:   answer,
+		Timestamp: time.Now(),
+		Tokens:    estimateTokens(answer),
+	})
+	if err := saveSavedSession(id, saga); err != nil {
+		fail("saving session: " + err.Error())
+		return
+	}
+
+	if jsonOutput {
+		resp.Response = answer
+		data, _ := output.Marshal(resp)
+		fmt.Println(string(data))
+		return
+	}
+
+	// stderr keeps stdout clean for scripts that capture the answer
+	fmt.Fprintln(os.Stderr, tui.MutedStyle.Render(fmt.Sprintf("Session: %s (%d messages) — continue with: clood ask --continue %s \"...\"",
+		id, len(saga.Messages), id)))
+}
@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestAskSessionMessages(t *testing.T) {
+	saga := &Saga{System: "Be brief.", Context: "a Go CLI"}
+	for i := 1; i <= 6; i++ {
+		role := "user"
+		if i%2 == 0 {
+			role = "assistant"
+		}
+		saga.Messages = append(saga.Messages, Message{Role: role, Content: fmt.Sprint("m", i), Tokens: 100})
+		if i == 3 {
+			saga.Messages = append(saga.Messages, Message{Role: noteRole, Content: "switched model"})
+		}
+	}
+	// The system message is "Be brief.\n\nProject Context:\na Go CLI", ~9 tokens
+
+	tests := []struct {
+		name                  string
+		maxHistory, maxTokens int
+		want                  []string
+	}{
+		{"everything", 0, 0, []string{"m1", "m2", "m3", "m4", "m5", "m6"}},
+		{"last 4", 4, 0, []string{"m3", "m4", "m5", "m6"}},
+		{"budget for 3", 0, 320, []string{"m4", "m5", "m6"}},
+		{"budget just short of 3", 0, 300, []string{"m5", "m6"}},
+		{"history cap inside the budget", 2, 10000, []string{"m5", "m6"}},
+		{"budget below one message", 0, 50, []string{"m6"}},
+	}
+	for _, tt := range tests {
+		messages := askSessionMessages(saga, tt.maxHistory, tt.maxTokens)
+		if messages[0].Role != "system" || messages[0].Content != "Be brief.\n\nProject Context:\na Go CLI" {
+			t.Errorf("%s: system message = %+v", tt.name, messages[0])
+		}
+		var got []string
+		for _, m := range messages[1:] {
+			if m.Role == noteRole {
+				t.Errorf("%s: a note was sent to the model", tt.name)
+			}
+			got = append(got, m.Content)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: askSessionMessages(%d, %d) = %q; want %q", tt.name, tt.maxHistory, tt.maxTokens, got, tt.want)
+		}
+	}
+}
+
+func TestAskSessionMessagesEstimatesTokens(t *testing.T) {
+	// Messages saved without a token count are estimated at 4 chars a token
+	long := string(make([]byte, 400))
+	saga := &Saga{Messages: []Message{
+		{Role: "user", Content: long},
+		{Role: "assistant", Content: long},
+		{Role: "user", Content: "short"},
+	}}
+	messages := askSessionMessages(saga, 0, 150)
+	if len(messages) != 2 || messages[1].Content != "short" {
+		t.Errorf("got %d messages; want the last reply and question", len(messages))
+	}
+}
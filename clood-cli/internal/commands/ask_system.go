@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dirtybirdnj/clood/internal/ollama"
+)
+
+// loadSystemPrompt resolves a --system value: "@path" reads the prompt
+// from a file, anything else is the prompt itself
+func loadSystemPrompt(value string) (string, error) {
+	path, ok := strings.CutPrefix(value, "@")
+	if !ok {
+		return strings.TrimSpace(value), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading --system file: %w", err)
+	}
+	system := strings.TrimSpace(string(data))
+	if system == "" {
+		return "", fmt.Errorf("--system file %s is empty", path)
+	}
+	return system, nil
+}
+
+// systemMessages is a one-turn /api/chat conversation: the system prompt,
+// then the user's prompt
+func systemMessages(system, prompt string) []ollama.Message {
+	return []ollama.Message{
+		{Role: "system", Content: system},
+		{Role: "user", Content: prompt},
+	}
+}
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"crypto/subtle"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// atcBasicAuth guards every ATC endpoint, the dashboard page included: the
+// browser prompts once on the page and then sends the same credentials with
+// its /ws upgrade and /events requests. /ws is checked here, before the
+// upgrade, since a WebSocket can't answer a 401 challenge itself.
+func atcBasicAuth(user, pass string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u, p, ok := r.BasicAuth()
+		if !ok ||
+			subtle.ConstantTimeCompare([]byte(u), []byte(user)) != 1 ||
+			subtle.ConstantTimeCompare([]byte(p), []byte(pass)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Basic realm="clood atc", charset="UTF-8"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// parseATCAuth splits a "user:password" --auth value
+func parseATCAuth(spec string) (user, pass string, err error) {
+	user, pass, ok := strings.Cut(spec, ":")
+	if !ok || user == "" || pass == "" {
+		return "", "", fmt.Errorf("--auth must be user:password")
+	}
+	return user, pass, nil
+}
+
+// atcSelfURL is the URL the server uses to reach itself (for --replay),
+// with the --auth credentials embedded so Go's client sends them
+func atcSelfURL(port int, useTLS bool, user, pass string) string {
+	u := url.URL{Scheme: "http", Host: fmt.Sprintf("localhost:%d", port)}
+	if useTLS {
+		u.Scheme = "https"
+	}
+	if user != "" {
+		u.User = url.UserPassword(user, pass)
+	}
+	return u.String()
+}
+
+// atcSelfClient talks to this ATC over loopback. The --tls-cert is usually
+// issued for the LAN name rather than localhost, so it isn't verified.
+func atcSelfClient(useTLS bool) *http.Client {
+	client := &http.Client{Timeout: 10 * time.Second}
+	if useTLS {
+		client.Transport = &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+	}
+	return client
+}
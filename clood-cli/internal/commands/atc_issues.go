@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dirtybirdnj/clood/internal/git"
+	"github.com/google/go-github/v50/github"
+)
+
+// atcIssueFetchConcurrency caps simultaneous GitHub requests per poll
+const atcIssueFetchConcurrency = 4
+
+// atcIssueFetcher merges open issues from several repos for planning mode.
+// The dashboard polls far more often than GitHub's unauthenticated limit
+// (60 requests/hour) allows, so when a poll would run out of quota the
+// fetcher serves the last results until the rate limit resets.
+type atcIssueFetcher struct {
+	client    *github.Client
+	repos     [][2]string // owner, name
+	decayRate float64     // per day, see decayedVelocity
+
+	mu      sync.Mutex
+	last    map[string][]IssuePacket // by owner/name
+	resetAt time.Time
+}
+
+func newATCIssueFetcher(repos []string, token string, decayRate float64) (*atcIssueFetcher, error) {
+	f := &atcIssueFetcher{last: make(map[string][]IssuePacket), decayRate: decayRate}
+	for _, r := range repos {
+		owner, name, ok := strings.Cut(strings.TrimSpace(r), "/")
+		if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+			return nil, fmt.Errorf("invalid repo %q (expected owner/repo)", r)
+		}
+		f.repos = append(f.repos, [2]string{owner, name})
+	}
+
+	f.client = git.GitHubClient(token)
+	return f, nil
+}
+
+// fetch returns the merged issue list. Repos that fail keep their
+// previous issues so one bad repo doesn't blank the leaderboard.
+func (f *atcIssueFetcher) fetch() []IssuePacket {
+	f.mu.Lock()
+	limited := time.Now().Before(f.resetAt)
+	f.mu.Unlock()
+
+	if !limited {
+		ctx := context.Background()
+		sem := make(chan struct{}, atcIssueFetchConcurrency)
+		var wg sync.WaitGroup
+		for _, r := range f.repos {
+			wg.Add(1)
+			go func(owner, name string) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				packets, resp, err := atcFetchIssues(ctx, f.client, owner, name)
+				f.record(owner+"/"+name, packets, resp, err)
+			}(r[0], r[1])
+		}
+		wg.Wait()
+	}
+
+	return f.merged()
+}
+
+func (f *atcIssueFetcher) record(repo string, packets []IssuePacket, resp *github.Response, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var rateErr *github.RateLimitError
+	switch {
+	case errors.As(err, &rateErr):
+		f.backOffUntil(rateErr.Rate.Reset.Time)
+		return
+	case err != nil:
+		return
+	}
+
+	f.last[repo] = packets
+	// Stop before the next poll would be refused
+	if resp != nil && resp.Rate.Limit > 0 && resp.Rate.Remaining < len(f.repos) {
+		f.backOffUntil(resp.Rate.Reset.Time)
+	}
+}
+
+func (f *atcIssueFetcher) backOffUntil(t time.Time) {
+	if t.After(f.resetAt) {
+		f.resetAt = t
+	}
+}
+
+func (f *atcIssueFetcher) merged() []IssuePacket {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// Decay is applied here rather than at fetch time so issues served
+	// from the cache while rate limited keep ageing
+	now := time.Now()
+	var all []IssuePacket
+	for _, packets := range f.last {
+		for _, p := range packets {
+			p.DecayedVelocity = decayedVelocity(p.Velocity, p.UpdatedAt, f.decayRate, now)
+			all = append(all, p)
+		}
+	}
+	// Velocity is per issue, so ranking across repos is a plain sort
+	sort.SliceStable(all, func(i, j int) bool {
+		if all[i].DecayedVelocity != all[j].DecayedVelocity {
+			return all[i].DecayedVelocity > all[j].DecayedVelocity
+		}
+		return all[i].UpdatedAt > all[j].UpdatedAt
+	})
+	return all
+}
+
+// decayedVelocity scales velocity by exp(-rate * days since updatedAt), so
+// at the default 0.1 a week without activity roughly halves the score
+func decayedVelocity(velocity int, updatedAt string, rate float64, now time.Time) int {
+	updated, err := time.Parse(time.RFC3339, updatedAt)
+	if err != nil || rate == 0 {
+		return velocity
+	}
+	days := max(now.Sub(updated).Hours()/24, 0)
+	return int(math.Round(float64(velocity) * math.Exp(-rate*days)))
+}
@@ -0,0 +1,206 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+)
+
+// fakeGitHub answers issue listings per owner/name, counting requests
+type fakeGitHub struct {
+	mu        sync.Mutex
+	requests  map[string]int
+	issues    map[string]string // JSON array by repo
+	status    map[string]int    // non-200 status by repo
+	remaining int               // X-RateLimit-Remaining on every reply
+	reset     time.Time
+}
+
+func (g *fakeGitHub) RoundTrip(req *http.Request) (*http.Response, error) {
+	// /repos/<owner>/<name>/issues
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	repo := parts[1] + "/" + parts[2]
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.requests[repo]++
+
+	status, body := http.StatusOK, g.issues[repo]
+	if s := g.status[repo]; s != 0 {
+		status, body = s, `{"message":"failed"}`
+	}
+	header := http.Header{"Content-Type": {"application/json"}}
+	header.Set("X-RateLimit-Limit", "60")
+	header.Set("X-RateLimit-Remaining", strconv.Itoa(g.remaining))
+	header.Set("X-RateLimit-Reset", strconv.FormatInt(g.reset.Unix(), 10))
+	if status == http.StatusForbidden && g.remaining == 0 {
+		body = `{"message":"API rate limit exceeded"}`
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func (g *fakeGitHub) count(repo string) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.requests[repo]
+}
+
+func issueJSON(number int, title, label string, updated time.Time) string {
+	return fmt.Sprintf(`{"id":%d,"number":%d,"title":%q,"state":"open","updated_at":%q,"labels":[{"name":%q}]}`,
+		number, number, title, updated.Format(time.RFC3339), label)
+}
+
+func newTestIssueFetcher(t *testing.T, g *fakeGitHub, repos ...string) *atcIssueFetcher {
+	t.Helper()
+	f, err := newATCIssueFetcher(repos, "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.client = github.NewClient(&http.Client{Transport: g})
+	return f
+}
+
+func TestATCIssueFetcherMerge(t *testing.T) {
+	old := time.Now().Add(-72 * time.Hour) // too old for the recency bonus
+	g := &fakeGitHub{
+		requests: map[string]int{},
+		status:   map[string]int{},
+		issues: map[string]string{
+			"me/api": "[" + issueJSON(1, "minor", "enhancement", old) + "," + issueJSON(2, "outage", "P0", old) + "]",
+			"me/web": "[" + issueJSON(7, "broken button", "bug", old) + "]",
+		},
+		remaining: 50,
+		reset:     time.Now().Add(time.Hour),
+	}
+	f := newTestIssueFetcher(t, g, "me/api", "me/web")
+
+	var got []string
+	for _, p := range f.fetch() {
+		got = append(got, fmt.Sprintf("%s#%d=%d", p.Repo, p.Number, p.DecayedVelocity))
+	}
+	want := []string{"me/api#2=500", "me/web#7=50", "me/api#1=25"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("fetch() = %v; want %v ranked across repos", got, want)
+	}
+
+	// A failing repo keeps what it had; the other still updates
+	g.mu.Lock()
+	g.status["me/web"] = http.StatusInternalServerError
+	g.issues["me/api"] = "[" + issueJSON(3, "new", "P1", old) + "]"
+	g.mu.Unlock()
+	got = nil
+	for _, p := range f.fetch() {
+		got = append(got, fmt.Sprintf("%s#%d", p.Repo, p.Number))
+	}
+	if fmt.Sprint(got) != "[me/api#3 me/web#7]" {
+		t.Errorf("after me/web failed: %v; want me/api's new issue and me/web's old one", got)
+	}
+}
+
+func TestATCIssueFetcherBacksOffBeforeQuotaRunsOut(t *testing.T) {
+	g := &fakeGitHub{
+		requests:  map[string]int{},
+		status:    map[string]int{},
+		issues:    map[string]string{"me/api": "[]", "me/web": "[]"},
+		remaining: 1, // fewer than one request per repo left
+		reset:     time.Now().Add(time.Hour),
+	}
+	f := newTestIssueFetcher(t, g, "me/api", "me/web")
+
+	f.fetch()
+	f.fetch()
+	if n := g.count("me/api"); n != 1 {
+		t.Errorf("me/api requested %d times; want the second poll served from cache", n)
+	}
+	if f.resetAt.Unix() != g.reset.Unix() {
+		t.Errorf("resetAt = %v; want the rate limit reset %v", f.resetAt, g.reset)
+	}
+
+	// Once the reset passes, polling resumes
+	f.mu.Lock()
+	f.resetAt = time.Now().Add(-time.Second)
+	f.mu.Unlock()
+	g.mu.Lock()
+	g.remaining = 50
+	g.mu.Unlock()
+	f.fetch()
+	if n := g.count("me/api"); n != 2 {
+		t.Errorf("me/api requested %d times after the reset; want 2", n)
+	}
+}
+
+func TestATCIssueFetcherRateLimited(t *testing.T) {
+	old := time.Now().Add(-72 * time.Hour)
+	g := &fakeGitHub{
+		requests:  map[string]int{},
+		status:    map[string]int{},
+		issues:    map[string]string{"me/api": "[" + issueJSON(1, "a", "bug", old) + "]"},
+		remaining: 50,
+		reset:     time.Now().Add(time.Hour),
+	}
+	f := newTestIssueFetcher(t, g, "me/api")
+	if got := f.fetch(); len(got) != 1 {
+		t.Fatalf("first fetch = %v", got)
+	}
+
+	g.mu.Lock()
+	g.status["me/api"] = http.StatusForbidden
+	g.remaining = 0
+	g.mu.Unlock()
+	if got := f.fetch(); len(got) != 1 || got[0].Number != 1 {
+		t.Errorf("rate limited fetch = %v; want the cached issue", got)
+	}
+	if !f.resetAt.After(time.Now()) {
+		t.Error("a rate limit error should back off until the reset")
+	}
+	before := g.count("me/api")
+	f.fetch()
+	if g.count("me/api") != before {
+		t.Error("fetch while backed off still called GitHub")
+	}
+}
+
+func TestNewATCIssueFetcherInvalidRepo(t *testing.T) {
+	for _, repo := range []string{"", "me", "/api", "me/", "me/api/extra"} {
+		if _, err := newATCIssueFetcher([]string{repo}, "", 0); err == nil {
+			t.Errorf("newATCIssueFetcher(%q) should fail", repo)
+		}
+	}
+	if f, err := newATCIssueFetcher([]string{" me/api "}, "", 0); err != nil || f.repos[0] != [2]string{"me", "api"} {
+		t.Errorf("newATCIssueFetcher(\" me/api \") = %v, %v", f, err)
+	}
+}
+
+func TestDecayedVelocity(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		velocity  int
+		updatedAt string
+		rate      float64
+		want      int
+	}{
+		{500, "2026-02-19T12:00:00Z", 0.1, 184}, // 10 days: e^-1
+		{500, "2026-02-22T12:00:00Z", 0.1, 248}, // a week roughly halves it
+		{500, "2026-03-01T12:00:00Z", 0.1, 500},
+		{500, "2026-03-02T12:00:00Z", 0.1, 500}, // future times don't boost
+		{500, "2026-02-19T12:00:00Z", 0, 500},
+		{500, "not a time", 0.1, 500},
+	}
+	for _, tt := range tests {
+		if got := decayedVelocity(tt.velocity, tt.updatedAt, tt.rate, now); got != tt.want {
+			t.Errorf("decayedVelocity(%d, %s, %v) = %d; want %d", tt.velocity, tt.updatedAt, tt.rate, got, tt.want)
+		}
+	}
+}
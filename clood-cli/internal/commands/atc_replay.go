@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dirtybirdnj/clood/internal/tui"
+)
+
+// loadExperimentReplay reads experiment events from a 'clood session
+// export' bundle (its experiment_events) or a bare JSON array of events
+// such as 'curl localhost:8080/experiment' returns.
+func loadExperimentReplay(path string) ([]ExperimentEvent, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []ExperimentEvent
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		if err := json.Unmarshal(data, &events); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+	} else {
+		var bundle SessionBundle
+		if err := json.Unmarshal(data, &bundle); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+		events = bundle.ExperimentEvents
+	}
+
+	if len(events) == 0 {
+		return nil, fmt.Errorf("%s has no experiment events (export with 'clood session export --atc <url>')", path)
+	}
+	return events, nil
+}
+
+// parseReplaySpeed accepts "2x", "0.5x" or a bare multiplier
+func parseReplaySpeed(s string) (float64, error) {
+	speed, err := strconv.ParseFloat(strings.TrimSuffix(strings.ToLower(strings.TrimSpace(s)), "x"), 64)
+	if err != nil || speed <= 0 {
+		return 0, fmt.Errorf("invalid replay speed %q (use e.g. 2x or 0.5x)", s)
+	}
+	return speed, nil
+}
+
+// replayExperiment re-POSTs events to the dashboard's own /experiment
+// endpoint, spaced by their original timestamps divided by speed. It waits
+// for a dashboard to connect first so the opening events aren't missed.
+func replayExperiment(hub *Hub, client *http.Client, baseURL string, events []ExperimentEvent, speed float64) {
+	for hub.clientCount() == 0 {
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	var prev time.Time
+	for i, event := range events {
+		if ts, err := time.Parse(time.RFC3339, event.Timestamp); err == nil {
+			if !prev.IsZero() && ts.After(prev) {
+				time.Sleep(time.Duration(float64(ts.Sub(prev)) / speed))
+			}
+			prev = ts
+		}
+
+		body, err := json.Marshal(event)
+		if err != nil {
+			continue
+		}
+		resp, err := client.Post(baseURL+"/experiment", "application/json", bytes.NewReader(body))
+		if err != nil {
+			fmt.Println(tui.ErrorStyle.Render(fmt.Sprintf("  Replay stopped at event %d: %v", i+1, err)))
+			return
+		}
+		resp.Body.Close()
+	}
+
+	fmt.Println(tui.SuccessStyle.Render(fmt.Sprintf("  ✓ Replay finished (%d events)", len(events))))
+}
+
+// fetchATCExperimentEvents pulls the recent experiment events from a
+// running 'clood atc --mode experiment'
+func fetchATCExperimentEvents(baseURL string) ([]ExperimentEvent, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(strings.TrimRight(baseURL, "/") + "/experiment")
+	if err != nil {
+		return nil, fmt.Errorf("fetching experiment events: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("atc returned %s", resp.Status)
+	}
+
+	var events []ExperimentEvent
+	if err := json.NewDecoder(resp.Body).Decode(&events); err != nil {
+		return nil, fmt.Errorf("decoding experiment events: %w", err)
+	}
+	return events, nil
+}
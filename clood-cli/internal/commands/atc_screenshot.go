@@ -0,0 +1,426 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// Screenshot layout, in unscaled pixels. Text is basicfont's 7x13, so the
+// image is drawn at 1/scale size and enlarged to keep it readable at
+// 1920x1080; SVG uses the same layout in its viewBox.
+const (
+	atcShotCharW   = 7
+	atcShotRowH    = 18
+	atcShotMargin  = 16
+	atcShotColGap  = 2 // characters between columns
+	atcShotMaxCell = 48
+	atcShotBaseW   = 960 // logical width that scale 1 is laid out for
+	atcShotBaseH   = 540
+)
+
+var (
+	atcShotBgTop    = color.RGBA{0x0a, 0x0a, 0x1a, 0xff}
+	atcShotBgBottom = color.RGBA{0x1a, 0x1a, 0x2e, 0xff}
+	atcShotBand     = color.RGBA{0x16, 0x21, 0x3e, 0xff}
+	atcShotText     = color.RGBA{0xee, 0xee, 0xee, 0xff}
+	atcShotMuted    = color.RGBA{0x88, 0x88, 0x88, 0xff}
+	atcShotGreen    = color.RGBA{0x00, 0xff, 0x88, 0xff}
+	atcShotAmber    = color.RGBA{0xff, 0xaa, 0x00, 0xff}
+	atcShotRed      = color.RGBA{0xff, 0x44, 0x44, 0xff}
+	atcShotGold     = color.RGBA{0xff, 0xd7, 0x00, 0xff}
+	atcShotSilver   = color.RGBA{0xc0, 0xc0, 0xc0, 0xff}
+	atcShotBronze   = color.RGBA{0xcd, 0x7f, 0x32, 0xff}
+)
+
+// atcSnapshot is the dashboard's last state reduced to tables
+type atcSnapshot struct {
+	Title  string
+	Status string
+	Tables []atcTable
+}
+
+type atcTable struct {
+	Title   string
+	Columns []string
+	Rows    []atcRow
+}
+
+// atcRow is one table line; Accent colours its first cell
+type atcRow struct {
+	Cells  []string
+	Accent color.RGBA
+}
+
+// atcSnapshotFrom builds the snapshot from the message last broadcast to
+// dashboards: issues (planning), hosts (active) or experiment state
+func atcSnapshotFrom(msg *ATCMessage) atcSnapshot {
+	snap := atcSnapshot{Title: "ATC Tower", Status: msg.Mode}
+	if t, err := time.Parse(time.RFC3339, msg.Time); err == nil {
+		snap.Status += " | " + t.Format("2006-01-02 15:04:05")
+	}
+
+	switch data := msg.Data.(type) {
+	case []IssuePacket:
+		snap.Title = "ATC Tower - Planning"
+		snap.Tables = append(snap.Tables, atcIssueTable(data))
+	case []HostStatus:
+		snap.Title = "ATC Tower - Active"
+		snap.Tables = append(snap.Tables, atcHostTable(data))
+	case map[string]interface{}:
+		snap.Title = "ATC Tower - Experiment"
+		if sessions, ok := data["sessions"].([]*ExperimentSession); ok {
+			snap.Tables = append(snap.Tables, atcSessionTable(sessions))
+		}
+		if hosts, ok := data["hosts"].([]HostStatus); ok {
+			snap.Tables = append(snap.Tables, atcHostTable(hosts))
+		}
+	}
+	return snap
+}
+
+func atcIssueTable(issues []IssuePacket) atcTable {
+	t := atcTable{Title: "Leaderboard", Columns: []string{"#", "Issue", "Title", "Velocity", "Decayed", "Labels"}}
+	for i, issue := range issues {
+		accent := atcShotGreen
+		switch i {
+		case 0:
+			accent = atcShotGold
+		case 1:
+			accent = atcShotSilver
+		case 2:
+			accent = atcShotBronze
+		}
+		ref := fmt.Sprintf("%s#%d", issue.Repo, issue.Number)
+		if issue.IsPR {
+			ref += " (PR)"
+		}
+		t.Rows = append(t.Rows, atcRow{
+			Cells: []string{strconv.Itoa(i + 1), ref, issue.Title,
+				strconv.Itoa(issue.Velocity), strconv.Itoa(issue.DecayedVelocity), strings.Join(issue.Labels, ", ")},
+			Accent: accent,
+		})
+	}
+	return t
+}
+
+func atcHostTable(hosts []HostStatus) atcTable {
+	t := atcTable{Title: "Hosts", Columns: []string{"Host", "Status", "Latency", "Active", "Models", "Hardware"}}
+	for _, h := range hosts {
+		row := atcRow{Accent: atcShotGreen}
+		status, latency := "online", fmt.Sprintf("%dms", h.Latency)
+		if !h.Online {
+			row.Accent, status, latency = atcShotRed, "offline", "-"
+		}
+		models := fmt.Sprintf("%d", len(h.Models))
+		if len(h.Models) > 0 {
+			models += ": " + strings.Join(h.Models, ", ")
+		}
+		hardware := strings.Trim(strings.Join([]string{h.Hardware.GPU, h.Hardware.Memory}, ", "), ", ")
+		row.Cells = []string{h.Name, status, latency, strconv.Itoa(h.ActiveReq), models, hardware}
+		t.Rows = append(t.Rows, row)
+	}
+	return t
+}
+
+func atcSessionTable(sessions []*ExperimentSession) atcTable {
+	t := atcTable{Title: "Sessions", Columns: []string{"Session", "Status", "Step", "Iterations", "Last model"}}
+	for _, s := range sessions {
+		row := atcRow{Accent: atcShotAmber}
+		switch s.Status {
+		case "completed":
+			row.Accent = atcShotGreen
+		case "failed":
+			row.Accent = atcShotRed
+		}
+		iterations, lastModel := 0, "-"
+		for _, step := range s.Steps {
+			iterations += len(step.Iterations)
+			if n := len(step.Iterations); n > 0 {
+				lastModel = step.Iterations[n-1].Model
+			}
+		}
+		name := s.Name
+		if name == "" {
+			name = s.ID
+		}
+		row.Cells = []string{name, s.Status, fmt.Sprintf("%d/%d", s.CurrentStep, s.TotalSteps),
+			strconv.Itoa(iterations), lastModel}
+		t.Rows = append(t.Rows, row)
+	}
+	return t
+}
+
+// atcCanvas is what the snapshot layout draws on: the PNG and SVG renderers
+type atcCanvas interface {
+	rect(x, y, w, h int, c color.RGBA)
+	text(x, baseline int, s string, c color.RGBA)
+}
+
+// drawATCSnapshot lays the snapshot out on a w x h canvas. Tables get the
+// columns' widest cell (up to atcShotMaxCell characters), shrinking the
+// widest column until the row fits; rows past the bottom are counted in
+// an "and N more" line.
+func drawATCSnapshot(c atcCanvas, snap atcSnapshot, w, h int) {
+	x := atcShotMargin
+	y := atcShotMargin + 13
+	c.text(x, y, snap.Title, atcShotGreen)
+	if snap.Status != "" {
+		c.text(w-atcShotMargin-len([]rune(snap.Status))*atcShotCharW, y, snap.Status, atcShotMuted)
+	}
+	y += atcShotRowH
+
+	maxChars := (w - 2*atcShotMargin) / atcShotCharW
+	bottom := h - atcShotMargin
+	for _, t := range snap.Tables {
+		if y+2*atcShotRowH > bottom {
+			break
+		}
+		y += atcShotRowH / 2
+		c.text(x, y+13, strings.ToUpper(t.Title), atcShotAmber)
+		y += atcShotRowH
+
+		widths := atcColumnWidths(t, maxChars)
+		atcDrawRow(c, x, y, widths, t.Columns, atcShotMuted, atcShotMuted)
+		y += atcShotRowH
+
+		if len(t.Rows) == 0 {
+			c.text(x, y+13, "(none)", atcShotMuted)
+			y += atcShotRowH
+			continue
+		}
+		for i, row := range t.Rows {
+			if y+2*atcShotRowH > bottom && i < len(t.Rows)-1 {
+				c.text(x, y+13, fmt.Sprintf("... and %d more", len(t.Rows)-i), atcShotMuted)
+				y += atcShotRowH
+				break
+			}
+			if i%2 == 0 {
+				c.rect(x-4, y, w-2*atcShotMargin+8, atcShotRowH, atcShotBand)
+			}
+			atcDrawRow(c, x, y, widths, row.Cells, row.Accent, atcShotText)
+			y += atcShotRowH
+		}
+	}
+}
+
+func atcDrawRow(c atcCanvas, x, y int, widths []int, cells []string, accent, fg color.RGBA) {
+	for i, cell := range cells {
+		if i >= len(widths) {
+			break
+		}
+		col := fg
+		if i == 0 && accent != (color.RGBA{}) {
+			col = accent
+		}
+		c.text(x, y+13, atcFit(cell, widths[i]), col)
+		x += (widths[i] + atcShotColGap) * atcShotCharW
+	}
+}
+
+// atcColumnWidths sizes each column in characters to fit maxChars
+func atcColumnWidths(t atcTable, maxChars int) []int {
+	widths := make([]int, len(t.Columns))
+	for i, col := range t.Columns {
+		widths[i] = len([]rune(col))
+		for _, row := range t.Rows {
+			if i < len(row.Cells) {
+				widths[i] = max(widths[i], len([]rune(row.Cells[i])))
+			}
+		}
+		widths[i] = min(widths[i], atcShotMaxCell)
+	}
+	for {
+		total := 0
+		widest := 0
+		for i, w := range widths {
+			total += w + atcShotColGap
+			if w > widths[widest] {
+				widest = i
+			}
+		}
+		if total-atcShotColGap <= maxChars || widths[widest] <= 4 {
+			return widths
+		}
+		widths[widest]--
+	}
+}
+
+// atcFit cuts s to n characters, marking the cut with "..."
+func atcFit(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	if n <= 3 {
+		return string(r[:n])
+	}
+	return string(r[:n-3]) + "..."
+}
+
+// atcShotScale is how much the layout is enlarged for a w x h image
+func atcShotScale(w, h int) int {
+	return max(1, min(w/atcShotBaseW, h/atcShotBaseH))
+}
+
+type atcPNGCanvas struct {
+	img    *image.RGBA
+	drawer *font.Drawer
+}
+
+func (p *atcPNGCanvas) rect(x, y, w, h int, c color.RGBA) {
+	b := image.Rect(x, y, x+w, y+h).Intersect(p.img.Bounds())
+	for py := b.Min.Y; py < b.Max.Y; py++ {
+		for px := b.Min.X; px < b.Max.X; px++ {
+			p.img.SetRGBA(px, py, c)
+		}
+	}
+}
+
+func (p *atcPNGCanvas) text(x, baseline int, s string, c color.RGBA) {
+	p.drawer.Src = image.NewUniform(c)
+	p.drawer.Dot = fixed.P(x, baseline)
+	p.drawer.DrawString(s)
+}
+
+// renderATCPNG draws the snapshot at w/scale x h/scale and enlarges it,
+// pixel for pixel, to w x h
+func renderATCPNG(snap atcSnapshot, w, h int) ([]byte, error) {
+	scale := atcShotScale(w, h)
+	lw, lh := (w+scale-1)/scale, (h+scale-1)/scale
+
+	small := image.NewRGBA(image.Rect(0, 0, lw, lh))
+	for y := 0; y < lh; y++ {
+		bg := atcLerp(atcShotBgTop, atcShotBgBottom, float64(y)/float64(lh))
+		for x := 0; x < lw; x++ {
+			small.SetRGBA(x, y, bg)
+		}
+	}
+	canvas := &atcPNGCanvas{img: small, drawer: &font.Drawer{Dst: small, Face: basicfont.Face7x13}}
+	drawATCSnapshot(canvas, snap, lw, lh)
+
+	img := small
+	if scale > 1 {
+		img = image.NewRGBA(image.Rect(0, 0, w, h))
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				img.SetRGBA(x, y, small.RGBAAt(x/scale, y/scale))
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func atcLerp(a, b color.RGBA, t float64) color.RGBA {
+	mix := func(x, y uint8) uint8 { return uint8(float64(x) + (float64(y)-float64(x))*t) }
+	return color.RGBA{mix(a.R, b.R), mix(a.G, b.G), mix(a.B, b.B), 0xff}
+}
+
+type atcSVGCanvas struct {
+	buf bytes.Buffer
+}
+
+func (s *atcSVGCanvas) rect(x, y, w, h int, c color.RGBA) {
+	fmt.Fprintf(&s.buf, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n", x, y, w, h, atcHex(c))
+}
+
+func (s *atcSVGCanvas) text(x, baseline int, str string, c color.RGBA) {
+	fmt.Fprintf(&s.buf, `<text x="%d" y="%d" fill="%s">`, x, baseline, atcHex(c))
+	xml.EscapeText(&s.buf, []byte(str))
+	s.buf.WriteString("</text>\n")
+}
+
+// renderATCSVG writes the same layout as renderATCPNG as vector text, with
+// the scale applied through the viewBox
+func renderATCSVG(snap atcSnapshot, w, h int) []byte {
+	scale := atcShotScale(w, h)
+	lw, lh := (w+scale-1)/scale, (h+scale-1)/scale
+
+	s := &atcSVGCanvas{}
+	fmt.Fprintf(&s.buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="monospace" font-size="12">`+"\n", w, h, lw, lh)
+	fmt.Fprintf(&s.buf, `<defs><linearGradient id="bg" x1="0" y1="0" x2="0" y2="1"><stop offset="0" stop-color="%s"/><stop offset="1" stop-color="%s"/></linearGradient></defs>`+"\n",
+		atcHex(atcShotBgTop), atcHex(atcShotBgBottom))
+	fmt.Fprintf(&s.buf, `<rect width="%d" height="%d" fill="url(#bg)"/>`+"\n", lw, lh)
+	drawATCSnapshot(s, snap, lw, lh)
+	s.buf.WriteString("</svg>\n")
+	return s.buf.Bytes()
+}
+
+func atcHex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// atcScreenshotHandler serves /screenshot?format=png|svg&width=&height=,
+// an image of the dashboard's last broadcast state
+func atcScreenshotHandler(hub *Hub) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		width, err := atcShotDimension(q.Get("width"), 1920, 320, 7680)
+		if err != nil {
+			http.Error(w, "width: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		height, err := atcShotDimension(q.Get("height"), 1080, 240, 4320)
+		if err != nil {
+			http.Error(w, "height: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		format := strings.ToLower(q.Get("format"))
+		if format == "" {
+			format = "png"
+		}
+		if format != "png" && format != "svg" {
+			http.Error(w, "format must be png or svg", http.StatusBadRequest)
+			return
+		}
+
+		msg := hub.getLastData()
+		if msg == nil {
+			http.Error(w, "no dashboard data yet", http.StatusServiceUnavailable)
+			return
+		}
+		snap := atcSnapshotFrom(msg)
+
+		filename := fmt.Sprintf("atc-%s-%s.%s", msg.Mode, time.Now().Format("20060102-150405"), format)
+		w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", filename))
+		if format == "svg" {
+			w.Header().Set("Content-Type", "image/svg+xml")
+			w.Write(renderATCSVG(snap, width, height))
+			return
+		}
+		data, err := renderATCPNG(snap, width, height)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(data)
+	}
+}
+
+func atcShotDimension(s string, def, lo, hi int) (int, error) {
+	if s == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < lo || n > hi {
+		return 0, fmt.Errorf("must be a number from %d to %d", lo, hi)
+	}
+	return n, nil
+}
@@ -0,0 +1,207 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// svgElement is an SVG element seen while decoding, with its text content
+type svgElement struct {
+	name  string
+	attrs map[string]string
+	text  string
+}
+
+// parseSVG decodes the document, failing the test if it isn't well-formed
+func parseSVG(t *testing.T, data []byte) []svgElement {
+	t.Helper()
+	var elems []svgElement
+	open := -1 // element the next character data belongs to
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	dec.Strict = true
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return elems
+		}
+		if err != nil {
+			t.Fatalf("SVG is not well-formed: %v\n%s", err, data)
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			e := svgElement{name: tok.Name.Local, attrs: map[string]string{}}
+			for _, a := range tok.Attr {
+				e.attrs[a.Name.Local] = a.Value
+			}
+			elems = append(elems, e)
+			open = len(elems) - 1
+		case xml.EndElement:
+			open = -1
+		case xml.CharData:
+			if open >= 0 {
+				elems[open].text += string(tok)
+			}
+		}
+	}
+}
+
+func svgTexts(elems []svgElement) []string {
+	var texts []string
+	for _, e := range elems {
+		if e.name == "text" {
+			texts = append(texts, e.text)
+		}
+	}
+	return texts
+}
+
+func TestRenderATCSVG(t *testing.T) {
+	snap := atcSnapshotFrom(&ATCMessage{
+		Mode: "planning",
+		Time: "2026-03-01T12:00:00Z",
+		Data: []IssuePacket{
+			{Repo: "me/api", Number: 4, Title: `<script>alert("x")</script> & 'co'`, Velocity: 500, DecayedVelocity: 400, Labels: []string{"P0"}},
+			{Repo: "me/web", Number: 9, Title: "fine", IsPR: true},
+		},
+	})
+	data := renderATCSVG(snap, 1920, 1080)
+	elems := parseSVG(t, data)
+
+	root := elems[0]
+	if root.name != "svg" || root.attrs["width"] != "1920" || root.attrs["height"] != "1080" || root.attrs["viewBox"] != "0 0 960 540" {
+		t.Errorf("root = %s %v; want a 1920x1080 svg with a scale 2 viewBox", root.name, root.attrs)
+	}
+	texts := svgTexts(elems)
+	want := []string{"ATC Tower - Planning", "planning | 2026-03-01 12:00:00", "LEADERBOARD",
+		"#", "Issue", "Title", "Velocity", "Decayed", "Labels",
+		"1", "me/api#4", `<script>alert("x")</script> & 'co'`, "500", "400", "P0",
+		"2", "me/web#9 (PR)", "fine", "0", "0", ""}
+	if fmt.Sprintf("%q", texts) != fmt.Sprintf("%q", want) {
+		t.Errorf("texts = %q\nwant %q", texts, want)
+	}
+	if bytes.Contains(data, []byte("<script>")) {
+		t.Error("issue title was written unescaped")
+	}
+}
+
+func TestRenderATCSVGTruncatesRows(t *testing.T) {
+	var issues []IssuePacket
+	for i := 0; i < 100; i++ {
+		issues = append(issues, IssuePacket{Repo: "me/api", Number: i, Title: strings.Repeat("long title ", 20)})
+	}
+	elems := parseSVG(t, renderATCSVG(atcSnapshotFrom(&ATCMessage{Mode: "planning", Data: issues}), 960, 540))
+
+	var more string
+	for _, s := range svgTexts(elems) {
+		if strings.HasPrefix(s, "... and ") {
+			more = s
+		}
+		if n := len([]rune(s)); n > atcShotMaxCell {
+			t.Errorf("cell %q is %d characters; want at most %d", s, n, atcShotMaxCell)
+		}
+	}
+	if more == "" {
+		t.Fatal("100 rows should not fit without an \"and N more\" line")
+	}
+	// Every text stays inside the viewBox
+	for _, e := range elems {
+		if y, _ := strconv.Atoi(e.attrs["y"]); e.name == "text" && y > 540 {
+			t.Errorf("text %q drawn at y=%s, below the image", e.text, e.attrs["y"])
+		}
+	}
+}
+
+func TestRenderATCPNG(t *testing.T) {
+	snap := atcSnapshotFrom(&ATCMessage{Mode: "active", Data: []HostStatus{{Name: "gpu", Online: true, Models: []string{"llama3"}}}})
+	for _, size := range [][2]int{{1920, 1080}, {1000, 700}, {320, 240}} {
+		data, err := renderATCPNG(snap, size[0], size[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		img, err := png.Decode(bytes.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b := img.Bounds(); b.Dx() != size[0] || b.Dy() != size[1] {
+			t.Errorf("renderATCPNG(%dx%d) is %dx%d", size[0], size[1], b.Dx(), b.Dy())
+		}
+	}
+}
+
+func TestATCFit(t *testing.T) {
+	tests := []struct {
+		s    string
+		n    int
+		want string
+	}{
+		{"short", 10, "short"},
+		{"exactly", 7, "exactly"},
+		{"truncated text", 8, "trunc..."},
+		{"héllo wörld", 8, "héllo..."},
+		{"abcdef", 3, "abc"},
+	}
+	for _, tt := range tests {
+		if got := atcFit(tt.s, tt.n); got != tt.want {
+			t.Errorf("atcFit(%q, %d) = %q; want %q", tt.s, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestATCColumnWidths(t *testing.T) {
+	table := atcTable{
+		Columns: []string{"Host", "Models"},
+		Rows:    []atcRow{{Cells: []string{"gpu", strings.Repeat("m", 100)}}},
+	}
+	if got := atcColumnWidths(table, 200); fmt.Sprint(got) != fmt.Sprint([]int{4, atcShotMaxCell}) {
+		t.Errorf("wide canvas widths = %v", got)
+	}
+	// The widest column gives way until the row fits
+	if got := atcColumnWidths(table, 30); fmt.Sprint(got) != "[4 24]" {
+		t.Errorf("narrow canvas widths = %v; want [4 24]", got)
+	}
+}
+
+func TestATCScreenshotHandler(t *testing.T) {
+	hub := newHub()
+	handler := atcScreenshotHandler(hub)
+	get := func(query string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest(http.MethodGet, "/screenshot"+query, nil))
+		return rec
+	}
+
+	if rec := get(""); rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("no data: status %d; want 503", rec.Code)
+	}
+
+	hub.lastData = &ATCMessage{Mode: "active", Data: []HostStatus{{Name: "gpu"}}}
+	tests := []struct {
+		query       string
+		code        int
+		contentType string
+	}{
+		{"", http.StatusOK, "image/png"},
+		{"?format=SVG&width=800&height=600", http.StatusOK, "image/svg+xml"},
+		{"?format=gif", http.StatusBadRequest, ""},
+		{"?width=10", http.StatusBadRequest, ""},
+		{"?height=wide", http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		rec := get(tt.query)
+		if rec.Code != tt.code {
+			t.Errorf("GET %q: status %d; want %d", tt.query, rec.Code, tt.code)
+			continue
+		}
+		if tt.contentType != "" && rec.Header().Get("Content-Type") != tt.contentType {
+			t.Errorf("GET %q: Content-Type %q; want %q", tt.query, rec.Header().Get("Content-Type"), tt.contentType)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"database/sql"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/dirtybirdnj/clood/internal/sqlite"
+)
+
+// atcStoreCapacity is how many catfight events atc.db keeps; older rows
+// are trimmed on insert so the table behaves as a ring buffer.
+const atcStoreCapacity = 1000
+
+// atcStoreMigrations is the versioned schema for atc.db.
+// Append new entries; never edit existing ones.
+var atcStoreMigrations = []string{
+	`CREATE TABLE atc_events (
+		id        INTEGER PRIMARY KEY AUTOINCREMENT,
+		type      TEXT NOT NULL,
+		timestamp TEXT NOT NULL,
+		data      TEXT NOT NULL
+	);`,
+}
+
+// atcEventStore persists catfight events for `clood atc --persist` so a
+// reloaded browser or a second dashboard can replay history.
+type atcEventStore struct {
+	db *sql.DB
+}
+
+// atcStorePath returns ~/.clood/atc.db
+func atcStorePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".clood", "atc.db"), nil
+}
+
+func openATCEventStore(path string) (*atcEventStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	db, err := sqlite.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sqlite.Migrate(db, atcStoreMigrations); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &atcEventStore{db: db}, nil
+}
+
+func (s *atcEventStore) Close() error {
+	return s.db.Close()
+}
+
+// Add appends an event and trims the table to atcStoreCapacity rows
+func (s *atcEventStore) Add(event CatfightEvent) error {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.db.Exec(`INSERT INTO atc_events (type, timestamp, data) VALUES (?, ?, ?)`,
+		event.Type, event.Timestamp, string(data))
+	if err != nil {
+		return err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`DELETE FROM atc_events WHERE id <= ?`, id-atcStoreCapacity)
+	return err
+}
+
+// Recent returns the last n events, oldest first
+func (s *atcEventStore) Recent(n int) ([]CatfightEvent, error) {
+	rows, err := s.db.Query(`SELECT type, timestamp, data FROM atc_events ORDER BY id DESC LIMIT ?`, n)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []CatfightEvent
+	for rows.Next() {
+		var event CatfightEvent
+		var data string
+		if err := rows.Scan(&event.Type, &event.Timestamp, &data); err != nil {
+			return nil, err
+		}
+		json.Unmarshal([]byte(data), &event.Data)
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Reverse into chronological order
+	for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+		events[i], events[j] = events[j], events[i]
+	}
+	return events, nil
+}
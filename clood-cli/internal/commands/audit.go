@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/dirtybirdnj/clood/internal/audit"
+	"github.com/dirtybirdnj/clood/internal/output"
+	"github.com/dirtybirdnj/clood/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// AuditCmd groups views over clood's decision logs
+func AuditCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Inspect decisions clood made on your agent's behalf",
+	}
+
+	cmd.AddCommand(auditSearchGateCmd())
+
+	return cmd
+}
+
+func auditSearchGateCmd() *cobra.Command {
+	var tail int
+
+	cmd := &cobra.Command{
+		Use:   "search-gate",
+		Short: "Show recent clood_should_search_web verdicts",
+		Long: `Shows the most recent decisions of the MCP clood_should_search_web gate:
+the query, the verdict, and the phrase that triggered it. Use it to spot
+misclassified queries when tuning the pattern lists.
+
+Decisions are logged to ~/.clood/search_gate.jsonl, rotated at 10 MB.
+
+Examples:
+  clood audit search-gate
+  clood audit search-gate --tail 200 -j`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := audit.SearchGatePath()
+			if err != nil {
+				return err
+			}
+			decisions, err := audit.TailSearchGate(path, tail)
+			if err != nil {
+				return fmt.Errorf("reading %s: %w", path, err)
+			}
+
+			if output.IsJSON() {
+				if decisions == nil {
+					decisions = []audit.SearchGateDecision{}
+				}
+				return output.JSON(decisions)
+			}
+
+			fmt.Println(tui.RenderHeader("Search Gate Decisions"))
+			fmt.Println()
+
+			if len(decisions) == 0 {
+				fmt.Println(tui.MutedStyle.Render("  No decisions logged yet (" + path + ")"))
+				return nil
+			}
+
+			for _, d := range decisions {
+				verdict := d.Verdict
+				switch d.Verdict {
+				case "WEB_SEARCH_APPROVED":
+					verdict = tui.WarningStyle.Render("WEB  ")
+				case "USE_LOCAL_LLM":
+					verdict = tui.AccentStyle.Render("LLM  ")
+				case "USE_LOCAL_TOOL":
+					verdict = tui.SuccessStyle.Render("LOCAL")
+				}
+
+				matched := "no pattern"
+				if d.Pattern != "" {
+					matched = fmt.Sprintf("%q", d.Pattern)
+				}
+				if d.Tool != "" {
+					matched += " → " + d.Tool
+				}
+
+				fmt.Printf("  %s %s %s\n", tui.MutedStyle.Render(d.Time.Local().Format("01-02 15:04:05")), verdict, d.Query)
+				fmt.Printf("%23s%s\n", "", tui.MutedStyle.Render(matched))
+			}
+
+			fmt.Println()
+			fmt.Println(tui.MutedStyle.Render(fmt.Sprintf("  %d decisions from %s", len(decisions), path)))
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&tail, "tail", 50, "Number of recent decisions to show")
+
+	return cmd
+}
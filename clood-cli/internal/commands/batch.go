@@ -119,14 +119,14 @@ Examples:
 				return fmt.Errorf("no inputs to process")
 			}
 
-			if !output.JSONMode {
+			if !output.IsJSON() {
 				fmt.Println(tui.RenderHeader("BATCH"))
 				fmt.Println()
 				fmt.Printf("  %s %d inputs\n", tui.MutedStyle.Render("Processing:"), len(inputs))
 			}
 
 			if dryRun {
-				if !output.JSONMode {
+				if !output.IsJSON() {
 					fmt.Println(tui.ErrorStyle.Render("\n  DRY RUN - showing first 3 inputs:\n"))
 					for i := 0; i < 3 && i < len(inputs); i++ {
 						fmt.Printf("  [%s] %s\n", inputs[i].ID, truncateTitle(inputs[i].Prompt, 60))
@@ -159,7 +159,7 @@ Examples:
 				return fmt.Errorf("no online Ollama hosts found")
 			}
 
-			if !output.JSONMode {
+			if !output.IsJSON() {
 				fmt.Printf("  %s %s on %s\n\n",
 					tui.AccentStyle.Render(""),
 					selectedModel,
@@ -173,7 +173,7 @@ Examples:
 			summary := BatchSummary{Total: len(inputs)}
 
 			for i, input := range inputs {
-				if !output.JSONMode {
+				if !output.IsJSON() {
 					fmt.Printf("\r  Processing %d/%d...", i+1, len(inputs))
 				}
 
@@ -205,7 +205,7 @@ Examples:
 				summary.AvgTime = summary.TotalTime / float64(summary.Successful)
 			}
 
-			if !output.JSONMode {
+			if !output.IsJSON() {
 				fmt.Print("\r                              \r")
 			}
 
@@ -232,7 +232,7 @@ Examples:
 					encoder.Encode(r)
 				}
 
-				if !output.JSONMode {
+				if !output.IsJSON() {
 					fmt.Printf("  %s Wrote %d results to %s\n",
 						tui.SuccessStyle.Render(""),
 						len(results),
@@ -240,8 +240,8 @@ Examples:
 				}
 			}
 
-			if output.JSONMode {
-				data, _ := json.MarshalIndent(batchOutput, "", "  ")
+			if output.IsJSON() {
+				data, _ := output.Marshal(batchOutput)
 				fmt.Println(string(data))
 				return nil
 			}
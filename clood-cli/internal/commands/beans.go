@@ -120,8 +120,8 @@ func beansAddCmd() *cobra.Command {
 				return err
 			}
 
-			if output.JSONMode {
-				data, _ := json.MarshalIndent(bean, "", "  ")
+			if output.IsJSON() {
+				data, _ := output.Marshal(bean)
 				fmt.Println(string(data))
 			} else {
 				fmt.Println(tui.SuccessStyle.Render(fmt.Sprintf("🫘 Bean #%d planted", bean.ID)))
@@ -178,8 +178,8 @@ func beansShowCmd() *cobra.Command {
 
 			for _, bean := range garden.Beans {
 				if bean.ID == id {
-					if output.JSONMode {
-						data, _ := json.MarshalIndent(bean, "", "  ")
+					if output.IsJSON() {
+						data, _ := output.Marshal(bean)
 						fmt.Println(string(data))
 					} else {
 						renderBeanDetail(bean)
@@ -230,8 +230,8 @@ Example:
 						return err
 					}
 
-					if output.JSONMode {
-						data, _ := json.MarshalIndent(garden.Beans[i], "", "  ")
+					if output.IsJSON() {
+						data, _ := output.Marshal(garden.Beans[i])
 						fmt.Println(string(data))
 					} else {
 						fmt.Println(tui.SuccessStyle.Render(fmt.Sprintf("⭐ Bean #%d forged into issue #%d", id, issueNum)))
@@ -276,8 +276,8 @@ func beansPruneCmd() *cobra.Command {
 						return err
 					}
 
-					if output.JSONMode {
-						data, _ := json.MarshalIndent(garden.Beans[i], "", "  ")
+					if output.IsJSON() {
+						data, _ := output.Marshal(garden.Beans[i])
 						fmt.Println(string(data))
 					} else {
 						fmt.Println(tui.MutedStyle.Render(fmt.Sprintf("🗑️  Bean #%d pruned", id)))
@@ -337,8 +337,8 @@ func beansEditCmd() *cobra.Command {
 						return err
 					}
 
-					if output.JSONMode {
-						data, _ := json.MarshalIndent(garden.Beans[i], "", "  ")
+					if output.IsJSON() {
+						data, _ := output.Marshal(garden.Beans[i])
 						fmt.Println(string(data))
 					} else {
 						fmt.Println(tui.SuccessStyle.Render(fmt.Sprintf("🫘 Bean #%d updated", id)))
@@ -416,8 +416,8 @@ func listBeans(showPruned bool) error {
 		}
 	}
 
-	if output.JSONMode {
-		data, _ := json.MarshalIndent(beans, "", "  ")
+	if output.IsJSON() {
+		data, _ := output.Marshal(beans)
 		fmt.Println(string(data))
 		return nil
 	}
@@ -1,20 +1,22 @@
 package commands
 
 import (
-	"encoding/json"
 	"fmt"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/dirtybirdnj/clood/internal/config"
 	"github.com/dirtybirdnj/clood/internal/hosts"
 	"github.com/dirtybirdnj/clood/internal/ollama"
+	"github.com/dirtybirdnj/clood/internal/output"
+	"github.com/dirtybirdnj/clood/internal/system"
 	"github.com/dirtybirdnj/clood/internal/tui"
 	"github.com/spf13/cobra"
 )
 
 func BenchCmd() *cobra.Command {
-	var jsonOutput bool
 	var hostName string
 	var prompt string
 
@@ -26,8 +28,12 @@ func BenchCmd() *cobra.Command {
   - Time to first token (latency)
   - Total generation time
 
-If no model is specified, benchmarks the default fast tier model.`,
+If no model is specified, benchmarks the default fast tier model.
+
+Every run is recorded to ~/.clood/bench.db. Use 'clood bench history'
+to see tokens/sec trends per model and spot regressions.`,
 		Run: func(cmd *cobra.Command, args []string) {
+			jsonOutput := output.IsJSON()
 			cfg, err := config.Load()
 			if err != nil {
 				fmt.Println(tui.ErrorStyle.Render("Error loading config: " + err.Error()))
@@ -44,35 +50,10 @@ If no model is specified, benchmarks the default fast tier model.`,
 			mgr := hosts.NewManager()
 			mgr.AddHosts(cfg.Hosts)
 
-			var targetHost *hosts.HostStatus
-			if hostName != "" {
-				host := mgr.GetHost(hostName)
-				if host == nil {
-					fmt.Println(tui.ErrorStyle.Render("Host not found: " + hostName))
-					return
-				}
-				targetHost = mgr.CheckHost(host)
-				if !targetHost.Online {
-					fmt.Println(tui.ErrorStyle.Render("Host is offline: " + hostName))
-					return
-				}
-			} else {
-				// Find best host with this model
-				found := mgr.FindModel(model)
-				if len(found) == 0 {
-					// Try any online host
-					targetHost = mgr.GetBestHost()
-					if targetHost == nil {
-						fmt.Println(tui.ErrorStyle.Render("No online hosts found"))
-						return
-					}
-					fmt.Printf("%s Model %s not found, trying on %s anyway\n",
-						tui.MutedStyle.Render("Warning:"),
-						model,
-						targetHost.Host.Name)
-				} else {
-					targetHost = found[0]
-				}
+			targetHost, err := findBenchHost(mgr, hostName, model)
+			if err != nil {
+				fmt.Println(tui.ErrorStyle.Render(err.Error()))
+				return
 			}
 
 			// Default prompt
@@ -94,6 +75,8 @@ If no model is specified, benchmarks the default fast tier model.`,
 				return
 			}
 
+			recordBenchRun(result, targetHost, prompt)
+
 			if jsonOutput {
 				printBenchJSON(result, targetHost)
 				return
@@ -103,13 +86,221 @@ If no model is specified, benchmarks the default fast tier model.`,
 		},
 	}
 
-	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 	cmd.Flags().StringVarP(&hostName, "host", "H", "", "Run on specific host")
 	cmd.Flags().StringVarP(&prompt, "prompt", "p", "", "Custom prompt for benchmark")
 
+	cmd.AddCommand(benchHistoryCmd())
+	cmd.AddCommand(benchQualityCmd())
+
 	return cmd
 }
 
+// findBenchHost picks hostName if given, else the best host with model,
+// else any online host (with a warning)
+func findBenchHost(mgr *hosts.Manager, hostName, model string) (*hosts.HostStatus, error) {
+	if hostName != "" {
+		host := mgr.GetHost(hostName)
+		if host == nil {
+			return nil, fmt.Errorf("host not found: %s", hostName)
+		}
+		status := mgr.CheckHost(host)
+		if !status.Online {
+			return nil, fmt.Errorf("host is offline: %s", hostName)
+		}
+		return status, nil
+	}
+
+	// Find best host with this model
+	if found := mgr.FindModel(model); len(found) > 0 {
+		return found[0], nil
+	}
+
+	// Try any online host
+	status := mgr.GetBestHost()
+	if status == nil {
+		return nil, fmt.Errorf("no online hosts found")
+	}
+	fmt.Printf("%s Model %s not found, trying on %s anyway\n",
+		tui.MutedStyle.Render("Warning:"),
+		model,
+		status.Host.Name)
+	return status, nil
+}
+
+// recordBenchRun appends the run to the bench history database.
+// Failures are reported but never fail the benchmark itself.
+func recordBenchRun(result *ollama.BenchmarkResult, host *hosts.HostStatus, prompt string) {
+	path, err := system.BenchHistoryPath()
+	if err != nil {
+		return
+	}
+	history, err := system.OpenBenchHistory(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s could not record bench history: %v\n", tui.MutedStyle.Render("Warning:"), err)
+		return
+	}
+	defer history.Close()
+
+	err = history.Record(system.BenchRun{
+		Host:         host.Host.Name,
+		Model:        result.Model,
+		PromptHash:   system.HashPrompt(prompt),
+		PromptTokens: result.PromptTokens,
+		Tokens:       result.GeneratedTokens,
+		DurationMs:   result.TotalDuration.Milliseconds(),
+		TokPerSec:    result.GenerateTokPerSec,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s could not record bench history: %v\n", tui.MutedStyle.Render("Warning:"), err)
+	}
+}
+
+func benchHistoryCmd() *cobra.Command {
+	var model string
+	var hostName string
+	var limit int
+	var quality bool
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Show benchmark trends from the history database",
+		Long: `Shows recorded benchmark runs grouped by model and host, with a
+tokens/sec trend line and the change from first to latest run.
+
+Examples:
+  clood bench history
+  clood bench history --model qwen2.5-coder:3b
+  clood bench history --host ubuntu25 --limit 10
+  clood bench history --quality    # 'clood bench quality' scores
+  clood bench history --json`,
+		Run: func(cmd *cobra.Command, args []string) {
+			path, err := system.BenchHistoryPath()
+			if err != nil {
+				fmt.Println(tui.ErrorStyle.Render("Error: " + err.Error()))
+				return
+			}
+			history, err := system.OpenBenchHistory(path)
+			if err != nil {
+				fmt.Println(tui.ErrorStyle.Render("Error opening bench history: " + err.Error()))
+				return
+			}
+			defer history.Close()
+
+			if quality {
+				printQualityHistory(history, model, hostName, limit)
+				return
+			}
+
+			runs, err := history.Runs(model, hostName, 0)
+			if err != nil {
+				fmt.Println(tui.ErrorStyle.Render("Error reading bench history: " + err.Error()))
+				return
+			}
+
+			// Group by model@host, preserving first-seen order
+			type series struct {
+				Model string            `json:"model"`
+				Host  string            `json:"host"`
+				Runs  []system.BenchRun `json:"runs"`
+			}
+			var order []string
+			grouped := make(map[string]*series)
+			for _, r := range runs {
+				key := r.Model + "@" + r.Host
+				if grouped[key] == nil {
+					grouped[key] = &series{Model: r.Model, Host: r.Host}
+					order = append(order, key)
+				}
+				grouped[key].Runs = append(grouped[key].Runs, r)
+			}
+			for _, s := range grouped {
+				if limit > 0 && len(s.Runs) > limit {
+					s.Runs = s.Runs[len(s.Runs)-limit:]
+				}
+			}
+
+			if output.IsJSON() {
+				result := make([]*series, 0, len(order))
+				for _, key := range order {
+					result = append(result, grouped[key])
+				}
+				output.MustJSON(result)
+				return
+			}
+
+			fmt.Println(tui.RenderHeader("Benchmark History"))
+			fmt.Println()
+
+			if len(order) == 0 {
+				fmt.Println(tui.MutedStyle.Render("  No benchmark runs recorded yet. Run 'clood bench' first."))
+				return
+			}
+
+			for _, key := range order {
+				s := grouped[key]
+				first := s.Runs[0]
+				last := s.Runs[len(s.Runs)-1]
+
+				fmt.Printf("  %s %s\n", tui.HeaderStyle.Render(s.Model), tui.MutedStyle.Render("@ "+s.Host))
+
+				values := make([]float64, len(s.Runs))
+				for i, r := range s.Runs {
+					values[i] = r.TokPerSec
+				}
+				fmt.Printf("    Trend:   %s\n", benchSparkline(values))
+				fmt.Printf("    Latest:  %s tok/s  (%s)\n", formatTokPerSec(last.TokPerSec),
+					last.RecordedAt.Local().Format("2006-01-02 15:04"))
+
+				if len(s.Runs) > 1 && first.TokPerSec > 0 {
+					change := (last.TokPerSec - first.TokPerSec) / first.TokPerSec * 100
+					changeStr := fmt.Sprintf("%+.1f%%", change)
+					if change <= -10 {
+						changeStr = tui.ErrorStyle.Render(changeStr + " regression")
+					} else if change >= 10 {
+						changeStr = tui.SuccessStyle.Render(changeStr)
+					}
+					fmt.Printf("    Change:  %s since %s (%d runs)\n", changeStr,
+						first.RecordedAt.Local().Format("2006-01-02"), len(s.Runs))
+				}
+				fmt.Println()
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&model, "model", "m", "", "Only show this model")
+	cmd.Flags().StringVarP(&hostName, "host", "H", "", "Only show this host")
+	cmd.Flags().IntVarP(&limit, "limit", "n", 20, "Most recent runs per model/host (0 = all)")
+	cmd.Flags().BoolVar(&quality, "quality", false, "Show quality scores instead of tokens/sec")
+
+	return cmd
+}
+
+// benchSparkline renders values as a compact unicode bar chart
+func benchSparkline(values []float64) string {
+	bars := []rune("▁▂▃▄▅▆▇█")
+	if len(values) == 0 {
+		return ""
+	}
+	min, max := values[0], values[0]
+	for _, v := range values {
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+	var sb strings.Builder
+	for _, v := range values {
+		idx := len(bars) - 1
+		if max > min {
+			idx = int((v - min) / (max - min) * float64(len(bars)-1))
+		}
+		sb.WriteRune(bars[idx])
+	}
+	return sb.String()
+}
+
 func printBenchResult(result *ollama.BenchmarkResult, host *hosts.HostStatus) {
 	fmt.Println(tui.RenderHeader("Benchmark Results"))
 	fmt.Println()
@@ -178,6 +369,6 @@ func printBenchJSON(result *ollama.BenchmarkResult, host *hosts.HostStatus) {
 		"gen_tok_per_sec":    result.GenerateTokPerSec,
 	}
 
-	out, _ := json.MarshalIndent(data, "", "  ")
+	out, _ := output.Marshal(data)
 	fmt.Println(string(out))
 }
@@ -0,0 +1,269 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dirtybirdnj/clood/internal/config"
+	"github.com/dirtybirdnj/clood/internal/hosts"
+	"github.com/dirtybirdnj/clood/internal/memory"
+	"github.com/dirtybirdnj/clood/internal/ollama"
+	"github.com/dirtybirdnj/clood/internal/output"
+	"github.com/dirtybirdnj/clood/internal/system"
+	"github.com/dirtybirdnj/clood/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+func benchQualityCmd() *cobra.Command {
+	var promptsPath string
+	var hostName string
+	var embedModel string
+	var threshold float64
+
+	cmd := &cobra.Command{
+		Use:   "quality [model]",
+		Short: "Score a model's answers against a prompt set",
+		Long: `Runs every prompt in a JSON prompt set and checks each response
+against its expected answer:
+
+  [{"prompt": "What is 2+2?", "expected": "\\b4\\b", "match": "regex"},
+   {"prompt": "Capital of France?", "expected": "Paris"},
+   {"prompt": "Why is the sky blue?", "expected": "Rayleigh scattering of sunlight", "match": "semantic"}]
+
+Match modes:
+  contains   case-insensitive substring (default)
+  regex      Go regular expression
+  semantic   embedding similarity >= --threshold (needs --embed-model)
+
+The aggregate score is recorded to ~/.clood/bench.db next to speed runs.
+See 'clood bench history --quality' for trends.
+
+Examples:
+  clood bench quality --prompts ./benchmarks/quality.json
+  clood bench quality llama3.1:8b --prompts quality.json --host ubuntu25`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			prompts, err := system.LoadQualityPrompts(promptsPath)
+			if err != nil {
+				return err
+			}
+			setHash, err := hashFile(promptsPath)
+			if err != nil {
+				return err
+			}
+
+			cfg, err := config.Load()
+			if err != nil {
+				return fmt.Errorf("loading config: %w", err)
+			}
+
+			model := cfg.Tiers.Fast.Model
+			if len(args) > 0 {
+				model = args[0]
+			}
+
+			mgr := hosts.NewManager()
+			mgr.AddHosts(cfg.Hosts)
+
+			targetHost, err := findBenchHost(mgr, hostName, model)
+			if err != nil {
+				return err
+			}
+
+			embed := qualityEmbedder(mgr, prompts, embedModel)
+
+			if !output.IsJSON() {
+				fmt.Printf("%s %d prompts on %s @ %s\n\n",
+					tui.MutedStyle.Render("Running:"), len(prompts), model, targetHost.Host.Name)
+			}
+
+			client := ollama.NewClient(targetHost.Host.URL, 120*time.Second)
+			start := time.Now()
+			var results []system.QualityResult
+			for i, p := range prompts {
+				var result system.QualityResult
+				resp, err := client.Generate(model, p.Prompt)
+				if err != nil {
+					result = system.QualityResult{Prompt: p.Prompt, Match: p.Match, Error: err.Error()}
+				} else {
+					result = system.ScoreResponse(p, resp.Response, embed, threshold)
+				}
+				results = append(results, result)
+
+				if !output.IsJSON() {
+					printQualityResult(i+1, result)
+				}
+			}
+
+			run := system.NewQualityRun(targetHost.Host.Name, model, setHash, results, time.Since(start))
+			recordQualityRun(run)
+
+			if output.IsJSON() {
+				return output.JSON(run)
+			}
+
+			fmt.Println()
+			scoreStr := fmt.Sprintf("%d/%d (%.0f%%)", run.Passed, run.Total, run.Score*100)
+			switch {
+			case run.Score >= 0.8:
+				scoreStr = tui.SuccessStyle.Render(scoreStr)
+			case run.Score < 0.5:
+				scoreStr = tui.ErrorStyle.Render(scoreStr)
+			}
+			fmt.Printf("  %s %s in %s\n", tui.HeaderStyle.Render("Score:"), scoreStr,
+				time.Duration(run.DurationMs*int64(time.Millisecond)).Round(time.Second))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&promptsPath, "prompts", "p", "", "JSON prompt set to score against (required)")
+	cmd.Flags().StringVarP(&hostName, "host", "H", "", "Run on specific host")
+	cmd.Flags().StringVar(&embedModel, "embed-model", "nomic-embed-text", "Embedding model for semantic matches")
+	cmd.Flags().Float64Var(&threshold, "threshold", system.DefaultSemanticThreshold, "Similarity a semantic match needs to pass")
+	cmd.MarkFlagRequired("prompts")
+
+	return cmd
+}
+
+// qualityEmbedder returns an embedder on whichever host serves model, or
+// nil when no prompt needs one or no host has it
+func qualityEmbedder(mgr *hosts.Manager, prompts []system.QualityPrompt, model string) memory.Embedder {
+	needed := false
+	for _, p := range prompts {
+		needed = needed || p.Match == system.MatchSemantic
+	}
+	if !needed {
+		return nil
+	}
+
+	candidates := []string{model}
+	if !strings.Contains(model, ":") {
+		candidates = append(candidates, model+":latest")
+	}
+	for _, name := range candidates {
+		if found := mgr.FindModel(name); len(found) > 0 {
+			client := mgr.GetClient(found[0].Host.Name)
+			return func(text string) ([]float64, error) {
+				return client.Embeddings(name, text)
+			}
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "%s no online host has %s; semantic prompts will fail (try: ollama pull %s)\n",
+		tui.MutedStyle.Render("Warning:"), model, model)
+	return nil
+}
+
+func printQualityResult(n int, r system.QualityResult) {
+	prompt := strings.Join(strings.Fields(r.Prompt), " ")
+	if len(prompt) > 60 {
+		prompt = prompt[:57] + "..."
+	}
+
+	mark := tui.SuccessStyle.Render("✓")
+	if !r.Passed {
+		mark = tui.ErrorStyle.Render("✗")
+	}
+	detail := r.Match
+	if r.Match == system.MatchSemantic && r.Error == "" {
+		detail = fmt.Sprintf("semantic %.2f", r.Score)
+	}
+	fmt.Printf("  %s %2d. %-60s %s\n", mark, n, prompt, tui.MutedStyle.Render(detail))
+	if r.Error != "" {
+		fmt.Printf("        %s\n", tui.ErrorStyle.Render(r.Error))
+	}
+}
+
+// recordQualityRun appends the run to bench.db, warning on failure
+func recordQualityRun(run system.QualityRun) {
+	path, err := system.BenchHistoryPath()
+	if err != nil {
+		return
+	}
+	history, err := system.OpenBenchHistory(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s could not record bench history: %v\n", tui.MutedStyle.Render("Warning:"), err)
+		return
+	}
+	defer history.Close()
+
+	if err := history.RecordQuality(run); err != nil {
+		fmt.Fprintf(os.Stderr, "%s could not record bench history: %v\n", tui.MutedStyle.Render("Warning:"), err)
+	}
+}
+
+// printQualityHistory is 'bench history --quality': score trends per
+// model@host, newest limit runs each
+func printQualityHistory(history *system.BenchHistory, model, hostName string, limit int) {
+	runs, err := history.QualityRuns(model, hostName, 0)
+	if err != nil {
+		fmt.Println(tui.ErrorStyle.Render("Error reading bench history: " + err.Error()))
+		return
+	}
+
+	type series struct {
+		Model string              `json:"model"`
+		Host  string              `json:"host"`
+		Runs  []system.QualityRun `json:"runs"`
+	}
+	var order []string
+	grouped := make(map[string]*series)
+	for _, r := range runs {
+		key := r.Model + "@" + r.Host
+		if grouped[key] == nil {
+			grouped[key] = &series{Model: r.Model, Host: r.Host}
+			order = append(order, key)
+		}
+		grouped[key].Runs = append(grouped[key].Runs, r)
+	}
+	for _, s := range grouped {
+		if limit > 0 && len(s.Runs) > limit {
+			s.Runs = s.Runs[len(s.Runs)-limit:]
+		}
+	}
+
+	if output.IsJSON() {
+		result := make([]*series, 0, len(order))
+		for _, key := range order {
+			result = append(result, grouped[key])
+		}
+		output.MustJSON(result)
+		return
+	}
+
+	fmt.Println(tui.RenderHeader("Quality History"))
+	fmt.Println()
+
+	if len(order) == 0 {
+		fmt.Println(tui.MutedStyle.Render("  No quality runs recorded yet. Run 'clood bench quality' first."))
+		return
+	}
+
+	for _, key := range order {
+		s := grouped[key]
+		last := s.Runs[len(s.Runs)-1]
+
+		values := make([]float64, len(s.Runs))
+		for i, r := range s.Runs {
+			values[i] = r.Score
+		}
+
+		fmt.Printf("  %s %s\n", tui.HeaderStyle.Render(s.Model), tui.MutedStyle.Render("@ "+s.Host))
+		fmt.Printf("    Trend:   %s\n", benchSparkline(values))
+		fmt.Printf("    Latest:  %d/%d (%.0f%%)  (%s)\n", last.Passed, last.Total, last.Score*100,
+			last.RecordedAt.Local().Format("2006-01-02 15:04"))
+		fmt.Println()
+	}
+}
+
+// hashFile identifies a prompt set by content so edited sets aren't
+// compared with their old selves
+func hashFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return system.HashPrompt(string(data)), nil
+}
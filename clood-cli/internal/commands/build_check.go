@@ -2,7 +2,6 @@ package commands
 
 import (
 	"bytes"
-	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
@@ -32,7 +31,6 @@ type BuildCheckResult struct {
 var goErrorPattern = regexp.MustCompile(`^(.+):(\d+):(\d+): (.+)$`)
 
 func BuildCheckCmd() *cobra.Command {
-	var jsonOutput bool
 
 	cmd := &cobra.Command{
 		Use:   "build-check [path]",
@@ -67,9 +65,9 @@ Examples:
 
 			result := runBuildCheck(path)
 
-			useJSON := jsonOutput || output.IsJSON()
+			useJSON := output.IsJSON()
 			if useJSON {
-				data, _ := json.MarshalIndent(result, "", "  ")
+				data, _ := output.Marshal(result)
 				fmt.Println(string(data))
 				return
 			}
@@ -79,8 +77,6 @@ Examples:
 		},
 	}
 
-	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
-
 	return cmd
 }
 
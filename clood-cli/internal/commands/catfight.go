@@ -14,6 +14,7 @@ import (
 	"github.com/dirtybirdnj/clood/internal/config"
 	"github.com/dirtybirdnj/clood/internal/hosts"
 	"github.com/dirtybirdnj/clood/internal/ollama"
+	"github.com/dirtybirdnj/clood/internal/output"
 	"github.com/dirtybirdnj/clood/internal/system"
 	"github.com/dirtybirdnj/clood/internal/tui"
 	"github.com/spf13/cobra"
@@ -21,21 +22,57 @@ import (
 
 // sendATCEvent sends an event to the ATC dashboard
 func sendATCEvent(atcURL, eventType string, data interface{}) {
+	// Fire and forget - don't block on ATC
+	go postATCEvent(atcURL, eventType, data)
+}
+
+// postATCEvent sends an event and waits for ATC to take it, for events
+// that must land before the command exits
+func postATCEvent(atcURL, eventType string, data interface{}) error {
 	if atcURL == "" {
-		return
+		return nil
 	}
 	event := map[string]interface{}{
 		"type": eventType,
 		"data": data,
 	}
 	body, _ := json.Marshal(event)
-	// Fire and forget - don't block on ATC
-	go func() {
-		resp, err := http.Post(atcURL+"/events", "application/json", bytes.NewReader(body))
-		if err == nil {
-			resp.Body.Close()
-		}
-	}()
+	resp, err := http.Post(atcURL+"/events", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// catfightProgressEvery is how many streamed tokens pass between ATC
+// iteration_progress events
+const catfightProgressEvery = 100
+
+// catfightRateWindow is how many recent token batches the rolling
+// tokens/sec in iteration_progress events averages over
+const catfightRateWindow = 5
+
+// tokenRate tracks when each token batch arrived to compute a rolling
+// tokens/sec that reacts to slowdowns faster than the whole-run average
+type tokenRate struct {
+	marks []time.Time
+}
+
+// mark records a batch boundary and returns the rolling tokens/sec
+func (r *tokenRate) mark(t time.Time) float64 {
+	r.marks = append(r.marks, t)
+	if len(r.marks) > catfightRateWindow+1 {
+		r.marks = r.marks[1:]
+	}
+	if len(r.marks) < 2 {
+		return 0
+	}
+	elapsed := t.Sub(r.marks[0]).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64((len(r.marks)-1)*catfightProgressEvery) / elapsed
 }
 
 // Cat represents a model in the catfight
@@ -67,14 +104,15 @@ type CatfightResult struct {
 
 // CatfightOutput is the full JSON output structure
 type CatfightOutput struct {
-	Timestamp  string           `json:"timestamp"`
-	Prompt     string           `json:"prompt"`
-	PromptFile string           `json:"prompt_file,omitempty"`
-	Hosts      []string         `json:"hosts"`
-	Models     []string         `json:"models"`
-	Results    []CatfightResult `json:"results"`
-	Winner     *CatfightResult  `json:"winner,omitempty"`
-	Summary    CatfightSummary  `json:"summary"`
+	Timestamp  string             `json:"timestamp"`
+	Prompt     string             `json:"prompt"`
+	PromptFile string             `json:"prompt_file,omitempty"`
+	Hosts      []string           `json:"hosts"`
+	Models     []string           `json:"models"`
+	Results    []CatfightResult   `json:"results"`
+	Winner     *CatfightResult    `json:"winner,omitempty"`
+	Summary    CatfightSummary    `json:"summary"`
+	Judgement  *CatfightJudgement `json:"judgement,omitempty"`
 }
 
 // CatfightSummary provides aggregate stats
@@ -97,19 +135,23 @@ var catSpinnerFrames = []string{
 
 func CatfightCmd() *cobra.Command {
 	var promptFile string
+	var promptTemplate string
+	var templateVars []string
 	var models string
 	var outputDir string
 	var host string
 	var hostNames string
 	var allHosts bool
 	var quiet bool
-	var jsonOutput bool
 	var markdownOutput bool
 	var crossHost bool
 	var streamOutput bool
 	var createIssue bool
 	var issueLabels string
 	var atcURL string
+	var tournament bool
+	var promptsPath string
+	var judge string
 
 	cmd := &cobra.Command{
 		Use:   "catfight [prompt]",
@@ -145,11 +187,62 @@ Examples:
   clood catfight --all-hosts --json -f prompt.txt
 
   # Post results to GitHub issue
-  clood catfight --issue "Compare sorting algorithms"`,
+  clood catfight --issue "Compare sorting algorithms"
+
+PROMPT TEMPLATES:
+  --prompt-template FILE is a Go text/template expanded once with --vars
+  key=value (repeatable), so every cat gets the identical prompt. A key
+  also fills its capitalized name: subject= sets {{.Subject}}.
+
+  clood catfight --prompt-template explain.txt --vars subject="quantum entanglement"
+
+JUDGING:
+  The winner is the fastest cat. --judge MODEL also has a local model rank
+  the responses on accuracy, conciseness, code quality and factual
+  correctness, and explain why. The verdict is added to every output
+  format and sent to ATC as an analysis event.
+
+  clood catfight --judge llama3.1:8b "Write a Go function to reverse a slice"
+
+TOURNAMENT:
+  --tournament pairs the models at random in a single-elimination
+  bracket. Each match runs every prompt (--prompts, one per line) and the
+  faster model advances until one is crowned champion. Runs on the first
+  selected host.
+
+  clood catfight --tournament --prompts prompts.txt --models "a,b,c,d"
+  clood catfight --tournament --atc http://localhost:8080 "Write fizzbuzz"`,
 		Run: func(cmd *cobra.Command, args []string) {
+			jsonOutput := output.IsJSON()
 			// Get the prompt
 			var prompt string
-			if promptFile != "" {
+			var tournamentPrompts []string
+			if len(templateVars) > 0 && promptTemplate == "" {
+				fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render("--vars fills a --prompt-template."))
+				return
+			}
+			if promptTemplate != "" && (promptFile != "" || promptsPath != "" || len(args) > 0) {
+				fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render("--prompt-template replaces the prompt; don't combine it with -f, --prompts or a prompt argument."))
+				return
+			}
+
+			if promptTemplate != "" {
+				var err error
+				if prompt, err = expandPromptTemplate(promptTemplate, templateVars); err != nil {
+					fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render("Error in prompt template: "+err.Error()))
+					return
+				}
+			} else if promptsPath != "" {
+				if !tournament {
+					fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render("--prompts is for --tournament; use -f for a single prompt file."))
+					return
+				}
+				var err error
+				if tournamentPrompts, err = loadTournamentPrompts(promptsPath); err != nil {
+					fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render("Error reading prompts: "+err.Error()))
+					return
+				}
+			} else if promptFile != "" {
 				data, err := os.ReadFile(promptFile)
 				if err != nil {
 					fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render("Error reading prompt file: "+err.Error()))
@@ -159,7 +252,7 @@ Examples:
 			} else if len(args) > 0 {
 				prompt = strings.Join(args, " ")
 			} else {
-				fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render("No prompt provided. Use -f <file> or pass prompt as argument."))
+				fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render("No prompt provided. Use -f <file>, --prompt-template <file> or pass prompt as argument."))
 				return
 			}
 
@@ -173,6 +266,14 @@ Examples:
 				}
 			}
 
+			// --output doubles as an export file when given a .json/.md path;
+			// anything else keeps the original per-response directory behavior
+			var exportPath string
+			if isCatfightExportPath(outputDir) {
+				exportPath = outputDir
+				outputDir = ""
+			}
+
 			// Set up output directory
 			if outputDir != "" {
 				if err := os.MkdirAll(outputDir, 0755); err != nil {
@@ -265,6 +366,19 @@ Examples:
 				})
 			}
 
+			if tournament {
+				if judge != "" {
+					fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render("--judge isn't supported with --tournament."))
+					return
+				}
+				if tournamentPrompts == nil {
+					tournamentPrompts = []string{prompt}
+				}
+				hc := hostClients[0]
+				runCatfightTournament(cats, tournamentPrompts, hc.name, hc.client, atcURL, jsonOutput)
+				return
+			}
+
 			// Collect host names and model names for output
 			var hostNameList []string
 			var modelNameList []string
@@ -352,19 +466,39 @@ Examples:
 					var err error
 					var responseBuilder strings.Builder
 
-					if streamOutput && !jsonOutput && !markdownOutput {
-						// Streaming mode with live spinner
-						fmt.Printf("    ")
+					showSpinner := streamOutput && !jsonOutput && !markdownOutput
+					if showSpinner || atcURL != "" {
+						// Streaming mode: live spinner and/or per-batch ATC timing
+						if showSpinner {
+							fmt.Printf("    ")
+						}
 						tokenCount := 0
 						spinnerIdx := 0
 						lastSpinnerUpdate := time.Now()
+						var rate tokenRate
 
 						resp, err = hc.client.GenerateStream(cat.Model, prompt, func(chunk ollama.GenerateResponse) {
 							responseBuilder.WriteString(chunk.Response)
+							if tokenCount == 0 {
+								// Rate is measured from the first token so
+								// model load and prompt eval don't skew it
+								rate.mark(time.Now())
+							}
 							tokenCount++
 
+							if atcURL != "" && tokenCount%catfightProgressEvery == 0 {
+								now := time.Now()
+								sendATCEvent(atcURL, "iteration_progress", map[string]interface{}{
+									"model":       cat.Model,
+									"host":        hc.name,
+									"tokens":      tokenCount,
+									"tokens_sec":  rate.mark(now),
+									"elapsed_sec": now.Sub(start).Seconds(),
+								})
+							}
+
 							// Show spinner animation every 100ms
-							if time.Since(lastSpinnerUpdate) > 100*time.Millisecond {
+							if showSpinner && time.Since(lastSpinnerUpdate) > 100*time.Millisecond {
 								fmt.Printf("\r    %s %s %d tokens...",
 									catSpinnerFrames[spinnerIdx%len(catSpinnerFrames)],
 									tui.MutedStyle.Render("generating"),
@@ -375,7 +509,9 @@ Examples:
 						})
 
 						// Clear the spinner line
-						fmt.Printf("\r                                                    \r")
+						if showSpinner {
+							fmt.Printf("\r                                                    \r")
+						}
 
 						if resp != nil {
 							resp.Response = responseBuilder.String()
@@ -479,6 +615,19 @@ Examples:
 				summary.AverageSpeed = totalSpeed / float64(successful)
 			}
 
+			// Have the judge rank the responses, on the first host
+			var judgement *CatfightJudgement
+			if judge != "" && successful > 0 {
+				if !jsonOutput && !markdownOutput {
+					fmt.Printf("\n%s Judge %s is deliberating...\n", tui.AccentStyle.Render("⚖"), judge)
+				}
+				var err error
+				judgement, err = judgeCatfight(hostClients[0].client, judge, prompt, results)
+				if err != nil {
+					fmt.Fprintln(os.Stderr, tui.WarningStyle.Render("Judging failed: "+err.Error()))
+				}
+			}
+
 			// Send ATC complete event
 			completeData := map[string]interface{}{
 				"successful": successful,
@@ -520,10 +669,45 @@ Examples:
 				}
 				sendATCEvent(atcURL, "analysis", analysisData)
 			}
+			if judgement != nil {
+				sendATCEvent(atcURL, "analysis", map[string]interface{}{
+					"analysis":  judgement.summary(),
+					"rankings":  judgement.rankingLine(),
+					"judge":     judgement.Judge,
+					"reasoning": judgement.Reasoning,
+				})
+			}
+
+			// Export results file (written before any early return below)
+			if exportPath != "" {
+				rows := make([]CatfightExportRow, 0, len(results))
+				for _, r := range results {
+					row := CatfightExportRow{
+						Model:       r.Cat.Model,
+						Cat:         r.Cat.Name,
+						Host:        r.Host,
+						Status:      "ok",
+						DurationSec: r.DurationSec,
+						Tokens:      r.Tokens,
+						TokSec:      r.TokSec,
+						Response:    r.Response,
+					}
+					if r.Error != nil {
+						row.Status = "error"
+						row.Error = r.ErrorStr
+					}
+					rows = append(rows, row)
+				}
+				if err := writeCatfightExport(exportPath, prompt, rows); err != nil {
+					fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render("Error writing export: "+err.Error()))
+				} else if !jsonOutput && !markdownOutput {
+					fmt.Printf("%s %s\n", tui.MutedStyle.Render("Exported:"), exportPath)
+				}
+			}
 
 			// JSON output
 			if jsonOutput {
-				output := CatfightOutput{
+				out := CatfightOutput{
 					Timestamp:  time.Now().Format(time.RFC3339),
 					Prompt:     prompt,
 					PromptFile: promptFile,
@@ -532,8 +716,9 @@ Examples:
 					Results:    results,
 					Winner:     fastest,
 					Summary:    summary,
+					Judgement:  judgement,
 				}
-				data, _ := json.MarshalIndent(output, "", "  ")
+				data, _ := output.Marshal(out)
 				fmt.Println(string(data))
 				return
 			}
@@ -563,6 +748,10 @@ Examples:
 				if fastest != nil {
 					fmt.Printf("\n**Winner:** %s (%s) on %s with %.1fs\n", fastest.Cat.Name, fastest.Cat.Model, fastest.Host, fastest.DurationSec)
 				}
+				if judgement != nil {
+					fmt.Println()
+					fmt.Print(judgement.markdown())
+				}
 				return
 			}
 
@@ -592,6 +781,9 @@ Examples:
 				if fastest != nil {
 					body.WriteString(fmt.Sprintf("\n**Winner:** %s (%s) on %s with %.1fs\n", fastest.Cat.Name, fastest.Cat.Model, fastest.Host, fastest.DurationSec))
 				}
+				if judgement != nil {
+					body.WriteString("\n" + judgement.markdown())
+				}
 
 				// Add responses in collapsible sections
 				body.WriteString("\n---\n\n## Responses\n\n")
@@ -672,6 +864,23 @@ Examples:
 				}
 			}
 
+			if judgement != nil {
+				fmt.Println()
+				fmt.Println(tui.RenderHeader("VERDICT: " + judgement.Judge))
+				fmt.Println()
+				for _, r := range judgement.Ranking {
+					fmt.Printf("  %s %s (%s)", tui.AccentStyle.Render(fmt.Sprintf("%d.", r.Rank)), r.Cat, r.Model)
+					if len(hostClients) > 1 {
+						fmt.Printf(" on %s", r.Host)
+					}
+					fmt.Println()
+				}
+				if len(judgement.Ranking) > 0 {
+					fmt.Println()
+				}
+				fmt.Println(judgement.Reasoning)
+			}
+
 			// Show all responses for easy comparison / LLM consumption
 			fmt.Println()
 			fmt.Println(tui.RenderHeader("RESPONSES"))
@@ -701,19 +910,25 @@ Examples:
 	}
 
 	cmd.Flags().StringVarP(&promptFile, "file", "f", "", "Read prompt from file")
+	cmd.Flags().StringVar(&promptTemplate, "prompt-template", "", "Read prompt from a text/template file filled by --vars")
+	cmd.Flags().StringArrayVar(&templateVars, "vars", nil, "Template variable as key=value (repeatable, with --prompt-template)")
 	cmd.Flags().StringVarP(&models, "models", "m", "", "Comma-separated list of models to compare")
-	cmd.Flags().StringVarP(&outputDir, "output", "o", "", "Directory to save outputs")
+	registerCompletion(cmd, "models", completeModelList)
+	cmd.Flags().StringVarP(&outputDir, "output", "o", "", "Directory to save outputs, or a .json/.md file to export results")
 	cmd.Flags().StringVarP(&host, "host", "H", "", "Ollama host URL (single host)")
 	cmd.Flags().StringVar(&hostNames, "hosts", "", "Comma-separated host names (e.g., ubuntu25,mac-mini)")
 	cmd.Flags().BoolVar(&allHosts, "all-hosts", false, "Run on ALL online hosts in parallel (garden mode)")
 	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Don't show prompt preview")
-	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output results as JSON")
 	cmd.Flags().BoolVar(&markdownOutput, "markdown", false, "Output results as Markdown")
 	cmd.Flags().BoolVar(&crossHost, "cross-host", false, "Compare same model across hosts")
 	cmd.Flags().BoolVarP(&streamOutput, "stream", "s", false, "Show live progress during generation")
 	cmd.Flags().BoolVar(&createIssue, "issue", false, "Create GitHub issue with results")
 	cmd.Flags().StringVar(&issueLabels, "labels", "", "Labels for GitHub issue (requires --issue)")
 	cmd.Flags().StringVar(&atcURL, "atc", "", "ATC dashboard URL for live events (e.g., http://localhost:8080)")
+	cmd.Flags().BoolVar(&tournament, "tournament", false, "Single-elimination bracket: the faster model of each pair advances")
+	cmd.Flags().StringVar(&promptsPath, "prompts", "", "File of prompts, one per line, each match runs (with --tournament)")
+	cmd.Flags().StringVar(&judge, "judge", "", "Model that ranks the responses by quality and explains why (e.g., llama3.1:8b)")
+	registerCompletion(cmd, "judge", completeModelNames)
 
 	return cmd
 }
@@ -742,3 +957,72 @@ func modelToName(model string) string {
 func sanitizeFilename(model string) string {
 	return strings.ReplaceAll(strings.ReplaceAll(model, ":", "_"), "/", "_")
 }
+
+// CatfightExportRow is one model/host result in an exported results file
+type CatfightExportRow struct {
+	Model       string  `json:"model"`
+	Cat         string  `json:"cat,omitempty"`
+	Host        string  `json:"host"`
+	Status      string  `json:"status"` // "ok", "error", "incomplete"
+	DurationSec float64 `json:"duration_sec"`
+	Tokens      int     `json:"tokens"`
+	TokSec      float64 `json:"tokens_per_sec"`
+	Response    string  `json:"response"`
+	Error       string  `json:"error,omitempty"`
+}
+
+// isCatfightExportPath reports whether path names an export file (.json or .md)
+func isCatfightExportPath(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".md", ".markdown":
+		return true
+	}
+	return false
+}
+
+// writeCatfightExport writes results as a JSON array or a Markdown table,
+// chosen by file extension. Failed runs are kept with their status so
+// partial battles still produce a complete record.
+func writeCatfightExport(path, prompt string, rows []CatfightExportRow) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		data, err := json.MarshalIndent(rows, "", "  ")
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(path, append(data, '\n'), 0644)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Kitchen Stadium - Catfight Results\n\n")
+	sb.WriteString(fmt.Sprintf("**Timestamp:** %s\n\n", time.Now().Format(time.RFC3339)))
+	sb.WriteString(fmt.Sprintf("**Prompt:** %s\n\n", prompt))
+	sb.WriteString("| Model | Host | Status | Time | Tokens | Speed |\n")
+	sb.WriteString("|-------|------|--------|------|--------|-------|\n")
+	for _, r := range rows {
+		if r.Status != "ok" {
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s | - | - | - |\n", r.Model, r.Host, r.Status))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %.1fs | %d | %.1f tok/s |\n",
+			r.Model, r.Host, r.Status, r.DurationSec, r.Tokens, r.TokSec))
+	}
+
+	sb.WriteString("\n## Responses\n\n")
+	for _, r := range rows {
+		sb.WriteString(fmt.Sprintf("### %s @ %s\n\n", r.Model, r.Host))
+		if r.Error != "" {
+			sb.WriteString(fmt.Sprintf("**Error:** %s\n\n", r.Error))
+		}
+		if r.Response != "" {
+			sb.WriteString(fmt.Sprintf("```\n%s\n```\n\n", strings.TrimSpace(r.Response)))
+		}
+	}
+
+	return os.WriteFile(path, []byte(sb.String()), 0644)
+}
@@ -0,0 +1,167 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dirtybirdnj/clood/internal/ollama"
+)
+
+// judgeResponseLimit caps how much of each response the judge reads, so
+// a few long answers don't overflow a small model's context
+const judgeResponseLimit = 4000
+
+const judgeSystemPrompt = `You are the judge of a contest between AI models. Several anonymous responses answer the same prompt. Rank them from best to worst on:
+
+1. Accuracy: does it correctly do what the prompt asks?
+2. Factual correctness: are its claims true?
+3. Code quality, if there is code: correct, idiomatic, readable, handles edge cases.
+4. Conciseness: complete without padding or repetition.
+
+Reply in exactly this format:
+RANKING: <response numbers, best first, comma-separated>
+REASONING: <a short paragraph per response explaining its place>`
+
+// CatfightJudgement is the judge model's ranking of a catfight's responses
+type CatfightJudgement struct {
+	Judge       string              `json:"judge"`
+	Ranking     []CatfightJudgeRank `json:"ranking"`
+	Reasoning   string              `json:"reasoning"`
+	DurationSec float64             `json:"duration_sec"`
+}
+
+// CatfightJudgeRank is one response's place in the judge's ranking
+type CatfightJudgeRank struct {
+	Rank  int    `json:"rank"`
+	Cat   string `json:"cat"`
+	Model string `json:"model"`
+	Host  string `json:"host"`
+}
+
+// judgeCatfight asks the judge model to rank the successful responses.
+// Responses are numbered rather than named so the judge can't favour a
+// model by reputation.
+func judgeCatfight(client *ollama.Client, judge, prompt string, results []CatfightResult) (*CatfightJudgement, error) {
+	var entrants []CatfightResult
+	for _, r := range results {
+		if r.Error == nil && strings.TrimSpace(r.Response) != "" {
+			entrants = append(entrants, r)
+		}
+	}
+	if len(entrants) == 0 {
+		return nil, fmt.Errorf("no responses to judge")
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "PROMPT:\n%s\n", prompt)
+	for i, r := range entrants {
+		response := r.Response
+		if len(response) > judgeResponseLimit {
+			response = response[:judgeResponseLimit] + "\n[truncated]"
+		}
+		fmt.Fprintf(&b, "\n--- RESPONSE %d ---\n%s\n", i+1, response)
+	}
+
+	start := time.Now()
+	resp, err := client.GenerateWithSystem(judge, judgeSystemPrompt, b.String())
+	if err != nil {
+		return nil, fmt.Errorf("judge %s: %w", judge, err)
+	}
+
+	order, reasoning := parseJudgeVerdict(resp.Response, len(entrants))
+	// Swap the anonymous labels back for names in the explanation
+	reasoning = judgeLabelRe.ReplaceAllStringFunc(reasoning, func(label string) string {
+		n, _ := strconv.Atoi(judgeNumberRe.FindString(label))
+		if n < 1 || n > len(entrants) {
+			return label
+		}
+		return fmt.Sprintf("%s (%s)", entrants[n-1].Cat.Name, entrants[n-1].Cat.Model)
+	})
+	j := &CatfightJudgement{
+		Judge:       judge,
+		Reasoning:   reasoning,
+		DurationSec: time.Since(start).Seconds(),
+	}
+	for i, n := range order {
+		r := entrants[n-1]
+		j.Ranking = append(j.Ranking, CatfightJudgeRank{Rank: i + 1, Cat: r.Cat.Name, Model: r.Cat.Model, Host: r.Host})
+	}
+	return j, nil
+}
+
+var (
+	judgeNumberRe = regexp.MustCompile(`\d+`)
+	judgeLabelRe  = regexp.MustCompile(`(?i)\bresponse #?\d+\b`)
+)
+
+// parseJudgeVerdict pulls the response numbers from the RANKING line
+// (ignoring repeats and numbers out of range) and the text after
+// REASONING. A reply that doesn't follow the format is kept whole as the
+// reasoning, with no ranking.
+func parseJudgeVerdict(reply string, entrants int) ([]int, string) {
+	var order []int
+	var reasoning []string
+	seen := map[int]bool{}
+	inReasoning := false
+	for _, line := range strings.Split(reply, "\n") {
+		trimmed := strings.TrimLeft(strings.TrimSpace(line), "*#")
+		upper := strings.ToUpper(trimmed)
+		switch {
+		case order == nil && strings.HasPrefix(upper, "RANKING"):
+			order = []int{}
+			for _, m := range judgeNumberRe.FindAllString(trimmed, -1) {
+				n, _ := strconv.Atoi(m)
+				if n >= 1 && n <= entrants && !seen[n] {
+					seen[n] = true
+					order = append(order, n)
+				}
+			}
+		case strings.HasPrefix(upper, "REASONING"):
+			inReasoning = true
+			if _, rest, ok := strings.Cut(trimmed, ":"); ok && strings.TrimSpace(rest) != "" {
+				reasoning = append(reasoning, strings.TrimSpace(strings.TrimLeft(rest, "*")))
+			}
+		case inReasoning:
+			reasoning = append(reasoning, line)
+		}
+	}
+	if !inReasoning {
+		return order, strings.TrimSpace(reply)
+	}
+	return order, strings.TrimSpace(strings.Join(reasoning, "\n"))
+}
+
+// rankingLine formats the judge's ranking like the speed rankings in the
+// ATC analysis event
+func (j *CatfightJudgement) rankingLine() string {
+	var parts []string
+	for _, r := range j.Ranking {
+		parts = append(parts, fmt.Sprintf("%d. %s@%s", r.Rank, r.Model, r.Host))
+	}
+	return strings.Join(parts, " → ")
+}
+
+// summary is a one-line verdict for the ATC analysis event
+func (j *CatfightJudgement) summary() string {
+	if len(j.Ranking) == 0 {
+		return fmt.Sprintf("Judge %s gave no ranking.", j.Judge)
+	}
+	return fmt.Sprintf("Judge %s ranks %s on %s first for quality.", j.Judge, j.Ranking[0].Model, j.Ranking[0].Host)
+}
+
+// markdown renders the verdict for --markdown and --issue output
+func (j *CatfightJudgement) markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "### Judge's Verdict (%s)\n\n", j.Judge)
+	for _, r := range j.Ranking {
+		fmt.Fprintf(&b, "%d. %s (%s) on %s\n", r.Rank, r.Cat, r.Model, r.Host)
+	}
+	if len(j.Ranking) > 0 {
+		b.WriteString("\n")
+	}
+	b.WriteString(j.Reasoning + "\n")
+	return b.String()
+}
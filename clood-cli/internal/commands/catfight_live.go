@@ -68,7 +68,7 @@ type liveArenaModel struct {
 	following      bool
 	streamChans    []chan string
 	logFile        *os.File // optional log file for output
-	mu             sync.Mutex
+	mu             *sync.Mutex
 }
 
 // Styles for live arena
@@ -89,6 +89,7 @@ func CatfightLiveCmd() *cobra.Command {
 	var ollamaURL string
 	var layout string
 	var logPath string
+	var outputPath string
 
 	cmd := &cobra.Command{
 		Use:   "catfight-live [prompt]",
@@ -190,6 +191,7 @@ Examples:
 				startTime:   startTime,
 				streamChans: streamChans,
 				logFile:     logFile,
+				mu:          &sync.Mutex{},
 			}
 
 			p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
@@ -208,6 +210,13 @@ Examples:
 			// After alt screen closes, print results to stdout for easy copying
 			if fm, ok := finalModel.(liveArenaModel); ok {
 				printBattleResults(fm, logFile)
+				if outputPath != "" {
+					if err := writeCatfightExport(outputPath, prompt, liveExportRows(fm)); err != nil {
+						fmt.Println(tui.ErrorStyle.Render("Error writing export: " + err.Error()))
+					} else {
+						fmt.Printf("📄 Results exported to: %s\n\n", outputPath)
+					}
+				}
 			}
 		},
 	}
@@ -216,6 +225,7 @@ Examples:
 	cmd.Flags().StringVar(&ollamaURL, "url", "http://localhost:11434", "Ollama URL")
 	cmd.Flags().StringVar(&layout, "layout", "interleaved", "Display layout: interleaved or split")
 	cmd.Flags().StringVarP(&logPath, "log", "l", "", "Write battle output to file (e.g., battle.md)")
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "Export final results table (.json for JSON array, otherwise Markdown)")
 
 	return cmd
 }
@@ -307,6 +317,35 @@ func printBattleResults(m liveArenaModel, logFile *os.File) {
 	}
 }
 
+// liveExportRows converts the finished arena into export rows
+func liveExportRows(m liveArenaModel) []CatfightExportRow {
+	rows := make([]CatfightExportRow, 0, len(m.cats))
+	for _, cat := range m.cats {
+		row := CatfightExportRow{
+			Model:    cat.Model,
+			Cat:      cat.Name,
+			Host:     m.ollamaURL,
+			Tokens:   cat.Tokens,
+			Response: strings.TrimSpace(cat.Buffer.String()),
+		}
+		switch cat.Status {
+		case "done":
+			row.Status = "ok"
+			row.DurationSec = cat.EndTime.Sub(m.startTime).Seconds()
+			if row.DurationSec > 0 {
+				row.TokSec = float64(cat.Tokens) / row.DurationSec
+			}
+		case "error":
+			row.Status = "error"
+			row.Error = "stream failed"
+		default:
+			row.Status = "incomplete"
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
 func (m liveArenaModel) Init() tea.Cmd {
 	return tea.Batch(
 		tea.Tick(100*time.Millisecond, func(t time.Time) tea.Msg {
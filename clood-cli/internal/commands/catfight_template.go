@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+)
+
+// expandPromptTemplate reads a text/template prompt file and fills it from
+// --vars key=value pairs. Each key is available as written and with its
+// first letter upper-cased, so --vars subject=x fills {{.Subject}}. A
+// variable the template uses but --vars doesn't set is an error rather
+// than "<no value>" in every model's prompt.
+func expandPromptTemplate(path string, vars []string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	values := map[string]string{}
+	for _, v := range vars {
+		key, value, ok := strings.Cut(v, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return "", fmt.Errorf("--vars %q: expected key=value", v)
+		}
+		values[key] = value
+		r, size := utf8.DecodeRuneInString(key)
+		if upper := string(unicode.ToUpper(r)) + key[size:]; upper != key {
+			if _, set := values[upper]; !set {
+				values[upper] = value
+			}
+		}
+	}
+
+	tmpl, err := template.New(path).Option("missingkey=error").Parse(string(data))
+	if err != nil {
+		return "", fmt.Errorf("parse template: %w", err)
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, values); err != nil {
+		return "", fmt.Errorf("expand template: %w", err)
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,260 @@
+package commands
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dirtybirdnj/clood/internal/ollama"
+	"github.com/dirtybirdnj/clood/internal/output"
+	"github.com/dirtybirdnj/clood/internal/tui"
+)
+
+// TournamentMatch is one head-to-head in the bracket. Times are the total
+// over every prompt; a model that errors on any prompt forfeits.
+type TournamentMatch struct {
+	A      string  `json:"a"`
+	B      string  `json:"b,omitempty"` // empty for a bye
+	ATime  float64 `json:"a_time_sec,omitempty"`
+	BTime  float64 `json:"b_time_sec,omitempty"`
+	AError string  `json:"a_error,omitempty"`
+	BError string  `json:"b_error,omitempty"`
+	Winner string  `json:"winner,omitempty"` // empty when both forfeit
+}
+
+// TournamentOutput is the JSON form of a finished bracket
+type TournamentOutput struct {
+	Timestamp string              `json:"timestamp"`
+	Host      string              `json:"host"`
+	Prompts   []string            `json:"prompts"`
+	Rounds    [][]TournamentMatch `json:"rounds"`
+	Champion  string              `json:"champion,omitempty"`
+	// ChampionTime is the champion's time in the final, or in its last
+	// match when it won on byes
+	ChampionTime float64 `json:"champion_time_sec,omitempty"`
+}
+
+// loadTournamentPrompts reads one prompt per line, skipping blanks and
+// # comments
+func loadTournamentPrompts(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var prompts []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			prompts = append(prompts, line)
+		}
+	}
+	if len(prompts) == 0 {
+		return nil, fmt.Errorf("no prompts in %s", path)
+	}
+	return prompts, nil
+}
+
+// runCatfightTournament plays a single-elimination bracket on one host:
+// models are paired at random, each pair runs every prompt, and the one
+// with the lower total time advances. An odd model out gets a bye.
+func runCatfightTournament(cats []Cat, prompts []string, hostName string, client *ollama.Client, atcURL string, jsonOutput bool) {
+	if len(cats) < 2 {
+		fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render("A tournament needs at least two models."))
+		return
+	}
+
+	alive := make([]string, len(cats))
+	for i, cat := range cats {
+		alive[i] = cat.Model
+	}
+	rand.Shuffle(len(alive), func(i, j int) { alive[i], alive[j] = alive[j], alive[i] })
+
+	if !jsonOutput {
+		fmt.Println(tui.RenderHeader("KITCHEN STADIUM - TOURNAMENT"))
+		fmt.Println()
+		fmt.Printf("%s %d cats, %d prompt(s) per match on %s\n\n",
+			tui.MutedStyle.Render("Bracket:"), len(alive), len(prompts), hostName)
+	}
+	sendATCEvent(atcURL, "start", map[string]interface{}{
+		"prompt": fmt.Sprintf("Tournament: %d prompts, single elimination", len(prompts)),
+		"models": alive,
+		"hosts":  []string{hostName},
+	})
+
+	out := TournamentOutput{
+		Timestamp: time.Now().Format(time.RFC3339),
+		Host:      hostName,
+		Prompts:   prompts,
+	}
+	// run times a model over the whole prompt set
+	run := func(model string) (float64, string) {
+		var total float64
+		for _, prompt := range prompts {
+			start := time.Now()
+			if _, err := client.Generate(model, prompt); err != nil {
+				return 0, err.Error()
+			}
+			total += time.Since(start).Seconds()
+		}
+		return total, ""
+	}
+
+	for len(alive) > 1 {
+		var round []TournamentMatch
+		var next []string
+		if !jsonOutput {
+			fmt.Println(tui.AccentStyle.Render(tournamentRoundName(len(alive))))
+		}
+		for i := 0; i < len(alive); i += 2 {
+			m := TournamentMatch{A: alive[i]}
+			if i+1 == len(alive) {
+				m.Winner = m.A
+				if !jsonOutput {
+					fmt.Printf("  %s advances on a bye\n", m.A)
+				}
+			} else {
+				m.B = alive[i+1]
+				if !jsonOutput {
+					fmt.Printf("  %s vs %s ... ", m.A, m.B)
+				}
+				m.ATime, m.AError = run(m.A)
+				m.BTime, m.BError = run(m.B)
+				switch {
+				case m.AError == "" && (m.BError != "" || m.ATime <= m.BTime):
+					m.Winner = m.A
+				case m.BError == "":
+					m.Winner = m.B
+				}
+				if !jsonOutput {
+					if m.Winner != "" {
+						fmt.Println(tui.SuccessStyle.Render(m.Winner + " advances"))
+					} else {
+						fmt.Println(tui.ErrorStyle.Render("both forfeit"))
+					}
+				}
+			}
+			if m.Winner != "" {
+				next = append(next, m.Winner)
+			}
+			round = append(round, m)
+		}
+		out.Rounds = append(out.Rounds, round)
+		alive = next
+		if !jsonOutput {
+			fmt.Println()
+		}
+	}
+
+	if len(alive) == 1 {
+		out.Champion = alive[0]
+		for r := len(out.Rounds) - 1; r >= 0 && out.ChampionTime == 0; r-- {
+			for _, m := range out.Rounds[r] {
+				if m.Winner == out.Champion && m.B != "" {
+					out.ChampionTime = tournamentWinnerTime(m)
+				}
+			}
+		}
+	}
+
+	completeData := map[string]interface{}{
+		"tournament": true,
+		"rounds":     len(out.Rounds),
+	}
+	if out.Champion != "" {
+		completeData["winner"] = out.Champion
+		completeData["winner_time"] = out.ChampionTime
+		completeData["winner_host"] = hostName
+	}
+	if err := postATCEvent(atcURL, "complete", completeData); err != nil && !jsonOutput {
+		fmt.Fprintln(os.Stderr, tui.WarningStyle.Render("Couldn't post the winner to ATC: "+err.Error()))
+	}
+
+	if jsonOutput {
+		data, _ := output.Marshal(out)
+		fmt.Println(string(data))
+		return
+	}
+
+	fmt.Println(tui.RenderHeader("BRACKET"))
+	fmt.Println()
+	fmt.Print(renderTournamentBracket(out.Rounds))
+	if out.Champion != "" {
+		fmt.Printf("%s %s (%.1fs in its last match)\n", tui.AccentStyle.Render("🏆 CHAMPION:"), out.Champion, out.ChampionTime)
+	} else {
+		fmt.Println(tui.ErrorStyle.Render("No champion: every remaining model failed."))
+	}
+}
+
+func tournamentWinnerTime(m TournamentMatch) float64 {
+	if m.Winner == m.B {
+		return m.BTime
+	}
+	return m.ATime
+}
+
+// tournamentRoundName names a round by how many models enter it
+func tournamentRoundName(entrants int) string {
+	switch {
+	case entrants <= 2:
+		return "FINAL"
+	case entrants <= 4:
+		return "SEMIFINALS"
+	case entrants <= 8:
+		return "QUARTERFINALS"
+	}
+	return fmt.Sprintf("ROUND OF %d", entrants)
+}
+
+// renderTournamentBracket draws each round's pairings with the winner
+// coming out of the join:
+//
+//	SEMIFINALS
+//	  mistral:7b        12.3s ─┐
+//	                            ├─▶ mistral:7b
+//	  qwen2.5-coder:3b  14.1s ─┘
+func renderTournamentBracket(rounds [][]TournamentMatch) string {
+	width := 0
+	for _, round := range rounds {
+		for _, m := range round {
+			width = max(width, len(m.A), len(m.B))
+		}
+	}
+
+	entry := func(model string, secs float64, errStr string) string {
+		result := fmt.Sprintf("%6.1fs", secs)
+		if errStr != "" {
+			result = "  error"
+		}
+		return fmt.Sprintf("  %-*s %s", width, model, result)
+	}
+
+	var sb strings.Builder
+	for _, round := range rounds {
+		entrants := 0
+		for _, m := range round {
+			entrants++
+			if m.B != "" {
+				entrants++
+			}
+		}
+		sb.WriteString(tournamentRoundName(entrants) + "\n")
+		for _, m := range round {
+			winner := m.Winner
+			if winner == "" {
+				winner = "✗"
+			}
+			if m.B == "" {
+				sb.WriteString(fmt.Sprintf("  %-*s %7s ──▶ %s\n", width, m.A, "bye", winner))
+				continue
+			}
+			pad := strings.Repeat(" ", width+11)
+			sb.WriteString(entry(m.A, m.ATime, m.AError) + " ─┐\n")
+			sb.WriteString(pad + " ├─▶ " + winner + "\n")
+			sb.WriteString(entry(m.B, m.BTime, m.BError) + " ─┘\n")
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
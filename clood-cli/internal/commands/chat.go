@@ -19,12 +19,17 @@ import (
 
 // Message represents a single chat message
 type Message struct {
-	Role      string    `json:"role"`      // "user" or "assistant"
+	Role      string    `json:"role"` // "user", "assistant", or "note"
 	Content   string    `json:"content"`
 	Timestamp time.Time `json:"timestamp"`
 	Tokens    int       `json:"tokens,omitempty"`
+	Model     string    `json:"model,omitempty"` // Model that wrote an assistant reply
 }
 
+// noteRole marks log entries such as model switches. They are kept in the
+// conversation log but never sent to the model.
+const noteRole = "note"
+
 // Saga represents the ongoing conversation for a project
 type Saga struct {
 	Name        string    `json:"name"`
@@ -34,6 +39,8 @@ type Saga struct {
 	Messages    []Message `json:"messages"`
 	Context     string    `json:"context,omitempty"` // Loaded project context
 	Goal        string    `json:"goal,omitempty"`    // Focus guardian goal
+	Model       string    `json:"model,omitempty"`   // Set by /model; empty routes by tier
+	System      string    `json:"system,omitempty"`  // Set by ask --system; sent ahead of Context
 }
 
 // SagaStats contains context usage information
@@ -44,11 +51,19 @@ type SagaStats struct {
 	MaxTokens      int
 	UsagePercent   float64
 	MessageCount   int
+	Model          string // Whose context window MaxTokens is
 }
 
 const (
-	maxContextTokens = 16000 // Default max context
-	tokensPerChar    = 0.25  // Rough estimate: 4 chars per token
+	tokensPerChar = 0.25 // Rough estimate: 4 chars per token
+
+	// contextWarnPercent is where the meter turns red and suggests a
+	// checkpoint
+	contextWarnPercent = 80
+
+	// chatHistoryShare is how much of the model's context window the
+	// history sent each turn may fill, leaving the rest for the reply
+	chatHistoryShare = 0.75
 )
 
 func ChatCmd() *cobra.Command {
@@ -70,17 +85,22 @@ The saga:
   - Focus guardian (Gamera-kun) detects drift from your goal
 
 Slash commands:
-  /save FILE   - Save conversation to file
+  /save NAME   - Save conversation to ~/.clood/sessions/NAME.json
+  /load NAME   - Replace conversation with a saved session
+  /list        - List saved sessions
+  /export FILE - Export conversation as markdown
   /clear       - Clear history (keep context)
   /stats       - Show saga statistics
   /context     - Show loaded context
   /goal [NEW]  - Show or update current goal
+  /model [NAME] - Show or switch the model, keeping history (auto to reset)
   /quit        - Exit and save saga
 
 Examples:
   clood chat                              # Start/continue saga
   clood chat --goal "fix auth bug"        # Start with focus goal
-  clood chat --tier 4                     # Force writing tier`,
+  clood chat --tier 4                     # Force writing tier
+  clood chat -m qwen2.5:32b               # Start on a specific model`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runChat(forceTier, forceModel, forceHost, goal)
 		},
@@ -117,6 +137,11 @@ func runChat(forceTier int, forceModel, forceHost, goal string) error {
 		saga.Goal = goal
 	}
 
+	// Model flag overrides the one saved by /model
+	if forceModel != "" {
+		saga.Model = forceModel
+	}
+
 	// Initialize focus guardian (Gamera-kun)
 	var guardian *focus.Guardian
 	if saga.Goal != "" {
@@ -144,6 +169,9 @@ func runChat(forceTier int, forceModel, forceHost, goal string) error {
 	if saga.Goal != "" {
 		fmt.Println(tui.MutedStyle.Render(fmt.Sprintf("Goal: %s", saga.Goal)))
 	}
+	if saga.Model != "" {
+		fmt.Println(tui.MutedStyle.Render(fmt.Sprintf("Model: %s", saga.Model)))
+	}
 
 	// Show health meter
 	stats := saga.GetStats()
@@ -172,7 +200,7 @@ func runChat(forceTier int, forceModel, forceHost, goal string) error {
 
 		// Handle slash commands
 		if strings.HasPrefix(input, "/") {
-			shouldQuit, newGuardian, err := handleSlashCommand(input, saga, guardian)
+			shouldQuit, newGuardian, err := handleSlashCommand(input, saga, guardian, reader)
 			if err != nil {
 				fmt.Println(tui.ErrorStyle.Render("Error: " + err.Error()))
 			}
@@ -220,16 +248,13 @@ func runChat(forceTier int, forceModel, forceHost, goal string) error {
 			Tokens:    estimateTokens(input),
 		})
 
-		// Build prompt with context and history
-		prompt := buildChatPrompt(saga)
-
 		// Route and execute
 		tier := forceTier
 		if tier == 0 {
 			tier = config.TierDeep // Default to deep for chat
 		}
 
-		result, err := r.Route(input, tier, forceModel)
+		result, err := r.Route(input, tier, saga.Model)
 		if err != nil {
 			fmt.Println(tui.ErrorStyle.Render("Routing error: " + err.Error()))
 			continue
@@ -241,15 +266,23 @@ func runChat(forceTier int, forceModel, forceHost, goal string) error {
 		}
 
 		// Show what model we're using
+		if saga.Model != "" && result.Model != saga.Model {
+			fmt.Println(tui.WarningStyle.Render(fmt.Sprintf("%s isn't available on any host, falling back", saga.Model)))
+		}
 		fmt.Println(tui.MutedStyle.Render(fmt.Sprintf("[%s on %s]", result.Model, result.Host.Host.Name)))
 		fmt.Println()
 
-		// Stream response
+		// Stream response with as much history as fits the model's
+		// context, so a model switched in with /model sees what was said
+		// recently and a long saga never overflows it
+		budget := int(float64(cfg.ContextWindow(result.Model)) * chatHistoryShare)
 		var responseBuilder strings.Builder
-		_, err = result.Client.GenerateStream(result.Model, prompt, func(chunk ollama.GenerateResponse) {
-			fmt.Print(chunk.Response)
-			responseBuilder.WriteString(chunk.Response)
+		done := r.GetManager().BeginRequest(result.Host.Host.Name)
+		_, err = result.Client.ChatStream(result.Model, askSessionMessages(saga, 0, budget), func(chunk ollama.ChatResponse) {
+			fmt.Print(chunk.Message.Content)
+			responseBuilder.WriteString(chunk.Message.Content)
 		})
+		done()
 
 		if err != nil {
 			fmt.Println()
@@ -267,6 +300,7 @@ func runChat(forceTier int, forceModel, forceHost, goal string) error {
 			Content:   response,
 			Timestamp: time.Now(),
 			Tokens:    estimateTokens(response),
+			Model:     result.Model,
 		})
 
 		// Save saga after each exchange
@@ -277,8 +311,8 @@ func runChat(forceTier int, forceModel, forceHost, goal string) error {
 
 		// Update health meter
 		stats = saga.GetStats()
-		if stats.UsagePercent > 80 {
-			fmt.Println(tui.ErrorStyle.Render(fmt.Sprintf("⚠️  Context at %.0f%% - consider /clear or compression", stats.UsagePercent)))
+		if stats.UsagePercent > contextWarnPercent {
+			fmt.Println(tui.ErrorStyle.Render(fmt.Sprintf("⚠️  Context at %.0f%% - run 'clood checkpoint save NAME' to summarize, then /clear", stats.UsagePercent)))
 		}
 	}
 
@@ -365,38 +399,7 @@ func loadProjectContext() (string, int) {
 	return context, estimateTokens(context)
 }
 
-func buildChatPrompt(saga *Saga) string {
-	var promptBuilder strings.Builder
-
-	// Add project context
-	if saga.Context != "" {
-		promptBuilder.WriteString("Project Context:\n")
-		promptBuilder.WriteString(saga.Context)
-		promptBuilder.WriteString("\n---\n\n")
-	}
-
-	// Add conversation history (last N messages)
-	maxHistory := 20
-	startIdx := 0
-	if len(saga.Messages) > maxHistory {
-		startIdx = len(saga.Messages) - maxHistory
-	}
-
-	promptBuilder.WriteString("Conversation:\n")
-	for _, msg := range saga.Messages[startIdx:] {
-		if msg.Role == "user" {
-			promptBuilder.WriteString("User: ")
-		} else {
-			promptBuilder.WriteString("Assistant: ")
-		}
-		promptBuilder.WriteString(msg.Content)
-		promptBuilder.WriteString("\n\n")
-	}
-
-	return promptBuilder.String()
-}
-
-func handleSlashCommand(input string, saga *Saga, guardian *focus.Guardian) (bool, *focus.Guardian, error) {
+func handleSlashCommand(input string, saga *Saga, guardian *focus.Guardian, reader *bufio.Reader) (bool, *focus.Guardian, error) {
 	parts := strings.Fields(input)
 	if len(parts) == 0 {
 		return false, nil, nil
@@ -411,7 +414,26 @@ func handleSlashCommand(input string, saga *Saga, guardian *focus.Guardian) (boo
 
 	case "/save":
 		if len(parts) < 2 {
-			return false, nil, fmt.Errorf("usage: /save FILENAME")
+			return false, nil, fmt.Errorf("usage: /save NAME")
+		}
+		// Markdown filenames keep the old export behaviour
+		if strings.HasSuffix(parts[1], ".md") {
+			return false, nil, saveConversationToFile(saga, parts[1])
+		}
+		return false, nil, saveChatSession(saga, parts[1])
+
+	case "/load":
+		if len(parts) < 2 {
+			return false, nil, fmt.Errorf("usage: /load NAME")
+		}
+		return false, nil, loadChatSession(saga, parts[1], reader)
+
+	case "/list":
+		return false, nil, listChatSessions()
+
+	case "/export":
+		if len(parts) < 2 {
+			return false, nil, fmt.Errorf("usage: /export FILENAME")
 		}
 		return false, nil, saveConversationToFile(saga, parts[1])
 
@@ -443,6 +465,36 @@ func handleSlashCommand(input string, saga *Saga, guardian *focus.Guardian) (boo
 		fmt.Println(tui.MutedStyle.Render("Gamera-kun will watch for drift."))
 		return false, newGuardian, saveSaga(saga)
 
+	case "/model":
+		if len(parts) < 2 {
+			if saga.Model == "" {
+				fmt.Println(tui.MutedStyle.Render("Model: auto (routed by tier). Use /model NAME to switch."))
+			} else {
+				fmt.Printf("  Model: %s\n", saga.Model)
+			}
+			return false, nil, nil
+		}
+		newModel := parts[1]
+		if strings.EqualFold(newModel, "auto") {
+			newModel = ""
+		}
+		if newModel == saga.Model {
+			return false, nil, nil
+		}
+		note := "Model switched to " + newModel
+		if newModel == "" {
+			note = "Model switched to auto (routed by tier)"
+		}
+		saga.Model = newModel
+		saga.Messages = append(saga.Messages, Message{
+			Role:      noteRole,
+			Content:   note,
+			Timestamp: time.Now(),
+		})
+		fmt.Println(tui.SuccessStyle.Render(note))
+		fmt.Println(tui.MutedStyle.Render(fmt.Sprintf("History carried over (%d messages).", len(saga.Messages)-1)))
+		return false, nil, saveSaga(saga)
+
 	case "/stats":
 		stats := saga.GetStats()
 		fmt.Println()
@@ -454,6 +506,9 @@ func handleSlashCommand(input string, saga *Saga, guardian *focus.Guardian) (boo
 		if saga.Goal != "" {
 			fmt.Printf("  Goal:          %s\n", saga.Goal)
 		}
+		if saga.Model != "" {
+			fmt.Printf("  Model:         %s\n", saga.Model)
+		}
 		fmt.Println()
 		renderHealthMeter(stats)
 		fmt.Println()
@@ -476,11 +531,15 @@ func handleSlashCommand(input string, saga *Saga, guardian *focus.Guardian) (boo
 	case "/help":
 		fmt.Println()
 		fmt.Println(tui.RenderHeader("Slash Commands"))
-		fmt.Println("  /save FILE   Save conversation to file")
+		fmt.Println("  /save NAME   Save conversation to ~/.clood/sessions")
+		fmt.Println("  /load NAME   Replace conversation with a saved session")
+		fmt.Println("  /list        List saved sessions")
+		fmt.Println("  /export FILE Export conversation as markdown")
 		fmt.Println("  /clear       Clear history (keep context)")
 		fmt.Println("  /stats       Show saga statistics")
 		fmt.Println("  /context     Show loaded context")
 		fmt.Println("  /goal [NEW]  Show or update focus goal")
+		fmt.Println("  /model [NAME] Show or switch model (auto to reset)")
 		fmt.Println("  /help        Show this help")
 		fmt.Println("  /quit        Exit and save saga")
 		fmt.Println()
@@ -491,6 +550,73 @@ func handleSlashCommand(input string, saga *Saga, guardian *focus.Guardian) (boo
 	}
 }
 
+// saveChatSession writes a copy of the conversation as a named session
+func saveChatSession(saga *Saga, name string) error {
+	id := savedSessionID(name)
+	if id == "" {
+		return fmt.Errorf("invalid session name %q", name)
+	}
+
+	session := *saga
+	session.Name = name
+	if err := saveSavedSession(id, &session); err != nil {
+		return err
+	}
+
+	fmt.Println(tui.SuccessStyle.Render(fmt.Sprintf("Session saved: %s (%d messages)", id, len(saga.Messages))))
+	return nil
+}
+
+// loadChatSession replaces the conversation with a saved session, asking
+// first if that would discard messages.
+func loadChatSession(saga *Saga, name string, reader *bufio.Reader) error {
+	id := savedSessionID(name)
+	session, err := loadSavedSession(id)
+	if err != nil {
+		return err
+	}
+
+	if len(saga.Messages) > 0 {
+		fmt.Print(tui.WarningStyle.Render(fmt.Sprintf("Replace the current %d messages? [y/N] ", len(saga.Messages))))
+		response, _ := reader.ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			fmt.Println(tui.MutedStyle.Render("Load cancelled."))
+			return nil
+		}
+	}
+
+	saga.Messages = session.Messages
+	if session.Context != "" {
+		saga.Context = session.Context
+	}
+	if err := saveSaga(saga); err != nil {
+		return err
+	}
+
+	fmt.Println(tui.SuccessStyle.Render(fmt.Sprintf("Loaded session %s (%d messages)", id, len(saga.Messages))))
+	return nil
+}
+
+func listChatSessions() error {
+	sessions, err := listSavedSessions()
+	if err != nil {
+		return err
+	}
+	if len(sessions) == 0 {
+		fmt.Println(tui.MutedStyle.Render("No saved sessions. Use /save NAME to create one."))
+		return nil
+	}
+
+	fmt.Println(tui.RenderHeader("Saved Sessions"))
+	for _, s := range sessions {
+		fmt.Printf("  %-24s %s\n", s.ID, tui.MutedStyle.Render(fmt.Sprintf("%d messages, %s",
+			s.Messages, s.UpdatedAt.Format("Jan 2 15:04"))))
+	}
+	fmt.Println()
+	return nil
+}
+
 func saveConversationToFile(saga *Saga, filename string) error {
 	var content strings.Builder
 
@@ -499,9 +625,15 @@ func saveConversationToFile(saga *Saga, filename string) error {
 	content.WriteString("---\n\n")
 
 	for _, msg := range saga.Messages {
-		if msg.Role == "user" {
+		switch {
+		case msg.Role == noteRole:
+			content.WriteString(fmt.Sprintf("_%s_\n\n", msg.Content))
+			continue
+		case msg.Role == "user":
 			content.WriteString("**You:**\n")
-		} else {
+		case msg.Model != "":
+			content.WriteString(fmt.Sprintf("**Assistant (%s):**\n", msg.Model))
+		default:
 			content.WriteString("**Assistant:**\n")
 		}
 		content.WriteString(msg.Content)
@@ -528,14 +660,33 @@ func (s *Saga) GetStats() SagaStats {
 	contextTokens := estimateTokens(s.Context)
 	totalTokens := historyTokens + contextTokens
 
+	model := s.currentModel()
+	cfg, _ := config.Load()
+	maxTokens := cfg.ContextWindow(model)
+
 	return SagaStats{
 		HistoryTokens:  historyTokens,
 		ContextTokens:  contextTokens,
 		TotalTokens:    totalTokens,
-		MaxTokens:      maxContextTokens,
-		UsagePercent:   float64(totalTokens) / float64(maxContextTokens) * 100,
+		MaxTokens:      maxTokens,
+		UsagePercent:   float64(totalTokens) / float64(maxTokens) * 100,
 		MessageCount:   len(s.Messages),
+		Model:          model,
+	}
+}
+
+// currentModel is the model set by /model, or else the one that wrote the
+// last reply when routing by tier
+func (s *Saga) currentModel() string {
+	if s.Model != "" {
+		return s.Model
+	}
+	for i := len(s.Messages) - 1; i >= 0; i-- {
+		if s.Messages[i].Model != "" {
+			return s.Messages[i].Model
+		}
 	}
+	return ""
 }
 
 func estimateTokens(text string) int {
@@ -556,13 +707,25 @@ func renderHealthMeter(stats SagaStats) {
 	var meterStr string
 	if stats.UsagePercent < 50 {
 		meterStr = tui.SuccessStyle.Render(fmt.Sprintf("Context: %s %.0f%%", bar, stats.UsagePercent))
-	} else if stats.UsagePercent < 80 {
+	} else if stats.UsagePercent < contextWarnPercent {
 		meterStr = tui.TierFastStyle.Render(fmt.Sprintf("Context: %s %.0f%% ⚠️", bar, stats.UsagePercent))
 	} else {
 		meterStr = tui.ErrorStyle.Render(fmt.Sprintf("Context: %s %.0f%% 🔴", bar, stats.UsagePercent))
 	}
 
-	fmt.Println(meterStr)
+	window := fmt.Sprintf(" %d / %d tokens", stats.TotalTokens, stats.MaxTokens)
+	if stats.Model != "" {
+		window += " (" + stats.Model + ")"
+	}
+	fmt.Println(meterStr + tui.MutedStyle.Render(window))
+
+	if stats.UsagePercent >= contextWarnPercent {
+		state := "Nearly full"
+		if stats.UsagePercent >= 100 {
+			state = "Over the window, so older turns are being cut"
+		}
+		fmt.Println(tui.MutedStyle.Render("  " + state + ": summarize with 'clood checkpoint save NAME', then /clear to start fresh"))
+	}
 }
 
 // renderGameraWarning displays the focus guardian warning box
@@ -124,7 +124,7 @@ func checkpointSaveCmd() *cobra.Command {
 			}
 
 			if output.IsJSON() {
-				data, _ := json.MarshalIndent(checkpoint, "", "  ")
+				data, _ := output.Marshal(checkpoint)
 				fmt.Println(string(data))
 				return
 			}
@@ -168,7 +168,7 @@ func checkpointLoadCmd() *cobra.Command {
 			}
 
 			if output.IsJSON() {
-				data, _ := json.MarshalIndent(checkpoint, "", "  ")
+				data, _ := output.Marshal(checkpoint)
 				fmt.Println(string(data))
 				return
 			}
@@ -192,7 +192,7 @@ func checkpointListCmd() *cobra.Command {
 			}
 
 			if output.IsJSON() {
-				data, _ := json.MarshalIndent(checkpoints, "", "  ")
+				data, _ := output.Marshal(checkpoints)
 				fmt.Println(string(data))
 				return
 			}
@@ -239,7 +239,7 @@ func checkpointShowCmd() *cobra.Command {
 			}
 
 			if output.IsJSON() {
-				data, _ := json.MarshalIndent(checkpoint, "", "  ")
+				data, _ := output.Marshal(checkpoint)
 				fmt.Println(string(data))
 				return
 			}
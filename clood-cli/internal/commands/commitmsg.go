@@ -2,7 +2,6 @@ package commands
 
 import (
 	"bufio"
-	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -11,6 +10,7 @@ import (
 	"time"
 
 	"github.com/dirtybirdnj/clood/internal/config"
+	"github.com/dirtybirdnj/clood/internal/git"
 	"github.com/dirtybirdnj/clood/internal/hosts"
 	"github.com/dirtybirdnj/clood/internal/ollama"
 	"github.com/dirtybirdnj/clood/internal/output"
@@ -39,6 +39,9 @@ Rules:
 
 Output ONLY the commit message with haiku, no explanations or markdown.`
 
+// commitMsgLearnCount is how many recent commits --learn shows the model
+const commitMsgLearnCount = 20
+
 // CommitMsgResult is the JSON output structure
 type CommitMsgResult struct {
 	Message   string `json:"message"`
@@ -49,11 +52,11 @@ type CommitMsgResult struct {
 }
 
 func CommitMsgCmd() *cobra.Command {
-	var jsonOutput bool
 	var dryRun bool
 	var apply bool
 	var haiku bool
 	var conventional bool
+	var learn bool
 
 	cmd := &cobra.Command{
 		Use:   "commit-msg",
@@ -64,10 +67,11 @@ Examples:
   git diff --staged | clood commit-msg     # Pipe diff, get message
   clood commit-msg                         # Auto-reads staged changes
   clood commit-msg --haiku                 # Include a haiku
+  clood commit-msg --learn                 # Match the repo's recent commit style
   clood commit-msg --apply                 # Generate and commit
   clood commit-msg --dry-run               # Preview only`,
 		Run: func(cmd *cobra.Command, args []string) {
-			useJSON := jsonOutput || output.IsJSON()
+			useJSON := output.IsJSON()
 
 			// Get diff - from stdin or git
 			diff, err := getDiff()
@@ -140,6 +144,17 @@ Examples:
 			if conventional {
 				systemPrompt += "\nALWAYS use conventional commit format (feat:, fix:, docs:, etc.)"
 			}
+			if learn {
+				examples, err := commitStyleExamples(commitMsgLearnCount)
+				if err != nil && !useJSON {
+					fmt.Println(tui.WarningStyle.Render("Could not read commit history, skipping --learn: " + err.Error()))
+				}
+				if examples != "" {
+					systemPrompt += "\n\nHere are this project's most recent commit messages. Match their conventions " +
+						"(mood, capitalization, ticket or scope prefixes, subject length, whether bodies are used), " +
+						"even where they differ from the rules above:\n\n" + examples
+				}
+			}
 
 			userPrompt := fmt.Sprintf("Generate a commit message for this diff:\n\n```diff\n%s\n```", diff)
 
@@ -186,7 +201,7 @@ Examples:
 					TimeMs:    elapsed.Milliseconds(),
 					DiffLines: diffLines,
 				}
-				data, _ := json.MarshalIndent(result, "", "  ")
+				data, _ := output.Marshal(result)
 				fmt.Println(string(data))
 				return
 			}
@@ -226,15 +241,43 @@ Examples:
 		},
 	}
 
-	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview only, don't commit")
 	cmd.Flags().BoolVar(&apply, "apply", false, "Apply the generated commit message")
 	cmd.Flags().BoolVar(&haiku, "haiku", false, "Include a haiku in the commit message")
 	cmd.Flags().BoolVar(&conventional, "conventional", false, "Use conventional commits format")
+	cmd.Flags().BoolVar(&learn, "learn", false, "Learn the project's commit style from the last 20 commits")
 
 	return cmd
 }
 
+// commitStyleExamples formats the last n commit messages as style examples.
+// Trailers are dropped since the attribution is added separately, and long
+// bodies are cut so the examples don't crowd out the diff.
+func commitStyleExamples(n int) (string, error) {
+	entries, err := git.Log(git.LogOptions{Path: ".", Count: n})
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	for _, e := range entries {
+		var body []string
+		for _, line := range strings.Split(e.Body, "\n") {
+			lower := strings.ToLower(line)
+			if strings.HasPrefix(lower, "co-authored-by:") || strings.HasPrefix(lower, "signed-off-by:") {
+				continue
+			}
+			body = append(body, line)
+		}
+		msg := strings.TrimSpace(e.Subject + "\n\n" + strings.TrimSpace(strings.Join(body, "\n")))
+		if len(msg) > 400 {
+			msg = msg[:400] + "..."
+		}
+		sb.WriteString("---\n" + msg + "\n")
+	}
+	return sb.String(), nil
+}
+
 // getDiff reads diff from stdin or runs git diff --staged
 func getDiff() (string, error) {
 	// Check if stdin has data
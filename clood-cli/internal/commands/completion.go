@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dirtybirdnj/clood/internal/config"
+	"github.com/dirtybirdnj/clood/internal/hosts"
+	"github.com/dirtybirdnj/clood/internal/sd"
+	"github.com/spf13/cobra"
+)
+
+// completionTimeout bounds each host check while the shell waits on a
+// completion; an offline host shouldn't freeze the prompt
+const completionTimeout = 2 * time.Second
+
+// completeModelNames completes a --model flag with the models on the
+// online hosts, the same list 'clood models --json' reports. The shell
+// scripts from 'clood completion' call it through cobra's __complete.
+func completeModelNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	mgr := hosts.NewManager(hosts.WithTimeout(completionTimeout))
+	mgr.AddHosts(cfg.Hosts)
+
+	var names []string
+	for name, m := range mgr.GetModels() {
+		desc := strings.Join(m.Hosts, ", ")
+		if m.SizeHuman != "" {
+			desc = m.SizeHuman + " on " + desc
+		}
+		names = append(names, name+"\t"+desc)
+	}
+	sort.Strings(names)
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeModelList completes a comma-separated --models flag: the models
+// already typed are kept and the last one is completed
+func completeModelList(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	prefix := ""
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		prefix = toComplete[:i+1]
+	}
+	names, directive := completeModelNames(cmd, args, toComplete)
+	for i, name := range names {
+		names[i] = prefix + name
+	}
+	return names, directive | cobra.ShellCompDirectiveNoSpace
+}
+
+// completeCheckpoints completes --checkpoint with the checkpoints on the
+// ComfyUI server named by the command's --url flag
+func completeCheckpoints(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	url := sd.DefaultComfyURL
+	if f := cmd.Flags().Lookup("url"); f != nil {
+		url = f.Value.String()
+	}
+	client := sd.NewClient(url)
+	client.HTTPClient.Timeout = completionTimeout
+
+	checkpoints, err := client.Models("checkpoints")
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return checkpoints, cobra.ShellCompDirectiveNoFileComp
+}
+
+// registerCompletion attaches fn to cmd's flag. It panics only on a
+// programming error (the flag doesn't exist).
+func registerCompletion(cmd *cobra.Command, flag string, fn func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective)) {
+	if err := cmd.RegisterFlagCompletionFunc(flag, fn); err != nil {
+		panic(fmt.Sprintf("completion for --%s: %v", flag, err))
+	}
+}
@@ -0,0 +1,231 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dirtybirdnj/clood/internal/config"
+	"github.com/dirtybirdnj/clood/internal/output"
+	"github.com/dirtybirdnj/clood/internal/tui"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+func ConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "View and change config values",
+		Long: `Reads and writes single values in the active config (config.yaml, or the
+--profile one) by dot-separated key. Lists are indexed from 0.
+
+get and list show the values in effect, including CLOOD_<KEY> environment
+overrides; set writes the file, and warns when an environment variable
+would still override the new value.
+
+Examples:
+  clood config list
+  clood config get tiers.fast.model
+  clood config get hosts              # a whole section, as YAML
+  clood config set tiers.fast.model qwen2.5-coder:7b
+  clood config set hosts.0.url http://gpu-server:11434
+  clood config reset`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listConfig()
+		},
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "Show every config value",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listConfig()
+		},
+	})
+	cmd.AddCommand(configGetCmd())
+	cmd.AddCommand(configSetCmd())
+	cmd.AddCommand(configResetCmd())
+
+	return cmd
+}
+
+func listConfig() error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	values, err := config.Values(cfg)
+	if err != nil {
+		return err
+	}
+	if output.IsJSON() {
+		return output.JSON(map[string]interface{}{
+			"path":   config.ConfigPath(),
+			"values": values,
+		})
+	}
+
+	width := 0
+	for _, kv := range values {
+		width = max(width, len(kv.Key))
+	}
+	fmt.Println(tui.MutedStyle.Render(config.ConfigPath()))
+	for _, kv := range values {
+		line := fmt.Sprintf("%-*s  %s", width, kv.Key, kv.Value)
+		if _, ok := os.LookupEnv(config.EnvName(kv.Key)); ok {
+			line += tui.MutedStyle.Render("  (from " + config.EnvName(kv.Key) + ")")
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
+
+func configGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print one config value or section",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+			node, err := config.Get(cfg, args[0])
+			if err != nil {
+				return err
+			}
+
+			if output.IsJSON() {
+				var value interface{}
+				if err := node.Decode(&value); err != nil {
+					return err
+				}
+				return output.JSON(map[string]interface{}{"key": args[0], "value": value})
+			}
+			if node.Kind == yaml.ScalarNode {
+				fmt.Println(node.Value)
+				return nil
+			}
+			data, err := yaml.Marshal(node)
+			if err != nil {
+				return err
+			}
+			fmt.Print(string(data))
+			return nil
+		},
+	}
+}
+
+func configSetCmd() *cobra.Command {
+	return withDryRun(&cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Change one config value and save",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key, value := args[0], args[1]
+
+			cfg, err := config.LoadFile()
+			if err != nil {
+				return err
+			}
+			old := ""
+			if node, err := config.Get(cfg, key); err == nil && node.Kind == yaml.ScalarNode {
+				old = node.Value
+			}
+			if err := config.Set(cfg, key, value); err != nil {
+				return err
+			}
+
+			if output.DryRun {
+				return output.WouldHaveDone("set "+key+" in "+config.ConfigPath(), map[string]interface{}{
+					"old": old,
+					"new": value,
+				})
+			}
+			if err := config.Save(cfg); err != nil {
+				return err
+			}
+
+			if output.IsJSON() {
+				return output.JSON(map[string]interface{}{
+					"key":   key,
+					"old":   old,
+					"value": value,
+					"path":  config.ConfigPath(),
+				})
+			}
+			fmt.Printf("%s %s = %s\n", tui.SuccessStyle.Render("✓"), key, value)
+			if env := config.EnvName(key); os.Getenv(env) != "" {
+				fmt.Println(tui.WarningStyle.Render("  " + env + " is set and still overrides this value"))
+			}
+			return nil
+		},
+	})
+}
+
+func configResetCmd() *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "reset",
+		Short: "Restore the default config",
+		Long: `Replaces the active config with the defaults. The old file is kept as
+<config>.bak.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := config.ProfilePath(config.ActiveProfile)
+			if err != nil {
+				return err
+			}
+			if output.DryRun {
+				return output.WouldHaveDone("reset "+path+" to defaults", map[string]interface{}{
+					"backup": path + ".bak",
+				})
+			}
+
+			_, statErr := os.Stat(path)
+			exists := statErr == nil
+			if exists && !yes {
+				if output.IsJSON() {
+					return fmt.Errorf("reset with --json needs --yes; there's no prompt to confirm at")
+				}
+				fmt.Print(tui.WarningStyle.Render(fmt.Sprintf("Replace %s with the defaults? [y/N] ", path)))
+				response, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+				response = strings.TrimSpace(strings.ToLower(response))
+				if response != "y" && response != "yes" {
+					fmt.Println(tui.MutedStyle.Render("Config unchanged."))
+					return nil
+				}
+			}
+
+			backup := ""
+			if exists {
+				data, err := os.ReadFile(path)
+				if err != nil {
+					return err
+				}
+				backup = path + ".bak"
+				if err := os.WriteFile(backup, data, 0644); err != nil {
+					return fmt.Errorf("backing up config: %w", err)
+				}
+			}
+			if err := config.SaveTo(config.DefaultConfig(), path); err != nil {
+				return err
+			}
+
+			if output.IsJSON() {
+				return output.JSON(map[string]interface{}{"path": path, "backup": backup})
+			}
+			fmt.Println(tui.SuccessStyle.Render("✓ Reset " + path + " to defaults"))
+			if backup != "" {
+				fmt.Println(tui.MutedStyle.Render("  Previous config saved as " + backup))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Don't ask before replacing the config")
+	return withDryRun(cmd)
+}
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/dirtybirdnj/clood/internal/context"
 	"github.com/dirtybirdnj/clood/internal/tui"
 	"github.com/spf13/cobra"
 )
@@ -14,31 +15,64 @@ func ContextCmd() *cobra.Command {
 	var maxTokens int
 	var includeTree bool
 	var includeReadme bool
+	var save string
 
 	cmd := &cobra.Command{
 		Use:   "context [path]",
 		Short: "Generate LLM-optimized context",
-		Long:  "Generate a context blob optimized for LLM consumption, sized to fit token limits.",
-		Args:  cobra.MaximumNArgs(1),
+		Long: `Generate a context blob optimized for LLM consumption, sized to fit token limits.
+
+If the project has a CONTEXT.md, its notes are included in the summary.
+--save writes the summary to a file to commit or share. Saving to
+CONTEXT.md keeps any notes already in it and replaces only the generated
+section, so the notes grow alongside the summary.
+
+Examples:
+  clood context
+  clood context --save CONTEXT.md
+  clood context ../api --tokens 8000 --save /tmp/api-context.md`,
+		Args: cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			path := "."
 			if len(args) > 0 {
 				path = args[0]
 			}
 
-			context := generateContext(path, maxTokens, includeTree, includeReadme)
-			fmt.Println(context)
+			if save == "" {
+				fmt.Println(generateContext(path, maxTokens, includeTree, includeReadme, true))
+				return
+			}
+
+			// The notes file's own notes sit outside the generated block,
+			// so don't repeat them inside it
+			notesPath, _ := filepath.Abs(filepath.Join(path, context.NotesFile))
+			savePath, _ := filepath.Abs(save)
+			includeNotes := savePath != notesPath
+
+			generated := generateContext(path, maxTokens, includeTree, includeReadme, includeNotes)
+			content := generated
+			if !includeNotes {
+				existing, _ := os.ReadFile(save)
+				content = context.MergeNotes(string(existing), generated)
+			}
+
+			if err := os.WriteFile(save, []byte(content), 0644); err != nil {
+				fmt.Println(tui.ErrorStyle.Render("Error writing " + save + ": " + err.Error()))
+				return
+			}
+			fmt.Println(tui.SuccessStyle.Render("✓ Context saved to " + save))
 		},
 	}
 
 	cmd.Flags().IntVarP(&maxTokens, "tokens", "t", 4000, "Target token count (approximate)")
 	cmd.Flags().BoolVar(&includeTree, "tree", true, "Include directory tree")
 	cmd.Flags().BoolVar(&includeReadme, "readme", true, "Include README content")
+	cmd.Flags().StringVar(&save, "save", "", "Write the context to a file (CONTEXT.md keeps its notes)")
 
 	return cmd
 }
 
-func generateContext(path string, maxTokens int, includeTree bool, includeReadme bool) string {
+func generateContext(path string, maxTokens int, includeTree bool, includeReadme bool, includeNotes bool) string {
 	var sb strings.Builder
 
 	absPath, _ := filepath.Abs(path)
@@ -57,6 +91,18 @@ func generateContext(path string, maxTokens int, includeTree bool, includeReadme
 		sb.WriteString("\n")
 	}
 
+	// Notes developers keep in CONTEXT.md come before anything derived
+	if includeNotes {
+		if notes := context.ReadNotes(path); notes != "" {
+			if maxChars := maxTokens * 2; len(notes) > maxChars {
+				notes = notes[:maxChars] + "\n\n... (truncated)"
+			}
+			sb.WriteString("## Project Notes (" + context.NotesFile + ")\n\n")
+			sb.WriteString(notes)
+			sb.WriteString("\n\n")
+		}
+	}
+
 	// Include README if present and requested
 	if includeReadme {
 		readmeContent := findAndReadReadme(path)
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -11,6 +12,7 @@ import (
 	"github.com/dirtybirdnj/clood/internal/agents"
 	"github.com/dirtybirdnj/clood/internal/config"
 	"github.com/dirtybirdnj/clood/internal/hosts"
+	"github.com/dirtybirdnj/clood/internal/ollama"
 	"github.com/dirtybirdnj/clood/internal/output"
 	"github.com/dirtybirdnj/clood/internal/tui"
 	"github.com/spf13/cobra"
@@ -34,10 +36,10 @@ func DelegateCmd() *cobra.Command {
 	var hostName string
 	var model string
 	var files []string
-	var outputJSON bool
 	var quiet bool
 	var formatOutput bool
 	var taskType string
+	var noStream bool
 
 	cmd := &cobra.Command{
 		Use:   "delegate [task]",
@@ -60,10 +62,14 @@ Examples:
   # Delegate to any host without specifying agent
   clood delegate --host ubuntu25 "Summarize the codebase structure"
 
+  # Pin an exact host/model - no tier routing, no fallback model
+  clood delegate --host ubuntu25 --model qwen2.5-coder:7b "refactor this function"
+
   # Get JSON output for scripts
   clood delegate --agent reviewer --json "Review internal/"`,
 		Args: cobra.MinimumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
+			outputJSON := output.IsJSON()
 			start := time.Now()
 
 			task := strings.Join(args, " ")
@@ -81,6 +87,7 @@ Examples:
 			// Load agent configuration
 			var agent *agents.Agent
 			var system string
+			var options map[string]interface{}
 
 			if agentName != "" {
 				agentCfg := agents.LoadConfigWithFallback()
@@ -99,6 +106,7 @@ Examples:
 					model = agent.Model
 				}
 				system = agent.System
+				options = agent.Options()
 			} else {
 				result.Agent = "default"
 			}
@@ -132,12 +140,18 @@ Examples:
 			result.Host = hostName
 			client := mgr.GetClient(hostName)
 
-			// Select model
+			// Select model. An explicit --model is honored exactly; agent and
+			// tier defaults may fall back to whatever the host has.
+			pinnedModel := cmd.Flags().Changed("model")
 			if model == "" {
 				model = cfg.Tiers.Fast.Model
 			}
 
 			// Verify model or use first available
+			if pinnedModel && !hostHasModel(status, model) {
+				outputDelegateError(outputJSON, quiet, fmt.Sprintf("Model %s not found on %s\nRun 'clood models --host %s' to list its models", model, hostName, hostName))
+				return
+			}
 			if !hostHasModel(status, model) {
 				if len(status.Models) > 0 {
 					model = status.Models[0].Name
@@ -165,8 +179,32 @@ Examples:
 					hostName)
 			}
 
+			// Stream tokens to the terminal unless output must be post-processed
+			if !noStream && !outputJSON && !formatOutput {
+				if !quiet {
+					fmt.Println(tui.RenderHeader("Results"))
+					fmt.Println()
+				}
+				resp, err := client.GenerateStreamWithSystemOptions(context.Background(), model, system, prompt, options, func(chunk ollama.GenerateResponse) {
+					fmt.Print(chunk.Response)
+				})
+				fmt.Println()
+				result.DurationMs = time.Since(start).Milliseconds()
+				if err != nil {
+					result.Error = err.Error()
+					outputDelegateResult(result, outputJSON, quiet, false, taskType)
+					return
+				}
+				result.Tokens = resp.EvalCount
+				if !quiet {
+					fmt.Println()
+					printDelegateFooter(result)
+				}
+				return
+			}
+
 			// Execute
-			resp, err := client.GenerateWithSystem(model, system, prompt)
+			resp, err := client.GenerateWithSystemOptions(context.Background(), model, system, prompt, options)
 			if err != nil {
 				result.Error = err.Error()
 				result.DurationMs = time.Since(start).Milliseconds()
@@ -186,10 +224,10 @@ Examples:
 	cmd.Flags().StringVarP(&hostName, "host", "H", "", "Target host (overrides agent config)")
 	cmd.Flags().StringVarP(&model, "model", "m", "", "Model to use (overrides agent config)")
 	cmd.Flags().StringArrayVarP(&files, "file", "f", nil, "Include file(s) as context")
-	cmd.Flags().BoolVar(&outputJSON, "json", false, "Output result as JSON")
 	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Quiet mode - only output response")
 	cmd.Flags().BoolVar(&formatOutput, "format", false, "Parse response into structured output with issues/actions")
 	cmd.Flags().StringVar(&taskType, "task-type", "review", "Task type for formatting: review, generate, document, analyze")
+	cmd.Flags().BoolVar(&noStream, "no-stream", false, "Wait for the full response instead of streaming tokens")
 
 	return cmd
 }
@@ -272,7 +310,7 @@ func outputDelegateResult(result DelegateResult, outputJSON, quiet, formatOutput
 
 		if outputJSON {
 			// Full structured JSON output (Strata-compatible)
-			data, _ := json.MarshalIndent(parsed, "", "  ")
+			data, _ := output.Marshal(parsed)
 			fmt.Println(string(data))
 		} else if !quiet {
 			// Formatted terminal output
@@ -287,7 +325,7 @@ func outputDelegateResult(result DelegateResult, outputJSON, quiet, formatOutput
 	}
 
 	if outputJSON {
-		data, _ := json.MarshalIndent(result, "", "  ")
+		data, _ := output.Marshal(result)
 		fmt.Println(string(data))
 		return
 	}
@@ -310,7 +348,11 @@ func outputDelegateResult(result DelegateResult, outputJSON, quiet, formatOutput
 	fmt.Println(result.Response)
 	fmt.Println()
 
-	// Metadata footer
+	printDelegateFooter(result)
+}
+
+// printDelegateFooter prints the agent/model/host/time metadata line
+func printDelegateFooter(result DelegateResult) {
 	fmt.Println(tui.MutedStyle.Render("───────────────────────────────────────"))
 	agentDisplay := result.Agent
 	if agentDisplay == "default" {
@@ -2,7 +2,6 @@ package commands
 
 import (
 	"bufio"
-	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -107,7 +106,7 @@ Examples:
 			}
 
 			if len(lines) == 0 {
-				if !output.JSONMode {
+				if !output.IsJSON() {
 					fmt.Println(tui.MutedStyle.Render("No log lines to analyze"))
 				}
 				return nil
@@ -126,7 +125,7 @@ Examples:
 				}
 			}
 
-			if !output.JSONMode {
+			if !output.IsJSON() {
 				fmt.Println(tui.RenderHeader("DIAGNOSE"))
 				fmt.Println()
 				fmt.Printf("  %s %d lines\n", tui.MutedStyle.Render("Analyzing:"), len(lines))
@@ -161,7 +160,7 @@ Examples:
 				return fmt.Errorf("no online Ollama hosts found")
 			}
 
-			if !output.JSONMode {
+			if !output.IsJSON() {
 				fmt.Printf("  %s %s on %s\n",
 					tui.AccentStyle.Render(""),
 					selectedModel,
@@ -176,14 +175,14 @@ Examples:
 			client := ollama.NewClient(onlineHost.Host.URL, 5*time.Minute)
 			startTime := time.Now()
 
-			if !output.JSONMode {
+			if !output.IsJSON() {
 				fmt.Print(tui.MutedStyle.Render("  Analyzing..."))
 			}
 
 			resp, err := client.Generate(selectedModel, prompt)
 			duration := time.Since(startTime)
 
-			if !output.JSONMode {
+			if !output.IsJSON() {
 				fmt.Print("\r                    \r")
 			}
 
@@ -202,8 +201,8 @@ Examples:
 				WarningCount: warningCount,
 			}
 
-			if output.JSONMode {
-				data, _ := json.MarshalIndent(diagOutput, "", "  ")
+			if output.IsJSON() {
+				data, _ := output.Marshal(diagOutput)
 				fmt.Println(string(data))
 				return nil
 			}
@@ -0,0 +1,287 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dirtybirdnj/clood/internal/config"
+	"github.com/dirtybirdnj/clood/internal/hosts"
+	"github.com/dirtybirdnj/clood/internal/memory"
+	"github.com/dirtybirdnj/clood/internal/ollama"
+	"github.com/dirtybirdnj/clood/internal/output"
+	"github.com/dirtybirdnj/clood/internal/system"
+	"github.com/dirtybirdnj/clood/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// ModelDiff is how closely two models' answers to one prompt agree
+type ModelDiff struct {
+	Prompt     string  `json:"prompt"`
+	ResponseA  string  `json:"response_a"`
+	ResponseB  string  `json:"response_b"`
+	Similarity float64 `json:"similarity"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// ModelDiffReport is the output of 'clood diff-models'
+type ModelDiffReport struct {
+	ModelA  string      `json:"model_a"`
+	ModelB  string      `json:"model_b"`
+	HostA   string      `json:"host_a"`
+	HostB   string      `json:"host_b"`
+	Metric  string      `json:"metric"`
+	Average float64     `json:"average_similarity"`
+	Diffs   []ModelDiff `json:"diffs"` // most divergent first
+}
+
+func DiffModelsCmd() *cobra.Command {
+	var modelA, modelB string
+	var promptsPath string
+	var metric string
+	var embedModel string
+	var top int
+	var showResponses bool
+
+	cmd := &cobra.Command{
+		Use:   "diff-models",
+		Short: "Show which prompts two models disagree on most",
+		Long: `Runs every prompt in a file (one per line, # for comments) on two models
+and scores how similar their answers are, then lists the prompts ranked
+from most to least divergent. Useful for seeing which kinds of task make
+the choice of model matter.
+
+Metrics:
+  rouge1     Word overlap (ROUGE-1 F1). Fast, no extra model. (default)
+  embedding  Cosine similarity of --embed-model embeddings; catches
+             answers that agree in different words.
+
+Examples:
+  clood diff-models --model-a llama3.1:8b --model-b qwen2.5:7b --prompts prompts.txt
+  clood diff-models -a llama3.1:8b -b qwen2.5:7b --prompts prompts.txt --metric embedding --show`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if metric != "rouge1" && metric != "embedding" {
+				return fmt.Errorf("unknown metric %q (use rouge1 or embedding)", metric)
+			}
+			prompts, err := system.LoadPromptLines(promptsPath)
+			if err != nil {
+				return err
+			}
+
+			cfg, err := config.Load()
+			if err != nil {
+				return fmt.Errorf("loading config: %w", err)
+			}
+			mgr := hosts.NewManager()
+			mgr.AddHosts(cfg.Hosts)
+			statuses := mgr.CheckAllHosts()
+
+			hostA := onlineHostWithModel(statuses, modelA)
+			if hostA == nil {
+				return fmt.Errorf("no online host has %s", modelA)
+			}
+			hostB := onlineHostWithModel(statuses, modelB)
+			if hostB == nil {
+				return fmt.Errorf("no online host has %s", modelB)
+			}
+
+			var embed memory.Embedder
+			if metric == "embedding" {
+				embedHost := onlineHostWithModel(statuses, embedModel)
+				if embedHost == nil {
+					return fmt.Errorf("no online host has %s (try: ollama pull %s)", embedModel, embedModel)
+				}
+				embedClient := ollama.NewClient(embedHost.Host.URL, 60*time.Second)
+				embed = func(text string) ([]float64, error) {
+					return embedClient.Embeddings(embedModel, text)
+				}
+			}
+
+			clientA := ollama.NewClient(hostA.Host.URL, 5*time.Minute)
+			clientB := ollama.NewClient(hostB.Host.URL, 5*time.Minute)
+
+			report := ModelDiffReport{
+				ModelA: modelA,
+				ModelB: modelB,
+				HostA:  hostA.Host.Name,
+				HostB:  hostB.Host.Name,
+				Metric: metric,
+			}
+
+			if !output.IsJSON() {
+				fmt.Fprintf(os.Stderr, "%s %s (%s) vs %s (%s), %d prompts\n",
+					tui.MutedStyle.Render("Comparing:"), modelA, hostA.Host.Name, modelB, hostB.Host.Name, len(prompts))
+			}
+
+			scored := 0
+			for i, prompt := range prompts {
+				if !output.IsJSON() {
+					fmt.Fprintf(os.Stderr, "\r%s %d/%d", tui.MutedStyle.Render("Running:"), i+1, len(prompts))
+				}
+				d := diffPrompt(clientA, modelA, clientB, modelB, prompt, embed)
+				if d.Error == "" {
+					report.Average += d.Similarity
+					scored++
+				}
+				report.Diffs = append(report.Diffs, d)
+			}
+			if !output.IsJSON() {
+				fmt.Fprintln(os.Stderr)
+			}
+			if scored > 0 {
+				report.Average /= float64(scored)
+			}
+
+			// Failed prompts sort last; they say nothing about agreement
+			sort.SliceStable(report.Diffs, func(i, j int) bool {
+				di, dj := report.Diffs[i], report.Diffs[j]
+				if (di.Error == "") != (dj.Error == "") {
+					return di.Error == ""
+				}
+				return di.Similarity < dj.Similarity
+			})
+
+			if output.IsJSON() {
+				return output.JSON(report)
+			}
+			printModelDiffReport(report, top, showResponses)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&modelA, "model-a", "a", "", "First model (required)")
+	cmd.Flags().StringVarP(&modelB, "model-b", "b", "", "Second model (required)")
+	cmd.Flags().StringVar(&promptsPath, "prompts", "", "File with one prompt per line (required)")
+	cmd.Flags().StringVar(&metric, "metric", "rouge1", "Similarity metric: rouge1 or embedding")
+	cmd.Flags().StringVar(&embedModel, "embed-model", "nomic-embed-text", "Embedding model for --metric embedding")
+	cmd.Flags().IntVarP(&top, "top", "n", 0, "Show only the N most divergent prompts (0 for all)")
+	cmd.Flags().BoolVar(&showResponses, "show", false, "Print both responses for each listed prompt")
+	cmd.MarkFlagRequired("model-a")
+	cmd.MarkFlagRequired("model-b")
+	cmd.MarkFlagRequired("prompts")
+
+	return cmd
+}
+
+// onlineHostWithModel returns the first online host (statuses are in
+// priority order) that has model, or nil
+func onlineHostWithModel(statuses []*hosts.HostStatus, model string) *hosts.HostStatus {
+	for _, s := range statuses {
+		if !s.Online {
+			continue
+		}
+		for _, m := range s.Models {
+			if m.Name == model || (!strings.Contains(model, ":") && m.Name == model+":latest") {
+				return s
+			}
+		}
+	}
+	return nil
+}
+
+// diffPrompt runs prompt on both models at once and scores the answers
+func diffPrompt(clientA *ollama.Client, modelA string, clientB *ollama.Client, modelB, prompt string, embed memory.Embedder) ModelDiff {
+	d := ModelDiff{Prompt: prompt}
+
+	var wg sync.WaitGroup
+	var errA, errB error
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		resp, err := clientA.Generate(modelA, prompt)
+		if err == nil {
+			d.ResponseA = resp.Response
+		}
+		errA = err
+	}()
+	go func() {
+		defer wg.Done()
+		resp, err := clientB.Generate(modelB, prompt)
+		if err == nil {
+			d.ResponseB = resp.Response
+		}
+		errB = err
+	}()
+	wg.Wait()
+
+	switch {
+	case errA != nil:
+		d.Error = modelA + ": " + errA.Error()
+		return d
+	case errB != nil:
+		d.Error = modelB + ": " + errB.Error()
+		return d
+	}
+
+	if embed == nil {
+		d.Similarity = system.Rouge1(d.ResponseA, d.ResponseB)
+		return d
+	}
+	va, err := embed(d.ResponseA)
+	if err != nil {
+		d.Error = "embedding: " + err.Error()
+		return d
+	}
+	vb, err := embed(d.ResponseB)
+	if err != nil {
+		d.Error = "embedding: " + err.Error()
+		return d
+	}
+	d.Similarity = memory.CosineSimilarity(va, vb)
+	return d
+}
+
+func printModelDiffReport(report ModelDiffReport, top int, showResponses bool) {
+	fmt.Println(tui.RenderHeader(fmt.Sprintf("%s vs %s", report.ModelA, report.ModelB)))
+	fmt.Println()
+	fmt.Println(tui.MutedStyle.Render("  Most divergent first (" + report.Metric + " similarity, 1.00 = identical)"))
+	fmt.Println()
+
+	diffs := report.Diffs
+	if top > 0 && len(diffs) > top {
+		diffs = diffs[:top]
+	}
+
+	for i, d := range diffs {
+		prompt := strings.Join(strings.Fields(d.Prompt), " ")
+		if len(prompt) > 64 {
+			prompt = prompt[:61] + "..."
+		}
+		if d.Error != "" {
+			fmt.Printf("  %2d.   %s  %-64s\n", i+1, tui.ErrorStyle.Render(" err"), prompt)
+			fmt.Printf("        %s\n", tui.ErrorStyle.Render(d.Error))
+			continue
+		}
+
+		score := fmt.Sprintf("%.2f", d.Similarity)
+		switch {
+		case d.Similarity < 0.3:
+			score = tui.ErrorStyle.Render(score)
+		case d.Similarity < 0.6:
+			score = tui.WarningStyle.Render(score)
+		default:
+			score = tui.SuccessStyle.Render(score)
+		}
+		fmt.Printf("  %2d.  %s  %s\n", i+1, score, prompt)
+
+		if showResponses {
+			fmt.Printf("        %s %s\n", tui.AccentStyle.Render(report.ModelA+":"), indentResponse(d.ResponseA))
+			fmt.Printf("        %s %s\n", tui.AccentStyle.Render(report.ModelB+":"), indentResponse(d.ResponseB))
+			fmt.Println()
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("  %s %.2f across %d prompts\n", tui.MutedStyle.Render("Average similarity:"), report.Average, len(report.Diffs))
+}
+
+func indentResponse(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) > 400 {
+		s = s[:400] + "..."
+	}
+	return strings.ReplaceAll(s, "\n", "\n          ")
+}
@@ -6,11 +6,15 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	neturl "net/url"
 	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/dirtybirdnj/clood/internal/config"
+	"github.com/dirtybirdnj/clood/internal/hosts"
+	"github.com/dirtybirdnj/clood/internal/output"
 	"github.com/dirtybirdnj/clood/internal/tui"
 	"github.com/spf13/cobra"
 )
@@ -34,13 +38,14 @@ type DiscoverResult struct {
 	FoundHosts  int              `json:"found_hosts"`
 	ScanTimeMs  int64            `json:"scan_time_ms"`
 	Hosts       []DiscoveredHost `json:"hosts"`
+	Saved       []string         `json:"saved,omitempty"` // config names added by --save
 }
 
 func DiscoverCmd() *cobra.Command {
-	var jsonOutput bool
 	var timeout int
 	var port int
 	var subnet string
+	var save bool
 
 	cmd := &cobra.Command{
 		Use:   "discover",
@@ -50,12 +55,24 @@ func DiscoverCmd() *cobra.Command {
 Scans the local subnet for machines with Ollama running on port 11434.
 Useful for multi-machine setup and debugging.
 
+The subnet is the /24 of the interface that carries the default route,
+so a VPN or Docker bridge doesn't get scanned by mistake. Every address
+is probed at once, each with --timeout to answer /api/version.
+
+--save adds the hosts that aren't configured yet to the config, named
+after their reverse DNS name (or host-<last octet>), after the existing
+hosts in priority.
+
+Also available as 'clood hosts discover'.
+
 Examples:
   clood discover                    # Scan local subnet
   clood discover --subnet 192.168.1.0/24  # Scan specific subnet
   clood discover --timeout 200      # Faster scan with shorter timeout
+  clood discover --save             # Add what was found to the config
   clood discover --json             # JSON output for scripts`,
 		Run: func(cmd *cobra.Command, args []string) {
+			jsonOutput := output.IsJSON()
 			startTime := time.Now()
 
 			// Get local IP and subnet
@@ -93,8 +110,23 @@ Examples:
 				Hosts:       hosts,
 			}
 
+			var saveErr error
+			if save && len(hosts) > 0 {
+				if output.DryRun {
+					names := make([]string, len(hosts))
+					for i, host := range hosts {
+						names[i] = discoveredHostName(host)
+					}
+					output.WouldHaveDone("add discovered hosts to "+config.ConfigPath(), map[string]interface{}{
+						"hosts": strings.Join(names, ", "),
+					})
+					return
+				}
+				result.Saved, saveErr = saveDiscoveredHosts(hosts)
+			}
+
 			if jsonOutput {
-				data, _ := json.MarshalIndent(result, "", "  ")
+				data, _ := output.Marshal(result)
 				fmt.Println(string(data))
 				return
 			}
@@ -121,26 +153,35 @@ Examples:
 					fmt.Printf("    %s %s\n", tui.MutedStyle.Render("Version:"), host.Version)
 					fmt.Printf("    %s %dms\n", tui.MutedStyle.Render("Latency:"), host.Latency)
 					if len(host.Models) > 0 {
-						fmt.Printf("    %s %s\n", tui.MutedStyle.Render("Models:"), strings.Join(host.Models, ", "))
+						fmt.Printf("    %s %d (%s)\n", tui.MutedStyle.Render("Models:"), len(host.Models), strings.Join(host.Models, ", "))
+					} else {
+						fmt.Printf("    %s 0\n", tui.MutedStyle.Render("Models:"))
 					}
 					fmt.Println()
 				}
 
-				// Suggest config additions
-				fmt.Println(tui.RenderHeader("Suggested Config"))
-				fmt.Println()
-				fmt.Println(tui.MutedStyle.Render("Add to ~/.config/clood/config.yaml:"))
-				fmt.Println()
-				fmt.Println("hosts:")
-				for i, host := range hosts {
-					name := fmt.Sprintf("host-%d", i+1)
-					if host.Hostname != "" {
-						name = sanitizeHostname(host.Hostname)
+				if save {
+					switch {
+					case saveErr != nil:
+						fmt.Println(tui.ErrorStyle.Render("  Error saving config: " + saveErr.Error()))
+					case len(result.Saved) == 0:
+						fmt.Println(tui.MutedStyle.Render("  All of them are already configured."))
+					default:
+						fmt.Println(tui.SuccessStyle.Render(fmt.Sprintf("  ✓ Added %s to %s", strings.Join(result.Saved, ", "), config.ConfigPath())))
+					}
+				} else {
+					// Suggest config additions
+					fmt.Println(tui.RenderHeader("Suggested Config"))
+					fmt.Println()
+					fmt.Println(tui.MutedStyle.Render("Add to ~/.config/clood/config.yaml (or rerun with --save):"))
+					fmt.Println()
+					fmt.Println("hosts:")
+					for i, host := range hosts {
+						fmt.Printf("  - name: %s\n", discoveredHostName(host))
+						fmt.Printf("    url: http://%s:%d\n", host.IP, host.Port)
+						fmt.Printf("    priority: %d\n", i+1)
+						fmt.Printf("    enabled: true\n")
 					}
-					fmt.Printf("  - name: %s\n", name)
-					fmt.Printf("    url: http://%s:%d\n", host.IP, host.Port)
-					fmt.Printf("    priority: %d\n", i+1)
-					fmt.Printf("    enabled: true\n")
 				}
 			}
 
@@ -152,31 +193,38 @@ Examples:
 		},
 	}
 
-	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 	cmd.Flags().IntVar(&timeout, "timeout", 500, "Connection timeout per host in ms")
 	cmd.Flags().IntVar(&port, "port", 11434, "Port to scan for Ollama")
 	cmd.Flags().StringVar(&subnet, "subnet", "", "Subnet to scan (default: auto-detect)")
+	cmd.Flags().BoolVar(&save, "save", false, "Add discovered hosts that aren't configured yet to the config")
 
-	return cmd
+	return withDryRun(cmd)
 }
 
-// getLocalNetwork returns the local IP and subnet
+// getLocalNetwork returns the local IP and its /24 subnet, preferring the
+// interface the default route goes out of
 func getLocalNetwork() (string, string) {
-	// Get all network interfaces
-	addrs, err := net.InterfaceAddrs()
+	if ip := defaultRouteIP(); ip != nil {
+		return ip.String(), subnet24(ip)
+	}
+
+	// No route out: the first up IPv4 interface
+	ifaces, err := net.Interfaces()
 	if err != nil {
 		return "unknown", "192.168.1.0/24"
 	}
-
-	for _, addr := range addrs {
-		if ipnet, ok := addr.(*net.IPNet); ok && !ipnet.IP.IsLoopback() {
-			if ipnet.IP.To4() != nil {
-				ip := ipnet.IP.String()
-				// Calculate /24 subnet
-				parts := strings.Split(ip, ".")
-				if len(parts) == 4 {
-					subnet := fmt.Sprintf("%s.%s.%s.0/24", parts[0], parts[1], parts[2])
-					return ip, subnet
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			if ipnet, ok := addr.(*net.IPNet); ok {
+				if ip := ipnet.IP.To4(); ip != nil && !ip.IsLinkLocalUnicast() {
+					return ip.String(), subnet24(ip)
 				}
 			}
 		}
@@ -185,6 +233,27 @@ func getLocalNetwork() (string, string) {
 	return "unknown", "192.168.1.0/24"
 }
 
+// defaultRouteIP is the local address the OS picks for reaching the
+// internet. Connecting a UDP socket only selects the route; nothing is sent.
+func defaultRouteIP() net.IP {
+	conn, err := net.Dial("udp4", "192.0.2.1:9") // TEST-NET-1
+	if err != nil {
+		return nil
+	}
+	defer conn.Close()
+	addr, ok := conn.LocalAddr().(*net.UDPAddr)
+	if !ok || addr.IP.IsLoopback() {
+		return nil
+	}
+	return addr.IP.To4()
+}
+
+// subnet24 is the /24 holding ip
+func subnet24(ip net.IP) string {
+	ip = ip.To4()
+	return fmt.Sprintf("%d.%d.%d.0/24", ip[0], ip[1], ip[2])
+}
+
 // generateIPs creates a list of IPs to scan from a CIDR
 func generateIPs(cidr string) []string {
 	var ips []string
@@ -229,8 +298,8 @@ func scanForOllama(ips []string, port int, timeoutMs int) []DiscoveredHost {
 	var mu sync.Mutex
 	var wg sync.WaitGroup
 
-	// Semaphore for rate limiting (50 concurrent connections)
-	sem := make(chan struct{}, 50)
+	// A whole /24 is probed at once; bigger ranges go 256 at a time
+	sem := make(chan struct{}, 256)
 
 	for _, ip := range ips {
 		wg.Add(1)
@@ -334,3 +403,97 @@ func sanitizeHostname(hostname string) string {
 	name = strings.ToLower(name)
 	return name
 }
+
+// discoveredHostName is the config name for a discovered host: its reverse
+// DNS name, or host-<last octet>
+func discoveredHostName(host DiscoveredHost) string {
+	if host.Hostname != "" {
+		return sanitizeHostname(host.Hostname)
+	}
+	return "host-" + host.IP[strings.LastIndex(host.IP, ".")+1:]
+}
+
+// configuredHostAddrs is the ip:port of every configured host. A loopback
+// host also stands for each of this machine's own addresses, since the
+// scan finds the local Ollama at its LAN IP rather than 127.0.0.1.
+func configuredHostAddrs(configured []*hosts.Host, local []string) map[string]bool {
+	addrs := map[string]bool{}
+	for _, h := range configured {
+		u, err := neturl.Parse(h.URL)
+		if err != nil {
+			continue
+		}
+		port := u.Port()
+		if port == "" {
+			port = "11434"
+		}
+		ips, err := net.LookupHost(u.Hostname())
+		if err != nil {
+			ips = []string{u.Hostname()}
+		}
+		for _, ip := range ips {
+			addrs[net.JoinHostPort(ip, port)] = true
+			if parsed := net.ParseIP(ip); parsed != nil && parsed.IsLoopback() {
+				for _, own := range local {
+					addrs[net.JoinHostPort(own, port)] = true
+				}
+			}
+		}
+	}
+	return addrs
+}
+
+// localIPs lists the addresses of this machine's network interfaces
+func localIPs() []string {
+	ifaceAddrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil
+	}
+	var ips []string
+	for _, addr := range ifaceAddrs {
+		if ipNet, ok := addr.(*net.IPNet); ok {
+			ips = append(ips, ipNet.IP.String())
+		}
+	}
+	return ips
+}
+
+// saveDiscoveredHosts adds the hosts whose URL isn't configured yet and
+// returns the names they were saved under
+func saveDiscoveredHosts(found []DiscoveredHost) ([]string, error) {
+	cfg, err := config.LoadFile()
+	if err != nil {
+		return nil, err
+	}
+
+	configured := configuredHostAddrs(cfg.Hosts, localIPs())
+	priority := 0
+	for _, h := range cfg.Hosts {
+		priority = max(priority, h.Priority)
+	}
+
+	var added []string
+	for _, host := range found {
+		if configured[net.JoinHostPort(host.IP, fmt.Sprint(host.Port))] {
+			continue
+		}
+		url := fmt.Sprintf("http://%s:%d", host.IP, host.Port)
+		name := discoveredHostName(host)
+		for n := 2; cfg.FindHost(name) != nil; n++ {
+			name = fmt.Sprintf("%s-%d", discoveredHostName(host), n)
+		}
+		priority++
+		if err := cfg.AddHost(&hosts.Host{Name: name, URL: url, Priority: priority, Enabled: true}); err != nil {
+			return nil, err
+		}
+		added = append(added, name)
+	}
+
+	if len(added) == 0 {
+		return nil, nil
+	}
+	if err := config.Save(cfg); err != nil {
+		return nil, err
+	}
+	return added, nil
+}
@@ -0,0 +1,31 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/dirtybirdnj/clood/internal/hosts"
+)
+
+func TestConfiguredHostAddrs(t *testing.T) {
+	local := []string{"127.0.0.1", "192.168.1.5", "fe80::1"}
+	tests := []struct {
+		url  string
+		addr string
+		want bool
+	}{
+		{"http://localhost:11434", "192.168.1.5:11434", true}, // the local Ollama found at its LAN IP
+		{"http://127.0.0.1:11434", "192.168.1.5:11434", true},
+		{"http://127.0.0.1", "192.168.1.5:11434", true}, // default port
+		{"http://127.0.0.1:11434", "[fe80::1]:11434", true},
+		{"http://127.0.0.1:11434", "192.168.1.5:8080", false}, // another port is another server
+		{"http://127.0.0.1:11434", "192.168.1.9:11434", false},
+		{"http://192.168.1.20:11434", "192.168.1.20:11434", true},
+		{"http://192.168.1.20:11434", "192.168.1.5:11434", false}, // only loopback stands for this machine
+	}
+	for _, tt := range tests {
+		addrs := configuredHostAddrs([]*hosts.Host{{Name: "h", URL: tt.url}}, local)
+		if addrs[tt.addr] != tt.want {
+			t.Errorf("configuredHostAddrs(%s)[%s] = %v; want %v", tt.url, tt.addr, addrs[tt.addr], tt.want)
+		}
+	}
+}
@@ -1,12 +1,14 @@
 package commands
 
 import (
-	"encoding/json"
 	"fmt"
+	"net/url"
 	"os/exec"
+	"time"
 
 	"github.com/dirtybirdnj/clood/internal/config"
 	"github.com/dirtybirdnj/clood/internal/hosts"
+	"github.com/dirtybirdnj/clood/internal/ollama"
 	"github.com/dirtybirdnj/clood/internal/output"
 	"github.com/dirtybirdnj/clood/internal/system"
 	"github.com/dirtybirdnj/clood/internal/tui"
@@ -26,6 +28,7 @@ type DiagnosticResult struct {
 type DoctorReport struct {
 	Hardware    HardwareDiag  `json:"hardware"`
 	Ollama      OllamaDiag    `json:"ollama"`
+	GPUBackend  GPUBackendDiag `json:"gpu_backend"`
 	Config      ConfigDiag    `json:"config"`
 	CLITools    []ToolDiag    `json:"cli_tools"`
 	Diagnostics []DiagnosticResult `json:"diagnostics"`
@@ -51,6 +54,17 @@ type OllamaDiag struct {
 	Status       string   `json:"status"`
 }
 
+// GPUBackendDiag compares the backend the local hardware should use with
+// what the local Ollama is actually doing
+type GPUBackendDiag struct {
+	Expected      string `json:"expected"` // "cuda", "rocm", "metal", "vulkan", "cpu"
+	Actual        string `json:"actual"`   // "gpu", "partial", "cpu", "unknown"
+	GPUPercent    int    `json:"gpu_percent,omitempty"`
+	LoadedModel   string `json:"loaded_model,omitempty"`
+	OllamaVersion string `json:"ollama_version,omitempty"`
+	Status        string `json:"status"`
+}
+
 type ConfigDiag struct {
 	Path      string `json:"path"`
 	Exists    bool   `json:"exists"`
@@ -74,7 +88,6 @@ type SummaryDiag struct {
 }
 
 func DoctorCmd() *cobra.Command {
-	var jsonOutput bool
 	var verbose bool
 
 	cmd := &cobra.Command{
@@ -90,11 +103,11 @@ Checks:
 
 Provides actionable recommendations with exact commands to fix issues.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			useJSON := jsonOutput || output.IsJSON()
+			useJSON := output.IsJSON()
 			report := runDiagnostics(verbose)
 
 			if useJSON {
-				data, _ := json.MarshalIndent(report, "", "  ")
+				data, _ := output.Marshal(report)
 				fmt.Println(string(data))
 				return
 			}
@@ -103,7 +116,6 @@ Provides actionable recommendations with exact commands to fix issues.`,
 		},
 	}
 
-	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Show detailed diagnostics")
 
 	return cmd
@@ -312,6 +324,11 @@ func runDiagnostics(verbose bool) DoctorReport {
 			})
 		}
 
+		// GPU backend check against the local Ollama
+		backend, backendDiags := checkGPUBackend(hw, statuses)
+		report.GPUBackend = backend
+		diagnostics = append(diagnostics, backendDiags...)
+
 		// Check tier models availability
 		if report.Ollama.ModelsCount > 0 {
 			allModels := mgr.GetAllModels()
@@ -372,6 +389,135 @@ func runDiagnostics(verbose bool) DoctorReport {
 	return report
 }
 
+// expectedGPUBackend maps the detected GPU to the backend Ollama should use
+func expectedGPUBackend(gpu *system.GPUInfo) string {
+	if gpu == nil {
+		return "cpu"
+	}
+	switch gpu.Type {
+	case "apple":
+		return "metal"
+	case "nvidia":
+		return "cuda"
+	case "amd":
+		return "rocm"
+	case "intel":
+		return "vulkan"
+	}
+	return "cpu"
+}
+
+// localHostStatus returns the online status for an Ollama on this machine
+func localHostStatus(statuses []*hosts.HostStatus) *hosts.HostStatus {
+	for _, s := range statuses {
+		if !s.Online || s.Host.Backend == hosts.BackendOpenAI {
+			continue
+		}
+		u, err := url.Parse(s.Host.URL)
+		if err != nil {
+			continue
+		}
+		switch u.Hostname() {
+		case "localhost", "127.0.0.1", "::1":
+			return s
+		}
+	}
+	return nil
+}
+
+// checkGPUBackend detects a GPU that Ollama isn't using. /api/version has
+// no backend info, so offload is read from /api/ps: a loaded model with
+// size_vram == 0 is running on the CPU.
+func checkGPUBackend(hw *system.HardwareInfo, statuses []*hosts.HostStatus) (GPUBackendDiag, []DiagnosticResult) {
+	local := localHostStatus(statuses)
+	if hw == nil || local == nil {
+		return GPUBackendDiag{}, nil
+	}
+
+	diag := GPUBackendDiag{
+		Expected:      expectedGPUBackend(hw.GPU),
+		Actual:        "unknown",
+		OllamaVersion: local.Version,
+		Status:        "ok",
+	}
+
+	client := ollama.NewClient(local.Host.URL, 5*time.Second)
+	running, err := client.RunningModels()
+	if err != nil || len(running) == 0 {
+		// Nothing loaded, so there is nothing to measure yet
+		return diag, nil
+	}
+
+	// Judge by the least-offloaded model: one CPU-bound model is enough to hurt
+	worst := running[0]
+	for _, m := range running[1:] {
+		if m.Size > 0 && worst.Size > 0 && float64(m.SizeVRAM)/float64(m.Size) < float64(worst.SizeVRAM)/float64(worst.Size) {
+			worst = m
+		}
+	}
+	diag.LoadedModel = worst.Name
+	if worst.Size > 0 {
+		diag.GPUPercent = int(float64(worst.SizeVRAM) * 100 / float64(worst.Size))
+	}
+	switch {
+	case worst.SizeVRAM == 0:
+		diag.Actual = "cpu"
+	case worst.SizeVRAM < worst.Size:
+		diag.Actual = "partial"
+	default:
+		diag.Actual = "gpu"
+	}
+
+	if diag.Expected == "cpu" {
+		return diag, nil
+	}
+
+	var diags []DiagnosticResult
+	switch diag.Actual {
+	case "cpu":
+		diag.Status = "warning"
+		diags = append(diags, DiagnosticResult{
+			Category:    "Ollama",
+			Name:        "GPU Backend",
+			Status:      "warning",
+			Message:     fmt.Sprintf("%s GPU detected but Ollama is running %s on CPU only", hw.GPU.Name, worst.Name),
+			Remediation: gpuBackendRemediation(diag.Expected),
+		})
+	case "partial":
+		diag.Status = "warning"
+		diags = append(diags, DiagnosticResult{
+			Category:    "Ollama",
+			Name:        "GPU Backend",
+			Status:      "warning",
+			Message:     fmt.Sprintf("%s is only %d%% on GPU (%s) - the rest runs on CPU", worst.Name, diag.GPUPercent, diag.Expected),
+			Remediation: "Use a smaller model/quant or lower num_ctx so it fits in VRAM",
+		})
+	default:
+		diags = append(diags, DiagnosticResult{
+			Category: "Ollama",
+			Name:     "GPU Backend",
+			Status:   "ok",
+			Message:  fmt.Sprintf("%s fully offloaded to GPU (%s)", worst.Name, diag.Expected),
+		})
+	}
+	return diag, diags
+}
+
+// gpuBackendRemediation suggests a fix for Ollama falling back to CPU
+func gpuBackendRemediation(backend string) string {
+	switch backend {
+	case "cuda":
+		return "Check 'nvidia-smi' works, update the NVIDIA driver, then restart Ollama (sudo systemctl restart ollama); 'journalctl -u ollama | grep -i cuda' shows why it fell back"
+	case "rocm":
+		return "Install ROCm (or use the ollama-linux-amd64-rocm build); for unsupported cards try HSA_OVERRIDE_GFX_VERSION (e.g. 10.3.0) or OLLAMA_VULKAN=1, then restart Ollama"
+	case "metal":
+		return "Run the native arm64 Ollama app, not an x86 build under Rosetta (check: file $(which ollama))"
+	case "vulkan":
+		return "Set OLLAMA_VULKAN=1 and restart Ollama to enable the Vulkan backend"
+	}
+	return "Restart Ollama and check its logs for GPU discovery errors"
+}
+
 func printDoctorReport(report DoctorReport, verbose bool) {
 	fmt.Println()
 	fmt.Println(tui.RenderHeader("CLOOD DOCTOR"))
@@ -386,6 +532,16 @@ func printDoctorReport(report DoctorReport, verbose bool) {
 		printDiagLine("GPU", report.Hardware.GPUType, "ok")
 	}
 	printDiagLine("VRAM", fmt.Sprintf("%.0fGB", report.Hardware.VRAMGB), statusFromValue(report.Hardware.VRAMGB, 8, 4))
+	if b := report.GPUBackend; b.Expected != "" {
+		value := b.Expected + " expected, Ollama on " + b.Actual
+		switch b.Actual {
+		case "partial":
+			value = fmt.Sprintf("%s expected, Ollama %d%% on GPU", b.Expected, b.GPUPercent)
+		case "unknown":
+			value = b.Expected + " expected (load a model to verify)"
+		}
+		printDiagLine("Backend", value, b.Status)
+	}
 	fmt.Println()
 
 	// Ollama section
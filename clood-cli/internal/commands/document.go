@@ -1,7 +1,6 @@
 package commands
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -72,7 +71,7 @@ Examples:
 				return fmt.Errorf("resolving path: %w", err)
 			}
 
-			if !output.JSONMode {
+			if !output.IsJSON() {
 				fmt.Println(tui.RenderHeader("DOCUMENT"))
 				fmt.Println()
 				fmt.Printf("  %s %s\n", tui.MutedStyle.Render("Path:"), absPath)
@@ -88,7 +87,7 @@ Examples:
 				return fmt.Errorf("no source files found in %s", absPath)
 			}
 
-			if !output.JSONMode {
+			if !output.IsJSON() {
 				fmt.Printf("  %s %d files\n", tui.MutedStyle.Render("Found:"), len(files))
 			}
 
@@ -104,7 +103,7 @@ Examples:
 						contextFiles = append(contextFiles, m)
 					}
 				}
-				if !output.JSONMode && len(contextFiles) > 0 {
+				if !output.IsJSON() && len(contextFiles) > 0 {
 					fmt.Printf("  %s %d context files\n",
 						tui.MutedStyle.Render("Context:"), len(contextFiles))
 				}
@@ -137,7 +136,7 @@ Examples:
 				return fmt.Errorf("no online Ollama hosts found")
 			}
 
-			if !output.JSONMode {
+			if !output.IsJSON() {
 				fmt.Printf("  %s %s\n\n",
 					tui.AccentStyle.Render(""),
 					selectedModel)
@@ -157,14 +156,14 @@ Examples:
 			client := ollama.NewClient(onlineHost.Host.URL, 5*time.Minute)
 			startTime := time.Now()
 
-			if !output.JSONMode {
+			if !output.IsJSON() {
 				fmt.Print(tui.MutedStyle.Render("  Generating documentation..."))
 			}
 
 			resp, err := client.Generate(selectedModel, prompt)
 			duration := time.Since(startTime)
 
-			if !output.JSONMode {
+			if !output.IsJSON() {
 				fmt.Print("\r                                    \r")
 			}
 
@@ -183,8 +182,8 @@ Examples:
 				ContextFiles:  contextFiles,
 			}
 
-			if output.JSONMode {
-				data, _ := json.MarshalIndent(docOutput, "", "  ")
+			if output.IsJSON() {
+				data, _ := output.Marshal(docOutput)
 				fmt.Println(string(data))
 				return nil
 			}
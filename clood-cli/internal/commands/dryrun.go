@@ -0,0 +1,31 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/dirtybirdnj/clood/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// dryRunAnnotation marks a command that honours the global --dry-run
+const dryRunAnnotation = "clood.dry-run"
+
+// withDryRun marks cmd as checking output.DryRun before it changes or
+// spends anything
+func withDryRun(cmd *cobra.Command) *cobra.Command {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[dryRunAnnotation] = "true"
+	return cmd
+}
+
+// CheckDryRun refuses the global --dry-run on commands that don't honour
+// it, so the flag never goes ignored while the command runs for real.
+// Commands with their own --dry-run flag never set output.DryRun.
+func CheckDryRun(cmd *cobra.Command) error {
+	if !output.DryRun || cmd.Annotations[dryRunAnnotation] != "" {
+		return nil
+	}
+	return fmt.Errorf("%s doesn't support --dry-run", cmd.CommandPath())
+}
@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/dirtybirdnj/clood/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func TestCheckDryRun(t *testing.T) {
+	defer func() { output.DryRun = false }()
+
+	tests := []struct {
+		cmd    *cobra.Command
+		dryRun bool
+		ok     bool
+	}{
+		{hostsRemoveCmd(), true, true},
+		{agentsDeleteCmd(), true, true},
+		{profileCreateCmd(), true, true},
+		{sdAnvilCmd(), true, true},
+		{sdVideoCmd(), true, true},
+		{sdUpscaleCmd(), true, true},
+		{configSetCmd(), true, true},
+		{sdRunWorkflowCmd(), true, true},
+		{sdWarmupCmd(), true, false},
+		{&cobra.Command{Use: "plain"}, true, false},
+		{&cobra.Command{Use: "plain"}, false, true},
+	}
+	for _, tt := range tests {
+		output.DryRun = tt.dryRun
+		if err := CheckDryRun(tt.cmd); (err == nil) != tt.ok {
+			t.Errorf("CheckDryRun(%s) with --dry-run=%v = %v; want ok=%v", tt.cmd.Name(), tt.dryRun, err, tt.ok)
+		}
+	}
+}
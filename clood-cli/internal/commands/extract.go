@@ -7,11 +7,13 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/dirtybirdnj/clood/internal/config"
 	"github.com/dirtybirdnj/clood/internal/hosts"
+	"github.com/dirtybirdnj/clood/internal/jsonschema"
 	"github.com/dirtybirdnj/clood/internal/ollama"
 	"github.com/dirtybirdnj/clood/internal/output"
 	"github.com/dirtybirdnj/clood/internal/tui"
@@ -44,10 +46,25 @@ func ExtractCmd() *cobra.Command {
 
 Reads from file or stdin, extracts data matching the schema.
 
+--schema takes either a comma-separated field list or a .yaml/.json file.
+A schema file can be JSON Schema (draft-07) or a field list:
+
+  fields:
+    - name: vendor
+      type: string
+      description: Company that issued the invoice
+      required: true
+    - name: amount
+      type: number
+
+With a schema file the model is given the full JSON Schema and its output
+is validated; invalid JSON or missing required fields are retried once.
+
 Examples:
   cat emails.txt | clood extract --schema "name,email,company"
   clood extract invoice.pdf --schema "date,amount,vendor"
   clood extract data.txt --auto --format csv
+  clood extract invoices.txt --schema invoice.yaml
   clood extract *.txt --schema "timestamp,level,message" -o logs.json`,
 		Run: func(cmd *cobra.Command, args []string) {
 			// Read input
@@ -100,7 +117,18 @@ Examples:
 				return
 			}
 
-			result, err := extractData(inputText, schema, model)
+			var result []map[string]interface{}
+			if isSchemaFile(schema) {
+				var s *jsonschema.Schema
+				s, err = jsonschema.Load(schema)
+				if err != nil {
+					fmt.Println(tui.ErrorStyle.Render("Schema error: " + err.Error()))
+					return
+				}
+				result, err = extractWithSchema(inputText, s, model)
+			} else {
+				result, err = extractData(inputText, schema, model)
+			}
 			if err != nil {
 				if output.IsJSON() {
 					fmt.Printf(`{"error": %q}`, err.Error())
@@ -139,7 +167,7 @@ Examples:
 		},
 	}
 
-	cmd.Flags().StringVar(&schema, "schema", "", "Comma-separated field names (e.g., \"name,email,company\")")
+	cmd.Flags().StringVar(&schema, "schema", "", "Comma-separated field names (e.g., \"name,email,company\") or a .yaml/.json schema file")
 	cmd.Flags().StringVar(&format, "format", "json", "Output format: json, csv, yaml")
 	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file")
 	cmd.Flags().StringVarP(&model, "model", "m", "", "Model to use")
@@ -169,10 +197,87 @@ Return a JSON array of objects with these exact field names. Include all matchin
 		userPrompt = userPrompt[:8000] + "\n(truncated)"
 	}
 
-	// Load config
+	client, modelName, err := extractClient(modelOverride)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GenerateWithSystem(modelName, extractSystemPrompt, userPrompt)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseExtractResponse(resp.Response)
+}
+
+// extractWithSchema extracts records described by a schema file. The model
+// sees the full JSON Schema, and a response that doesn't parse or validate
+// is retried once with the problems appended to the prompt.
+func extractWithSchema(text string, schema *jsonschema.Schema, modelOverride string) ([]map[string]interface{}, error) {
+	record := schema.Record()
+	schemaJSON, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	// Truncate the text rather than the prompt so the schema survives
+	if len(text) > 6000 {
+		text = text[:6000] + "\n(truncated)"
+	}
+
+	userPrompt := fmt.Sprintf(`Extract data from the text below. Each object you return must conform to this JSON Schema (draft-07):
+
+%s
+
+Text to extract from:
+%s
+
+Return a JSON array of objects. Include all matching entities found.`, schemaJSON, text)
+
+	client, modelName, err := extractClient(modelOverride)
+	if err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < 2; attempt++ {
+		prompt := userPrompt
+		if lastErr != nil {
+			prompt += fmt.Sprintf("\n\nYour previous answer was rejected: %v\nReturn a corrected JSON array that satisfies the schema.", lastErr)
+		}
+
+		resp, err := client.GenerateWithSystem(modelName, extractSystemPrompt, prompt)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := parseExtractResponse(resp.Response)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		var problems []string
+		for i, item := range result {
+			for _, p := range record.Validate(map[string]interface{}(item)) {
+				problems = append(problems, fmt.Sprintf("[%d]%s", i, strings.TrimPrefix(p, "value")))
+			}
+		}
+		if len(problems) == 0 {
+			return result, nil
+		}
+		lastErr = fmt.Errorf("schema validation failed: %s", strings.Join(problems, "; "))
+	}
+
+	return nil, lastErr
+}
+
+// extractClient picks the first online host with models and the model to
+// run on it
+func extractClient(modelOverride string) (*ollama.Client, string, error) {
 	cfg, err := config.Load()
 	if err != nil {
-		return nil, fmt.Errorf("config error: %w", err)
+		return nil, "", fmt.Errorf("config error: %w", err)
 	}
 
 	mgr := hosts.NewManager()
@@ -188,7 +293,7 @@ Return a JSON array of objects with these exact field names. Include all matchin
 	}
 
 	if targetHost == nil {
-		return nil, fmt.Errorf("no Ollama hosts available")
+		return nil, "", fmt.Errorf("no Ollama hosts available")
 	}
 
 	modelName := modelOverride
@@ -199,15 +304,13 @@ Return a JSON array of objects with these exact field names. Include all matchin
 		}
 	}
 
-	// Generate
-	client := ollama.NewClient(targetHost.Host.URL, 60*time.Second)
-	resp, err := client.GenerateWithSystem(modelName, extractSystemPrompt, userPrompt)
-	if err != nil {
-		return nil, err
-	}
+	return ollama.NewClient(targetHost.Host.URL, 60*time.Second), modelName, nil
+}
 
-	// Parse response as JSON
-	responseText := strings.TrimSpace(resp.Response)
+// parseExtractResponse decodes the model's answer as an array of objects,
+// or a single object, ignoring a surrounding markdown fence
+func parseExtractResponse(response string) ([]map[string]interface{}, error) {
+	responseText := strings.TrimSpace(response)
 
 	// Clean up markdown if present
 	responseText = strings.TrimPrefix(responseText, "```json\n")
@@ -229,6 +332,18 @@ Return a JSON array of objects with these exact field names. Include all matchin
 	return result, nil
 }
 
+// isSchemaFile reports whether --schema names a schema file rather than a
+// field list
+func isSchemaFile(schema string) bool {
+	switch strings.ToLower(filepath.Ext(schema)) {
+	case ".yaml", ".yml", ".json":
+	default:
+		return false
+	}
+	info, err := os.Stat(schema)
+	return err == nil && !info.IsDir()
+}
+
 func formatJSON(data []map[string]interface{}) (string, error) {
 	bytes, err := json.MarshalIndent(data, "", "  ")
 	if err != nil {
@@ -1,12 +1,12 @@
 package commands
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
 
 	"github.com/dirtybirdnj/clood/internal/flyingcats"
+	"github.com/dirtybirdnj/clood/internal/output"
 	"github.com/dirtybirdnj/clood/internal/tui"
 	"github.com/spf13/cobra"
 )
@@ -23,7 +23,6 @@ func FlyingCatsCmd() *cobra.Command {
 	var chaos bool
 	var antenna bool
 	var seed int64
-	var jsonOutput bool
 	var count int
 
 	cmd := &cobra.Command{
@@ -56,6 +55,7 @@ Examples:
   clood flying-cats --seed 42 "options"    # Reproducible randomness
   clood flying-cats -n 3 "what time?"      # Generate 3 variations`,
 		Run: func(cmd *cobra.Command, args []string) {
+			jsonOutput := output.IsJSON()
 			var gen *flyingcats.Generator
 			if seed != 0 {
 				gen = flyingcats.NewSeededGenerator(seed)
@@ -63,7 +63,7 @@ Examples:
 				gen = flyingcats.NewGenerator()
 			}
 
-			var output string
+			var text string
 			var query string
 			var mode string
 
@@ -74,9 +74,9 @@ Examples:
 					for i := 0; i < count; i++ {
 						outputs = append(outputs, fmt.Sprintf("--- Transmission %d ---\n%s", i+1, gen.Antenna()))
 					}
-					output = strings.Join(outputs, "\n\n")
+					text = strings.Join(outputs, "\n\n")
 				} else {
-					output = gen.Antenna()
+					text = gen.Antenna()
 				}
 			} else if chaos {
 				mode = "chaos"
@@ -85,9 +85,9 @@ Examples:
 					for i := 0; i < count; i++ {
 						outputs = append(outputs, fmt.Sprintf("--- Chaos Stream %d ---\n%s", i+1, gen.Chaos()))
 					}
-					output = strings.Join(outputs, "\n\n")
+					text = strings.Join(outputs, "\n\n")
 				} else {
-					output = gen.Chaos()
+					text = gen.Chaos()
 				}
 			} else if len(args) > 0 {
 				mode = "query"
@@ -97,26 +97,26 @@ Examples:
 					for i := 0; i < count; i++ {
 						outputs = append(outputs, fmt.Sprintf("--- Variation %d ---\n%s", i+1, gen.Generate(query)))
 					}
-					output = strings.Join(outputs, "\n\n")
+					text = strings.Join(outputs, "\n\n")
 				} else {
-					output = gen.Generate(query)
+					text = gen.Generate(query)
 				}
 			} else {
 				// No args and no mode - show a helpful cat
 				mode = "greeting"
-				output = gen.Antenna()
+				text = gen.Antenna()
 			}
 
 			if jsonOutput {
 				result := FlyingCatsOutput{
 					Query:  query,
 					Mode:   mode,
-					Output: output,
+					Output: text,
 				}
 				if seed != 0 {
 					result.Seed = seed
 				}
-				data, _ := json.MarshalIndent(result, "", "  ")
+				data, _ := output.Marshal(result)
 				fmt.Println(string(data))
 				return
 			}
@@ -126,7 +126,7 @@ Examples:
 				fmt.Println(tui.RenderHeader("FLYING CATS OF DELAWARE"))
 				fmt.Println()
 			}
-			fmt.Println(output)
+			fmt.Println(text)
 			fmt.Println()
 		},
 	}
@@ -134,7 +134,6 @@ Examples:
 	cmd.Flags().BoolVar(&chaos, "chaos", false, "Pure stream of consciousness mode")
 	cmd.Flags().BoolVar(&antenna, "antenna", false, "Delaware radio tower transmission mode")
 	cmd.Flags().Int64Var(&seed, "seed", 0, "Random seed for reproducible output")
-	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 	cmd.Flags().IntVarP(&count, "count", "n", 1, "Generate multiple variations")
 
 	return cmd
@@ -1,7 +1,6 @@
 package commands
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -23,9 +22,11 @@ type FocusState struct {
 }
 
 func FocusCmd() *cobra.Command {
+	var clearContext bool
+
 	cmd := &cobra.Command{
-		Use:   "focus",
-		Short: "Focus Guardian - drift detection for sessions (Gamera-kun)",
+		Use:   "focus [path]",
+		Short: "Focus Guardian - drift detection and code context for sessions (Gamera-kun)",
 		Long: `The Focus Guardian (Gamera-kun, the slow tortoise) watches over your session
 to ensure you stay on track with your stated goal.
 
@@ -36,9 +37,55 @@ Commands:
   clood focus check "some message"    Check if message drifts from goal
   clood focus status                  Show current focus state
   clood focus reset                   Clear drift counter
-  clood focus clear                   Remove goal entirely`,
+  clood focus clear                   Remove goal entirely
+
+Code context:
+  clood focus ./internal/hosts        Cache tree, symbols and imports for a directory
+  clood focus --clear                 Drop the cached code context
+
+The cached context belongs to the project it was made in (the git
+repository, or the directory outside one): 'clood ask' run anywhere in
+that project prepends it (skip it with --no-context), and 'clood
+preflight' shows it. --clear drops the current project's context.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if clearContext {
+				if err := clearFocusContext(); err != nil {
+					return err
+				}
+				if output.IsJSON() {
+					return output.JSON(map[string]interface{}{"cleared": true})
+				}
+				fmt.Println(tui.SuccessStyle.Render("✓ Focus context cleared"))
+				return nil
+			}
+			if len(args) == 0 {
+				return cmd.Help()
+			}
+
+			fc, err := buildFocusContext(args[0])
+			if err != nil {
+				return err
+			}
+			if err := saveFocusContext(fc); err != nil {
+				return fmt.Errorf("saving focus context: %w", err)
+			}
+
+			if output.IsJSON() {
+				return output.JSON(fc)
+			}
+
+			fmt.Println(tui.SuccessStyle.Render("✓ Focus context cached"))
+			fmt.Printf("\n  Path: %s\n", fc.Path)
+			fmt.Printf("  Files: %d, exported symbols: %d, %d bytes\n", fc.Files, fc.Symbols, len(fc.Context))
+			fmt.Println()
+			fmt.Println(tui.MutedStyle.Render("  'clood ask' in " + fc.Root + " will include it until 'clood focus --clear'"))
+			return nil
+		},
 	}
 
+	cmd.Flags().BoolVar(&clearContext, "clear", false, "Remove the cached code context")
+
 	cmd.AddCommand(focusSetCmd())
 	cmd.AddCommand(focusCheckCmd())
 	cmd.AddCommand(focusStatusCmd())
@@ -70,7 +117,7 @@ func focusSetCmd() *cobra.Command {
 			}
 
 			if output.IsJSON() {
-				data, _ := json.MarshalIndent(state, "", "  ")
+				data, _ := output.Marshal(state)
 				fmt.Println(string(data))
 				return
 			}
@@ -109,15 +156,14 @@ func focusCheckCmd() *cobra.Command {
 			saveFocusState(state)
 
 			if output.IsJSON() {
-				data, _ := json.MarshalIndent(map[string]interface{}{
+				output.JSON(map[string]interface{}{
 					"is_drift":    result.IsDrift,
 					"confidence":  result.Confidence,
 					"keywords":    result.Keywords,
 					"drift_count": guardian.DriftCount,
 					"status":      state.Status,
 					"warning":     result.Message,
-				}, "", "  ")
-				fmt.Println(string(data))
+				})
 				return
 			}
 
@@ -162,7 +208,7 @@ func focusStatusCmd() *cobra.Command {
 			}
 
 			if output.IsJSON() {
-				data, _ := json.MarshalIndent(state, "", "  ")
+				data, _ := output.Marshal(state)
 				fmt.Println(string(data))
 				return
 			}
@@ -209,7 +255,7 @@ func focusResetCmd() *cobra.Command {
 			saveFocusState(state)
 
 			if output.IsJSON() {
-				data, _ := json.MarshalIndent(state, "", "  ")
+				data, _ := output.Marshal(state)
 				fmt.Println(string(data))
 				return
 			}
@@ -0,0 +1,201 @@
+package commands
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// focusContextDepth is how deep the cached tree goes
+	focusContextDepth = 3
+
+	// focusContextMaxSymbols caps the symbol list so a big directory
+	// doesn't crowd the question out of the prompt
+	focusContextMaxSymbols = 150
+)
+
+// FocusContext is the code context cached by 'clood focus <path>' and
+// prepended to later 'clood ask' prompts run in the same project
+type FocusContext struct {
+	Path      string    `yaml:"path" json:"path"`
+	Root      string    `yaml:"root" json:"root"`
+	CreatedAt time.Time `yaml:"created_at" json:"created_at"`
+	Files     int       `yaml:"files" json:"files"`
+	Symbols   int       `yaml:"symbols" json:"symbols"`
+	Context   string    `yaml:"context" json:"context"`
+}
+
+// focusProjectRoot is the project a focus context belongs to: the git
+// top level around the working directory, or the directory itself
+// outside a repository
+func focusProjectRoot() string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	git := exec.Command("git", "rev-parse", "--show-toplevel")
+	git.Dir = cwd
+	if out, err := git.Output(); err == nil {
+		return filepath.Clean(strings.TrimSpace(string(out)))
+	}
+	return cwd
+}
+
+// getFocusContextPath is the cache file for a project root, one per
+// project so a focus in one repository never leaks into another
+func getFocusContextPath(root string) string {
+	home, _ := os.UserHomeDir()
+	sum := sha256.Sum256([]byte(root))
+	return filepath.Join(home, ".clood", "focus_context", hex.EncodeToString(sum[:8])+".yaml")
+}
+
+// loadFocusContext returns the current project's focus context, or nil if
+// none is set
+func loadFocusContext() *FocusContext {
+	root := focusProjectRoot()
+	data, err := os.ReadFile(getFocusContextPath(root))
+	if err != nil {
+		return nil
+	}
+	var fc FocusContext
+	if err := yaml.Unmarshal(data, &fc); err != nil || fc.Context == "" || fc.Root != root {
+		return nil
+	}
+	return &fc
+}
+
+// saveFocusContext caches fc for the current project
+func saveFocusContext(fc *FocusContext) error {
+	fc.Root = focusProjectRoot()
+	path := getFocusContextPath(fc.Root)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(fc)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// clearFocusContext drops the current project's focus context
+func clearFocusContext() error {
+	err := os.Remove(getFocusContextPath(focusProjectRoot()))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// withFocusContext prepends the cached focus context to a prompt
+func withFocusContext(prompt string) string {
+	fc := loadFocusContext()
+	if fc == nil {
+		return prompt
+	}
+	block := fmt.Sprintf("Focus context (%s):\n%s", fc.Path, fc.Context)
+	if prompt == "" {
+		return block
+	}
+	return block + "\n\n" + prompt
+}
+
+// buildFocusContext runs tree, symbols and imports over dir and joins
+// them into one block of prompt context
+func buildFocusContext(dir string) (*FocusContext, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	fc := &FocusContext{Path: abs, CreatedAt: time.Now()}
+	var sb strings.Builder
+
+	tree, err := buildTree(abs, focusContextDepth, newTreeFilter(abs, false, false), 0)
+	if err != nil {
+		return nil, err
+	}
+	sb.WriteString("## Structure\n")
+	sb.WriteString(tree.Name + "/\n")
+	fc.Files = writeFocusTree(&sb, tree.Children, "  ")
+
+	var symbols []Symbol
+	for _, sym := range extractSymbols(abs, true, "") {
+		if !strings.HasSuffix(sym.File, "_test.go") {
+			symbols = append(symbols, sym)
+		}
+	}
+	fc.Symbols = len(symbols)
+	if len(symbols) > 0 {
+		sb.WriteString("\n## Exported symbols\n")
+		for i, sym := range symbols {
+			if i == focusContextMaxSymbols {
+				sb.WriteString(fmt.Sprintf("... and %d more\n", len(symbols)-i))
+				break
+			}
+			rel, _ := filepath.Rel(abs, sym.File)
+			sb.WriteString(fmt.Sprintf("%s %s (%s:%d)\n", sym.Kind, sym.Name, rel, sym.Line))
+		}
+	}
+
+	if imports, err := processDirectory(abs, false); err == nil && len(imports) > 0 {
+		byPackage := map[string]map[string]bool{}
+		for _, info := range imports {
+			if byPackage[info.Package] == nil {
+				byPackage[info.Package] = map[string]bool{}
+			}
+			for _, imp := range info.Imports {
+				byPackage[info.Package][imp] = true
+			}
+		}
+		var pkgs []string
+		for pkg := range byPackage {
+			pkgs = append(pkgs, pkg)
+		}
+		sort.Strings(pkgs)
+
+		sb.WriteString("\n## Imports\n")
+		for _, pkg := range pkgs {
+			var deps []string
+			for imp := range byPackage[pkg] {
+				deps = append(deps, imp)
+			}
+			sort.Strings(deps)
+			sb.WriteString(fmt.Sprintf("package %s: %s\n", pkg, strings.Join(deps, ", ")))
+		}
+	}
+
+	fc.Context = strings.TrimRight(sb.String(), "\n")
+	return fc, nil
+}
+
+// writeFocusTree writes an indented listing and returns the file count
+func writeFocusTree(sb *strings.Builder, nodes []TreeNode, indent string) int {
+	files := 0
+	for _, node := range nodes {
+		if node.Type == "dir" {
+			sb.WriteString(indent + node.Name + "/\n")
+			files += writeFocusTree(sb, node.Children, indent+"  ")
+			continue
+		}
+		sb.WriteString(indent + node.Name + "\n")
+		files++
+	}
+	return files
+}
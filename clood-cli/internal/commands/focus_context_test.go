@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFocusContextPerProject(t *testing.T) {
+	setTestHome(t)
+	projectA, projectB := t.TempDir(), t.TempDir()
+
+	t.Chdir(projectA)
+	fc := &FocusContext{Path: filepath.Join(projectA, "internal"), Context: "## Structure\nhosts/"}
+	if err := saveFocusContext(fc); err != nil {
+		t.Fatal(err)
+	}
+	if got := withFocusContext("question"); !strings.HasPrefix(got, "Focus context (") || !strings.HasSuffix(got, "\n\nquestion") {
+		t.Errorf("in the focused project: %q", got)
+	}
+
+	// Another project doesn't see it
+	t.Chdir(projectB)
+	if got := withFocusContext("question"); got != "question" {
+		t.Errorf("in another project: %q; want the prompt unchanged", got)
+	}
+	if err := clearFocusContext(); err != nil {
+		t.Errorf("clearing a project without a context: %v", err)
+	}
+
+	// Clearing is per project too
+	t.Chdir(projectA)
+	if loadFocusContext() == nil {
+		t.Fatal("clearing project B dropped project A's context")
+	}
+	if err := clearFocusContext(); err != nil {
+		t.Fatal(err)
+	}
+	if loadFocusContext() != nil {
+		t.Error("context still loaded after clearing")
+	}
+}
+
+func TestFocusContextRootMismatch(t *testing.T) {
+	setTestHome(t)
+	project := t.TempDir()
+	t.Chdir(project)
+
+	// A cache file naming some other root is never used
+	path := getFocusContextPath(focusProjectRoot())
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("root: /elsewhere\ncontext: stale\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if fc := loadFocusContext(); fc != nil {
+		t.Errorf("loadFocusContext() = %+v; want nil for another root", fc)
+	}
+}
@@ -1,7 +1,6 @@
 package commands
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -47,13 +46,14 @@ def test_function(input, expected):
 
 // TestGenResult holds the generation output
 type TestGenResult struct {
-	SourceFile string  `json:"source_file"`
-	TestFile   string  `json:"test_file,omitempty"`
-	Model      string  `json:"model"`
-	Host       string  `json:"host"`
-	Duration   float64 `json:"duration_seconds"`
-	Tests      string  `json:"tests"`
-	Tokens     int     `json:"tokens"`
+	SourceFile string   `json:"source_file"`
+	TestFile   string   `json:"test_file,omitempty"`
+	Model      string   `json:"model"`
+	Host       string   `json:"host"`
+	Duration   float64  `json:"duration_seconds"`
+	Tests      string   `json:"tests"`
+	Tokens     int      `json:"tokens"`
+	StyleFrom  []string `json:"style_from,omitempty"` // existing tests the style was matched to
 }
 
 func GenerateTestsCmd() *cobra.Command {
@@ -61,6 +61,7 @@ func GenerateTestsCmd() *cobra.Command {
 	var function string
 	var style string
 	var model string
+	var ignoreExisting bool
 
 	cmd := &cobra.Command{
 		Use:   "generate-tests <source-file>",
@@ -69,6 +70,12 @@ func GenerateTestsCmd() *cobra.Command {
 
 Saves Claude tokens by using local models for test scaffolding.
 
+For Go, the *_test.go files already in the package set the style: whether
+tests are table-driven, use testify or gomock, t.Parallel and so on. The
+model is shown a representative existing test and the existing test names
+and asked to match them. --ignore-existing falls back to the generic
+table-driven template.
+
 Examples:
   clood generate-tests src/auth/login.go
   clood generate-tests src/router.go --function HandleRequest
@@ -77,7 +84,7 @@ Examples:
 		Args: cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			sourceFile := args[0]
-			result, err := generateTests(sourceFile, function, style, model, outputFile)
+			result, err := generateTests(sourceFile, function, style, model, outputFile, !ignoreExisting)
 			if err != nil {
 				if output.IsJSON() {
 					fmt.Printf(`{"error": %q}`, err.Error())
@@ -88,7 +95,7 @@ Examples:
 			}
 
 			if output.IsJSON() {
-				data, _ := json.MarshalIndent(result, "", "  ")
+				data, _ := output.Marshal(result)
 				fmt.Println(string(data))
 				return
 			}
@@ -101,11 +108,12 @@ Examples:
 	cmd.Flags().StringVar(&function, "function", "", "Generate tests for specific function only")
 	cmd.Flags().StringVar(&style, "style", "", "Test style (table-driven, pytest, jest)")
 	cmd.Flags().StringVarP(&model, "model", "m", "", "Model to use (default: tier1 fast)")
+	cmd.Flags().BoolVar(&ignoreExisting, "ignore-existing", false, "Don't match the style of the package's existing tests")
 
 	return cmd
 }
 
-func generateTests(sourceFile, function, style, modelOverride, outputFile string) (*TestGenResult, error) {
+func generateTests(sourceFile, function, style, modelOverride, outputFile string, matchExisting bool) (*TestGenResult, error) {
 	// Read source file
 	sourceCode, err := os.ReadFile(sourceFile)
 	if err != nil {
@@ -118,9 +126,17 @@ func generateTests(sourceFile, function, style, modelOverride, outputFile string
 
 	// Build prompt
 	systemPrompt := testGenSystemPrompt
+	var existing *goTestStyle
 	switch lang {
 	case "go":
-		systemPrompt += "\n\n" + testGenGoPrompt
+		if matchExisting {
+			existing = detectGoTestStyle(sourceFile)
+		}
+		if existing != nil {
+			systemPrompt += "\n\n" + existing.Prompt()
+		} else {
+			systemPrompt += "\n\n" + testGenGoPrompt
+		}
 	case "python":
 		systemPrompt += "\n\n" + testGenPythonPrompt
 	}
@@ -201,6 +217,9 @@ func generateTests(sourceFile, function, style, modelOverride, outputFile string
 		Tests:      tests,
 		Tokens:     resp.EvalCount,
 	}
+	if existing != nil {
+		result.StyleFrom = existing.Files
+	}
 
 	// Write to file if requested
 	if outputFile != "" {
@@ -249,6 +268,9 @@ func printTestGenResult(result *TestGenResult) {
 	fmt.Printf("  Source: %s\n", result.SourceFile)
 	fmt.Printf("  Model:  %s (%s)\n", result.Model, result.Host)
 	fmt.Printf("  Time:   %.1fs (%d tokens)\n", result.Duration, result.Tokens)
+	if len(result.StyleFrom) > 0 {
+		fmt.Printf("  Style:  matched %s\n", strings.Join(result.StyleFrom, ", "))
+	}
 
 	if result.TestFile != "" {
 		fmt.Printf("  Output: %s\n", result.TestFile)
@@ -0,0 +1,180 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// goTestStyle is how a package's existing tests are written, so generated
+// tests can match them instead of a generic template
+type goTestStyle struct {
+	Files       []string // test files the style was read from
+	Package     string   // package clause used by the tests, e.g. foo or foo_test
+	TableDriven bool
+	Subtests    bool
+	Testify     bool // github.com/stretchr/testify assert/require
+	Gomock      bool // github.com/golang/mock or go.uber.org/mock
+	Parallel    bool
+	Helpers     bool // t.Helper() helpers
+	TempDir     bool // t.TempDir / t.Setenv fixtures
+	Signatures  []string
+	Example     string // one representative test function
+}
+
+var (
+	goPackageRe  = regexp.MustCompile(`(?m)^package\s+(\w+)`)
+	goTableRe    = regexp.MustCompile(`(?s)(tests|cases|testCases|tt)\s*:?=\s*\[\]struct\s*\{.*?for\s+_,\s*\w+\s*:=\s*range\s+`)
+	goTestFuncRe = regexp.MustCompile(`^func\s+(Test|Benchmark|Fuzz)\w*\(`)
+)
+
+const (
+	goMaxExample  = 40         // lines of the example test shown to the model
+	goMaxSigs     = 12         // existing test names listed
+	goMaxTestRead = 200 * 1024 // bytes of test files scanned
+)
+
+// detectGoTestStyle reads the *_test.go files next to sourceFile. It
+// returns nil when the package has no tests yet.
+func detectGoTestStyle(sourceFile string) *goTestStyle {
+	dir := filepath.Dir(sourceFile)
+	paths, _ := filepath.Glob(filepath.Join(dir, "*_test.go"))
+	if len(paths) == 0 {
+		return nil
+	}
+
+	// The source file's own test file is the best example of all
+	own := strings.TrimSuffix(sourceFile, ".go") + "_test.go"
+	sort.SliceStable(paths, func(i, j int) bool {
+		return paths[i] == own && paths[j] != own
+	})
+
+	style := &goTestStyle{}
+	var tableExample, anyExample string
+	read := 0
+
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil || read > goMaxTestRead {
+			continue
+		}
+		read += len(data)
+		src := string(data)
+		style.Files = append(style.Files, filepath.Base(p))
+
+		if m := goPackageRe.FindStringSubmatch(src); m != nil && style.Package == "" {
+			style.Package = m[1]
+		}
+		style.TableDriven = style.TableDriven || goTableRe.MatchString(src)
+		style.Subtests = style.Subtests || strings.Contains(src, "t.Run(")
+		style.Testify = style.Testify || strings.Contains(src, "github.com/stretchr/testify")
+		style.Gomock = style.Gomock || strings.Contains(src, "github.com/golang/mock") || strings.Contains(src, "go.uber.org/mock")
+		style.Parallel = style.Parallel || strings.Contains(src, "t.Parallel()")
+		style.Helpers = style.Helpers || strings.Contains(src, "t.Helper()")
+		style.TempDir = style.TempDir || strings.Contains(src, "t.TempDir()") || strings.Contains(src, "t.Setenv(")
+
+		for _, sym := range parseGoFile(p, false, "func") {
+			if !strings.HasPrefix(sym.Name, "Test") && !strings.HasPrefix(sym.Name, "Benchmark") && !strings.HasPrefix(sym.Name, "Fuzz") {
+				continue
+			}
+			body := goFuncAt(src, sym.Line)
+			if body == "" {
+				continue
+			}
+			if len(style.Signatures) < goMaxSigs {
+				sig, _, _ := strings.Cut(body, "\n")
+				style.Signatures = append(style.Signatures, strings.TrimSuffix(strings.TrimSpace(sig), " {"))
+			}
+			if anyExample == "" {
+				anyExample = body
+			}
+			if tableExample == "" && goTableRe.MatchString(body) {
+				tableExample = body
+			}
+		}
+	}
+
+	style.Example = tableExample
+	if style.Example == "" {
+		style.Example = anyExample
+	}
+	return style
+}
+
+// goFuncAt returns the top-level func starting on 1-based line, cut to
+// goMaxExample lines
+func goFuncAt(src string, line int) string {
+	lines := strings.Split(src, "\n")
+	if line < 1 || line > len(lines) || !goTestFuncRe.MatchString(lines[line-1]) {
+		return ""
+	}
+
+	var out []string
+	for i := line - 1; i < len(lines); i++ {
+		out = append(out, lines[i])
+		if lines[i] == "}" {
+			break
+		}
+		if len(out) == goMaxExample {
+			out = append(out, "\t// ...")
+			break
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// Prompt describes the style for the system prompt
+func (s *goTestStyle) Prompt() string {
+	var sb strings.Builder
+	sb.WriteString("This package already has tests (" + strings.Join(s.Files, ", ") + "). ")
+	sb.WriteString("Match their style exactly rather than a generic template:\n")
+
+	if s.Package != "" {
+		sb.WriteString(fmt.Sprintf("- Use `package %s`\n", s.Package))
+	}
+	if s.TableDriven {
+		sb.WriteString("- Table-driven tests")
+		if s.Subtests {
+			sb.WriteString(" with t.Run subtests")
+		}
+		sb.WriteString("\n")
+	} else {
+		sb.WriteString("- Plain test functions, not table-driven\n")
+		if s.Subtests {
+			sb.WriteString("- Group cases with t.Run subtests\n")
+		}
+	}
+	if s.Testify {
+		sb.WriteString("- Assertions with github.com/stretchr/testify (assert/require)\n")
+	} else {
+		sb.WriteString("- Standard library only: if ... { t.Errorf(...) }, no assertion libraries\n")
+	}
+	if s.Gomock {
+		sb.WriteString("- Mocks with gomock, as in the existing tests\n")
+	} else {
+		sb.WriteString("- No mocking frameworks; fakes are plain functions or structs\n")
+	}
+	if s.Parallel {
+		sb.WriteString("- Call t.Parallel() like the existing tests\n")
+	}
+	if s.Helpers {
+		sb.WriteString("- Factor shared setup into helpers that call t.Helper()\n")
+	}
+	if s.TempDir {
+		sb.WriteString("- Use t.TempDir() and t.Setenv() for filesystem and environment fixtures\n")
+	}
+
+	if len(s.Signatures) > 0 {
+		sb.WriteString("\nExisting tests (don't redefine these names):\n")
+		for _, sig := range s.Signatures {
+			sb.WriteString("  " + sig + "\n")
+		}
+	}
+	if s.Example != "" {
+		sb.WriteString("\nA representative existing test:\n```go\n" + s.Example + "\n```\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,266 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dirtybirdnj/clood/internal/config"
+	"github.com/dirtybirdnj/clood/internal/git"
+	"github.com/dirtybirdnj/clood/internal/output"
+	"github.com/dirtybirdnj/clood/internal/router"
+	"github.com/dirtybirdnj/clood/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+const diffSummarySystemPrompt = `You explain code changes to the developer who has to review them.
+Summarize these changes in plain English, focusing on intent and impact:
+what the change is for, what behaviour is different afterwards, and
+anything risky or worth a closer look. Use a short paragraph or a few
+bullets. Do not repeat the diff or restate file lists line by line.`
+
+// diffSummaryMaxChars keeps a huge diff from overflowing the fast tier's
+// context window
+const diffSummaryMaxChars = 12000
+
+// GitCmd groups git helpers that share the MCP git tools' plumbing
+func GitCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "git",
+		Short: "Git helpers with optional local LLM summaries",
+	}
+
+	cmd.AddCommand(gitDiffCmd())
+	cmd.AddCommand(gitLogCmd())
+
+	return cmd
+}
+
+func gitDiffCmd() *cobra.Command {
+	var staged bool
+	var commit string
+	var branch string
+	var stat bool
+	var contextLines int
+	var summarize bool
+	var model string
+
+	cmd := &cobra.Command{
+		Use:   "diff [file]",
+		Short: "Show a diff, optionally with a plain-English summary",
+		Long: `Prints git diff output for the current repository.
+
+With --summarize, the full diff is also sent to the fast tier with a prompt
+to explain its intent and impact, and the summary is printed in a box
+after the raw diff.
+
+Examples:
+  clood git diff
+  clood git diff --staged --summarize
+  clood git diff --branch feature/x --stat --summarize
+  clood git diff internal/router/router.go --commit HEAD~1 --summarize -m qwen2.5-coder:7b`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts := git.DiffOptions{
+				Path:    ".",
+				Commit:  commit,
+				Branch:  branch,
+				Staged:  staged,
+				Context: contextLines,
+				Stat:    stat,
+			}
+			if len(args) > 0 {
+				opts.File = args[0]
+			}
+
+			diff, err := git.Diff(opts)
+			if err != nil {
+				return err
+			}
+			if strings.TrimSpace(diff) == "" {
+				if output.IsJSON() {
+					return output.JSON(map[string]interface{}{"diff": ""})
+				}
+				fmt.Println(tui.MutedStyle.Render("No changes"))
+				return nil
+			}
+
+			if !summarize {
+				if output.IsJSON() {
+					return output.JSON(map[string]interface{}{"diff": diff})
+				}
+				fmt.Print(diff)
+				return nil
+			}
+
+			// --stat shows the stat, but the model needs the full diff
+			full := diff
+			if stat {
+				opts.Stat = false
+				if full, err = git.Diff(opts); err != nil {
+					return err
+				}
+			}
+			summary, usedModel, err := summarizeDiff(full, model)
+
+			if output.IsJSON() {
+				result := map[string]interface{}{"diff": diff}
+				if err != nil {
+					result["summary_error"] = err.Error()
+				} else {
+					result["summary"] = summary
+					result["model"] = usedModel
+				}
+				return output.JSON(result)
+			}
+
+			fmt.Print(diff)
+			fmt.Println()
+			if err != nil {
+				return fmt.Errorf("summarizing diff: %w", err)
+			}
+			fmt.Println(renderDiffSummary(summary, usedModel))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&staged, "staged", false, "Show staged changes only")
+	cmd.Flags().StringVar(&commit, "commit", "", "Diff against this commit")
+	cmd.Flags().StringVar(&branch, "branch", "", "Changes on this branch since it forked from the current one")
+	cmd.Flags().BoolVar(&stat, "stat", false, "Show a stat summary instead of the full diff")
+	cmd.Flags().IntVarP(&contextLines, "context", "U", 0, "Lines of context (default: git's 3)")
+	cmd.Flags().BoolVarP(&summarize, "summarize", "s", false, "Append a plain-English summary from a local LLM")
+	cmd.Flags().StringVarP(&model, "model", "m", "", "Override model (default: fast tier)")
+
+	return cmd
+}
+
+// summarizeDiff asks the fast tier to explain diff, returning the summary
+// and the model that wrote it
+func summarizeDiff(diff, model string) (string, string, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return "", "", fmt.Errorf("loading config: %w", err)
+	}
+	r := router.NewRouter(cfg)
+	route, err := r.Route("summarize diff", router.TierFast, model)
+	if err != nil {
+		return "", "", fmt.Errorf("routing: %w", err)
+	}
+	if route.Client == nil {
+		return "", "", fmt.Errorf("no available host with model %s", route.Model)
+	}
+
+	if len(diff) > diffSummaryMaxChars {
+		diff = diff[:diffSummaryMaxChars] + "\n... (truncated)"
+	}
+	if !output.IsJSON() {
+		fmt.Fprintf(os.Stderr, "%s %s on %s\n", tui.MutedStyle.Render("Summarizing:"), route.Model, route.Host.Host.Name)
+	}
+	resp, err := route.Client.GenerateWithSystem(route.Model, diffSummarySystemPrompt, "```diff\n"+diff+"\n```")
+	if err != nil {
+		return "", "", err
+	}
+	return strings.TrimSpace(resp.Response), route.Model, nil
+}
+
+func renderDiffSummary(summary, model string) string {
+	title := tui.AccentStyle.Render("Summary") + tui.MutedStyle.Render(" · "+model)
+	return tui.BoxStyle.Render(title + "\n\n" + summary)
+}
+
+func gitLogCmd() *cobra.Command {
+	var count int
+	var author string
+	var since string
+	var until string
+	var grep string
+	var format string
+	var changelog bool
+
+	cmd := &cobra.Command{
+		Use:   "log [file]",
+		Short: "Show commit history, or a CHANGELOG section built from it",
+		Long: `Lists commits for the current repository.
+
+--since takes a date ("2024-01-01", "2 weeks ago") or a tag, branch or
+commit, in which case it shows the commits after it (since..HEAD).
+
+With --changelog the commits are grouped by conventional commit type
+(feat, fix, docs, chore, ...) into a Markdown section ready to paste into
+CHANGELOG.md. Subjects that aren't "type(scope): description" go under
+"Other Changes"; merge commits are left out. --changelog reads every
+matching commit unless --count is given.
+
+Examples:
+  clood git log -n 10
+  clood git log --author alice --since "1 week ago"
+  clood git log --format conventional --json
+  clood git log --changelog --since v0.1.0 >> CHANGELOG.md`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts := git.LogOptions{
+				Path:   ".",
+				Count:  count,
+				Author: author,
+				Until:  until,
+				Grep:   grep,
+				Format: format,
+			}
+			if changelog && !cmd.Flags().Changed("count") {
+				opts.Count = -1
+			}
+			if since != "" {
+				opts.SetSince(since)
+			}
+			if len(args) > 0 {
+				opts.File = args[0]
+			}
+
+			entries, err := git.Log(opts)
+			if err != nil {
+				return err
+			}
+
+			if changelog {
+				text := git.GenerateChangelog(entries, since)
+				if output.IsJSON() {
+					return output.JSON(map[string]interface{}{
+						"since":     since,
+						"commits":   len(entries),
+						"changelog": text,
+					})
+				}
+				fmt.Print(text)
+				return nil
+			}
+
+			if output.IsJSON() {
+				if entries == nil {
+					entries = []git.LogEntry{}
+				}
+				return output.JSON(entries)
+			}
+			if len(entries) == 0 {
+				fmt.Println(tui.MutedStyle.Render("No commits"))
+				return nil
+			}
+			for _, e := range entries {
+				date, _, _ := strings.Cut(e.Date, " ")
+				fmt.Printf("%s %s %s %s\n", tui.AccentStyle.Render(e.ShortHash), tui.MutedStyle.Render(date),
+					e.Subject, tui.MutedStyle.Render("("+e.Author+")"))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVarP(&count, "count", "n", 20, "Number of commits")
+	cmd.Flags().StringVar(&author, "author", "", "Only commits by this author")
+	cmd.Flags().StringVar(&since, "since", "", "Only commits after this date, tag, branch or commit")
+	cmd.Flags().StringVar(&until, "until", "", "Only commits before this date")
+	cmd.Flags().StringVar(&grep, "grep", "", "Only commits whose message matches")
+	cmd.Flags().StringVar(&format, "format", "", "Extra parsing for --json: "+strings.Join(git.LogFormats, ", "))
+	cmd.Flags().BoolVar(&changelog, "changelog", false, "Print a Markdown CHANGELOG section grouped by commit type")
+
+	return cmd
+}
@@ -9,15 +9,20 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/dirtybirdnj/clood/internal/jsonpath"
+	"github.com/dirtybirdnj/clood/internal/output"
 	"github.com/dirtybirdnj/clood/internal/tui"
 	"github.com/spf13/cobra"
 )
 
-// GrepMatch represents a single match result
+// GrepMatch represents a single match result. Structured (--json-path)
+// matches have no line; Path and Value locate them instead.
 type GrepMatch struct {
-	File    string `json:"file"`
-	Line    int    `json:"line"`
-	Content string `json:"content"`
+	File    string          `json:"file"`
+	Line    int             `json:"line,omitempty"`
+	Content string          `json:"content"`
+	Path    string          `json:"path,omitempty"`
+	Value   json.RawMessage `json:"value,omitempty"`
 }
 
 // GrepResult contains all matches for a file
@@ -28,7 +33,6 @@ type GrepResult struct {
 }
 
 func GrepCmd() *cobra.Command {
-	var jsonOutput bool
 	var caseInsensitive bool
 	var showLineNumbers bool
 	var contextBefore int
@@ -37,17 +41,44 @@ func GrepCmd() *cobra.Command {
 	var fileTypes []string
 	var filesOnly bool
 	var countOnly bool
+	var jsonPathExpr string
 
 	cmd := &cobra.Command{
 		Use:   "grep PATTERN [PATH]",
 		Short: "Search codebase content using regex",
-		Long:  "Search files recursively for a regex pattern, similar to grep but optimized for codebases.",
-		Args:  cobra.RangeArgs(1, 2),
+		Long: `Search files recursively for a regex pattern, similar to grep but optimized for codebases.
+
+With --json-path the pattern is replaced by a jq-style path evaluated
+against every JSON and YAML file instead:
+
+  clood grep --json-path '.services[].image' docker-compose.yaml
+  clood grep --json-path '.dependencies' -j .
+
+Paths support .key, ."quoted key", .[N], .[-N] and .[] (every element).`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if jsonPathExpr != "" {
+				return cobra.MaximumNArgs(1)(cmd, args)
+			}
+			return cobra.RangeArgs(1, 2)(cmd, args)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			pattern := args[0]
+			jsonOutput := output.IsJSON()
+			var pattern string
+			if jsonPathExpr == "" {
+				pattern, args = args[0], args[1:]
+			}
 			searchPath := "."
-			if len(args) > 1 {
-				searchPath = args[1]
+			if len(args) > 0 {
+				searchPath = args[0]
+			}
+
+			var jp *jsonpath.Path
+			if jsonPathExpr != "" {
+				var err error
+				if jp, err = jsonpath.Compile(jsonPathExpr); err != nil {
+					fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render("Invalid path: "+err.Error()))
+					return nil
+				}
 			}
 
 			// Handle context flags
@@ -98,7 +129,12 @@ func GrepCmd() *cobra.Command {
 				}
 
 				// Search the file
-				matches, err := searchFile(path, re, showLineNumbers, contextBefore, contextAfter)
+				var matches []GrepMatch
+				if jp != nil {
+					matches, err = searchStructuredFile(path, jp)
+				} else {
+					matches, err = searchFile(path, re, showLineNumbers, contextBefore, contextAfter)
+				}
 				if err != nil {
 					return nil // Skip files we can't read
 				}
@@ -108,9 +144,11 @@ func GrepCmd() *cobra.Command {
 
 					if filesOnly {
 						// Just track file names
-						lines := make([]int, len(matches))
-						for i, m := range matches {
-							lines[i] = m.Line
+						var lines []int
+						for _, m := range matches {
+							if m.Line > 0 {
+								lines = append(lines, m.Line)
+							}
 						}
 						fileResults = append(fileResults, GrepResult{
 							File:    path,
@@ -138,11 +176,11 @@ func GrepCmd() *cobra.Command {
 			// Output results
 			if jsonOutput {
 				if filesOnly || countOnly {
-					output, _ := json.MarshalIndent(fileResults, "", "  ")
-					fmt.Println(string(output))
+					data, _ := output.Marshal(fileResults)
+					fmt.Println(string(data))
 				} else {
-					output, _ := json.MarshalIndent(allMatches, "", "  ")
-					fmt.Println(string(output))
+					data, _ := output.Marshal(allMatches)
+					fmt.Println(string(data))
 				}
 			} else {
 				if filesOnly {
@@ -156,6 +194,8 @@ func GrepCmd() *cobra.Command {
 						fmt.Printf("%s: %d\n", r.File, r.Matches)
 					}
 					fmt.Println(tui.MutedStyle.Render(fmt.Sprintf("\nTotal: %d matches in %d files", totalMatches, len(fileResults))))
+				} else if jp != nil {
+					printStructuredMatches(allMatches)
 				} else {
 					printMatches(allMatches, re)
 				}
@@ -169,7 +209,6 @@ func GrepCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Output as JSON")
 	cmd.Flags().BoolVarP(&caseInsensitive, "ignore-case", "i", false, "Case insensitive search")
 	cmd.Flags().BoolVarP(&showLineNumbers, "line-number", "n", true, "Show line numbers")
 	cmd.Flags().IntVarP(&contextBefore, "before-context", "B", 0, "Lines before match")
@@ -178,6 +217,7 @@ func GrepCmd() *cobra.Command {
 	cmd.Flags().StringSliceVarP(&fileTypes, "type", "t", nil, "Filter by file type (go, py, js, ts, rs)")
 	cmd.Flags().BoolVar(&filesOnly, "files-only", false, "Only list files with matches")
 	cmd.Flags().BoolVar(&countOnly, "count", false, "Only show match counts")
+	cmd.Flags().StringVar(&jsonPathExpr, "json-path", "", "Query JSON/YAML files with a jq-style path instead of a regex")
 
 	return cmd
 }
@@ -297,6 +337,63 @@ func searchFile(path string, re *regexp.Regexp, showLineNumbers bool, contextBef
 	return matches, nil
 }
 
+// searchStructuredFile evaluates jp against a JSON or YAML file. Other
+// files, and ones that don't parse, produce no matches.
+func searchStructuredFile(path string, jp *jsonpath.Path) ([]GrepMatch, error) {
+	var docs []interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		doc, err := jsonpath.DecodeJSON(data)
+		if err != nil {
+			return nil, nil
+		}
+		docs = []interface{}{doc}
+	case ".yaml", ".yml":
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if docs, err = jsonpath.DecodeYAML(data); err != nil {
+			return nil, nil
+		}
+	default:
+		return nil, nil
+	}
+
+	var matches []GrepMatch
+	for _, doc := range docs {
+		for _, m := range jp.Find(doc) {
+			value, _ := json.Marshal(m.Value)
+			matches = append(matches, GrepMatch{
+				File:    path,
+				Content: string(value),
+				Path:    m.Path,
+				Value:   value,
+			})
+		}
+	}
+	return matches, nil
+}
+
+func printStructuredMatches(matches []GrepMatch) {
+	currentFile := ""
+
+	for _, m := range matches {
+		if m.File != currentFile {
+			if currentFile != "" {
+				fmt.Println()
+			}
+			currentFile = m.File
+			fmt.Println(tui.HeaderStyle.Render(m.File))
+		}
+		fmt.Printf("  %s │ %s\n", tui.MutedStyle.Render(m.Path), tui.SuccessStyle.Render(m.Content))
+	}
+}
+
 func printMatches(matches []GrepMatch, re *regexp.Regexp) {
 	currentFile := ""
 
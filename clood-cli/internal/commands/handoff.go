@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/dirtybirdnj/clood/internal/output"
 	"github.com/dirtybirdnj/clood/internal/tui"
 	"github.com/spf13/cobra"
 )
@@ -32,7 +33,6 @@ func HandoffCmd() *cobra.Command {
 	var loadMode bool
 	var historyMode bool
 	var diffMode bool
-	var jsonOutput bool
 	var noPush bool
 
 	cmd := &cobra.Command{
@@ -59,6 +59,7 @@ Examples:
   clood handoff --history                             # View history
   clood handoff --diff                                # Changes since last`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput := output.IsJSON()
 			// Determine mode
 			if loadMode {
 				return runHandoffLoad(jsonOutput)
@@ -88,7 +89,6 @@ Examples:
 	cmd.Flags().BoolVarP(&loadMode, "load", "l", false, "Load and display latest context")
 	cmd.Flags().BoolVar(&historyMode, "history", false, "View handoff history")
 	cmd.Flags().BoolVar(&diffMode, "diff", false, "Show changes since last handoff")
-	cmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Output as JSON")
 	cmd.Flags().BoolVar(&noPush, "no-push", false, "Save without pushing to remote")
 
 	return cmd
@@ -146,7 +146,7 @@ func runHandoffSave(summary string, noPush, jsonOutput bool) error {
 
 	// Output
 	if jsonOutput {
-		data, _ := json.MarshalIndent(handoff, "", "  ")
+		data, _ := output.Marshal(handoff)
 		fmt.Println(string(data))
 	} else {
 		fmt.Println(tui.SuccessStyle.Render("Session handoff saved."))
@@ -176,7 +176,7 @@ func runHandoffLoad(jsonOutput bool) error {
 	}
 
 	if jsonOutput {
-		data, _ := json.MarshalIndent(handoff, "", "  ")
+		data, _ := output.Marshal(handoff)
 		fmt.Println(string(data))
 		return nil
 	}
@@ -234,7 +234,7 @@ func runHandoffHistory(jsonOutput bool) error {
 	}
 
 	if jsonOutput {
-		data, _ := json.MarshalIndent(handoffs, "", "  ")
+		data, _ := output.Marshal(handoffs)
 		fmt.Println(string(data))
 		return nil
 	}
@@ -1,7 +1,6 @@
 package commands
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"os/exec"
@@ -36,6 +35,8 @@ type HostHealth struct {
 	Online    bool   `json:"online"`
 	LatencyMs int64  `json:"latency_ms"`
 	Error     string `json:"error,omitempty"`
+
+	Warmup *WarmupHealth `json:"warmup,omitempty"`
 }
 
 type ServiceHealth struct {
@@ -59,15 +60,19 @@ type ConfigHealth struct {
 }
 
 func HealthCmd() *cobra.Command {
-	var jsonOutput bool
+	var warmupModel string
 
 	cmd := &cobra.Command{
 		Use:   "health",
 		Short: "Check status of all clood services",
-		Long:  "Comprehensive health check of Ollama hosts, services, and CLI tools.",
+		Long: `Comprehensive health check of Ollama hosts, services, and CLI tools.
+
+Host latency is the round trip to /api/version. --warmup <model> also sends
+a tiny prompt to every online host with that model and reports the time
+to first token, which includes loading the model into VRAM when it is
+cold. Warmup results are cached for 60 seconds.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			// Check both local --json and global -j flag
-			useJSON := jsonOutput || output.IsJSON()
+			useJSON := output.IsJSON()
 
 			cfg, err := config.Load()
 			if err != nil {
@@ -85,6 +90,15 @@ func HealthCmd() *cobra.Command {
 			mgr.AddHosts(cfg.Hosts)
 			statuses := mgr.CheckAllHosts()
 
+			var warmups map[string]*WarmupHealth
+			if warmupModel != "" {
+				if !useJSON {
+					fmt.Println(tui.MutedStyle.Render("Warming up " + warmupModel + "..."))
+					fmt.Println()
+				}
+				warmups = warmupHosts(mgr, statuses, warmupModel)
+			}
+
 			for _, status := range statuses {
 				hh := HostHealth{
 					Name:      status.Host.Name,
@@ -95,6 +109,7 @@ func HealthCmd() *cobra.Command {
 				if status.Error != nil {
 					hh.Error = status.Error.Error()
 				}
+				hh.Warmup = warmups[status.Host.Name]
 				report.OllamaHosts = append(report.OllamaHosts, hh)
 			}
 
@@ -141,7 +156,7 @@ func HealthCmd() *cobra.Command {
 
 			// JSON output
 			if useJSON {
-				data, _ := json.MarshalIndent(report, "", "  ")
+				data, _ := output.Marshal(report)
 				fmt.Println(string(data))
 				return
 			}
@@ -155,6 +170,9 @@ func HealthCmd() *cobra.Command {
 					err = fmt.Errorf("%s", hh.Error)
 				}
 				printServiceStatusCompact(hh.Name, hh.Online, time.Duration(hh.LatencyMs)*time.Millisecond, err)
+				if hh.Warmup != nil {
+					printWarmup(hh.Warmup)
+				}
 			}
 
 			fmt.Println()
@@ -195,11 +213,25 @@ func HealthCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	cmd.Flags().StringVar(&warmupModel, "warmup", "", "Measure time-to-first-token for this model (sends a real prompt)")
 
 	return cmd
 }
 
+func printWarmup(w *WarmupHealth) {
+	if w.Error != "" {
+		fmt.Printf("      %s %s\n", tui.MutedStyle.Render("warmup "+w.Model+":"), tui.WarningStyle.Render(w.Error))
+		return
+	}
+	cached := ""
+	if w.Cached {
+		cached = tui.MutedStyle.Render(" (cached)")
+	}
+	fmt.Printf("      %s first token in %s%s\n",
+		tui.MutedStyle.Render("warmup "+w.Model+":"),
+		time.Duration(w.FirstTokenMs*int64(time.Millisecond)).Round(10*time.Millisecond), cached)
+}
+
 func checkService(url string) ServiceStatus {
 	status := ServiceStatus{URL: url}
 
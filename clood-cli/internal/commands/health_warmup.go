@@ -0,0 +1,134 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dirtybirdnj/clood/internal/hosts"
+	"github.com/dirtybirdnj/clood/internal/ollama"
+)
+
+const (
+	// warmupCacheTTL keeps repeated `health --warmup` runs from reloading
+	// the model every time.
+	warmupCacheTTL = 60 * time.Second
+	warmupPrompt   = "hi"
+	// warmupTimeout allows for large models loading from disk
+	warmupTimeout = 5 * time.Minute
+)
+
+// WarmupHealth is the cold-start measurement for one host
+type WarmupHealth struct {
+	Model        string `json:"model"`
+	FirstTokenMs int64  `json:"first_token_ms,omitempty"`
+	Cached       bool   `json:"cached,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+type warmupCacheEntry struct {
+	FirstTokenMs int64     `json:"first_token_ms"`
+	MeasuredAt   time.Time `json:"measured_at"`
+}
+
+// warmupCachePath returns ~/.clood/warmup.json
+func warmupCachePath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".clood", "warmup.json")
+}
+
+func loadWarmupCache() map[string]warmupCacheEntry {
+	cache := make(map[string]warmupCacheEntry)
+	if data, err := os.ReadFile(warmupCachePath()); err == nil {
+		json.Unmarshal(data, &cache)
+	}
+	return cache
+}
+
+func saveWarmupCache(cache map[string]warmupCacheEntry) error {
+	// Drop stale entries so the file doesn't grow forever
+	for key, entry := range cache {
+		if time.Since(entry.MeasuredAt) > warmupCacheTTL {
+			delete(cache, key)
+		}
+	}
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := warmupCachePath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// warmupHosts measures time-to-first-token for model on every online host
+// that has it. Results younger than warmupCacheTTL are reused.
+func warmupHosts(mgr *hosts.Manager, statuses []*hosts.HostStatus, model string) map[string]*WarmupHealth {
+	cache := loadWarmupCache()
+	results := make(map[string]*WarmupHealth)
+	dirty := false
+
+	for _, status := range statuses {
+		if !status.Online {
+			continue
+		}
+		name := status.Host.Name
+		wh := &WarmupHealth{Model: model}
+		results[name] = wh
+
+		if !hostHasModel(status, model) {
+			wh.Error = "model not installed"
+			continue
+		}
+
+		key := name + "|" + model
+		if entry, ok := cache[key]; ok && time.Since(entry.MeasuredAt) < warmupCacheTTL {
+			wh.FirstTokenMs = entry.FirstTokenMs
+			wh.Cached = true
+			continue
+		}
+
+		ttft, err := measureFirstToken(mgr.GetClient(name), model)
+		if err != nil {
+			wh.Error = err.Error()
+			continue
+		}
+		wh.FirstTokenMs = ttft.Milliseconds()
+		cache[key] = warmupCacheEntry{FirstTokenMs: wh.FirstTokenMs, MeasuredAt: time.Now()}
+		dirty = true
+	}
+
+	if dirty {
+		saveWarmupCache(cache)
+	}
+	return results
+}
+
+// measureFirstToken sends a tiny prompt and returns the time until the
+// first token arrives, which includes loading the model if it was cold.
+// The stream is cancelled as soon as that token shows up.
+func measureFirstToken(client *ollama.Client, model string) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), warmupTimeout)
+	defer cancel()
+
+	var ttft time.Duration
+	start := time.Now()
+	_, err := client.GenerateStreamWithSystemContext(ctx, model, "", warmupPrompt, func(chunk ollama.GenerateResponse) {
+		if ttft == 0 && (chunk.Response != "" || chunk.Done) {
+			ttft = time.Since(start)
+			cancel()
+		}
+	})
+	if ttft > 0 {
+		return ttft, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return 0, fmt.Errorf("no tokens received")
+}
@@ -1,7 +1,7 @@
 package commands
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
@@ -17,11 +17,11 @@ import (
 )
 
 func HostsCmd() *cobra.Command {
-	var jsonOutput bool
 	var gardenView bool
 	var verbose bool
 	var live bool
 	var interval int
+	var timeout time.Duration
 
 	cmd := &cobra.Command{
 		Use:   "hosts",
@@ -38,7 +38,7 @@ Use --live for continuous monitoring with auto-refresh.`,
 				return
 			}
 
-			mgr := hosts.NewManager()
+			mgr := hosts.NewManager(hosts.WithTimeout(timeout))
 			mgr.AddHosts(cfg.Hosts)
 
 			// Live monitoring mode
@@ -47,21 +47,27 @@ Use --live for continuous monitoring with auto-refresh.`,
 				return
 			}
 
+			// Ctrl+C cancels in-flight checks instead of waiting out the timeout
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
 			var statuses []*hosts.HostStatus
 
 			// Check for JSON output mode - no animation needed
-			if jsonOutput || output.IsJSON() {
-				statuses = mgr.CheckAllHosts()
+			if output.IsJSON() {
+				statuses = mgr.CheckAllHostsContext(ctx)
 			} else {
 				// Use streaming loader with animation
-				statuses = checkHostsWithAnimation(mgr)
+				statuses = checkHostsWithAnimation(ctx, mgr)
+			}
+			if ctx.Err() != nil {
+				return
 			}
 
 			// Detect if localhost is same as a named host
 			localAlias := detectLocalAlias(statuses)
 
-			// Check both local --json flag and global -j flag
-			if jsonOutput || output.IsJSON() {
+			if output.IsJSON() {
 				if gardenView {
 					printGardenJSON(statuses, verbose)
 				} else {
@@ -117,15 +123,209 @@ Use --live for continuous monitoring with auto-refresh.`,
 		},
 	}
 
-	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 	cmd.Flags().BoolVar(&gardenView, "garden", false, "Show ASCII art garden visualization")
 	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Show all model details")
 	cmd.Flags().BoolVar(&live, "live", false, "Continuous monitoring with auto-refresh")
 	cmd.Flags().IntVar(&interval, "interval", 5, "Refresh interval in seconds (for --live)")
+	cmd.Flags().DurationVar(&timeout, "timeout", hosts.DefaultCheckTimeout, "Per-host health check timeout")
+
+	cmd.AddCommand(hostsAddCmd())
+	cmd.AddCommand(hostsRemoveCmd())
+	cmd.AddCommand(hostsTunnelCmd())
+	cmd.AddCommand(DiscoverCmd())
+
+	return cmd
+}
+
+func hostsAddCmd() *cobra.Command {
+	var priority int
+	var backend string
+	var apiKey string
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "add <name> <url>",
+		Short: "Add a host to the config",
+		Long: `Adds a new LLM host to ~/.config/clood/config.yaml.
+
+The host is pinged before the config is written. Use --force to add
+a host that is currently offline.
+
+Examples:
+  clood hosts add mac-studio http://10.0.1.5:11434
+  clood hosts add llamacpp http://192.168.4.64:8080 --backend openai
+  clood hosts add laptop http://laptop.local:11434 --priority 3 --force`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			name, url := args[0], strings.TrimRight(args[1], "/")
+
+			if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+				fmt.Println(tui.ErrorStyle.Render("URL must start with http:// or https://"))
+				return
+			}
+
+			cfg, err := config.LoadFile()
+			if err != nil {
+				fmt.Println(tui.ErrorStyle.Render("Error loading config: " + err.Error()))
+				return
+			}
+
+			host := &hosts.Host{
+				Name:     name,
+				URL:      url,
+				Priority: priority,
+				Enabled:  true,
+				Backend:  hosts.BackendType(backend),
+				APIKey:   apiKey,
+			}
+			if host.Backend == hosts.BackendOllama {
+				host.Backend = "" // Default, keep the YAML clean
+			}
+
+			if err := cfg.AddHost(host); err != nil {
+				fmt.Println(tui.ErrorStyle.Render("Error: " + err.Error()))
+				return
+			}
+
+			if !force {
+				mgr := hosts.NewManager()
+				mgr.AddHost(host)
+				status := mgr.CheckHost(host)
+				if !status.Online {
+					msg := "Host is not reachable"
+					if status.Error != nil {
+						msg += ": " + status.Error.Error()
+					}
+					fmt.Println(tui.ErrorStyle.Render(msg))
+					fmt.Println(tui.MutedStyle.Render("Use --force to add it anyway."))
+					return
+				}
+			}
+
+			if err := config.Save(cfg); err != nil {
+				fmt.Println(tui.ErrorStyle.Render("Error saving config: " + err.Error()))
+				return
+			}
+
+			printHostsAfterChange(cfg, fmt.Sprintf("Added host %s (%s)", name, url))
+		},
+	}
+
+	cmd.Flags().IntVar(&priority, "priority", 10, "Host priority (lower = preferred)")
+	cmd.Flags().StringVar(&backend, "backend", "ollama", "Backend type: ollama or openai")
+	cmd.Flags().StringVar(&apiKey, "api-key", "", "Optional API key for the backend")
+	cmd.Flags().BoolVar(&force, "force", false, "Skip the reachability check")
 
 	return cmd
 }
 
+func hostsRemoveCmd() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:     "remove <name>",
+		Aliases: []string{"rm"},
+		Short:   "Remove a host from the config",
+		Long: `Removes a host from ~/.config/clood/config.yaml.
+
+By default the host is pinged first and the removal is refused if it is
+still online, to avoid dropping a working host by typo. Use --force to
+remove it regardless.
+
+Examples:
+  clood hosts remove mac-studio
+  clood hosts rm old-box --force`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			name := args[0]
+
+			cfg, err := config.LoadFile()
+			if err != nil {
+				fmt.Println(tui.ErrorStyle.Render("Error loading config: " + err.Error()))
+				return
+			}
+
+			host := cfg.FindHost(name)
+			if host == nil {
+				fmt.Println(tui.ErrorStyle.Render(fmt.Sprintf("Host %q not found in config", name)))
+				return
+			}
+
+			if !force {
+				mgr := hosts.NewManager()
+				mgr.AddHost(host)
+				if status := mgr.CheckHost(host); status.Online {
+					fmt.Println(tui.ErrorStyle.Render(fmt.Sprintf("Host %s is still online", name)))
+					fmt.Println(tui.MutedStyle.Render("Use --force to remove it anyway."))
+					return
+				}
+			}
+
+			if err := cfg.RemoveHost(name); err != nil {
+				fmt.Println(tui.ErrorStyle.Render("Error: " + err.Error()))
+				return
+			}
+			if output.DryRun {
+				output.WouldHaveDone("remove host "+name+" from "+config.ConfigPath(), map[string]interface{}{
+					"url": host.URL,
+				})
+				return
+			}
+
+			if err := config.Save(cfg); err != nil {
+				fmt.Println(tui.ErrorStyle.Render("Error saving config: " + err.Error()))
+				return
+			}
+
+			printHostsAfterChange(cfg, fmt.Sprintf("Removed host %s", name))
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Remove even if the host is online")
+
+	return withDryRun(cmd)
+}
+
+// printHostsAfterChange confirms a config mutation and shows the resulting host list
+func printHostsAfterChange(cfg *config.Config, message string) {
+	if output.IsJSON() {
+		type hostEntry struct {
+			Name     string `json:"name"`
+			URL      string `json:"url"`
+			Priority int    `json:"priority"`
+			Enabled  bool   `json:"enabled"`
+			Backend  string `json:"backend"`
+		}
+		result := struct {
+			Status string      `json:"status"`
+			Config string      `json:"config"`
+			Hosts  []hostEntry `json:"hosts"`
+		}{
+			Status: message,
+			Config: config.ConfigPath(),
+		}
+		for _, h := range cfg.Hosts {
+			result.Hosts = append(result.Hosts, hostEntry{
+				Name:     h.Name,
+				URL:      h.URL,
+				Priority: h.Priority,
+				Enabled:  h.Enabled,
+				Backend:  string(h.GetBackend()),
+			})
+		}
+		output.MustJSON(result)
+		return
+	}
+
+	fmt.Println(tui.SuccessStyle.Render("✓ " + message))
+	fmt.Println(tui.MutedStyle.Render("  Config: " + config.ConfigPath()))
+	fmt.Println()
+	for _, h := range cfg.Hosts {
+		fmt.Printf("  • %-16s %s %s\n", h.Name, h.URL,
+			tui.MutedStyle.Render(fmt.Sprintf("(priority %d)", h.Priority)))
+	}
+}
+
 // runLiveHostMonitor provides continuous host monitoring with auto-refresh
 func runLiveHostMonitor(mgr *hosts.Manager, gardenView bool, verbose bool, interval int) {
 	// Clear screen and hide cursor
@@ -302,13 +502,14 @@ func printHostStatusWithAlias(status *hosts.HostStatus, localAlias string) {
 
 func printHostsJSON(statuses []*hosts.HostStatus) {
 	type hostJSON struct {
-		Name    string   `json:"name"`
-		URL     string   `json:"url"`
-		Online  bool     `json:"online"`
-		Latency int64    `json:"latency_ms,omitempty"`
-		Version string   `json:"version,omitempty"`
-		Models  []string `json:"models,omitempty"`
-		Error   string   `json:"error,omitempty"`
+		Name    string        `json:"name"`
+		URL     string        `json:"url"`
+		Online  bool          `json:"online"`
+		Latency int64         `json:"latency_ms,omitempty"`
+		Version string        `json:"version,omitempty"`
+		Models  []string      `json:"models,omitempty"`
+		Details []hosts.Model `json:"model_details,omitempty"` // sizes and approximate VRAM
+		Error   string        `json:"error,omitempty"`
 	}
 
 	var result []hostJSON
@@ -324,6 +525,7 @@ func printHostsJSON(statuses []*hosts.HostStatus) {
 			for _, m := range s.Models {
 				h.Models = append(h.Models, m.Name)
 			}
+			h.Details = hosts.HostModels(s)
 		}
 		if s.Error != nil {
 			h.Error = s.Error.Error()
@@ -331,7 +533,7 @@ func printHostsJSON(statuses []*hosts.HostStatus) {
 		result = append(result, h)
 	}
 
-	data, _ := json.MarshalIndent(result, "", "  ")
+	data, _ := output.Marshal(result)
 	fmt.Println(string(data))
 }
 
@@ -458,10 +660,10 @@ func printHostBox(status *hosts.HostStatus, verbose bool) {
 }
 
 func printGardenJSON(statuses []*hosts.HostStatus, verbose bool) {
-	output := GardenOutput{}
+	out := GardenOutput{}
 
 	hostname, _ := os.Hostname()
-	output.Driver = hostname
+	out.Driver = hostname
 
 	uniqueModels := make(map[string]bool)
 
@@ -486,16 +688,16 @@ func printGardenJSON(statuses []*hosts.HostStatus, verbose bool) {
 		}
 
 		if s.Online {
-			output.Summary.Online++
+			out.Summary.Online++
 		}
 
-		output.Hosts = append(output.Hosts, gs)
+		out.Hosts = append(out.Hosts, gs)
 	}
 
-	output.Summary.Total = len(statuses)
-	output.Summary.UniqueModels = len(uniqueModels)
+	out.Summary.Total = len(statuses)
+	out.Summary.UniqueModels = len(uniqueModels)
 
-	data, _ := json.MarshalIndent(output, "", "  ")
+	data, _ := output.Marshal(out)
 	fmt.Println(string(data))
 }
 
@@ -507,7 +709,7 @@ func truncateGarden(s string, max int) string {
 }
 
 // checkHostsWithAnimation checks hosts with a streaming animated display
-func checkHostsWithAnimation(mgr *hosts.Manager) []*hosts.HostStatus {
+func checkHostsWithAnimation(ctx context.Context, mgr *hosts.Manager) []*hosts.HostStatus {
 	allHosts := mgr.GetAllHosts()
 	if len(allHosts) == 0 {
 		return nil
@@ -532,7 +734,7 @@ func checkHostsWithAnimation(mgr *hosts.Manager) []*hosts.HostStatus {
 	loader.Start()
 
 	// Start streaming check
-	resultChan, total := mgr.CheckAllHostsStreaming()
+	resultChan, total := mgr.CheckAllHostsStreamingContext(ctx)
 	statuses := make([]*hosts.HostStatus, total)
 
 	// Collect results as they stream in
@@ -0,0 +1,273 @@
+package commands
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dirtybirdnj/clood/internal/config"
+	"github.com/dirtybirdnj/clood/internal/hosts"
+	"github.com/dirtybirdnj/clood/internal/output"
+	"github.com/dirtybirdnj/clood/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// tunnelStartupWait is how long ssh gets to fail (bad key, port in use)
+// before the tunnel is considered up
+const tunnelStartupWait = 2 * time.Second
+
+func hostsTunnelCmd() *cobra.Command {
+	var port int
+	var stop bool
+
+	cmd := &cobra.Command{
+		Use:   "tunnel <name>",
+		Short: "Forward a remote Ollama over SSH",
+		Long: `Starts a background 'ssh -N -L' port-forward to a host and points the
+host's URL at the local end, so remote Ollama instances that only listen
+on localhost become reachable.
+
+The SSH target comes from the host's ssh_host field:
+
+  hosts:
+    - name: ubuntu25
+      url: http://192.168.4.64:11434
+      ssh_host: mat@ubuntu25.local
+
+The tunnel PID is written to ~/.clood/tunnels/<name>.pid. Use --stop to
+kill the tunnel and restore the original URL.
+
+Examples:
+  clood hosts tunnel ubuntu25
+  clood hosts tunnel ubuntu25 --port 11436
+  clood hosts tunnel --stop ubuntu25`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			cfg, err := config.LoadFile()
+			if err != nil {
+				return fmt.Errorf("loading config: %w", err)
+			}
+
+			host := cfg.FindHost(name)
+			if host == nil {
+				return fmt.Errorf("host %q not found in config", name)
+			}
+
+			if stop {
+				return stopHostTunnel(cfg, host)
+			}
+			return startHostTunnel(cfg, host, port)
+		},
+	}
+
+	cmd.Flags().IntVar(&port, "port", 11435, "Local port to forward")
+	cmd.Flags().BoolVar(&stop, "stop", false, "Stop the tunnel and restore the original URL")
+
+	return cmd
+}
+
+func startHostTunnel(cfg *config.Config, host *hosts.Host, port int) error {
+	if host.SSHHost == "" {
+		return fmt.Errorf("host %s has no ssh_host in %s", host.Name, config.ConfigPath())
+	}
+	if pid, _, err := readTunnel(host.Name); err == nil && isTunnelProcess(pid, host) {
+		return fmt.Errorf("tunnel for %s already running (pid %d); use --stop first", host.Name, pid)
+	}
+
+	// Forward to whatever port Ollama listens on over there, on the remote
+	// side's loopback since that's often all it binds to
+	remotePort := "11434"
+	if u, err := url.Parse(host.URL); err == nil && u.Port() != "" {
+		remotePort = u.Port()
+	}
+
+	dir, err := tunnelDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("create tunnel dir: %w", err)
+	}
+
+	logPath := filepath.Join(dir, host.Name+".log")
+	logFile, err := os.Create(logPath)
+	if err != nil {
+		return fmt.Errorf("create tunnel log: %w", err)
+	}
+	defer logFile.Close()
+
+	ssh := exec.Command("ssh", "-N",
+		"-o", "ExitOnForwardFailure=yes",
+		"-o", "BatchMode=yes",
+		"-o", "ServerAliveInterval=30",
+		"-L", fmt.Sprintf("%d:localhost:%s", port, remotePort),
+		"--", host.SSHHost)
+	detachTunnel(ssh)
+	ssh.Stdout = logFile
+	ssh.Stderr = logFile
+	if err := ssh.Start(); err != nil {
+		return fmt.Errorf("start ssh: %w", err)
+	}
+
+	exited := make(chan error, 1)
+	go func() { exited <- ssh.Wait() }()
+	select {
+	case err := <-exited:
+		msg := strings.TrimSpace(readFileOrEmpty(logPath))
+		if msg == "" && err != nil {
+			msg = err.Error()
+		}
+		return fmt.Errorf("ssh to %s exited: %s", host.SSHHost, msg)
+	case <-time.After(tunnelStartupWait):
+	}
+
+	pid := ssh.Process.Pid
+	originalURL := host.URL
+	if err := writeTunnel(host.Name, pid, originalURL); err != nil {
+		ssh.Process.Kill()
+		return err
+	}
+
+	host.URL = fmt.Sprintf("http://localhost:%d", port)
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("saving config: %w", err)
+	}
+
+	if output.IsJSON() {
+		return output.JSON(map[string]interface{}{
+			"host":         host.Name,
+			"pid":          pid,
+			"url":          host.URL,
+			"original_url": originalURL,
+			"ssh_host":     host.SSHHost,
+		})
+	}
+
+	fmt.Println(tui.SuccessStyle.Render(fmt.Sprintf("✓ Tunnel up: %s → %s:%s", host.URL, host.SSHHost, remotePort)))
+	fmt.Println(tui.MutedStyle.Render(fmt.Sprintf("  pid %d, log %s", pid, logPath)))
+	fmt.Println(tui.MutedStyle.Render(fmt.Sprintf("  Stop with: clood hosts tunnel --stop %s", host.Name)))
+	return nil
+}
+
+func stopHostTunnel(cfg *config.Config, host *hosts.Host) error {
+	pid, originalURL, err := readTunnel(host.Name)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no tunnel recorded for %s", host.Name)
+		}
+		return err
+	}
+
+	// An already-dead tunnel still gets its URL restored. A PID that now
+	// belongs to something else is left alone.
+	stopped := false
+	if isTunnelProcess(pid, host) {
+		proc, _ := os.FindProcess(pid)
+		if err := terminateProcess(proc); err != nil {
+			return fmt.Errorf("stop tunnel pid %d: %w", pid, err)
+		}
+		stopped = true
+	}
+
+	if originalURL != "" {
+		host.URL = originalURL
+		if err := config.Save(cfg); err != nil {
+			return fmt.Errorf("saving config: %w", err)
+		}
+	}
+	removeTunnel(host.Name)
+
+	if output.IsJSON() {
+		return output.JSON(map[string]interface{}{
+			"host":    host.Name,
+			"pid":     pid,
+			"stopped": stopped,
+			"url":     host.URL,
+		})
+	}
+
+	if stopped {
+		fmt.Println(tui.SuccessStyle.Render(fmt.Sprintf("✓ Stopped tunnel for %s (pid %d)", host.Name, pid)))
+	} else {
+		fmt.Println(tui.MutedStyle.Render(fmt.Sprintf("Tunnel for %s was not running (pid %d)", host.Name, pid)))
+	}
+	fmt.Println(tui.MutedStyle.Render("  URL restored to " + host.URL))
+	return nil
+}
+
+func tunnelDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".clood", "tunnels"), nil
+}
+
+// writeTunnel records a running tunnel: <name>.pid holds the ssh PID and
+// <name>.url the URL to restore on --stop
+func writeTunnel(name string, pid int, originalURL string) error {
+	dir, err := tunnelDir()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, name+".pid"), []byte(strconv.Itoa(pid)+"\n"), 0644); err != nil {
+		return fmt.Errorf("write tunnel pid: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name+".url"), []byte(originalURL+"\n"), 0644); err != nil {
+		return fmt.Errorf("write tunnel url: %w", err)
+	}
+	return nil
+}
+
+func readTunnel(name string) (int, string, error) {
+	dir, err := tunnelDir()
+	if err != nil {
+		return 0, "", err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, name+".pid"))
+	if err != nil {
+		return 0, "", err
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, "", fmt.Errorf("bad tunnel pid file for %s: %w", name, err)
+	}
+	originalURL := strings.TrimSpace(readFileOrEmpty(filepath.Join(dir, name+".url")))
+	return pid, originalURL, nil
+}
+
+func removeTunnel(name string) {
+	dir, err := tunnelDir()
+	if err != nil {
+		return
+	}
+	for _, ext := range []string{".pid", ".url", ".log"} {
+		os.Remove(filepath.Join(dir, name+ext))
+	}
+}
+
+// isTunnelProcess reports whether pid is still the ssh started for host,
+// rather than an unrelated process given the PID after the tunnel died
+func isTunnelProcess(pid int, host *hosts.Host) bool {
+	args := processArgs(pid)
+	if len(args) < 2 {
+		return false
+	}
+	name := strings.TrimSuffix(filepath.Base(args[0]), ".exe")
+	return name == "ssh" && args[len(args)-2] == "--" && args[len(args)-1] == host.SSHHost
+}
+
+func readFileOrEmpty(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
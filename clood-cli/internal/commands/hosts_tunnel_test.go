@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/dirtybirdnj/clood/internal/hosts"
+)
+
+func setTestHome(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	return home
+}
+
+func TestWriteReadTunnel(t *testing.T) {
+	home := setTestHome(t)
+	dir := filepath.Join(home, ".clood", "tunnels")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := readTunnel("gpu"); !os.IsNotExist(err) {
+		t.Fatalf("readTunnel with nothing recorded = %v; want not-exist", err)
+	}
+
+	if err := writeTunnel("gpu", 4242, "http://192.168.4.64:11434"); err != nil {
+		t.Fatal(err)
+	}
+	pid, url, err := readTunnel("gpu")
+	if err != nil || pid != 4242 || url != "http://192.168.4.64:11434" {
+		t.Errorf("readTunnel = %d, %q, %v; want 4242 and the original URL", pid, url, err)
+	}
+
+	// A tunnel recorded without a URL file still reads, with nothing to restore
+	os.Remove(filepath.Join(dir, "gpu.url"))
+	if pid, url, err := readTunnel("gpu"); err != nil || pid != 4242 || url != "" {
+		t.Errorf("readTunnel without url = %d, %q, %v", pid, url, err)
+	}
+
+	os.WriteFile(filepath.Join(dir, "gpu.pid"), []byte("not a pid\n"), 0644)
+	if _, _, err := readTunnel("gpu"); err == nil {
+		t.Error("readTunnel with a bad pid file should fail")
+	}
+
+	os.WriteFile(filepath.Join(dir, "gpu.log"), []byte("log"), 0644)
+	removeTunnel("gpu")
+	for _, ext := range []string{".pid", ".url", ".log"} {
+		if _, err := os.Stat(filepath.Join(dir, "gpu"+ext)); !os.IsNotExist(err) {
+			t.Errorf("removeTunnel left gpu%s", ext)
+		}
+	}
+}
+
+func TestIsTunnelProcess(t *testing.T) {
+	host := &hosts.Host{Name: "gpu", SSHHost: "mat@gpu.local"}
+
+	// The test binary itself is alive but not an ssh tunnel
+	if isTunnelProcess(os.Getpid(), host) {
+		t.Error("the test process was taken for a tunnel")
+	}
+
+	bash, err := exec.LookPath("bash")
+	if err != nil || runtime.GOOS == "windows" {
+		t.Skip("needs bash to fake an ssh process")
+	}
+	// A process that looks like 'ssh ... -- mat@gpu.local' to ps
+	fake := exec.Command(bash, "-c", `exec -a ssh sh -c 'sleep 30; :' -- "$0"`, host.SSHHost)
+	if err := fake.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		fake.Process.Kill()
+		fake.Wait()
+	}()
+
+	waitFor := func(want bool) bool {
+		for i := 0; i < 50; i++ {
+			if isTunnelProcess(fake.Process.Pid, host) == want {
+				return true
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+		return false
+	}
+	if !waitFor(true) {
+		t.Fatalf("ssh tunnel pid %d not recognised: %q", fake.Process.Pid, processArgs(fake.Process.Pid))
+	}
+	if isTunnelProcess(fake.Process.Pid, &hosts.Host{Name: "other", SSHHost: "mat@other.local"}) {
+		t.Error("tunnel to another host was taken for this one")
+	}
+
+	fake.Process.Kill()
+	fake.Wait()
+	if isTunnelProcess(fake.Process.Pid, host) {
+		t.Error("an exited tunnel was still reported as running")
+	}
+}
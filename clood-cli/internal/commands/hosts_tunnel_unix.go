@@ -0,0 +1,30 @@
+//go:build !windows
+
+package commands
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// detachTunnel puts ssh in its own session, so closing the terminal that
+// started it (or Ctrl+C there) doesn't take the tunnel down
+func detachTunnel(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+}
+
+// processArgs returns pid's command line, or nil if it isn't running
+func processArgs(pid int) []string {
+	out, err := exec.Command("ps", "-o", "args=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return nil
+	}
+	return strings.Fields(string(out))
+}
+
+func terminateProcess(proc *os.Process) error {
+	return proc.Signal(syscall.SIGTERM)
+}
@@ -0,0 +1,32 @@
+//go:build windows
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+)
+
+// detachTunnel starts ssh in its own process group, out of reach of the
+// console's Ctrl+C
+func detachTunnel(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+}
+
+// processArgs returns pid's command line, or nil if it isn't running
+func processArgs(pid int) []string {
+	query := fmt.Sprintf("(Get-CimInstance Win32_Process -Filter 'ProcessId=%d').CommandLine", pid)
+	out, err := exec.Command("powershell", "-NoProfile", "-Command", query).Output()
+	if err != nil {
+		return nil
+	}
+	return strings.Fields(strings.ReplaceAll(string(out), `"`, ""))
+}
+
+// terminateProcess kills proc: Windows has no SIGTERM to send
+func terminateProcess(proc *os.Process) error {
+	return proc.Kill()
+}
@@ -159,12 +159,11 @@ func hfListCmd() *cobra.Command {
 				for _, m := range popularGGUFModels {
 					models = append(models, m)
 				}
-				data, _ := json.MarshalIndent(ModelList{
+				output.JSON(ModelList{
 					ModelDir:  modelDir,
 					Models:    models,
 					Installed: installed,
-				}, "", "  ")
-				fmt.Println(string(data))
+				})
 				return
 			}
 
@@ -300,7 +299,7 @@ func pullGGUFModel(modelName, modelDir, quant string) error {
 				Path:    outputFile,
 				Success: true,
 			}
-			data, _ := json.MarshalIndent(result, "", "  ")
+			data, _ := output.Marshal(result)
 			fmt.Println(string(data))
 		} else {
 			fmt.Printf("  %s %s already exists at %s\n", tui.SuccessStyle.Render("✓"), model.Name, outputFile)
@@ -329,7 +328,7 @@ func pullGGUFModel(modelName, modelDir, quant string) error {
 				Success: false,
 				Error:   err.Error(),
 			}
-			data, _ := json.MarshalIndent(result, "", "  ")
+			data, _ := output.Marshal(result)
 			fmt.Println(string(data))
 		}
 		return fmt.Errorf("download failed: %w", err)
@@ -352,7 +351,7 @@ func pullGGUFModel(modelName, modelDir, quant string) error {
 			Duration: duration.Round(time.Second).String(),
 			Success:  true,
 		}
-		data, _ := json.MarshalIndent(result, "", "  ")
+		data, _ := output.Marshal(result)
 		fmt.Println(string(data))
 	} else {
 		fmt.Println()
@@ -443,7 +442,7 @@ func hfPathCmd() *cobra.Command {
 		Run: func(cmd *cobra.Command, args []string) {
 			modelDir := getModelDir()
 			if output.IsJSON() {
-				data, _ := json.MarshalIndent(map[string]string{"path": modelDir}, "", "  ")
+				data, _ := output.Marshal(map[string]string{"path": modelDir})
 				fmt.Println(string(data))
 			} else {
 				fmt.Println(modelDir)
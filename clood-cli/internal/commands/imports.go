@@ -1,7 +1,6 @@
 package commands
 
 import (
-	"encoding/json"
 	"fmt"
 	"go/parser"
 	"go/token"
@@ -11,6 +10,8 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/dirtybirdnj/clood/internal/analyze"
+	"github.com/dirtybirdnj/clood/internal/output"
 	"github.com/dirtybirdnj/clood/internal/tui"
 	"github.com/spf13/cobra"
 )
@@ -29,16 +30,20 @@ type DependencyInfo struct {
 }
 
 func ImportsCmd() *cobra.Command {
-	var jsonOutput bool
 	var reverseMode bool
 	var showAll bool
+	var checkCircular bool
 
 	cmd := &cobra.Command{
 		Use:   "imports [FILE|DIR]",
 		Short: "Analyze Go imports and dependencies",
-		Long:  "Parse Go files to show imports or find reverse dependencies (who imports a package).",
+		Long: `Parse Go files to show imports or find reverse dependencies (who imports a package).
+
+With --check-circular, builds the package import graph for the module and
+reports any cycles (exit code 1 if one is found).`,
 		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput := output.IsJSON()
 			target := "."
 			if len(args) > 0 {
 				target = args[0]
@@ -50,6 +55,13 @@ func ImportsCmd() *cobra.Command {
 				return nil
 			}
 
+			if checkCircular {
+				if !info.IsDir() {
+					target = filepath.Dir(target)
+				}
+				return checkImportCycles(target, output.IsJSON())
+			}
+
 			if reverseMode {
 				// Reverse mode: find files that import the target package
 				return findReverseImports(target, jsonOutput)
@@ -77,8 +89,8 @@ func ImportsCmd() *cobra.Command {
 
 			// Output results
 			if jsonOutput {
-				output, _ := json.MarshalIndent(results, "", "  ")
-				fmt.Println(string(output))
+				data, _ := output.Marshal(results)
+				fmt.Println(string(data))
 			} else {
 				printImports(results)
 			}
@@ -87,9 +99,9 @@ func ImportsCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Output as JSON")
 	cmd.Flags().BoolVarP(&reverseMode, "reverse", "r", false, "Find files that import the given package path")
 	cmd.Flags().BoolVarP(&showAll, "all", "a", false, "Show all files (including those with no imports)")
+	cmd.Flags().BoolVar(&checkCircular, "check-circular", false, "Detect circular imports between packages")
 
 	return cmd
 }
@@ -105,7 +117,7 @@ func processDirectory(dir string, showAll bool) ([]ImportInfo, error) {
 		// Skip unwanted directories
 		if info.IsDir() {
 			name := info.Name()
-			if shouldSkipImportDir(name) {
+			if path != dir && shouldSkipImportDir(name) {
 				return filepath.SkipDir
 			}
 			return nil
@@ -173,7 +185,7 @@ func findReverseImports(pkgPath string, jsonOutput bool) error {
 		}
 
 		if info.IsDir() {
-			if shouldSkipImportDir(info.Name()) {
+			if path != "." && shouldSkipImportDir(info.Name()) {
 				return filepath.SkipDir
 			}
 			return nil
@@ -204,11 +216,10 @@ func findReverseImports(pkgPath string, jsonOutput bool) error {
 	}
 
 	if jsonOutput {
-		output, _ := json.MarshalIndent(DependencyInfo{
+		output.JSON(DependencyInfo{
 			Package:   pkgPath,
 			ImportedBy: importedBy,
-		}, "", "  ")
-		fmt.Println(string(output))
+		})
 	} else {
 		fmt.Println(tui.RenderHeader("Reverse Dependencies"))
 		fmt.Println()
@@ -227,6 +238,102 @@ func findReverseImports(pkgPath string, jsonOutput bool) error {
 	return nil
 }
 
+// ImportCycleReport is the --check-circular result
+type ImportCycleReport struct {
+	Module   string   `json:"module"`
+	Packages int      `json:"packages"`
+	Cycles   []string `json:"cycles"`
+}
+
+// checkImportCycles reports import cycles among the module's packages under
+// dir and exits non-zero when there are any
+func checkImportCycles(dir string, jsonOutput bool) error {
+	modRoot, modPath, err := findGoModule(dir)
+	if err != nil {
+		return err
+	}
+
+	files, err := processDirectory(dir, false)
+	if err != nil {
+		return err
+	}
+
+	// Only in-module edges can close a cycle
+	graph := make(map[string][]string)
+	for _, f := range files {
+		abs, err := filepath.Abs(filepath.Dir(f.File))
+		if err != nil {
+			continue
+		}
+		rel, err := filepath.Rel(modRoot, abs)
+		if err != nil {
+			continue
+		}
+		pkg := modPath
+		if rel != "." {
+			pkg = modPath + "/" + filepath.ToSlash(rel)
+		}
+		if _, ok := graph[pkg]; !ok {
+			graph[pkg] = nil
+		}
+		for _, imp := range f.Imports {
+			if imp == modPath || strings.HasPrefix(imp, modPath+"/") {
+				graph[pkg] = append(graph[pkg], imp)
+			}
+		}
+	}
+
+	report := ImportCycleReport{Module: modPath, Packages: len(graph), Cycles: []string{}}
+	for _, cycle := range analyze.ImportCycles(graph) {
+		report.Cycles = append(report.Cycles, "cycle: "+strings.Join(cycle, " -> "))
+	}
+
+	if jsonOutput {
+		data, _ := output.Marshal(report)
+		fmt.Println(string(data))
+	} else {
+		fmt.Println(tui.RenderHeader("Circular Imports"))
+		fmt.Println()
+		fmt.Printf("  Module:   %s\n", report.Module)
+		fmt.Printf("  Packages: %d\n\n", report.Packages)
+		if len(report.Cycles) == 0 {
+			fmt.Println(tui.SuccessStyle.Render("  ✓ No import cycles"))
+		}
+		for _, c := range report.Cycles {
+			fmt.Println(tui.ErrorStyle.Render("  " + c))
+		}
+	}
+
+	if len(report.Cycles) > 0 {
+		os.Exit(1)
+	}
+	return nil
+}
+
+// findGoModule walks up from dir to the nearest go.mod and returns its
+// directory and module path
+func findGoModule(dir string) (string, string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", "", err
+	}
+
+	for d := abs; ; d = filepath.Dir(d) {
+		data, err := os.ReadFile(filepath.Join(d, "go.mod"))
+		if err == nil {
+			for _, line := range strings.Split(string(data), "\n") {
+				if rest, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+					return d, strings.Trim(strings.TrimSpace(rest), `"`), nil
+				}
+			}
+			return "", "", fmt.Errorf("%s has no module directive", filepath.Join(d, "go.mod"))
+		}
+		if filepath.Dir(d) == d {
+			return "", "", fmt.Errorf("no go.mod found above %s", abs)
+		}
+	}
+}
+
 func printImports(results []ImportInfo) {
 	if len(results) == 0 {
 		fmt.Println(tui.MutedStyle.Render("No Go files found"))
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/dirtybirdnj/clood/internal/config"
 	"github.com/dirtybirdnj/clood/internal/inception"
 	"github.com/dirtybirdnj/clood/internal/tui"
 	"github.com/spf13/cobra"
@@ -49,14 +51,16 @@ type inceptionModel struct {
 	subQueryModel      string
 	subQueryCount      int
 	subQueryResponses  []string // Track expert responses for continuation
+	currentExpert      string   // The streaming expert answer so far
 	needsContinuation  bool     // Flag to auto-continue after sub-queries
+	notice             string   // /roles feedback shown on the prompt screen
 
 	// Spinner for loading states
 	spinner spinner.Model
 
 	// Channels
 	inputChan  chan string
-	outputChan chan string
+	outputChan <-chan string
 }
 
 // Inception-specific styles
@@ -81,6 +85,8 @@ var (
 func InceptionCmd() *cobra.Command {
 	var model string
 	var expertModel string
+	var depth int
+	var roles string
 
 	cmd := &cobra.Command{
 		Use:   "inception",
@@ -90,8 +96,9 @@ func InceptionCmd() *cobra.Command {
 The main LLM can emit:
   <sub-query model="science">What is orbital velocity?</sub-query>
 
-The stream pauses, queries the expert model, injects the response,
-and the main LLM continues with the new knowledge.
+The stream pauses and queries the expert model, whose answer streams in
+as it's generated behind an [EXPERT: science] label; then the main LLM
+continues with the new knowledge.
 
 Available expert aliases (mapped to installed models):
   science, math, reason  → llama3.1:8b (knowledge/reasoning)
@@ -99,17 +106,47 @@ Available expert aliases (mapped to installed models):
   creative, writer       → mistral:7b (language/creativity)
   fast, quick            → tinyllama/qwen2.5-coder:3b (speed)
 
+Add or override aliases in ~/.config/clood/inception.yaml:
+  expert_aliases:
+    physics: phi4:14b
+
+--roles maps aliases for this session only, over inception.yaml:
+  --roles science=phi4:14b,code=qwen2.5-coder:32b
+
+While it runs, type /roles to list the mapping, or /roles alias=model,...
+to change it; later sub-queries use the new models, even mid-stream.
+
+Experts answer directly by default. --depth 2 (or max_depth: 2 in
+inception.yaml) lets an expert send its own sub-queries one level
+further; the limit stops experts calling each other forever.
+
 Examples:
   clood inception                                    # defaults
   clood inception --model llama3.1:8b                # stronger main model
   clood inception --model qwen2.5-coder:14b          # use your 14b model
+  clood inception --depth 2                          # experts may ask experts
+  clood inception --roles science=phi4:14b           # this session's science expert
 
 Pro tip: Ask something that REQUIRES expert knowledge, like:
   "Write Python code to calculate the ISS orbital position"
   "Explain quantum entanglement and write a simulation"`,
 		Run: func(cmd *cobra.Command, args []string) {
-			// Create inception handler
-			handler := inception.NewHandler()
+			// Create inception handler, with any aliases from inception.yaml
+			var aliases map[string]string
+			maxDepth := 0
+			if cfg, err := config.Load(); err == nil {
+				aliases = cfg.Inception.ExpertAliases
+				maxDepth = cfg.Inception.MaxDepth
+			} else {
+				fmt.Println(tui.WarningStyle.Render("Ignoring config: " + err.Error()))
+			}
+			handler := inception.NewHandler(aliases)
+			if cmd.Flags().Changed("depth") {
+				maxDepth = depth
+			}
+			if maxDepth > 0 {
+				handler.MaxDepth = maxDepth
+			}
 			if expertModel != "" {
 				// Override default expert model
 				handler.Registry["science"] = expertModel
@@ -117,6 +154,14 @@ Pro tip: Ask something that REQUIRES expert knowledge, like:
 				handler.Registry["code"] = expertModel
 				handler.Registry["default"] = expertModel
 			}
+			if roles != "" {
+				parsed, err := inception.ParseRoles(roles)
+				if err != nil {
+					fmt.Println(tui.ErrorStyle.Render("--roles: " + err.Error()))
+					return
+				}
+				handler.SetRoles(parsed)
+			}
 
 			// Initialize spinner
 			s := spinner.New()
@@ -132,14 +177,6 @@ Pro tip: Ask something that REQUIRES expert knowledge, like:
 				spinner:    s,
 			}
 
-			// Set up handler callbacks
-			handler.OnSubQueryStart = func(q inception.SubQuery) {
-				// This will be called from the stream processor goroutine
-			}
-			handler.OnSubQueryEnd = func(r inception.SubQueryResult) {
-				// This will be called from the stream processor goroutine
-			}
-
 			p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
 			if _, err := p.Run(); err != nil {
 				fmt.Println(tui.ErrorStyle.Render("Error: " + err.Error()))
@@ -149,6 +186,8 @@ Pro tip: Ask something that REQUIRES expert knowledge, like:
 
 	cmd.Flags().StringVar(&model, "model", "qwen2.5-coder:3b", "Main model for generation")
 	cmd.Flags().StringVar(&expertModel, "expert", "", "Expert model for sub-queries (defaults to model registry)")
+	cmd.Flags().StringVar(&roles, "roles", "", "Expert aliases for this session, as alias=model,... (overrides inception.yaml)")
+	cmd.Flags().IntVar(&depth, "depth", inception.DefaultMaxDepth, "Sub-query nesting limit; 2 lets experts query other experts")
 	cmd.Flags().Bool("demo", false, "Demo mode: inject a sub-query example to show the feature")
 
 	return cmd
@@ -182,6 +221,12 @@ func (m inceptionModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.content += injection
 		m.subQueryActive = false
 		m.needsContinuation = true // Mark that we need to continue after main stream
+		// The footer is all that's injected once an answer has streamed;
+		// the continuation needs the answer itself
+		if m.currentExpert != "" {
+			injection = fmt.Sprintf("%s %s\n", expertLabel(m.subQueryModel), strings.TrimSpace(m.currentExpert))
+			m.currentExpert = ""
+		}
 		m.subQueryResponses = append(m.subQueryResponses, injection)
 		m.viewport.SetContent(m.renderContent())
 		if m.following {
@@ -195,6 +240,7 @@ func (m inceptionModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case inceptionSubQueryChunkMsg:
 		// Streaming chunk from expert model - append to display
 		m.content += string(msg)
+		m.currentExpert += string(msg)
 		m.viewport.SetContent(m.renderContent())
 		if m.following {
 			m.viewport.GotoBottom()
@@ -208,8 +254,8 @@ func (m inceptionModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.subQueryActive = true
 		m.subQueryModel = msg.query.Model
 		m.subQueryCount++
-		// Add visual indicator - start of expert response section
-		m.content += fmt.Sprintf("\n───── ⚡ EXPERT [%s] ─────\n", msg.query.Model)
+		// Visual indicator - the expert's tokens follow it on the same line
+		m.content += fmt.Sprintf("\n%s ", expertLabel(msg.query.Model))
 		m.viewport.SetContent(m.renderContent())
 		if m.following {
 			m.viewport.GotoBottom()
@@ -255,25 +301,7 @@ func (m inceptionModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.subQueryResponses = nil // Clear for next round
 
 			// Start new stream for continuation
-			m.streaming = true
-			m.following = true
-			rawChan := make(chan string, 100)
-			ctx := context.Background()
-			processor := inception.NewStreamProcessor(ctx, m.handler, rawChan)
-			m.outputChan = make(chan string, 100)
-
-			processedChan := processor.Start()
-			go func() {
-				for chunk := range processedChan {
-					m.outputChan <- chunk
-				}
-				close(m.outputChan)
-			}()
-
-			historyCopy := make([]ChatMessage, len(m.history))
-			copy(historyCopy, m.history)
-			go streamInceptionChat(m.modelName, historyCopy, rawChan)
-
+			m.startInceptionStream()
 			cmds = append(cmds, waitForInceptionOutput(m.outputChan))
 		} else {
 			m.content += "\n═══════════════════════════════════════════════════════════════════\n"
@@ -307,7 +335,12 @@ func (m inceptionModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 		case "enter":
-			if m.inputBuffer != "" && !m.streaming {
+			if arg, ok := rolesCommand(m.inputBuffer); ok {
+				// Handled even mid-stream; the handler locks the registry
+				m.inputBuffer = ""
+				m.applyRoles(arg)
+				m.viewport.SetContent(m.renderContent())
+			} else if m.inputBuffer != "" && !m.streaming {
 				if m.promptMode {
 					m.startInception()
 					m.viewport.SetContent(m.renderContent())
@@ -379,29 +412,112 @@ func (m inceptionModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
-func waitForInceptionOutput(ch chan string) tea.Cmd {
+func waitForInceptionOutput(ch <-chan string) tea.Cmd {
 	return func() tea.Msg {
 		chunk, ok := <-ch
 		if !ok {
 			return inceptionDoneMsg{}
 		}
-		// Check if this is an injection (sub-query response footer)
-		if strings.HasPrefix(chunk, "\n───── END EXPERT") {
+		// Check if this is an injection (sub-query response footer, or
+		// the failure that stands in for one)
+		if strings.HasPrefix(chunk, "\n───── END EXPERT") || strings.HasPrefix(chunk, "\n[Sub-query failed") {
 			return inceptionInjectionMsg(chunk)
 		}
 		// Check if this is a sub-query start marker
-		if strings.HasPrefix(chunk, "⚡START⚡") {
-			model := strings.TrimPrefix(chunk, "⚡START⚡")
+		if model, ok := strings.CutPrefix(chunk, inception.ExpertStartPrefix); ok {
 			return inceptionSubQueryStartMsg{query: inception.SubQuery{Model: model}}
 		}
 		// Check if this is a streaming sub-query chunk
-		if strings.HasPrefix(chunk, "⚡") {
-			return inceptionSubQueryChunkMsg(strings.TrimPrefix(chunk, "⚡"))
+		if token, ok := strings.CutPrefix(chunk, inception.ExpertChunkPrefix); ok {
+			return inceptionSubQueryChunkMsg(token)
 		}
 		return inceptionChunkMsg(chunk)
 	}
 }
 
+// expertLabel prefixes an expert's streamed answer
+func expertLabel(model string) string {
+	return fmt.Sprintf("[EXPERT: %s]", model)
+}
+
+// splitExpertLabel separates a leading expertLabel from the answer text
+func splitExpertLabel(line string) (label, rest string, ok bool) {
+	if !strings.HasPrefix(line, "[EXPERT: ") {
+		return "", line, false
+	}
+	end := strings.IndexByte(line, ']')
+	if end < 0 {
+		return "", line, false
+	}
+	return line[:end+1], line[end+1:], true
+}
+
+// rolesCommand reports whether input is a /roles command, and its argument
+func rolesCommand(input string) (string, bool) {
+	fields := strings.Fields(input)
+	if len(fields) == 0 || fields[0] != "/roles" {
+		return "", false
+	}
+	return strings.Join(fields[1:], ""), true
+}
+
+// applyRoles lists the alias mapping, or with arg (alias=model,...)
+// updates it for the sub-queries that follow
+func (m *inceptionModel) applyRoles(arg string) {
+	var msg string
+	if arg == "" {
+		roles := m.handler.Roles()
+		aliases := make([]string, 0, len(roles))
+		for alias := range roles {
+			aliases = append(aliases, alias)
+		}
+		sort.Strings(aliases)
+		for i, alias := range aliases {
+			aliases[i] = alias + "=" + roles[alias]
+		}
+		msg = "🎭 ROLES: " + strings.Join(aliases, ", ")
+	} else if roles, err := inception.ParseRoles(arg); err != nil {
+		msg = "❌ /roles: " + err.Error()
+	} else {
+		m.handler.SetRoles(roles)
+		var set []string
+		for alias, model := range roles {
+			set = append(set, alias+"="+model)
+		}
+		sort.Strings(set)
+		msg = "🎭 ROLES UPDATED: " + strings.Join(set, ", ")
+	}
+
+	if m.promptMode {
+		m.notice = msg
+		return
+	}
+	m.content += "\n" + msg + "\n"
+	if m.following {
+		m.viewport.GotoBottom()
+	}
+}
+
+// startInceptionStream streams m.history from the main model through an
+// inception processor, which streams any expert answers inline
+func (m *inceptionModel) startInceptionStream() {
+	m.streaming = true
+	m.following = true
+
+	// Create raw stream channel from Ollama
+	rawChan := make(chan string, 100)
+
+	// Create inception processor
+	processor := inception.NewStreamProcessor(context.Background(), m.handler, rawChan)
+	processor.StreamExperts = true
+	m.outputChan = processor.Start()
+
+	// Start Ollama stream
+	historyCopy := make([]ChatMessage, len(m.history))
+	copy(historyCopy, m.history)
+	go streamInceptionChat(m.modelName, historyCopy, rawChan)
+}
+
 func (m *inceptionModel) startInception() {
 	prompt := m.inputBuffer
 	m.inputBuffer = ""
@@ -424,42 +540,7 @@ func (m *inceptionModel) startInception() {
 	m.content += "═══════════════════════════════════════════════════════════════════\n\n"
 
 	// Start streaming with inception processing
-	m.streaming = true
-	m.following = true
-
-	// Create raw stream channel from Ollama
-	rawChan := make(chan string, 100)
-
-	// Create inception processor
-	ctx := context.Background()
-	processor := inception.NewStreamProcessor(ctx, m.handler, rawChan)
-	m.outputChan = make(chan string, 100)
-
-	// Set up callbacks for streaming sub-query
-	m.handler.OnSubQueryStart = func(q inception.SubQuery) {
-		// Send header with special prefix
-		m.outputChan <- fmt.Sprintf("⚡START⚡%s", q.Model)
-	}
-	m.handler.OnSubQueryChunk = func(chunk string) {
-		// Send chunk with prefix
-		m.outputChan <- "⚡" + chunk
-	}
-
-	// Start the processor
-	processedChan := processor.Start()
-
-	// Forward processed output to our output channel
-	go func() {
-		for chunk := range processedChan {
-			m.outputChan <- chunk
-		}
-		close(m.outputChan)
-	}()
-
-	// Start Ollama stream
-	historyCopy := make([]ChatMessage, len(m.history))
-	copy(historyCopy, m.history)
-	go streamInceptionChat(m.modelName, historyCopy, rawChan)
+	m.startInceptionStream()
 }
 
 func (m *inceptionModel) submitFollowUp() {
@@ -477,34 +558,8 @@ func (m *inceptionModel) submitFollowUp() {
 	m.content += fmt.Sprintf("ASSISTANT [%s]\n", m.modelName)
 	m.content += "═══════════════════════════════════════════════════════════════════\n\n"
 
-	m.streaming = true
-	m.following = true
-
 	// Same inception pipeline
-	rawChan := make(chan string, 100)
-	ctx := context.Background()
-	processor := inception.NewStreamProcessor(ctx, m.handler, rawChan)
-	m.outputChan = make(chan string, 100)
-
-	// Set up callbacks for streaming sub-query
-	m.handler.OnSubQueryStart = func(q inception.SubQuery) {
-		m.outputChan <- fmt.Sprintf("⚡START⚡%s", q.Model)
-	}
-	m.handler.OnSubQueryChunk = func(chunk string) {
-		m.outputChan <- "⚡" + chunk
-	}
-
-	processedChan := processor.Start()
-	go func() {
-		for chunk := range processedChan {
-			m.outputChan <- chunk
-		}
-		close(m.outputChan)
-	}()
-
-	historyCopy := make([]ChatMessage, len(m.history))
-	copy(historyCopy, m.history)
-	go streamInceptionChat(m.modelName, historyCopy, rawChan)
+	m.startInceptionStream()
 
 	m.viewport.SetContent(m.renderContent())
 	m.viewport.GotoBottom()
@@ -625,6 +680,11 @@ func (m inceptionModel) renderContent() string {
 				sb.WriteString(leftPad)
 				sb.WriteString(subQueryStyle.Render(wrappedLine))
 				sb.WriteString("\n")
+			} else if label, rest, ok := splitExpertLabel(wrappedLine); ok {
+				sb.WriteString(leftPad)
+				sb.WriteString(subQueryStyle.Render(label))
+				sb.WriteString(rest)
+				sb.WriteString("\n")
 			} else if strings.Contains(wrappedLine, "⏳") {
 				sb.WriteString(leftPad)
 				sb.WriteString(subQueryActiveStyle.Render(wrappedLine))
@@ -680,6 +740,14 @@ func (m inceptionModel) renderPromptMode() string {
 	sb.WriteString(padding)
 	sb.WriteString(infoStyle.Render("Experts: science, math, code, creative, reason"))
 	sb.WriteString("\n\n")
+	if m.notice != "" {
+		for _, line := range wrapText(m.notice, boxWidth) {
+			sb.WriteString(padding)
+			sb.WriteString(infoStyle.Render(line))
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
 
 	// Instructions
 	sb.WriteString(padding)
@@ -724,7 +792,7 @@ func (m inceptionModel) renderPromptMode() string {
 	sb.WriteString("\n")
 	sb.WriteString(padding)
 	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#666666"))
-	sb.WriteString(helpStyle.Render("[Enter] start inception  [Esc] quit"))
+	sb.WriteString(helpStyle.Render("[Enter] start inception  /roles map experts  [Esc] quit"))
 	sb.WriteString("\n")
 
 	return sb.String()
@@ -794,7 +862,7 @@ func (m inceptionModel) View() string {
 	} else if m.inputBuffer != "" {
 		helpText = "[enter]send [esc]clear"
 	} else {
-		helpText = "Ask follow-up questions  [F]ollow [esc]quit"
+		helpText = "Ask follow-up questions  /roles map experts  [F]ollow [esc]quit"
 	}
 
 	// Build multi-line input display
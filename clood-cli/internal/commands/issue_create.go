@@ -0,0 +1,290 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dirtybirdnj/clood/internal/analyze"
+	"github.com/dirtybirdnj/clood/internal/config"
+	"github.com/dirtybirdnj/clood/internal/git"
+	"github.com/dirtybirdnj/clood/internal/output"
+	"github.com/dirtybirdnj/clood/internal/router"
+	"github.com/dirtybirdnj/clood/internal/tui"
+	"github.com/google/go-github/v50/github"
+	"github.com/spf13/cobra"
+)
+
+const issueReportSystemPrompt = `You are a maintainer filing a GitHub issue from static analysis results.
+Write for a developer who hasn't seen the analysis. Be concrete: name files,
+lines and messages exactly as given. Do not invent problems that aren't in
+the analysis, and leave out sections that would be empty.`
+
+const issueReportTemplate = `Respond in exactly this format:
+
+TITLE: <one line, under 80 characters>
+
+BODY:
+## Summary
+<one or two sentences on what's wrong overall>
+
+## Build errors
+- <file:line - message>
+
+## Vet warnings
+- <file:line - message>
+
+## TODOs
+- <file:line - item>
+
+## Suggested next steps
+1. <most important fix first>`
+
+// IssueCmd groups commands that write GitHub issues. 'clood issues' is the
+// read-only dashboard.
+func IssueCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "issue",
+		Short: "Create GitHub issues from local analysis",
+	}
+
+	cmd.AddCommand(issueCreateCmd())
+
+	return cmd
+}
+
+// IssueDraft is the issue 'issue create' would open
+type IssueDraft struct {
+	Repo   string   `json:"repo,omitempty"`
+	Title  string   `json:"title"`
+	Body   string   `json:"body"`
+	Labels []string `json:"labels"`
+	Model  string   `json:"model"`
+	URL    string   `json:"url,omitempty"`
+}
+
+func issueCreateCmd() *cobra.Command {
+	var fromAnalysis bool
+	var lang string
+	var model string
+	var repo string
+	var extraLabels []string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "create [path]",
+		Short: "Open an issue written by a local LLM from static analysis",
+		Long: `Runs the same static analysis as 'clood bcbc' (build, vet or lint, TODO
+scan) on path, has a local model write it up as a structured bug report,
+and opens it through the GitHub API using GITHUB_TOKEN.
+
+Labels are suggested from the findings: bug for build and vet failures,
+enhancement for TODOs. GitHub issues have no draft state, so use --dry-run
+to review the title, body and labels before anything is created.
+
+Examples:
+  clood issue create --from-analysis --dry-run
+  clood issue create --from-analysis ./services/api --label triage
+  clood issue create --from-analysis --repo me/project -m llama3.1:8b`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !fromAnalysis {
+				return fmt.Errorf("nothing to file from: use --from-analysis")
+			}
+
+			path := "."
+			if len(args) > 0 {
+				path = args[0]
+			}
+			absPath, err := filepath.Abs(path)
+			if err != nil {
+				return err
+			}
+
+			parsed, err := analyze.ParseLanguage(lang)
+			if err != nil {
+				return err
+			}
+
+			if !output.IsJSON() {
+				fmt.Fprintf(os.Stderr, "%s %s\n", tui.MutedStyle.Render("Analyzing:"), absPath)
+			}
+			analysis, err := analyze.RunAnalysisLang(absPath, parsed, false)
+			if err != nil {
+				return fmt.Errorf("analysis: %w", err)
+			}
+			if !analysis.HasFindings() {
+				if output.IsJSON() {
+					return output.JSON(map[string]interface{}{"created": false, "reason": "no findings"})
+				}
+				fmt.Println(tui.SuccessStyle.Render("✓ Nothing to report: " + analysis.FormatSummary()))
+				return nil
+			}
+
+			if !dryRun && os.Getenv("GITHUB_TOKEN") == "" {
+				return fmt.Errorf("GITHUB_TOKEN is not set (use --dry-run to preview)")
+			}
+
+			draft, err := draftIssueFromAnalysis(analysis, model)
+			if err != nil {
+				return err
+			}
+			draft.Labels = mergeLabels(analysis.SuggestedLabels(), extraLabels)
+
+			if !dryRun {
+				if repo == "" {
+					if repo, err = detectGitRepo(); err != nil {
+						return fmt.Errorf("detecting repository (or pass --repo): %w", err)
+					}
+				}
+				draft.Repo = repo
+				if draft.URL, err = createGitHubIssue(repo, draft); err != nil {
+					return fmt.Errorf("creating issue: %w", err)
+				}
+			}
+
+			if output.IsJSON() {
+				return output.JSON(draft)
+			}
+
+			if dryRun {
+				fmt.Println(tui.RenderHeader("Issue (dry run, not created)"))
+			} else {
+				fmt.Println(tui.RenderHeader("Issue created"))
+			}
+			fmt.Println()
+			fmt.Printf("  %s %s\n", tui.MutedStyle.Render("Title:"), draft.Title)
+			fmt.Printf("  %s %s\n", tui.MutedStyle.Render("Labels:"), strings.Join(draft.Labels, ", "))
+			fmt.Printf("  %s %s\n", tui.MutedStyle.Render("Model:"), draft.Model)
+			fmt.Println()
+			for _, line := range strings.Split(draft.Body, "\n") {
+				fmt.Println("  " + line)
+			}
+			fmt.Println()
+			if draft.URL != "" {
+				fmt.Println(tui.SuccessStyle.Render("✓ " + draft.URL))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&fromAnalysis, "from-analysis", false, "Write the issue from build, vet and TODO analysis")
+	cmd.Flags().StringVar(&lang, "lang", "auto", "Project language: go, python, auto")
+	cmd.Flags().StringVarP(&model, "model", "m", "", "Override model (default: writing tier)")
+	cmd.Flags().StringVar(&repo, "repo", "", "Repository as owner/name (default: from gh)")
+	cmd.Flags().StringSliceVarP(&extraLabels, "label", "l", nil, "Additional labels")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the issue without creating it")
+
+	return cmd
+}
+
+// draftIssueFromAnalysis asks the writing tier to turn the analysis into a
+// titled issue body
+func draftIssueFromAnalysis(analysis *analyze.CodebaseAnalysis, model string) (*IssueDraft, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	r := router.NewRouter(cfg)
+	route, err := r.Route("write issue", router.TierWriting, model)
+	if err != nil {
+		return nil, fmt.Errorf("routing: %w", err)
+	}
+	if route.Client == nil {
+		return nil, fmt.Errorf("no available host with model %s", route.Model)
+	}
+
+	findings := analysis.FormatForClaude()
+	if len(findings) > 12000 {
+		findings = findings[:12000] + "\n... (truncated)"
+	}
+	prompt := "Static analysis results:\n\n" + findings + "\n\n" + issueReportTemplate
+
+	if !output.IsJSON() {
+		fmt.Fprintf(os.Stderr, "%s %s on %s\n", tui.MutedStyle.Render("Writing issue:"), route.Model, route.Host.Host.Name)
+	}
+	resp, err := route.Client.GenerateWithSystem(route.Model, issueReportSystemPrompt, prompt)
+	if err != nil {
+		return nil, fmt.Errorf("generating issue: %w", err)
+	}
+
+	title, body := parseIssueReport(resp.Response)
+	if title == "" {
+		title = fallbackIssueTitle(analysis)
+	}
+	body += "\n\n---\n_Generated by `clood issue create --from-analysis` with " + route.Model + "._\n"
+
+	return &IssueDraft{Title: title, Body: body, Model: route.Model}, nil
+}
+
+// parseIssueReport splits a "TITLE: ... BODY: ..." response. Without the
+// markers the whole response is the body.
+func parseIssueReport(text string) (title, body string) {
+	text = strings.TrimSpace(text)
+	rest := text
+	if i := strings.Index(text, "TITLE:"); i >= 0 {
+		line, after, _ := strings.Cut(text[i+len("TITLE:"):], "\n")
+		title = strings.Trim(strings.TrimSpace(line), `"*`)
+		rest = after
+	}
+	if i := strings.Index(rest, "BODY:"); i >= 0 {
+		rest = rest[i+len("BODY:"):]
+	}
+	return title, strings.TrimSpace(rest)
+}
+
+func fallbackIssueTitle(a *analyze.CodebaseAnalysis) string {
+	var parts []string
+	if !a.BuildStatus.Success {
+		parts = append(parts, "build failing")
+	}
+	if n := len(a.GoVet.Issues); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d vet warnings", n))
+	}
+	if a.TestStatus.Failed > 0 {
+		parts = append(parts, fmt.Sprintf("%d failing tests", a.TestStatus.Failed))
+	}
+	if n := len(a.TodoItems); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d TODOs", n))
+	}
+	return "Static analysis: " + strings.Join(parts, ", ")
+}
+
+func mergeLabels(suggested, extra []string) []string {
+	labels := []string{}
+	seen := map[string]bool{}
+	for _, l := range append(suggested, extra...) {
+		l = strings.TrimSpace(l)
+		if l != "" && !seen[l] {
+			seen[l] = true
+			labels = append(labels, l)
+		}
+	}
+	return labels
+}
+
+// createGitHubIssue opens the issue and returns its URL
+func createGitHubIssue(repo string, draft *IssueDraft) (string, error) {
+	token := os.Getenv("GITHUB_TOKEN")
+	if token == "" {
+		return "", fmt.Errorf("GITHUB_TOKEN is not set")
+	}
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok || owner == "" || name == "" {
+		return "", fmt.Errorf("invalid repo %q (expected owner/repo)", repo)
+	}
+
+	client := git.GitHubClient(token)
+	issue, _, err := client.Issues.Create(context.Background(), owner, name, &github.IssueRequest{
+		Title:  github.String(draft.Title),
+		Body:   github.String(draft.Body),
+		Labels: &draft.Labels,
+	})
+	if err != nil {
+		return "", err
+	}
+	return issue.GetHTMLURL(), nil
+}
@@ -6,6 +6,7 @@ import (
 	"os/exec"
 	"strings"
 
+	"github.com/dirtybirdnj/clood/internal/output"
 	"github.com/dirtybirdnj/clood/internal/tui"
 	"github.com/spf13/cobra"
 )
@@ -45,7 +46,6 @@ type IssuesOutput struct {
 }
 
 func IssuesCmd() *cobra.Command {
-	var jsonOutput bool
 	var readyOnly bool
 	var buildableOnly bool
 	var bugsOnly bool
@@ -70,6 +70,7 @@ Examples:
   clood issues --buildable  # Only ready to build
   clood issues --bugs       # Only bugs`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput := output.IsJSON()
 			// Check if gh CLI is available
 			if _, err := exec.LookPath("gh"); err != nil {
 				return fmt.Errorf("gh CLI not found - install from https://cli.github.com")
@@ -106,7 +107,6 @@ Examples:
 		},
 	}
 
-	cmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Output as JSON for agents")
 	cmd.Flags().BoolVar(&readyOnly, "ready", false, "Show only ready to close")
 	cmd.Flags().BoolVar(&buildableOnly, "buildable", false, "Show only ready to build")
 	cmd.Flags().BoolVar(&bugsOnly, "bugs", false, "Show only bugs")
@@ -209,7 +209,7 @@ func categorizeIssues(issues []GitHubIssue) IssueCategory {
 }
 
 func outputJSON(categories IssueCategory) error {
-	output := IssuesOutput{
+	result := IssuesOutput{
 		Categories: categories,
 		Summary: IssueSummary{
 			Total:      len(categories.ReadyToClose) + len(categories.ReadyToBuild) + len(categories.NotStarted) + len(categories.Bugs) + len(categories.InProgress),
@@ -221,13 +221,7 @@ func outputJSON(categories IssueCategory) error {
 		},
 	}
 
-	data, err := json.MarshalIndent(output, "", "  ")
-	if err != nil {
-		return err
-	}
-
-	fmt.Println(string(data))
-	return nil
+	return output.JSON(result)
 }
 
 func outputTUI(categories IssueCategory) error {
@@ -54,6 +54,7 @@ func McpCmd() *cobra.Command {
 	var port int
 	var quiet bool
 	var copyConfig bool
+	var transport string
 
 	cmd := &cobra.Command{
 		Use:   "mcp",
@@ -63,6 +64,13 @@ func McpCmd() *cobra.Command {
 This is the easiest way to make clood tools available to Claude Code.
 Just run it and clood tools become available to AI agents.
 
+Transports:
+  stdio (default) - JSON-RPC over stdin/stdout. The client launches
+                    clood itself, as Claude Desktop does.
+  sse             - HTTP server on --port. Setting --port picks this
+                    transport unless --transport says otherwise.
+                    'clood serve --sse' has more network options.
+
 Tools exposed:
   LOCAL (0 network, 0 LLM tokens):
     clood_grep      - Search codebase with regex
@@ -78,17 +86,41 @@ Tools exposed:
     clood_hosts     - Check Ollama hosts
     clood_models    - List available models
     clood_health    - System health check
+    clood_refresh_tools - Re-check hosts for the clood_ask model list
+
+With --dry-run, tools that change things (clood_memory_forget,
+clood_run_command, clood_clipboard_write) report what they would have
+done instead. Each of them also takes a dry_run argument per call.
+
+mcp.rate_limits in config caps calls per tool, e.g. clood_ask: 10/minute.
+Each client session gets its own allowance; a call over it returns a
+rate_limited error with retry_after in seconds.
 
 Examples:
-  clood mcp           # Start on default port 8765
-  clood mcp -p 9000   # Custom port
-  clood mcp -q        # Quiet mode (less output)
-  clood mcp --copy    # Copy .mcp.json config to clipboard`,
+  clood mcp                    # stdio, for Claude Desktop and other launchers
+  clood mcp --copy             # Copy the stdio client config to clipboard
+  clood mcp --transport sse    # SSE on default port 8765
+  clood mcp -p 9000            # SSE on a custom port
+  clood mcp --transport sse -q # Quiet mode (less output)
+  clood mcp --dry-run          # Tools that change things only describe it`,
 		Run: func(cmd *cobra.Command, args []string) {
+			if !cmd.Flags().Changed("transport") && cmd.Flags().Changed("port") {
+				transport = "sse"
+			}
+			if transport != "stdio" && transport != "sse" {
+				fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render("Error: unknown transport "+transport+" (want stdio or sse)"))
+				return
+			}
+
 			// Create MCP server with all tools
 			mcpSrv, err := cloodmcp.NewServer()
 			if err != nil {
-				fmt.Println(tui.ErrorStyle.Render("Error: " + err.Error()))
+				fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render("Error: "+err.Error()))
+				return
+			}
+
+			if transport == "stdio" {
+				serveMCPStdio(mcpSrv, copyConfig)
 				return
 			}
 
@@ -156,9 +188,39 @@ Examples:
 		},
 	}
 
-	cmd.Flags().IntVarP(&port, "port", "p", 8765, "Port")
-	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Minimal output")
+	cmd.Flags().StringVarP(&transport, "transport", "t", "stdio", "Transport: stdio or sse")
+	cmd.Flags().IntVarP(&port, "port", "p", 8765, "Port (sse)")
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Minimal output (sse)")
 	cmd.Flags().BoolVarP(&copyConfig, "copy", "c", false, "Copy .mcp.json config to clipboard")
 
-	return cmd
+	return withDryRun(cmd)
+}
+
+// serveMCPStdio runs the server over stdin/stdout until the client closes
+// the pipe. Stdout carries the protocol, so everything else goes to stderr.
+func serveMCPStdio(mcpSrv *cloodmcp.Server, copyConfig bool) {
+	if copyConfig {
+		command, err := os.Executable()
+		if err != nil {
+			command = "clood"
+		}
+		mcpJSON := fmt.Sprintf(`{
+  "mcpServers": {
+    "clood": {"command": %q, "args": ["mcp"]}
+  }
+}`, command)
+		if copyToClipboard(mcpJSON) {
+			fmt.Fprintf(os.Stderr, "%s MCP client config copied to clipboard\n", tui.SuccessStyle.Render("✓"))
+		} else {
+			fmt.Fprintln(os.Stderr, tui.MutedStyle.Render("Clipboard not available. Here's the config:"))
+			fmt.Fprintln(os.Stderr)
+			fmt.Fprintln(os.Stderr, mcpJSON)
+		}
+		return
+	}
+
+	if err := server.ServeStdio(mcpSrv.MCPServer()); err != nil {
+		fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render("Error: "+err.Error()))
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,584 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dirtybirdnj/clood/internal/config"
+	"github.com/dirtybirdnj/clood/internal/memory"
+	"github.com/dirtybirdnj/clood/internal/output"
+	"github.com/dirtybirdnj/clood/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// memoryShared is the memory --shared flag: a database path, or
+// sharedFromConfig when given bare
+var memoryShared string
+
+// sharedFromConfig is what a bare --shared holds: use memory.shared_db
+const sharedFromConfig = "memory.shared_db"
+
+func MemoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "memory",
+		Short: "Store and recall persistent notes",
+		Long: `Persistent knowledge shared with the clood_memory_* MCP tools.
+
+Memories live in ~/.clood/memory.db (SQLite, with a full-text index for
+recall). A memory.json from an older clood is imported on first use and
+kept as memory.json.migrated. Scope memories with --project so recall in
+one workspace isn't cluttered with facts from another.
+
+--shared points any memory command at a team database instead, such as
+one on a network mount: --shared=/Volumes/shared/team.db, or a bare
+--shared for the memory.shared_db path in config. 'recall --all' and
+'list --all' search your local memories and the shared ones together.
+
+A shared database uses SQLite's rollback journal, not WAL: WAL keeps its
+index in shared memory, which only works for processes on one machine.
+SQLite on a network filesystem is still only as safe as the share's file
+locking, so use a mount with working locks and keep to the occasional
+store rather than bulk imports from several machines at once.
+
+Examples:
+  clood memory store --project clood "sd workflows live in internal/sd"
+  clood memory recall --project clood workflow
+  clood memory list
+  clood memory forget 1a2b3c4d
+  clood memory forget --tag project-x
+  clood memory import notes.md --tag obsidian
+  clood memory store --shared "we deploy from main on fridays"
+  clood memory recall --shared deploy
+  clood memory recall --all deploy`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listMemories("", "", 20, false)
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&memoryShared, "shared", "", "Use a shared team database (bare: memory.shared_db from config)")
+	cmd.PersistentFlags().Lookup("shared").NoOptDefVal = sharedFromConfig
+
+	cmd.AddCommand(memoryStoreCmd())
+	cmd.AddCommand(memoryRecallCmd())
+	cmd.AddCommand(memoryListCmd())
+	cmd.AddCommand(memoryForgetCmd())
+	cmd.AddCommand(memoryImportCmd())
+
+	return cmd
+}
+
+func memoryStoreCmd() *cobra.Command {
+	var tags []string
+	var context, project string
+
+	cmd := &cobra.Command{
+		Use:   "store <content>",
+		Short: "Remember a fact or note",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := openMemoryStore()
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			mem, err := store.Store(strings.Join(args, " "), tags, context, project)
+			if err != nil {
+				return err
+			}
+
+			if output.IsJSON() {
+				return output.JSON(mem)
+			}
+
+			fmt.Println(tui.SuccessStyle.Render("✓ Stored memory " + mem.ID))
+			printMemory(*mem)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&tags, "tags", "t", nil, "Comma-separated tags")
+	cmd.Flags().StringVar(&context, "context", "", "Where this came from (file, issue, ...)")
+	cmd.Flags().StringVarP(&project, "project", "p", "", "Project scope (default: global)")
+
+	return cmd
+}
+
+func memoryRecallCmd() *cobra.Command {
+	var tag, project string
+	var limit int
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "recall [query]",
+		Short: "Search memories by keyword",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			stores, err := openMemoryStores(all)
+			if err != nil {
+				return err
+			}
+			defer closeMemoryStores(stores)
+
+			var lists [][]memory.Memory
+			for _, store := range stores {
+				lists = append(lists, store.Recall(strings.Join(args, " "), tag, project, limit))
+			}
+			results := memory.Merge(limit, lists...)
+			if output.IsJSON() {
+				return output.JSON(results)
+			}
+
+			if len(results) == 0 {
+				fmt.Println(tui.MutedStyle.Render("No matching memories"))
+				return nil
+			}
+			for _, mem := range results {
+				printMemory(mem)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&tag, "tag", "", "Only memories with this tag")
+	cmd.Flags().StringVarP(&project, "project", "p", "", "Only memories scoped to this project")
+	cmd.Flags().IntVarP(&limit, "limit", "n", 10, "Max results")
+	cmd.Flags().BoolVar(&all, "all", false, "Search local and shared memories together")
+
+	return cmd
+}
+
+func memoryListCmd() *cobra.Command {
+	var tag, project string
+	var limit int
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List memories, newest first",
+		Long: `List memories, newest first.
+
+Without --project every memory is shown along with its project scope.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listMemories(tag, project, limit, all)
+		},
+	}
+
+	cmd.Flags().StringVar(&tag, "tag", "", "Only memories with this tag")
+	cmd.Flags().StringVarP(&project, "project", "p", "", "Only memories scoped to this project")
+	cmd.Flags().IntVarP(&limit, "limit", "n", 20, "Max results (0 for all)")
+	cmd.Flags().BoolVar(&all, "all", false, "List local and shared memories together")
+
+	return cmd
+}
+
+func memoryForgetCmd() *cobra.Command {
+	var tag, query string
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "forget [id]",
+		Short: "Delete a memory by ID, or in bulk by tag or query",
+		Long: `Deletes one memory by ID, every memory with a tag (--tag), or every
+memory matching a keyword search (--query). --query lists the matches
+and asks before deleting; --yes skips the question. With the global
+--dry-run it only lists what would be deleted.
+
+Examples:
+  clood memory forget 1a2b3c4d
+  clood memory forget --tag project-x
+  clood memory forget --query "old project"`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			modes := 0
+			for _, set := range []bool{len(args) == 1, tag != "", query != ""} {
+				if set {
+					modes++
+				}
+			}
+			if modes != 1 {
+				return fmt.Errorf("give exactly one of an ID, --tag or --query")
+			}
+
+			store, err := openMemoryStore()
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			if output.DryRun {
+				return dryRunForget(store, args, tag, query)
+			}
+
+			switch {
+			case tag != "":
+				n, err := store.ForgetByTag(tag)
+				if err != nil {
+					return err
+				}
+				if output.IsJSON() {
+					return output.JSON(map[string]interface{}{
+						"status":    "forgotten",
+						"tag":       tag,
+						"count":     n,
+						"remaining": store.Count(),
+					})
+				}
+				fmt.Println(tui.SuccessStyle.Render(fmt.Sprintf("✓ Forgot %d memories tagged %s", n, tag)))
+				return nil
+
+			case query != "":
+				return forgetByQuery(store, query, yes)
+			}
+
+			if err := store.Forget(args[0]); err != nil {
+				return err
+			}
+
+			if output.IsJSON() {
+				return output.JSON(map[string]interface{}{
+					"status":    "forgotten",
+					"id":        args[0],
+					"remaining": store.Count(),
+				})
+			}
+			fmt.Println(tui.SuccessStyle.Render("✓ Forgot memory " + args[0]))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&tag, "tag", "", "Delete every memory with this tag")
+	cmd.Flags().StringVar(&query, "query", "", "Delete every memory matching this keyword search")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Don't ask before deleting --query matches")
+
+	return withDryRun(cmd)
+}
+
+// dryRunForget describes what forget would delete without deleting it
+func dryRunForget(store *memory.Store, args []string, tag, query string) error {
+	var matches []memory.Memory
+	params := map[string]interface{}{}
+	switch {
+	case len(args) == 1:
+		for _, mem := range store.List("", "", 0) {
+			if mem.ID == args[0] {
+				matches = append(matches, mem)
+			}
+		}
+		if len(matches) == 0 {
+			return fmt.Errorf("memory not found: %s", args[0])
+		}
+		params["id"] = args[0]
+	case tag != "":
+		matches = store.List(tag, "", 0)
+		params["tag"] = tag
+	default:
+		matches = store.Recall(query, "", "", 0)
+		params["query"] = query
+	}
+	ids := make([]string, len(matches))
+	for i, mem := range matches {
+		ids[i] = mem.ID
+	}
+	params["count"] = len(matches)
+	params["remaining"] = store.Count() - len(matches)
+	params["ids"] = strings.Join(ids, ", ")
+	if output.IsJSON() {
+		params["ids"] = ids
+	}
+	return output.WouldHaveDone(fmt.Sprintf("forget %d memories", len(matches)), params)
+}
+
+// forgetByQuery shows what query matches and deletes it once confirmed
+func forgetByQuery(store *memory.Store, query string, yes bool) error {
+	matches := store.Recall(query, "", "", 0)
+	if len(matches) == 0 {
+		if output.IsJSON() {
+			return output.JSON(map[string]interface{}{"status": "none", "query": query, "count": 0})
+		}
+		fmt.Println(tui.MutedStyle.Render("No memories match " + query))
+		return nil
+	}
+
+	if !yes {
+		if output.IsJSON() {
+			return fmt.Errorf("--query with --json needs --yes; there's no prompt to confirm at")
+		}
+		fmt.Printf("%d memories match %q:\n\n", len(matches), query)
+		for _, mem := range matches {
+			printMemory(mem)
+		}
+		fmt.Print(tui.WarningStyle.Render(fmt.Sprintf("Delete all %d? [y/N] ", len(matches))))
+		response, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			fmt.Println(tui.MutedStyle.Render("Nothing deleted."))
+			return nil
+		}
+	}
+
+	removed, err := store.ForgetByQuery(query)
+	if err != nil {
+		return err
+	}
+	if output.IsJSON() {
+		ids := make([]string, len(removed))
+		for i, mem := range removed {
+			ids[i] = mem.ID
+		}
+		return output.JSON(map[string]interface{}{
+			"status":    "forgotten",
+			"query":     query,
+			"count":     len(removed),
+			"ids":       ids,
+			"remaining": store.Count(),
+		})
+	}
+	fmt.Println(tui.SuccessStyle.Render(fmt.Sprintf("✓ Forgot %d memories matching %q", len(removed), query)))
+	return nil
+}
+
+func memoryImportCmd() *cobra.Command {
+	var tags []string
+	var project string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "import <notes.md>",
+		Short: "Import the sections of a Markdown notes file as memories",
+		Long: `Imports each "## Heading" section of a Markdown file (e.g. an Obsidian
+note) as a memory titled by the heading. Memories are tagged with --tag
+plus any #hashtags in the section.
+
+Re-importing is safe: a memory with the same title in the same project is
+updated rather than duplicated.
+
+Examples:
+  clood memory import notes.md --tag obsidian
+  clood memory import notes.md --project clood --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			sections := memory.ParseMarkdownSections(string(data))
+			if len(sections) == 0 {
+				return fmt.Errorf("no \"## Heading\" sections found in %s", args[0])
+			}
+
+			store, err := openMemoryStore()
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+			results, err := store.Import(sections, tags, filepath.Base(args[0]), project, dryRun)
+			if err != nil {
+				return err
+			}
+
+			counts := map[string]int{}
+			for _, r := range results {
+				counts[r.Action]++
+			}
+
+			if output.IsJSON() {
+				return output.JSON(map[string]interface{}{
+					"file":      args[0],
+					"dry_run":   dryRun,
+					"created":   counts["created"],
+					"updated":   counts["updated"],
+					"unchanged": counts["unchanged"],
+					"results":   results,
+				})
+			}
+
+			header := "Import " + args[0]
+			if dryRun {
+				header += " (dry run)"
+			}
+			fmt.Println(tui.RenderHeader(header))
+			fmt.Println()
+			for _, r := range results {
+				marker := tui.SuccessStyle.Render("+")
+				switch r.Action {
+				case "updated":
+					marker = tui.WarningStyle.Render("~")
+				case "unchanged":
+					marker = tui.MutedStyle.Render("=")
+				}
+				id := r.Memory.ID
+				if id == "" {
+					id = "(new)   "
+				}
+				line := fmt.Sprintf("  %s %s %s", marker, tui.MutedStyle.Render(id), r.Memory.Title)
+				if len(r.Memory.Tags) > 0 {
+					line += " " + tui.MutedStyle.Render("#"+strings.Join(r.Memory.Tags, " #"))
+				}
+				fmt.Println(line)
+			}
+			fmt.Println()
+
+			verb := "Imported"
+			if dryRun {
+				verb = "Would import"
+			}
+			fmt.Println(tui.MutedStyle.Render(fmt.Sprintf("  %s: %d created, %d updated, %d unchanged",
+				verb, counts["created"], counts["updated"], counts["unchanged"])))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&tags, "tag", "t", nil, "Tags to add to every imported memory")
+	cmd.Flags().StringVarP(&project, "project", "p", "", "Project scope (default: global)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be imported without saving")
+
+	return cmd
+}
+
+func listMemories(tag, project string, limit int, all bool) error {
+	stores, err := openMemoryStores(all)
+	if err != nil {
+		return err
+	}
+	defer closeMemoryStores(stores)
+
+	var lists [][]memory.Memory
+	var projects []string
+	total := 0
+	for _, store := range stores {
+		lists = append(lists, store.List(tag, project, limit))
+		projects = append(projects, store.Projects()...)
+		total += store.Count()
+	}
+	memories := memory.Merge(limit, lists...)
+	if output.IsJSON() {
+		return output.JSON(map[string]interface{}{
+			"memories": memories,
+			"count":    len(memories),
+			"total":    total,
+			"projects": uniqueSorted(projects),
+		})
+	}
+
+	fmt.Println(tui.RenderHeader("Memories"))
+	fmt.Println()
+	if len(memories) == 0 {
+		fmt.Println(tui.MutedStyle.Render("  No memories yet (try: clood memory store \"...\")"))
+		return nil
+	}
+	for _, mem := range memories {
+		printMemory(mem)
+	}
+	fmt.Println(tui.MutedStyle.Render(fmt.Sprintf("  %d of %d shown", len(memories), total)))
+	return nil
+}
+
+// openMemoryStore opens the store memory commands work on: the shared
+// database with --shared, else ~/.clood/memory.db
+func openMemoryStore() (*memory.Store, error) {
+	if memoryShared == "" {
+		return memory.NewStore()
+	}
+	path, err := sharedMemoryPath()
+	if err != nil {
+		return nil, err
+	}
+	return memory.OpenShared(path)
+}
+
+// openMemoryStores is openMemoryStore, or with all the local store and
+// the shared one. Under --all a shared store that won't open is only a
+// warning, so recall keeps working while the share is unmounted.
+func openMemoryStores(all bool) ([]*memory.Store, error) {
+	if !all {
+		store, err := openMemoryStore()
+		if err != nil {
+			return nil, err
+		}
+		return []*memory.Store{store}, nil
+	}
+
+	local, err := memory.NewStore()
+	if err != nil {
+		return nil, err
+	}
+	stores := []*memory.Store{local}
+	path, err := sharedMemoryPath()
+	if err == nil {
+		var shared *memory.Store
+		if shared, err = memory.OpenShared(path); err == nil {
+			stores = append(stores, shared)
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, tui.WarningStyle.Render("Local memories only: "+err.Error()))
+	}
+	return stores, nil
+}
+
+func closeMemoryStores(stores []*memory.Store) {
+	for _, store := range stores {
+		store.Close()
+	}
+}
+
+// sharedMemoryPath is the --shared path, or memory.shared_db from config
+// for a bare --shared or an --all without one
+func sharedMemoryPath() (string, error) {
+	if memoryShared != "" && memoryShared != sharedFromConfig {
+		return memoryShared, nil
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return "", err
+	}
+	if cfg.Memory.SharedDB == "" {
+		return "", fmt.Errorf("no shared memory database: use --shared=PATH or set memory.shared_db in config")
+	}
+	return cfg.Memory.SharedDB, nil
+}
+
+// uniqueSorted sorts values and drops repeats
+func uniqueSorted(values []string) []string {
+	sort.Strings(values)
+	var unique []string
+	for i, v := range values {
+		if i == 0 || v != values[i-1] {
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
+
+func printMemory(mem memory.Memory) {
+	scope := "global"
+	if mem.Project != "" {
+		scope = mem.Project
+	}
+	if mem.Shared {
+		scope = "shared:" + scope
+	}
+	content := mem.Content
+	if mem.Title != "" {
+		content = tui.HeaderStyle.Render(mem.Title) + "\n           " + strings.ReplaceAll(content, "\n", "\n           ")
+	}
+	fmt.Printf("  %s %s %s\n",
+		tui.MutedStyle.Render(mem.ID),
+		tui.WarningStyle.Render("["+scope+"]"),
+		content)
+
+	var meta []string
+	if len(mem.Tags) > 0 {
+		meta = append(meta, "#"+strings.Join(mem.Tags, " #"))
+	}
+	if mem.Context != "" {
+		meta = append(meta, mem.Context)
+	}
+	meta = append(meta, mem.CreatedAt.Format("2006-01-02 15:04"))
+	fmt.Println(tui.MutedStyle.Render("           " + strings.Join(meta, " · ")))
+}
@@ -1,9 +1,11 @@
 package commands
 
 import (
-	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"sort"
+	"strings"
 
 	"github.com/dirtybirdnj/clood/internal/config"
 	"github.com/dirtybirdnj/clood/internal/hosts"
@@ -11,10 +13,10 @@ import (
 	"github.com/dirtybirdnj/clood/internal/system"
 	"github.com/dirtybirdnj/clood/internal/tui"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 func ModelsCmd() *cobra.Command {
-	var jsonOutput bool
 	var hostFilter string
 	var showStorage bool
 
@@ -22,7 +24,14 @@ func ModelsCmd() *cobra.Command {
 		Use:   "models",
 		Short: "List available models across all hosts",
 		Long: `Lists all models available across all configured Ollama hosts.
-Shows which hosts have each model and model details.`,
+Shows which hosts have each model and model details.
+
+With --json the output is an object keyed by model name, each entry
+holding size, size_human, parameter_size, vram_mb_estimate and hosts.
+Older versions printed just the host list per model; scripts that relied
+on that should read .hosts now:
+
+  clood models --json | jq 'map_values(.hosts)'`,
 		Run: func(cmd *cobra.Command, args []string) {
 			cfg, err := config.Load()
 			if err != nil {
@@ -33,8 +42,7 @@ Shows which hosts have each model and model details.`,
 			mgr := hosts.NewManager()
 			mgr.AddHosts(cfg.Hosts)
 
-			// Check both local --json and global -j flag
-			useJSON := jsonOutput || output.IsJSON()
+			useJSON := output.IsJSON()
 
 			// Only show progress in human mode
 			if !useJSON {
@@ -59,7 +67,11 @@ Shows which hosts have each model and model details.`,
 			}
 
 			// Show all models across all hosts
-			allModels := mgr.GetAllModels()
+			details := mgr.GetModels()
+			allModels := make(map[string][]string, len(details))
+			for name, m := range details {
+				allModels[name] = m.Hosts
+			}
 
 			// If --storage --json, just output storage report
 			if showStorage && useJSON {
@@ -68,7 +80,7 @@ Shows which hosts have each model and model details.`,
 			}
 
 			if useJSON {
-				data, _ := json.MarshalIndent(allModels, "", "  ")
+				data, _ := output.Marshal(details)
 				fmt.Println(string(data))
 				return
 			}
@@ -93,16 +105,21 @@ Shows which hosts have each model and model details.`,
 
 			for family, models := range families {
 				fmt.Printf("  %s\n", tui.HeaderStyle.Render(family))
+				width := 0
+				for _, model := range models {
+					width = max(width, len(model))
+				}
 				for _, model := range models {
-					hosts := allModels[model]
-					hostStr := ""
-					for i, h := range hosts {
-						if i > 0 {
-							hostStr += ", "
-						}
-						hostStr += h
+					m := details[model]
+					size, params := m.SizeHuman, m.ParameterSize
+					if size == "" {
+						size = "-"
 					}
-					fmt.Printf("    %s %s\n", model, tui.MutedStyle.Render("("+hostStr+")"))
+					if params == "" {
+						params = "-"
+					}
+					fmt.Printf("    %-*s %9s %7s %s\n", width, model, size, params,
+						tui.MutedStyle.Render("("+strings.Join(m.Hosts, ", ")+")"))
 				}
 				fmt.Println()
 			}
@@ -126,13 +143,193 @@ Shows which hosts have each model and model details.`,
 		},
 	}
 
-	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 	cmd.Flags().StringVarP(&hostFilter, "host", "H", "", "Show models for specific host")
 	cmd.Flags().BoolVar(&showStorage, "storage", false, "Show storage usage by host")
 
+	cmd.AddCommand(modelsPullCmd())
+	cmd.AddCommand(modelsRecommendCmd())
+
+	return cmd
+}
+
+func modelsPullCmd() *cobra.Command {
+	var hostName string
+	var allHosts bool
+
+	cmd := &cobra.Command{
+		Use:   "pull <model>",
+		Short: "Pull a model to one or all hosts",
+		Long: `Pull (download) a model to an Ollama host, or to every online host
+at once with --all-hosts. Hosts pull concurrently; a failure on one
+host does not stop the others.
+
+Examples:
+  clood models pull qwen2.5-coder:7b               # Pull to best available host
+  clood models pull --host ubuntu25 llama3.1:8b    # Pull to specific host
+  clood models pull --all-hosts qwen2.5-coder:7b   # Keep every host in sync`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return fmt.Errorf("loading config: %w", err)
+			}
+
+			model := args[0]
+			if !allHosts {
+				return pullModel(cfg, model, hostName)
+			}
+			if hostName != "" {
+				return fmt.Errorf("--host and --all-hosts are mutually exclusive")
+			}
+
+			results, err := pullModelAllHosts(cfg, model, output.IsJSON())
+			if err != nil {
+				return err
+			}
+
+			if output.IsJSON() {
+				return output.JSON(map[string]interface{}{
+					"model": model,
+					"hosts": results,
+				})
+			}
+			printPullSummary(model, results)
+
+			for _, r := range results {
+				if r.Status == "failed" {
+					return fmt.Errorf("pull failed on one or more hosts")
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&hostName, "host", "", "Target host (default: best available)")
+	cmd.Flags().BoolVar(&allHosts, "all-hosts", false, "Pull to every online host concurrently")
+
+	return cmd
+}
+
+// capabilitiesPath returns ~/.config/clood/capabilities.yaml
+func capabilitiesPath() string {
+	return filepath.Join(config.ConfigDir(), "capabilities.yaml")
+}
+
+func modelsRecommendCmd() *cobra.Command {
+	var top int
+	var initFile bool
+
+	cmd := &cobra.Command{
+		Use:   "recommend <task>",
+		Short: "Suggest the best available model for a task",
+		Long: `Scores every model on the online hosts against a capability matrix and
+ranks them for the task.
+
+The task is free text and is matched to one of: code, reasoning, math,
+summarization, writing, vision, chat.
+
+Scores (0-10 per task) come from a built-in matrix. Override or add model
+families in ~/.config/clood/capabilities.yaml:
+
+  models:
+    qwen2.5-coder:
+      code: 10
+    my-finetune:
+      summarization: 9
+
+Run with --init to write the built-in matrix there as a starting point.
+
+Examples:
+  clood models recommend "code generation"
+  clood models recommend "document summarization" --top 3
+  clood models recommend --init`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if initFile {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.MinimumNArgs(1)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := capabilitiesPath()
+			if initFile {
+				return writeCapabilityMatrix(path)
+			}
+
+			matrix, err := system.LoadCapabilityMatrix(path)
+			if err != nil {
+				return err
+			}
+
+			cfg, err := config.Load()
+			if err != nil {
+				return fmt.Errorf("loading config: %w", err)
+			}
+			mgr := hosts.NewManager()
+			mgr.AddHosts(cfg.Hosts)
+
+			description := strings.Join(args, " ")
+			task := system.MatchTask(description)
+			recs := matrix.Recommend(mgr.GetAllModels(), task)
+			if top > 0 && len(recs) > top {
+				recs = recs[:top]
+			}
+
+			if output.IsJSON() {
+				return output.JSON(map[string]interface{}{
+					"task":            description,
+					"task_type":       task,
+					"recommendations": recs,
+				})
+			}
+
+			fmt.Println(tui.RenderHeader("Recommended for " + task))
+			fmt.Println()
+			if len(recs) == 0 {
+				fmt.Println(tui.MutedStyle.Render("  No models found on any online host"))
+				return nil
+			}
+			for i, r := range recs {
+				score := tui.MutedStyle.Render(" -- ")
+				if r.Score > 0 {
+					score = tui.SuccessStyle.Render(fmt.Sprintf("%4.1f", r.Score))
+				}
+				fmt.Printf("  %d. %s %s %s\n", i+1, score, r.Model,
+					tui.MutedStyle.Render("("+strings.Join(r.Hosts, ", ")+")"))
+				fmt.Printf("            %s\n", tui.MutedStyle.Render(r.Reason))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVarP(&top, "top", "n", 5, "Number of models to show (0 for all)")
+	cmd.Flags().BoolVar(&initFile, "init", false, "Write the built-in matrix to ~/.config/clood/capabilities.yaml")
+
 	return cmd
 }
 
+// writeCapabilityMatrix saves the built-in matrix for users to edit
+func writeCapabilityMatrix(path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists", path)
+	}
+	data, err := yaml.Marshal(system.DefaultCapabilityMatrix())
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return err
+	}
+
+	if output.IsJSON() {
+		return output.JSON(map[string]interface{}{"status": "written", "path": path})
+	}
+	fmt.Println(tui.SuccessStyle.Render("✓ Wrote capability matrix to " + path))
+	return nil
+}
+
 // ModelInfo holds enriched model information for display
 type ModelInfo struct {
 	Name              string   `json:"name"`
@@ -180,13 +377,13 @@ func printHostModels(status *hosts.HostStatus, jsonOutput bool) {
 			}
 			models = append(models, info)
 		}
-		output := map[string]interface{}{
+		out := map[string]interface{}{
 			"host":          status.Host.Name,
 			"model_count":   len(models),
 			"total_size_gb": totalGB,
 			"models":        models,
 		}
-		data, _ := json.MarshalIndent(output, "", "  ")
+		data, _ := output.Marshal(out)
 		fmt.Println(string(data))
 		return
 	}
@@ -394,7 +591,7 @@ func printStorageReport(mgr *hosts.Manager, jsonOutput bool) {
 	report.TotalGB = float64(totalBytes) / (1024 * 1024 * 1024)
 
 	if jsonOutput {
-		data, _ := json.MarshalIndent(report, "", "  ")
+		data, _ := output.Marshal(report)
 		fmt.Println(string(data))
 		return
 	}
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -196,7 +197,7 @@ func runAgentForMap(agentName, task string, files []string, cfg *config.Config,
 
 	// Execute
 	client := mgr.GetClient(hostName)
-	resp, err := client.GenerateWithSystem(model, agent.System, prompt)
+	resp, err := client.GenerateWithSystemOptions(context.Background(), model, agent.System, prompt, agent.Options())
 	if err != nil {
 		result.Error = err.Error()
 		result.DurationMs = time.Since(start).Milliseconds()
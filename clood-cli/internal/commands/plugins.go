@@ -0,0 +1,188 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dirtybirdnj/clood/internal/output"
+	"github.com/dirtybirdnj/clood/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// pluginPrefix is the executable name prefix that makes a plugin, the same
+// convention git uses: clood-foo on $PATH becomes `clood foo`
+const pluginPrefix = "clood-"
+
+// FindPlugins returns plugin name -> executable path for every clood-*
+// executable on $PATH. When a name appears in several directories the
+// first one on $PATH wins.
+func FindPlugins() map[string]string {
+	plugins := map[string]string{}
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			name, ok := pluginName(e.Name())
+			if !ok || plugins[name] != "" {
+				continue
+			}
+			path := filepath.Join(dir, e.Name())
+			if info, err := os.Stat(path); err == nil && isExecutable(info) {
+				plugins[name] = path
+			}
+		}
+	}
+	return plugins
+}
+
+// pluginName strips the prefix (and .exe on Windows) from an executable name
+func pluginName(file string) (string, bool) {
+	if !strings.HasPrefix(file, pluginPrefix) {
+		return "", false
+	}
+	name := strings.TrimPrefix(file, pluginPrefix)
+	if runtime.GOOS == "windows" {
+		if !strings.EqualFold(filepath.Ext(name), ".exe") {
+			return "", false
+		}
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	return name, name != ""
+}
+
+func isExecutable(info os.FileInfo) bool {
+	if info.IsDir() {
+		return false
+	}
+	return runtime.GOOS == "windows" || info.Mode()&0111 != 0
+}
+
+// PluginCmds builds a command for each plugin on $PATH, sorted by name.
+// Names for which skip returns true (built-in commands) are left out.
+func PluginCmds(skip func(name string) bool) []*cobra.Command {
+	plugins := FindPlugins()
+	names := make([]string, 0, len(plugins))
+	for name := range plugins {
+		if !skip(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	cmds := make([]*cobra.Command, 0, len(names))
+	for _, name := range names {
+		cmds = append(cmds, pluginCmd(name, plugins[name]))
+	}
+	return cmds
+}
+
+func pluginCmd(name, path string) *cobra.Command {
+	return &cobra.Command{
+		Use:   name,
+		Short: "Plugin: " + path,
+		Long: fmt.Sprintf(`Runs the plugin %s with the remaining arguments.
+
+Arguments are passed through untouched, except clood's own --json/-j
+and --json-stream, which plugins read from the environment instead:
+
+  CLOOD_ARGS       the arguments as a JSON array
+  CLOOD_JSON       "true" when JSON output was asked for, else "false"
+  CLOOD_JSON_MODE  the --json mode: pretty, ndjson or off
+  CLOOD_BIN        path to the clood executable, for calling back into clood`, path),
+		DisableFlagParsing: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			os.Exit(runPlugin(path, args))
+		},
+	}
+}
+
+// runPlugin execs the plugin and returns its exit code. stdout and stdin
+// are the terminal's; stderr is held back so a failure can be shown as an
+// error, and passed through unchanged on success.
+func runPlugin(path string, args []string) int {
+	jsonMode, pass, err := pluginJSONMode(output.JSONMode, args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render(err.Error()))
+		return 2
+	}
+
+	argsJSON, _ := json.Marshal(append([]string{}, pass...))
+	self, _ := os.Executable()
+
+	var stderr bytes.Buffer
+	c := exec.Command(path, pass...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = &stderr
+	c.Env = append(os.Environ(),
+		"CLOOD_ARGS="+string(argsJSON),
+		"CLOOD_JSON="+strconv.FormatBool(jsonMode != output.Off),
+		"CLOOD_JSON_MODE="+jsonMode.String(),
+		"CLOOD_BIN="+self,
+	)
+
+	err = c.Run()
+	if err == nil {
+		io.Copy(os.Stderr, &stderr)
+		return 0
+	}
+
+	code := 1
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		code = max(exitErr.ExitCode(), 1) // -1 when killed by a signal
+	} else {
+		stderr.WriteString(err.Error())
+	}
+	if msg := strings.TrimRight(stderr.String(), "\n"); msg != "" {
+		fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render(msg))
+	}
+	fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render(fmt.Sprintf("%s exited with status %d", filepath.Base(path), code)))
+	return code
+}
+
+// pluginJSONMode takes clood's JSON flags out of a plugin's arguments,
+// resolving them the way the root command would, starting from mode
+func pluginJSONMode(mode output.Mode, args []string) (output.Mode, []string, error) {
+	var pass []string
+	for _, a := range args {
+		switch {
+		case a == "--json" || a == "-j":
+			mode = output.Pretty
+		case strings.HasPrefix(a, "--json="):
+			if err := mode.Set(strings.TrimPrefix(a, "--json=")); err != nil {
+				return mode, nil, fmt.Errorf("invalid argument %q for --json: %w", a, err)
+			}
+		case a == "--json-stream":
+			mode = output.NDJSON
+		case strings.HasPrefix(a, "--json-stream="):
+			on, err := strconv.ParseBool(strings.TrimPrefix(a, "--json-stream="))
+			if err != nil {
+				return mode, nil, fmt.Errorf("invalid argument %q for --json-stream: %w", a, err)
+			}
+			if on {
+				mode = output.NDJSON
+			} else if mode == output.NDJSON {
+				mode = output.Off
+			}
+		default:
+			pass = append(pass, a)
+		}
+	}
+	return mode, pass, nil
+}
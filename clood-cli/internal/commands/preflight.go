@@ -1,7 +1,6 @@
 package commands
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -14,7 +13,6 @@ import (
 )
 
 func PreflightCmd() *cobra.Command {
-	var jsonOutput bool
 
 	cmd := &cobra.Command{
 		Use:   "preflight",
@@ -39,7 +37,7 @@ This helps avoid unnecessary network requests by showing what can be done locall
 			modelSeen := make(map[string]bool)
 
 			// Check for JSON output - no animation needed
-			isJSON := jsonOutput || output.IsJSON()
+			isJSON := output.IsJSON()
 
 			if cfg != nil {
 				mgr := hosts.NewManager()
@@ -86,8 +84,6 @@ This helps avoid unnecessary network requests by showing what can be done locall
 		},
 	}
 
-	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
-
 	return cmd
 }
 
@@ -131,7 +127,17 @@ func printPreflightJSON(cwd string, hosts []hostInfo, models []string, ollamaOnl
 		result["models_available"] = models
 	}
 
-	data, _ := json.MarshalIndent(result, "", "  ")
+	if fc := loadFocusContext(); fc != nil {
+		result["focus_context"] = map[string]interface{}{
+			"path":       fc.Path,
+			"created_at": fc.CreatedAt,
+			"files":      fc.Files,
+			"symbols":    fc.Symbols,
+			"bytes":      len(fc.Context),
+		}
+	}
+
+	data, _ := output.Marshal(result)
 	fmt.Println(string(data))
 }
 
@@ -239,6 +245,15 @@ func printPreflightHuman(cwd string, hosts []hostInfo, models []string, ollamaOn
 	}
 	fmt.Println()
 
+	// Cached code context from 'clood focus <path>'
+	if fc := loadFocusContext(); fc != nil {
+		fmt.Println(tui.AccentStyle.Render("  FOCUS CONTEXT"))
+		fmt.Printf("    %s (%d files, %d symbols, cached %s)\n",
+			fc.Path, fc.Files, fc.Symbols, fc.CreatedAt.Format("2006-01-02 15:04"))
+		fmt.Println(tui.MutedStyle.Render("    Prepended to 'clood ask' - clear with 'clood focus --clear'"))
+		fmt.Println()
+	}
+
 	// Recommended workflow
 	fmt.Println(tui.AccentStyle.Render("  RECOMMENDED WORKFLOW"))
 	fmt.Println()
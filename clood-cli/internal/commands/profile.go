@@ -0,0 +1,184 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dirtybirdnj/clood/internal/config"
+	"github.com/dirtybirdnj/clood/internal/output"
+	"github.com/dirtybirdnj/clood/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// ProfileInfo describes one config profile for 'clood profile list'
+type ProfileInfo struct {
+	Name   string `json:"name"` // "default" for config.yaml
+	Path   string `json:"path"`
+	Active bool   `json:"active"`
+	Hosts  int    `json:"hosts"`
+	Model  string `json:"deep_model,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+func ProfileCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profile",
+		Short: "List and create config profiles",
+		Long: `Profiles are alternative configs for different machines or setups, e.g.
+32B models on a workstation and 3B models on a laptop. Each is a full
+config.yaml stored in ~/.config/clood/profiles/<name>.yaml.
+
+Pick one for any command with the global --profile flag; without it
+clood uses ~/.config/clood/config.yaml as before.
+
+Examples:
+  clood profile create laptop      # Copy the current config as a start
+  clood profile list
+  clood --profile laptop ask "explain this regex"`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listProfiles()
+		},
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "Show available profiles",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listProfiles()
+		},
+	})
+	cmd.AddCommand(profileCreateCmd())
+
+	return cmd
+}
+
+func profileCreateCmd() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create a profile from the current config",
+		Long: `Copies the config in use (config.yaml, or the --profile one) to
+~/.config/clood/profiles/<name>.yaml for editing. Without a config file
+the defaults are written instead.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if err := config.ValidateProfileName(name); err != nil {
+				return err
+			}
+			if name == "default" {
+				return fmt.Errorf(`"default" is reserved for config.yaml`)
+			}
+
+			dest, err := config.ProfilePath(name)
+			if err != nil {
+				return err
+			}
+			if _, err := os.Stat(dest); err == nil && !force {
+				return fmt.Errorf("profile %q already exists: %s (use --force to overwrite)", name, dest)
+			}
+
+			// Copy the file rather than re-marshalling, to keep its comments
+			src, err := config.ProfilePath(config.ActiveProfile)
+			if err != nil {
+				return err
+			}
+			data, err := os.ReadFile(src)
+			switch {
+			case os.IsNotExist(err) && config.ActiveProfile != "":
+				return fmt.Errorf("profile %q not found: %s", config.ActiveProfile, src)
+			case err != nil && !os.IsNotExist(err):
+				return err
+			case output.DryRun:
+				if err != nil {
+					src = "defaults"
+				}
+				return output.WouldHaveDone("create profile "+name+" at "+dest, map[string]interface{}{
+					"copied_from": src,
+				})
+			case os.IsNotExist(err):
+				if err := config.SaveTo(config.DefaultConfig(), dest); err != nil {
+					return err
+				}
+				src = "defaults"
+			default:
+				if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+					return fmt.Errorf("create profiles dir: %w", err)
+				}
+				if err := os.WriteFile(dest, data, 0644); err != nil {
+					return err
+				}
+			}
+
+			if output.IsJSON() {
+				return output.JSON(map[string]string{"name": name, "path": dest, "copied_from": src})
+			}
+			fmt.Println(tui.SuccessStyle.Render(fmt.Sprintf("Created profile %s from %s", name, src)))
+			fmt.Println(tui.MutedStyle.Render("  Edit: " + dest))
+			fmt.Println(tui.MutedStyle.Render("  Use:  clood --profile " + name + " <command>"))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite an existing profile")
+
+	return withDryRun(cmd)
+}
+
+func listProfiles() error {
+	names, err := config.ListProfiles()
+	if err != nil {
+		return err
+	}
+
+	profiles := []ProfileInfo{profileInfo("")}
+	for _, name := range names {
+		profiles = append(profiles, profileInfo(name))
+	}
+
+	if output.IsJSON() {
+		return output.JSON(profiles)
+	}
+
+	fmt.Println(tui.RenderHeader("Config Profiles"))
+	fmt.Println()
+	for _, p := range profiles {
+		marker := "  "
+		name := fmt.Sprintf("%-16s", p.Name)
+		if p.Active {
+			marker = tui.SuccessStyle.Render("● ")
+			name = tui.SuccessStyle.Render(name)
+		}
+		detail := tui.MutedStyle.Render(fmt.Sprintf("%d hosts, deep tier %s", p.Hosts, p.Model))
+		if p.Error != "" {
+			detail = tui.ErrorStyle.Render(p.Error)
+		}
+		fmt.Printf("  %s%s %s\n", marker, name, detail)
+		fmt.Printf("    %s\n", tui.MutedStyle.Render(p.Path))
+	}
+	if len(names) == 0 {
+		fmt.Println()
+		fmt.Println(tui.MutedStyle.Render("  No profiles yet. Create one with: clood profile create <name>"))
+	}
+	return nil
+}
+
+func profileInfo(name string) ProfileInfo {
+	// A bad name's path is left empty; LoadProfile below reports it
+	path, _ := config.ProfilePath(name)
+	info := ProfileInfo{Name: name, Path: path, Active: name == config.ActiveProfile}
+	if name == "" {
+		info.Name = "default"
+	}
+
+	cfg, err := config.LoadProfile(name)
+	if err != nil {
+		info.Error = err.Error()
+		return info
+	}
+	info.Hosts = len(cfg.Hosts)
+	info.Model = cfg.Tiers.Deep.Model
+	return info
+}
@@ -5,6 +5,7 @@ import (
 	"os/exec"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/dirtybirdnj/clood/internal/config"
@@ -93,20 +94,6 @@ func getDiskInfo(path string) (*DiskInfo, error) {
 	}, nil
 }
 
-// formatBytes formats bytes as human-readable string
-func formatBytes(b int64) string {
-	const unit = 1024
-	if b < unit {
-		return fmt.Sprintf("%d B", b)
-	}
-	div, exp := int64(unit), 0
-	for n := b / unit; n >= unit; n /= unit {
-		div *= unit
-		exp++
-	}
-	return fmt.Sprintf("%.1f %cB", float64(b)/float64(div), "KMGTPE"[exp])
-}
-
 // getModelSize returns estimated size for a model
 func getModelSize(model string) float64 {
 	if size, ok := modelSizes[model]; ok {
@@ -226,9 +213,9 @@ func showRecommendations(cfg *config.Config) error {
 	if err == nil {
 		fmt.Println(tui.RenderHeader("Disk Usage"))
 		fmt.Println()
-		fmt.Printf("  Available: %s\n", formatBytes(disk.Available))
-		fmt.Printf("  Used:      %s (%d%%)\n", formatBytes(disk.Used), disk.UsedPct)
-		fmt.Printf("  Total:     %s\n", formatBytes(disk.Total))
+		fmt.Printf("  Available: %s\n", hosts.FormatSize(disk.Available))
+		fmt.Printf("  Used:      %s (%d%%)\n", hosts.FormatSize(disk.Used), disk.UsedPct)
+		fmt.Printf("  Total:     %s\n", hosts.FormatSize(disk.Total))
 		fmt.Println()
 
 		if missingSize > 0 {
@@ -237,12 +224,12 @@ func showRecommendations(cfg *config.Config) error {
 				fmt.Printf("  %s ~%.1fGB needed for missing models, %s available\n",
 					tui.SuccessStyle.Render("✓"),
 					missingSize,
-					formatBytes(disk.Available))
+					hosts.FormatSize(disk.Available))
 			} else {
 				fmt.Printf("  %s ~%.1fGB needed but only %s available\n",
 					tui.ErrorStyle.Render("⚠"),
 					missingSize,
-					formatBytes(disk.Available))
+					hosts.FormatSize(disk.Available))
 			}
 		}
 		fmt.Println()
@@ -314,13 +301,13 @@ func pullModel(cfg *config.Config, model, hostName string) error {
 		if err == nil {
 			if disk.Available < neededBytes {
 				return fmt.Errorf("insufficient disk space: need ~%.1fGB, only %s available",
-					modelSize, formatBytes(disk.Available))
+					modelSize, hosts.FormatSize(disk.Available))
 			}
 			if disk.UsedPct > 90 {
 				fmt.Printf("  %s Disk is %d%% full (%s available)\n",
 					tui.ErrorStyle.Render("⚠"),
 					disk.UsedPct,
-					formatBytes(disk.Available))
+					hosts.FormatSize(disk.Available))
 			}
 		}
 	}
@@ -362,3 +349,127 @@ func pullModel(cfg *config.Config, model, hostName string) error {
 	fmt.Printf("  %s %s pulled to %s in %v\n", tui.SuccessStyle.Render("✓"), model, targetHost, elapsed.Round(time.Second))
 	return nil
 }
+
+// HostPullResult records the outcome of pulling a model to one host
+type HostPullResult struct {
+	Host        string        `json:"host"`
+	Status      string        `json:"status"` // "pulled", "installed", "failed"
+	Duration    time.Duration `json:"-"`
+	DurationSec float64       `json:"duration_sec,omitempty"`
+	Error       string        `json:"error,omitempty"`
+}
+
+// pullModelAllHosts pulls model to every online host concurrently. A failure
+// on one host does not stop the others; the summary reports each outcome.
+// When quiet is set no progress is drawn (for --json).
+func pullModelAllHosts(cfg *config.Config, model string, quiet bool) ([]HostPullResult, error) {
+	mgr := hosts.NewManager()
+	mgr.AddHosts(cfg.Hosts)
+
+	online := mgr.GetOnlineHosts()
+	if len(online) == 0 {
+		return nil, fmt.Errorf("no hosts available")
+	}
+
+	loader := tui.NewStreamingLoader("Pulling " + model)
+	for _, st := range online {
+		loader.AddItem(st.Host.Name)
+	}
+
+	if !quiet {
+		fmt.Printf("  Pulling %s (~%.1fGB) to %d hosts...\n", model, getModelSize(model), len(online))
+
+		// Reserve lines for the loader to overwrite
+		fmt.Println()
+		fmt.Println()
+		for range online {
+			fmt.Println()
+		}
+		loader.Start()
+	}
+
+	results := make([]HostPullResult, len(online))
+	var wg sync.WaitGroup
+	for i, st := range online {
+		wg.Add(1)
+		go func(idx int, status *hosts.HostStatus) {
+			defer wg.Done()
+			results[idx] = pullToHost(mgr, loader, status, model)
+		}(i, st)
+	}
+	wg.Wait()
+	loader.Stop()
+
+	return results, nil
+}
+
+// pullToHost pulls a model to a single host, reporting progress to loader
+func pullToHost(mgr *hosts.Manager, loader *tui.StreamingLoader, status *hosts.HostStatus, model string) HostPullResult {
+	name := status.Host.Name
+	result := HostPullResult{Host: name}
+
+	for _, m := range status.Models {
+		if m.Name == model {
+			result.Status = "installed"
+			loader.UpdateItem(name, "done", "already installed")
+			return result
+		}
+	}
+
+	client := mgr.GetClient(name)
+	if client == nil {
+		result.Status = "failed"
+		result.Error = "no client for host"
+		loader.UpdateItem(name, "error", result.Error)
+		return result
+	}
+
+	start := time.Now()
+	loader.UpdateItem(name, "pulling", tui.MutedStyle.Render("starting..."))
+	err := client.Pull(model, func(st string, completed, total int64) {
+		if total > 0 {
+			loader.UpdateItem(name, "pulling", fmt.Sprintf("%s %3d%% %s",
+				tui.ProgressBar(completed, total, 20),
+				int(completed*100/total),
+				tui.MutedStyle.Render(st)))
+		} else if st != "" {
+			loader.UpdateItem(name, "pulling", tui.MutedStyle.Render(st))
+		}
+	})
+	result.Duration = time.Since(start)
+	result.DurationSec = result.Duration.Seconds()
+
+	if err != nil {
+		result.Status = "failed"
+		result.Error = err.Error()
+		loader.UpdateItem(name, "error", result.Error)
+		return result
+	}
+
+	result.Status = "pulled"
+	loader.UpdateItem(name, "done", result.Duration.Round(time.Second).String())
+	return result
+}
+
+// printPullSummary prints the per-host outcome of an --all-hosts pull
+func printPullSummary(model string, results []HostPullResult) {
+	fmt.Println(tui.RenderHeader("Pull Summary: " + model))
+	fmt.Println()
+
+	succeeded := 0
+	for _, r := range results {
+		switch r.Status {
+		case "pulled":
+			succeeded++
+			fmt.Printf("  %s %-16s pulled in %v\n", tui.SuccessStyle.Render("✓"), r.Host, r.Duration.Round(time.Second))
+		case "installed":
+			succeeded++
+			fmt.Printf("  %s %-16s %s\n", tui.SuccessStyle.Render("✓"), r.Host, tui.MutedStyle.Render("already installed"))
+		default:
+			fmt.Printf("  %s %-16s %s\n", tui.ErrorStyle.Render("✗"), r.Host, r.Error)
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("  %s %d/%d hosts have %s\n", tui.MutedStyle.Render("Summary:"), succeeded, len(results), model)
+}
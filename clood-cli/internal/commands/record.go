@@ -0,0 +1,285 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dirtybirdnj/clood/internal/tui"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// SessionRecording is what clood --record writes: the command line, and
+// everything it read and printed with the time since it started
+type SessionRecording struct {
+	Version    int              `json:"version"`
+	Args       []string         `json:"args"`
+	RecordedAt time.Time        `json:"recorded_at"`
+	Duration   float64          `json:"duration_sec"`
+	ExitCode   int              `json:"exit_code"`
+	Events     []RecordingEvent `json:"events"`
+}
+
+// RecordingEvent is one chunk of a recorded stream
+type RecordingEvent struct {
+	At     float64 `json:"at"`     // seconds since the command started
+	Stream string  `json:"stream"` // stdin, stdout or stderr
+	Data   string  `json:"data"`
+}
+
+// RecordArgs finds the global --record flag in args, which main checks
+// before cobra runs. It returns the recording path and args without the
+// flag. Anything after "--" belongs to the command and isn't looked at.
+func RecordArgs(args []string) (string, []string, bool, error) {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if path, ok := strings.CutPrefix(arg, "--record="); ok {
+			if path == "" {
+				return "", nil, false, fmt.Errorf("--record needs a file: clood --record session.clood <command>")
+			}
+			rest := append(append([]string{}, args[:i]...), args[i+1:]...)
+			return path, rest, true, nil
+		}
+		if arg == "--record" {
+			if i+1 >= len(args) || strings.HasPrefix(args[i+1], "-") {
+				return "", nil, false, fmt.Errorf("--record needs a file: clood --record session.clood <command>")
+			}
+			rest := append(append([]string{}, args[:i]...), args[i+2:]...)
+			return args[i+1], rest, true, nil
+		}
+	}
+	return "", args, false, nil
+}
+
+// RecordSession runs clood with args as a child process, passing its
+// output through while saving it, with timings, to path. Piped stdin is
+// recorded too; an interactive terminal is handed straight to the child.
+// It returns the child's exit code.
+func RecordSession(path string, args []string) int {
+	self, err := os.Executable()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render("record: "+err.Error()))
+		return 1
+	}
+
+	rec := &sessionRecorder{start: time.Now()}
+	child := exec.Command(self, args...)
+	child.Env = os.Environ()
+	if term.IsTerminal(int(os.Stdout.Fd())) {
+		// The child writes to a pipe; keep the colours it would use on a terminal
+		child.Env = append(child.Env, "CLICOLOR_FORCE=1")
+	}
+	child.Stdout = rec.writer("stdout", os.Stdout)
+	child.Stderr = rec.writer("stderr", os.Stderr)
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		child.Stdin = os.Stdin
+	} else {
+		// Copy through our own pipe: exec would wait for its copy to hit
+		// EOF, which a stdin nobody closes never does
+		pr, pw, err := os.Pipe()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render("record: "+err.Error()))
+			return 1
+		}
+		defer pr.Close()
+		go func() {
+			io.Copy(pw, io.TeeReader(os.Stdin, rec.writer("stdin", io.Discard)))
+			pw.Close()
+		}()
+		child.Stdin = pr
+	}
+
+	// Ctrl+C goes to the child; the recorder stays up to save what it got
+	signal.Ignore(os.Interrupt)
+
+	exitCode := 0
+	if err := child.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			exitCode = exitErr.ExitCode()
+		} else {
+			fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render("record: "+err.Error()))
+			return 1
+		}
+	}
+
+	recording := SessionRecording{
+		Version:    1,
+		Args:       args,
+		RecordedAt: rec.start,
+		Duration:   time.Since(rec.start).Seconds(),
+		ExitCode:   exitCode,
+		Events:     rec.finish(),
+	}
+	data, err := json.MarshalIndent(recording, "", "  ")
+	if err == nil {
+		err = os.WriteFile(path, data, 0644)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render("record: "+err.Error()))
+		return 1
+	}
+	fmt.Fprintln(os.Stderr, tui.MutedStyle.Render(fmt.Sprintf("Recorded %d events to %s (clood replay %s)", len(recording.Events), path, path)))
+	return exitCode
+}
+
+// sessionRecorder collects the events of every stream in order
+type sessionRecorder struct {
+	start  time.Time
+	mu     sync.Mutex
+	events []RecordingEvent
+	// partial holds the bytes of a UTF-8 character split across writes,
+	// per stream, so Data stays valid text
+	partial map[string][]byte
+}
+
+func (r *sessionRecorder) writer(stream string, out io.Writer) io.Writer {
+	return recordingWriter{rec: r, stream: stream, out: out}
+}
+
+func (r *sessionRecorder) add(stream string, p []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.partial == nil {
+		r.partial = map[string][]byte{}
+	}
+	data := append(r.partial[stream], p...)
+	cut := len(data)
+	// Hold back an incomplete character at the end for the next write
+	for i := 1; i < utf8.UTFMax && i <= len(data); i++ {
+		if b := data[len(data)-i]; utf8.RuneStart(b) {
+			if !utf8.FullRune(data[len(data)-i:]) {
+				cut = len(data) - i
+			}
+			break
+		}
+	}
+	r.partial[stream] = append([]byte(nil), data[cut:]...)
+	if cut > 0 {
+		r.events = append(r.events, RecordingEvent{
+			At:     time.Since(r.start).Seconds(),
+			Stream: stream,
+			Data:   string(data[:cut]),
+		})
+	}
+}
+
+// finish flushes any held-back bytes and returns the events
+func (r *sessionRecorder) finish() []RecordingEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for stream, rest := range r.partial {
+		if len(rest) > 0 {
+			r.events = append(r.events, RecordingEvent{At: time.Since(r.start).Seconds(), Stream: stream, Data: string(rest)})
+		}
+	}
+	return r.events
+}
+
+type recordingWriter struct {
+	rec    *sessionRecorder
+	stream string
+	out    io.Writer
+}
+
+func (w recordingWriter) Write(p []byte) (int, error) {
+	w.rec.add(w.stream, p)
+	return w.out.Write(p)
+}
+
+// ReplayCmd plays a recording back
+func ReplayCmd() *cobra.Command {
+	var speed string
+	var showInput bool
+
+	cmd := &cobra.Command{
+		Use:   "replay <session.clood>",
+		Short: "Play back a session saved with clood --record",
+		Long: `Prints a session recorded with 'clood --record FILE <command>' as it
+originally appeared, pausing between output the way the command did. No
+model or host is contacted, so it works with Ollama offline: handy for
+bug reports and screencasts.
+
+--speed scales the pauses: 2x plays twice as fast, 0.5x at half speed.
+
+Examples:
+  clood --record session.clood ask "What is a goroutine?"
+  clood replay session.clood
+  clood replay session.clood --speed 2x`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			multiplier, err := parseReplaySpeed(speed)
+			if err != nil {
+				return err
+			}
+			data, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			var recording SessionRecording
+			if err := json.Unmarshal(data, &recording); err != nil {
+				return fmt.Errorf("%s is not a clood recording: %w", args[0], err)
+			}
+
+			fmt.Fprintln(os.Stderr, tui.MutedStyle.Render(fmt.Sprintf("▶ clood %s  (recorded %s)",
+				quoteArgs(recording.Args), recording.RecordedAt.Format("2006-01-02 15:04"))))
+			replaySession(recording, multiplier, showInput)
+			fmt.Fprintln(os.Stderr, tui.MutedStyle.Render(fmt.Sprintf("■ exit %d after %.1fs", recording.ExitCode, recording.Duration)))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&speed, "speed", "1x", "Playback speed, e.g. 2x or 0.5x")
+	cmd.Flags().BoolVar(&showInput, "show-input", false, "Also print what was piped to the command's stdin")
+
+	return cmd
+}
+
+// quoteArgs joins args for display, quoting the ones with spaces
+func quoteArgs(args []string) string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		if a == "" || strings.ContainsAny(a, " \t\n\"'") {
+			a = strconv.Quote(a)
+		}
+		quoted[i] = a
+	}
+	return strings.Join(quoted, " ")
+}
+
+// replaySession writes each event to the stream it came from, at its
+// recorded time divided by speed
+func replaySession(recording SessionRecording, speed float64, showInput bool) {
+	start := time.Now()
+	for _, ev := range recording.Events {
+		var out io.Writer
+		switch ev.Stream {
+		case "stdout":
+			out = os.Stdout
+		case "stderr":
+			out = os.Stderr
+		case "stdin":
+			if !showInput {
+				continue
+			}
+			out = os.Stdout
+		default:
+			continue
+		}
+		due := time.Duration(ev.At / speed * float64(time.Second))
+		if wait := due - time.Since(start); wait > 0 {
+			time.Sleep(wait)
+		}
+		io.WriteString(out, ev.Data)
+	}
+}
@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestRecordArgs(t *testing.T) {
+	tests := []struct {
+		args []string
+		path string
+		rest []string
+		ok   bool
+	}{
+		{[]string{"ask", "hi"}, "", []string{"ask", "hi"}, false},
+		{[]string{"--record", "s.clood", "ask", "hi"}, "s.clood", []string{"ask", "hi"}, true},
+		{[]string{"--record=s.clood", "ask", "hi"}, "s.clood", []string{"ask", "hi"}, true},
+		{[]string{"ask", "--record", "s.clood", "hi"}, "s.clood", []string{"ask", "hi"}, true},
+		{[]string{"ask", "hi", "--record=s.clood"}, "s.clood", []string{"ask", "hi"}, true},
+		// After "--" the flag is the command's argument, not ours
+		{[]string{"run", "--", "--record", "s.clood"}, "", []string{"run", "--", "--record", "s.clood"}, false},
+		{[]string{"run", "--", "--record="}, "", []string{"run", "--", "--record="}, false},
+		{[]string{"--record", "s.clood", "run", "--", "--record", "x"}, "s.clood", []string{"run", "--", "--record", "x"}, true},
+	}
+	for _, tt := range tests {
+		orig := append([]string(nil), tt.args...)
+		path, rest, ok, err := RecordArgs(tt.args)
+		if err != nil {
+			t.Errorf("RecordArgs(%q): %v", tt.args, err)
+			continue
+		}
+		if path != tt.path || ok != tt.ok || !reflect.DeepEqual(rest, tt.rest) {
+			t.Errorf("RecordArgs(%q) = %q, %q, %v; want %q, %q, %v", tt.args, path, rest, ok, tt.path, tt.rest, tt.ok)
+		}
+		if !reflect.DeepEqual(tt.args, orig) {
+			t.Errorf("RecordArgs(%q) modified its argument", orig)
+		}
+	}
+}
+
+func TestRecordArgsMissingFile(t *testing.T) {
+	for _, args := range [][]string{
+		{"--record"},
+		{"--record="},
+		{"--record", "--json", "ask"},
+		{"ask", "--record="},
+	} {
+		if _, _, _, err := RecordArgs(args); err == nil || !strings.Contains(err.Error(), "--record needs a file") {
+			t.Errorf("RecordArgs(%q) = %v; want a missing-file error", args, err)
+		}
+	}
+}
+
+func TestSessionRecorderSplitRunes(t *testing.T) {
+	text := "héllo, 世界 🐈!"
+	data := []byte(text)
+
+	// Every way of cutting the text in two, including inside each rune
+	for cut := 0; cut <= len(data); cut++ {
+		rec := &sessionRecorder{start: time.Now()}
+		rec.add("stdout", data[:cut])
+		rec.add("stdout", data[cut:])
+		var got strings.Builder
+		for _, ev := range rec.finish() {
+			if !utf8.ValidString(ev.Data) {
+				t.Errorf("cut at %d: event %q is not valid UTF-8", cut, ev.Data)
+			}
+			got.WriteString(ev.Data)
+		}
+		if got.String() != text {
+			t.Errorf("cut at %d: recorded %q; want %q", cut, got.String(), text)
+		}
+	}
+
+	// One byte per write outputs whole runes only
+	rec := &sessionRecorder{start: time.Now()}
+	for i := range data {
+		rec.add("stdout", data[i:i+1])
+	}
+	events := rec.finish()
+	if len(events) != utf8.RuneCountInString(text) {
+		t.Errorf("byte-at-a-time: %d events; want one per rune (%d)", len(events), utf8.RuneCountInString(text))
+	}
+}
+
+func TestSessionRecorderStreams(t *testing.T) {
+	rec := &sessionRecorder{start: time.Now()}
+	star := []byte("★")
+	rec.add("stdout", star[:1])
+	rec.add("stderr", []byte("err\n"))
+	rec.add("stdout", star[1:])
+	// A truncated character still left at the end is flushed as it is
+	rec.add("stderr", star[:2])
+
+	var stdout, stderr string
+	for _, ev := range rec.finish() {
+		switch ev.Stream {
+		case "stdout":
+			stdout += ev.Data
+		case "stderr":
+			stderr += ev.Data
+		}
+	}
+	if stdout != "★" {
+		t.Errorf("stdout = %q; want %q", stdout, "★")
+	}
+	if stderr != "err\n"+string(star[:2]) {
+		t.Errorf("stderr = %q", stderr)
+	}
+}
@@ -26,6 +26,7 @@ type PRReviewResult struct {
 	Issues       []ReviewIssue  `json:"issues"`
 	Model        string         `json:"model"`
 	Duration     float64        `json:"duration_seconds"`
+	ReviewURL    string         `json:"review_url,omitempty"`
 }
 
 type ReviewIssue struct {
@@ -39,6 +40,7 @@ type ReviewIssue struct {
 
 func ReviewPRCmd() *cobra.Command {
 	var dryRun bool
+	var post bool
 	var securityOnly bool
 	var model string
 	var host string
@@ -54,30 +56,48 @@ Reviews check for:
   - Logic problems (edge cases, error handling)
   - Performance concerns (N+1 queries, allocations)
 
+With --post the review is submitted through the GitHub API (using
+GITHUB_TOKEN): findings on lines in the diff become inline comments, and
+the summary and remaining findings form the review body.
+
 Examples:
   clood review-pr 123               # Review PR #123
   clood review-pr 123 --security    # Security focus only
+  clood review-pr 123 --post        # Post as a GitHub PR review
   clood review-pr 123 --dry-run     # Preview without posting
   clood review-pr 123 --model llama3.1:8b  # Use specific model`,
 		Args: cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			prNumber := args[0]
-			result, err := reviewPR(prNumber, model, host, securityOnly, dryRun)
+			result, diff, err := reviewPR(prNumber, model, host, securityOnly, dryRun)
 			if err != nil {
 				fmt.Println(tui.ErrorStyle.Render("Error: " + err.Error()))
 				return
 			}
 
+			if post || dryRun {
+				url, err := postPRReview(result, diff, dryRun)
+				if err != nil {
+					fmt.Println(tui.ErrorStyle.Render("Failed to post review: " + err.Error()))
+					return
+				}
+				result.ReviewURL = url
+			}
+
 			if output.IsJSON() {
-				data, _ := json.MarshalIndent(result, "", "  ")
+				data, _ := output.Marshal(result)
 				fmt.Println(string(data))
 				return
 			}
 
 			printReviewResult(result)
+			if result.ReviewURL != "" {
+				fmt.Println(tui.SuccessStyle.Render("✓ Review posted: " + result.ReviewURL))
+			}
 		},
 	}
 
+	cmd.Flags().BoolVar(&post, "post", false, "Post the review to the PR on GitHub")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview review without posting")
 	cmd.Flags().BoolVar(&securityOnly, "security", false, "Focus on security issues only")
 	cmd.Flags().StringVarP(&model, "model", "m", "qwen2.5-coder:7b", "Model to use for review")
@@ -86,33 +106,34 @@ Examples:
 	return cmd
 }
 
-func reviewPR(prNumber, model, host string, securityOnly, dryRun bool) (*PRReviewResult, error) {
+func reviewPR(prNumber, model, host string, securityOnly, dryRun bool) (*PRReviewResult, string, error) {
 	startTime := time.Now()
 
 	// Get PR info
 	prInfo, err := getPRInfo(prNumber)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get PR info: %w", err)
+		return nil, "", fmt.Errorf("failed to get PR info: %w", err)
 	}
 
 	// Get PR diff
 	diff, err := getPRDiff(prNumber)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get PR diff: %w", err)
+		return nil, "", fmt.Errorf("failed to get PR diff: %w", err)
 	}
 
 	// Truncate if too long
-	if len(diff) > 15000 {
-		diff = diff[:15000] + "\n... (truncated)"
+	promptDiff := diff
+	if len(promptDiff) > 15000 {
+		promptDiff = promptDiff[:15000] + "\n... (truncated)"
 	}
 
 	// Build review prompt
-	prompt := buildReviewPrompt(prInfo, diff, securityOnly)
+	prompt := buildReviewPrompt(prInfo, promptDiff, securityOnly)
 
 	// Call model
 	reviewText, err := callModel(host, model, prompt)
 	if err != nil {
-		return nil, fmt.Errorf("model review failed: %w", err)
+		return nil, "", fmt.Errorf("model review failed: %w", err)
 	}
 
 	// Parse issues from response
@@ -130,7 +151,7 @@ func reviewPR(prNumber, model, host string, securityOnly, dryRun bool) (*PRRevie
 		Duration:     time.Since(startTime).Seconds(),
 	}
 
-	return result, nil
+	return result, diff, nil
 }
 
 type prInfo struct {
@@ -0,0 +1,224 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/dirtybirdnj/clood/internal/git"
+	"github.com/dirtybirdnj/clood/internal/output"
+	"github.com/dirtybirdnj/clood/internal/tui"
+	"github.com/google/go-github/v50/github"
+)
+
+// postPRReview submits the review as a formal GitHub PR review, or prints
+// it when dryRun is set. Findings on lines inside the diff become inline
+// comments; everything else goes in the review body.
+func postPRReview(result *PRReviewResult, diff string, dryRun bool) (string, error) {
+	review := buildPRReview(result, diff)
+
+	if dryRun {
+		if !output.IsJSON() {
+			printPRReviewPreview(review)
+		}
+		return "", nil
+	}
+
+	token := os.Getenv("GITHUB_TOKEN")
+	if token == "" {
+		return "", fmt.Errorf("GITHUB_TOKEN is not set")
+	}
+
+	repo, err := detectGitRepo()
+	if err != nil {
+		return "", fmt.Errorf("detecting repository: %w", err)
+	}
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok {
+		return "", fmt.Errorf("unexpected repository name %q", repo)
+	}
+
+	client := git.GitHubClient(token)
+	posted, _, err := client.PullRequests.CreateReview(context.Background(), owner, name, result.PRNumber, review)
+	if err != nil {
+		return "", err
+	}
+	return posted.GetHTMLURL(), nil
+}
+
+func buildPRReview(result *PRReviewResult, diff string) *github.PullRequestReviewRequest {
+	lines := diffNewLines(diff)
+
+	var comments []*github.DraftReviewComment
+	var general []ReviewIssue
+	for _, issue := range result.Issues {
+		path := reviewIssuePath(issue.File, lines)
+		if path == "" || !lines[path][issue.Line] {
+			general = append(general, issue)
+			continue
+		}
+		comments = append(comments, &github.DraftReviewComment{
+			Path: github.String(path),
+			Line: github.Int(issue.Line),
+			Side: github.String("RIGHT"),
+			Body: github.String(reviewIssueMarkdown(issue, false)),
+		})
+	}
+
+	var body strings.Builder
+	counts := map[string]int{}
+	for _, issue := range result.Issues {
+		counts[issue.Severity]++
+	}
+	fmt.Fprintf(&body, "## clood review\n\n")
+	if len(result.Issues) == 0 {
+		body.WriteString("No issues found.\n")
+	} else {
+		fmt.Fprintf(&body, "**%d critical, %d warning, %d note**", counts["critical"], counts["warning"], counts["note"])
+		if len(comments) > 0 {
+			fmt.Fprintf(&body, " (%d inline)", len(comments))
+		}
+		body.WriteString("\n")
+	}
+	if len(general) > 0 {
+		body.WriteString("\n### Findings\n\n")
+		for _, issue := range general {
+			body.WriteString("- " + reviewIssueMarkdown(issue, true) + "\n")
+		}
+	}
+	fmt.Fprintf(&body, "\n---\n_Reviewed locally by %s in %.1fs_\n", result.Model, result.Duration)
+
+	return &github.PullRequestReviewRequest{
+		Body:     github.String(body.String()),
+		Event:    github.String("COMMENT"),
+		Comments: comments,
+	}
+}
+
+// reviewIssuePath matches a model-reported file against the diff's paths,
+// tolerating a/ and b/ prefixes
+func reviewIssuePath(file string, lines map[string]map[int]bool) string {
+	for _, candidate := range []string{file, strings.TrimPrefix(file, "b/"), strings.TrimPrefix(file, "a/")} {
+		if _, ok := lines[candidate]; ok {
+			return candidate
+		}
+	}
+	return ""
+}
+
+func reviewIssueMarkdown(issue ReviewIssue, withLocation bool) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "**%s** (%s)", strings.ToUpper(issue.Severity), issue.Category)
+	if withLocation && issue.File != "" && issue.File != "general" {
+		location := issue.File
+		if issue.Line > 0 {
+			location = fmt.Sprintf("%s:%d", issue.File, issue.Line)
+		}
+		fmt.Fprintf(&sb, " `%s`", location)
+	}
+	sb.WriteString(": " + issue.Description)
+	if issue.Suggestion != "" {
+		// Indented under the list item in the body, its own paragraph inline
+		sep := "\n\n"
+		if withLocation {
+			sep = "\n  "
+		}
+		sb.WriteString(sep + "Suggestion: " + issue.Suggestion)
+	}
+	return sb.String()
+}
+
+// diffNewLines returns, per file, the new-side line numbers present in the
+// diff's hunks. GitHub rejects review comments on any other line. Hunk
+// headers give each hunk's length, so content lines that happen to start
+// with "--- " or "+++ " aren't mistaken for file headers.
+func diffNewLines(diff string) map[string]map[int]bool {
+	lines := make(map[string]map[int]bool)
+	var current map[int]bool
+	line, oldLeft, newLeft := 0, 0, 0
+
+	scanner := bufio.NewScanner(strings.NewReader(diff))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		text := scanner.Text()
+		if oldLeft > 0 || newLeft > 0 {
+			switch {
+			case strings.HasPrefix(text, "+"):
+				newLeft--
+				if current != nil {
+					current[line] = true
+				}
+				line++
+			case strings.HasPrefix(text, "-"):
+				oldLeft--
+			case strings.HasPrefix(text, `\`):
+				// "\ No newline at end of file"
+			default:
+				// Context; some tools strip the space off blank lines
+				oldLeft--
+				newLeft--
+				if current != nil {
+					current[line] = true
+				}
+				line++
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(text, "+++ "):
+			path := strings.TrimPrefix(text, "+++ ")
+			if path == "/dev/null" {
+				current = nil
+				continue
+			}
+			path = strings.TrimPrefix(path, "b/")
+			current = make(map[int]bool)
+			lines[path] = current
+		case strings.HasPrefix(text, "@@ "):
+			// @@ -a,b +c,d @@, where a missing count means 1
+			fields := strings.Fields(text)
+			if len(fields) < 3 {
+				continue
+			}
+			_, oldLeft = hunkRange(fields[1], "-")
+			line, newLeft = hunkRange(fields[2], "+")
+		}
+	}
+	return lines
+}
+
+// hunkRange parses one side of a hunk header, "+12,5" or "+12"
+func hunkRange(field, sign string) (start, count int) {
+	startStr, countStr, hasCount := strings.Cut(strings.TrimPrefix(field, sign), ",")
+	start, _ = strconv.Atoi(startStr)
+	count = 1
+	if hasCount {
+		count, _ = strconv.Atoi(countStr)
+	}
+	return start, count
+}
+
+func printPRReviewPreview(review *github.PullRequestReviewRequest) {
+	fmt.Println(tui.RenderHeader("Review (dry run, not posted)"))
+	fmt.Println()
+	for _, l := range strings.Split(strings.TrimRight(review.GetBody(), "\n"), "\n") {
+		fmt.Println("  " + l)
+	}
+	fmt.Println()
+	if len(review.Comments) == 0 {
+		fmt.Println(tui.MutedStyle.Render("  No inline comments"))
+		return
+	}
+	fmt.Println(tui.HeaderStyle.Render(fmt.Sprintf("  Inline comments (%d)", len(review.Comments))))
+	for _, c := range review.Comments {
+		fmt.Printf("  %s\n", tui.AccentStyle.Render(fmt.Sprintf("%s:%d", c.GetPath(), c.GetLine())))
+		for _, l := range strings.Split(c.GetBody(), "\n") {
+			fmt.Printf("  │ %s\n", l)
+		}
+	}
+	fmt.Println()
+}
@@ -0,0 +1,133 @@
+package commands
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+const testPRDiff = `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,5 @@
+ package main
+-import "fmt"
++import (
++	"fmt"
++)
+ 
+@@ -10,3 +11,4 @@ func main() {
+ 	a := 1
+--- this removed line looks like a header
++++ this added line looks like one too
++	b := 2
+ }
+diff --git a/new.txt b/new.txt
+new file mode 100644
+--- /dev/null
++++ b/new.txt
+@@ -0,0 +1,2 @@
++first
++second
+\ No newline at end of file
+diff --git a/gone.txt b/gone.txt
+deleted file mode 100644
+--- a/gone.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-bye
+diff --git a/one.txt b/one.txt
+--- a/one.txt
++++ b/one.txt
+@@ -3 +3 @@
+-old
++new
+`
+
+func sortedLines(m map[int]bool) []int {
+	var lines []int
+	for l := range m {
+		lines = append(lines, l)
+	}
+	sort.Ints(lines)
+	return lines
+}
+
+func TestDiffNewLines(t *testing.T) {
+	got := diffNewLines(testPRDiff)
+
+	want := map[string][]int{
+		"main.go": {1, 2, 3, 4, 5, 11, 12, 13, 14},
+		"new.txt": {1, 2},
+		"one.txt": {3},
+	}
+	if len(got) != len(want) {
+		t.Errorf("files = %v; want main.go, new.txt and one.txt", got)
+	}
+	for file, lines := range want {
+		if l := sortedLines(got[file]); !reflect.DeepEqual(l, lines) {
+			t.Errorf("diffNewLines()[%q] = %v; want %v", file, l, lines)
+		}
+	}
+	if _, ok := got["this added line looks like one too"]; ok {
+		t.Error("an added line starting with +++ was read as a file header")
+	}
+}
+
+func TestBuildPRReview(t *testing.T) {
+	result := &PRReviewResult{
+		Model:    "qwen2.5-coder:7b",
+		Duration: 3.14,
+		Issues: []ReviewIssue{
+			{Severity: "critical", Category: "logic", File: "main.go", Line: 12, Description: "b is unused", Suggestion: "remove it"},
+			{Severity: "warning", Category: "style", File: "b/new.txt", Line: 2, Description: "no trailing newline"},
+			{Severity: "note", Category: "style", File: "main.go", Line: 40, Description: "outside the diff"},
+			{Severity: "note", Category: "security", File: "general", Description: "overall remark"},
+			{Severity: "warning", Category: "logic", File: "gone.txt", Line: 1, Description: "deleted file"},
+		},
+	}
+	review := buildPRReview(result, testPRDiff)
+
+	if review.GetEvent() != "COMMENT" {
+		t.Errorf("event = %q; want COMMENT", review.GetEvent())
+	}
+	var inline []string
+	for _, c := range review.Comments {
+		inline = append(inline, c.GetPath()+":"+strings.TrimSpace(strings.SplitN(c.GetBody(), ":", 2)[0]))
+		if c.GetSide() != "RIGHT" {
+			t.Errorf("comment on %s:%d is on side %q; want RIGHT", c.GetPath(), c.GetLine(), c.GetSide())
+		}
+	}
+	if len(review.Comments) != 2 || review.Comments[0].GetPath() != "main.go" || review.Comments[0].GetLine() != 12 ||
+		review.Comments[1].GetPath() != "new.txt" || review.Comments[1].GetLine() != 2 {
+		t.Fatalf("inline comments = %v; want main.go:12 and new.txt:2", inline)
+	}
+	if body := review.Comments[0].GetBody(); body != "**CRITICAL** (logic): b is unused\n\nSuggestion: remove it" {
+		t.Errorf("inline body = %q", body)
+	}
+
+	body := review.GetBody()
+	for _, want := range []string{
+		"**1 critical, 2 warning, 2 note** (2 inline)",
+		"- **NOTE** (style) `main.go:40`: outside the diff",
+		"- **NOTE** (security): overall remark",
+		"- **WARNING** (logic) `gone.txt:1`: deleted file",
+		"_Reviewed locally by qwen2.5-coder:7b in 3.1s_",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("review body is missing %q:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, "b is unused") {
+		t.Errorf("inline finding repeated in the body:\n%s", body)
+	}
+}
+
+func TestBuildPRReviewNoIssues(t *testing.T) {
+	review := buildPRReview(&PRReviewResult{Model: "m"}, testPRDiff)
+	if len(review.Comments) != 0 || !strings.Contains(review.GetBody(), "No issues found.") {
+		t.Errorf("review = %q with %d comments", review.GetBody(), len(review.Comments))
+	}
+}
@@ -1,16 +1,20 @@
 package commands
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/dirtybirdnj/clood/internal/agents"
 	"github.com/dirtybirdnj/clood/internal/config"
 	"github.com/dirtybirdnj/clood/internal/hosts"
 	"github.com/dirtybirdnj/clood/internal/ollama"
+	"github.com/dirtybirdnj/clood/internal/output"
 	"github.com/dirtybirdnj/clood/internal/tui"
 	"github.com/spf13/cobra"
 )
@@ -23,8 +27,13 @@ type RunResult struct {
 	Tokens   int    `json:"tokens,omitempty"`
 	Duration int64  `json:"duration_ms,omitempty"`
 	Error    string `json:"error,omitempty"`
+	TimedOut bool   `json:"timed_out,omitempty"`
 }
 
+// runTimeoutExitCode is returned when --timeout expires, so scripts can
+// tell a hung model apart from other failures
+const runTimeoutExitCode = 2
+
 func RunCmd() *cobra.Command {
 	var hostName string
 	var model string
@@ -32,9 +41,9 @@ func RunCmd() *cobra.Command {
 	var systemFile string
 	var promptFile string
 	var agentName string
-	var outputJSON bool
 	var quiet bool
 	var noStream bool
+	var timeout time.Duration
 
 	cmd := &cobra.Command{
 		Use:   "run [prompt]",
@@ -67,8 +76,12 @@ Examples:
   cat file.go | clood run --host ubuntu25 --prompt-file - --json
 
   # Quiet mode for scripts (only output response)
-  clood run --host ubuntu25 -q "summarize"`,
+  clood run --host ubuntu25 -q "summarize"
+
+  # Give up after 60s; exits with code 2 on timeout
+  clood run --timeout 60s --json "summarize"`,
 		Run: func(cmd *cobra.Command, args []string) {
+			outputJSON := output.IsJSON()
 			cfg, err := config.Load()
 			if err != nil {
 				outputError(outputJSON, quiet, "Error loading config: "+err.Error())
@@ -76,6 +89,7 @@ Examples:
 			}
 
 			// Apply agent configuration if specified
+			var options map[string]interface{}
 			if agentName != "" {
 				agentCfg := agents.LoadConfigWithFallback()
 				agent := agentCfg.GetAgent(agentName)
@@ -94,6 +108,7 @@ Examples:
 				if systemPrompt == "" && systemFile == "" && agent.System != "" {
 					systemPrompt = agent.System
 				}
+				options = agent.Options()
 
 				// Show which agent is being used (unless quiet/json)
 				if !quiet && !outputJSON {
@@ -185,9 +200,19 @@ Examples:
 				Model: model,
 			}
 
+			ctx := context.Background()
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+
 			if noStream || outputJSON {
 				// Blocking execution
-				resp, err := client.GenerateWithSystem(model, system, prompt)
+				resp, err := client.GenerateWithSystemOptions(ctx, model, system, prompt, options)
+				if isRunTimeout(ctx, err) {
+					exitRunTimeout(result, timeout, outputJSON)
+				}
 				if err != nil {
 					result.Error = err.Error()
 					outputResult(result, outputJSON, quiet)
@@ -200,11 +225,15 @@ Examples:
 			} else {
 				// Streaming execution
 				var response strings.Builder
-				resp, err := client.GenerateStreamWithSystem(model, system, prompt, func(chunk ollama.GenerateResponse) {
+				resp, err := client.GenerateStreamWithSystemOptions(ctx, model, system, prompt, options, func(chunk ollama.GenerateResponse) {
 					fmt.Print(chunk.Response)
 					response.WriteString(chunk.Response)
 				})
 
+				if isRunTimeout(ctx, err) {
+					fmt.Println()
+					exitRunTimeout(result, timeout, outputJSON)
+				}
 				if err != nil {
 					fmt.Fprintln(os.Stderr)
 					outputError(outputJSON, quiet, err.Error())
@@ -230,9 +259,9 @@ Examples:
 	cmd.Flags().StringVarP(&systemPrompt, "system", "s", "", "System prompt (agent role definition)")
 	cmd.Flags().StringVar(&systemFile, "system-file", "", "Load system prompt from file")
 	cmd.Flags().StringVarP(&promptFile, "prompt-file", "f", "", "Load prompt from file (use - for stdin)")
-	cmd.Flags().BoolVar(&outputJSON, "json", false, "Output result as JSON")
 	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Quiet mode - only output response")
 	cmd.Flags().BoolVar(&noStream, "no-stream", false, "Disable streaming output")
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Abort if the model hasn't finished within this duration, e.g. 60s (exit code 2)")
 
 	return cmd
 }
@@ -283,6 +312,27 @@ func hostHasModel(status *hosts.HostStatus, model string) bool {
 	return false
 }
 
+// isRunTimeout reports whether err came from the --timeout deadline
+func isRunTimeout(ctx context.Context, err error) bool {
+	return err != nil && (errors.Is(err, context.DeadlineExceeded) || ctx.Err() == context.DeadlineExceeded)
+}
+
+// exitRunTimeout reports a --timeout expiry on stderr and exits with
+// runTimeoutExitCode. The error goes to stderr even in --json mode so
+// stdout only ever carries model output.
+func exitRunTimeout(result RunResult, timeout time.Duration, outputJSON bool) {
+	result.Error = fmt.Sprintf("timed out after %s waiting for %s on %s", timeout, result.Model, result.Host)
+	result.TimedOut = true
+
+	if outputJSON {
+		data, _ := json.Marshal(result)
+		fmt.Fprintln(os.Stderr, string(data))
+	} else {
+		fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render("Error: "+result.Error))
+	}
+	os.Exit(runTimeoutExitCode)
+}
+
 func outputError(outputJSON, quiet bool, msg string) {
 	if outputJSON {
 		result := RunResult{Error: msg}
@@ -295,7 +345,7 @@ func outputError(outputJSON, quiet bool, msg string) {
 
 func outputResult(result RunResult, outputJSON, quiet bool) {
 	if outputJSON {
-		data, _ := json.MarshalIndent(result, "", "  ")
+		data, _ := output.Marshal(result)
 		fmt.Println(string(data))
 	} else if quiet {
 		fmt.Print(result.Response)
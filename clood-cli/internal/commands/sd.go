@@ -0,0 +1,1713 @@
+package commands
+
+import (
+	"encoding/base64"
+	"fmt"
+	"image"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dirtybirdnj/clood/internal/output"
+	"github.com/dirtybirdnj/clood/internal/sd"
+	"github.com/dirtybirdnj/clood/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// SdCmd groups Stable Diffusion commands backed by ComfyUI
+func SdCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sd",
+		Short: "Stable Diffusion image generation via ComfyUI",
+		Long: `Drive a ComfyUI server for Stable Diffusion image generation.
+
+ComfyUI must be running and reachable at --url (default ` + sd.DefaultComfyURL + `).
+
+--webhook URL POSTs {command, prompt, checkpoint, duration, image_path,
+success} as JSON when each image of paint, anvil, inpaint, upscale,
+video or run-workflow finishes, or fails. It runs alongside the command
+with a 5s timeout; a webhook that fails is reported on stderr and
+doesn't fail the command.`,
+	}
+	cmd.PersistentFlags().StringVar(&sdWebhookURL, "webhook", "", "POST a JSON notice here when each generated image finishes")
+	cobra.OnFinalize(waitSDWebhooks)
+
+	cmd.AddCommand(sdRefineCmd())
+	cmd.AddCommand(sdExtendCmd())
+	cmd.AddCommand(sdInpaintCmd())
+	cmd.AddCommand(sdAnvilCmd())
+	cmd.AddCommand(sdPaintCmd())
+	cmd.AddCommand(sdRunWorkflowCmd())
+	cmd.AddCommand(sdWarmupCmd())
+	cmd.AddCommand(sdRemixCmd())
+	cmd.AddCommand(sdVideoCmd())
+	cmd.AddCommand(sdUpscaleCmd())
+	cmd.AddCommand(sdDeconstructCmd())
+	cmd.AddCommand(sdInventoryCmd())
+	cmd.AddCommand(sdStatusCmd())
+	cmd.AddCommand(sdGalleryCmd())
+	cmd.AddCommand(sdPresetsCmd())
+
+	return cmd
+}
+
+// sdTimingsPath holds recent generation times for the sd status wait
+// estimate
+func sdTimingsPath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".clood", "sd_timings.json")
+}
+
+// newSDClient is sd.NewClient with generation times recorded
+func newSDClient(comfyURL string) *sd.Client {
+	client := sd.NewClient(comfyURL)
+	client.TimingsPath = sdTimingsPath()
+	return client
+}
+
+func sdRefineCmd() *cobra.Command {
+	var imagePath string
+	var denoise float64
+	var checkpoint string
+	var comfyURL string
+	var negative string
+	var negPreset string
+	var steps int
+	var seed int64
+	var sampler string
+	var scheduler string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "refine <prompt>",
+		Short: "Image-to-image: refine an existing image with a prompt",
+		Long: `Loads a local image, encodes it to latent space (VAEEncode) and runs
+the KSampler with the given denoise strength.
+
+Lower --denoise keeps more of the original image; 1.0 ignores it entirely.
+The output size follows the input image. A warning is printed when it is
+far from the checkpoint's native resolution (512 for SD1.5, 1024 for SDXL).
+
+Examples:
+  clood sd refine "ghibli style, soft light" --image cat.png
+  clood sd refine "oil painting" --image sketch.png --denoise 0.4
+  clood sd refine "watercolor" --image in.png --dry-run   # print workflow JSON`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if imagePath == "" {
+				return fmt.Errorf("--image is required")
+			}
+			if denoise <= 0 || denoise > 1 {
+				return fmt.Errorf("--denoise must be in (0, 1], got %.2f", denoise)
+			}
+
+			samplerName, schedulerName, err := sd.ResolveSampler(sampler, scheduler, cmd.Flags().Changed("scheduler"))
+			if err != nil {
+				return err
+			}
+
+			width, height, err := sd.ImageSize(imagePath)
+			if err != nil {
+				return err
+			}
+
+			prompt := sd.NewPrompt(args[0]).WithSeed(seed)
+			if err := applyNegative(prompt, negative, negPreset); err != nil {
+				return err
+			}
+
+			cfg := sd.DefaultWorkflowConfig()
+			cfg.Prompt = prompt
+			cfg.Checkpoint = checkpoint
+			cfg.Steps = steps
+			cfg.Width = width
+			cfg.Height = height
+			cfg.InputImagePath = imagePath
+			cfg.Denoise = denoise
+			cfg.Sampler = samplerName
+			cfg.Scheduler = schedulerName
+
+			if warning := checkRefineDimensions(width, height, checkpoint); warning != "" {
+				fmt.Fprintln(os.Stderr, tui.WarningStyle.Render("⚠ "+warning))
+			}
+
+			if dryRun {
+				workflow, err := sd.BuildBasicWorkflow(cfg)
+				if err != nil {
+					return err
+				}
+				data, err := workflow.ToJSON()
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(data))
+				return nil
+			}
+
+			client := newSDClient(comfyURL)
+			promptID, err := client.Generate(cfg)
+			if err != nil {
+				return err
+			}
+
+			if output.IsJSON() {
+				return output.JSON(map[string]interface{}{
+					"prompt_id": promptID,
+					"image":     imagePath,
+					"width":     width,
+					"height":    height,
+					"denoise":   denoise,
+				})
+			}
+
+			fmt.Printf("%s Queued img2img %s (%dx%d, denoise %.2f)\n",
+				tui.SuccessStyle.Render("✓"), promptID, width, height, denoise)
+			fmt.Println(tui.MutedStyle.Render("  Output lands in ComfyUI's output directory with prefix '" + cfg.OutputPrefix + "'"))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&imagePath, "image", "", "Input image (PNG or JPEG)")
+	cmd.Flags().Float64Var(&denoise, "denoise", 0.6, "Denoise strength (0-1, lower keeps more of the input)")
+	cmd.Flags().StringVar(&checkpoint, "checkpoint", "sd_xl_base_1.0.safetensors", "Checkpoint to load")
+	registerCompletion(cmd, "checkpoint", completeCheckpoints)
+	cmd.Flags().StringVar(&comfyURL, "url", sd.DefaultComfyURL, "ComfyUI server URL")
+	cmd.Flags().StringVar(&negative, "negative", "", "Negative prompt (default: common artifacts)")
+	addNegPresetFlag(cmd, &negPreset)
+	cmd.Flags().IntVar(&steps, "steps", 25, "Sampling steps")
+	cmd.Flags().Int64Var(&seed, "seed", -1, "Seed (-1 for random)")
+	addSamplerFlags(cmd, &sampler, &scheduler)
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the workflow JSON instead of submitting it")
+
+	return cmd
+}
+
+func sdExtendCmd() *cobra.Command {
+	var imagePath string
+	var direction string
+	var pixels int
+	var checkpoint string
+	var comfyURL string
+	var negative string
+	var negPreset string
+	var steps int
+	var seed int64
+	var sampler string
+	var scheduler string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "extend <prompt>",
+		Short: "Outpaint: extend an image beyond one of its borders",
+		Long: `Pads the image on one side, masks the new strip and inpaints it with
+the prompt, leaving the original pixels as they were.
+
+The canvas and mask are built locally and uploaded with the image;
+--pixels is rounded up so the padded side is a multiple of 8. An
+inpainting checkpoint blends the seam far better than a base model.
+
+Examples:
+  clood sd extend "continue the scene" --image input.png --direction right --pixels 512
+  clood sd extend "more sky, clouds" --image beach.png --direction up --pixels 256
+  clood sd extend "forest" --image in.png --direction left --dry-run   # print workflow JSON`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if imagePath == "" {
+				return fmt.Errorf("--image is required")
+			}
+			dir, err := sd.NormalizeExtendDirection(direction)
+			if err != nil {
+				return err
+			}
+
+			samplerName, schedulerName, err := sd.ResolveSampler(sampler, scheduler, cmd.Flags().Changed("scheduler"))
+			if err != nil {
+				return err
+			}
+
+			canvasPath, maskPath, width, height, err := sd.BuildOutpaintInputs(imagePath, dir, pixels, "")
+			if err != nil {
+				return err
+			}
+			defer os.Remove(canvasPath)
+			defer os.Remove(maskPath)
+
+			prompt := sd.NewPrompt(args[0]).WithSeed(seed)
+			if err := applyNegative(prompt, negative, negPreset); err != nil {
+				return err
+			}
+
+			cfg := sd.DefaultWorkflowConfig()
+			cfg.Prompt = prompt
+			cfg.Checkpoint = checkpoint
+			cfg.Steps = steps
+			cfg.Width = width
+			cfg.Height = height
+			cfg.InputImagePath = canvasPath
+			cfg.MaskImagePath = maskPath
+			cfg.Sampler = samplerName
+			cfg.Scheduler = schedulerName
+
+			if !strings.Contains(strings.ToLower(checkpoint), "inpaint") {
+				fmt.Fprintln(os.Stderr, tui.WarningStyle.Render("⚠ "+checkpoint+" is not an inpainting model; expect a visible seam"))
+			}
+
+			if dryRun {
+				workflow, err := sd.BuildBasicWorkflow(cfg)
+				if err != nil {
+					return err
+				}
+				data, err := workflow.ToJSON()
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(data))
+				return nil
+			}
+
+			client := newSDClient(comfyURL)
+			promptID, err := client.Generate(cfg)
+			if err != nil {
+				return err
+			}
+
+			if output.IsJSON() {
+				return output.JSON(map[string]interface{}{
+					"prompt_id": promptID,
+					"image":     imagePath,
+					"direction": dir,
+					"width":     width,
+					"height":    height,
+				})
+			}
+
+			fmt.Printf("%s Queued outpaint %s (%s to %dx%d)\n",
+				tui.SuccessStyle.Render("✓"), promptID, dir, width, height)
+			fmt.Println(tui.MutedStyle.Render("  Output lands in ComfyUI's output directory with prefix '" + cfg.OutputPrefix + "'"))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&imagePath, "image", "", "Input image (PNG or JPEG)")
+	cmd.Flags().StringVar(&direction, "direction", "right", "Side to extend: left, right, up or down")
+	cmd.Flags().IntVar(&pixels, "pixels", 512, "How far to extend the image")
+	cmd.Flags().StringVar(&checkpoint, "checkpoint", "sd_xl_base_1.0_inpainting_0.1.safetensors", "Checkpoint to load (an inpainting model)")
+	registerCompletion(cmd, "checkpoint", completeCheckpoints)
+	cmd.Flags().StringVar(&comfyURL, "url", sd.DefaultComfyURL, "ComfyUI server URL")
+	cmd.Flags().StringVar(&negative, "negative", "", "Negative prompt (default: common artifacts)")
+	addNegPresetFlag(cmd, &negPreset)
+	cmd.Flags().IntVar(&steps, "steps", 30, "Sampling steps")
+	cmd.Flags().Int64Var(&seed, "seed", -1, "Seed (-1 for random)")
+	addSamplerFlags(cmd, &sampler, &scheduler)
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the workflow JSON instead of submitting it")
+
+	return cmd
+}
+
+// addSamplerFlags registers --sampler and --scheduler with ComfyUI's defaults
+func addSamplerFlags(cmd *cobra.Command, sampler, scheduler *string) {
+	cmd.Flags().StringVar(sampler, "sampler", sd.DefaultSampler,
+		"KSampler algorithm (euler, euler_a, dpm++2m, dpm++2m_karras, ddim, ...)")
+	cmd.Flags().StringVar(scheduler, "scheduler", sd.DefaultScheduler,
+		"Noise schedule (normal, karras, exponential, sgm_uniform, simple)")
+}
+
+// streamSDProgress forwards ComfyUI's sampling steps to ATC as
+// sd_progress events, with the latent preview as a data URL when ComfyUI
+// sends one. Failing to connect only costs the live view, so it warns and
+// returns a no-op stop.
+func streamSDProgress(client *sd.Client, atcURL, command string) (stop func()) {
+	if atcURL == "" {
+		return func() {}
+	}
+	stop, err := client.ListenProgress(func(p sd.Progress) {
+		data := map[string]interface{}{
+			"command":   command,
+			"prompt_id": p.PromptID,
+			"node":      p.Node,
+			"step":      p.Step,
+			"max":       p.Max,
+		}
+		if len(p.Preview) > 0 {
+			data["preview"] = "data:" + p.PreviewType + ";base64," + base64.StdEncoding.EncodeToString(p.Preview)
+		}
+		sendATCEvent(atcURL, "sd_progress", data)
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, tui.WarningStyle.Render("⚠ No progress for ATC: "+err.Error()))
+		return func() {}
+	}
+	return stop
+}
+
+// checkRefineDimensions returns a warning when the input image is a poor
+// fit for the checkpoint, or "" when it looks fine.
+func checkRefineDimensions(width, height int, checkpoint string) string {
+	native := sd.NativeResolution(checkpoint)
+	if width%8 != 0 || height%8 != 0 {
+		return fmt.Sprintf("image is %dx%d; ComfyUI will crop to a multiple of 8", width, height)
+	}
+
+	area := float64(width * height)
+	nativeArea := float64(native * native)
+	if area < nativeArea*0.5 || area > nativeArea*2 {
+		return fmt.Sprintf("image is %dx%d but %s is trained at %dx%d; expect artifacts or resize first",
+			width, height, checkpoint, native, native)
+	}
+	return ""
+}
+
+func sdAnvilCmd() *cobra.Command {
+	var checkpoints []string
+	var comfyURL string
+	var outDir string
+	var negative string
+	var negPreset string
+	var steps int
+	var seed int64
+	var sampler string
+	var scheduler string
+	var makeGIF bool
+	var atcURL string
+
+	cmd := &cobra.Command{
+		Use:   "anvil <prompt>",
+		Short: "Run one prompt across several checkpoints and compare",
+		Long: `Generates the same prompt and seed with each --checkpoint, downloads the
+results from ComfyUI, and writes an HTML comparison gallery (compare.html)
+into the output directory.
+
+With --gif, also writes compare.gif next to the gallery: one frame per
+checkpoint, 2 seconds each, looping. Frames are scaled to the smallest
+generated image so SD1.5 and SDXL results line up.
+
+Examples:
+  clood sd anvil "cat in a rainy alley" --checkpoint sd_xl_base_1.0.safetensors --checkpoint dreamshaper_8.safetensors
+  clood sd anvil "lighthouse at dusk" --checkpoint a.safetensors,b.safetensors --gif`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(checkpoints) < 2 {
+				return fmt.Errorf("need at least two --checkpoint values to compare")
+			}
+
+			samplerName, schedulerName, err := sd.ResolveSampler(sampler, scheduler, cmd.Flags().Changed("scheduler"))
+			if err != nil {
+				return err
+			}
+
+			prompt := sd.NewPrompt(args[0]).WithSeed(seed)
+			if err := applyNegative(prompt, negative, negPreset); err != nil {
+				return err
+			}
+
+			batch := sd.NewBatchConfig("anvil", prompt)
+			batch.Description = args[0]
+			if outDir != "" {
+				batch.OutputDir = outDir
+			}
+			batch.Variations = sd.CheckpointSweep(checkpoints, nil)
+			for i := range batch.Variations {
+				batch.Variations[i].Steps = &steps
+				batch.Variations[i].Sampler = samplerName
+				batch.Variations[i].Scheduler = schedulerName
+			}
+
+			if output.DryRun {
+				return output.WouldHaveDone(fmt.Sprintf("compare %d checkpoints on %s", len(checkpoints), comfyURL), map[string]interface{}{
+					"prompt":      prompt.Positive,
+					"negative":    prompt.Negative,
+					"checkpoints": strings.Join(checkpoints, ", "),
+					"steps":       steps,
+					"seed":        seed,
+					"sampler":     samplerName,
+					"gif":         makeGIF,
+					"output_dir":  batch.OutputDir,
+				})
+			}
+
+			client := newSDClient(comfyURL)
+			stopProgress := streamSDProgress(client, atcURL, "anvil")
+			result, err := sd.RunBatch(client, batch, func(vr sd.VariationResult) {
+				sdWebhookBatch("anvil", vr)
+				if output.IsJSON() {
+					return
+				}
+				if vr.Success {
+					fmt.Printf("  %s %s %s\n", tui.SuccessStyle.Render("✓"), vr.Variation.Name,
+						tui.MutedStyle.Render(fmt.Sprintf("(%.1fs)", vr.GenerateTime.Seconds())))
+				} else {
+					fmt.Printf("  %s %s: %s\n", tui.ErrorStyle.Render("✗"), vr.Variation.Name, vr.Error)
+				}
+			})
+			stopProgress()
+			if err != nil {
+				return err
+			}
+
+			gallery := sd.NewCompareGallery(result)
+			f, err := os.Create(gallery.OutputPath)
+			if err != nil {
+				return err
+			}
+			if err := gallery.Render(f); err != nil {
+				f.Close()
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+
+			var gifPath string
+			if makeGIF {
+				gifPath, err = writeAnvilGIF(result)
+				if err != nil {
+					return err
+				}
+			}
+
+			if output.IsJSON() {
+				return output.JSON(map[string]interface{}{
+					"results": result.Results,
+					"gallery": gallery.OutputPath,
+					"gif":     gifPath,
+				})
+			}
+
+			fmt.Println()
+			fmt.Println(tui.SuccessStyle.Render("✓ Gallery: " + gallery.OutputPath))
+			if gifPath != "" {
+				fmt.Println(tui.SuccessStyle.Render("✓ GIF:     " + gifPath))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&checkpoints, "checkpoint", nil, "Checkpoint to compare (repeat or comma-separate)")
+	cmd.Flags().StringVar(&comfyURL, "url", sd.DefaultComfyURL, "ComfyUI server URL")
+	cmd.Flags().StringVarP(&outDir, "out", "o", "", "Output directory (default: outputs/batches/anvil)")
+	cmd.Flags().StringVar(&negative, "negative", "", "Negative prompt (default: common artifacts)")
+	addNegPresetFlag(cmd, &negPreset)
+	cmd.Flags().IntVar(&steps, "steps", 25, "Sampling steps")
+	cmd.Flags().Int64Var(&seed, "seed", 42069, "Seed shared by every checkpoint")
+	addSamplerFlags(cmd, &sampler, &scheduler)
+	cmd.Flags().BoolVar(&makeGIF, "gif", false, "Also write compare.gif cycling through the results")
+	cmd.Flags().StringVar(&atcURL, "atc", "", "ATC dashboard URL for live step progress (e.g., http://localhost:8080)")
+
+	return withDryRun(cmd)
+}
+
+// writeAnvilGIF encodes the successful results as compare.gif beside the gallery
+func writeAnvilGIF(result *sd.BatchResult) (string, error) {
+	var frames []string
+	for _, vr := range result.Results {
+		if vr.Success {
+			frames = append(frames, filepath.Join(result.Config.OutputDir, vr.OutputPath))
+		}
+	}
+	if len(frames) == 0 {
+		return "", fmt.Errorf("no images generated, skipping GIF")
+	}
+
+	path := filepath.Join(result.Config.OutputDir, "compare.gif")
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	if err := sd.WriteCompareGIF(f, frames, sd.CompareGIFDelay); err != nil {
+		f.Close()
+		return "", fmt.Errorf("writing %s: %w", path, err)
+	}
+	return path, f.Close()
+}
+
+func sdPaintCmd() *cobra.Command {
+	var checkpoint string
+	var comfyURL string
+	var outDir string
+	var negative string
+	var negPreset string
+	var variationsPath string
+	var parallel int
+	var steps int
+	var seed int64
+	var sampler string
+	var scheduler string
+	var controlNets []string
+	var controlNetStrength float64
+	var controlNetModel string
+	var loras []string
+	var atcURL string
+	var enhance bool
+	var enhanceModel string
+	var edit bool
+
+	cmd := &cobra.Command{
+		Use:   "paint <prompt>",
+		Short: "Generate images from a prompt, optionally across variations",
+		Long: `Generates an image from a prompt and downloads it from ComfyUI.
+
+With --variations, the prompt is a template whose {placeholders} are
+filled from each entry of a JSON file, and every expansion is generated:
+
+  [{"subject": "tortoise"}, {"subject": "red panda"}]
+
+Images are named after the variant values (tortoise.png, red_panda.png)
+and an HTML gallery (compare.html) is written beside them. --parallel
+controls how many generations are queued on ComfyUI at once.
+
+--controlnet conditions the image on a reference: the image is run through
+the type's preprocessor (canny, depth, pose, lineart, scribble, or none for
+an already-processed hint) and fed to an installed ControlNet model picked
+by name from ComfyUI's models/controlnet. Depth, pose, lineart and
+scribble need the comfyui_controlnet_aux nodes.
+
+--lora loads a LoRA from ComfyUI's models/loras. name:early=1.0,late=0.3
+applies the early weight for the first 70% of the steps and the late one
+after (split=0.6 moves the switch), by running the sampler in two stages;
+name:0.8 keeps one weight throughout.
+
+Attention syntax like "(tortoise:1.5), wearing (spectacles:0.8)" is sent
+to ComfyUI as written; 'clood sd deconstruct' shows the weights it sets.
+
+--enhance first has a local LLM (the writing tier, or --enhance-model)
+expand a short prompt with style descriptors, lighting and quality tags,
+and generates from that. The image's sidecar JSON keeps both: "prompt"
+is what was generated, "original_prompt" what you typed.
+
+--edit opens the prompt in a full-screen editor before anything is
+generated; the argument is optional then. Enter adds a newline, ctrl+v
+pastes, and ctrl+enter (or ctrl+s, for terminals that don't send it)
+generates; esc cancels. With --enhance the editor shows the enhanced
+prompt, so it can be reviewed and trimmed first.
+
+Examples:
+  clood sd paint "a lighthouse at dusk, oil painting"
+  clood sd paint --enhance "a tortoise"
+  clood sd paint --edit --enhance "a tortoise"
+  clood sd paint "portrait" --controlnet canny ./reference.png --controlnet-strength 0.8
+  clood sd paint "dancer" --controlnet pose=./pose.jpg --controlnet depth=./room.png
+  clood sd paint "a forest shrine" --lora ghibli_style:early=1.0,late=0.3
+  clood sd paint --variations variants.json "a {subject} in ghibli style"
+  clood sd paint --variations variants.json --parallel 3 --seed 42 "a {subject}, {style}"
+  clood sd paint "a lighthouse at dusk" --atc http://localhost:8080   # watch steps live`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if edit {
+				return nil
+			}
+			return cobra.MinimumNArgs(1)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			samplerName, schedulerName, err := sd.ResolveSampler(sampler, scheduler, cmd.Flags().Changed("scheduler"))
+			if err != nil {
+				return err
+			}
+
+			text := ""
+			var extra []string
+			if len(args) > 0 {
+				text, extra = args[0], args[1:]
+			}
+
+			client := newSDClient(comfyURL)
+			cns, err := resolveControlNets(client, controlNets, extra, controlNetStrength, controlNetModel)
+			if err != nil {
+				return err
+			}
+
+			var schedules []sd.LoRASchedule
+			for _, spec := range loras {
+				ls, err := sd.ParseLoRASchedule(spec)
+				if err != nil {
+					return err
+				}
+				schedules = append(schedules, ls)
+			}
+
+			if enhance && variationsPath != "" {
+				return fmt.Errorf("--enhance doesn't combine with --variations: the model would rewrite the {placeholders}")
+			}
+			if edit && (!enhance || output.DryRun) {
+				if text, err = editSDPrompt(text, "Prompt"); err != nil {
+					return err
+				}
+			}
+			if strings.TrimSpace(text) == "" {
+				return fmt.Errorf("a prompt is required")
+			}
+
+			prompt := sd.NewPrompt(text).WithSeed(seed)
+			if err := applyNegative(prompt, negative, negPreset); err != nil {
+				return err
+			}
+			if enhance && !output.DryRun {
+				enhanced, _, err := enhanceSDPrompt(text, enhanceModel)
+				if err != nil {
+					return fmt.Errorf("enhancing prompt: %w", err)
+				}
+				if edit {
+					if enhanced, err = editSDPrompt(enhanced, "Enhanced prompt (review before generating)"); err != nil {
+						return err
+					}
+				}
+				prompt.WithEnhanced(enhanced)
+				if !output.IsJSON() {
+					fmt.Printf("%s %s\n\n", tui.MutedStyle.Render("Enhanced:"), enhanced)
+				}
+			}
+
+			batch := sd.NewBatchConfig("paint", prompt)
+			batch.Description = text
+			batch.Parallel = parallel
+			batch.ControlNets = cns
+			batch.LoRASchedules = schedules
+			if outDir != "" {
+				batch.OutputDir = outDir
+			}
+
+			if variationsPath != "" {
+				variants, err := sd.LoadPromptVariants(variationsPath)
+				if err != nil {
+					return err
+				}
+				if batch.Variations, err = sd.PromptVariations(text, variants); err != nil {
+					return err
+				}
+			} else {
+				batch.Variations = []sd.Variation{{Name: "paint"}}
+			}
+			for i := range batch.Variations {
+				batch.Variations[i].Checkpoint = checkpoint
+				batch.Variations[i].Steps = &steps
+				batch.Variations[i].Sampler = samplerName
+				batch.Variations[i].Scheduler = schedulerName
+			}
+
+			if output.DryRun {
+				names := make([]string, len(batch.Variations))
+				for i, v := range batch.Variations {
+					names[i] = v.Name
+				}
+				return output.WouldHaveDone(fmt.Sprintf("paint %d image(s) on %s", len(batch.Variations), comfyURL), map[string]interface{}{
+					"prompt":      prompt.Positive,
+					"negative":    prompt.Negative,
+					"enhance":     enhance,
+					"checkpoint":  checkpoint,
+					"steps":       steps,
+					"seed":        seed,
+					"sampler":     samplerName,
+					"controlnets": len(cns),
+					"loras":       strings.Join(loras, " "),
+					"images":      strings.Join(names, ", "),
+					"output_dir":  batch.OutputDir,
+				})
+			}
+
+			if !output.IsJSON() {
+				fmt.Printf("%s %d image(s) on %s, %d at a time\n",
+					tui.MutedStyle.Render("Painting:"), len(batch.Variations), checkpoint, min(max(parallel, 1), len(batch.Variations)))
+				for _, cn := range cns {
+					fmt.Printf("%s %s %s via %s (strength %.2f)\n", tui.MutedStyle.Render("ControlNet:"), cn.Type, cn.ImagePath, cn.Model, cn.Strength)
+				}
+				for _, ls := range schedules {
+					if ls.Constant() {
+						fmt.Printf("%s %s (%.2f)\n", tui.MutedStyle.Render("LoRA:"), ls.Name, ls.Early)
+					} else {
+						fmt.Printf("%s %s (%.2f for the first %.0f%% of steps, then %.2f)\n", tui.MutedStyle.Render("LoRA:"), ls.Name, ls.Early, ls.Split*100, ls.Late)
+					}
+				}
+				fmt.Println()
+			}
+
+			stopProgress := streamSDProgress(client, atcURL, "paint")
+			result, err := sd.RunBatch(client, batch, func(vr sd.VariationResult) {
+				sdWebhookBatch("paint", vr)
+				if output.IsJSON() {
+					return
+				}
+				if vr.Success {
+					fmt.Printf("  %s %s %s\n", tui.SuccessStyle.Render("✓"), vr.OutputPath,
+						tui.MutedStyle.Render(fmt.Sprintf("(%.1fs)", vr.GenerateTime.Seconds())))
+				} else {
+					fmt.Printf("  %s %s: %s\n", tui.ErrorStyle.Render("✗"), vr.Variation.Name, vr.Error)
+				}
+			})
+			stopProgress()
+			if err != nil {
+				return err
+			}
+
+			var galleryPath string
+			if len(result.Results) > 1 {
+				gallery := sd.NewCompareGallery(result)
+				f, err := os.Create(gallery.OutputPath)
+				if err != nil {
+					return err
+				}
+				if err := gallery.Render(f); err != nil {
+					f.Close()
+					return err
+				}
+				if err := f.Close(); err != nil {
+					return err
+				}
+				galleryPath = gallery.OutputPath
+			}
+
+			if output.IsJSON() {
+				out := map[string]interface{}{
+					"results":    result.Results,
+					"output_dir": batch.OutputDir,
+					"gallery":    galleryPath,
+				}
+				if prompt.Original != "" {
+					out["prompt"] = prompt.Positive
+					out["original_prompt"] = prompt.Original
+				}
+				return output.JSON(out)
+			}
+
+			fmt.Println()
+			fmt.Println(tui.SuccessStyle.Render("✓ Saved to " + batch.OutputDir))
+			if galleryPath != "" {
+				fmt.Println(tui.SuccessStyle.Render("✓ Gallery: " + galleryPath))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&checkpoint, "checkpoint", "sd_xl_base_1.0.safetensors", "Checkpoint to load")
+	registerCompletion(cmd, "checkpoint", completeCheckpoints)
+	cmd.Flags().StringVar(&comfyURL, "url", sd.DefaultComfyURL, "ComfyUI server URL")
+	cmd.Flags().StringVarP(&outDir, "out", "o", "", "Output directory (default: outputs/batches/paint)")
+	cmd.Flags().StringVar(&negative, "negative", "", "Negative prompt (default: common artifacts)")
+	addNegPresetFlag(cmd, &negPreset)
+	cmd.Flags().StringVar(&variationsPath, "variations", "", "JSON file of placeholder values to expand the prompt with")
+	cmd.Flags().IntVar(&parallel, "parallel", 2, "Generations to run at once")
+	cmd.Flags().IntVar(&steps, "steps", 25, "Sampling steps")
+	cmd.Flags().Int64Var(&seed, "seed", -1, "Seed (-1 for random)")
+	cmd.Flags().StringArrayVar(&controlNets, "controlnet", nil, "ControlNet type and reference image: 'canny ./ref.png' or canny=./ref.png (repeatable)")
+	cmd.Flags().Float64Var(&controlNetStrength, "controlnet-strength", 1.0, "ControlNet strength (0-1)")
+	cmd.Flags().StringVar(&controlNetModel, "controlnet-model", "", "ControlNet model file (default: matched to the type from installed models)")
+	cmd.Flags().StringArrayVar(&loras, "lora", nil, "LoRA and weight, or a schedule: name:0.8 or name:early=1.0,late=0.3[,split=0.7] (repeatable)")
+	addSamplerFlags(cmd, &sampler, &scheduler)
+	cmd.Flags().StringVar(&atcURL, "atc", "", "ATC dashboard URL for live step progress (e.g., http://localhost:8080)")
+	cmd.Flags().BoolVar(&enhance, "enhance", false, "Expand the prompt with a local LLM before generating")
+	cmd.Flags().StringVar(&enhanceModel, "enhance-model", "", "Model for --enhance (default: writing tier)")
+	registerCompletion(cmd, "enhance-model", completeModelNames)
+	cmd.Flags().BoolVar(&edit, "edit", false, "Edit the prompt (or the --enhance result) in a full-screen editor first")
+
+	return withDryRun(cmd)
+}
+
+// resolveControlNets turns --controlnet values into configs. A bare type
+// ("--controlnet canny ./ref.png") takes its image from the positional
+// arguments after the prompt, in order. Models are matched against what
+// ComfyUI has installed unless --controlnet-model names one.
+func resolveControlNets(client *sd.Client, specs, images []string, strength float64, model string) ([]sd.ControlNetConfig, error) {
+	if len(specs) == 0 {
+		if len(images) > 0 {
+			return nil, fmt.Errorf("unexpected arguments after the prompt: %s", strings.Join(images, " "))
+		}
+		return nil, nil
+	}
+	if strength < 0 || strength > 1 {
+		return nil, fmt.Errorf("--controlnet-strength must be between 0 and 1")
+	}
+
+	var cns []sd.ControlNetConfig
+	for _, spec := range specs {
+		var cn sd.ControlNetConfig
+		if strings.Contains(spec, "=") {
+			var err error
+			if cn, err = sd.ParseControlNetSpec(spec); err != nil {
+				return nil, err
+			}
+		} else {
+			if len(images) == 0 {
+				return nil, fmt.Errorf("--controlnet %s needs a reference image", spec)
+			}
+			typ, err := sd.NormalizeControlNetType(spec)
+			if err != nil {
+				return nil, err
+			}
+			cn = sd.ControlNetConfig{Type: typ, ImagePath: images[0]}
+			images = images[1:]
+		}
+		if _, err := os.Stat(cn.ImagePath); err != nil {
+			return nil, fmt.Errorf("controlnet image: %w", err)
+		}
+		cn.Strength = strength
+		cns = append(cns, cn)
+	}
+	if len(images) > 0 {
+		return nil, fmt.Errorf("unexpected arguments after the prompt: %s", strings.Join(images, " "))
+	}
+
+	if model != "" {
+		for i := range cns {
+			cns[i].Model = model
+		}
+		return cns, nil
+	}
+
+	installed, err := client.Models(sd.InventoryFolders["controlnet"])
+	if err != nil {
+		return nil, fmt.Errorf("listing ControlNet models: %w", err)
+	}
+	for i := range cns {
+		if cns[i].Model, err = sd.MatchControlNetModel(cns[i].Type, installed); err != nil {
+			return nil, err
+		}
+	}
+	return cns, nil
+}
+
+func sdWarmupCmd() *cobra.Command {
+	var checkpoint string
+	var comfyURL string
+	var timeout time.Duration
+	var noWait bool
+
+	cmd := &cobra.Command{
+		Use:   "warmup",
+		Short: "Load a checkpoint into VRAM ahead of paint runs",
+		Long: `Runs a one-step, 64x64 workflow so ComfyUI loads the checkpoint and
+keeps it on the GPU. The next paint with the same --checkpoint starts
+sampling straight away instead of waiting on the model load.
+
+Nothing is queued when ComfyUI's queue or history shows the checkpoint
+was the last one used, since it's most likely still loaded.
+
+Examples:
+  clood sd warmup --checkpoint sdxl_base.safetensors
+  clood sd warmup --checkpoint juggernaut.safetensors --no-wait`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := newSDClient(comfyURL)
+			wait := timeout
+			if noWait {
+				wait = 0
+			}
+
+			start := time.Now()
+			warmed, err := client.Warmup(checkpoint, wait)
+			if err != nil {
+				return fmt.Errorf("warmup %s: %w", checkpoint, err)
+			}
+
+			if output.IsJSON() {
+				return output.JSON(map[string]interface{}{
+					"checkpoint":     checkpoint,
+					"already_loaded": !warmed,
+					"waited":         warmed && !noWait,
+					"elapsed_sec":    time.Since(start).Seconds(),
+				})
+			}
+
+			switch {
+			case !warmed:
+				fmt.Printf("%s %s is already loaded\n", tui.SuccessStyle.Render("✓"), checkpoint)
+			case noWait:
+				fmt.Printf("%s Queued warmup for %s\n", tui.SuccessStyle.Render("✓"), checkpoint)
+			default:
+				fmt.Printf("%s %s loaded in %.1fs\n", tui.SuccessStyle.Render("✓"), checkpoint, time.Since(start).Seconds())
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&checkpoint, "checkpoint", "sd_xl_base_1.0.safetensors", "Checkpoint to load")
+	registerCompletion(cmd, "checkpoint", completeCheckpoints)
+	cmd.Flags().StringVar(&comfyURL, "url", sd.DefaultComfyURL, "ComfyUI server URL")
+	cmd.Flags().DurationVar(&timeout, "timeout", 5*time.Minute, "How long to wait for the load")
+	cmd.Flags().BoolVar(&noWait, "no-wait", false, "Queue the warmup and return without waiting")
+
+	return cmd
+}
+
+func sdVideoCmd() *cobra.Command {
+	var prompt string
+	var negative string
+	var negPreset string
+	var checkpoint string
+	var motionModel string
+	var comfyURL string
+	var outputPath string
+	var frames int
+	var fps int
+	var steps int
+	var width int
+	var height int
+	var seed int64
+	var sampler string
+	var scheduler string
+	var timeout time.Duration
+	var keepFrames string
+
+	cmd := &cobra.Command{
+		Use:   "video",
+		Short: "Generate a short video clip with AnimateDiff",
+		Long: `Samples --frames latents together through an AnimateDiff motion model,
+with VideoLinearCFGGuidance ramping CFG across the clip, then downloads
+the frames from ComfyUI and stitches them into an MP4 with ffmpeg.
+
+Needs the ComfyUI-AnimateDiff-Evolved nodes, a motion model in their
+models directory, and ffmpeg on PATH. Motion models are trained against
+SD1.5, so use an SD1.5 checkpoint at 512px.
+
+Examples:
+  clood sd video --prompt "a tortoise walking" --frames 16 --fps 8 --output tortoise.mp4
+  clood sd video --prompt "waves on a beach" --seed 42 --keep-frames ./frames`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if prompt == "" {
+				return fmt.Errorf("--prompt is required")
+			}
+			if frames < 2 || frames > sd.MaxVideoFrames {
+				return fmt.Errorf("--frames must be between 2 and %d", sd.MaxVideoFrames)
+			}
+			if fps <= 0 {
+				return fmt.Errorf("--fps must be positive")
+			}
+			ffmpeg, err := sd.FindFFmpeg()
+			if err != nil {
+				return err
+			}
+			samplerName, schedulerName, err := sd.ResolveSampler(sampler, scheduler, cmd.Flags().Changed("scheduler"))
+			if err != nil {
+				return err
+			}
+
+			p := sd.NewPrompt(prompt).WithSeed(seed)
+			if err := applyNegative(p, negative, negPreset); err != nil {
+				return err
+			}
+
+			cfg := sd.DefaultWorkflowConfig()
+			cfg.Prompt = p
+			cfg.Checkpoint = checkpoint
+			cfg.Steps = steps
+			cfg.Width = width
+			cfg.Height = height
+			cfg.Sampler = samplerName
+			cfg.Scheduler = schedulerName
+			cfg.OutputPrefix = "clood_video"
+			cfg.VideoMode = true
+			cfg.Frames = frames
+			cfg.FPS = fps
+			cfg.MotionModel = motionModel
+
+			if output.DryRun {
+				return output.WouldHaveDone(fmt.Sprintf("generate a %d-frame video on %s", frames, comfyURL), map[string]interface{}{
+					"prompt":       p.Positive,
+					"negative":     p.Negative,
+					"checkpoint":   checkpoint,
+					"motion_model": motionModel,
+					"size":         fmt.Sprintf("%dx%d", width, height),
+					"fps":          fps,
+					"steps":        steps,
+					"seed":         seed,
+					"output":       outputPath,
+				})
+			}
+
+			if !output.IsJSON() {
+				fmt.Printf("%s %d frames at %dx%d on %s + %s\n",
+					tui.MutedStyle.Render("Video:"), frames, width, height, checkpoint, motionModel)
+			}
+
+			client := newSDClient(comfyURL)
+			start := time.Now()
+			done := func(err error) error {
+				return sdWebhookDone("video", cfg.Prompt.Positive, checkpoint, start, outputPath, err)
+			}
+			promptID, err := client.Generate(cfg)
+			if err != nil {
+				return done(err)
+			}
+			images, err := client.Wait(promptID, timeout)
+			if err != nil {
+				return done(err)
+			}
+			if len(images) == 0 {
+				return done(fmt.Errorf("prompt %s finished without any frames", promptID))
+			}
+
+			frameDir := keepFrames
+			if frameDir == "" {
+				if frameDir, err = os.MkdirTemp("", "clood-video-"); err != nil {
+					return done(err)
+				}
+				defer os.RemoveAll(frameDir)
+			} else if err := os.MkdirAll(frameDir, 0755); err != nil {
+				return done(err)
+			}
+
+			if _, err := client.DownloadFrames(sd.SortFrames(images), frameDir); err != nil {
+				return done(err)
+			}
+			if err := sd.StitchFrames(ffmpeg, frameDir, fps, outputPath); err != nil {
+				return done(err)
+			}
+			done(nil)
+
+			if output.IsJSON() {
+				result := map[string]interface{}{
+					"prompt_id":   promptID,
+					"output":      outputPath,
+					"frames":      len(images),
+					"fps":         fps,
+					"elapsed_sec": time.Since(start).Seconds(),
+				}
+				if keepFrames != "" {
+					result["frames_dir"] = keepFrames
+				}
+				return output.JSON(result)
+			}
+
+			fmt.Printf("%s %s %s\n", tui.SuccessStyle.Render("✓"), outputPath,
+				tui.MutedStyle.Render(fmt.Sprintf("(%d frames, %.1fs at %d fps, made in %.1fs)",
+					len(images), float64(len(images))/float64(fps), fps, time.Since(start).Seconds())))
+			if keepFrames != "" {
+				fmt.Println(tui.MutedStyle.Render("  Frames kept in " + keepFrames))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&prompt, "prompt", "", "What the clip shows")
+	cmd.Flags().StringVar(&negative, "negative", "", "Negative prompt (default: common artifacts)")
+	addNegPresetFlag(cmd, &negPreset)
+	cmd.Flags().StringVar(&checkpoint, "checkpoint", "v1-5-pruned-emaonly.safetensors", "SD1.5 checkpoint to load")
+	registerCompletion(cmd, "checkpoint", completeCheckpoints)
+	cmd.Flags().StringVar(&motionModel, "motion-model", sd.DefaultMotionModel, "AnimateDiff motion model")
+	cmd.Flags().StringVar(&comfyURL, "url", sd.DefaultComfyURL, "ComfyUI server URL")
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "video.mp4", "MP4 file to write")
+	cmd.Flags().IntVar(&frames, "frames", sd.DefaultVideoFrames, "Frames to generate")
+	cmd.Flags().IntVar(&fps, "fps", sd.DefaultVideoFPS, "Playback frame rate")
+	cmd.Flags().IntVar(&steps, "steps", 20, "Sampling steps")
+	cmd.Flags().IntVar(&width, "width", 512, "Frame width")
+	cmd.Flags().IntVar(&height, "height", 512, "Frame height")
+	cmd.Flags().Int64Var(&seed, "seed", -1, "Seed (-1 for random)")
+	addSamplerFlags(cmd, &sampler, &scheduler)
+	cmd.Flags().DurationVar(&timeout, "timeout", 15*time.Minute, "How long to wait for the frames")
+	cmd.Flags().StringVar(&keepFrames, "keep-frames", "", "Directory to keep the PNG frames in (default: discarded)")
+
+	return withDryRun(cmd)
+}
+
+func sdUpscaleCmd() *cobra.Command {
+	var inputPath string
+	var scale float64
+	var model string
+	var noDetail bool
+	var checkpoint string
+	var prompt string
+	var negative string
+	var negPreset string
+	var denoise float64
+	var steps int
+	var seed int64
+	var sampler string
+	var scheduler string
+	var comfyURL string
+	var outputPath string
+	var timeout time.Duration
+	var listModels bool
+
+	cmd := &cobra.Command{
+		Use:   "upscale",
+		Short: "Upscale an image with an upscale model and add detail",
+		Long: `Enlarges an image with an ESRGAN-style upscale model, resizes it to
+exactly --scale, then redraws it tile by tile with UltimateSDUpscale at a
+low denoise so the extra pixels carry real detail.
+
+--model picks from the upscale models ComfyUI reports in /object_info
+(see --list-models); without it, one whose native factor matches --scale
+is used. --no-detail skips the redraw and needs no checkpoint or the
+ComfyUI_UltimateSDUpscale nodes.
+
+Examples:
+  clood sd upscale --input output.png --scale 4 --model 4xUltraSharp.pth
+  clood sd upscale --input sketch.png --scale 2 --prompt "oil painting, rich brushwork"
+  clood sd upscale --input photo.jpg --scale 2 --no-detail
+  clood sd upscale --list-models`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := newSDClient(comfyURL)
+			models, err := client.UpscaleModels()
+			if err != nil {
+				return fmt.Errorf("listing upscale models: %w", err)
+			}
+
+			if listModels {
+				if output.IsJSON() {
+					return output.JSON(map[string]interface{}{"upscale_models": models})
+				}
+				if len(models) == 0 {
+					fmt.Println(tui.MutedStyle.Render("No upscale models in ComfyUI's models/upscale_models"))
+					return nil
+				}
+				for _, m := range models {
+					factor := ""
+					if f := sd.UpscaleModelFactor(m); f > 0 {
+						factor = tui.MutedStyle.Render(fmt.Sprintf(" (%dx)", f))
+					}
+					fmt.Println("  " + m + factor)
+				}
+				return nil
+			}
+
+			if inputPath == "" {
+				return fmt.Errorf("--input is required")
+			}
+			width, height, err := sd.ImageSize(inputPath)
+			if err != nil {
+				return err
+			}
+			upscaleModel, err := sd.PickUpscaleModel(models, model, scale)
+			if err != nil {
+				return err
+			}
+			samplerName, schedulerName, err := sd.ResolveSampler(sampler, scheduler, cmd.Flags().Changed("scheduler"))
+			if err != nil {
+				return err
+			}
+
+			p := sd.NewPrompt(prompt).WithSeed(seed)
+			if err := applyNegative(p, negative, negPreset); err != nil {
+				return err
+			}
+			cfg := &sd.UpscaleConfig{
+				InputImagePath: inputPath,
+				Width:          width,
+				Height:         height,
+				Scale:          scale,
+				Model:          upscaleModel,
+				Detail:         !noDetail,
+				Checkpoint:     checkpoint,
+				Prompt:         p,
+				Steps:          steps,
+				CFGScale:       7.0,
+				Sampler:        samplerName,
+				Scheduler:      schedulerName,
+				Denoise:        denoise,
+				OutputPrefix:   "clood_upscale",
+			}
+			outW, outH := cfg.TargetSize()
+
+			if outputPath == "" {
+				ext := filepath.Ext(inputPath)
+				outputPath = strings.TrimSuffix(inputPath, ext) + fmt.Sprintf("_x%g.png", scale)
+			}
+
+			if output.DryRun {
+				params := map[string]interface{}{
+					"input":  inputPath,
+					"size":   fmt.Sprintf("%dx%d -> %dx%d", width, height, outW, outH),
+					"model":  upscaleModel,
+					"detail": cfg.Detail,
+					"output": outputPath,
+				}
+				if cfg.Detail {
+					params["checkpoint"] = checkpoint
+					params["denoise"] = denoise
+					params["prompt"] = p.Positive
+				}
+				return output.WouldHaveDone(fmt.Sprintf("upscale %s %gx on %s", inputPath, scale, comfyURL), params)
+			}
+
+			if !output.IsJSON() {
+				detail := "no detail pass"
+				if cfg.Detail {
+					detail = fmt.Sprintf("detail via %s at denoise %.2f", checkpoint, denoise)
+				}
+				fmt.Printf("%s %dx%d -> %dx%d with %s, %s\n",
+					tui.MutedStyle.Render("Upscale:"), width, height, outW, outH, upscaleModel, detail)
+			}
+
+			start := time.Now()
+			done := func(err error) error {
+				used := upscaleModel
+				if cfg.Detail {
+					used = checkpoint
+				}
+				return sdWebhookDone("upscale", p.Positive, used, start, outputPath, err)
+			}
+			promptID, err := client.Upscale(cfg)
+			if err != nil {
+				return done(err)
+			}
+			images, err := client.Wait(promptID, timeout)
+			if err != nil {
+				return done(err)
+			}
+			if len(images) == 0 {
+				return done(fmt.Errorf("prompt %s finished without an image", promptID))
+			}
+			if err := client.Download(images[0], outputPath); err != nil {
+				return done(err)
+			}
+			done(nil)
+
+			if output.IsJSON() {
+				return output.JSON(map[string]interface{}{
+					"prompt_id":     promptID,
+					"input":         inputPath,
+					"output":        outputPath,
+					"upscale_model": upscaleModel,
+					"width":         outW,
+					"height":        outH,
+					"detail":        cfg.Detail,
+					"elapsed_sec":   time.Since(start).Seconds(),
+				})
+			}
+
+			fmt.Printf("%s %s %s\n", tui.SuccessStyle.Render("✓"), outputPath,
+				tui.MutedStyle.Render(fmt.Sprintf("(%dx%d, %.1fs)", outW, outH, time.Since(start).Seconds())))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&inputPath, "input", "", "Image to upscale (PNG or JPEG)")
+	cmd.Flags().Float64Var(&scale, "scale", 2, "Output size relative to the input")
+	cmd.Flags().StringVar(&model, "model", "", "Upscale model file (default: one matching --scale)")
+	cmd.Flags().BoolVar(&noDetail, "no-detail", false, "Only run the upscale model, without the UltimateSDUpscale redraw")
+	cmd.Flags().StringVar(&checkpoint, "checkpoint", "sd_xl_base_1.0.safetensors", "Checkpoint for the detail pass")
+	registerCompletion(cmd, "checkpoint", completeCheckpoints)
+	cmd.Flags().StringVar(&prompt, "prompt", "high quality, sharp focus, fine detail", "Prompt for the detail pass")
+	cmd.Flags().StringVar(&negative, "negative", "", "Negative prompt (default: common artifacts)")
+	addNegPresetFlag(cmd, &negPreset)
+	cmd.Flags().Float64Var(&denoise, "denoise", sd.DefaultUpscaleDenoise, "How much the detail pass may change (0-1)")
+	cmd.Flags().IntVar(&steps, "steps", 20, "Sampling steps per tile")
+	cmd.Flags().Int64Var(&seed, "seed", -1, "Seed (-1 for random)")
+	addSamplerFlags(cmd, &sampler, &scheduler)
+	cmd.Flags().StringVar(&comfyURL, "url", sd.DefaultComfyURL, "ComfyUI server URL")
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "File to save (default: <input>_x<scale>.png)")
+	cmd.Flags().DurationVar(&timeout, "timeout", 10*time.Minute, "How long to wait for the result")
+	cmd.Flags().BoolVar(&listModels, "list-models", false, "List the upscale models ComfyUI has and exit")
+
+	return withDryRun(cmd)
+}
+
+func sdRemixCmd() *cobra.Command {
+	var checkpoint string
+	var comfyURL string
+	var extra string
+	var seed int64
+	var sampler string
+	var scheduler string
+	var dryRun bool
+	var compare bool
+	var outDir string
+
+	cmd := &cobra.Command{
+		Use:   "remix <civitai-url|image.png>",
+		Short: "Re-run a CivitAI or A1111 image's prompt and settings locally",
+		Long: `Fetches an image's generation metadata (prompt, negative, model, seed,
+steps, CFG, sampler, size) from the CivitAI API and queues the same
+generation on ComfyUI. Public images need no API key.
+
+A local PNG saved by AUTOMATIC1111 or Forge works too: the settings are read
+from its embedded "parameters" text, and <lora:name:weight> tags in the
+prompt are applied as LoRAs.
+
+The image's model name is used as the checkpoint unless --checkpoint is
+given. A1111 sampler names are mapped to ComfyUI's sampler and scheduler.
+
+With --compare, remix waits for the image, downloads it alongside the
+source image and writes compare.html: the two side by side, a fidelity
+score (how close colour and layout are), and the settings with any that
+differ from the source highlighted.
+
+Examples:
+  clood sd remix https://civitai.com/images/1234567
+  clood sd remix https://civitai.com/images/1234567 --checkpoint sd_xl_base_1.0.safetensors
+  clood sd remix https://civitai.com/images/1234567 --add "watercolor" --seed -1
+  clood sd remix https://civitai.com/images/1234567 --dry-run   # print metadata and workflow
+  clood sd remix https://civitai.com/images/1234567 --compare --out ./remix
+  clood sd remix ./00042-1234567890.png --checkpoint dreamshaper_8.safetensors`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			src, err := sd.NewMultiSourceParser().Parse(args[0])
+			if err != nil {
+				return err
+			}
+
+			cfg := src.WorkflowConfig(checkpoint)
+			if extra != "" {
+				cfg.Prompt.Positive += ", " + extra
+			}
+			if cmd.Flags().Changed("seed") {
+				cfg.Prompt.Seed = seed
+			}
+			if cmd.Flags().Changed("sampler") || cmd.Flags().Changed("scheduler") {
+				if !cmd.Flags().Changed("sampler") {
+					sampler = cfg.Sampler
+				}
+				if !cmd.Flags().Changed("scheduler") {
+					scheduler = cfg.Scheduler
+				}
+				cfg.Sampler, cfg.Scheduler, err = sd.ResolveSampler(sampler, scheduler, cmd.Flags().Changed("scheduler"))
+				if err != nil {
+					return err
+				}
+			}
+			if cfg.Checkpoint == "" {
+				return fmt.Errorf("image has no model name; pass --checkpoint")
+			}
+
+			if dryRun {
+				workflow, err := sd.BuildBasicWorkflow(cfg)
+				if err != nil {
+					return err
+				}
+				if output.IsJSON() {
+					return output.JSON(map[string]interface{}{
+						"source":   src,
+						"workflow": workflow,
+					})
+				}
+				data, err := workflow.ToJSON()
+				if err != nil {
+					return err
+				}
+				printImageSource(src)
+				fmt.Println()
+				fmt.Println(string(data))
+				return nil
+			}
+
+			client := newSDClient(comfyURL)
+			promptID, err := client.Generate(cfg)
+			if err != nil {
+				return err
+			}
+
+			if compare {
+				if outDir == "" {
+					outDir = "remix-" + src.ID
+				}
+				return compareRemix(client, src, cfg, promptID, outDir)
+			}
+
+			if output.IsJSON() {
+				return output.JSON(map[string]interface{}{
+					"prompt_id":  promptID,
+					"source":     src,
+					"checkpoint": cfg.Checkpoint,
+				})
+			}
+
+			printImageSource(src)
+			fmt.Println()
+			fmt.Printf("%s Queued remix %s on %s\n", tui.SuccessStyle.Render("✓"), promptID, cfg.Checkpoint)
+			fmt.Println(tui.MutedStyle.Render("  Output lands in ComfyUI's output directory with prefix '" + cfg.OutputPrefix + "'"))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&checkpoint, "checkpoint", "", "Checkpoint to use instead of the image's model")
+	registerCompletion(cmd, "checkpoint", completeCheckpoints)
+	cmd.Flags().StringVar(&comfyURL, "url", sd.DefaultComfyURL, "ComfyUI server URL")
+	cmd.Flags().StringVar(&extra, "add", "", "Extra terms to append to the prompt")
+	cmd.Flags().Int64Var(&seed, "seed", -1, "Override the image's seed (-1 for random)")
+	cmd.Flags().StringVar(&sampler, "sampler", "", "Override the image's sampler (e.g. euler_a, dpm++2m_karras)")
+	cmd.Flags().StringVar(&scheduler, "scheduler", "", "Override the image's scheduler (normal, karras, ...)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the metadata and workflow instead of submitting")
+	cmd.Flags().BoolVar(&compare, "compare", false, "Wait for the image and write a side-by-side compare.html with the source")
+	cmd.Flags().StringVar(&outDir, "out", "", "Directory for --compare output (default: remix-<image id>)")
+
+	return cmd
+}
+
+// remixCompareTimeout bounds the wait for a --compare remix to render
+const remixCompareTimeout = 5 * time.Minute
+
+// compareRemix waits for a queued remix, downloads it and the source image
+// into outDir, and writes the source vs. reproduction page. A source image
+// that can't be fetched or decoded only costs the left panel and the score.
+func compareRemix(client *sd.Client, src *sd.ImageSource, cfg *sd.WorkflowConfig, promptID, outDir string) error {
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return err
+	}
+	if !output.IsJSON() {
+		printImageSource(src)
+		fmt.Println()
+		fmt.Printf("%s Rendering remix %s on %s...\n", tui.MutedStyle.Render("⏳"), promptID, cfg.Checkpoint)
+	}
+
+	images, err := client.Wait(promptID, remixCompareTimeout)
+	if err != nil {
+		return err
+	}
+	if len(images) == 0 {
+		return fmt.Errorf("prompt %s produced no images", promptID)
+	}
+	rc := &sd.RemixComparison{
+		Source:       src,
+		Config:       cfg,
+		Reproduction: "reproduction" + filepath.Ext(images[0].Filename),
+		Fidelity:     -1,
+	}
+	if err := client.Download(images[0], filepath.Join(outDir, rc.Reproduction)); err != nil {
+		return err
+	}
+
+	sourceName := "source" + src.SourceImageExt()
+	if err := src.DownloadImage(client.HTTPClient, filepath.Join(outDir, sourceName)); err != nil {
+		fmt.Fprintln(os.Stderr, tui.WarningStyle.Render("⚠ Source image unavailable: "+err.Error()))
+	} else {
+		rc.SourceImage = sourceName
+		a, errA := decodeImageFile(filepath.Join(outDir, sourceName))
+		b, errB := decodeImageFile(filepath.Join(outDir, rc.Reproduction))
+		if errA == nil && errB == nil {
+			rc.Fidelity = sd.ImageFidelity(a, b)
+		} else if !output.IsJSON() {
+			fmt.Fprintln(os.Stderr, tui.WarningStyle.Render("⚠ Couldn't decode both images to score fidelity"))
+		}
+	}
+
+	gallery := sd.NewRemixGallery(rc, outDir)
+	f, err := os.Create(gallery.OutputPath)
+	if err != nil {
+		return err
+	}
+	if err := gallery.Render(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	if output.IsJSON() {
+		result := map[string]interface{}{
+			"prompt_id":    promptID,
+			"source":       src,
+			"checkpoint":   cfg.Checkpoint,
+			"reproduction": filepath.Join(outDir, rc.Reproduction),
+			"gallery":      gallery.OutputPath,
+		}
+		if rc.Fidelity >= 0 {
+			result["fidelity"] = rc.Fidelity
+		}
+		return output.JSON(result)
+	}
+
+	fmt.Println(tui.SuccessStyle.Render("✓ Reproduction: " + filepath.Join(outDir, rc.Reproduction)))
+	if rc.Fidelity >= 0 {
+		fmt.Printf("%s %.0f%%\n", tui.MutedStyle.Render("  Fidelity:"), rc.Fidelity*100)
+	}
+	fmt.Println(tui.SuccessStyle.Render("✓ Comparison:   " + gallery.OutputPath))
+	return nil
+}
+
+func decodeImageFile(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	img, _, err := image.Decode(f)
+	return img, err
+}
+
+func sdDeconstructCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deconstruct <prompt|civitai-url>",
+		Short: "Show the attention weights in a prompt",
+		Long: `Parses a prompt's attention syntax and lists the emphasis ComfyUI will
+apply: (text:1.3) sets a weight, (text) multiplies by 1.1 per pair of
+parentheses, and \( \) are literal. Prompts are always sent to ComfyUI
+unchanged; this just shows how they'll be read.
+
+Given a CivitAI image URL, its prompt and negative prompt are fetched and
+both are broken down.
+
+Examples:
+  clood sd deconstruct "(tortoise:1.5), wearing (spectacles:0.8), ((ghibli style))"
+  clood sd deconstruct https://civitai.com/images/1234567`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			prompt, negative := args[0], ""
+			var src *sd.ImageSource
+			if _, ok := sd.CivitAIImageID(args[0]); ok {
+				var err error
+				if src, err = sd.NewMultiSourceParser().Parse(args[0]); err != nil {
+					return err
+				}
+				prompt, negative = src.Prompt, src.Negative
+			}
+
+			if output.IsJSON() {
+				result := map[string]interface{}{
+					"prompt":  prompt,
+					"weights": sd.AttentionTerms(prompt),
+				}
+				if src != nil {
+					result["source"] = src.URL
+					result["negative"] = negative
+					result["negative_weights"] = sd.AttentionTerms(negative)
+				}
+				return output.JSON(result)
+			}
+
+			if src != nil {
+				fmt.Println(tui.RenderHeader("Source: " + src.URL))
+			}
+			printAttention("Prompt", prompt)
+			if negative != "" {
+				fmt.Println()
+				printAttention("Negative", negative)
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// printAttention lists a prompt's weighted terms in the order they
+// appear, with ▲ for emphasis and ▼ for de-emphasis
+func printAttention(label, prompt string) {
+	fmt.Printf("%s %s\n", tui.MutedStyle.Render(label+":"), prompt)
+	terms := sd.AttentionTerms(prompt)
+	if len(terms) == 0 {
+		fmt.Println(tui.MutedStyle.Render("  No attention weights (everything at 1.0)"))
+		return
+	}
+
+	width := 0
+	for _, t := range terms {
+		width = max(width, len(t.Text))
+	}
+	for _, t := range terms {
+		marker := tui.SuccessStyle.Render("▲")
+		if t.Weight < 1 {
+			marker = tui.WarningStyle.Render("▼")
+		}
+		how := ""
+		if !t.Explicit {
+			how = tui.MutedStyle.Render("  (from parentheses)")
+		}
+		fmt.Printf("  %s %-*s %5.2f%s\n", marker, width, t.Text, t.Weight, how)
+	}
+}
+
+func printImageSource(src *sd.ImageSource) {
+	fmt.Println(tui.RenderHeader("Source: " + src.URL))
+	fmt.Printf("  Prompt:   %s\n", src.Prompt)
+	if src.Negative != "" {
+		fmt.Printf("  Negative: %s\n", src.Negative)
+	}
+	fmt.Printf("  Model:    %s\n", src.Model)
+	fmt.Printf("  Seed:     %d  Steps: %d  CFG: %.1f  Sampler: %s\n", src.Seed, src.Steps, src.CFGScale, src.Sampler)
+	if src.Width > 0 {
+		fmt.Printf("  Size:     %dx%d\n", src.Width, src.Height)
+	}
+	for _, l := range src.LoRAs {
+		fmt.Printf("  LoRA:     %s (%.2f)\n", l.Name, l.Weight)
+	}
+}
+
+func sdInventoryCmd() *cobra.Command {
+	var comfyURL string
+	var wishlistPath string
+
+	cmd := &cobra.Command{
+		Use:   "inventory",
+		Short: "List local models, or diff them against a CivitAI wishlist",
+		Long: `Lists the checkpoints, LoRAs, VAEs, embeddings, ControlNets and upscalers
+ComfyUI can load.
+
+With --wishlist, resolves each CivitAI model ID in the file and reports which
+ones are missing locally, with their download URLs. The wishlist is JSON:
+
+  [{"id": 133005, "type": "checkpoint"}, {"id": 58390, "type": "lora"}]
+
+Matching is fuzzy: version and precision suffixes are stripped, so
+juggernautXL_v9Rdphoto2Lightning.safetensors on CivitAI matches a local
+juggernautXL_v10.safetensors.
+
+Examples:
+  clood sd inventory
+  clood sd inventory --wishlist civitai-wishlist.json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var items []sd.WishlistItem
+			if wishlistPath != "" {
+				var err error
+				if items, err = sd.LoadWishlist(wishlistPath); err != nil {
+					return err
+				}
+			}
+
+			inv, err := newSDClient(comfyURL).Inventory()
+			if err != nil {
+				return err
+			}
+
+			if wishlistPath == "" {
+				if output.IsJSON() {
+					return output.JSON(inv)
+				}
+				printInventory(inv)
+				return nil
+			}
+
+			entries := sd.DiffWishlist(items, inv, sd.NewMultiSourceParser().CivitAIModel)
+			if output.IsJSON() {
+				return output.JSON(entries)
+			}
+			printWishlistDiff(entries)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&comfyURL, "url", sd.DefaultComfyURL, "ComfyUI server URL")
+	cmd.Flags().StringVar(&wishlistPath, "wishlist", "", "JSON file of CivitAI model IDs to check for")
+
+	return cmd
+}
+
+func printInventory(inv sd.Inventory) {
+	var types []string
+	for typ := range inv {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+
+	fmt.Println(tui.RenderHeader("Model Inventory"))
+	for _, typ := range types {
+		fmt.Println()
+		fmt.Printf("  %s %s\n", tui.HeaderStyle.Render(typ), tui.MutedStyle.Render(fmt.Sprintf("(%d)", len(inv[typ]))))
+		for _, f := range inv[typ] {
+			fmt.Printf("    %s\n", f)
+		}
+	}
+}
+
+func printWishlistDiff(entries []sd.WishlistEntry) {
+	missing := 0
+	fmt.Println(tui.RenderHeader("Wishlist"))
+	fmt.Println()
+	for _, e := range entries {
+		switch {
+		case e.Error != "":
+			fmt.Printf("  %s %d: %s\n", tui.WarningStyle.Render("?"), e.ID, e.Error)
+		case e.Missing:
+			missing++
+			fmt.Printf("  %s %s %s\n", tui.ErrorStyle.Render("✗"), e.Model.Name, tui.MutedStyle.Render(e.Model.FileName))
+			if e.Model.DownloadURL != "" {
+				fmt.Printf("      %s\n", e.Model.DownloadURL)
+			}
+		default:
+			fmt.Printf("  %s %s %s\n", tui.SuccessStyle.Render("✓"), e.Model.Name, tui.MutedStyle.Render("→ "+e.LocalFile))
+		}
+	}
+	fmt.Println()
+	fmt.Printf("  %d of %d missing\n", missing, len(entries))
+}
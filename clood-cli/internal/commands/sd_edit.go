@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dirtybirdnj/clood/internal/tui"
+)
+
+// editSDPrompt opens a full-screen editor holding prompt and returns what
+// was submitted. Most terminals send ctrl+enter as ctrl+j, or not at all,
+// so ctrl+s submits too.
+func editSDPrompt(prompt, title string) (string, error) {
+	if stat, err := os.Stdin.Stat(); err != nil || stat.Mode()&os.ModeCharDevice == 0 {
+		return "", fmt.Errorf("--edit needs a terminal")
+	}
+
+	ta := textarea.New()
+	ta.Placeholder = "Describe the image..."
+	ta.ShowLineNumbers = false
+	ta.CharLimit = 0
+	ta.SetValue(prompt)
+	ta.Focus()
+
+	final, err := tea.NewProgram(promptEditorModel{textarea: ta, title: title}, tea.WithAltScreen()).Run()
+	if err != nil {
+		return "", err
+	}
+	m := final.(promptEditorModel)
+	if !m.submitted {
+		return "", fmt.Errorf("prompt editing cancelled")
+	}
+	edited := strings.TrimSpace(m.textarea.Value())
+	if edited == "" {
+		return "", fmt.Errorf("the edited prompt is empty")
+	}
+	return edited, nil
+}
+
+// promptEditorModel is the bubbletea model for 'sd paint --edit'
+type promptEditorModel struct {
+	textarea  textarea.Model
+	title     string
+	submitted bool
+}
+
+func (m promptEditorModel) Init() tea.Cmd {
+	return textarea.Blink
+}
+
+func (m promptEditorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.textarea.SetWidth(msg.Width - 2)
+		m.textarea.SetHeight(max(msg.Height-5, 3))
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+j", "ctrl+s":
+			m.submitted = true
+			return m, tea.Quit
+		case "esc", "ctrl+c":
+			return m, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	m.textarea, cmd = m.textarea.Update(msg)
+	return m, cmd
+}
+
+func (m promptEditorModel) View() string {
+	return fmt.Sprintf("%s\n\n%s\n\n%s",
+		tui.RenderHeader(m.title),
+		m.textarea.View(),
+		tui.MutedStyle.Render("ctrl+enter/ctrl+s generate · enter newline · ctrl+v paste · esc cancel"))
+}
@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dirtybirdnj/clood/internal/config"
+	"github.com/dirtybirdnj/clood/internal/output"
+	"github.com/dirtybirdnj/clood/internal/router"
+	"github.com/dirtybirdnj/clood/internal/sd"
+	"github.com/dirtybirdnj/clood/internal/tui"
+)
+
+// enhanceSDPrompt has the writing tier expand a short prompt into a
+// detailed one, returning it and the model that wrote it
+func enhanceSDPrompt(short, model string) (string, string, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return "", "", fmt.Errorf("loading config: %w", err)
+	}
+	r := router.NewRouter(cfg)
+	route, err := r.Route("enhance sd prompt", router.TierWriting, model)
+	if err != nil {
+		return "", "", fmt.Errorf("routing: %w", err)
+	}
+	if route.Client == nil {
+		return "", "", fmt.Errorf("no available host with model %s", route.Model)
+	}
+
+	if !output.IsJSON() {
+		fmt.Fprintf(os.Stderr, "%s %s on %s\n", tui.MutedStyle.Render("Enhancing:"), route.Model, route.Host.Host.Name)
+	}
+	resp, err := route.Client.GenerateWithSystem(route.Model, sd.EnhanceSystemPrompt, short)
+	if err != nil {
+		return "", "", err
+	}
+	enhanced := sd.CleanEnhancedPrompt(resp.Response)
+	if enhanced == "" {
+		return "", "", fmt.Errorf("%s returned an empty prompt", route.Model)
+	}
+	return enhanced, route.Model, nil
+}
@@ -0,0 +1,425 @@
+package commands
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dirtybirdnj/clood/internal/output"
+	"github.com/dirtybirdnj/clood/internal/sd"
+	"github.com/dirtybirdnj/clood/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+func sdGalleryCmd() *cobra.Command {
+	var useTUI bool
+	var limit int
+	var tag string
+
+	cmd := &cobra.Command{
+		Use:   "gallery [dir]",
+		Short: "Browse generated images and how they were made",
+		Long: `Lists the images under dir (default: outputs), newest first, with the
+checkpoint, prompt and dimensions each was generated with.
+
+Settings come from the .json sidecar clood writes next to every image it
+generates, or for other ComfyUI images from the workflow embedded in the
+PNG.
+
+--tui opens an interactive browser: arrow keys (or j/k) move, enter opens
+the image in the system viewer, q quits.
+
+Tag images with 'sd gallery tag' and list just one tag with --tag;
+'sd gallery search' finds images by prompt, checkpoint, LoRA or tag.
+
+Examples:
+  clood sd gallery
+  clood sd gallery outputs/batches/paint --tui
+  clood sd gallery tag ./image.png "ghibli,character"
+  clood sd gallery --tag ghibli
+  clood sd gallery search "tortoise"`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := "outputs"
+			if len(args) > 0 {
+				dir = args[0]
+			}
+
+			images, err := sd.LoadGallery(dir)
+			if err != nil {
+				return err
+			}
+			empty := "No images in " + dir
+			if tag != "" {
+				images = sd.FilterByTag(images, tag)
+				empty = fmt.Sprintf("No images tagged %q in %s", tag, dir)
+			}
+
+			if output.IsJSON() {
+				return output.JSON(map[string]interface{}{"dir": dir, "images": images, "count": len(images)})
+			}
+			if len(images) == 0 {
+				fmt.Println(tui.MutedStyle.Render(empty))
+				return nil
+			}
+
+			if useTUI {
+				_, err := tea.NewProgram(newGalleryModel(dir, images), tea.WithAltScreen()).Run()
+				return err
+			}
+
+			title := "Gallery: " + dir
+			if tag != "" {
+				title += " #" + strings.ToLower(tag)
+			}
+			printGallery(title, dir, images, limit)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&useTUI, "tui", false, "Browse interactively and open images with the system viewer")
+	cmd.Flags().IntVarP(&limit, "limit", "n", 50, "Max images to list (0 for all)")
+	cmd.Flags().StringVar(&tag, "tag", "", "Only images with this tag")
+
+	cmd.AddCommand(sdGalleryTagCmd())
+	cmd.AddCommand(sdGallerySearchCmd())
+
+	return cmd
+}
+
+func sdGalleryTagCmd() *cobra.Command {
+	var remove bool
+
+	cmd := &cobra.Command{
+		Use:   "tag <image>... <tags>",
+		Short: "Tag images, saving the tags in their .json sidecars",
+		Long: `Adds comma-separated tags to each image's .json sidecar, creating the
+sidecar from the PNG's workflow if there isn't one. Tags are lowercased.
+
+Examples:
+  clood sd gallery tag ./image.png "ghibli,character"
+  clood sd gallery tag outputs/batches/paint/*.png tortoise
+  clood sd gallery tag ./image.png character --remove`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			paths, tags := args[:len(args)-1], sd.ParseTags(args[len(args)-1])
+			if len(tags) == 0 {
+				return fmt.Errorf("no tags in %q", args[len(args)-1])
+			}
+
+			if output.DryRun {
+				action := "tag"
+				if remove {
+					action = "untag"
+				}
+				return output.WouldHaveDone(fmt.Sprintf("%s %d image(s)", action, len(paths)), map[string]interface{}{
+					"images": paths,
+					"tags":   tags,
+				})
+			}
+
+			results := map[string][]string{}
+			for _, path := range paths {
+				current, err := sd.TagImage(path, tags, remove)
+				if err != nil {
+					return err
+				}
+				results[path] = current
+				if !output.IsJSON() {
+					fmt.Printf("%s %s  %s\n", tui.SuccessStyle.Render("✓"), path, tui.MutedStyle.Render(formatTags(current)))
+				}
+			}
+			if output.IsJSON() {
+				return output.JSON(map[string]interface{}{"tags": results})
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&remove, "remove", false, "Remove the tags instead of adding them")
+
+	return withDryRun(cmd)
+}
+
+func sdGallerySearchCmd() *cobra.Command {
+	var dir string
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "search <query>",
+		Short: "Find images by prompt, checkpoint, LoRA or tag",
+		Long: `Searches the metadata of every image under --dir (default: outputs)
+for images whose prompt, checkpoint, LoRAs or tags contain every word of
+the query. Case doesn't matter.
+
+Examples:
+  clood sd gallery search "tortoise"
+  clood sd gallery search "ghibli sdxl" --dir outputs/batches`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			query := strings.Join(args, " ")
+			images, err := sd.LoadGallery(dir)
+			if err != nil {
+				return err
+			}
+			matches := sd.SearchGallery(images, query)
+
+			if output.IsJSON() {
+				return output.JSON(map[string]interface{}{"dir": dir, "query": query, "images": matches, "count": len(matches)})
+			}
+			if len(matches) == 0 {
+				fmt.Println(tui.MutedStyle.Render(fmt.Sprintf("No images in %s match %q", dir, query)))
+				return nil
+			}
+			printGallery(fmt.Sprintf("Gallery: %s matching %q", dir, query), dir, matches, limit)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", "outputs", "Directory to search")
+	cmd.Flags().IntVarP(&limit, "limit", "n", 50, "Max images to list (0 for all)")
+
+	return cmd
+}
+
+// printGallery lists images under a header, newest first, up to limit
+func printGallery(title, dir string, images []sd.GalleryImage, limit int) {
+	fmt.Println(tui.RenderHeader(title))
+	fmt.Println()
+	shown := images
+	if limit > 0 && len(shown) > limit {
+		shown = shown[:limit]
+	}
+	for _, img := range shown {
+		rel, err := filepath.Rel(dir, img.Path)
+		if err != nil {
+			rel = img.Path
+		}
+		fmt.Printf("  %s  %-32s %s\n",
+			tui.MutedStyle.Render(img.Created.Format("2006-01-02 15:04")),
+			truncateRunes(rel, 32),
+			tui.MutedStyle.Render(galleryDetail(img)))
+	}
+	fmt.Println()
+	fmt.Println(tui.MutedStyle.Render(fmt.Sprintf("  %d of %d shown (--tui to browse)", len(shown), len(images))))
+}
+
+// formatTags renders tags as "#a #b"
+func formatTags(tags []string) string {
+	if len(tags) == 0 {
+		return "no tags"
+	}
+	return "#" + strings.Join(tags, " #")
+}
+
+// galleryDetail is the one-line summary of how an image was made
+func galleryDetail(img sd.GalleryImage) string {
+	var parts []string
+	if m := img.Metadata; m.Width > 0 {
+		parts = append(parts, fmt.Sprintf("%dx%d", m.Width, m.Height))
+	}
+	if c := img.Metadata.Checkpoint; c != "" {
+		parts = append(parts, strings.TrimSuffix(c, filepath.Ext(c)))
+	}
+	if tags := img.Metadata.Tags; len(tags) > 0 {
+		parts = append(parts, formatTags(tags))
+	}
+	if p := img.Metadata.Prompt; p != "" {
+		parts = append(parts, truncateRunes(strings.Join(strings.Fields(p), " "), 48))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// truncateRunes cuts s to n characters, which for the · separators and
+// non-ASCII prompts is not n bytes
+func truncateRunes(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
+
+// openImage shows path in the platform's default image viewer
+func openImage(path string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", path)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", path)
+	default:
+		cmd = exec.Command("xdg-open", path)
+	}
+	return cmd.Start()
+}
+
+// galleryModel is the bubbletea model for 'sd gallery --tui'
+type galleryModel struct {
+	dir    string
+	images []sd.GalleryImage
+	cursor int
+	offset int // first visible row
+	width  int
+	height int
+	status string
+}
+
+var (
+	galleryTitleStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(tui.ColorPrimary).
+				Background(lipgloss.Color("#1a1a2e")).
+				Padding(0, 1)
+
+	gallerySelectedStyle = lipgloss.NewStyle().
+				Foreground(tui.ColorPrimary).
+				Bold(true)
+
+	galleryLabelStyle = lipgloss.NewStyle().
+				Foreground(tui.ColorMuted).
+				Width(12)
+
+	galleryHelpStyle = lipgloss.NewStyle().
+				Foreground(tui.ColorMuted)
+)
+
+func newGalleryModel(dir string, images []sd.GalleryImage) galleryModel {
+	return galleryModel{dir: dir, images: images, width: 80, height: 24}
+}
+
+func (m galleryModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m galleryModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c", "esc":
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.images)-1 {
+				m.cursor++
+			}
+		case "pgup":
+			m.cursor = max(m.cursor-m.listHeight(), 0)
+		case "pgdown":
+			m.cursor = min(m.cursor+m.listHeight(), len(m.images)-1)
+		case "home", "g":
+			m.cursor = 0
+		case "end", "G":
+			m.cursor = len(m.images) - 1
+		case "enter", "o":
+			path := m.images[m.cursor].Path
+			if err := openImage(path); err != nil {
+				m.status = tui.ErrorStyle.Render("open failed: " + err.Error())
+			} else {
+				m.status = "opened " + filepath.Base(path)
+			}
+		}
+	}
+
+	// Keep the cursor on screen
+	if h := m.listHeight(); m.cursor < m.offset {
+		m.offset = m.cursor
+	} else if m.cursor >= m.offset+h {
+		m.offset = m.cursor - h + 1
+	}
+	return m, nil
+}
+
+// listHeight is the number of rows left for the list after the header,
+// the detail pane and the footer
+func (m galleryModel) listHeight() int {
+	return max(m.height-15, 3)
+}
+
+func (m galleryModel) View() string {
+	var sb strings.Builder
+
+	sb.WriteString(galleryTitleStyle.Render("SD GALLERY"))
+	sb.WriteString(galleryHelpStyle.Render(fmt.Sprintf(" %s · %d images", m.dir, len(m.images))))
+	sb.WriteString("\n" + strings.Repeat("─", m.width) + "\n")
+
+	end := min(m.offset+m.listHeight(), len(m.images))
+	for i := m.offset; i < end; i++ {
+		img := m.images[i]
+		rel, err := filepath.Rel(m.dir, img.Path)
+		if err != nil {
+			rel = img.Path
+		}
+		line := fmt.Sprintf("%s  %-32s %s",
+			img.Created.Format("01-02 15:04"), truncateRunes(rel, 32), galleryDetail(img))
+		line = truncateRunes(line, max(m.width-4, 20))
+		if i == m.cursor {
+			sb.WriteString(gallerySelectedStyle.Render("▸ " + line))
+		} else {
+			sb.WriteString("  " + line)
+		}
+		sb.WriteString("\n")
+	}
+	for i := end - m.offset; i < m.listHeight(); i++ {
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(strings.Repeat("─", m.width) + "\n")
+	sb.WriteString(m.renderDetail())
+
+	help := "[↑↓/jk]move [enter]open [g/G]top/bottom [q]uit"
+	if m.status != "" {
+		help += "  " + m.status
+	}
+	sb.WriteString("\n" + galleryHelpStyle.Render(help))
+	return sb.String()
+}
+
+// renderDetail shows the full metadata for the selected image
+func (m galleryModel) renderDetail() string {
+	img := m.images[m.cursor]
+	meta := img.Metadata
+	width := max(m.width-14, 20)
+
+	row := func(label, value string) string {
+		if value == "" {
+			value = tui.MutedStyle.Render("-")
+		}
+		return galleryLabelStyle.Render(label) + truncateRunes(value, width) + "\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(row("File", img.Path))
+	sb.WriteString(row("Created", img.Created.Format("2006-01-02 15:04:05")))
+	sb.WriteString(row("Checkpoint", meta.Checkpoint))
+	dims := ""
+	if meta.Width > 0 {
+		dims = fmt.Sprintf("%dx%d", meta.Width, meta.Height)
+	}
+	sb.WriteString(row("Size", dims))
+	settings := ""
+	if meta.Steps > 0 {
+		settings = fmt.Sprintf("seed %d · %d steps · cfg %.1f · %s", meta.Seed, meta.Steps, meta.CFGScale, meta.Sampler)
+	}
+	sb.WriteString(row("Settings", settings))
+	sb.WriteString(row("Prompt", strings.Join(strings.Fields(meta.Prompt), " ")))
+	sb.WriteString(row("Negative", strings.Join(strings.Fields(meta.Negative), " ")))
+	tags := ""
+	if len(meta.Tags) > 0 {
+		tags = formatTags(meta.Tags)
+	}
+	sb.WriteString(row("Tags", tags))
+	source := map[string]string{"sidecar": "sidecar .json", "png": "PNG workflow", "none": "no metadata found"}[img.Source]
+	sb.WriteString(row("Source", source))
+	return sb.String()
+}
@@ -0,0 +1,170 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dirtybirdnj/clood/internal/output"
+	"github.com/dirtybirdnj/clood/internal/sd"
+	"github.com/dirtybirdnj/clood/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+func sdInpaintCmd() *cobra.Command {
+	var imagePath string
+	var maskPath string
+	var prompt string
+	var denoise float64
+	var checkpoint string
+	var comfyURL string
+	var negative string
+	var negPreset string
+	var steps int
+	var seed int64
+	var sampler string
+	var scheduler string
+	var outputPath string
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "inpaint [prompt]",
+		Short: "Regenerate the masked part of an image",
+		Long: `Redraws only the area of --image that --mask marks, from the prompt,
+and saves the result once ComfyUI finishes.
+
+The mask is a PNG the same size as the image: white is repainted, black
+is kept, and grays count as white from half brightness up. The image and
+mask go through LoadImageMask and InpaintModelConditioning, so --denoise
+below 1 keeps some of what was under the mask; 1 replaces it outright.
+Inpainting checkpoints blend edges best, but any checkpoint works.
+
+Examples:
+  clood sd inpaint --image base.png --mask mask.png --prompt "replace with ocean waves" --denoise 0.9
+  clood sd inpaint "a red door" --image house.png --mask door.png -o house_red.png
+  clood sd inpaint "sky" --image in.png --mask top.png --dry-run   # print workflow JSON`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				if prompt != "" && prompt != args[0] {
+					return fmt.Errorf("prompt given twice: as an argument and with --prompt")
+				}
+				prompt = args[0]
+			}
+			if prompt == "" {
+				return fmt.Errorf("a prompt is required (argument or --prompt)")
+			}
+			if imagePath == "" || maskPath == "" {
+				return fmt.Errorf("--image and --mask are required")
+			}
+			if denoise <= 0 || denoise > 1 {
+				return fmt.Errorf("--denoise must be in (0, 1], got %.2f", denoise)
+			}
+
+			width, height, coverage, err := sd.CheckInpaintInputs(imagePath, maskPath)
+			if err != nil {
+				return err
+			}
+			samplerName, schedulerName, err := sd.ResolveSampler(sampler, scheduler, cmd.Flags().Changed("scheduler"))
+			if err != nil {
+				return err
+			}
+
+			p := sd.NewPrompt(prompt).WithSeed(seed)
+			if err := applyNegative(p, negative, negPreset); err != nil {
+				return err
+			}
+
+			cfg := sd.DefaultWorkflowConfig()
+			cfg.Prompt = p
+			cfg.Checkpoint = checkpoint
+			cfg.Steps = steps
+			cfg.Width = width
+			cfg.Height = height
+			cfg.InputImagePath = imagePath
+			cfg.MaskImagePath = maskPath
+			cfg.Denoise = denoise
+			cfg.Sampler = samplerName
+			cfg.Scheduler = schedulerName
+			cfg.OutputPrefix = "clood_inpaint"
+
+			if output.DryRun {
+				workflow, err := sd.BuildBasicWorkflow(cfg)
+				if err != nil {
+					return err
+				}
+				data, err := workflow.ToJSON()
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(data))
+				return nil
+			}
+
+			if outputPath == "" {
+				ext := filepath.Ext(imagePath)
+				outputPath = strings.TrimSuffix(imagePath, ext) + "_inpaint.png"
+			}
+
+			if !output.IsJSON() {
+				fmt.Printf("%s %dx%d, %.0f%% masked, denoise %.2f with %s\n",
+					tui.MutedStyle.Render("Inpaint:"), width, height, coverage*100, denoise, checkpoint)
+			}
+
+			start := time.Now()
+			done := func(err error) error {
+				return sdWebhookDone("inpaint", prompt, checkpoint, start, outputPath, err)
+			}
+			client := newSDClient(comfyURL)
+			promptID, err := client.Generate(cfg)
+			if err != nil {
+				return done(err)
+			}
+			images, err := client.Wait(promptID, timeout)
+			if err != nil {
+				return done(err)
+			}
+			if len(images) == 0 {
+				return done(fmt.Errorf("prompt %s finished without an image", promptID))
+			}
+			if err := client.Download(images[0], outputPath); err != nil {
+				return done(err)
+			}
+			done(nil)
+
+			if output.IsJSON() {
+				return output.JSON(map[string]interface{}{
+					"prompt_id":   promptID,
+					"image":       imagePath,
+					"mask":        maskPath,
+					"output":      outputPath,
+					"coverage":    coverage,
+					"denoise":     denoise,
+					"elapsed_sec": time.Since(start).Seconds(),
+				})
+			}
+
+			fmt.Printf("%s %s %s\n", tui.SuccessStyle.Render("✓"), outputPath,
+				tui.MutedStyle.Render(fmt.Sprintf("(%.1fs)", time.Since(start).Seconds())))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&imagePath, "image", "", "Image to inpaint (PNG or JPEG)")
+	cmd.Flags().StringVar(&maskPath, "mask", "", "Mask PNG, white where the image should change")
+	cmd.Flags().StringVar(&prompt, "prompt", "", "What to paint in the masked area (or pass it as the argument)")
+	cmd.Flags().Float64Var(&denoise, "denoise", 0.9, "How much the masked area may change (0-1)")
+	cmd.Flags().StringVar(&checkpoint, "checkpoint", "sd_xl_base_1.0.safetensors", "Checkpoint to load")
+	registerCompletion(cmd, "checkpoint", completeCheckpoints)
+	cmd.Flags().StringVar(&comfyURL, "url", sd.DefaultComfyURL, "ComfyUI server URL")
+	cmd.Flags().StringVar(&negative, "negative", "", "Negative prompt (default: common artifacts)")
+	addNegPresetFlag(cmd, &negPreset)
+	cmd.Flags().IntVar(&steps, "steps", 25, "Sampling steps")
+	cmd.Flags().Int64Var(&seed, "seed", -1, "Seed (-1 for random)")
+	addSamplerFlags(cmd, &sampler, &scheduler)
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "File to save (default: <image>_inpaint.png)")
+	cmd.Flags().DurationVar(&timeout, "timeout", 10*time.Minute, "How long to wait for the result")
+
+	return withDryRun(cmd)
+}
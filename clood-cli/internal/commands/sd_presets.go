@@ -0,0 +1,127 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dirtybirdnj/clood/internal/config"
+	"github.com/dirtybirdnj/clood/internal/output"
+	"github.com/dirtybirdnj/clood/internal/sd"
+	"github.com/dirtybirdnj/clood/internal/tui"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// sdNegativesPath returns ~/.config/clood/sd_negatives.yaml
+func sdNegativesPath() string {
+	return filepath.Join(config.ConfigDir(), "sd_negatives.yaml")
+}
+
+// applyNegative sets prompt's negative from --neg-preset and --negative.
+// With neither, the default negative stays.
+func applyNegative(prompt *sd.Prompt, negative, preset string) error {
+	presets := sd.DefaultNegativePresets()
+	if preset != "" {
+		var err error
+		if presets, err = sd.LoadNegativePresets(sdNegativesPath()); err != nil {
+			return err
+		}
+	}
+	text, err := sd.ResolveNegative(presets, preset, negative)
+	if err != nil {
+		return err
+	}
+	if text != "" {
+		prompt.WithNegative(text)
+	}
+	return nil
+}
+
+func addNegPresetFlag(cmd *cobra.Command, preset *string) {
+	cmd.Flags().StringVar(preset, "neg-preset", "", "Named negative prompt (see 'clood sd presets'); combines with --negative")
+}
+
+func sdPresetsCmd() *cobra.Command {
+	var initFile bool
+
+	cmd := &cobra.Command{
+		Use:   "presets",
+		Short: "List named prompt presets",
+		Long: `Lists the presets the sd commands accept by name.
+
+Negative presets (--negatives) are used with --neg-preset. photo-realistic,
+anime and cinematic are built in; add your own or override them in
+~/.config/clood/sd_negatives.yaml:
+
+  photo-realistic: "cartoon, painting, blurry, deformed, watermark"
+  product: "busy background, reflections, fingerprints, low quality"
+
+--neg-preset and --negative combine: the preset comes first, then the
+literal text, joined with a comma.
+
+Run with --init to write the built-in presets there as a starting point.
+
+Examples:
+  clood sd presets --negatives
+  clood sd presets --negatives --init
+  clood sd paint "a tortoise" --neg-preset photo-realistic --negative "hat"`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := sdNegativesPath()
+			if initFile {
+				return writeNegativePresets(path)
+			}
+			presets, err := sd.LoadNegativePresets(path)
+			if err != nil {
+				return err
+			}
+
+			if output.IsJSON() {
+				return output.JSON(map[string]interface{}{"negatives": presets, "path": path})
+			}
+
+			fmt.Println(tui.RenderHeader("Negative Presets"))
+			for _, name := range sd.PresetNames(presets) {
+				fmt.Printf("  %s\n", tui.AccentStyle.Render(name))
+				fmt.Printf("    %s\n", tui.MutedStyle.Render(presets[name]))
+			}
+			fmt.Println()
+			if _, err := os.Stat(path); err != nil {
+				fmt.Println(tui.MutedStyle.Render("  Built-in presets only; add your own in " + path))
+			} else {
+				fmt.Println(tui.MutedStyle.Render("  From built-ins and " + path))
+			}
+			return nil
+		},
+	}
+
+	// Negatives are the only kind of preset so far, so they're listed
+	// either way; the flag keeps scripts working once there are others
+	cmd.Flags().Bool("negatives", false, "List negative prompt presets")
+	cmd.Flags().BoolVar(&initFile, "init", false, "Write the built-in negative presets to sd_negatives.yaml")
+
+	return cmd
+}
+
+func writeNegativePresets(path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists", path)
+	}
+	data, err := yaml.Marshal(sd.DefaultNegativePresets())
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return err
+	}
+
+	if output.IsJSON() {
+		return output.JSON(map[string]interface{}{"status": "written", "path": path})
+	}
+	fmt.Println(tui.SuccessStyle.Render("✓ Wrote negative presets to " + path))
+	return nil
+}
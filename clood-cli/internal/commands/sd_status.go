@@ -0,0 +1,118 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/dirtybirdnj/clood/internal/hosts"
+	"github.com/dirtybirdnj/clood/internal/output"
+	"github.com/dirtybirdnj/clood/internal/sd"
+	"github.com/dirtybirdnj/clood/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+func sdStatusCmd() *cobra.Command {
+	var comfyURL string
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show ComfyUI's system stats, checkpoints and queue",
+		Long: `Reports ComfyUI's versions, RAM and GPU memory, the installed
+checkpoints, and how busy the queue is.
+
+The estimated wait is the number of running and pending prompts times
+the average generation time of the last 10 runs clood waited on (kept in
+~/.clood/sd_timings.json). Until something has been generated it shows
+as unknown.
+
+Examples:
+  clood sd status
+  clood sd status --json | jq .estimated_wait_sec`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := newSDClient(comfyURL)
+
+			stats, err := client.SystemStats()
+			if err != nil {
+				return fmt.Errorf("ComfyUI not reachable at %s: %w", comfyURL, err)
+			}
+			queue, err := client.QueueStatus()
+			if err != nil {
+				return err
+			}
+			checkpoints, err := client.Models("checkpoints")
+			if err != nil {
+				return err
+			}
+
+			timings, err := sd.LoadTimings(client.TimingsPath)
+			if err != nil {
+				return err
+			}
+			avg, known := timings.Average()
+			wait := sd.EstimateWait(queue, avg)
+			waitText := sd.ApproxDuration(wait)
+			if !known && queue.Pending+queue.Running > 0 {
+				waitText = "unknown"
+			}
+
+			if output.IsJSON() {
+				result := map[string]interface{}{
+					"url":            comfyURL,
+					"system":         stats.System,
+					"devices":        stats.Devices,
+					"checkpoints":    checkpoints,
+					"queue_pending":  queue.Pending,
+					"queue_running":  queue.Running,
+					"estimated_wait": waitText,
+					"timed_runs":     len(timings.Recent),
+				}
+				if known {
+					result["estimated_wait_sec"] = wait.Seconds()
+					result["average_generation_sec"] = avg.Seconds()
+				}
+				return output.JSON(result)
+			}
+
+			fmt.Println(tui.RenderHeader("ComfyUI Status"))
+			fmt.Printf("  %s %s\n", tui.MutedStyle.Render("URL:    "), comfyURL)
+			version := "Python " + stats.System.PythonVersion
+			if stats.System.ComfyUIVersion != "" {
+				version = stats.System.ComfyUIVersion + ", " + version
+			}
+			fmt.Printf("  %s %s (%s)\n", tui.MutedStyle.Render("Server: "), stats.System.OS, version)
+			fmt.Printf("  %s %s free of %s\n", tui.MutedStyle.Render("RAM:    "),
+				hosts.FormatSize(stats.System.RAMFree), hosts.FormatSize(stats.System.RAMTotal))
+			for _, dev := range stats.Devices {
+				fmt.Printf("  %s %s, %s VRAM free of %s\n", tui.MutedStyle.Render("Device: "),
+					dev.Name, hosts.FormatSize(dev.VRAMFree), hosts.FormatSize(dev.VRAMTotal))
+			}
+
+			fmt.Println()
+			queueLine := fmt.Sprintf("%d running, %d pending", queue.Running, queue.Pending)
+			switch {
+			case queue.Pending+queue.Running == 0:
+				fmt.Printf("  %s %s\n", tui.MutedStyle.Render("Queue:  "), tui.SuccessStyle.Render("idle"))
+			case queue.Pending > 0:
+				fmt.Printf("  %s %s\n", tui.MutedStyle.Render("Queue:  "), tui.WarningStyle.Render(queueLine))
+			default:
+				fmt.Printf("  %s %s\n", tui.MutedStyle.Render("Queue:  "), queueLine)
+			}
+			fmt.Printf("  %s %s\n", tui.MutedStyle.Render("Wait:   "), waitText)
+			if known {
+				fmt.Printf("  %s %s per run (last %d)\n", tui.MutedStyle.Render("Average:"),
+					sd.ApproxDuration(avg), len(timings.Recent))
+			}
+
+			fmt.Println()
+			fmt.Printf("  %s %s\n", tui.HeaderStyle.Render("Checkpoints"), tui.MutedStyle.Render(fmt.Sprintf("(%d)", len(checkpoints))))
+			for _, ckpt := range checkpoints {
+				fmt.Printf("    %s\n", ckpt)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&comfyURL, "url", sd.DefaultComfyURL, "ComfyUI server URL")
+
+	return cmd
+}
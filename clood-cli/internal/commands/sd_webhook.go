@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/dirtybirdnj/clood/internal/sd"
+	"github.com/dirtybirdnj/clood/internal/tui"
+)
+
+// sdWebhookTimeout bounds each --webhook POST
+const sdWebhookTimeout = 5 * time.Second
+
+// sdWebhookURL is the sd --webhook flag
+var sdWebhookURL string
+
+// sdWebhooks tracks POSTs still in flight, which waitSDWebhooks lets
+// finish before clood exits
+var sdWebhooks sync.WaitGroup
+
+// sdWebhookPayload is what --webhook receives for each finished image
+type sdWebhookPayload struct {
+	Command    string  `json:"command"`
+	Prompt     string  `json:"prompt"`
+	Checkpoint string  `json:"checkpoint"`
+	Duration   float64 `json:"duration"` // seconds
+	ImagePath  string  `json:"image_path"`
+	Success    bool    `json:"success"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// sdWebhookDone reports a single-image generation that started at start
+// and returns err, so it can wrap a command's return value
+func sdWebhookDone(command, prompt, checkpoint string, start time.Time, imagePath string, err error) error {
+	p := sdWebhookPayload{
+		Command:    command,
+		Prompt:     prompt,
+		Checkpoint: checkpoint,
+		Duration:   time.Since(start).Seconds(),
+		ImagePath:  imagePath,
+		Success:    err == nil,
+	}
+	if err != nil {
+		p.Error = err.Error()
+	}
+	fireSDWebhook(p)
+	return err
+}
+
+// sdWebhookBatch reports one image of a paint or anvil batch
+func sdWebhookBatch(command string, vr sd.VariationResult) {
+	fireSDWebhook(sdWebhookPayload{
+		Command:    command,
+		Prompt:     vr.Metadata.Prompt,
+		Checkpoint: vr.Variation.Checkpoint,
+		Duration:   vr.GenerateTime.Seconds(),
+		ImagePath:  vr.OutputPath,
+		Success:    vr.Success,
+		Error:      vr.Error,
+	})
+}
+
+// fireSDWebhook POSTs p to --webhook in the background. Failures are
+// printed to stderr and never fail the command.
+func fireSDWebhook(p sdWebhookPayload) {
+	if sdWebhookURL == "" {
+		return
+	}
+	sdWebhooks.Add(1)
+	go func() {
+		defer sdWebhooks.Done()
+		if err := postSDWebhook(sdWebhookURL, p); err != nil {
+			fmt.Fprintln(os.Stderr, tui.WarningStyle.Render("webhook: "+err.Error()))
+		}
+	}()
+}
+
+func postSDWebhook(url string, p sdWebhookPayload) error {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), sdWebhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned %s", url, resp.Status)
+	}
+	return nil
+}
+
+// waitSDWebhooks holds exit until in-flight webhooks finish, which the
+// timeout caps at sdWebhookTimeout
+func waitSDWebhooks() {
+	sdWebhooks.Wait()
+}
@@ -0,0 +1,203 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dirtybirdnj/clood/internal/output"
+	"github.com/dirtybirdnj/clood/internal/sd"
+	"github.com/dirtybirdnj/clood/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+func sdRunWorkflowCmd() *cobra.Command {
+	var workflowPath string
+	var inputMap string
+	var comfyURL string
+	var outDir string
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "run-workflow",
+		Short: "Queue your own ComfyUI workflow JSON, with inputs filled in",
+		Long: `Runs a workflow built in ComfyUI instead of one clood assembles from
+flags: the escape hatch for custom nodes (IPAdapter, FaceDetailer, ...)
+and graphs the other sd commands can't express.
+
+Export the workflow from ComfyUI with "Save (API Format)". --input-map
+is a JSON object (or @file.json) of values to set before queueing:
+
+  "6.text"          input text of node 6
+  "KSampler.seed"   input seed of the node titled KSampler
+  "positive_prompt" the node titled positive_prompt, when it has only
+                    one input that isn't wired to another node
+
+Titles come from the node's _meta and don't care about case. An image
+input given a path to a local file gets the file uploaded first. The
+command waits for the run and downloads every image it outputs.
+
+Examples:
+  clood sd run-workflow --workflow my-workflow.json --input-map '{"positive_prompt": "a tortoise"}'
+  clood sd run-workflow --workflow faces.json --input-map '{"KSampler.seed": 42, "LoadImage.image": "./me.png"}'
+  clood sd run-workflow --workflow ipadapter.json --input-map @inputs.json -o out/
+  clood sd run-workflow --workflow my-workflow.json --dry-run   # print the filled-in workflow`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if workflowPath == "" {
+				return fmt.Errorf("--workflow is required")
+			}
+			workflow, err := sd.LoadWorkflowFile(workflowPath)
+			if err != nil {
+				return err
+			}
+
+			values, err := parseInputMap(inputMap)
+			if err != nil {
+				return err
+			}
+			applied, err := workflow.ApplyInputMap(values)
+			if err != nil {
+				return err
+			}
+
+			if output.DryRun {
+				data, err := json.MarshalIndent(workflow.Nodes, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(data))
+				return nil
+			}
+
+			client := newSDClient(comfyURL)
+			for _, a := range applied {
+				path, ok := a.Value.(string)
+				if a.Input != "image" || !ok || !fileExists(path) {
+					continue
+				}
+				name, err := client.UploadImage(path)
+				if err != nil {
+					return fmt.Errorf("%s: %w", a.Key, err)
+				}
+				workflow.Nodes[a.NodeID].Inputs["image"] = name
+			}
+
+			if outDir == "" {
+				outDir = filepath.Join("outputs", "batches", strings.TrimSuffix(filepath.Base(workflowPath), filepath.Ext(workflowPath)))
+			}
+			if !output.IsJSON() {
+				fmt.Printf("%s %s (%d nodes)\n", tui.MutedStyle.Render("Workflow:"), workflowPath, len(workflow.Nodes))
+				for _, a := range applied {
+					fmt.Printf("  %s %s.%s = %v\n", tui.MutedStyle.Render("set"), a.NodeID, a.Input, a.Value)
+				}
+			}
+
+			start := time.Now()
+			prompt, checkpoint := workflowPromptAndCheckpoint(workflow)
+			done := func(imagePath string, err error) error {
+				return sdWebhookDone("run-workflow", prompt, checkpoint, start, imagePath, err)
+			}
+			promptID, err := client.Queue(workflow)
+			if err != nil {
+				return done("", err)
+			}
+			images, err := client.Wait(promptID, timeout)
+			if err != nil {
+				return done("", err)
+			}
+			if err := os.MkdirAll(outDir, 0755); err != nil {
+				return done("", err)
+			}
+			var saved []string
+			for _, img := range images {
+				dest := filepath.Join(outDir, img.Filename)
+				if err := client.Download(img, dest); err != nil {
+					return done(dest, err)
+				}
+				saved = append(saved, dest)
+				done(dest, nil)
+			}
+
+			if output.IsJSON() {
+				return output.JSON(map[string]interface{}{
+					"prompt_id":   promptID,
+					"workflow":    workflowPath,
+					"inputs":      applied,
+					"images":      saved,
+					"elapsed_sec": time.Since(start).Seconds(),
+				})
+			}
+
+			if len(saved) == 0 {
+				fmt.Println(tui.WarningStyle.Render(fmt.Sprintf("Prompt %s finished without output images (no SaveImage node?)", promptID)))
+				return nil
+			}
+			for _, path := range saved {
+				fmt.Printf("%s %s\n", tui.SuccessStyle.Render("✓"), path)
+			}
+			fmt.Println(tui.MutedStyle.Render(fmt.Sprintf("(%.1fs)", time.Since(start).Seconds())))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&workflowPath, "workflow", "", "Workflow JSON saved from ComfyUI with Save (API Format)")
+	cmd.Flags().StringVar(&inputMap, "input-map", "", `Inputs to set, as JSON or @file: {"node.input": value, "title": value}`)
+	cmd.Flags().StringVar(&comfyURL, "url", sd.DefaultComfyURL, "ComfyUI server URL")
+	cmd.Flags().StringVarP(&outDir, "out", "o", "", "Output directory (default: outputs/batches/<workflow name>)")
+	cmd.Flags().DurationVar(&timeout, "timeout", 10*time.Minute, "How long to wait for the run")
+
+	return withDryRun(cmd)
+}
+
+// parseInputMap reads --input-map, inline or from @file. Numbers are kept
+// as written so large seeds aren't rounded through float64.
+func parseInputMap(s string) (map[string]interface{}, error) {
+	if s == "" {
+		return nil, nil
+	}
+	data := []byte(s)
+	if path, ok := strings.CutPrefix(s, "@"); ok {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	var values map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&values); err != nil {
+		return nil, fmt.Errorf("--input-map: want a JSON object: %w", err)
+	}
+	return values, nil
+}
+
+// workflowPromptAndCheckpoint finds the positive prompt (the text wired
+// to a sampler's positive input) and checkpoint of a workflow, for the
+// --webhook notice
+func workflowPromptAndCheckpoint(w *sd.ComfyWorkflow) (string, string) {
+	ids := make([]string, 0, len(w.Nodes))
+	for id := range w.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var prompt, checkpoint string
+	for _, id := range ids {
+		node := w.Nodes[id]
+		if link, ok := node.Inputs["positive"].([]interface{}); ok && len(link) == 2 && prompt == "" {
+			if from, ok := link[0].(string); ok {
+				prompt, _ = w.Nodes[from].Inputs["text"].(string)
+			}
+		}
+		if ckpt, ok := node.Inputs["ckpt_name"].(string); ok && checkpoint == "" {
+			checkpoint = ckpt
+		}
+	}
+	return prompt, checkpoint
+}
@@ -116,5 +116,5 @@ Examples:
 	cmd.Flags().BoolVar(&useSSE, "sse", false, "Use SSE (Server-Sent Events) transport")
 	cmd.Flags().StringVar(&baseURL, "base-url", "", "Base URL for SSE endpoints (default: http://localhost:PORT)")
 
-	return cmd
+	return withDryRun(cmd)
 }
@@ -15,26 +15,26 @@ import (
 
 // SessionContext represents the CONTEXT.yaml session state
 type SessionContext struct {
-	Session   string              `yaml:"session"`
-	Branch    string              `yaml:"branch"`
-	Status    string              `yaml:"status"`
-	Focus     SessionContextFocus `yaml:"focus"`
-	Next      []string            `yaml:"next"`
-	Summary   string              `yaml:"summary"`
-	Blockers  []string            `yaml:"blockers,omitempty"`
-	UpdatedBy string              `yaml:"updated_by,omitempty"`
+	Session   string              `yaml:"session" json:"session"`
+	Branch    string              `yaml:"branch" json:"branch"`
+	Status    string              `yaml:"status" json:"status"`
+	Focus     SessionContextFocus `yaml:"focus" json:"focus"`
+	Next      []string            `yaml:"next" json:"next"`
+	Summary   string              `yaml:"summary" json:"summary"`
+	Blockers  []string            `yaml:"blockers,omitempty" json:"blockers,omitempty"`
+	UpdatedBy string              `yaml:"updated_by,omitempty" json:"updated_by,omitempty"`
 }
 
 // SessionContextFocus defines what files/issues are relevant
 type SessionContextFocus struct {
-	Files  []SessionFocusFile `yaml:"files,omitempty"`
-	Issues []int              `yaml:"issues,omitempty"`
+	Files  []SessionFocusFile `yaml:"files,omitempty" json:"files,omitempty"`
+	Issues []int              `yaml:"issues,omitempty" json:"issues,omitempty"`
 }
 
 // SessionFocusFile represents a file with context on why it matters
 type SessionFocusFile struct {
-	Path string `yaml:"path"`
-	Why  string `yaml:"why,omitempty"`
+	Path string `yaml:"path" json:"path"`
+	Why  string `yaml:"why,omitempty" json:"why,omitempty"`
 }
 
 const sessionContextFile = "CONTEXT.yaml"
@@ -55,6 +55,9 @@ to share across machines and agents.`,
 	cmd.AddCommand(sessionLoadCmd())
 	cmd.AddCommand(sessionSaveCmd())
 	cmd.AddCommand(sessionInitCmd())
+	cmd.AddCommand(sessionExportCmd())
+	cmd.AddCommand(sessionImportCmd())
+	cmd.AddCommand(sessionUsageCmd())
 
 	return cmd
 }
@@ -0,0 +1,305 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dirtybirdnj/clood/internal/output"
+	"github.com/dirtybirdnj/clood/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// sessionBundleSchema is bumped whenever SessionBundle changes incompatibly
+const sessionBundleSchema = 1
+
+// SessionBundle is the portable, machine-readable form of a session.
+// Unlike handoff (human-readable text), it round-trips through import.
+type SessionBundle struct {
+	SchemaVersion int             `json:"schema_version"`
+	SessionID     string          `json:"session_id"`
+	ExportedAt    time.Time       `json:"exported_at"`
+	ExportedFrom  string          `json:"exported_from,omitempty"` // hostname
+	Context       *SessionContext `json:"context,omitempty"`       // CONTEXT.yaml
+	Saga          *Saga           `json:"saga,omitempty"`          // chat history
+	Focus         *FocusState     `json:"focus,omitempty"`
+	Beans         []Bean          `json:"beans,omitempty"`
+	Tokens        SessionTokens   `json:"tokens"`
+
+	// ExperimentEvents come from a running 'clood atc --mode experiment'
+	// (export --atc) and feed 'clood atc --replay'
+	ExperimentEvents []ExperimentEvent `json:"experiment_events,omitempty"`
+}
+
+// SessionTokens records context size at export time
+type SessionTokens struct {
+	History  int `json:"history"`
+	Context  int `json:"context"`
+	Total    int `json:"total"`
+	Messages int `json:"messages"`
+}
+
+func sessionExportCmd() *cobra.Command {
+	var outPath string
+	var includePruned bool
+	var atcURL string
+
+	cmd := &cobra.Command{
+		Use:   "export [session-id]",
+		Short: "Export session as portable JSON",
+		Long: `Export the current session to a JSON file that 'clood session import'
+can restore on another machine.
+
+The bundle contains CONTEXT.yaml, the chat saga (conversation history),
+focus state, beans, token counts, and a schema version. With --atc it
+also captures the experiment events of a running ATC dashboard, which
+'clood atc --mode experiment --replay' can play back later.
+
+The session ID is the 'session' field shown by 'clood session show'.
+It is optional and only checked against the current session.
+
+Examples:
+  clood session export --out session.json
+  clood session export 2025-01-02T10:00:00-05:00 --out session.json
+  clood session export --atc http://localhost:8080 --out session.json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bundle, err := buildSessionBundle(includePruned)
+			if err != nil {
+				return err
+			}
+
+			if len(args) == 1 && args[0] != bundle.SessionID {
+				return fmt.Errorf("session %q not found (current session is %q)", args[0], bundle.SessionID)
+			}
+
+			if atcURL != "" {
+				if bundle.ExperimentEvents, err = fetchATCExperimentEvents(atcURL); err != nil {
+					return err
+				}
+			}
+
+			data, err := json.MarshalIndent(bundle, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			if outPath == "" || outPath == "-" {
+				fmt.Println(string(data))
+				return nil
+			}
+
+			if err := os.WriteFile(outPath, data, 0644); err != nil {
+				return fmt.Errorf("writing %s: %w", outPath, err)
+			}
+
+			if output.IsJSON() {
+				return output.JSON(map[string]interface{}{
+					"status":     "exported",
+					"path":       outPath,
+					"session_id": bundle.SessionID,
+					"tokens":     bundle.Tokens,
+				})
+			}
+
+			fmt.Println(tui.SuccessStyle.Render("✓ Session exported to " + outPath))
+			fmt.Printf("  Session:  %s\n", bundle.SessionID)
+			fmt.Printf("  Messages: %d (~%d tokens)\n", bundle.Tokens.Messages, bundle.Tokens.Total)
+			fmt.Printf("  Beans:    %d\n", len(bundle.Beans))
+			if len(bundle.ExperimentEvents) > 0 {
+				fmt.Printf("  Events:   %d experiment events\n", len(bundle.ExperimentEvents))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&outPath, "out", "o", "", "Output file (default: stdout)")
+	cmd.Flags().BoolVar(&includePruned, "include-pruned", false, "Include pruned beans")
+	cmd.Flags().StringVar(&atcURL, "atc", "", "Also capture experiment events from a running ATC (e.g. http://localhost:8080)")
+
+	return cmd
+}
+
+func sessionImportCmd() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Import a session exported with 'session export'",
+		Long: `Restore a session bundle into the current directory.
+
+Writes CONTEXT.yaml and .clood/saga.json, restores focus state, and merges
+beans into the garden (beans with an identical vision are skipped).
+
+Existing CONTEXT.yaml or saga files are left alone unless --force is given.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			var bundle SessionBundle
+			if err := json.Unmarshal(data, &bundle); err != nil {
+				return fmt.Errorf("parsing %s: %w", args[0], err)
+			}
+			if bundle.SchemaVersion == 0 || bundle.SchemaVersion > sessionBundleSchema {
+				return fmt.Errorf("unsupported session schema version %d (this clood supports up to %d)",
+					bundle.SchemaVersion, sessionBundleSchema)
+			}
+
+			added, err := importSessionBundle(&bundle, force)
+			if err != nil {
+				return err
+			}
+
+			if output.IsJSON() {
+				return output.JSON(map[string]interface{}{
+					"status":      "imported",
+					"session_id":  bundle.SessionID,
+					"beans_added": added,
+					"tokens":      bundle.Tokens,
+				})
+			}
+
+			fmt.Println(tui.SuccessStyle.Render("✓ Session imported: " + bundle.SessionID))
+			if bundle.ExportedFrom != "" {
+				fmt.Printf("  From:     %s (%s)\n", bundle.ExportedFrom, bundle.ExportedAt.Format("2006-01-02 15:04"))
+			}
+			fmt.Printf("  Messages: %d (~%d tokens)\n", bundle.Tokens.Messages, bundle.Tokens.Total)
+			fmt.Printf("  Beans:    %d added\n", added)
+			fmt.Println()
+			fmt.Println(tui.MutedStyle.Render("  Continue with 'clood chat' or 'clood session show'"))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Overwrite existing CONTEXT.yaml and saga")
+
+	return cmd
+}
+
+// buildSessionBundle gathers everything that makes up the current session
+func buildSessionBundle(includePruned bool) (*SessionBundle, error) {
+	bundle := &SessionBundle{
+		SchemaVersion: sessionBundleSchema,
+		ExportedAt:    time.Now(),
+	}
+	bundle.ExportedFrom, _ = os.Hostname()
+
+	if ctx, err := loadSessionContext(); err == nil {
+		bundle.Context = ctx
+		bundle.SessionID = ctx.Session
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("loading %s: %w", sessionContextFile, err)
+	}
+
+	if data, err := os.ReadFile(getSagaPath()); err == nil {
+		var saga Saga
+		if err := json.Unmarshal(data, &saga); err != nil {
+			return nil, fmt.Errorf("parsing saga: %w", err)
+		}
+		bundle.Saga = &saga
+		stats := saga.GetStats()
+		bundle.Tokens = SessionTokens{
+			History:  stats.HistoryTokens,
+			Context:  stats.ContextTokens,
+			Total:    stats.TotalTokens,
+			Messages: stats.MessageCount,
+		}
+		if bundle.SessionID == "" {
+			bundle.SessionID = saga.CreatedAt.Format(time.RFC3339)
+		}
+	}
+
+	bundle.Focus = GetFocusState()
+
+	if garden, err := loadGarden(); err == nil {
+		for _, b := range garden.Beans {
+			if b.Pruned && !includePruned {
+				continue
+			}
+			bundle.Beans = append(bundle.Beans, b)
+		}
+	}
+
+	if bundle.Context == nil && bundle.Saga == nil {
+		return nil, fmt.Errorf("no session found (run 'clood session init' or 'clood chat' first)")
+	}
+
+	return bundle, nil
+}
+
+// importSessionBundle restores a bundle and returns how many beans were added
+func importSessionBundle(bundle *SessionBundle, force bool) (int, error) {
+	if bundle.Context != nil {
+		if _, err := os.Stat(sessionContextFile); err == nil && !force {
+			return 0, fmt.Errorf("%s already exists (use --force to overwrite)", sessionContextFile)
+		}
+	}
+	if bundle.Saga != nil {
+		if _, err := os.Stat(getSagaPath()); err == nil && !force {
+			return 0, fmt.Errorf("%s already exists (use --force to overwrite)", getSagaPath())
+		}
+	}
+
+	if bundle.Context != nil {
+		bundle.Context.UpdatedBy = "clood session import"
+		if err := saveSessionContext(bundle.Context); err != nil {
+			return 0, err
+		}
+	}
+
+	if bundle.Saga != nil {
+		// The saga belongs to wherever it was imported
+		cwd, _ := os.Getwd()
+		bundle.Saga.ProjectPath = cwd
+		if err := os.MkdirAll(filepath.Dir(getSagaPath()), 0755); err != nil {
+			return 0, fmt.Errorf("creating .clood dir: %w", err)
+		}
+		if err := saveSaga(bundle.Saga); err != nil {
+			return 0, err
+		}
+	}
+
+	if bundle.Focus != nil {
+		if err := saveFocusState(bundle.Focus); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(bundle.Beans) == 0 {
+		return 0, nil
+	}
+
+	garden, err := loadGarden()
+	if err != nil {
+		return 0, err
+	}
+	seen := make(map[string]bool)
+	for _, b := range garden.Beans {
+		seen[b.Vision] = true
+	}
+
+	added := 0
+	for _, b := range bundle.Beans {
+		if seen[b.Vision] {
+			continue
+		}
+		// IDs are per-garden, so renumber on the way in
+		b.ID = garden.NextID
+		garden.NextID++
+		garden.Beans = append(garden.Beans, b)
+		seen[b.Vision] = true
+		added++
+	}
+
+	if added > 0 {
+		if err := saveGarden(garden); err != nil {
+			return 0, err
+		}
+	}
+	return added, nil
+}
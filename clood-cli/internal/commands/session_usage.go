@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/dirtybirdnj/clood/internal/config"
+	"github.com/dirtybirdnj/clood/internal/output"
+	"github.com/dirtybirdnj/clood/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+func sessionUsageCmd() *cobra.Command {
+	var model string
+
+	cmd := &cobra.Command{
+		Use:     "usage",
+		Aliases: []string{"gauge"},
+		Short:   "Show how much of the model's context window the saga uses",
+		Long: `Shows the chat saga's token count against the context window of its
+model as a gauge: green under 50%, yellow to 80%, red above.
+
+The model is the one set with /model, or else the one that wrote the last
+reply. Context windows come from a built-in table by model family and
+can be overridden in config.yaml:
+
+  context_windows:
+    llama3.1:8b: 32768
+    my-finetune: 65536
+
+Examples:
+  clood session usage
+  clood session usage -m qwen2.5-coder:7b   # would it fit on this model?`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := os.ReadFile(getSagaPath())
+			if os.IsNotExist(err) {
+				return fmt.Errorf("no saga in this directory (start one with 'clood chat')")
+			}
+			if err != nil {
+				return err
+			}
+			var saga Saga
+			if err := json.Unmarshal(data, &saga); err != nil {
+				return fmt.Errorf("parsing saga: %w", err)
+			}
+
+			stats := saga.GetStats()
+			if model != "" {
+				cfg, _ := config.Load()
+				stats.Model = model
+				stats.MaxTokens = cfg.ContextWindow(model)
+				stats.UsagePercent = float64(stats.TotalTokens) / float64(stats.MaxTokens) * 100
+			}
+
+			if output.IsJSON() {
+				return output.JSON(map[string]interface{}{
+					"model":          stats.Model,
+					"used_tokens":    stats.TotalTokens,
+					"history_tokens": stats.HistoryTokens,
+					"context_tokens": stats.ContextTokens,
+					"context_window": stats.MaxTokens,
+					"usage_percent":  stats.UsagePercent,
+					"messages":       stats.MessageCount,
+				})
+			}
+
+			fmt.Println(tui.RenderHeader("Context Usage"))
+			fmt.Printf("  History: %d tokens (%d messages)\n", stats.HistoryTokens, stats.MessageCount)
+			fmt.Printf("  Context: %d tokens\n", stats.ContextTokens)
+			if stats.Model == "" {
+				fmt.Println(tui.MutedStyle.Render(fmt.Sprintf("  No model yet; assuming a %d token window", stats.MaxTokens)))
+			}
+			fmt.Println()
+			renderHealthMeter(stats)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&model, "model", "m", "", "Measure against this model's context window instead")
+
+	return cmd
+}
@@ -2,7 +2,6 @@ package commands
 
 import (
 	"bufio"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
@@ -86,7 +85,7 @@ func runSetupJSON(hostURL string) {
 	hw, err := system.DetectHardware()
 	if err != nil {
 		result["error"] = err.Error()
-		data, _ := json.MarshalIndent(result, "", "  ")
+		data, _ := output.Marshal(result)
 		fmt.Println(string(data))
 		return
 	}
@@ -111,7 +110,7 @@ func runSetupJSON(hostURL string) {
 	config := generateConfig(hw, ollamaURL)
 	result["config"] = config
 
-	data, _ := json.MarshalIndent(result, "", "  ")
+	data, _ := output.Marshal(result)
 	fmt.Println(string(data))
 }
 
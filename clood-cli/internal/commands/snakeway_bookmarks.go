@@ -0,0 +1,148 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// bookmarkSnippetLen caps the sentence shown for a bookmark
+const bookmarkSnippetLen = 60
+
+// toggleBookmark marks the line at the top of the viewport, or unmarks it
+// if it's already marked. Bookmarks stay sorted so B1 is the earliest.
+func (m *snakewayModel) toggleBookmark() {
+	line := m.viewport.YOffset
+	for i, b := range m.bookmarks {
+		if b == line {
+			m.bookmarks = append(m.bookmarks[:i], m.bookmarks[i+1:]...)
+			return
+		}
+	}
+	m.bookmarks = append(m.bookmarks, line)
+	sort.Ints(m.bookmarks)
+}
+
+// gotoBookmark scrolls the bookmarked line to the top of the viewport
+func (m *snakewayModel) gotoBookmark(idx int) {
+	if idx < 0 || idx >= len(m.bookmarks) {
+		return
+	}
+	m.viewport.SetYOffset(m.bookmarks[idx])
+	m.following = false
+	m.showBookmarks = false
+}
+
+// bookmarkSnippets is the first sentence at each bookmark, in order
+func (m snakewayModel) bookmarkSnippets() []string {
+	lines, _ := m.wrappedLines()
+	snippets := make([]string, 0, len(m.bookmarks))
+	for _, b := range m.bookmarks {
+		snippets = append(snippets, firstSentence(lines, b))
+	}
+	return snippets
+}
+
+// firstSentence joins wrapped lines from start until a sentence ends or
+// the paragraph does. Blank lines and rules at the mark are skipped so a
+// bookmark on a turn's banner shows what follows it.
+func firstSentence(lines []string, start int) string {
+	for start < len(lines) {
+		trimmed := strings.TrimSpace(lines[start])
+		if trimmed != "" && ruleChar(trimmed) == "" {
+			break
+		}
+		start++
+	}
+
+	var words []string
+	for i := start; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == "" || ruleChar(trimmed) != "" {
+			break
+		}
+		for _, w := range strings.Fields(trimmed) {
+			words = append(words, w)
+			if strings.ContainsAny(w[len(w)-1:], ".!?") {
+				return truncateSnippet(strings.Join(words, " "))
+			}
+		}
+	}
+	if len(words) == 0 {
+		return "(end of conversation)"
+	}
+	return truncateSnippet(strings.Join(words, " "))
+}
+
+func truncateSnippet(s string) string {
+	if len(s) > bookmarkSnippetLen {
+		return s[:bookmarkSnippetLen-3] + "..."
+	}
+	return s
+}
+
+func (m snakewayModel) renderBookmarksOverlay() string {
+	var sb strings.Builder
+
+	boxWidth := 70
+	if m.width < boxWidth+4 {
+		boxWidth = m.width - 4
+	}
+
+	// Header
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FFD700")).
+		Background(lipgloss.Color("#1a1a2e")).
+		Width(boxWidth).
+		Align(lipgloss.Center).
+		Padding(0, 1)
+
+	sb.WriteString(headerStyle.Render("🔖 BOOKMARKS"))
+	sb.WriteString("\n")
+	sb.WriteString(strings.Repeat("─", boxWidth))
+	sb.WriteString("\n\n")
+
+	if len(m.bookmarks) == 0 {
+		sb.WriteString("  No bookmarks yet.\n")
+		sb.WriteString("  (ctrl+m marks the line at the top of the view)\n")
+	} else {
+		lineStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+		for i, snippet := range m.bookmarkSnippets() {
+			sb.WriteString(fmt.Sprintf("  %s %s\n",
+				swQuestionStyle.Render(fmt.Sprintf("B%d:", i+1)),
+				snippet))
+			sb.WriteString(fmt.Sprintf("      %s\n\n", lineStyle.Render(fmt.Sprintf("line %d", m.bookmarks[i]+1))))
+		}
+	}
+
+	// Footer
+	sb.WriteString("\n")
+	footerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#666666"))
+	sb.WriteString(footerStyle.Render("  [b] close  [1-9] jump to bookmark"))
+	sb.WriteString("\n")
+
+	// Center the whole thing
+	lines := strings.Split(sb.String(), "\n")
+	topPad := (m.height - len(lines)) / 2
+	if topPad < 0 {
+		topPad = 0
+	}
+	leftPad := (m.width - boxWidth) / 2
+	if leftPad < 0 {
+		leftPad = 0
+	}
+	padding := strings.Repeat(" ", leftPad)
+
+	var result strings.Builder
+	result.WriteString(strings.Repeat("\n", topPad))
+	for _, line := range lines {
+		result.WriteString(padding)
+		result.WriteString(line)
+		result.WriteString("\n")
+	}
+
+	return result.String()
+}
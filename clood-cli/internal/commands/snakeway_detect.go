@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// smartQuestionsMsg delivers an LLM classification of the content's
+// questions. gen ties it to the detection run that produced it so a
+// late answer for older content doesn't clobber a newer one.
+type smartQuestionsMsg struct {
+	gen       int
+	questions []Question
+	err       error
+}
+
+// smartDetectQuestions classifies questions in the background so the user
+// can keep reading while the model works
+func smartDetectQuestions(model, content string, gen int) tea.Cmd {
+	return func() tea.Msg {
+		questions, err := classifyQuestions(model, content)
+		return smartQuestionsMsg{gen: gen, questions: questions, err: err}
+	}
+}
+
+// classifyQuestions asks model which numbered lines are questions for the
+// reader. Unlike detectQuestions it skips code comments and rhetorical
+// asides, and catches requests phrased without a "?".
+func classifyQuestions(model, content string) ([]Question, error) {
+	lines := strings.Split(content, "\n")
+
+	var numbered strings.Builder
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.Trim(trimmed, "─═") == "" {
+			continue
+		}
+		fmt.Fprintf(&numbered, "%d: %s\n", i, trimmed)
+	}
+
+	prompt := `Below is a conversation with numbered lines. Identify the lines where the
+assistant asks the user something they are expected to answer. Include
+implicit questions such as "Tell me what you think about X" or "Let me know
+which option you prefer". Exclude code, code comments, quoted text, and
+rhetorical questions the text answers itself.
+
+Reply with JSON only, in the form {"lines": [3, 17]}.
+
+` + numbered.String()
+
+	reqBody := map[string]interface{}{
+		"model":  model,
+		"prompt": prompt,
+		"stream": false,
+		"format": "json",
+		"options": map[string]interface{}{
+			"temperature": 0,
+		},
+	}
+	jsonBody, _ := json.Marshal(reqBody)
+
+	client := &http.Client{Timeout: 120 * time.Second}
+	resp, err := client.Post("http://localhost:11434/api/generate", "application/json", bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ollama returned %s", resp.Status)
+	}
+
+	var result struct {
+		Response string `json:"response"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	var answer struct {
+		Lines []int `json:"lines"`
+	}
+	if err := json.Unmarshal([]byte(result.Response), &answer); err != nil {
+		return nil, fmt.Errorf("parsing classification: %w", err)
+	}
+
+	return questionsAtLines(lines, answer.Lines), nil
+}
+
+// questionsAtLines builds Questions for the given line indices, ignoring
+// duplicates and indices the model invented
+func questionsAtLines(lines []string, indices []int) []Question {
+	sort.Ints(indices)
+
+	var questions []Question
+	last := -1
+	for _, i := range indices {
+		if i < 0 || i >= len(lines) || i == last {
+			continue
+		}
+		last = i
+		text := strings.TrimSpace(lines[i])
+		if text == "" {
+			continue
+		}
+		questions = append(questions, Question{
+			Index: len(questions),
+			Text:  text,
+			State: "awaiting",
+			Line:  i,
+		})
+	}
+	return questions
+}
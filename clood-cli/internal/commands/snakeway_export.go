@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// exportConversation writes the conversation to --export and notes the
+// result for the footer
+func (m *snakewayModel) exportConversation() error {
+	md := renderConversationMarkdown(m.content, m.questions, m.modelName, m.bookmarkSnippets())
+	if err := os.WriteFile(m.exportPath, []byte(md), 0644); err != nil {
+		m.exportStatus = "export failed: " + err.Error()
+		return err
+	}
+	m.exportStatus = "exported to " + m.exportPath
+	return nil
+}
+
+// carryAnswers keeps the responses from prev on the matching questions in
+// next, so re-detecting after a new turn doesn't lose what was answered
+func carryAnswers(prev, next []Question) []Question {
+	answered := map[string][]Question{}
+	for _, q := range prev {
+		if q.State != "" && q.State != "awaiting" {
+			answered[q.Text] = append(answered[q.Text], q)
+		}
+	}
+	for i := range next {
+		if qs := answered[next[i].Text]; len(qs) > 0 {
+			next[i].Response = qs[0].Response
+			next[i].State = qs[0].State
+			answered[next[i].Text] = qs[1:]
+		}
+	}
+	return next
+}
+
+// renderConversationMarkdown turns the transcript into Markdown: a summary
+// of every question and its answer, then the conversation with each
+// question highlighted and its answer inline. Bookmarks, if any, are listed
+// between the two by their first sentence.
+func renderConversationMarkdown(content string, questions []Question, model string, bookmarks []string) string {
+	var sb strings.Builder
+
+	sb.WriteString("# Snake Way Conversation\n\n")
+	sb.WriteString(fmt.Sprintf("_Exported %s", time.Now().Format("2006-01-02 15:04")))
+	if model != "" {
+		sb.WriteString(" · model " + model)
+	}
+	sb.WriteString("_\n\n")
+
+	answered := 0
+	for _, q := range questions {
+		if q.State == "answered" {
+			answered++
+		}
+	}
+
+	sb.WriteString("## Questions\n\n")
+	if len(questions) == 0 {
+		sb.WriteString("No questions detected.\n\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("%d of %d answered.\n\n", answered, len(questions)))
+		for i, q := range questions {
+			sb.WriteString(fmt.Sprintf("%d. **%s**\n", i+1, q.Text))
+			sb.WriteString("   " + markdownAnswer(q) + "\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(bookmarks) > 0 {
+		sb.WriteString("## Bookmarks\n\n")
+		for i, b := range bookmarks {
+			sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, b))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("## Conversation\n\n")
+
+	// Questions are matched to lines by text, in order, so repeated
+	// questions pair up with their own answers
+	byText := map[string][]int{}
+	for i, q := range questions {
+		byText[q.Text] = append(byText[q.Text], i)
+	}
+
+	lines := strings.Split(content, "\n")
+	for i := 0; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+
+		// Banners are a title between two rules; a lone ─ rule ends a turn
+		if rule := ruleChar(trimmed); rule != "" {
+			if i+2 < len(lines) && ruleChar(strings.TrimSpace(lines[i+2])) == rule {
+				sb.WriteString("### " + strings.TrimSpace(lines[i+1]) + "\n")
+				i += 2
+			} else if rule == "─" {
+				sb.WriteString("---\n")
+			}
+			continue
+		}
+
+		if idx := byText[trimmed]; len(idx) > 0 {
+			q := questions[idx[0]]
+			byText[trimmed] = idx[1:]
+			sb.WriteString(fmt.Sprintf("> **Q%d: %s**\n>\n> %s\n\n", idx[0]+1, q.Text, markdownAnswer(q)))
+			continue
+		}
+
+		sb.WriteString(lines[i] + "\n")
+	}
+
+	return strings.TrimRight(sb.String(), "\n") + "\n"
+}
+
+func markdownAnswer(q Question) string {
+	if q.State == "answered" && q.Response != "" {
+		return "**Answer:** " + q.Response
+	}
+	state := q.State
+	if state == "" || state == "awaiting" {
+		state = "unanswered"
+	}
+	return "_(" + state + ")_"
+}
+
+// ruleChar returns the character a horizontal rule line is drawn with, or
+// "" if s isn't one
+func ruleChar(s string) string {
+	for _, ch := range []string{"═", "─"} {
+		if len(s) >= 3*len(ch) && strings.Trim(s, ch) == "" {
+			return ch
+		}
+	}
+	return ""
+}
@@ -57,8 +57,24 @@ type snakewayModel struct {
 	history           []ChatMessage // Conversation history for context
 	currentAssistant  string // Accumulates current assistant response
 	promptMode        bool   // Initial prompt entry mode
+	smartDetect       bool   // Reclassify questions with detectModel
+	detectModel       string // Fast model used by --smart-detect
+	detecting         bool   // Smart detection in flight
+	detectGen         int    // Bumped per detection run to drop stale results
+	exportPath        string // --export Markdown file (ctrl+s, or on esc)
+	exportStatus      string // Result of the last export, shown in the footer
+	costPerMillion    float64 // --cost-per-million, USD per 1M tokens for the status bar
+	bookmarks         []int   // Rendered lines marked with ctrl+m, in order
+	showBookmarks     bool    // Show bookmarks overlay
 }
 
+// Token counts past these turn the status bar meter yellow, then red
+const (
+	swTokensWarn  = 8000
+	swTokensAlarm = 32000
+)
+
+
 // Styles
 var (
 	swHeaderStyle = lipgloss.NewStyle().
@@ -94,6 +110,10 @@ func SnakewayProtoCmd() *cobra.Command {
 	var live bool
 	var stream bool
 	var model string
+	var smartDetect bool
+	var detectModel string
+	var exportPath string
+	var costPerMillion float64
 
 	cmd := &cobra.Command{
 		Use:   "snakeway-proto",
@@ -110,6 +130,22 @@ Modes:
 - --live: Generate from ollama (waits for completion)
 - --stream: REALTIME streaming (scroll while generating!)
 
+Questions are lines ending in ?. With --smart-detect, a fast local model
+(--detect-model) reclassifies them once content is complete, skipping code
+comments and catching implicit asks like "Tell me what you think".
+
+With --export, ctrl+s writes the conversation as Markdown (a summary of
+questions and answers, then the transcript with answers inline), and
+quitting with esc writes it once more. Bookmarks are exported too.
+
+Bookmarks: ctrl+m (with nothing typed) marks the line at the top of the
+view, or unmarks it; b lists bookmarks with their first sentence, and 1-9
+in that list jumps back to one.
+
+The status bar shows a running token estimate for the conversation (plus
+what's typed but not sent) and its cost at --cost-per-million, which is
+$0 for local models; set it when routing to a paid endpoint.
+
 No input zones yet - just navigation.`,
 		Run: func(cmd *cobra.Command, args []string) {
 			var content string
@@ -142,11 +178,21 @@ No input zones yet - just navigation.`,
 				modelName:  model,
 				history:    []ChatMessage{},
 				promptMode: promptMode,
+				smartDetect: smartDetect,
+				detectModel: detectModel,
+				detecting:   smartDetect && content != "",
+				exportPath:  exportPath,
+				costPerMillion: costPerMillion,
 			}
 
 			p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
-			if _, err := p.Run(); err != nil {
+			final, err := p.Run()
+			if err != nil {
 				fmt.Println(tui.ErrorStyle.Render("Error: " + err.Error()))
+				return
+			}
+			if fm, ok := final.(snakewayModel); ok && fm.exportStatus != "" {
+				fmt.Println(tui.MutedStyle.Render(fm.exportStatus))
 			}
 		},
 	}
@@ -154,6 +200,10 @@ No input zones yet - just navigation.`,
 	cmd.Flags().BoolVar(&live, "live", false, "Generate live content from ollama (blocking)")
 	cmd.Flags().BoolVar(&stream, "stream", false, "REALTIME streaming - scroll while generating")
 	cmd.Flags().StringVar(&model, "model", "qwen2.5-coder:3b", "Model to use for generation")
+	cmd.Flags().BoolVar(&smartDetect, "smart-detect", false, "Classify questions with an LLM instead of the ? heuristic")
+	cmd.Flags().StringVar(&detectModel, "detect-model", "qwen2.5-coder:3b", "Model used by --smart-detect")
+	cmd.Flags().StringVar(&exportPath, "export", "", "Markdown file to export the conversation to (ctrl+s, or on esc)")
+	cmd.Flags().Float64Var(&costPerMillion, "cost-per-million", 0, "USD per 1M tokens shown in the status bar (0 for local models)")
 
 	return cmd
 }
@@ -162,9 +212,20 @@ func (m snakewayModel) Init() tea.Cmd {
 	if m.streaming && m.streamChan != nil {
 		return waitForStream(m.streamChan)
 	}
+	if m.smartDetect && m.content != "" {
+		// Static and --live content is already complete
+		return smartDetectQuestions(m.detectModel, m.content, m.detectGen)
+	}
 	return nil
 }
 
+// startSmartDetect kicks off background classification of the current content
+func (m *snakewayModel) startSmartDetect() tea.Cmd {
+	m.detectGen++
+	m.detecting = true
+	return smartDetectQuestions(m.detectModel, m.content, m.detectGen)
+}
+
 // waitForStream returns a command that waits for the next stream chunk
 func waitForStream(ch chan string) tea.Cmd {
 	return func() tea.Msg {
@@ -206,7 +267,24 @@ func (m snakewayModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.content += "\n───────────────────────────────────────────────────────────────────\n"
 		m.viewport.SetContent(m.renderContent())
 		// Re-detect questions now that content is complete
-		m.questions = detectQuestions(m.content)
+		m.questions = carryAnswers(m.questions, detectQuestions(m.content))
+		if m.smartDetect {
+			cmds = append(cmds, m.startSmartDetect())
+		}
+
+	case smartQuestionsMsg:
+		if msg.gen != m.detectGen {
+			break
+		}
+		m.detecting = false
+		if msg.err != nil {
+			// Keep the heuristic results
+			break
+		}
+		m.questions = carryAnswers(m.questions, msg.questions)
+		if m.currentQ >= len(m.questions) {
+			m.currentQ = max(len(m.questions)-1, 0)
+		}
 
 	case streamErrorMsg:
 		m.content += fmt.Sprintf("\nERROR: %v\n", msg)
@@ -224,11 +302,23 @@ func (m snakewayModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.inputBuffer != "" {
 				m.inputBuffer = ""
 			} else {
+				if m.exportPath != "" {
+					m.exportConversation()
+				}
 				return m, tea.Quit
 			}
 
+		case "ctrl+s":
+			if m.exportPath != "" {
+				m.exportConversation()
+			}
+
 		case "enter":
-			if m.inputBuffer != "" && !m.streaming {
+			// ctrl+m reaches us as enter (terminals send both as CR), so
+			// with nothing typed it bookmarks the top line
+			if m.inputBuffer == "" && !m.promptMode {
+				m.toggleBookmark()
+			} else if m.inputBuffer != "" && !m.streaming {
 				if m.promptMode {
 					// Start streaming with user's prompt
 					m.startWithPrompt()
@@ -269,15 +359,29 @@ func (m snakewayModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Toggle question summary overlay
 			m.showSummary = !m.showSummary
 			m.showContext = false
+			m.showBookmarks = false
 
 		case "ctrl+k":
 			// Toggle context shorthand overlay
 			m.showContext = !m.showContext
 			m.showSummary = false
+			m.showBookmarks = false
+
+		case "b":
+			// Toggle bookmarks overlay, or type the letter
+			if m.inputBuffer == "" {
+				m.showBookmarks = !m.showBookmarks
+				m.showSummary = false
+				m.showContext = false
+			} else {
+				m.inputBuffer += key
+			}
 
 		case "1", "2", "3", "4", "5", "6", "7", "8", "9":
-			// Only jump to questions if input is empty, otherwise type the number
-			if m.inputBuffer == "" && len(m.questions) > 0 {
+			// Only jump if input is empty, otherwise type the number
+			if m.inputBuffer == "" && m.showBookmarks {
+				m.gotoBookmark(int(key[0] - '1'))
+			} else if m.inputBuffer == "" && len(m.questions) > 0 {
 				idx := int(key[0] - '1')
 				if idx < len(m.questions) {
 					m.currentQ = idx
@@ -458,37 +562,7 @@ func streamChatWithHistory(model string, history []ChatMessage, ch chan string)
 
 func (m snakewayModel) renderContent() string {
 	var sb strings.Builder
-
-	// Calculate content width and padding
-	contentWidth := 70
-	if m.width > 20 && m.width < contentWidth+20 {
-		contentWidth = m.width - 10
-	}
-	leftPad := ""
-	if m.width > contentWidth+10 {
-		leftPad = strings.Repeat(" ", (m.width-contentWidth)/2)
-	}
-
-	// First, wrap the raw content to our content width
-	// Split by existing newlines, wrap each paragraph
-	paragraphs := strings.Split(m.content, "\n")
-	var wrappedLines []string
-	for _, para := range paragraphs {
-		// Don't wrap formatting lines
-		if strings.HasPrefix(para, "═══") || strings.HasPrefix(para, "───") ||
-			strings.HasPrefix(para, "TURN") || strings.HasPrefix(para, "STREAMING") ||
-			strings.HasPrefix(para, "FLYING") || strings.HasPrefix(para, "ADDITIONAL") ||
-			strings.HasPrefix(para, "END OF") || strings.HasPrefix(para, "YOU ARE") ||
-			strings.HasPrefix(para, "USER") || strings.HasPrefix(para, "ASSISTANT") || para == "" {
-			wrappedLines = append(wrappedLines, para)
-		} else {
-			// Wrap this paragraph
-			wrapped := wordWrap(para, contentWidth)
-			for _, wl := range strings.Split(wrapped, "\n") {
-				wrappedLines = append(wrappedLines, wl)
-			}
-		}
-	}
+	wrappedLines, leftPad := m.wrappedLines()
 
 	for i, line := range wrappedLines {
 		// Check if this line is a question marker
@@ -542,6 +616,42 @@ func (m snakewayModel) renderContent() string {
 	return sb.String()
 }
 
+// wrappedLines is the content as the viewport shows it, wrapped to the
+// content width, with the padding that centers it
+func (m snakewayModel) wrappedLines() ([]string, string) {
+	contentWidth := 70
+	if m.width > 20 && m.width < contentWidth+20 {
+		contentWidth = m.width - 10
+	}
+	leftPad := ""
+	if m.width > contentWidth+10 {
+		leftPad = strings.Repeat(" ", (m.width-contentWidth)/2)
+	}
+
+	// First, wrap the raw content to our content width
+	// Split by existing newlines, wrap each paragraph
+	paragraphs := strings.Split(m.content, "\n")
+	var wrappedLines []string
+	for _, para := range paragraphs {
+		// Don't wrap formatting lines
+		if strings.HasPrefix(para, "═══") || strings.HasPrefix(para, "───") ||
+			strings.HasPrefix(para, "TURN") || strings.HasPrefix(para, "STREAMING") ||
+			strings.HasPrefix(para, "FLYING") || strings.HasPrefix(para, "ADDITIONAL") ||
+			strings.HasPrefix(para, "END OF") || strings.HasPrefix(para, "YOU ARE") ||
+			strings.HasPrefix(para, "USER") || strings.HasPrefix(para, "ASSISTANT") || para == "" {
+			wrappedLines = append(wrappedLines, para)
+		} else {
+			// Wrap this paragraph
+			wrapped := wordWrap(para, contentWidth)
+			for _, wl := range strings.Split(wrapped, "\n") {
+				wrappedLines = append(wrappedLines, wl)
+			}
+		}
+	}
+
+	return wrappedLines, leftPad
+}
+
 func (m snakewayModel) renderPromptMode() string {
 	var sb strings.Builder
 
@@ -640,10 +750,7 @@ func (m snakewayModel) renderContextOverlay() string {
 	sb.WriteString("\n")
 	sb.WriteString(strings.Repeat("─", boxWidth))
 	sb.WriteString("\n")
-	tokenEst := 0
-	for _, msg := range m.history {
-		tokenEst += len(msg.Content) / 4 // Rough estimate: 4 chars per token
-	}
+	tokenEst := m.contextTokens()
 	summaryStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
 	sb.WriteString(summaryStyle.Render(fmt.Sprintf("  ~%d tokens in context | %d messages", tokenEst, len(m.history))))
 	sb.WriteString("\n")
@@ -737,7 +844,11 @@ func (m snakewayModel) renderSummaryOverlay() string {
 
 	if len(m.questions) == 0 {
 		sb.WriteString("  No questions detected yet.\n")
-		sb.WriteString("  (Questions are lines ending with ?)\n")
+		if m.smartDetect {
+			sb.WriteString(fmt.Sprintf("  (Questions are classified by %s)\n", m.detectModel))
+		} else {
+			sb.WriteString("  (Questions are lines ending with ?)\n")
+		}
 	} else {
 		answered := 0
 		for i, q := range m.questions {
@@ -837,6 +948,10 @@ func (m snakewayModel) View() string {
 		return m.renderContextOverlay()
 	}
 
+	if m.showBookmarks {
+		return m.renderBookmarksOverlay()
+	}
+
 	// Header
 	title := swHeaderStyle.Render("🐍 SNAKE WAY")
 
@@ -855,6 +970,13 @@ func (m snakewayModel) View() string {
 	if len(m.questions) > 0 {
 		statusParts = append(statusParts, swQuestionStyle.Render(fmt.Sprintf(" Q%d/%d", m.currentQ+1, len(m.questions))))
 	}
+	if len(m.bookmarks) > 0 {
+		statusParts = append(statusParts, swHelpStyle.Render(fmt.Sprintf(" 🔖%d", len(m.bookmarks))))
+	}
+	if m.detecting {
+		statusParts = append(statusParts, swStateAwaitingStyle.Render(" ⋯ classifying"))
+	}
+	statusParts = append(statusParts, m.renderTokenMeter())
 
 	header := fmt.Sprintf("%s%s%s\n%s\n",
 		title, modelInfo, strings.Join(statusParts, ""),
@@ -890,7 +1012,13 @@ func (m snakewayModel) View() string {
 	} else if m.inputBuffer != "" {
 		helpText = "[enter]send [esc]clear [F]ollow"
 	} else {
-		helpText = "[Tab]Q's [^K]context [1-9]jump [F]ollow"
+		helpText = "[Tab]Q's [^K]context [1-9]jump [F]ollow [^M]mark [b]ookmarks"
+		if m.exportPath != "" {
+			helpText += " [^S]export"
+		}
+	}
+	if m.exportStatus != "" {
+		helpText += "  " + m.exportStatus
 	}
 
 	footer = fmt.Sprintf("\n%s%s%s\n%s %s█\n%s",
@@ -904,6 +1032,42 @@ func (m snakewayModel) View() string {
 	return header + m.viewport.View() + footer
 }
 
+// contextTokens estimates the tokens sent with the next request: the
+// history plus any reply still streaming in
+func (m snakewayModel) contextTokens() int {
+	tokens := len(m.currentAssistant) / 4 // Rough estimate: 4 chars per token
+	for _, msg := range m.history {
+		tokens += len(msg.Content) / 4
+	}
+	return tokens
+}
+
+// renderTokenMeter is the status bar's token count and cost. Typed but
+// unsent input shows as +N, and the meter changes colour as it grows.
+func (m snakewayModel) renderTokenMeter() string {
+	tokens := m.contextTokens()
+	pending := len(m.inputBuffer) / 4
+
+	color := "#00BFFF"
+	switch {
+	case tokens+pending >= swTokensAlarm:
+		color = "#FF6B6B"
+	case tokens+pending >= swTokensWarn:
+		color = "#FFD700"
+	}
+
+	meter := fmt.Sprintf(" ~%d tok", tokens)
+	if pending > 0 {
+		meter += fmt.Sprintf(" +%d", pending)
+	}
+	if m.costPerMillion > 0 {
+		meter += fmt.Sprintf(" · $%.4f", float64(tokens+pending)*m.costPerMillion/1e6)
+	} else {
+		meter += " · $0.00 local"
+	}
+	return lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(color)).Render(meter)
+}
+
 // generateTestConversation creates fake conversation data with questions
 func generateTestConversation() (string, []Question) {
 	var sb strings.Builder
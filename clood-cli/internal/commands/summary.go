@@ -1,11 +1,11 @@
 package commands
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 
+	"github.com/dirtybirdnj/clood/internal/output"
 	"github.com/dirtybirdnj/clood/internal/tui"
 	"github.com/spf13/cobra"
 )
@@ -23,19 +23,45 @@ type ProjectSummary struct {
 }
 
 func SummaryCmd() *cobra.Command {
-	var jsonOutput bool
+	var level string
+	var model string
 
 	cmd := &cobra.Command{
 		Use:   "summary [path]",
 		Short: "Generate project structure summary",
-		Long:  "Detect project type and generate a JSON summary for LLM context.",
-		Args:  cobra.MaximumNArgs(1),
+		Long: `Detect project type and generate a JSON summary for LLM context.
+
+With --level, the summary, context and symbols output is handed to the
+writing tier to write a summary for one audience:
+  exec    2-3 sentences for non-technical readers
+  tech    one-paragraph architecture overview
+  detail  per-package summaries and key functions, about a page`,
+		Example: `  clood summary
+  clood summary --level exec
+  clood summary ./internal --level detail --json`,
+		Args: cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
+			jsonOutput := output.IsJSON()
 			path := "."
 			if len(args) > 0 {
 				path = args[0]
 			}
 
+			if level != "" {
+				ls, err := generateLevelSummary(path, level, model)
+				if err != nil {
+					fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render(err.Error()))
+					return
+				}
+				if jsonOutput {
+					data, _ := output.Marshal(ls)
+					fmt.Println(string(data))
+				} else {
+					fmt.Println(ls.Summary)
+				}
+				return
+			}
+
 			summary, err := generateSummary(path)
 			if err != nil {
 				fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render(err.Error()))
@@ -43,15 +69,16 @@ func SummaryCmd() *cobra.Command {
 			}
 
 			if jsonOutput {
-				output, _ := json.MarshalIndent(summary, "", "  ")
-				fmt.Println(string(output))
+				data, _ := output.Marshal(summary)
+				fmt.Println(string(data))
 			} else {
 				printSummary(summary)
 			}
 		},
 	}
 
-	cmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Output as JSON")
+	cmd.Flags().StringVar(&level, "level", "", "Write a model summary: exec, tech, detail")
+	cmd.Flags().StringVarP(&model, "model", "m", "", "Override model (default: writing tier)")
 
 	return cmd
 }
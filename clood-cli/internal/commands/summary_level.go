@@ -0,0 +1,175 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dirtybirdnj/clood/internal/config"
+	"github.com/dirtybirdnj/clood/internal/router"
+	"github.com/dirtybirdnj/clood/internal/tui"
+)
+
+// SummaryLevels are the audiences 'clood summary --level' writes for
+var SummaryLevels = []string{"exec", "tech", "detail"}
+
+// summaryPackageSymbols caps the symbols listed per package at the detail
+// level so one large package doesn't crowd out the rest
+const summaryPackageSymbols = 25
+
+// LevelSummary is a model-written summary for one audience
+type LevelSummary struct {
+	Level   string `json:"level"`
+	Path    string `json:"path"`
+	Model   string `json:"model"`
+	Summary string `json:"summary"`
+}
+
+var summaryLevelSystemPrompts = map[string]string{
+	"exec": `You are writing for executives and other non-technical readers.
+Write 2-3 plain sentences saying what the project is, who it is for and why it matters.
+Do not use jargon, package names, file names, function names or technology names unless
+the project cannot be described without them. No headings, lists or code.`,
+
+	"tech": `You are writing for engineers who are new to this codebase.
+Write one paragraph giving an architecture overview: the main components, how they
+fit together, how data or control flows between them, and the key technologies used.
+Name packages and directories where it helps. No headings, lists or code blocks.`,
+
+	"detail": `You are writing reference notes for engineers who will work in this codebase.
+Write about one page of Markdown: a short overview, then one "###" section per package
+with a sentence or two on its responsibility and a bullet list of its key functions or
+types and what each does. Only describe symbols that appear in the context; do not invent any.`,
+}
+
+// generateLevelSummary gathers summary, context and symbol output for path
+// and asks the writing tier to summarise it for level's audience
+func generateLevelSummary(path, level, model string) (*LevelSummary, error) {
+	system, ok := summaryLevelSystemPrompts[level]
+	if !ok {
+		return nil, fmt.Errorf("unknown level %q (want %s)", level, strings.Join(SummaryLevels, ", "))
+	}
+
+	prompt, err := buildLevelSummaryPrompt(path, level)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+	r := router.NewRouter(cfg)
+	route, err := r.Route("summarize project", router.TierWriting, model)
+	if err != nil {
+		return nil, fmt.Errorf("routing: %w", err)
+	}
+	if route.Client == nil {
+		return nil, fmt.Errorf("no available host with model %s", route.Model)
+	}
+
+	fmt.Fprintf(os.Stderr, "%s %s on %s\n", tui.MutedStyle.Render("Summarizing ("+level+"):"), route.Model, route.Host.Host.Name)
+	resp, err := route.Client.GenerateWithSystem(route.Model, system, prompt)
+	if err != nil {
+		return nil, fmt.Errorf("generating summary: %w", err)
+	}
+
+	abs, _ := filepath.Abs(path)
+	return &LevelSummary{
+		Level:   level,
+		Path:    abs,
+		Model:   route.Model,
+		Summary: strings.TrimSpace(resp.Response),
+	}, nil
+}
+
+// buildLevelSummaryPrompt joins the project summary, README and focus
+// context for path. The detail level also gets symbols grouped by package.
+func buildLevelSummaryPrompt(path, level string) (string, error) {
+	summary, err := generateSummary(path)
+	if err != nil {
+		return "", err
+	}
+	fc, err := buildFocusContext(path)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	data, _ := json.MarshalIndent(summary, "", "  ")
+	sb.WriteString("## Project summary\n")
+	sb.Write(data)
+	sb.WriteString("\n\n")
+
+	if readme := readSummaryDoc(fc.Path); readme != "" {
+		sb.WriteString("## README\n")
+		sb.WriteString(readme)
+		sb.WriteString("\n\n")
+	}
+
+	sb.WriteString(fc.Context)
+	sb.WriteString("\n")
+
+	if level == "detail" {
+		sb.WriteString("\n## Symbols by package\n")
+		sb.WriteString(packageSymbolListing(fc.Path))
+	}
+
+	sb.WriteString("\nSummarize this project for the audience described in your instructions.")
+	return sb.String(), nil
+}
+
+// readSummaryDoc returns the start of the project's README or agent notes
+func readSummaryDoc(dir string) string {
+	for _, name := range []string{"README.md", "CLAUDE.md", "AGENTS.md"} {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		s := string(content)
+		if len(s) > 2000 {
+			s = s[:2000] + "\n... (truncated)"
+		}
+		return s
+	}
+	return ""
+}
+
+// packageSymbolListing lists exported functions, types and classes per
+// directory, skipping tests
+func packageSymbolListing(dir string) string {
+	byPackage := map[string][]Symbol{}
+	for _, sym := range extractSymbols(dir, true, "") {
+		if strings.HasSuffix(sym.File, "_test.go") || sym.Kind == "const" || sym.Kind == "var" {
+			continue
+		}
+		rel, err := filepath.Rel(dir, filepath.Dir(sym.File))
+		if err != nil {
+			rel = filepath.Dir(sym.File)
+		}
+		byPackage[rel] = append(byPackage[rel], sym)
+	}
+
+	var pkgs []string
+	for pkg := range byPackage {
+		pkgs = append(pkgs, pkg)
+	}
+	sort.Strings(pkgs)
+
+	var sb strings.Builder
+	for _, pkg := range pkgs {
+		syms := byPackage[pkg]
+		sb.WriteString(pkg + ":\n")
+		for i, sym := range syms {
+			if i == summaryPackageSymbols {
+				sb.WriteString(fmt.Sprintf("  ... and %d more\n", len(syms)-i))
+				break
+			}
+			sb.WriteString(fmt.Sprintf("  %s %s\n", sym.Kind, sym.Name))
+		}
+	}
+	return sb.String()
+}
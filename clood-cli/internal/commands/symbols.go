@@ -2,13 +2,14 @@ package commands
 
 import (
 	"bufio"
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
 
+	"github.com/dirtybirdnj/clood/internal/analyze"
+	"github.com/dirtybirdnj/clood/internal/output"
 	"github.com/dirtybirdnj/clood/internal/tui"
 	"github.com/spf13/cobra"
 )
@@ -22,7 +23,6 @@ type Symbol struct {
 }
 
 func SymbolsCmd() *cobra.Command {
-	var jsonOutput bool
 	var exportedOnly bool
 	var kindFilter string
 
@@ -32,6 +32,7 @@ func SymbolsCmd() *cobra.Command {
 		Long:  "Parse source files and extract function/type/class definitions.",
 		Args:  cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
+			jsonOutput := output.IsJSON()
 			path := "."
 			if len(args) > 0 {
 				path = args[0]
@@ -40,17 +41,16 @@ func SymbolsCmd() *cobra.Command {
 			symbols := extractSymbols(path, exportedOnly, kindFilter)
 
 			if jsonOutput {
-				output, _ := json.MarshalIndent(symbols, "", "  ")
-				fmt.Println(string(output))
+				data, _ := output.Marshal(symbols)
+				fmt.Println(string(data))
 			} else {
 				printSymbols(symbols)
 			}
 		},
 	}
 
-	cmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Output as JSON")
 	cmd.Flags().BoolVarP(&exportedOnly, "exported", "e", false, "Only show exported symbols")
-	cmd.Flags().StringVarP(&kindFilter, "type", "t", "", "Filter by kind (func, type, const, var)")
+	cmd.Flags().StringVarP(&kindFilter, "type", "t", "", "Filter by kind (func, type, class, const, var, trait, impl)")
 
 	return cmd
 }
@@ -75,7 +75,9 @@ func extractSymbols(path string, exportedOnly bool, kindFilter string) []Symbol
 		case ".py":
 			symbols = append(symbols, parsePythonFile(p, kindFilter)...)
 		case ".js", ".ts", ".jsx", ".tsx":
-			symbols = append(symbols, parseJSFile(p, exportedOnly, kindFilter)...)
+			symbols = append(symbols, parseLineSymbols(p, exportedOnly, kindFilter, analyze.JSSymbol)...)
+		case ".rs":
+			symbols = append(symbols, parseLineSymbols(p, exportedOnly, kindFilter, analyze.RustSymbol)...)
 		}
 
 		return nil
@@ -188,7 +190,9 @@ func parsePythonFile(path string, kindFilter string) []Symbol {
 	return symbols
 }
 
-func parseJSFile(path string, exportedOnly bool, kindFilter string) []Symbol {
+// parseLineSymbols runs a single-line matcher such as analyze.JSSymbol
+// over every line of path.
+func parseLineSymbols(path string, exportedOnly bool, kindFilter string, match func(string) (string, string, bool, bool)) []Symbol {
 	var symbols []Symbol
 
 	file, err := os.Open(path)
@@ -197,45 +201,30 @@ func parseJSFile(path string, exportedOnly bool, kindFilter string) []Symbol {
 	}
 	defer file.Close()
 
-	// Simplified patterns - a real implementation would use a proper parser
-	funcPattern := regexp.MustCompile(`(?:export\s+)?(?:async\s+)?function\s+(\w+)`)
-	classPattern := regexp.MustCompile(`(?:export\s+)?class\s+(\w+)`)
-	constPattern := regexp.MustCompile(`(?:export\s+)?const\s+(\w+)`)
-
 	scanner := bufio.NewScanner(file)
 	lineNum := 0
 
 	for scanner.Scan() {
 		lineNum++
-		line := scanner.Text()
-
-		var name, kind string
-		exported := strings.Contains(line, "export")
 
-		if matches := funcPattern.FindStringSubmatch(line); matches != nil {
-			name, kind = matches[1], "func"
-		} else if matches := classPattern.FindStringSubmatch(line); matches != nil {
-			name, kind = matches[1], "class"
-		} else if matches := constPattern.FindStringSubmatch(line); matches != nil {
-			name, kind = matches[1], "const"
+		name, kind, exported, ok := match(scanner.Text())
+		if !ok {
+			continue
 		}
-
-		if name != "" {
-			if exportedOnly && !exported {
-				continue
-			}
-			if kindFilter != "" && kind != kindFilter {
-				continue
-			}
-
-			symbols = append(symbols, Symbol{
-				Name:     name,
-				Kind:     kind,
-				File:     path,
-				Line:     lineNum,
-				Exported: exported,
-			})
+		if exportedOnly && !exported {
+			continue
+		}
+		if kindFilter != "" && kind != kindFilter {
+			continue
 		}
+
+		symbols = append(symbols, Symbol{
+			Name:     name,
+			Kind:     kind,
+			File:     path,
+			Line:     lineNum,
+			Exported: exported,
+		})
 	}
 
 	return symbols
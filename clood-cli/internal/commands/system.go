@@ -1,8 +1,8 @@
 package commands
 
 import (
-	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/dirtybirdnj/clood/internal/output"
 	"github.com/dirtybirdnj/clood/internal/system"
@@ -11,7 +11,9 @@ import (
 )
 
 func SystemCmd() *cobra.Command {
-	var jsonOutput bool
+	var live bool
+	var host string
+	var interval time.Duration
 
 	cmd := &cobra.Command{
 		Use:   "system",
@@ -22,7 +24,11 @@ func SystemCmd() *cobra.Command {
   - GPU type and VRAM
   - All disk storage with usage
   - Ollama models directory location and headroom
-  - Model recommendations based on hardware`,
+  - Model recommendations based on hardware
+
+--live instead watches Ollama's loaded models (/api/ps) and their VRAM
+footprint, redrawing every --interval. Useful for seeing which model is
+occupying VRAM when a new load is slow.`,
 		Run: func(cmd *cobra.Command, args []string) {
 			hw, err := system.DetectHardware()
 			if err != nil {
@@ -30,9 +36,13 @@ func SystemCmd() *cobra.Command {
 				return
 			}
 
-			// Check both local --json and global -j flag
-			if jsonOutput || output.IsJSON() {
-				data, _ := json.MarshalIndent(hw.JSON(), "", "  ")
+			if live {
+				runLiveOllamaMonitor(hw, host, interval, output.IsJSON())
+				return
+			}
+
+			if output.IsJSON() {
+				data, _ := output.Marshal(hw.JSON())
 				fmt.Println(string(data))
 				return
 			}
@@ -79,7 +89,9 @@ func SystemCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	cmd.Flags().BoolVar(&live, "live", false, "Watch loaded models and VRAM usage")
+	cmd.Flags().StringVar(&host, "host", "http://localhost:11434", "Ollama host to watch with --live")
+	cmd.Flags().DurationVar(&interval, "interval", 2*time.Second, "Refresh interval for --live")
 
 	return cmd
 }
@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/dirtybirdnj/clood/internal/hosts"
+	"github.com/dirtybirdnj/clood/internal/ollama"
+	"github.com/dirtybirdnj/clood/internal/output"
+	"github.com/dirtybirdnj/clood/internal/system"
+	"github.com/dirtybirdnj/clood/internal/tui"
+)
+
+// runLiveOllamaMonitor polls /api/ps and redraws the loaded models in place
+// until interrupted. With jsonOut it prints one snapshot instead.
+func runLiveOllamaMonitor(hw *system.HardwareInfo, host string, interval time.Duration, jsonOut bool) {
+	client := ollama.NewClient(host, 5*time.Second)
+
+	if jsonOut {
+		running, err := client.RunningModels()
+		if err != nil {
+			output.MustJSON(map[string]interface{}{"host": host, "error": err.Error()})
+			return
+		}
+		output.MustJSON(map[string]interface{}{"host": host, "models": running})
+		return
+	}
+
+	// Clear screen and hide cursor
+	fmt.Print("\033[2J\033[H\033[?25l")
+	defer fmt.Print("\033[?25h") // Show cursor on exit
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	refresh := func() {
+		fmt.Print("\033[H")
+		fmt.Println(tui.RenderHeader("Ollama Memory - LIVE"))
+		fmt.Println()
+		fmt.Printf("  %s %s\n\n", tui.MutedStyle.Render("Host:"), host)
+
+		running, err := client.RunningModels()
+		if err != nil {
+			fmt.Println("  " + tui.ErrorStyle.Render("✗ "+err.Error()) + "\033[K")
+			fmt.Println(tui.MutedStyle.Render("  /api/ps needs Ollama 0.1.33 or newer") + "\033[K")
+		} else {
+			printRunningModels(running, hw)
+		}
+
+		fmt.Println()
+		fmt.Printf("  %s Refreshing every %s | Press Ctrl+C to exit\033[K\n",
+			tui.MutedStyle.Render(""),
+			interval)
+		fmt.Print("\033[J") // Clear to end of screen
+	}
+
+	refresh()
+
+	for {
+		select {
+		case <-ticker.C:
+			refresh()
+		case <-sigChan:
+			fmt.Println("\n  Stopped.")
+			return
+		}
+	}
+}
+
+// printRunningModels prints a table of loaded models and their footprint.
+// Lines end in an erase-to-end-of-line so a shorter redraw leaves no residue.
+func printRunningModels(running []ollama.RunningModel, hw *system.HardwareInfo) {
+	if len(running) == 0 {
+		fmt.Println(tui.MutedStyle.Render("  No models loaded") + "\033[K")
+		return
+	}
+
+	fmt.Printf("  %-32s %10s %10s %6s  %s\033[K\n", "MODEL", "SIZE", "VRAM", "GPU", "UNLOADS")
+
+	var totalVRAM, totalSize int64
+	for _, m := range running {
+		totalVRAM += m.SizeVRAM
+		totalSize += m.Size
+
+		gpu := "-"
+		if m.Size > 0 {
+			gpu = fmt.Sprintf("%d%%", m.SizeVRAM*100/m.Size)
+		}
+		unloads := "-"
+		if !m.ExpiresAt.IsZero() {
+			if left := time.Until(m.ExpiresAt); left > 0 {
+				unloads = "in " + left.Round(time.Second).String()
+			} else {
+				unloads = "now"
+			}
+		}
+
+		fmt.Printf("  %-32s %10s %10s %6s  %s\033[K\n",
+			truncate(m.Name, 32), hosts.FormatSize(m.Size), hosts.FormatSize(m.SizeVRAM), gpu, tui.MutedStyle.Render(unloads))
+	}
+
+	fmt.Println("\033[K")
+	summary := fmt.Sprintf("%s in VRAM, %s total", hosts.FormatSize(totalVRAM), hosts.FormatSize(totalSize))
+	if hw != nil && hw.OllamaVRAM > 0 {
+		summary += fmt.Sprintf(" (%.0f GB available to Ollama)", hw.OllamaVRAM)
+	}
+	fmt.Printf("  %s %s\033[K\n", tui.MutedStyle.Render("Loaded:"), summary)
+}
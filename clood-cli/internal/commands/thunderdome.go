@@ -1,7 +1,6 @@
 package commands
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"sort"
@@ -131,7 +130,7 @@ Examples:
 				targetHosts = mgr.GetAllHosts()
 			}
 
-			if !output.JSONMode {
+			if !output.IsJSON() {
 				fmt.Println(tui.RenderHeader("THUNDERDOME"))
 				fmt.Println()
 				fmt.Printf("  %s Scanning hosts...\n", tui.AccentStyle.Render(""))
@@ -159,7 +158,7 @@ Examples:
 				return
 			}
 
-			if !output.JSONMode {
+			if !output.IsJSON() {
 				fmt.Printf("  %s %d hosts online, %d total models\n\n",
 					tui.SuccessStyle.Render(""),
 					len(onlineHosts),
@@ -207,7 +206,7 @@ Examples:
 			hostsCompleted := 0
 			for hr := range resultsChan {
 				hostsCompleted++
-				if !output.JSONMode {
+				if !output.IsJSON() {
 					successCount := 0
 					for _, r := range hr.results {
 						if r.Error == "" {
@@ -290,8 +289,8 @@ Examples:
 				Summary:   summary,
 			}
 
-			if output.JSONMode {
-				data, _ := json.MarshalIndent(thunderdomeOutput, "", "  ")
+			if output.IsJSON() {
+				data, _ := output.Marshal(thunderdomeOutput)
 				fmt.Println(string(data))
 				return
 			}
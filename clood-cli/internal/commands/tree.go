@@ -7,6 +7,9 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/dirtybirdnj/clood/internal/gitignore"
+	"github.com/dirtybirdnj/clood/internal/hosts"
+	"github.com/dirtybirdnj/clood/internal/output"
 	"github.com/dirtybirdnj/clood/internal/tui"
 	"github.com/spf13/cobra"
 )
@@ -20,38 +23,79 @@ type TreeNode struct {
 	Children []TreeNode `json:"children,omitempty"`
 }
 
+// treeFilter decides which directory entries the tree hides
+type treeFilter struct {
+	root       string
+	showHidden bool
+	ignore     *gitignore.Matcher
+}
+
+func newTreeFilter(root string, showHidden, noGitignore bool) *treeFilter {
+	f := &treeFilter{root: root, showHidden: showHidden}
+	if !noGitignore {
+		// An unreadable .gitignore just means nothing extra is hidden
+		f.ignore, _ = gitignore.Load(root)
+	}
+	return f
+}
+
+func (f *treeFilter) skip(dir string, entry os.DirEntry) bool {
+	name := entry.Name()
+
+	// Skip hidden unless requested
+	if !f.showHidden && strings.HasPrefix(name, ".") {
+		return true
+	}
+
+	// Skip common ignores even without a .gitignore
+	if name == "node_modules" || name == "vendor" || name == "__pycache__" || name == ".git" {
+		return true
+	}
+
+	if rel, err := filepath.Rel(f.root, filepath.Join(dir, name)); err == nil {
+		return f.ignore.Match(rel, entry.IsDir())
+	}
+	return false
+}
+
 func TreeCmd() *cobra.Command {
 	var depth int
 	var showHidden bool
-	var jsonOutput bool
+	var noGitignore bool
 
 	cmd := &cobra.Command{
 		Use:   "tree [path]",
 		Short: "Display directory tree (respects .gitignore)",
-		Long:  "Smart directory tree that respects .gitignore and shows file metadata.",
-		Args:  cobra.MaximumNArgs(1),
+		Long: `Smart directory tree that respects .gitignore and shows file metadata.
+
+Patterns from the .gitignore at the root of the scanned directory are
+applied during the walk; use --no-gitignore to show everything.`,
+		Args: cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
+			jsonOutput := output.IsJSON()
 			path := "."
 			if len(args) > 0 {
 				path = args[0]
 			}
 
+			filter := newTreeFilter(path, showHidden, noGitignore)
+
 			if jsonOutput {
-				tree, err := buildTree(path, depth, showHidden, 0)
+				tree, err := buildTree(path, depth, filter, 0)
 				if err != nil {
 					errJSON, _ := json.Marshal(map[string]string{"error": err.Error()})
 					fmt.Println(string(errJSON))
 					return
 				}
-				output, _ := json.MarshalIndent(tree, "", "  ")
-				fmt.Println(string(output))
+				data, _ := output.Marshal(tree)
+				fmt.Println(string(data))
 				return
 			}
 
 			fmt.Println(tui.RenderHeader("Directory Tree: " + path))
 			fmt.Println()
 
-			err := printTree(path, "", depth, showHidden, 0)
+			err := printTree(path, "", depth, filter, 0)
 			if err != nil {
 				fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render(err.Error()))
 			}
@@ -60,12 +104,12 @@ func TreeCmd() *cobra.Command {
 
 	cmd.Flags().IntVarP(&depth, "depth", "d", 3, "Maximum depth to traverse")
 	cmd.Flags().BoolVarP(&showHidden, "all", "a", false, "Show hidden files")
-	cmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Output as JSON")
+	cmd.Flags().BoolVar(&noGitignore, "no-gitignore", false, "Don't apply .gitignore rules")
 
 	return cmd
 }
 
-func printTree(path string, prefix string, maxDepth int, showHidden bool, currentDepth int) error {
+func printTree(path string, prefix string, maxDepth int, filter *treeFilter, currentDepth int) error {
 	if maxDepth > 0 && currentDepth >= maxDepth {
 		return nil
 	}
@@ -78,18 +122,9 @@ func printTree(path string, prefix string, maxDepth int, showHidden bool, curren
 	// Filter entries
 	var filtered []os.DirEntry
 	for _, entry := range entries {
-		name := entry.Name()
-
-		// Skip hidden unless requested
-		if !showHidden && strings.HasPrefix(name, ".") {
-			continue
-		}
-
-		// Skip common ignores (simplified - TODO: parse .gitignore)
-		if name == "node_modules" || name == "vendor" || name == "__pycache__" || name == ".git" {
+		if filter.skip(path, entry) {
 			continue
 		}
-
 		filtered = append(filtered, entry)
 	}
 
@@ -109,7 +144,7 @@ func printTree(path string, prefix string, maxDepth int, showHidden bool, curren
 			// Show file size
 			info, err := entry.Info()
 			if err == nil {
-				size := formatSize(info.Size())
+				size := hosts.FormatSize(info.Size())
 				displayName = name + " " + tui.MutedStyle.Render("("+size+")")
 			}
 		}
@@ -121,28 +156,15 @@ func printTree(path string, prefix string, maxDepth int, showHidden bool, curren
 			if isLast {
 				newPrefix = prefix + "    "
 			}
-			printTree(filepath.Join(path, name), newPrefix, maxDepth, showHidden, currentDepth+1)
+			printTree(filepath.Join(path, name), newPrefix, maxDepth, filter, currentDepth+1)
 		}
 	}
 
 	return nil
 }
 
-func formatSize(bytes int64) string {
-	const unit = 1024
-	if bytes < unit {
-		return fmt.Sprintf("%d B", bytes)
-	}
-	div, exp := int64(unit), 0
-	for n := bytes / unit; n >= unit; n /= unit {
-		div *= unit
-		exp++
-	}
-	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
-}
-
 // buildTree constructs a TreeNode hierarchy for JSON output
-func buildTree(path string, maxDepth int, showHidden bool, currentDepth int) (*TreeNode, error) {
+func buildTree(path string, maxDepth int, filter *treeFilter, currentDepth int) (*TreeNode, error) {
 	absPath, err := filepath.Abs(path)
 	if err != nil {
 		return nil, err
@@ -176,20 +198,12 @@ func buildTree(path string, maxDepth int, showHidden bool, currentDepth int) (*T
 	}
 
 	for _, entry := range entries {
-		name := entry.Name()
-
-		// Skip hidden unless requested
-		if !showHidden && strings.HasPrefix(name, ".") {
-			continue
-		}
-
-		// Skip common ignores
-		if name == "node_modules" || name == "vendor" || name == "__pycache__" || name == ".git" {
+		if filter.skip(path, entry) {
 			continue
 		}
 
-		childPath := filepath.Join(absPath, name)
-		child, err := buildTree(childPath, maxDepth, showHidden, currentDepth+1)
+		childPath := filepath.Join(absPath, entry.Name())
+		child, err := buildTree(childPath, maxDepth, filter, currentDepth+1)
 		if err != nil {
 			continue // Skip entries that can't be read
 		}
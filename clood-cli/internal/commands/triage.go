@@ -98,14 +98,14 @@ Examples:
 				repo = detected
 			}
 
-			if !output.JSONMode && !live {
+			if !output.IsJSON() && !live {
 				fmt.Println(tui.RenderHeader("TRIAGE"))
 				fmt.Println()
 				fmt.Printf("  %s %s\n", tui.MutedStyle.Render("Repo:"), repo)
 			}
 
 			// Check Ollama health
-			if !output.JSONMode && !live {
+			if !output.IsJSON() && !live {
 				fmt.Printf("  %s Checking Ollama health...\n", tui.AccentStyle.Render(""))
 			}
 
@@ -125,7 +125,7 @@ Examples:
 				return fmt.Errorf("no online Ollama hosts found")
 			}
 
-			if !output.JSONMode && !live {
+			if !output.IsJSON() && !live {
 				fmt.Printf("  %s Using %s (%d models)\n\n",
 					tui.SuccessStyle.Render(""),
 					onlineHost.Host.Name,
@@ -173,13 +173,13 @@ Examples:
 			}
 
 			if len(issues) == 0 {
-				if !output.JSONMode {
+				if !output.IsJSON() {
 					fmt.Println(tui.MutedStyle.Render("  No issues to process"))
 				}
 				return nil
 			}
 
-			if !output.JSONMode && !live {
+			if !output.IsJSON() && !live {
 				fmt.Printf("  %s Found %d issues\n", tui.AccentStyle.Render(""), len(issues))
 				fmt.Printf("  %s Models: %s\n", tui.MutedStyle.Render("Using"), strings.Join(modelsToUse, ", "))
 				if dryRun {
@@ -196,7 +196,7 @@ Examples:
 			client := ollama.NewClient(onlineHost.Host.URL, time.Duration(timeout)*time.Minute)
 
 			for i, issue := range issues {
-				if !output.JSONMode && !live {
+				if !output.IsJSON() && !live {
 					fmt.Printf("  [%d/%d] #%d %s\n",
 						i+1, len(issues),
 						issue.Number,
@@ -214,7 +214,7 @@ Examples:
 						result.SkipReason = "existing catfight comment"
 						summary.Skipped++
 						results = append(results, result)
-						if !output.JSONMode && !live {
+						if !output.IsJSON() && !live {
 							fmt.Printf("       %s (has existing analysis)\n",
 								tui.MutedStyle.Render("SKIPPED"))
 						}
@@ -258,7 +258,7 @@ Examples:
 
 					result.Analysis = append(result.Analysis, catResult)
 
-					if !output.JSONMode && !live {
+					if !output.IsJSON() && !live {
 						if err != nil {
 							fmt.Printf("       %s %s: %s\n",
 								tui.ErrorStyle.Render(""),
@@ -290,14 +290,14 @@ Examples:
 				if !dryRun && result.Winner != nil {
 					comment := formatTriageComment(result)
 					if err := postGitHubComment(repo, issue.Number, comment); err != nil {
-						if !output.JSONMode {
+						if !output.IsJSON() {
 							fmt.Printf("       %s Failed to post comment: %s\n",
 								tui.ErrorStyle.Render(""),
 								err.Error())
 						}
 					} else {
 						summary.CommentsAdded++
-						if !output.JSONMode && !live {
+						if !output.IsJSON() && !live {
 							fmt.Printf("       %s Comment posted\n",
 								tui.SuccessStyle.Render(""))
 						}
@@ -324,8 +324,8 @@ Examples:
 				TotalTime: totalDuration.Seconds(),
 			}
 
-			if output.JSONMode {
-				data, _ := json.MarshalIndent(triageOutput, "", "  ")
+			if output.IsJSON() {
+				data, _ := output.Marshal(triageOutput)
 				fmt.Println(string(data))
 				return nil
 			}
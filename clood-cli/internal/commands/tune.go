@@ -1,7 +1,6 @@
 package commands
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
@@ -11,6 +10,7 @@ import (
 
 	"github.com/dirtybirdnj/clood/internal/config"
 	"github.com/dirtybirdnj/clood/internal/hosts"
+	"github.com/dirtybirdnj/clood/internal/output"
 	"github.com/spf13/cobra"
 )
 
@@ -32,9 +32,10 @@ type TuneReport struct {
 }
 
 func TuneCmd() *cobra.Command {
-	var jsonOutput bool
 	var showModelfiles bool
 	var targetHost string
+	var promptType string
+	var judge string
 
 	cmd := &cobra.Command{
 		Use:   "tune",
@@ -47,26 +48,40 @@ This command helps optimize Ollama for code analysis by:
 - Suggesting response length settings (num_predict)
 - Generating optimized Modelfiles for common tasks
 
+--type benchmarks every model on the online hosts instead: it runs a
+built-in suite of prompts (code or writing), measures tokens/sec, and has
+a judge model (--judge, default tiers.deep.model) rank the answers to
+each prompt. The fastest model is written to tiers.fast.model and the
+best-ranked one to tiers.deep.model. With --dry-run the config is left
+alone.
+
 Examples:
   clood tune                    # Analyze local Ollama
   clood tune --host ubuntu25    # Analyze remote host
   clood tune --modelfiles       # Show optimized modelfile templates
-  clood tune --json             # Output as JSON for scripting`,
+  clood tune --json             # Output as JSON for scripting
+  clood tune --type code        # Pick the fast and deep tiers for coding
+  clood tune --type code --judge llama3.1:8b --dry-run`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput := output.IsJSON()
 			if showModelfiles {
 				printModelfiles()
 				return nil
 			}
+			if promptType != "" {
+				return runTuneType(promptType, judge, targetHost, jsonOutput)
+			}
 
 			return runTuneAnalysis(targetHost, jsonOutput)
 		},
 	}
 
-	cmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Output as JSON")
 	cmd.Flags().BoolVar(&showModelfiles, "modelfiles", false, "Show optimized modelfile templates")
 	cmd.Flags().StringVarP(&targetHost, "host", "H", "", "Target host to analyze")
+	cmd.Flags().StringVar(&promptType, "type", "", "Benchmark all models on a prompt suite (code, writing) and set the fast/deep tiers")
+	cmd.Flags().StringVar(&judge, "judge", "", "Model that ranks answers for --type (default: tiers.deep.model)")
 
-	return cmd
+	return withDryRun(cmd)
 }
 
 func runTuneAnalysis(targetHost string, jsonOutput bool) error {
@@ -114,7 +129,7 @@ func runTuneAnalysis(targetHost string, jsonOutput bool) error {
 
 	// Output
 	if jsonOutput {
-		data, _ := json.MarshalIndent(report, "", "  ")
+		data, _ := output.Marshal(report)
 		fmt.Println(string(data))
 	} else {
 		printTuneReport(report)
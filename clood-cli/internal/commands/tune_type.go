@@ -0,0 +1,291 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dirtybirdnj/clood/internal/config"
+	"github.com/dirtybirdnj/clood/internal/hosts"
+	"github.com/dirtybirdnj/clood/internal/ollama"
+	"github.com/dirtybirdnj/clood/internal/output"
+	"github.com/dirtybirdnj/clood/internal/tui"
+)
+
+// tuneSuites are the benchmark prompts for each --type
+var tuneSuites = map[string][]string{
+	"code": {
+		"Write a Go function that reverses a singly linked list in place. Include the type definition.",
+		"Write a Python function that returns the longest palindromic substring of a string, with a short explanation of its complexity.",
+		"Find and fix the bug in this Go code:\n\nfunc sum(xs []int) int {\n\tvar total int\n\tfor i := 1; i <= len(xs); i++ {\n\t\ttotal += xs[i]\n\t}\n\treturn total\n}",
+		"Write a SQL query that returns the top 3 customers by total order amount from tables customers(id, name) and orders(id, customer_id, amount).",
+	},
+	"writing": {
+		"Write a three-sentence README introduction for a command-line tool that routes prompts to local LLMs.",
+		"Summarize the trade-offs between SQLite and PostgreSQL for a small web app in one paragraph.",
+		"Rewrite this commit message to be clear and concise: \"fixed the thing where it broke when the config was missing stuff and also some other cleanup\"",
+	},
+}
+
+// TuneModelResult is one model's showing across a tune suite
+type TuneModelResult struct {
+	Model     string  `json:"model"`
+	Host      string  `json:"host"`
+	TokPerSec float64 `json:"tokens_per_sec"`
+	Quality   float64 `json:"quality"` // mean judge placement, 1 = always ranked first
+	Answered  int     `json:"answered"`
+	Errors    int     `json:"errors,omitempty"`
+}
+
+// TuneTypeReport is the result of clood tune --type
+type TuneTypeReport struct {
+	Type    string            `json:"type"`
+	Judge   string            `json:"judge"`
+	Prompts int               `json:"prompts"`
+	Models  []TuneModelResult `json:"models"`
+	Fastest string            `json:"fastest,omitempty"`
+	Best    string            `json:"best,omitempty"`
+	Saved   bool              `json:"saved"`
+}
+
+// tuneCandidate is a model and the host it's benchmarked on
+type tuneCandidate struct {
+	model string
+	host  *hosts.HostStatus
+}
+
+// runTuneType benchmarks every model on the online hosts against the
+// suite for promptType, has judge rank the answers to each prompt, and
+// writes the fastest model to tiers.fast and the best-ranked to tiers.deep
+func runTuneType(promptType, judge, targetHost string, jsonOutput bool) error {
+	prompts, ok := tuneSuites[promptType]
+	if !ok {
+		return fmt.Errorf("unknown --type %q (want %s)", promptType, strings.Join(tuneTypes(), ", "))
+	}
+	jsonOutput = jsonOutput || output.IsJSON()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	mgr := hosts.NewManager()
+	mgr.AddHosts(cfg.Hosts)
+
+	candidates, err := tuneCandidates(mgr, targetHost)
+	if err != nil {
+		return err
+	}
+	if judge == "" {
+		judge = cfg.Tiers.Deep.Model
+	}
+	if judge == "" {
+		return fmt.Errorf("no judge model: pass --judge or set tiers.deep.model")
+	}
+	judgeHost := candidates[0].host
+	for _, c := range candidates {
+		if c.model == judge {
+			judgeHost = c.host
+			break
+		}
+	}
+	judgeClient := ollama.NewClient(judgeHost.Host.URL, 300*time.Second)
+
+	progress := func(format string, a ...interface{}) {
+		if !jsonOutput {
+			fmt.Printf(format, a...)
+		}
+	}
+	progress("%s %d %s prompts × %d models, judged by %s on %s\n\n",
+		tui.MutedStyle.Render("Tuning:"), len(prompts), promptType, len(candidates), judge, judgeHost.Host.Name)
+
+	results := make([]TuneModelResult, len(candidates))
+	evalTokens := make([]int, len(candidates))
+	evalNanos := make([]int64, len(candidates))
+	placements := make([]float64, len(candidates))
+	for i, c := range candidates {
+		results[i] = TuneModelResult{Model: c.model, Host: c.host.Host.Name}
+	}
+
+	for pi, prompt := range prompts {
+		progress("%s prompt %d/%d\n", tui.MutedStyle.Render("▸"), pi+1, len(prompts))
+		answers := make([]CatfightResult, len(candidates))
+		for i, c := range candidates {
+			client := ollama.NewClient(c.host.Host.URL, 300*time.Second)
+			resp, err := client.Generate(c.model, prompt)
+			answers[i] = CatfightResult{Cat: Cat{Name: c.host.Host.Name, Model: c.model}, Host: c.host.Host.Name}
+			if err != nil {
+				answers[i].Error = err
+				results[i].Errors++
+				progress("  %-32s %s\n", c.model, tui.ErrorStyle.Render(err.Error()))
+				continue
+			}
+			answers[i].Response = resp.Response
+			results[i].Answered++
+			evalTokens[i] += resp.EvalCount
+			evalNanos[i] += resp.EvalDuration
+			progress("  %-32s %s\n", c.model, tui.MutedStyle.Render(fmt.Sprintf("%.1f tok/s", tokPerSec(resp.EvalCount, resp.EvalDuration))))
+		}
+
+		judgement, err := judgeCatfight(judgeClient, judge, prompt, answers)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s prompt %d not judged: %v\n", tui.WarningStyle.Render("Warning:"), pi+1, err)
+			continue
+		}
+		addPlacements(placements, candidates, judgement)
+	}
+
+	for i := range results {
+		results[i].TokPerSec = tokPerSec(evalTokens[i], evalNanos[i])
+		results[i].Quality = placements[i] / float64(len(prompts))
+	}
+	report := TuneTypeReport{Type: promptType, Judge: judge, Prompts: len(prompts), Models: results}
+	report.Fastest, report.Best = tuneWinners(results)
+	if report.Fastest == "" {
+		return fmt.Errorf("no model answered any %s prompt", promptType)
+	}
+
+	if output.DryRun {
+		if jsonOutput {
+			data, _ := output.Marshal(report)
+			fmt.Println(string(data))
+			return nil
+		}
+		printTuneTypeReport(report)
+		return output.WouldHaveDone("set tiers.fast.model and tiers.deep.model in "+config.ConfigPath(), map[string]interface{}{
+			"tiers.fast.model": report.Fastest,
+			"tiers.deep.model": report.Best,
+		})
+	}
+
+	file, err := config.LoadFile()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	file.Tiers.Fast.Model = report.Fastest
+	file.Tiers.Deep.Model = report.Best
+	if err := config.Save(file); err != nil {
+		return fmt.Errorf("saving config: %w", err)
+	}
+	report.Saved = true
+
+	if jsonOutput {
+		data, _ := output.Marshal(report)
+		fmt.Println(string(data))
+		return nil
+	}
+	printTuneTypeReport(report)
+	fmt.Printf("\n%s tiers.fast.model = %s, tiers.deep.model = %s in %s\n",
+		tui.SuccessStyle.Render("✓"), report.Fastest, report.Best, config.ConfigPath())
+	return nil
+}
+
+// tuneCandidates lists each chat model once, on the first online host
+// that has it. Embedding models are skipped since they can't answer.
+func tuneCandidates(mgr *hosts.Manager, targetHost string) ([]tuneCandidate, error) {
+	var statuses []*hosts.HostStatus
+	if targetHost != "" {
+		host := mgr.GetHost(targetHost)
+		if host == nil {
+			return nil, fmt.Errorf("host not found: %s", targetHost)
+		}
+		statuses = []*hosts.HostStatus{mgr.CheckHost(host)}
+	} else {
+		statuses = mgr.CheckAllHosts()
+	}
+
+	seen := map[string]bool{}
+	var candidates []tuneCandidate
+	for _, st := range statuses {
+		if st == nil || !st.Online {
+			continue
+		}
+		for _, m := range st.Models {
+			if seen[m.Name] || strings.Contains(strings.ToLower(m.Name), "embed") {
+				continue
+			}
+			seen[m.Name] = true
+			candidates = append(candidates, tuneCandidate{model: m.Name, host: st})
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no models found on online hosts")
+	}
+	return candidates, nil
+}
+
+// addPlacements credits each ranked model with its place in the
+// judgement: 1 for first down to 0 for last. Unranked models get nothing.
+func addPlacements(placements []float64, candidates []tuneCandidate, j *CatfightJudgement) {
+	n := len(j.Ranking)
+	for _, r := range j.Ranking {
+		score := 1.0
+		if n > 1 {
+			score = float64(n-r.Rank) / float64(n-1)
+		}
+		for i, c := range candidates {
+			if c.model == r.Model {
+				placements[i] += score
+				break
+			}
+		}
+	}
+}
+
+// tuneWinners picks the fastest model and the best-judged one, among the
+// models that answered at least one prompt. Ties on quality go to the
+// faster model.
+func tuneWinners(results []TuneModelResult) (fastest, best string) {
+	var fastRate, bestRate, bestQuality float64
+	for _, r := range results {
+		if r.Answered == 0 {
+			continue
+		}
+		if fastest == "" || r.TokPerSec > fastRate {
+			fastest, fastRate = r.Model, r.TokPerSec
+		}
+		if best == "" || r.Quality > bestQuality || (r.Quality == bestQuality && r.TokPerSec > bestRate) {
+			best, bestQuality, bestRate = r.Model, r.Quality, r.TokPerSec
+		}
+	}
+	return fastest, best
+}
+
+func tokPerSec(tokens int, nanos int64) float64 {
+	if nanos <= 0 {
+		return 0
+	}
+	return float64(tokens) / (float64(nanos) / 1e9)
+}
+
+func tuneTypes() []string {
+	types := make([]string, 0, len(tuneSuites))
+	for t := range tuneSuites {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func printTuneTypeReport(report TuneTypeReport) {
+	models := append([]TuneModelResult(nil), report.Models...)
+	sort.SliceStable(models, func(i, j int) bool { return models[i].Quality > models[j].Quality })
+
+	fmt.Println()
+	fmt.Println(tui.RenderHeader(fmt.Sprintf("Tune: %s", report.Type)))
+	fmt.Println()
+	fmt.Printf("  %-32s %-12s %10s %8s\n", "MODEL", "HOST", "TOK/S", "QUALITY")
+	for _, m := range models {
+		line := fmt.Sprintf("  %-32s %-12s %10.1f %7.0f%%", m.Model, m.Host, m.TokPerSec, m.Quality*100)
+		if m.Answered == 0 {
+			line = tui.MutedStyle.Render(fmt.Sprintf("  %-32s %-12s %s", m.Model, m.Host, "no answers"))
+		} else if m.Errors > 0 {
+			line += tui.MutedStyle.Render(fmt.Sprintf("  (%d failed)", m.Errors))
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+	fmt.Printf("  %s %s\n", tui.MutedStyle.Render("Fastest (tiers.fast):"), report.Fastest)
+	fmt.Printf("  %s %s\n", tui.MutedStyle.Render("Best    (tiers.deep):"), report.Best)
+}
@@ -19,6 +19,9 @@ import (
 const (
 	repoOwner = "dirtybirdnj"
 	repoName  = "clood"
+
+	// releaseTimeout bounds the release lookup for clood update
+	releaseTimeout = 30 * time.Second
 )
 
 // GitHubRelease represents a GitHub release
@@ -75,10 +78,10 @@ Examples:
 func runUpdateCheck(targetVersion string) {
 	currentVersion := CurrentVersion
 
-	release, err := getRelease(targetVersion)
+	release, err := getRelease(targetVersion, releaseTimeout)
 	if err != nil {
 		if output.IsJSON() {
-			fmt.Printf(`{"error": %q}`, err.Error())
+			output.JSON(map[string]interface{}{"error": err.Error()})
 		} else {
 			fmt.Println(tui.ErrorStyle.Render("Error checking for updates: " + err.Error()))
 		}
@@ -86,13 +89,12 @@ func runUpdateCheck(targetVersion string) {
 	}
 
 	if output.IsJSON() {
-		data, _ := json.MarshalIndent(map[string]interface{}{
-			"current_version": currentVersion,
-			"latest_version":  release.TagName,
+		output.JSON(map[string]interface{}{
+			"current_version":  currentVersion,
+			"latest_version":   release.TagName,
 			"update_available": release.TagName != currentVersion,
-			"published_at":    release.PublishedAt,
-		}, "", "  ")
-		fmt.Println(string(data))
+			"published_at":     release.PublishedAt,
+		})
 		return
 	}
 
@@ -127,10 +129,10 @@ func runUpdate(targetVersion string, force bool) {
 		fmt.Print("Checking for updates... ")
 	}
 
-	release, err := getRelease(targetVersion)
+	release, err := getRelease(targetVersion, releaseTimeout)
 	if err != nil {
 		if output.IsJSON() {
-			fmt.Printf(`{"error": %q}`, err.Error())
+			output.JSON(map[string]interface{}{"error": err.Error()})
 		} else {
 			fmt.Println(tui.ErrorStyle.Render("FAILED"))
 			fmt.Println(tui.ErrorStyle.Render("Error: " + err.Error()))
@@ -145,7 +147,7 @@ func runUpdate(targetVersion string, force bool) {
 	// Check if update needed
 	if release.TagName == currentVersion && !force {
 		if output.IsJSON() {
-			fmt.Printf(`{"status": "up_to_date", "version": %q}`, currentVersion)
+			output.JSON(map[string]interface{}{"status": "up_to_date", "version": currentVersion})
 		} else {
 			fmt.Println()
 			fmt.Println(tui.SuccessStyle.Render("✓ Already on latest version: " + currentVersion))
@@ -168,7 +170,7 @@ func runUpdate(targetVersion string, force bool) {
 
 	if downloadURL == "" {
 		if output.IsJSON() {
-			fmt.Printf(`{"error": "No binary found for %s"}`, binaryName)
+			output.JSON(map[string]interface{}{"error": "No binary found for " + binaryName})
 		} else {
 			fmt.Println(tui.ErrorStyle.Render("No binary available for " + binaryName))
 			fmt.Println(tui.MutedStyle.Render("Available binaries:"))
@@ -185,7 +187,7 @@ func runUpdate(targetVersion string, force bool) {
 	execPath, err := os.Executable()
 	if err != nil {
 		if output.IsJSON() {
-			fmt.Printf(`{"error": %q}`, err.Error())
+			output.JSON(map[string]interface{}{"error": err.Error()})
 		} else {
 			fmt.Println(tui.ErrorStyle.Render("Cannot find current executable: " + err.Error()))
 		}
@@ -201,7 +203,7 @@ func runUpdate(targetVersion string, force bool) {
 	tempFile, err := downloadBinary(downloadURL)
 	if err != nil {
 		if output.IsJSON() {
-			fmt.Printf(`{"error": %q}`, err.Error())
+			output.JSON(map[string]interface{}{"error": err.Error()})
 		} else {
 			fmt.Println(tui.ErrorStyle.Render("FAILED"))
 			fmt.Println(tui.ErrorStyle.Render("Download failed: " + err.Error()))
@@ -221,7 +223,7 @@ func runUpdate(targetVersion string, force bool) {
 
 	if err := replaceBinary(tempFile, execPath); err != nil {
 		if output.IsJSON() {
-			fmt.Printf(`{"error": %q}`, err.Error())
+			output.JSON(map[string]interface{}{"error": err.Error()})
 		} else {
 			fmt.Println(tui.ErrorStyle.Render("FAILED"))
 			fmt.Println()
@@ -239,12 +241,11 @@ func runUpdate(targetVersion string, force bool) {
 
 	// Success
 	if output.IsJSON() {
-		data, _ := json.MarshalIndent(map[string]interface{}{
+		output.JSON(map[string]interface{}{
 			"status":           "updated",
 			"previous_version": currentVersion,
 			"new_version":      release.TagName,
-		}, "", "  ")
-		fmt.Println(string(data))
+		})
 	} else {
 		fmt.Println()
 		fmt.Println(tui.SuccessStyle.Render("✓ Updated to " + release.TagName))
@@ -253,7 +254,9 @@ func runUpdate(targetVersion string, force bool) {
 	}
 }
 
-func getRelease(version string) (*GitHubRelease, error) {
+// getRelease fetches a release by tag, or the latest one for "" or
+// "latest", giving up after timeout
+func getRelease(version string, timeout time.Duration) (*GitHubRelease, error) {
 	var url string
 	if version == "" || version == "latest" {
 		url = fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/latest", repoOwner, repoName)
@@ -264,7 +267,7 @@ func getRelease(version string) (*GitHubRelease, error) {
 		url = fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/tags/%s", repoOwner, repoName, version)
 	}
 
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := &http.Client{Timeout: timeout}
 	resp, err := client.Get(url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch release info: %w", err)
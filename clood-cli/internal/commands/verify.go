@@ -1,7 +1,6 @@
 package commands
 
 import (
-	"encoding/json"
 	"fmt"
 	"time"
 
@@ -32,7 +31,6 @@ type VerifyReport struct {
 }
 
 func VerifyCmd() *cobra.Command {
-	var jsonOutput bool
 	var quick bool
 
 	cmd := &cobra.Command{
@@ -48,12 +46,12 @@ Tests:
 
 Use --quick to skip the inference test for faster verification.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			useJSON := jsonOutput || output.IsJSON()
+			useJSON := output.IsJSON()
 
 			report := runVerifyTests(quick, useJSON)
 
 			if useJSON {
-				data, _ := json.MarshalIndent(report, "", "  ")
+				data, _ := output.Marshal(report)
 				fmt.Println(string(data))
 				return
 			}
@@ -62,7 +60,6 @@ Use --quick to skip the inference test for faster verification.`,
 		},
 	}
 
-	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 	cmd.Flags().BoolVar(&quick, "quick", false, "Skip inference test for faster verification")
 
 	return cmd
@@ -0,0 +1,149 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dirtybirdnj/clood/internal/output"
+	"github.com/dirtybirdnj/clood/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// updateCheckTTL is how long a release lookup is reused
+	updateCheckTTL = 24 * time.Hour
+
+	// updateCheckTimeout keeps --check-update from stalling offline
+	updateCheckTimeout = 3 * time.Second
+)
+
+type updateCheckCache struct {
+	LatestVersion string    `json:"latest_version"`
+	CheckedAt     time.Time `json:"checked_at"`
+}
+
+func VersionCmd() *cobra.Command {
+	var checkUpdate bool
+
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "Show the clood version, optionally checking for a newer release",
+		Long: `Prints the version, like --version.
+
+With --check-update, also asks GitHub for the latest release and says
+if it's newer. The answer is cached in ~/.clood/update_check.json for a
+day, and a failed lookup is skipped quietly.
+
+Examples:
+  clood version
+  clood version --check-update`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			latest := ""
+			if checkUpdate {
+				latest = latestReleaseVersion()
+			}
+			behind := latest != "" && versionNewer(latest, CurrentVersion)
+
+			if output.IsJSON() {
+				result := map[string]interface{}{"version": CurrentVersion}
+				if latest != "" {
+					result["latest_version"] = latest
+					result["update_available"] = behind
+				}
+				return output.JSON(result)
+			}
+
+			fmt.Println(tui.RenderBanner())
+			fmt.Println("Version: " + CurrentVersion)
+			if behind {
+				fmt.Println()
+				fmt.Println(tui.WarningStyle.Render(fmt.Sprintf("⬆ %s is available (you have %s)", latest, CurrentVersion)))
+				fmt.Println(tui.MutedStyle.Render("  Run 'clood update' to install"))
+			} else if latest != "" {
+				fmt.Println(tui.SuccessStyle.Render("✓ You're on the latest version"))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&checkUpdate, "check-update", false, "Check GitHub for a newer release")
+
+	return cmd
+}
+
+// updateCheckPath returns ~/.clood/update_check.json
+func updateCheckPath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".clood", "update_check.json")
+}
+
+// latestReleaseVersion returns the latest release tag, from the cache when
+// it's fresh. Any failure returns "" so callers just skip the notice.
+func latestReleaseVersion() string {
+	var cache updateCheckCache
+	if data, err := os.ReadFile(updateCheckPath()); err == nil {
+		if json.Unmarshal(data, &cache) == nil && cache.LatestVersion != "" && time.Since(cache.CheckedAt) < updateCheckTTL {
+			return cache.LatestVersion
+		}
+	}
+
+	release, err := getRelease("latest", updateCheckTimeout)
+	if err != nil || release.TagName == "" {
+		return ""
+	}
+
+	cache = updateCheckCache{LatestVersion: release.TagName, CheckedAt: time.Now()}
+	if data, err := json.MarshalIndent(cache, "", "  "); err == nil {
+		path := updateCheckPath()
+		if os.MkdirAll(filepath.Dir(path), 0755) == nil {
+			os.WriteFile(path, data, 0644)
+		}
+	}
+	return release.TagName
+}
+
+// versionNewer reports whether version a is ahead of b, comparing the
+// dot-separated numbers of tags like v0.3.1. Pre-release suffixes are
+// ignored; a non-numeric version (dev builds) is never behind.
+func versionNewer(a, b string) bool {
+	pa, okA := parseVersion(a)
+	pb, okB := parseVersion(b)
+	if !okA || !okB {
+		return false
+	}
+	for i := 0; i < max(len(pa), len(pb)); i++ {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if x != y {
+			return x > y
+		}
+	}
+	return false
+}
+
+func parseVersion(v string) ([]int, bool) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	var parts []int
+	for _, p := range strings.Split(v, ".") {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, false
+		}
+		parts = append(parts, n)
+	}
+	return parts, true
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"regexp"
+	"runtime"
 	"strings"
 	"time"
 
@@ -42,6 +44,12 @@ type watchModel struct {
 	lastSize    int64
 	ready       bool
 	currentSect int       // Currently highlighted section
+
+	// --notify: desktop notifications as cats finish and when a winner is
+	// announced. Anything already done when watching starts stays quiet.
+	notify      bool
+	notified    map[int]bool // section indexes already announced
+	winnerShown bool
 }
 
 // Styles for the watch TUI
@@ -167,6 +175,9 @@ func (m watchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					content, _ := os.ReadFile(m.file)
 					m.content = string(content)
 					m.sections = parseSections(m.content)
+					if m.notify {
+						m.sendNotifications()
+					}
 					m.viewport.SetContent(m.renderContent())
 					if m.following {
 						m.viewport.GotoBottom()
@@ -268,6 +279,59 @@ func (m watchModel) View() string {
 	return header + m.viewport.View() + footer
 }
 
+// watchWinnerPattern matches catfight's summary line, with or without a host:
+// "WINNER: Persian wins with 12.3s!" / "WINNER: Persian on mini wins with 12.3s!"
+var watchWinnerPattern = regexp.MustCompile(`WINNER:\S*\s+(\w+)(?: on (\S+))? wins with (\d+\.?\d*)s!`)
+
+// parseWinner returns the winning cat and its time once catfight prints them
+func parseWinner(content string) (name, host, secs string, ok bool) {
+	m := watchWinnerPattern.FindStringSubmatch(content)
+	if m == nil {
+		return "", "", "", false
+	}
+	return m[1], m[2], m[3], true
+}
+
+// markNotified records what's already finished so only new completions alert
+func (m *watchModel) markNotified() {
+	m.notified = make(map[int]bool)
+	for _, sect := range m.sections {
+		if sect.Status == "done" {
+			m.notified[sect.Index] = true
+		}
+	}
+	_, _, _, m.winnerShown = parseWinner(m.content)
+}
+
+// sendNotifications announces newly finished cats and the winner
+func (m *watchModel) sendNotifications() {
+	for _, sect := range m.sections {
+		if sect.Status != "done" || m.notified[sect.Index] {
+			continue
+		}
+		m.notified[sect.Index] = true
+		desktopNotify("🐱 "+sect.Name+" finished",
+			fmt.Sprintf("%s: %ss, %s tok/s", sect.Model, sect.Duration, sect.TokSec))
+	}
+
+	if m.winnerShown {
+		return
+	}
+	if name, host, secs, ok := parseWinner(m.content); ok {
+		m.winnerShown = true
+		winner := name
+		for _, sect := range m.sections {
+			if sect.Name == name {
+				winner = fmt.Sprintf("%s (%s)", name, sect.Model)
+			}
+		}
+		if host != "" {
+			winner += " on " + host
+		}
+		desktopNotify("🏆 Catfight complete", fmt.Sprintf("%s wins with %ss", winner, secs))
+	}
+}
+
 // parseSections extracts section info from catfight output
 func parseSections(content string) []Section {
 	var sections []Section
@@ -307,10 +371,14 @@ func parseSections(content string) []Section {
 func WatchCmd() *cobra.Command {
 	var file string
 	var dir string
+	var notify bool
+	var exts []string
+	var debounce time.Duration
+	var noClear bool
 
 	cmd := &cobra.Command{
-		Use:   "watch [file]",
-		Short: "Watch catfight output in real-time with scrollable TUI",
+		Use:   "watch [file] | watch -- <command> [args]",
+		Short: "Watch catfight output in real-time, or re-run a command on changes",
 		Long: `Watch catfight battles unfold in a scrollable, navigable interface.
 
 The watch command provides a "long scroll with moving frame" experience:
@@ -329,6 +397,9 @@ Examples:
   # Pipe catfight output directly (coming soon)
   clood catfight "prompt" | clood watch -
 
+  # Desktop notification as each model finishes and for the winner
+  clood watch /tmp/catfight-results.txt --notify
+
 Navigation:
   f        Toggle follow mode (auto-scroll)
   g        Go to top
@@ -336,8 +407,27 @@ Navigation:
   j/k      Scroll down/up
   n/p      Next/previous section
   1-9      Jump to section by number
-  q/Esc    Quit`,
+  q/Esc    Quit
+
+Re-running a command:
+  Everything after -- is a command to run now and again whenever a .go
+  file under the current directory changes (--ext picks other files).
+  Changes are batched until they've been quiet for --debounce, and the
+  terminal is cleared before each run. A command starting with clood
+  runs this same binary. Ctrl+C stops watching.
+
+  clood watch -- clood analyze ./internal --check-deprecated
+  clood watch -- go test ./...
+  clood watch --ext .go,.yaml -- clood context`,
 		Run: func(cmd *cobra.Command, args []string) {
+			if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+				if err := watchAndRun(args[dash:], ".", exts, debounce, !noClear); err != nil {
+					fmt.Fprintln(os.Stderr, tui.ErrorStyle.Render("Error: "+err.Error()))
+					os.Exit(1)
+				}
+				return
+			}
+
 			var targetFile string
 
 			if len(args) > 0 {
@@ -389,6 +479,13 @@ Navigation:
 				following: true,
 				file:      targetFile,
 				lastSize:  initialSize,
+				notify:    notify,
+			}
+			if notify {
+				if err := desktopNotifyAvailable(); err != nil {
+					fmt.Fprintln(os.Stderr, tui.WarningStyle.Render("--notify: "+err.Error()))
+				}
+				m.markNotified()
 			}
 
 			p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
@@ -400,6 +497,10 @@ Navigation:
 
 	cmd.Flags().StringVarP(&file, "file", "f", "", "File to watch")
 	cmd.Flags().StringVarP(&dir, "dir", "d", "", "Directory to find latest results file")
+	cmd.Flags().BoolVar(&notify, "notify", false, "Desktop notification when a model finishes and when the winner is in")
+	cmd.Flags().StringSliceVar(&exts, "ext", []string{".go"}, "With --: file extensions whose changes re-run the command")
+	cmd.Flags().DurationVar(&debounce, "debounce", 500*time.Millisecond, "With --: how long changes must be quiet before re-running")
+	cmd.Flags().BoolVar(&noClear, "no-clear", false, "With --: keep earlier output instead of clearing the terminal")
 
 	return cmd
 }
@@ -427,3 +528,41 @@ func WatchReader(r io.Reader) error {
 	_, err := p.Run()
 	return err
 }
+
+// desktopNotify pops a desktop notification, like openImage picking the
+// tool by platform. It runs in the background and failures are dropped so
+// a missing notifier never interrupts the watch.
+func desktopNotify(title, message string) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(message), appleScriptString(title))
+		cmd = exec.Command("osascript", "-e", script)
+	default:
+		cmd = exec.Command("notify-send", title, message)
+	}
+	if cmd.Start() == nil {
+		go cmd.Wait()
+	}
+}
+
+// desktopNotifyAvailable reports why desktopNotify can't work here, if it can't
+func desktopNotifyAvailable() error {
+	tool := "notify-send"
+	switch runtime.GOOS {
+	case "darwin":
+		tool = "osascript"
+	case "windows":
+		return fmt.Errorf("desktop notifications aren't supported on Windows")
+	}
+	if _, err := exec.LookPath(tool); err != nil {
+		return fmt.Errorf("%s not found, notifications disabled", tool)
+	}
+	return nil
+}
+
+// appleScriptString quotes s as an AppleScript string literal
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dirtybirdnj/clood/internal/tui"
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchAndRun runs argv, then runs it again each time a file with one of
+// exts changes under dir, once changes have been quiet for debounce. A
+// leading "clood" runs this binary, so 'clood watch -- clood analyze'
+// doesn't depend on which clood is first on PATH.
+func watchAndRun(argv []string, dir string, exts []string, debounce time.Duration, clear bool) error {
+	if len(argv) == 0 {
+		return fmt.Errorf("no command to run: clood watch -- <command> [args]")
+	}
+	display := strings.Join(argv, " ")
+	if argv[0] == "clood" {
+		if self, err := os.Executable(); err == nil {
+			argv = append([]string{self}, argv[1:]...)
+		}
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+	if err := addWatchDirs(watcher, dir); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	runs := 0
+	run := func(reason string) {
+		runs++
+		if clear {
+			fmt.Print("\033[H\033[2J")
+		}
+		fmt.Println(tui.MutedStyle.Render(fmt.Sprintf("[%s] run %d: %s%s", time.Now().Format("15:04:05"), runs, display, reason)))
+		fmt.Println()
+
+		start := time.Now()
+		c := exec.CommandContext(ctx, argv[0], argv[1:]...)
+		c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+		err := c.Run()
+		took := time.Since(start).Round(10 * time.Millisecond)
+
+		fmt.Println()
+		var exitErr *exec.ExitError
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err == nil:
+			fmt.Println(tui.SuccessStyle.Render(fmt.Sprintf("✓ exit 0 in %s", took)) + tui.MutedStyle.Render(" · waiting for changes (ctrl+c to stop)"))
+		case errors.As(err, &exitErr):
+			fmt.Println(tui.ErrorStyle.Render(fmt.Sprintf("✗ exit %d in %s", exitErr.ExitCode(), took)) + tui.MutedStyle.Render(" · waiting for changes (ctrl+c to stop)"))
+		default:
+			fmt.Println(tui.ErrorStyle.Render("✗ " + err.Error()))
+		}
+	}
+
+	run("")
+
+	var timer <-chan time.Time
+	var changed string
+	for {
+		select {
+		case <-ctx.Done():
+			fmt.Println()
+			return nil
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Fprintln(os.Stderr, tui.WarningStyle.Render("watch: "+err.Error()))
+		case ev, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if ev.Has(fsnotify.Create) {
+				if info, err := os.Stat(ev.Name); err == nil && info.IsDir() {
+					addWatchDirs(watcher, ev.Name)
+					continue
+				}
+			}
+			if ev.Has(fsnotify.Chmod) || !hasWatchExt(ev.Name, exts) {
+				continue
+			}
+			if rel, err := filepath.Rel(dir, ev.Name); err == nil {
+				changed = rel
+			} else {
+				changed = ev.Name
+			}
+			timer = time.After(debounce)
+		case <-timer:
+			timer = nil
+			run(" (" + changed + " changed)")
+		}
+	}
+}
+
+// addWatchDirs watches root and every directory under it, skipping the
+// ones no source change happens in
+func addWatchDirs(watcher *fsnotify.Watcher, root string) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || !info.IsDir() {
+			return nil
+		}
+		if name := info.Name(); path != root && (name == "vendor" || name == "node_modules" || strings.HasPrefix(name, ".")) {
+			return filepath.SkipDir
+		}
+		return watcher.Add(path)
+	})
+}
+
+// hasWatchExt reports whether path ends in one of exts; none means any file
+func hasWatchExt(path string, exts []string) bool {
+	if len(exts) == 0 {
+		return true
+	}
+	for _, ext := range exts {
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		if strings.HasSuffix(path, ext) {
+			return true
+		}
+	}
+	return false
+}
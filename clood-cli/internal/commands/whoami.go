@@ -73,7 +73,7 @@ Use -v for detailed hardware and model information.`,
 		Run: func(cmd *cobra.Command, args []string) {
 			identity := getGardenIdentity(verbose)
 
-			if output.JSONMode {
+			if output.IsJSON() {
 				data, _ := json.Marshal(identity)
 				fmt.Println(string(data))
 				return
@@ -4,7 +4,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/dirtybirdnj/clood/internal/hosts"
@@ -13,10 +17,30 @@ import (
 
 // Config represents the global clood configuration
 type Config struct {
-	Hosts    []*hosts.Host `yaml:"hosts"`
-	Tiers    TierConfig    `yaml:"tiers"`
-	Routing  RoutingConfig `yaml:"routing"`
+	Hosts    []*hosts.Host  `yaml:"hosts"`
+	Tiers    TierConfig     `yaml:"tiers"`
+	Routing  RoutingConfig  `yaml:"routing"`
 	Defaults DefaultsConfig `yaml:"defaults"`
+	Storage  StorageConfig  `yaml:"storage,omitempty"`
+	MCP      MCPConfig      `yaml:"mcp,omitempty"`
+	Memory   MemoryConfig   `yaml:"memory,omitempty"`
+
+	// ContextWindows overrides the built-in context lengths, by model
+	// name or family (see ContextWindow)
+	ContextWindows map[string]int `yaml:"context_windows,omitempty"`
+
+	// Inception is read from inception.yaml next to config.yaml
+	Inception InceptionConfig `yaml:"-"`
+}
+
+// InceptionConfig extends the inception expert registry, e.g.
+//
+//	expert_aliases:
+//	  physics: phi4:14b
+//	max_depth: 2 # let experts ask other experts
+type InceptionConfig struct {
+	ExpertAliases map[string]string `yaml:"expert_aliases"`
+	MaxDepth      int               `yaml:"max_depth,omitempty"`
 }
 
 // TierConfig defines model tiers
@@ -35,7 +59,7 @@ type TierSettings struct {
 
 // RoutingConfig defines routing behavior
 type RoutingConfig struct {
-	Strategy string `yaml:"strategy"` // "fastest", "round-robin", "least-loaded"
+	Strategy string `yaml:"strategy"` // "fastest", "round-robin", "least-latency" (or "least-loaded"), "random"
 	Fallback bool   `yaml:"fallback"` // Try next host if first fails
 }
 
@@ -45,6 +69,37 @@ type DefaultsConfig struct {
 	Timeout string `yaml:"timeout"`
 }
 
+// StorageConfig sets when preflight warns about ~/.clood disk usage
+type StorageConfig struct {
+	GalleryWarnGB float64 `yaml:"gallery_warn_gb,omitempty"` // ~/.clood/gallery
+	MemoryWarnMB  float64 `yaml:"memory_warn_mb,omitempty"`  // ~/.clood/memory.db
+}
+
+// MemoryConfig configures 'clood memory'
+type MemoryConfig struct {
+	// SharedDB is the team memory database 'clood memory --shared' uses,
+	// usually on a network mount
+	SharedDB string `yaml:"shared_db,omitempty"`
+}
+
+// MCPConfig controls what 'clood mcp' lets agents do
+type MCPConfig struct {
+	// GitWriteTools enables clood_git_stash push, pop and apply. Off by
+	// default: unlike the other git tools they change the working tree.
+	GitWriteTools bool `yaml:"git_write_tools,omitempty"`
+
+	// RateLimits caps calls per tool, e.g. clood_ask: 10/minute, so a
+	// looping agent can't tie up the GPUs. Each MCP session gets its own
+	// allowance; calls over it fail with rate_limited and retry_after.
+	RateLimits map[string]string `yaml:"rate_limits,omitempty"`
+}
+
+// Default storage warning thresholds
+const (
+	DefaultGalleryWarnGB = 5
+	DefaultMemoryWarnMB  = 100
+)
+
 // DefaultConfig returns the default configuration
 // NOTE: Only localhost is configured by default. Use 'clood discover' to find other hosts.
 func DefaultConfig() *Config {
@@ -66,6 +121,10 @@ func DefaultConfig() *Config {
 			Stream:  true,
 			Timeout: "30s",
 		},
+		Storage: StorageConfig{
+			GalleryWarnGB: DefaultGalleryWarnGB,
+			MemoryWarnMB:  DefaultMemoryWarnMB,
+		},
 	}
 }
 
@@ -87,19 +146,144 @@ func ConfigDir() string {
 	return filepath.Join(home, ".config", "clood")
 }
 
-// ConfigPath returns the full path to the config file
+// ActiveProfile is the profile chosen with the global --profile flag.
+// Empty means the default config.yaml.
+var ActiveProfile string
+
+var profileNameRe = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// ConfigPath returns the full path to the config file of the active
+// profile, or "" if --profile isn't a valid name (Load reports why)
 func ConfigPath() string {
-	return filepath.Join(ConfigDir(), "config.yaml")
+	path, err := ProfilePath(ActiveProfile)
+	if err != nil {
+		return ""
+	}
+	return path
+}
+
+// ProfilesDir holds named profiles, one <name>.yaml each
+func ProfilesDir() string {
+	return filepath.Join(ConfigDir(), "profiles")
 }
 
-// Load reads the config from disk, falling back to defaults
+// ProfilePath returns the config file for profile name, or config.yaml
+// for the empty name. Names that would leave ProfilesDir, like ../x, are
+// an error.
+func ProfilePath(name string) (string, error) {
+	if name == "" {
+		return filepath.Join(ConfigDir(), "config.yaml"), nil
+	}
+	if err := ValidateProfileName(name); err != nil {
+		return "", err
+	}
+	return filepath.Join(ProfilesDir(), name+".yaml"), nil
+}
+
+// ValidateProfileName rejects names that can't be a plain file name
+func ValidateProfileName(name string) error {
+	if !profileNameRe.MatchString(name) || strings.HasPrefix(name, ".") {
+		return fmt.Errorf("invalid profile name %q (use letters, digits, - _ .)", name)
+	}
+	return nil
+}
+
+// ListProfiles returns the names of the profiles in ProfilesDir, sorted
+func ListProfiles() ([]string, error) {
+	entries, err := os.ReadDir(ProfilesDir())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && filepath.Ext(e.Name()) == ".yaml" && !strings.HasPrefix(e.Name(), ".") {
+			names = append(names, strings.TrimSuffix(e.Name(), ".yaml"))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// InceptionPath returns the path to the inception registry overrides
+func InceptionPath() string {
+	return filepath.Join(ConfigDir(), "inception.yaml")
+}
+
+// inceptionWarning makes a bad inception.yaml warn once per run, however
+// often the config is loaded
+var inceptionWarning sync.Once
+
+// loadInception reads inception.yaml; a missing file means no overrides
+func loadInception() (InceptionConfig, error) {
+	var ic InceptionConfig
+	data, err := os.ReadFile(InceptionPath())
+	if os.IsNotExist(err) {
+		return ic, nil
+	}
+	if err != nil {
+		return ic, fmt.Errorf("read inception config: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &ic); err != nil {
+		return ic, fmt.Errorf("parse %s: %w", InceptionPath(), err)
+	}
+	return ic, nil
+}
+
+// Load reads the active profile's config from disk, falling back to
+// defaults, with CLOOD_<KEY> environment variables taking precedence
 func Load() (*Config, error) {
-	path := ConfigPath()
+	return LoadProfile(ActiveProfile)
+}
+
+// LoadFile reads the active profile's config without environment
+// overrides, for commands that change it and write it back with Save
+func LoadFile() (*Config, error) {
+	return loadProfileFile(ActiveProfile)
+}
+
+// LoadProfile reads profile name's config. The empty name is the default
+// config.yaml, which falls back to defaults when missing; a named profile
+// must exist.
+func LoadProfile(name string) (*Config, error) {
+	cfg, err := loadProfileFile(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyEnv(cfg, os.Environ()); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func loadProfileFile(name string) (*Config, error) {
+	path, err := ProfilePath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	// inception.yaml is optional and only matters to inception, so a
+	// broken one shouldn't stop every other command
+	inceptionCfg, err := loadInception()
+	if err != nil {
+		inceptionWarning.Do(func() {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring inception config: %v\n", err)
+		})
+		inceptionCfg = InceptionConfig{}
+	}
 
 	// Check if file exists
 	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if name != "" {
+			return nil, fmt.Errorf("profile %q not found: %s (see: clood profile list)", name, path)
+		}
 		// Return defaults
-		return DefaultConfig(), nil
+		cfg := DefaultConfig()
+		cfg.Inception = inceptionCfg
+		return cfg, nil
 	}
 
 	// Read file
@@ -135,6 +319,14 @@ func Load() (*Config, error) {
 	if cfg.Defaults.Timeout == "" {
 		cfg.Defaults.Timeout = "30s"
 	}
+	if cfg.Storage.GalleryWarnGB == 0 {
+		cfg.Storage.GalleryWarnGB = DefaultGalleryWarnGB
+	}
+	if cfg.Storage.MemoryWarnMB == 0 {
+		cfg.Storage.MemoryWarnMB = DefaultMemoryWarnMB
+	}
+
+	cfg.Inception = inceptionCfg
 
 	// Enable hosts by default if not specified
 	for _, h := range cfg.Hosts {
@@ -148,9 +340,18 @@ func Load() (*Config, error) {
 	return &cfg, nil
 }
 
-// Save writes the config to disk
+// Save writes the config to the active profile's file
 func Save(cfg *Config) error {
-	dir := ConfigDir()
+	path, err := ProfilePath(ActiveProfile)
+	if err != nil {
+		return err
+	}
+	return SaveTo(cfg, path)
+}
+
+// SaveTo writes the config to path
+func SaveTo(cfg *Config, path string) error {
+	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return fmt.Errorf("create config dir: %w", err)
 	}
@@ -160,14 +361,65 @@ func Save(cfg *Config) error {
 		return fmt.Errorf("marshal config: %w", err)
 	}
 
-	path := ConfigPath()
-	if err := os.WriteFile(path, data, 0644); err != nil {
+	// Write to a temp file in the same dir then rename, so a crash mid-write
+	// never leaves a truncated config behind
+	tmp, err := os.CreateTemp(dir, ".config-*.yaml")
+	if err != nil {
+		return fmt.Errorf("create temp config: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // No-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
 		return fmt.Errorf("write config: %w", err)
 	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+	if err := os.Chmod(tmpPath, 0644); err != nil {
+		return fmt.Errorf("chmod config: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("replace config: %w", err)
+	}
+
+	return nil
+}
+
+// FindHost returns the configured host with the given name, or nil
+func (c *Config) FindHost(name string) *hosts.Host {
+	for _, h := range c.Hosts {
+		if h.Name == name {
+			return h
+		}
+	}
+	return nil
+}
 
+// AddHost appends a host to the config. Names must be unique.
+func (c *Config) AddHost(host *hosts.Host) error {
+	if host.Name == "" {
+		return fmt.Errorf("host name is required")
+	}
+	if c.FindHost(host.Name) != nil {
+		return fmt.Errorf("host %q already exists", host.Name)
+	}
+	c.Hosts = append(c.Hosts, host)
 	return nil
 }
 
+// RemoveHost deletes the named host from the config
+func (c *Config) RemoveHost(name string) error {
+	for i, h := range c.Hosts {
+		if h.Name == name {
+			c.Hosts = append(c.Hosts[:i], c.Hosts[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("host %q not found", name)
+}
+
 // GetTimeout returns the timeout as a duration
 func (c *Config) GetTimeout() time.Duration {
 	d, err := time.ParseDuration(c.Defaults.Timeout)
@@ -256,6 +508,9 @@ func WriteExampleConfig(path string) error {
 	example := `# clood configuration
 # Location: ~/.config/clood/config.yaml
 #
+# Any key can be overridden with a CLOOD_<KEY> environment variable, e.g.
+# CLOOD_TIERS_FAST_MODEL=qwen2.5:3b or CLOOD_HOSTS_0_URL=http://gpu:11434
+#
 # The Server Garden - Host Configuration
 
 # Ollama hosts in the garden
@@ -293,13 +548,23 @@ tiers:
 
 # Routing behavior
 routing:
-  strategy: fastest   # Options: fastest, round-robin, least-loaded
+  strategy: fastest   # Options: fastest, round-robin, least-latency, random
   fallback: true      # Try next host if first fails
 
 # Default settings
 defaults:
   stream: true        # Stream responses
   timeout: 120s       # Request timeout
+
+# MCP server ('clood mcp')
+mcp:
+  git_write_tools: false  # Let clood_git_stash push, pop and apply stashes
+  # rate_limits:          # Calls per second, minute or hour, per session
+  #   clood_ask: 10/minute
+
+# Team memories ('clood memory --shared')
+# memory:
+#   shared_db: /Volumes/shared/team.db
 `
 	return os.WriteFile(path, []byte(example), 0644)
 }
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestProfilePath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("APPDATA", filepath.Join(home, ".config"))
+	dir := ConfigDir()
+
+	tests := []struct {
+		name, want string
+	}{
+		{"", filepath.Join(dir, "config.yaml")},
+		{"work", filepath.Join(dir, "profiles", "work.yaml")},
+		{"gpu-box_2.old", filepath.Join(dir, "profiles", "gpu-box_2.old.yaml")},
+	}
+	for _, tt := range tests {
+		got, err := ProfilePath(tt.name)
+		if err != nil || got != tt.want {
+			t.Errorf("ProfilePath(%q) = %q, %v; want %q", tt.name, got, err, tt.want)
+		}
+	}
+
+	for _, name := range []string{"../x", "..", ".hidden", "a/b", `a\b`, "/etc/passwd", "a b"} {
+		if got, err := ProfilePath(name); err == nil {
+			t.Errorf("ProfilePath(%q) = %q; want an error", name, got)
+		}
+	}
+}
+
+func TestSaveRejectsBadProfile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("APPDATA", filepath.Join(home, ".config"))
+	defer func(prev string) { ActiveProfile = prev }(ActiveProfile)
+	ActiveProfile = "../escaped"
+
+	if err := Save(DefaultConfig()); err == nil || !strings.Contains(err.Error(), "invalid profile name") {
+		t.Errorf("Save with profile %q = %v; want an invalid name error", ActiveProfile, err)
+	}
+	if _, err := Load(); err == nil {
+		t.Errorf("Load with profile %q should fail", ActiveProfile)
+	}
+	if path := ConfigPath(); path != "" {
+		t.Errorf("ConfigPath() = %q; want empty for a bad profile", path)
+	}
+	if _, err := os.Stat(filepath.Join(ConfigDir(), "escaped.yaml")); !os.IsNotExist(err) {
+		t.Error("Save wrote outside the profiles directory")
+	}
+}
@@ -0,0 +1,71 @@
+package config
+
+import "strings"
+
+// DefaultContextWindow is assumed for models the table doesn't know.
+// It matches the num_ctx clood asks Ollama for.
+const DefaultContextWindow = 16384
+
+// contextWindows lists context lengths (in tokens) by model family, the
+// part of the Ollama name before the tag. Longer names win, so
+// "llama3.1" beats "llama3".
+var contextWindows = map[string]int{
+	"llama2":            4096,
+	"llama3":            8192,
+	"llama3.1":          131072,
+	"llama3.2":          131072,
+	"llama3.3":          131072,
+	"llama3-groq-tool":  8192,
+	"codellama":         16384,
+	"mistral":           32768,
+	"mistral-nemo":      131072,
+	"mixtral":           32768,
+	"gemma":             8192,
+	"gemma2":            8192,
+	"gemma3":            131072,
+	"phi3":              4096,
+	"phi4":              16384,
+	"qwen2":             32768,
+	"qwen2.5":           32768,
+	"qwen2.5-coder":     32768,
+	"qwen3":             40960,
+	"deepseek-coder":    16384,
+	"deepseek-coder-v2": 163840,
+	"deepseek-r1":       131072,
+	"starcoder2":        16384,
+	"tinyllama":         2048,
+}
+
+// ContextWindow returns model's context length in tokens. The
+// context_windows section of config.yaml is checked first, by full name
+// and then by family, e.g.
+//
+//	context_windows:
+//	  llama3.1:8b: 32768   # what your num_ctx actually allows
+//	  mystery-model: 65536
+func (c *Config) ContextWindow(model string) int {
+	family := strings.ToLower(model)
+	if i := strings.LastIndex(family, "/"); i >= 0 {
+		family = family[i+1:]
+	}
+	if i := strings.Index(family, ":"); i >= 0 {
+		family = family[:i]
+	}
+
+	if c != nil {
+		if n := c.ContextWindows[model]; n > 0 {
+			return n
+		}
+		if n := c.ContextWindows[family]; n > 0 {
+			return n
+		}
+	}
+
+	best, window := "", DefaultContextWindow
+	for name, n := range contextWindows {
+		if strings.HasPrefix(family, name) && len(name) > len(best) {
+			best, window = name, n
+		}
+	}
+	return window
+}
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// EnvPrefix starts every environment override of a config key
+const EnvPrefix = "CLOOD_"
+
+// applyEnv overrides config keys from CLOOD_<KEY> environment variables,
+// where KEY is the YAML path upper-cased and joined with underscores:
+//
+//	CLOOD_TIERS_FAST_MODEL=qwen2.5:3b
+//	CLOOD_HOSTS_0_URL=http://gpu-server:11434
+//	CLOOD_CONTEXT_WINDOWS_LLAMA3=8192
+//	CLOOD_INCEPTION_MAX_DEPTH=2
+//
+// List indexes past the end add entries; lists of strings are
+// comma-separated. Variables that don't name a config key (CLOOD_ATC_AUTH,
+// ...) are left alone.
+func applyEnv(cfg *Config, environ []string) error {
+	var names []string
+	values := map[string]string{}
+	for _, kv := range environ {
+		name, value, ok := strings.Cut(kv, "=")
+		if !ok || !strings.HasPrefix(name, EnvPrefix) {
+			continue
+		}
+		names = append(names, name)
+		values[name] = value
+	}
+	// Sorted so overrides apply in the same order every run
+	sort.Strings(names)
+
+	hostCount := len(cfg.Hosts)
+	for _, name := range names {
+		key := strings.TrimPrefix(name, EnvPrefix)
+		target := reflect.ValueOf(cfg).Elem()
+		if rest, ok := strings.CutPrefix(key, "INCEPTION_"); ok {
+			key, target = rest, reflect.ValueOf(&cfg.Inception).Elem()
+		}
+		if err := setEnvPath(target, key, values[name]); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	// Hosts added from the environment get the same defaults as ones
+	// added with 'clood hosts add'
+	for i := hostCount; i < len(cfg.Hosts); i++ {
+		h := cfg.Hosts[i]
+		if h.Name == "" {
+			h.Name = fmt.Sprintf("host%d", i)
+		}
+		if h.Priority == 0 {
+			h.Priority = 10
+		}
+		if _, set := values[fmt.Sprintf("%sHOSTS_%d_ENABLED", EnvPrefix, i)]; !set {
+			h.Enabled = true
+		}
+	}
+	return nil
+}
+
+// setEnvPath resolves key (e.g. TIERS_FAST_MODEL) against v's YAML field
+// names and sets what it names. Keys that match nothing are ignored.
+func setEnvPath(v reflect.Value, key, value string) error {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		// Longest tag first, so CONTEXT_WINDOWS wins over a CONTEXT field
+		best, bestTag := -1, ""
+		for i := 0; i < v.NumField(); i++ {
+			tag := envTag(v.Type().Field(i))
+			if tag == "" || len(tag) <= len(bestTag) {
+				continue
+			}
+			if key == tag || strings.HasPrefix(key, tag+"_") {
+				best, bestTag = i, tag
+			}
+		}
+		if best < 0 {
+			return nil
+		}
+		rest := strings.TrimPrefix(strings.TrimPrefix(key, bestTag), "_")
+		field := v.Field(best)
+		if rest == "" {
+			return setEnvValue(field, value)
+		}
+		return setEnvPath(field, rest, value)
+
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.String {
+			return nil // a list of strings is set whole, not by index
+		}
+		idx, rest, _ := strings.Cut(key, "_")
+		i, err := strconv.Atoi(idx)
+		if err != nil || i < 0 || i > 1000 {
+			return fmt.Errorf("invalid list index %q", idx)
+		}
+		for v.Len() <= i {
+			v.Set(reflect.Append(v, reflect.Zero(v.Type().Elem())))
+		}
+		if rest == "" {
+			return setEnvValue(v.Index(i), value)
+		}
+		return setEnvPath(v.Index(i), rest, value)
+
+	case reflect.Map:
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if err := setEnvValue(elem, value); err != nil {
+			return err
+		}
+		v.SetMapIndex(reflect.ValueOf(strings.ToLower(key)), elem)
+		return nil
+	}
+	return nil
+}
+
+// setEnvValue parses value into a scalar or string-list field
+func setEnvValue(v reflect.Value, value string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", value)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", value)
+		}
+		v.SetInt(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", value)
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("set list entries by index")
+		}
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("not a settable key")
+	}
+	return nil
+}
+
+// envTag is a field's YAML name as it appears in an environment variable
+func envTag(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+	if name == "-" || !f.IsExported() {
+		return ""
+	}
+	if name == "" {
+		name = f.Name
+	}
+	return strings.ToUpper(name)
+}
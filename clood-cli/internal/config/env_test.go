@@ -0,0 +1,106 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dirtybirdnj/clood/internal/hosts"
+)
+
+func TestApplyEnv(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Hosts = []*hosts.Host{{Name: "local", URL: "http://localhost:11434", Priority: 1, Enabled: true}}
+
+	err := applyEnv(cfg, []string{
+		"CLOOD_TIERS_FAST_MODEL=qwen2.5:3b",
+		"CLOOD_TIERS_DEEP_FALLBACK=llama3.1:8b",
+		"CLOOD_ROUTING_FALLBACK=false",
+		"CLOOD_DEFAULTS_STREAM=true",
+		"CLOOD_STORAGE_GALLERY_WARN_GB=2.5",
+		"CLOOD_MCP_GIT_WRITE_TOOLS=1",
+		"CLOOD_MCP_RATE_LIMITS_CLOOD_ASK=10/m",
+		"CLOOD_CONTEXT_WINDOWS_LLAMA3=8192",
+		"CLOOD_INCEPTION_MAX_DEPTH=2",
+		"CLOOD_INCEPTION_EXPERT_ALIASES_PHYSICS=phi4:14b",
+		"CLOOD_HOSTS_0_URL=http://gpu:11434",
+		"CLOOD_HOSTS_0_MODELS=a, b,,c",
+		"CLOOD_HOSTS_1_URL=http://new:11434",
+		"CLOOD_ATC_AUTH=secret", // not a config key
+		"CLOOD_NO_SUCH_KEY=x",
+		"OTHER_TIERS_FAST_MODEL=ignored",
+		"CLOOD_MALFORMED",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Tiers.Fast.Model != "qwen2.5:3b" || cfg.Tiers.Deep.Fallback != "llama3.1:8b" {
+		t.Errorf("tiers = %+v", cfg.Tiers)
+	}
+	if cfg.Routing.Fallback || !cfg.Defaults.Stream || !cfg.MCP.GitWriteTools {
+		t.Errorf("bools: routing.fallback=%v defaults.stream=%v mcp.git_write_tools=%v",
+			cfg.Routing.Fallback, cfg.Defaults.Stream, cfg.MCP.GitWriteTools)
+	}
+	if cfg.Storage.GalleryWarnGB != 2.5 {
+		t.Errorf("gallery_warn_gb = %v", cfg.Storage.GalleryWarnGB)
+	}
+	if cfg.MCP.RateLimits["clood_ask"] != "10/m" {
+		t.Errorf("rate_limits = %v", cfg.MCP.RateLimits)
+	}
+	if cfg.ContextWindows["llama3"] != 8192 {
+		t.Errorf("context_windows = %v", cfg.ContextWindows)
+	}
+	if cfg.Inception.MaxDepth != 2 || cfg.Inception.ExpertAliases["physics"] != "phi4:14b" {
+		t.Errorf("inception = %+v", cfg.Inception)
+	}
+
+	if len(cfg.Hosts) != 2 {
+		t.Fatalf("hosts = %d, want the existing one plus one added", len(cfg.Hosts))
+	}
+	local := cfg.Hosts[0]
+	if local.Name != "local" || local.URL != "http://gpu:11434" || local.Priority != 1 {
+		t.Errorf("existing host = %+v", local)
+	}
+	if !reflect.DeepEqual(local.Models, []string{"a", "b", "c"}) {
+		t.Errorf("models = %q", local.Models)
+	}
+	// Added hosts get the defaults 'clood hosts add' would give them
+	added := cfg.Hosts[1]
+	if added.Name != "host1" || added.URL != "http://new:11434" || added.Priority != 10 || !added.Enabled {
+		t.Errorf("added host = %+v", added)
+	}
+}
+
+func TestApplyEnvAddedHostEnabled(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Hosts = nil
+	if err := applyEnv(cfg, []string{"CLOOD_HOSTS_0_URL=http://a:11434", "CLOOD_HOSTS_0_ENABLED=false", "CLOOD_HOSTS_0_NAME=a"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Hosts) != 1 || cfg.Hosts[0].Enabled || cfg.Hosts[0].Name != "a" {
+		t.Errorf("host = %+v, want an explicit enabled=false kept", cfg.Hosts[0])
+	}
+}
+
+func TestApplyEnvInvalid(t *testing.T) {
+	tests := []struct {
+		env, want string
+	}{
+		{"CLOOD_DEFAULTS_STREAM=maybe", `CLOOD_DEFAULTS_STREAM: invalid boolean "maybe"`},
+		{"CLOOD_HOSTS_0_PRIORITY=high", `CLOOD_HOSTS_0_PRIORITY: invalid integer "high"`},
+		{"CLOOD_STORAGE_MEMORY_WARN_MB=lots", `CLOOD_STORAGE_MEMORY_WARN_MB: invalid number "lots"`},
+		{"CLOOD_CONTEXT_WINDOWS_LLAMA3=big", `CLOOD_CONTEXT_WINDOWS_LLAMA3: invalid integer "big"`},
+		{"CLOOD_HOSTS_X_URL=http://a", `CLOOD_HOSTS_X_URL: invalid list index "X"`},
+		{"CLOOD_HOSTS_5000_URL=http://a", `invalid list index "5000"`},
+		{"CLOOD_HOSTS=a", "set list entries by index"},
+		{"CLOOD_TIERS=x", "not a settable key"},
+	}
+	for _, tt := range tests {
+		cfg := DefaultConfig()
+		err := applyEnv(cfg, []string{tt.env})
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("applyEnv(%s) = %v; want %q", tt.env, err, tt.want)
+		}
+	}
+}
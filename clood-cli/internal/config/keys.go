@@ -0,0 +1,178 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// KeyValue is one config value under its dot-separated key
+type KeyValue struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Get returns the YAML node at a dot-separated key such as
+// tiers.fast.model or hosts.0.url: a scalar, or a whole section
+func Get(cfg *Config, key string) (*yaml.Node, error) {
+	root, err := configNode(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return lookupKey(root, key, false)
+}
+
+// Set changes the value at a dot-separated key. The result is decoded
+// back into cfg, so unknown keys and values of the wrong type (a word for
+// a number, say) are errors and cfg is left as it was. List entries past
+// the end are added, e.g. hosts.3.url.
+func Set(cfg *Config, key, value string) error {
+	root, err := configNode(cfg)
+	if err != nil {
+		return err
+	}
+	node, err := lookupKey(root, key, true)
+	if err != nil {
+		return err
+	}
+	if node.Kind != yaml.ScalarNode && len(node.Content) > 0 {
+		return fmt.Errorf("%s is a section; set one of its keys instead", key)
+	}
+	*node = yaml.Node{Kind: yaml.ScalarNode, Value: value}
+
+	data, err := yaml.Marshal(root)
+	if err != nil {
+		return fmt.Errorf("marshal config: %w", err)
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	var next Config
+	if err := dec.Decode(&next); err != nil {
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			return fmt.Errorf("invalid value for %s: %w", key, err)
+		}
+		// Drop the "line N:" positions, which point into a file the user
+		// never saw
+		for _, msg := range typeErr.Errors {
+			if strings.Contains(msg, "not found in type") {
+				return fmt.Errorf("unknown config key %s", key)
+			}
+		}
+		_, msg, _ := strings.Cut(typeErr.Errors[0], ": ")
+		return fmt.Errorf("invalid value for %s: %s", key, msg)
+	}
+	next.Inception = cfg.Inception
+	*cfg = next
+	return nil
+}
+
+// Values lists every config value in file order
+func Values(cfg *Config) ([]KeyValue, error) {
+	root, err := configNode(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var values []KeyValue
+	var walk func(n *yaml.Node, prefix string)
+	walk = func(n *yaml.Node, prefix string) {
+		switch n.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				walk(n.Content[i+1], joinKey(prefix, n.Content[i].Value))
+			}
+		case yaml.SequenceNode:
+			for i, item := range n.Content {
+				walk(item, joinKey(prefix, strconv.Itoa(i)))
+			}
+		case yaml.ScalarNode:
+			values = append(values, KeyValue{Key: prefix, Value: n.Value})
+		}
+	}
+	walk(root, "")
+	return values, nil
+}
+
+// EnvName is the environment variable that overrides key (see applyEnv)
+func EnvName(key string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+func configNode(cfg *Config) (*yaml.Node, error) {
+	var root yaml.Node
+	if err := root.Encode(cfg); err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+	return &root, nil
+}
+
+// lookupKey walks mappings by name and lists by index. With create, missing
+// keys and the next list entry are added as empty nodes for Set to fill.
+func lookupKey(root *yaml.Node, key string, create bool) (*yaml.Node, error) {
+	if key == "" {
+		return nil, fmt.Errorf("empty key")
+	}
+	parts := strings.Split(key, ".")
+	node := root
+	for i, part := range parts {
+		path := strings.Join(parts[:i+1], ".")
+		switch node.Kind {
+		case yaml.MappingNode:
+			var next *yaml.Node
+			for j := 0; j+1 < len(node.Content); j += 2 {
+				if node.Content[j].Value == part {
+					next = node.Content[j+1]
+					break
+				}
+			}
+			if next == nil {
+				if !create {
+					return nil, fmt.Errorf("unknown config key %s", path)
+				}
+				next = emptyNode(parts[i+1:])
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: part}, next)
+			}
+			node = next
+
+		case yaml.SequenceNode:
+			idx, err := strconv.Atoi(part)
+			if err != nil || idx < 0 {
+				return nil, fmt.Errorf("%s: %q is not a list index", path, part)
+			}
+			if idx == len(node.Content) && create {
+				node.Content = append(node.Content, emptyNode(parts[i+1:]))
+			}
+			if idx >= len(node.Content) {
+				return nil, fmt.Errorf("%s: index out of range (%d entries)", path, len(node.Content))
+			}
+			node = node.Content[idx]
+
+		default:
+			return nil, fmt.Errorf("%s is a value, not a section", strings.Join(parts[:i], "."))
+		}
+	}
+	return node, nil
+}
+
+// emptyNode is the node a new key holds: a list when the rest of the key
+// starts with an index, a mapping when there's more key, else a value
+func emptyNode(rest []string) *yaml.Node {
+	if len(rest) == 0 {
+		return &yaml.Node{Kind: yaml.ScalarNode}
+	}
+	if _, err := strconv.Atoi(rest[0]); err == nil {
+		return &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	}
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+}
@@ -0,0 +1,177 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dirtybirdnj/clood/internal/hosts"
+	"gopkg.in/yaml.v3"
+)
+
+func testKeysConfig() *Config {
+	cfg := DefaultConfig()
+	cfg.Hosts = []*hosts.Host{{Name: "local", URL: "http://localhost:11434", Priority: 1, Enabled: true}}
+	return cfg
+}
+
+func TestGet(t *testing.T) {
+	cfg := testKeysConfig()
+	cfg.Tiers.Fast.Model = "qwen2.5:3b"
+
+	tests := []struct {
+		key  string
+		kind yaml.Kind
+		want string
+	}{
+		{"tiers.fast.model", yaml.ScalarNode, "qwen2.5:3b"},
+		{"hosts.0.url", yaml.ScalarNode, "http://localhost:11434"},
+		{"hosts.0.priority", yaml.ScalarNode, "1"},
+		{"tiers.fast", yaml.MappingNode, ""},
+		{"hosts", yaml.SequenceNode, ""},
+	}
+	for _, tt := range tests {
+		node, err := Get(cfg, tt.key)
+		if err != nil {
+			t.Errorf("Get(%q): %v", tt.key, err)
+			continue
+		}
+		if node.Kind != tt.kind || node.Value != tt.want {
+			t.Errorf("Get(%q) = kind %v value %q; want kind %v value %q", tt.key, node.Kind, node.Value, tt.kind, tt.want)
+		}
+	}
+}
+
+func TestGetInvalid(t *testing.T) {
+	tests := []struct {
+		key, want string
+	}{
+		{"", "empty key"},
+		{"tiers.nope", "unknown config key tiers.nope"},
+		{"hosts.1.url", "hosts.1: index out of range (1 entries)"},
+		{"hosts.x", `hosts.x: "x" is not a list index`},
+		{"hosts.-1", `hosts.-1: "-1" is not a list index`},
+		{"tiers.fast.model.name", "tiers.fast.model is a value, not a section"},
+	}
+	for _, tt := range tests {
+		_, err := Get(testKeysConfig(), tt.key)
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("Get(%q) = %v; want %q", tt.key, err, tt.want)
+		}
+	}
+}
+
+func TestSet(t *testing.T) {
+	cfg := testKeysConfig()
+	for _, kv := range []KeyValue{
+		{"tiers.fast.model", "qwen2.5:3b"},
+		{"hosts.0.priority", "5"},
+		{"defaults.stream", "true"},
+		{"mcp.rate_limits.clood_ask", "10/m"},
+		{"hosts.1.url", "http://gpu:11434"}, // one past the end adds an entry
+		{"hosts.1.name", "gpu"},
+		{"hosts.0.models.0", "llama3.1:8b"},
+	} {
+		if err := Set(cfg, kv.Key, kv.Value); err != nil {
+			t.Fatalf("Set(%q, %q): %v", kv.Key, kv.Value, err)
+		}
+	}
+
+	if cfg.Tiers.Fast.Model != "qwen2.5:3b" || !cfg.Defaults.Stream {
+		t.Errorf("tiers.fast.model = %q, defaults.stream = %v", cfg.Tiers.Fast.Model, cfg.Defaults.Stream)
+	}
+	if cfg.MCP.RateLimits["clood_ask"] != "10/m" {
+		t.Errorf("rate_limits = %v", cfg.MCP.RateLimits)
+	}
+	if len(cfg.Hosts) != 2 {
+		t.Fatalf("hosts = %d, want 2", len(cfg.Hosts))
+	}
+	if h := cfg.Hosts[0]; h.Priority != 5 || len(h.Models) != 1 || h.Models[0] != "llama3.1:8b" {
+		t.Errorf("hosts.0 = %+v", h)
+	}
+	if h := cfg.Hosts[1]; h.Name != "gpu" || h.URL != "http://gpu:11434" {
+		t.Errorf("hosts.1 = %+v", h)
+	}
+}
+
+func TestSetInvalid(t *testing.T) {
+	tests := []struct {
+		key, value, want string
+	}{
+		{"hosts.0.priority", "high", "invalid value for hosts.0.priority:"},
+		{"defaults.stream", "maybe", "invalid value for defaults.stream:"},
+		{"tiers.fast.nope", "x", "unknown config key tiers.fast.nope"},
+		{"nope", "x", "unknown config key nope"},
+		{"hosts.2.url", "http://a", "hosts.2: index out of range (1 entries)"},
+		{"tiers.fast", "x", "tiers.fast is a section"},
+		{"tiers.fast.model.name", "x", "tiers.fast.model is a value, not a section"},
+	}
+	for _, tt := range tests {
+		cfg := testKeysConfig()
+		err := Set(cfg, tt.key, tt.value)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Set(%q, %q) = %v; want %q", tt.key, tt.value, err, tt.want)
+			continue
+		}
+		if strings.Contains(err.Error(), "line ") {
+			t.Errorf("Set(%q, %q) = %v; want no YAML line numbers", tt.key, tt.value, err)
+		}
+		// A failed Set leaves the config alone
+		if cfg.Hosts[0].Priority != 1 || cfg.Defaults.Stream != DefaultConfig().Defaults.Stream {
+			t.Errorf("Set(%q, %q) changed the config after failing", tt.key, tt.value)
+		}
+	}
+}
+
+func TestValues(t *testing.T) {
+	cfg := testKeysConfig()
+	cfg.Hosts[0].Models = []string{"a", "b"}
+	values, err := Values(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string]string)
+	index := make(map[string]int)
+	for i, kv := range values {
+		got[kv.Key] = kv.Value
+		index[kv.Key] = i
+	}
+	for key, want := range map[string]string{
+		"hosts.0.name":     "local",
+		"hosts.0.url":      "http://localhost:11434",
+		"hosts.0.models.0": "a",
+		"hosts.0.models.1": "b",
+	} {
+		if got[key] != want {
+			t.Errorf("Values()[%q] = %q; want %q", key, got[key], want)
+		}
+	}
+	if _, ok := got["hosts.0"]; ok {
+		t.Error("Values() should list only scalars, not sections")
+	}
+	if index["hosts.0.name"] > index["hosts.0.url"] {
+		t.Error("Values() should keep file order")
+	}
+
+	// Every listed key reads back through Get
+	for _, kv := range values {
+		node, err := Get(cfg, kv.Key)
+		if err != nil || node.Value != kv.Value {
+			t.Errorf("Get(%q) = %v, %v; want %q", kv.Key, node, err, kv.Value)
+		}
+	}
+}
+
+func TestEnvName(t *testing.T) {
+	tests := []struct {
+		key, want string
+	}{
+		{"tiers.fast.model", "CLOOD_TIERS_FAST_MODEL"},
+		{"hosts.0.url", "CLOOD_HOSTS_0_URL"},
+	}
+	for _, tt := range tests {
+		if got := EnvName(tt.key); got != tt.want {
+			t.Errorf("EnvName(%q) = %q; want %q", tt.key, got, tt.want)
+		}
+	}
+}
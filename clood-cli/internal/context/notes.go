@@ -0,0 +1,84 @@
+package context
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// NotesFile is the shared context file 'clood context --save' writes and
+// later runs read back. Everything outside the generated block is notes
+// written by people and is kept when the file is regenerated.
+const NotesFile = "CONTEXT.md"
+
+const (
+	generatedStart = "<!-- clood:generated - regenerate with 'clood context --save' -->"
+	generatedEnd   = "<!-- /clood:generated -->"
+)
+
+// defaultNotes seeds a new CONTEXT.md
+const defaultNotes = `## Notes
+
+<!-- Project notes for people and LLMs: conventions, gotchas, where things
+live. Kept as-is when the summary below is regenerated. -->
+`
+
+// ReadNotes returns the hand-written part of dir's CONTEXT.md, or "" if
+// there is none
+func ReadNotes(dir string) string {
+	data, err := os.ReadFile(filepath.Join(dir, NotesFile))
+	if err != nil {
+		return ""
+	}
+	notes := strings.TrimSpace(stripComments(stripGenerated(string(data))))
+	if notes == strings.TrimSpace(stripComments(defaultNotes)) {
+		return ""
+	}
+	return notes
+}
+
+// MergeNotes returns the contents for a context file whose generated block
+// is replaced by generated, keeping any notes in existing
+func MergeNotes(existing, generated string) string {
+	block := generatedStart + "\n" + strings.TrimSpace(generated) + "\n" + generatedEnd + "\n"
+
+	if start := strings.Index(existing, generatedStart); start >= 0 {
+		if end := strings.Index(existing[start:], generatedEnd); end >= 0 {
+			rest := strings.TrimPrefix(existing[start+end+len(generatedEnd):], "\n")
+			return existing[:start] + block + rest
+		}
+	}
+
+	notes := strings.TrimSpace(existing)
+	if notes == "" {
+		notes = strings.TrimSpace(defaultNotes)
+	}
+	return notes + "\n\n" + block
+}
+
+func stripGenerated(s string) string {
+	start := strings.Index(s, generatedStart)
+	if start < 0 {
+		return s
+	}
+	end := strings.Index(s[start:], generatedEnd)
+	if end < 0 {
+		return s[:start]
+	}
+	return s[:start] + s[start+end+len(generatedEnd):]
+}
+
+// stripComments drops HTML comments, which hold instructions rather than notes
+func stripComments(s string) string {
+	for {
+		start := strings.Index(s, "<!--")
+		if start < 0 {
+			return s
+		}
+		end := strings.Index(s[start:], "-->")
+		if end < 0 {
+			return s[:start]
+		}
+		s = s[:start] + s[start+end+len("-->"):]
+	}
+}
@@ -0,0 +1,45 @@
+package context
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMergeAndReadNotes(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, NotesFile)
+
+	if got := ReadNotes(dir); got != "" {
+		t.Fatalf("missing file: got %q", got)
+	}
+
+	// A fresh file gets the template, which doesn't count as notes
+	first := MergeNotes("", "# Project: demo\nv1")
+	if err := os.WriteFile(path, []byte(first), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := ReadNotes(dir); got != "" {
+		t.Errorf("template read as notes: %q", got)
+	}
+
+	// Notes added by hand survive regeneration; the old summary doesn't
+	edited := strings.Replace(first, "## Notes", "## Notes\n\nRun make gen before tests.", 1)
+	second := MergeNotes(edited, "# Project: demo\nv2")
+	if !strings.Contains(second, "Run make gen") || strings.Contains(second, "v1") || !strings.Contains(second, "v2") {
+		t.Errorf("regenerated file:\n%s", second)
+	}
+	if err := os.WriteFile(path, []byte(second), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := ReadNotes(dir); !strings.Contains(got, "Run make gen") || strings.Contains(got, "v2") || strings.Contains(got, "<!--") {
+		t.Errorf("notes = %q", got)
+	}
+
+	// A hand-written file with no generated block keeps all of it
+	third := MergeNotes("Use tabs.\n", "summary")
+	if !strings.HasPrefix(third, "Use tabs.\n\n"+generatedStart) {
+		t.Errorf("merged into plain file:\n%s", third)
+	}
+}
@@ -0,0 +1,81 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// changelogSections are the CHANGELOG headings in the order they appear,
+// keyed by conventional commit type
+var changelogSections = []struct {
+	Type, Title string
+}{
+	{"feat", "Features"},
+	{"fix", "Bug Fixes"},
+	{"perf", "Performance"},
+	{"refactor", "Refactoring"},
+	{"docs", "Documentation"},
+	{"test", "Tests"},
+	{"build", "Build"},
+	{"ci", "CI"},
+	{"style", "Style"},
+	{"revert", "Reverts"},
+	{"chore", "Chores"},
+}
+
+// GenerateChangelog formats commits as a Markdown CHANGELOG section,
+// grouped by conventional commit type. Subjects that aren't conventional
+// go under "Other Changes"; merge commits are left out. since titles the
+// section ("" for "Unreleased").
+func GenerateChangelog(entries []LogEntry, since string) string {
+	groups := map[string][]string{}
+	var breaking []string
+
+	for _, e := range entries {
+		if mergePRPattern.MatchString(e.Subject) || mergeBranchPattern.MatchString(e.Subject) {
+			continue
+		}
+		parsed := parseLogFormat("conventional", e, "")
+		if parsed["valid"] != "true" {
+			groups[""] = append(groups[""], fmt.Sprintf("- %s (%s)", e.Subject, e.ShortHash))
+			continue
+		}
+
+		item := parsed["description"]
+		if scope := parsed["scope"]; scope != "" {
+			item = fmt.Sprintf("**%s:** %s", scope, item)
+		}
+		item = fmt.Sprintf("- %s (%s)", item, e.ShortHash)
+		groups[parsed["type"]] = append(groups[parsed["type"]], item)
+		if parsed["breaking"] == "true" {
+			breaking = append(breaking, item)
+		}
+	}
+
+	var sb strings.Builder
+	if since != "" {
+		fmt.Fprintf(&sb, "## Changes since %s\n", since)
+	} else {
+		sb.WriteString("## Unreleased\n")
+	}
+
+	writeSection := func(title string, items []string) {
+		if len(items) == 0 {
+			return
+		}
+		fmt.Fprintf(&sb, "\n### %s\n\n", title)
+		for _, item := range items {
+			sb.WriteString(item + "\n")
+		}
+	}
+	writeSection("⚠ Breaking Changes", breaking)
+	for _, section := range changelogSections {
+		writeSection(section.Title, groups[section.Type])
+	}
+	writeSection("Other Changes", groups[""])
+
+	if len(groups) == 0 {
+		sb.WriteString("\nNo changes.\n")
+	}
+	return sb.String()
+}
@@ -5,6 +5,7 @@ package git
 import (
 	"fmt"
 	"os/exec"
+	"regexp"
 	"strconv"
 	"strings"
 )
@@ -37,6 +38,12 @@ func RecentCommits(path string, count int) ([]string, error) {
 	return lines, nil
 }
 
+// IsRef reports whether name resolves to a commit (a tag, branch or hash)
+func IsRef(path, name string) bool {
+	cmd := exec.Command("git", "-C", path, "rev-parse", "--verify", "--quiet", name+"^{commit}")
+	return cmd.Run() == nil
+}
+
 // CurrentBranch returns the current branch name
 func CurrentBranch(path string) (string, error) {
 	cmd := exec.Command("git", "-C", path, "branch", "--show-current")
@@ -56,13 +63,20 @@ type DiffOptions struct {
 	Path     string // Repository path
 	File     string // Specific file to diff (optional)
 	Commit   string // Specific commit to diff (optional)
+	Branch   string // Diff current branch against this one, three-dot (optional)
 	Staged   bool   // Show staged changes only
 	Context  int    // Lines of context (default 3)
 	Stat     bool   // Show stat summary instead of full diff
 }
 
-// Diff returns git diff output
+// Diff returns git diff output. With Branch set it runs
+// `git diff <current>...<branch>`: the changes on Branch since it forked
+// from the current branch, i.e. what a merge would bring in.
 func Diff(opts DiffOptions) (string, error) {
+	if opts.Branch != "" && (opts.Commit != "" || opts.Staged) {
+		return "", fmt.Errorf("branch cannot be combined with commit or staged")
+	}
+
 	args := []string{"-C", opts.Path, "diff"}
 
 	if opts.Staged {
@@ -79,6 +93,17 @@ func Diff(opts DiffOptions) (string, error) {
 		args = append(args, opts.Commit)
 	}
 
+	if opts.Branch != "" {
+		current, err := CurrentBranch(opts.Path)
+		if err != nil {
+			return "", fmt.Errorf("finding current branch: %w", err)
+		}
+		if current == "" {
+			current = "HEAD" // detached
+		}
+		args = append(args, current+"..."+opts.Branch)
+	}
+
 	if opts.File != "" {
 		args = append(args, "--", opts.File)
 	}
@@ -137,18 +162,23 @@ func parseBlameOutput(output string) ([]BlameLine, error) {
 	currentLine := BlameLine{}
 	lineNum := 0
 
+	// Porcelain output only gives a commit's author the first time the
+	// commit appears, so remember it for the lines that follow
+	seen := map[string]BlameLine{}
+
 	for _, line := range strings.Split(output, "\n") {
 		if len(line) == 0 {
 			continue
 		}
 
 		// Line starting with a commit hash (40 chars)
-		if len(line) >= 40 && !strings.HasPrefix(line, "\t") {
-			parts := strings.Fields(line)
-			if len(parts) >= 3 {
-				currentLine.Commit = parts[0][:8] // Short hash
-				lineNum, _ = strconv.Atoi(parts[2])
-				currentLine.Line = lineNum
+		if parts := strings.Fields(line); len(parts) >= 3 && isCommitHash(parts[0]) {
+			currentLine.Commit = parts[0][:8] // Short hash
+			lineNum, _ = strconv.Atoi(parts[2])
+			currentLine.Line = lineNum
+			if prev, ok := seen[currentLine.Commit]; ok {
+				currentLine.Author = prev.Author
+				currentLine.Date = prev.Date
 			}
 		} else if strings.HasPrefix(line, "author ") {
 			currentLine.Author = strings.TrimPrefix(line, "author ")
@@ -158,6 +188,7 @@ func parseBlameOutput(output string) ([]BlameLine, error) {
 		} else if strings.HasPrefix(line, "\t") {
 			currentLine.Content = strings.TrimPrefix(line, "\t")
 			lines = append(lines, currentLine)
+			seen[currentLine.Commit] = currentLine
 			currentLine = BlameLine{}
 		}
 	}
@@ -165,29 +196,62 @@ func parseBlameOutput(output string) ([]BlameLine, error) {
 	return lines, nil
 }
 
+func isCommitHash(s string) bool {
+	if len(s) != 40 {
+		return false
+	}
+	for _, c := range s {
+		if !strings.ContainsRune("0123456789abcdef", c) {
+			return false
+		}
+	}
+	return true
+}
+
 // LogOptions configures log output
 type LogOptions struct {
 	Path    string
-	Count   int    // Number of commits (default 20)
+	Count   int    // Number of commits (default 20, negative for no limit)
 	Author  string // Filter by author
 	Since   string // Since date (e.g., "2024-01-01")
 	Until   string // Until date
+	Rev     string // Revision range (e.g., "v0.1.0..HEAD")
 	Grep    string // Search commit messages
 	OneLine bool   // One-line format
 	Stat    bool   // Include stats
 	File    string // Filter to specific file
+	Format  string // Extra parsing: coauthors, merge-commits or conventional
 }
 
+// LogFormats are the values LogOptions.Format accepts
+var LogFormats = []string{"coauthors", "merge-commits", "conventional"}
+
 // LogEntry represents a commit in the log
 type LogEntry struct {
-	Hash       string   `json:"hash"`
-	ShortHash  string   `json:"short_hash"`
-	Author     string   `json:"author"`
-	AuthorEmail string  `json:"author_email"`
-	Date       string   `json:"date"`
-	Subject    string   `json:"subject"`
-	Body       string   `json:"body,omitempty"`
-	Files      []string `json:"files,omitempty"`
+	Hash        string            `json:"hash"`
+	ShortHash   string            `json:"short_hash"`
+	Author      string            `json:"author"`
+	AuthorEmail string            `json:"author_email"`
+	Date        string            `json:"date"`
+	Subject     string            `json:"subject"`
+	Body        string            `json:"body,omitempty"`
+	Files       []string          `json:"files,omitempty"`
+	Parsed      map[string]string `json:"parsed,omitempty"` // Filled by LogOptions.Format
+}
+
+// SetSince limits the log to commits after since: a tag, branch or commit
+// becomes the range since..HEAD, anything else is passed to --since as a
+// date
+func (o *LogOptions) SetSince(since string) {
+	path := o.Path
+	if path == "" {
+		path = "."
+	}
+	if IsRef(path, since) {
+		o.Rev = since + "..HEAD"
+		return
+	}
+	o.Since = since
 }
 
 // Log returns commit history with filtering options
@@ -196,12 +260,27 @@ func Log(opts LogOptions) ([]LogEntry, error) {
 		opts.Count = 20
 	}
 
-	// Use a format that's easy to parse
-	format := "%H%n%h%n%an%n%ae%n%ai%n%s%n%b%n---COMMIT_END---"
+	// Use a format that's easy to parse. Formats that need more than the
+	// subject and body get one extra line after the subject.
+	extra := ""
+	switch opts.Format {
+	case "":
+	case "coauthors":
+		extra = "%(trailers:key=Co-authored-by,valueonly,separator=%x1f)%n"
+	case "merge-commits":
+		extra = "%P%n"
+	case "conventional":
+	default:
+		return nil, fmt.Errorf("unknown log format %q (want %s)", opts.Format, strings.Join(LogFormats, ", "))
+	}
+	format := "%H%n%h%n%an%n%ae%n%ai%n%s%n" + extra + "%b%n---COMMIT_END---"
 
-	args := []string{"-C", opts.Path, "log",
-		fmt.Sprintf("-n%d", opts.Count),
-		fmt.Sprintf("--format=%s", format),
+	args := []string{"-C", opts.Path, "log", fmt.Sprintf("--format=%s", format)}
+	if opts.Count > 0 {
+		args = append(args, fmt.Sprintf("-n%d", opts.Count))
+	}
+	if opts.Format == "merge-commits" {
+		args = append(args, "--merges")
 	}
 
 	if opts.Author != "" {
@@ -216,6 +295,9 @@ func Log(opts LogOptions) ([]LogEntry, error) {
 	if opts.Grep != "" {
 		args = append(args, fmt.Sprintf("--grep=%s", opts.Grep))
 	}
+	if opts.Rev != "" {
+		args = append(args, opts.Rev)
+	}
 	if opts.File != "" {
 		args = append(args, "--", opts.File)
 	}
@@ -226,10 +308,10 @@ func Log(opts LogOptions) ([]LogEntry, error) {
 		return nil, fmt.Errorf("git log failed: %w", err)
 	}
 
-	return parseLogOutput(string(output))
+	return parseLogOutput(string(output), opts.Format, extra != "")
 }
 
-func parseLogOutput(output string) ([]LogEntry, error) {
+func parseLogOutput(output, format string, hasExtra bool) ([]LogEntry, error) {
 	var entries []LogEntry
 
 	commits := strings.Split(output, "---COMMIT_END---")
@@ -253,9 +335,23 @@ func parseLogOutput(output string) ([]LogEntry, error) {
 			Subject:     lines[5],
 		}
 
+		// The extra format line, when there is one, comes before the body.
+		// It can be missing when it and the body were both empty.
+		bodyStart, extraLine := 6, ""
+		if hasExtra {
+			bodyStart = 7
+			if len(lines) > 6 {
+				extraLine = lines[6]
+			}
+		}
+
 		// Body is everything after subject
-		if len(lines) > 6 {
-			entry.Body = strings.TrimSpace(strings.Join(lines[6:], "\n"))
+		if len(lines) > bodyStart {
+			entry.Body = strings.TrimSpace(strings.Join(lines[bodyStart:], "\n"))
+		}
+
+		if format != "" {
+			entry.Parsed = parseLogFormat(format, entry, extraLine)
 		}
 
 		entries = append(entries, entry)
@@ -264,6 +360,75 @@ func parseLogOutput(output string) ([]LogEntry, error) {
 	return entries, nil
 }
 
+var (
+	conventionalPattern = regexp.MustCompile(`^(\w+)(?:\(([^)]*)\))?(!)?: (.+)$`)
+	mergePRPattern      = regexp.MustCompile(`^Merge pull request #(\d+) from (\S+)`)
+	mergeBranchPattern  = regexp.MustCompile(`^Merge (?:remote-tracking )?branch '([^']+)'(?: of \S+)?(?: into (\S+))?`)
+)
+
+// conventionalTypes are the commit types a conventional subject may use
+var conventionalTypes = map[string]bool{
+	"feat": true, "fix": true, "chore": true, "docs": true, "style": true, "refactor": true,
+	"perf": true, "test": true, "build": true, "ci": true, "revert": true,
+}
+
+// parseLogFormat extracts the metadata a LogOptions.Format asks for.
+// extra is the format's additional line: the Co-authored-by trailers
+// (0x1f separated) or the parent hashes.
+func parseLogFormat(format string, entry LogEntry, extra string) map[string]string {
+	parsed := map[string]string{}
+
+	switch format {
+	case "coauthors":
+		var coauthors []string
+		for _, c := range strings.Split(extra, "\x1f") {
+			if c = strings.TrimSpace(c); c != "" {
+				coauthors = append(coauthors, c)
+			}
+		}
+		parsed["coauthors"] = strings.Join(coauthors, ", ")
+		parsed["count"] = strconv.Itoa(len(coauthors))
+
+	case "merge-commits":
+		if parents := strings.Fields(extra); len(parents) > 0 {
+			parsed["parents"] = strings.Join(parents, " ")
+		}
+		if m := mergePRPattern.FindStringSubmatch(entry.Subject); m != nil {
+			parsed["pr"] = m[1]
+			parsed["branch"] = m[2]
+		} else if m := mergeBranchPattern.FindStringSubmatch(entry.Subject); m != nil {
+			parsed["branch"] = m[1]
+			if m[2] != "" {
+				parsed["into"] = m[2]
+			}
+		}
+
+	case "conventional":
+		m := conventionalPattern.FindStringSubmatch(entry.Subject)
+		if m == nil {
+			parsed["valid"] = "false"
+			parsed["error"] = "subject is not 'type(scope): description'"
+			break
+		}
+		parsed["type"] = m[1]
+		if m[2] != "" {
+			parsed["scope"] = m[2]
+		}
+		parsed["description"] = m[4]
+		if m[3] == "!" || strings.Contains(entry.Body, "BREAKING CHANGE:") {
+			parsed["breaking"] = "true"
+		}
+		if conventionalTypes[m[1]] {
+			parsed["valid"] = "true"
+		} else {
+			parsed["valid"] = "false"
+			parsed["error"] = fmt.Sprintf("unknown type %q", m[1])
+		}
+	}
+
+	return parsed
+}
+
 // Branch represents a git branch
 type Branch struct {
 	Name      string `json:"name"`
@@ -367,6 +532,40 @@ func StashShow(path string, index int) (string, error) {
 	return string(output), nil
 }
 
+// StashPush stashes the working tree's changes, with an optional message,
+// and returns git's output. includeUntracked also stashes untracked files.
+func StashPush(path, message string, includeUntracked bool) (string, error) {
+	args := []string{"-C", path, "stash", "push"}
+	if includeUntracked {
+		args = append(args, "--include-untracked")
+	}
+	if message != "" {
+		args = append(args, "-m", message)
+	}
+	cmd := exec.Command("git", args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git stash push failed: %w - %s", err, strings.TrimSpace(string(output)))
+	}
+	return string(output), nil
+}
+
+// StashApply restores a stash entry onto the working tree; with pop the
+// entry is dropped once it applies cleanly
+func StashApply(path string, index int, pop bool) (string, error) {
+	action := "apply"
+	if pop {
+		action = "pop"
+	}
+	stashRef := fmt.Sprintf("stash@{%d}", index)
+	cmd := exec.Command("git", "-C", path, "stash", action, stashRef)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git stash %s failed: %w - %s", action, err, strings.TrimSpace(string(output)))
+	}
+	return string(output), nil
+}
+
 // Show returns the content of a specific commit
 func Show(path, commit string, stat bool) (string, error) {
 	args := []string{"-C", path, "show", commit}
@@ -381,3 +580,52 @@ func Show(path, commit string, stat bool) (string, error) {
 	}
 	return string(output), nil
 }
+
+// CommitMessages returns the full message of each commit, keyed by the
+// hash as given (short hashes from Blame work)
+func CommitMessages(path string, commits []string) (map[string]string, error) {
+	messages := make(map[string]string, len(commits))
+	if len(commits) == 0 {
+		return messages, nil
+	}
+
+	args := append([]string{"-C", path, "show", "-s", "--format=%H%x00%B%x1e"}, commits...)
+	cmd := exec.Command("git", args...)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git show failed: %w", err)
+	}
+
+	for _, record := range strings.Split(string(output), "\x1e") {
+		hash, body, ok := strings.Cut(strings.TrimLeft(record, "\n"), "\x00")
+		if !ok {
+			continue
+		}
+		for _, c := range commits {
+			if strings.HasPrefix(hash, c) {
+				messages[c] = strings.TrimSpace(body)
+			}
+		}
+	}
+	return messages, nil
+}
+
+// GitHubRepo returns owner/repo for the origin remote, if it's on GitHub
+func GitHubRepo(path string) (string, error) {
+	cmd := exec.Command("git", "-C", path, "remote", "get-url", "origin")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("no origin remote: %w", err)
+	}
+
+	url := strings.TrimSpace(string(output))
+	for _, prefix := range []string{"git@github.com:", "ssh://git@github.com/", "https://github.com/", "http://github.com/"} {
+		if strings.HasPrefix(url, prefix) {
+			repo := strings.TrimSuffix(strings.TrimPrefix(url, prefix), ".git")
+			if strings.Count(repo, "/") == 1 {
+				return repo, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("origin %s is not a GitHub repository", url)
+}
@@ -0,0 +1,27 @@
+package git
+
+import (
+	"net/http"
+
+	"github.com/google/go-github/v50/github"
+)
+
+// GitHubClient returns a GitHub API client that sends token with every
+// request, or an anonymous one when token is empty
+func GitHubClient(token string) *github.Client {
+	if token == "" {
+		return github.NewClient(nil)
+	}
+	return github.NewClient(&http.Client{Transport: &tokenTransport{token: token}})
+}
+
+// tokenTransport adds a bearer token to every request
+type tokenTransport struct {
+	token string
+}
+
+func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return http.DefaultTransport.RoundTrip(req)
+}
@@ -0,0 +1,160 @@
+// Package gitignore is a lightweight .gitignore matcher for directory walks.
+//
+// It supports the common syntax: comments, negation (!), directory-only
+// patterns (trailing /), anchoring (leading or inner /), *, ?, [...] and **.
+// Only the root .gitignore is read; nested .gitignore files are not.
+package gitignore
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Matcher holds compiled rules from a .gitignore file
+type Matcher struct {
+	rules []rule
+}
+
+type rule struct {
+	re      *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// Load reads root/.gitignore. A missing file yields an empty Matcher.
+func Load(root string) (*Matcher, error) {
+	f, err := os.Open(filepath.Join(root, ".gitignore"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Matcher{}, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return New(lines), nil
+}
+
+// New compiles gitignore-style pattern lines
+func New(lines []string) *Matcher {
+	m := &Matcher{}
+	for _, line := range lines {
+		if r, ok := parseRule(line); ok {
+			m.rules = append(m.rules, r)
+		}
+	}
+	return m
+}
+
+// Match reports whether relPath (slash- or OS-separated, relative to the
+// .gitignore's directory) is ignored. Callers walking a tree should skip
+// ignored directories, since their contents are ignored too.
+func (m *Matcher) Match(relPath string, isDir bool) bool {
+	if m == nil || len(m.rules) == 0 {
+		return false
+	}
+	relPath = strings.TrimPrefix(filepath.ToSlash(relPath), "./")
+
+	ignored := false
+	for _, r := range m.rules {
+		if r.dirOnly && !isDir {
+			continue
+		}
+		if r.re.MatchString(relPath) {
+			ignored = !r.negate
+		}
+	}
+	return ignored
+}
+
+func parseRule(line string) (rule, bool) {
+	line = strings.TrimRight(line, " \t\r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return rule{}, false
+	}
+
+	var r rule
+	if strings.HasPrefix(line, "!") {
+		r.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, "/") {
+		r.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return rule{}, false
+	}
+
+	// A slash anywhere but the end anchors the pattern to the root
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+
+	expr := patternToRegexp(line)
+	if anchored {
+		expr = "^" + expr + "$"
+	} else {
+		expr = "^(?:.*/)?" + expr + "$"
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return rule{}, false
+	}
+	r.re = re
+	return r, true
+}
+
+// patternToRegexp translates one glob pattern into a regexp fragment
+func patternToRegexp(p string) string {
+	var sb strings.Builder
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		switch {
+		case strings.HasPrefix(p[i:], "**/"):
+			// Leading or inner "**/": zero or more directories
+			sb.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(p[i:], "**"):
+			// Trailing "**": everything inside
+			sb.WriteString(".*")
+			i++
+		case c == '*':
+			sb.WriteString("[^/]*")
+		case c == '?':
+			sb.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(p[i+1:], ']')
+			if end < 0 {
+				sb.WriteString(`\[`)
+				continue
+			}
+			class := p[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			sb.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		case c == '\\' && i+1 < len(p):
+			i++
+			sb.WriteString(regexp.QuoteMeta(string(p[i])))
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,58 @@
+package gitignore
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	m := New([]string{
+		"# build output",
+		"dist/",
+		"*.log",
+		"!keep.log",
+		"/root-only.txt",
+		"docs/generated",
+		"**/cache",
+		"assets/**",
+		"tmp[0-9]",
+		"",
+	})
+
+	tests := []struct {
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{"dist", true, true},
+		{"dist", false, false}, // dir-only rule
+		{"web/dist", true, true},
+		{"app.log", false, true},
+		{"logs/app.log", false, true},
+		{"keep.log", false, false}, // negated
+		{"root-only.txt", false, true},
+		{"sub/root-only.txt", false, false}, // anchored
+		{"docs/generated", true, true},
+		{"other/docs/generated", true, false},
+		{"cache", true, true},
+		{"a/b/cache", true, true},
+		{"assets/img/logo.png", false, true},
+		{"assets", true, false},
+		{"tmp1", true, true},
+		{"tmpx", true, false},
+		{"main.go", false, false},
+	}
+
+	for _, tt := range tests {
+		if got := m.Match(tt.path, tt.isDir); got != tt.want {
+			t.Errorf("Match(%q, dir=%v) = %v, want %v", tt.path, tt.isDir, got, tt.want)
+		}
+	}
+}
+
+func TestLoadMissing(t *testing.T) {
+	m, err := Load(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Match("anything", false) {
+		t.Error("empty matcher should not ignore anything")
+	}
+}
@@ -0,0 +1,155 @@
+package hosts
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// BalanceStrategy picks among several online hosts that can serve a request
+type BalanceStrategy string
+
+const (
+	BalancePriority     BalanceStrategy = ""              // First by priority, then latency (the default)
+	BalanceRoundRobin   BalanceStrategy = "round-robin"   // Rotate through candidates
+	BalanceLeastLatency BalanceStrategy = "least-latency" // Lowest latency weighted by in-flight requests
+	BalanceRandom       BalanceStrategy = "random"
+)
+
+// ParseBalanceStrategy accepts the --load-balance and routing.strategy
+// spellings. "fastest" is the priority default; "least-loaded" is an alias
+// for least-latency.
+func ParseBalanceStrategy(s string) (BalanceStrategy, error) {
+	switch s {
+	case "", "fastest", "priority":
+		return BalancePriority, nil
+	case "round-robin":
+		return BalanceRoundRobin, nil
+	case "least-latency", "least-loaded":
+		return BalanceLeastLatency, nil
+	case "random":
+		return BalanceRandom, nil
+	}
+	return BalancePriority, fmt.Errorf("unknown load-balance strategy %q (use round-robin, least-latency or random)", s)
+}
+
+// RequestLoad counts in-flight requests by host name. A long-lived process
+// that rebuilds its Manager from config, like the MCP server, shares one
+// between them so the rebuild doesn't forget what's still running.
+type RequestLoad struct {
+	mu     sync.Mutex
+	active map[string]int
+}
+
+// NewRequestLoad returns an empty RequestLoad
+func NewRequestLoad() *RequestLoad {
+	return &RequestLoad{active: make(map[string]int)}
+}
+
+func (l *RequestLoad) add(name string, n int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.active[name]+n >= 0 {
+		l.active[name] += n
+	}
+}
+
+func (l *RequestLoad) get(name string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.active[name]
+}
+
+// WithRequestLoad makes the Manager count in-flight requests in l
+func WithRequestLoad(l *RequestLoad) ManagerOption {
+	return func(m *Manager) {
+		if l != nil {
+			m.load = l
+		}
+	}
+}
+
+// WithRoundRobinOffset starts the round-robin counter at n, so callers that
+// live for one request can carry the rotation across runs
+func WithRoundRobinOffset(n uint64) ManagerOption {
+	return func(m *Manager) {
+		m.rrNext = n
+	}
+}
+
+// RoundRobinOffset returns the counter the next round-robin pick will use
+func (m *Manager) RoundRobinOffset() uint64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.rrNext
+}
+
+// BeginRequest records a request dispatched to the named host. Call the
+// returned func when it completes.
+func (m *Manager) BeginRequest(name string) (done func()) {
+	m.load.add(name, 1)
+	var once sync.Once
+	return func() {
+		once.Do(func() { m.load.add(name, -1) })
+	}
+}
+
+// ActiveRequests returns the number of in-flight requests on the named host
+func (m *Manager) ActiveRequests(name string) int {
+	return m.load.get(name)
+}
+
+// SelectHost picks one of candidates, which should already be in priority
+// order, refreshing each candidate's ActiveRequests along the way
+func (m *Manager) SelectHost(candidates []*HostStatus, strategy BalanceStrategy) *HostStatus {
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, c := range candidates {
+		c.ActiveRequests = m.load.get(c.Host.Name)
+	}
+
+	switch strategy {
+	case BalanceRoundRobin:
+		pick := candidates[m.rrNext%uint64(len(candidates))]
+		m.rrNext++
+		return pick
+	case BalanceRandom:
+		return candidates[rand.Intn(len(candidates))]
+	case BalanceLeastLatency:
+		best := candidates[0]
+		for _, c := range candidates[1:] {
+			if balanceScore(c) < balanceScore(best) {
+				best = c
+			}
+		}
+		return best
+	}
+	return candidates[0]
+}
+
+// balanceScore is the latency a new request should expect: each request
+// already in flight is assumed to cost one more round of the host's latency
+func balanceScore(s *HostStatus) time.Duration {
+	return s.Latency * time.Duration(1+s.ActiveRequests)
+}
+
+// GetHostWithModelBalanced is GetHostWithModel choosing among every online
+// host that has the model with the given strategy
+func (m *Manager) GetHostWithModelBalanced(modelName string, strategy BalanceStrategy) *HostStatus {
+	var candidates []*HostStatus
+	for _, status := range m.GetOnlineHosts() {
+		for _, model := range status.Models {
+			if model.Name == modelName {
+				candidates = append(candidates, status)
+				break
+			}
+		}
+	}
+	return m.SelectHost(candidates, strategy)
+}
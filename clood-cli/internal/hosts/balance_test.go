@@ -0,0 +1,88 @@
+package hosts
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSelectHost(t *testing.T) {
+	m := NewManager(WithRoundRobinOffset(1))
+	a := &HostStatus{Host: &Host{Name: "a"}, Latency: 10 * time.Millisecond}
+	b := &HostStatus{Host: &Host{Name: "b"}, Latency: 25 * time.Millisecond}
+	candidates := []*HostStatus{a, b}
+
+	if got := m.SelectHost(candidates, BalancePriority); got != a {
+		t.Errorf("priority picked %s", got.Host.Name)
+	}
+
+	var order []string
+	for i := 0; i < 3; i++ {
+		order = append(order, m.SelectHost(candidates, BalanceRoundRobin).Host.Name)
+	}
+	if order[0] != "b" || order[1] != "a" || order[2] != "b" || m.RoundRobinOffset() != 4 {
+		t.Errorf("round-robin order = %v, offset %d", order, m.RoundRobinOffset())
+	}
+
+	if got := m.SelectHost(candidates, BalanceLeastLatency); got != a {
+		t.Errorf("least-latency idle picked %s", got.Host.Name)
+	}
+
+	// Three in flight on a: 10ms * 4 = 40ms, so b's 25ms wins
+	var dones []func()
+	for i := 0; i < 3; i++ {
+		dones = append(dones, m.BeginRequest("a"))
+	}
+	if got := m.SelectHost(candidates, BalanceLeastLatency); got != b || a.ActiveRequests != 3 {
+		t.Errorf("least-latency loaded picked %s (a active %d)", got.Host.Name, a.ActiveRequests)
+	}
+	for _, done := range dones {
+		done()
+	}
+	if n := m.ActiveRequests("a"); n != 0 {
+		t.Errorf("active after completion = %d", n)
+	}
+
+	if m.SelectHost(nil, BalanceRandom) != nil {
+		t.Error("empty candidates should select nil")
+	}
+}
+
+func TestParseBalanceStrategy(t *testing.T) {
+	for in, want := range map[string]BalanceStrategy{
+		"":             BalancePriority,
+		"fastest":      BalancePriority,
+		"round-robin":  BalanceRoundRobin,
+		"least-loaded": BalanceLeastLatency,
+		"random":       BalanceRandom,
+	} {
+		if got, err := ParseBalanceStrategy(in); err != nil || got != want {
+			t.Errorf("ParseBalanceStrategy(%q) = %q, %v", in, got, err)
+		}
+	}
+	if _, err := ParseBalanceStrategy("busiest"); err == nil {
+		t.Error("expected error for unknown strategy")
+	}
+}
+
+func TestRequestLoadSharedAcrossManagers(t *testing.T) {
+	load := NewRequestLoad()
+	first := NewManager(WithRequestLoad(load))
+	done := first.BeginRequest("a")
+
+	// A manager rebuilt from config still sees the request in flight
+	second := NewManager(WithRequestLoad(load))
+	a := &HostStatus{Host: &Host{Name: "a"}, Latency: 10 * time.Millisecond}
+	b := &HostStatus{Host: &Host{Name: "b"}, Latency: 15 * time.Millisecond}
+	if got := second.SelectHost([]*HostStatus{a, b}, BalanceLeastLatency); got != b {
+		t.Errorf("picked %s with a busy", got.Host.Name)
+	}
+
+	done()
+	done() // calling done twice doesn't go negative
+	if n := second.ActiveRequests("a"); n != 0 {
+		t.Errorf("active after completion = %d", n)
+	}
+	if got := second.SelectHost([]*HostStatus{a, b}, BalanceLeastLatency); got != a {
+		t.Errorf("picked %s with a idle", got.Host.Name)
+	}
+}
@@ -1,6 +1,8 @@
 package hosts
 
 import (
+	"context"
+	"fmt"
 	"sort"
 	"sync"
 	"time"
@@ -22,9 +24,10 @@ type Host struct {
 	URL      string      `yaml:"url"`
 	Priority int         `yaml:"priority"` // Lower = higher priority
 	Enabled  bool        `yaml:"enabled"`
-	Backend  BackendType `yaml:"backend,omitempty"` // ollama (default) or openai
-	Models   []string    `yaml:"models,omitempty"`  // Static model list (for openai backends)
-	APIKey   string      `yaml:"api_key,omitempty"` // Optional API key
+	Backend  BackendType `yaml:"backend,omitempty"`  // ollama (default) or openai
+	Models   []string    `yaml:"models,omitempty"`   // Static model list (for openai backends)
+	APIKey   string      `yaml:"api_key,omitempty"`  // Optional API key
+	SSHHost  string      `yaml:"ssh_host,omitempty"` // SSH target for 'clood hosts tunnel' (user@box)
 }
 
 // HostStatus contains the current status of a host
@@ -36,23 +39,57 @@ type HostStatus struct {
 	Models    []ollama.Model
 	Error     error
 	CheckedAt time.Time
+
+	// ActiveRequests is the number of requests this manager has dispatched
+	// to the host that haven't completed, as of the last SelectHost
+	ActiveRequests int
 }
 
+// DefaultCheckTimeout bounds a single host health check
+const DefaultCheckTimeout = 5 * time.Second
+
 // Manager handles multiple Ollama hosts
 type Manager struct {
-	hosts   []*Host
-	clients map[string]*ollama.Client
-	status  map[string]*HostStatus
-	mu      sync.RWMutex
+	hosts        []*Host
+	clients      map[string]*ollama.Client
+	status       map[string]*HostStatus
+	checkTimeout time.Duration
+	load         *RequestLoad // in-flight requests by host name
+	rrNext       uint64       // next round-robin pick
+	mu           sync.RWMutex
+}
+
+// ManagerOption configures a Manager
+type ManagerOption func(*Manager)
+
+// WithTimeout sets how long CheckHost waits for a host before marking it
+// offline. Use a short value on localhost and a longer one over VPN.
+func WithTimeout(d time.Duration) ManagerOption {
+	return func(m *Manager) {
+		if d > 0 {
+			m.checkTimeout = d
+		}
+	}
 }
 
 // NewManager creates a new host manager
-func NewManager() *Manager {
-	return &Manager{
-		hosts:   make([]*Host, 0),
-		clients: make(map[string]*ollama.Client),
-		status:  make(map[string]*HostStatus),
+func NewManager(opts ...ManagerOption) *Manager {
+	m := &Manager{
+		hosts:        make([]*Host, 0),
+		clients:      make(map[string]*ollama.Client),
+		status:       make(map[string]*HostStatus),
+		checkTimeout: DefaultCheckTimeout,
+		load:         NewRequestLoad(),
+	}
+	for _, opt := range opts {
+		opt(m)
 	}
+	return m
+}
+
+// CheckTimeout returns the per-host health check timeout
+func (m *Manager) CheckTimeout() time.Duration {
+	return m.checkTimeout
 }
 
 // DefaultHosts returns the default host configuration
@@ -123,6 +160,15 @@ func (m *Manager) GetAllHosts() []*Host {
 
 // CheckHost checks the status of a single host
 func (m *Manager) CheckHost(host *Host) *HostStatus {
+	return m.CheckHostContext(context.Background(), host)
+}
+
+// CheckHostContext checks a single host, giving up when ctx is cancelled
+// or the manager's check timeout elapses
+func (m *Manager) CheckHostContext(ctx context.Context, host *Host) *HostStatus {
+	ctx, cancel := context.WithTimeout(ctx, m.checkTimeout)
+	defer cancel()
+
 	client := m.GetClient(host.Name)
 	if client == nil {
 		return &HostStatus{
@@ -139,7 +185,7 @@ func (m *Manager) CheckHost(host *Host) *HostStatus {
 	}
 
 	// Ping for latency
-	latency, err := client.Ping()
+	latency, err := client.PingContext(ctx)
 	if err != nil {
 		status.Online = false
 		status.Error = err
@@ -150,12 +196,12 @@ func (m *Manager) CheckHost(host *Host) *HostStatus {
 	status.Latency = latency
 
 	// Get version
-	if version, err := client.Version(); err == nil {
+	if version, err := client.VersionContext(ctx); err == nil {
 		status.Version = version
 	}
 
 	// Get models
-	if models, err := client.ListModels(); err == nil {
+	if models, err := client.ListModelsContext(ctx); err == nil {
 		status.Models = models
 	}
 
@@ -169,6 +215,12 @@ func (m *Manager) CheckHost(host *Host) *HostStatus {
 
 // CheckAllHosts checks all hosts concurrently
 func (m *Manager) CheckAllHosts() []*HostStatus {
+	return m.CheckAllHostsContext(context.Background())
+}
+
+// CheckAllHostsContext checks all hosts concurrently. Cancelling ctx
+// (e.g. on Ctrl+C) aborts in-flight checks; those hosts report offline.
+func (m *Manager) CheckAllHostsContext(ctx context.Context) []*HostStatus {
 	hosts := m.GetAllHosts()
 	results := make([]*HostStatus, len(hosts))
 
@@ -177,7 +229,7 @@ func (m *Manager) CheckAllHosts() []*HostStatus {
 		wg.Add(1)
 		go func(idx int, h *Host) {
 			defer wg.Done()
-			results[idx] = m.CheckHost(h)
+			results[idx] = m.CheckHostContext(ctx, h)
 		}(i, host)
 	}
 	wg.Wait()
@@ -195,6 +247,11 @@ type HostCheckResult struct {
 // as each host completes. Caller should read from the returned channel
 // until it's closed. The total number of results will equal len(GetAllHosts()).
 func (m *Manager) CheckAllHostsStreaming() (<-chan HostCheckResult, int) {
+	return m.CheckAllHostsStreamingContext(context.Background())
+}
+
+// CheckAllHostsStreamingContext is CheckAllHostsStreaming with cancellation
+func (m *Manager) CheckAllHostsStreamingContext(ctx context.Context) (<-chan HostCheckResult, int) {
 	hosts := m.GetAllHosts()
 	results := make(chan HostCheckResult, len(hosts))
 
@@ -204,7 +261,7 @@ func (m *Manager) CheckAllHostsStreaming() (<-chan HostCheckResult, int) {
 			wg.Add(1)
 			go func(idx int, h *Host) {
 				defer wg.Done()
-				status := m.CheckHost(h)
+				status := m.CheckHostContext(ctx, h)
 				results <- HostCheckResult{Index: idx, Status: status}
 			}(i, host)
 		}
@@ -278,6 +335,86 @@ func (m *Manager) GetAllModels() map[string][]string {
 	return models
 }
 
+// Model summarizes one model across every online host that has it
+type Model struct {
+	Name           string   `json:"name"`
+	Size           int64    `json:"size"`                       // bytes on disk
+	SizeHuman      string   `json:"size_human"`                 // e.g. "4.1 GB"
+	ParameterSize  string   `json:"parameter_size,omitempty"`   // e.g. "7.6B", when Ollama reports it
+	VRAMEstimateMB int64    `json:"vram_mb_estimate,omitempty"` // approximate, see EstimateVRAMMB
+	Hosts          []string `json:"hosts"`
+}
+
+// vramOverhead scales a model's file size up to what loading it takes:
+// the weights plus KV cache and runtime buffers at a default context
+const vramOverhead = 1.3
+
+// EstimateVRAMMB approximates the VRAM, in MB, a model needs from its size
+// on disk. It's a rough planning figure: long contexts need more.
+func EstimateVRAMMB(sizeBytes int64) int64 {
+	return int64(float64(sizeBytes)*vramOverhead) / (1024 * 1024)
+}
+
+// HostModels lists the models on one host in the same form as GetModels
+func HostModels(status *HostStatus) []Model {
+	models := make([]Model, 0, len(status.Models))
+	for _, om := range status.Models {
+		models = append(models, Model{
+			Name:           om.Name,
+			Size:           om.Size,
+			SizeHuman:      FormatSize(om.Size),
+			ParameterSize:  om.Details.ParameterSize,
+			VRAMEstimateMB: EstimateVRAMMB(om.Size),
+			Hosts:          []string{status.Host.Name},
+		})
+	}
+	return models
+}
+
+// GetModels is GetAllModels with size and parameter count from /api/tags
+func (m *Manager) GetModels() map[string]*Model {
+	statuses := m.CheckAllHosts()
+	models := make(map[string]*Model)
+
+	for _, status := range statuses {
+		if !status.Online {
+			continue
+		}
+		for _, om := range status.Models {
+			model, ok := models[om.Name]
+			if !ok {
+				model = &Model{Name: om.Name}
+				models[om.Name] = model
+			}
+			if model.Size == 0 && om.Size > 0 {
+				model.Size = om.Size
+				model.SizeHuman = FormatSize(om.Size)
+				model.VRAMEstimateMB = EstimateVRAMMB(om.Size)
+			}
+			if model.ParameterSize == "" {
+				model.ParameterSize = om.Details.ParameterSize
+			}
+			model.Hosts = append(model.Hosts, status.Host.Name)
+		}
+	}
+
+	return models
+}
+
+// FormatSize renders a byte count like "4.1 GB"
+func FormatSize(b int64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	div, exp := int64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(b)/float64(div), "KMGTPE"[exp])
+}
+
 // FindModel searches for a model across all hosts
 func (m *Manager) FindModel(modelName string) []*HostStatus {
 	statuses := m.CheckAllHosts()
@@ -0,0 +1,108 @@
+package hosts
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/dirtybirdnj/clood/internal/ollama"
+)
+
+func TestHostModelsVRAMEstimate(t *testing.T) {
+	const gb = 1024 * 1024 * 1024
+	if got := EstimateVRAMMB(4 * gb); got != 5324 {
+		t.Errorf("4 GB model = %d MB, want 5324", got)
+	}
+
+	status := &HostStatus{
+		Host:   &Host{Name: "mini"},
+		Models: []ollama.Model{{Name: "qwen2.5-coder:7b", Size: 4 * gb}},
+	}
+	models := HostModels(status)
+	if len(models) != 1 || models[0].VRAMEstimateMB != 5324 || models[0].Hosts[0] != "mini" {
+		t.Errorf("HostModels = %+v", models)
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	tests := []struct {
+		bytes int64
+		want  string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1024, "1.0 KB"},
+		{1536, "1.5 KB"},
+		{5 * 1024 * 1024, "5.0 MB"},
+		{4402341478, "4.1 GB"},
+		{3 << 40, "3.0 TB"},
+	}
+	for _, tt := range tests {
+		if got := FormatSize(tt.bytes); got != tt.want {
+			t.Errorf("FormatSize(%d) = %q; want %q", tt.bytes, got, tt.want)
+		}
+	}
+}
+
+// GetModels is what 'clood models --json' and clood_models print, so its
+// JSON shape is a contract with scripts
+func TestGetModelsJSON(t *testing.T) {
+	ollamaAPI := func(tags string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/api/tags":
+				w.Write([]byte(tags))
+			case "/api/version":
+				w.Write([]byte(`{"version":"0.5.0"}`))
+			default:
+				w.Write([]byte("Ollama is running"))
+			}
+		}))
+	}
+	mini := ollamaAPI(`{"models":[{"name":"qwen2.5-coder:7b","size":4683087332,"details":{"parameter_size":"7.6B"}}]}`)
+	defer mini.Close()
+	gpu := ollamaAPI(`{"models":[{"name":"qwen2.5-coder:7b","size":4683087332,"details":{"parameter_size":"7.6B"}},{"name":"tinyllama:latest","size":637700138}]}`)
+	defer gpu.Close()
+
+	m := NewManager()
+	m.AddHosts([]*Host{
+		{Name: "mini", URL: mini.URL, Priority: 1, Enabled: true},
+		{Name: "gpu", URL: gpu.URL, Priority: 2, Enabled: true},
+	})
+	data, err := json.Marshal(m.GetModels())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got map[string]map[string]interface{}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	qwen := got["qwen2.5-coder:7b"]
+	if qwen == nil || len(got) != 2 {
+		t.Fatalf("models = %s; want qwen2.5-coder:7b and tinyllama:latest", data)
+	}
+	for key, want := range map[string]interface{}{
+		"name":             "qwen2.5-coder:7b",
+		"size":             4683087332.0,
+		"size_human":       "4.4 GB",
+		"parameter_size":   "7.6B",
+		"vram_mb_estimate": 5805.0,
+	} {
+		if qwen[key] != want {
+			t.Errorf("qwen2.5-coder:7b %s = %v; want %v", key, qwen[key], want)
+		}
+	}
+	hosts, _ := qwen["hosts"].([]interface{})
+	if len(hosts) != 2 {
+		t.Errorf("qwen2.5-coder:7b hosts = %v; want both", qwen["hosts"])
+	}
+	if tiny := got["tinyllama:latest"]; !reflect.DeepEqual(tiny["hosts"], []interface{}{"gpu"}) {
+		t.Errorf("tinyllama hosts = %v; want [gpu]", tiny["hosts"])
+	}
+	if _, ok := got["tinyllama:latest"]["parameter_size"]; ok {
+		t.Error("parameter_size should be left out when Ollama doesn't report it")
+	}
+}
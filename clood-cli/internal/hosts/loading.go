@@ -0,0 +1,57 @@
+package hosts
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dirtybirdnj/clood/internal/ollama"
+)
+
+// LoadTracker spots models that are still loading into memory.
+//
+// /api/ps has no load state, but Ollama only fixes a runner's expiry once
+// it has loaded and served a request; until then it reports keep_alive
+// from now, so expires_at moves forward with the clock on every poll.
+// A model whose expires_at kept pace with the time between two polls is
+// therefore still loading. A model seen for the first time is assumed
+// ready; it takes a second poll to tell.
+type LoadTracker struct {
+	mu   sync.Mutex
+	last map[string]psSnapshot // by host name
+}
+
+type psSnapshot struct {
+	at      time.Time
+	expires map[string]time.Time // by model name
+}
+
+// NewLoadTracker creates an empty tracker
+func NewLoadTracker() *LoadTracker {
+	return &LoadTracker{last: map[string]psSnapshot{}}
+}
+
+// Observe records host's /api/ps models as of now and returns the names
+// of those that are loading
+func (t *LoadTracker) Observe(host string, running []ollama.RunningModel, now time.Time) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	prev, hadPrev := t.last[host]
+	snap := psSnapshot{at: now, expires: make(map[string]time.Time, len(running))}
+
+	var loading []string
+	for _, m := range running {
+		snap.expires[m.Name] = m.ExpiresAt
+		before, ok := prev.expires[m.Name]
+		if !hadPrev || !ok {
+			continue
+		}
+		elapsed := now.Sub(prev.at)
+		if elapsed > 0 && m.ExpiresAt.Sub(before) >= elapsed/2 {
+			loading = append(loading, m.Name)
+		}
+	}
+
+	t.last[host] = snap
+	return loading
+}
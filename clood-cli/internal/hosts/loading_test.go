@@ -0,0 +1,41 @@
+package hosts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dirtybirdnj/clood/internal/ollama"
+)
+
+func TestLoadTracker(t *testing.T) {
+	tr := NewLoadTracker()
+	t0 := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	keepAlive := 5 * time.Minute
+	fixed := t0.Add(keepAlive)
+
+	poll := func(at time.Time, loadingExpiry bool) []string {
+		big := at.Add(keepAlive) // recomputed every poll while loading
+		if !loadingExpiry {
+			big = fixed
+		}
+		return tr.Observe("gpu", []ollama.RunningModel{
+			{Name: "small:7b", ExpiresAt: fixed},
+			{Name: "big:32b", ExpiresAt: big},
+		}, at)
+	}
+
+	if got := poll(t0, true); len(got) != 0 {
+		t.Errorf("first poll reported loading %v", got)
+	}
+	if got := poll(t0.Add(10*time.Second), true); len(got) != 1 || got[0] != "big:32b" {
+		t.Errorf("second poll loading = %v, want [big:32b]", got)
+	}
+	if got := poll(t0.Add(20*time.Second), false); len(got) != 0 {
+		t.Errorf("after expiry settled loading = %v", got)
+	}
+
+	// Hosts are tracked separately
+	if got := tr.Observe("mini", []ollama.RunningModel{{Name: "big:32b", ExpiresAt: t0.Add(time.Hour)}}, t0.Add(30*time.Second)); len(got) != 0 {
+		t.Errorf("new host reported loading %v", got)
+	}
+}
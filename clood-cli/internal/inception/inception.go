@@ -30,8 +30,33 @@ import (
 
 // SubQueryPattern matches inception triggers in LLM output.
 // Format: <sub-query model="model_name">question here</sub-query>
+//
+// It only matches the canonical form; DetectSubQuery uses a tolerant
+// scanner that also accepts other attribute orders and nested tags.
 var SubQueryPattern = regexp.MustCompile(`<sub-query\s+model="([^"]+)">([\s\S]*?)</sub-query>`)
 
+const (
+	subQueryOpen  = "<sub-query"
+	subQueryClose = "</sub-query>"
+)
+
+// subQueryModelAttr pulls the model attribute out of an opening tag,
+// wherever it appears among the attributes and with either quote style
+var subQueryModelAttr = regexp.MustCompile(`(?:^|\s)model\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+
+// Expert stream markers. A StreamProcessor with StreamExperts set frames
+// each sub-query answer on its output as ExpertStartPrefix+alias, then
+// ExpertChunkPrefix+token per token, then the END EXPERT footer. The
+// record separator byte keeps them from colliding with model text.
+const (
+	ExpertStartPrefix = "\x1eEXPERT-START\x1e"
+	ExpertChunkPrefix = "\x1eEXPERT\x1e"
+)
+
+// DefaultPartialTimeout bounds how long a StreamProcessor holds text
+// after an unclosed <sub-query> before giving up and emitting it as-is
+const DefaultPartialTimeout = 30 * time.Second
+
 // SubQuery represents a detected sub-query in the stream
 type SubQuery struct {
 	Model    string // Target model for the sub-query (e.g., "science", "math")
@@ -73,14 +98,22 @@ func DefaultRegistry() ModelRegistry {
 	}
 }
 
+// DefaultMaxDepth keeps experts from calling other experts
+const DefaultMaxDepth = 1
+
+// maxNestedSubQueries caps how many sub-queries one expert answer may
+// make, so a chatty expert can't fan out without bound at every level
+const maxNestedSubQueries = 3
+
 // Handler processes streams with inception support
 type Handler struct {
-	Registry     ModelRegistry
-	MaxDepth     int           // Maximum nesting depth (default: 1)
-	Timeout      time.Duration // Timeout for sub-queries
-	OllamaURL    string        // Ollama API URL
-	currentDepth int
-	mu           sync.Mutex
+	// Registry may be edited directly before streaming starts; once a
+	// stream is running, change it with SetRoles
+	Registry  ModelRegistry
+	mu        sync.RWMutex
+	MaxDepth  int           // Maximum nesting depth (default: 1, the main stream's experts only)
+	Timeout   time.Duration // Timeout for sub-queries
+	OllamaURL string        // Ollama API URL
 
 	// Callbacks for UI integration
 	OnSubQueryStart func(query SubQuery)
@@ -89,11 +122,20 @@ type Handler struct {
 	OnDepthExceeded func(query SubQuery)
 }
 
-// NewHandler creates a new inception handler
-func NewHandler() *Handler {
+// NewHandler creates a new inception handler. overrides (alias -> model,
+// usually from inception.yaml) are merged over DefaultRegistry.
+func NewHandler(overrides map[string]string) *Handler {
+	registry := DefaultRegistry()
+	for alias, model := range overrides {
+		alias = strings.ToLower(strings.TrimSpace(alias))
+		if alias != "" && model != "" {
+			registry[alias] = model
+		}
+	}
+
 	return &Handler{
-		Registry:  DefaultRegistry(),
-		MaxDepth:  1, // One level deep by default
+		Registry:  registry,
+		MaxDepth:  DefaultMaxDepth,
 		Timeout:   60 * time.Second,
 		OllamaURL: "http://localhost:11434",
 	}
@@ -101,49 +143,135 @@ func NewHandler() *Handler {
 
 // DetectSubQuery checks if the buffer contains a sub-query pattern
 func DetectSubQuery(buffer string) *SubQuery {
-	matches := SubQueryPattern.FindStringSubmatch(buffer)
-	if matches == nil {
-		return nil
+	query, _, _ := findSubQuery(buffer)
+	return query
+}
+
+// findSubQuery returns the first complete, well-formed sub-query in buffer
+// and the byte range [start, end) it occupies. Blocks without a model
+// attribute are skipped and left as literal text.
+func findSubQuery(buffer string) (*SubQuery, int, int) {
+	offset := 0
+	for {
+		start, tagEnd, end := scanSubQueryBlock(buffer[offset:])
+		if start < 0 || end < 0 {
+			return nil, -1, -1
+		}
+		start, tagEnd, end = start+offset, tagEnd+offset, end+offset
+
+		attrs := buffer[start+len(subQueryOpen) : tagEnd-1]
+		if m := subQueryModelAttr.FindStringSubmatch(attrs); m != nil {
+			model := strings.TrimSpace(m[1] + m[2])
+			if model != "" {
+				return &SubQuery{
+					Model:    model,
+					Query:    strings.TrimSpace(buffer[tagEnd : end-len(subQueryClose)]),
+					RawMatch: buffer[start:end],
+				}, start, end
+			}
+		}
+		offset = end
+	}
+}
+
+// scanSubQueryBlock finds the first <sub-query ...> opening tag in s.
+// It returns the tag's start, the offset just past its '>' (or -1 if the
+// tag itself is unfinished), and the offset just past the matching
+// </sub-query> (or -1 if unclosed). Nested opening tags are counted so
+// the outer block ends at its own closing tag; their text stays literal.
+// start is -1 when s contains no opening tag at all.
+func scanSubQueryBlock(s string) (start, tagEnd, end int) {
+	start = -1
+	for from := 0; from < len(s); {
+		i := strings.Index(s[from:], subQueryOpen)
+		if i < 0 {
+			return -1, -1, -1
+		}
+		i += from
+		next := i + len(subQueryOpen)
+		if next == len(s) || isTagBoundary(s[next]) {
+			start = i
+			break
+		}
+		from = next // e.g. "<sub-queryfoo", not our tag
+	}
+	if start < 0 {
+		return -1, -1, -1
 	}
 
-	return &SubQuery{
-		Model:    strings.TrimSpace(matches[1]),
-		Query:    strings.TrimSpace(matches[2]),
-		RawMatch: matches[0],
+	gt := strings.IndexByte(s[start:], '>')
+	if gt < 0 {
+		return start, -1, -1
 	}
+	tagEnd = start + gt + 1
+
+	depth := 1
+	pos := tagEnd
+	for depth > 0 {
+		closeIdx := strings.Index(s[pos:], subQueryClose)
+		if closeIdx < 0 {
+			return start, tagEnd, -1
+		}
+		openIdx := strings.Index(s[pos:], subQueryOpen)
+		if openIdx >= 0 && openIdx < closeIdx {
+			depth++
+			pos += openIdx + len(subQueryOpen)
+			continue
+		}
+		depth--
+		pos += closeIdx + len(subQueryClose)
+	}
+	return start, tagEnd, pos
+}
+
+func isTagBoundary(c byte) bool {
+	return c == '>' || c == ' ' || c == '\t' || c == '\n' || c == '\r'
 }
 
 // HasPartialSubQuery checks if buffer might contain an incomplete sub-query
 // This helps decide whether to wait for more content before processing
 func HasPartialSubQuery(buffer string) bool {
-	// Check for opening tag without closing tag
-	if strings.Contains(buffer, "<sub-query") && !strings.Contains(buffer, "</sub-query>") {
-		return true
+	offset := 0
+	for {
+		start, _, end := scanSubQueryBlock(buffer[offset:])
+		if start < 0 {
+			break
+		}
+		if end < 0 {
+			// Opening tag without its matching close
+			return true
+		}
+		offset += end
 	}
-	// Check for partial opening tag at the end of buffer (last 15 chars)
+
 	// The tag might be split across chunks: "<sub-" then "query..."
-	suffix := buffer
-	if len(suffix) > 15 {
-		suffix = buffer[len(buffer)-15:]
-	}
-	partials := []string{"<s", "<su", "<sub", "<sub-", "<sub-q", "<sub-qu", "<sub-que", "<sub-quer", "<sub-query"}
-	for _, partial := range partials {
-		if strings.Contains(suffix, partial) && !strings.Contains(buffer, "</sub-query>") {
+	rest := buffer[offset:]
+	if i := strings.LastIndexByte(rest, '<'); i >= 0 {
+		tail := rest[i:]
+		if len(tail) < len(subQueryOpen) && strings.HasPrefix(subQueryOpen, tail) {
 			return true
 		}
 	}
 	return false
 }
 
-// ExecuteSubQuery runs a synchronous query to another model
-func (h *Handler) ExecuteSubQuery(ctx context.Context, query SubQuery) SubQueryResult {
+// ExecuteSubQuery runs a synchronous query to another model. depth is how
+// many experts deep the asker is: 0 for the main stream. It fails once
+// depth reaches MaxDepth; below that, the expert may itself use
+// <sub-query> tags, which are answered at depth+1 and spliced into its
+// response.
+func (h *Handler) ExecuteSubQuery(ctx context.Context, query SubQuery, depth int) SubQueryResult {
+	return h.executeSubQuery(ctx, query, depth, h.OnSubQueryChunk)
+}
+
+// executeSubQuery is ExecuteSubQuery with the per-token callback passed
+// in, so a StreamProcessor can route tokens to its own output
+func (h *Handler) executeSubQuery(ctx context.Context, query SubQuery, depth int, onChunk func(string)) SubQueryResult {
 	start := time.Now()
 	result := SubQueryResult{Query: query}
 
 	// Check depth limit
-	h.mu.Lock()
-	if h.currentDepth >= h.MaxDepth {
-		h.mu.Unlock()
+	if depth >= h.MaxDepth {
 		result.Error = fmt.Errorf("inception depth limit (%d) exceeded", h.MaxDepth)
 		result.Duration = time.Since(start)
 		if h.OnDepthExceeded != nil {
@@ -151,14 +279,7 @@ func (h *Handler) ExecuteSubQuery(ctx context.Context, query SubQuery) SubQueryR
 		}
 		return result
 	}
-	h.currentDepth++
-	h.mu.Unlock()
-
-	defer func() {
-		h.mu.Lock()
-		h.currentDepth--
-		h.mu.Unlock()
-	}()
+	nested := depth+1 < h.MaxDepth
 
 	// Resolve model name
 	modelName := h.resolveModel(query.Model)
@@ -168,10 +289,18 @@ func (h *Handler) ExecuteSubQuery(ctx context.Context, query SubQuery) SubQueryR
 		h.OnSubQueryStart(query)
 	}
 
-	// Build the request with a system prompt that prevents further inception
+	// Build the request with a system prompt that prevents further inception,
+	// unless there is depth left for the expert to use
 	systemPrompt := `You are an expert assistant responding to a sub-query from another AI.
 Be concise and direct. Answer the question precisely.
 IMPORTANT: Do NOT use <sub-query> tags in your response. Answer directly.`
+	if nested {
+		systemPrompt = `You are an expert assistant responding to a sub-query from another AI.
+Be concise and direct. Answer the question precisely.
+If part of the answer needs another specialist, you may ask one with
+<sub-query model="MODEL_NAME">question</sub-query>; the answer replaces the tag.
+Only do this when you cannot answer that part yourself.`
+	}
 
 	reqBody := map[string]interface{}{
 		"model":  modelName,
@@ -227,8 +356,8 @@ IMPORTANT: Do NOT use <sub-query> tags in your response. Answer directly.`
 		if chunk.Response != "" {
 			responseBuilder.WriteString(chunk.Response)
 			// Notify UI of streaming chunk
-			if h.OnSubQueryChunk != nil {
-				h.OnSubQueryChunk(chunk.Response)
+			if onChunk != nil {
+				onChunk(chunk.Response)
 			}
 		}
 		if chunk.Done {
@@ -237,6 +366,9 @@ IMPORTANT: Do NOT use <sub-query> tags in your response. Answer directly.`
 	}
 
 	result.Response = responseBuilder.String()
+	if nested {
+		result.Response = h.resolveNested(ctx, result.Response, depth+1)
+	}
 	result.Duration = time.Since(start)
 
 	// Notify end
@@ -247,8 +379,78 @@ IMPORTANT: Do NOT use <sub-query> tags in your response. Answer directly.`
 	return result
 }
 
+// resolveNested answers the sub-queries in an expert's response at depth
+// and replaces each tag with its answer. Tags past maxNestedSubQueries
+// are left as they are.
+func (h *Handler) resolveNested(ctx context.Context, response string, depth int) string {
+	var out strings.Builder
+	rest := response
+	for i := 0; i < maxNestedSubQueries; i++ {
+		query, start, end := findSubQuery(rest)
+		if query == nil {
+			break
+		}
+		out.WriteString(rest[:start])
+		r := h.ExecuteSubQuery(ctx, *query, depth)
+		if r.Error != nil {
+			fmt.Fprintf(&out, "[Sub-query failed: %v]", r.Error)
+		} else {
+			fmt.Fprintf(&out, "[%s: %s]", query.Model, strings.TrimSpace(r.Response))
+		}
+		rest = rest[end:]
+	}
+	out.WriteString(rest)
+	return out.String()
+}
+
+// ParseRoles reads alias=model pairs separated by commas, as given to
+// inception --roles or /roles. Aliases are lowercased like the registry's.
+func ParseRoles(spec string) (map[string]string, error) {
+	roles := map[string]string{}
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		alias, model, ok := strings.Cut(pair, "=")
+		alias = strings.ToLower(strings.TrimSpace(alias))
+		model = strings.TrimSpace(model)
+		if !ok || alias == "" || model == "" {
+			return nil, fmt.Errorf("role %q: want alias=model", pair)
+		}
+		roles[alias] = model
+	}
+	if len(roles) == 0 {
+		return nil, fmt.Errorf("no roles in %q: want alias=model[,alias=model...]", spec)
+	}
+	return roles, nil
+}
+
+// SetRoles points aliases at models, taking effect from the next
+// sub-query. It's safe to call while a stream is running.
+func (h *Handler) SetRoles(roles map[string]string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for alias, model := range roles {
+		h.Registry[strings.ToLower(alias)] = model
+	}
+}
+
+// Roles returns a copy of the alias registry
+func (h *Handler) Roles() ModelRegistry {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	roles := make(ModelRegistry, len(h.Registry))
+	for alias, model := range h.Registry {
+		roles[alias] = model
+	}
+	return roles
+}
+
 // resolveModel converts a friendly name to an actual model name
 func (h *Handler) resolveModel(name string) string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
 	if resolved, ok := h.Registry[strings.ToLower(name)]; ok {
 		return resolved
 	}
@@ -265,26 +467,26 @@ func (h *Handler) ProcessBuffer(ctx context.Context, buffer string) (string, str
 	}
 
 	// Execute the sub-query (streams via OnSubQueryChunk if set)
-	result := h.ExecuteSubQuery(ctx, *query)
+	result := h.ExecuteSubQuery(ctx, *query, 0)
 
 	// Remove the sub-query tag from buffer
 	cleanedBuffer := strings.Replace(buffer, query.RawMatch, "", 1)
 
-	// Format the injection - if OnSubQueryChunk is set, we already streamed the content
-	// so just include the footer. Otherwise include full response.
-	var injection string
+	// If OnSubQueryChunk is set, we already streamed the content
+	return cleanedBuffer, formatInjection(*query, result, h.OnSubQueryChunk != nil), true
+}
+
+// formatInjection is the text that replaces a sub-query in the stream:
+// just a footer when the answer was already streamed, else the full answer
+func formatInjection(query SubQuery, result SubQueryResult, streamed bool) string {
 	if result.Error != nil {
-		injection = fmt.Sprintf("\n[Sub-query failed: %v]\n", result.Error)
-	} else if h.OnSubQueryChunk != nil {
-		// Content was streamed, just add footer
-		injection = fmt.Sprintf("\n───── END EXPERT [%.1fs] ─────\n\n", result.Duration.Seconds())
-	} else {
-		// No streaming, include full response
-		injection = fmt.Sprintf("\n───── 🌀 SUB-QUERY RESPONSE [%s] ─────\n%s\n───── END SUB-QUERY [%.1fs] ─────\n\n",
-			query.Model, result.Response, result.Duration.Seconds())
+		return fmt.Sprintf("\n[Sub-query failed: %v]\n", result.Error)
 	}
-
-	return cleanedBuffer, injection, true
+	if streamed {
+		return fmt.Sprintf("\n───── END EXPERT [%.1fs] ─────\n\n", result.Duration.Seconds())
+	}
+	return fmt.Sprintf("\n───── 🌀 SUB-QUERY RESPONSE [%s] ─────\n%s\n───── END SUB-QUERY [%.1fs] ─────\n\n",
+		query.Model, result.Response, result.Duration.Seconds())
 }
 
 // StreamProcessor wraps a stream channel with inception support
@@ -294,16 +496,36 @@ type StreamProcessor struct {
 	OutputChan chan string
 	buffer     strings.Builder
 	ctx        context.Context
+
+	// PartialTimeout is how long text after an unclosed <sub-query> is held
+	// before it is emitted as plain text (default: DefaultPartialTimeout)
+	PartialTimeout time.Duration
+	holdSince      time.Time
+
+	// StreamExperts sends each expert's answer to OutputChan token by
+	// token, framed by the Expert*Prefix markers, instead of all at once
+	// when it finishes
+	StreamExperts bool
 }
 
 // NewStreamProcessor creates a processor that wraps an input channel
 func NewStreamProcessor(ctx context.Context, handler *Handler, input <-chan string) *StreamProcessor {
 	return &StreamProcessor{
-		Handler:    handler,
-		InputChan:  input,
-		OutputChan: make(chan string, 100),
-		ctx:        ctx,
+		Handler:        handler,
+		InputChan:      input,
+		OutputChan:     make(chan string, 100),
+		ctx:            ctx,
+		PartialTimeout: DefaultPartialTimeout,
+	}
+}
+
+// flushPartial gives up on a held sub-query and emits it as plain text
+func (p *StreamProcessor) flushPartial() {
+	if p.buffer.Len() > 0 {
+		p.OutputChan <- p.buffer.String()
 	}
+	p.buffer.Reset()
+	p.holdSince = time.Time{}
 }
 
 // Process reads from input, handles inception, writes to output
@@ -311,10 +533,27 @@ func (p *StreamProcessor) Process() {
 	defer close(p.OutputChan)
 
 	for {
+		// While holding a partial sub-query, wake up when it times out even
+		// if the model has stopped sending chunks
+		var timeout <-chan time.Time
+		var timer *time.Timer
+		if !p.holdSince.IsZero() && p.PartialTimeout > 0 {
+			timer = time.NewTimer(time.Until(p.holdSince.Add(p.PartialTimeout)))
+			timeout = timer.C
+		}
+
 		select {
 		case <-p.ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
 			return
+		case <-timeout:
+			p.flushPartial()
 		case chunk, ok := <-p.InputChan:
+			if timer != nil {
+				timer.Stop()
+			}
 			if !ok {
 				// Input closed, flush remaining buffer
 				if p.buffer.Len() > 0 {
@@ -327,39 +566,55 @@ func (p *StreamProcessor) Process() {
 			bufferStr := p.buffer.String()
 
 			// Check for complete sub-query
-			if query := DetectSubQuery(bufferStr); query != nil {
-				// Found complete sub-query - process it
-				_, injection, _ := p.Handler.ProcessBuffer(p.ctx, bufferStr)
-
+			if query, start, end := findSubQuery(bufferStr); query != nil {
 				// Output everything before the sub-query tag position
-				preQuery := strings.Split(bufferStr, "<sub-query")[0]
-				if preQuery != "" {
+				// first, so the text reads in order around the answer
+				if preQuery := bufferStr[:start]; preQuery != "" {
 					p.OutputChan <- preQuery
 				}
 
-				// Output the injection (sub-query response)
-				p.OutputChan <- injection
+				// Found complete sub-query - process it and output the
+				// injection (sub-query response)
+				p.OutputChan <- p.runSubQuery(*query)
 
 				// Reset buffer and keep anything AFTER the closing tag
 				p.buffer.Reset()
-				// Find content after </sub-query> in the ORIGINAL buffer
-				afterParts := strings.SplitN(bufferStr, "</sub-query>", 2)
-				if len(afterParts) > 1 && afterParts[1] != "" {
-					p.buffer.WriteString(afterParts[1])
-				}
+				p.buffer.WriteString(bufferStr[end:])
+				p.holdSince = time.Time{}
 
 			} else if HasPartialSubQuery(bufferStr) {
-				// Might be incomplete sub-query, hold in buffer
+				// Might be incomplete sub-query, hold in buffer - but not forever
+				if p.holdSince.IsZero() {
+					p.holdSince = time.Now()
+				} else if p.PartialTimeout > 0 && time.Since(p.holdSince) >= p.PartialTimeout {
+					p.flushPartial()
+				}
 				continue
 			} else {
 				// No sub-query, output and clear buffer
 				p.OutputChan <- bufferStr
 				p.buffer.Reset()
+				p.holdSince = time.Time{}
 			}
 		}
 	}
 }
 
+// runSubQuery answers query and returns the text to inject. With
+// StreamExperts the answer has already gone out by the time it returns,
+// so that's just the footer.
+func (p *StreamProcessor) runSubQuery(query SubQuery) string {
+	if !p.StreamExperts {
+		_, injection, _ := p.Handler.ProcessBuffer(p.ctx, query.RawMatch)
+		return injection
+	}
+	p.OutputChan <- ExpertStartPrefix + query.Model
+	result := p.Handler.executeSubQuery(p.ctx, query, 0, func(chunk string) {
+		p.OutputChan <- ExpertChunkPrefix + chunk
+	})
+	return formatInjection(query, result, true)
+}
+
 // Start begins processing in a goroutine and returns the output channel
 func (p *StreamProcessor) Start() <-chan string {
 	go p.Process()
@@ -0,0 +1,217 @@
+package inception
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDetectSubQuery(t *testing.T) {
+	tests := []struct {
+		name   string
+		buffer string
+		model  string
+		query  string
+	}{
+		{"canonical", `before <sub-query model="math">2+2?</sub-query> after`, "math", "2+2?"},
+		{"attribute order", `<sub-query id="1" model='science'>why?</sub-query>`, "science", "why?"},
+		{"nested is literal", `<sub-query model="code">a <sub-query model="x">b</sub-query> c</sub-query>`,
+			"code", `a <sub-query model="x">b</sub-query> c`},
+		{"skips malformed", `<sub-query>no model</sub-query> <sub-query model="math">ok</sub-query>`, "math", "ok"},
+		{"unclosed", `<sub-query model="math">never ends`, "", ""},
+		{"not our tag", `<sub-queryish model="math">x</sub-query>`, "", ""},
+	}
+
+	for _, tt := range tests {
+		q := DetectSubQuery(tt.buffer)
+		if tt.model == "" {
+			if q != nil {
+				t.Errorf("%s: got %+v, want nil", tt.name, q)
+			}
+			continue
+		}
+		if q == nil || q.Model != tt.model || q.Query != tt.query {
+			t.Errorf("%s: got %+v, want model=%q query=%q", tt.name, q, tt.model, tt.query)
+		}
+	}
+}
+
+func TestHasPartialSubQuery(t *testing.T) {
+	tests := []struct {
+		buffer string
+		want   bool
+	}{
+		{"plain text", false},
+		{"text <sub-", true},
+		{"text <sub-query", true},
+		{`<sub-query model="m">open`, true},
+		{`<sub-query model="a">x <sub-query>y</sub-query>`, true}, // outer still open
+		{`<sub-query model="a">x</sub-query> done`, false},
+		{"a <strong> tag", false},
+	}
+	for _, tt := range tests {
+		if got := HasPartialSubQuery(tt.buffer); got != tt.want {
+			t.Errorf("HasPartialSubQuery(%q) = %v, want %v", tt.buffer, got, tt.want)
+		}
+	}
+}
+
+func TestStreamProcessorPartialTimeout(t *testing.T) {
+	input := make(chan string)
+	p := NewStreamProcessor(context.Background(), NewHandler(nil), input)
+	p.PartialTimeout = 50 * time.Millisecond
+	out := p.Start()
+
+	// An unclosed tag followed by silence must not hold the stream forever
+	input <- `hello <sub-query model="math">never closed`
+
+	select {
+	case got := <-out:
+		if !strings.Contains(got, "never closed") {
+			t.Errorf("flushed %q, want the held text", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("partial sub-query was never flushed")
+	}
+
+	input <- "more"
+	if got := <-out; got != "more" {
+		t.Errorf("after flush got %q, want plain passthrough", got)
+	}
+	close(input)
+}
+
+func TestNewHandlerOverrides(t *testing.T) {
+	h := NewHandler(map[string]string{"Physics": "phi4:14b", "code": "qwen2.5-coder:14b", "empty": ""})
+
+	if got := h.resolveModel("physics"); got != "phi4:14b" {
+		t.Errorf("physics resolved to %q, want phi4:14b", got)
+	}
+	if got := h.resolveModel("code"); got != "qwen2.5-coder:14b" {
+		t.Errorf("code resolved to %q, want the override", got)
+	}
+	if got := h.resolveModel("math"); got != DefaultRegistry()["math"] {
+		t.Errorf("math resolved to %q, want the default", got)
+	}
+	if _, ok := h.Registry["empty"]; ok {
+		t.Error("alias with no model should be ignored")
+	}
+}
+
+func TestParseRoles(t *testing.T) {
+	roles, err := ParseRoles(" Science=phi4:14b, code=qwen2.5-coder:32b,")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if roles["science"] != "phi4:14b" || roles["code"] != "qwen2.5-coder:32b" || len(roles) != 2 {
+		t.Errorf("ParseRoles = %v", roles)
+	}
+
+	for _, bad := range []string{"", "science", "science=", "=phi4:14b", "code=qwen,math"} {
+		if _, err := ParseRoles(bad); err == nil {
+			t.Errorf("ParseRoles(%q) succeeded, want an error", bad)
+		}
+	}
+}
+
+func TestSetRoles(t *testing.T) {
+	h := NewHandler(nil)
+	h.SetRoles(map[string]string{"Science": "phi4:14b", "physics": "phi4:14b"})
+
+	if got := h.resolveModel("science"); got != "phi4:14b" {
+		t.Errorf("science resolved to %q, want phi4:14b", got)
+	}
+	if got := h.resolveModel("physics"); got != "phi4:14b" {
+		t.Errorf("physics resolved to %q, want phi4:14b", got)
+	}
+	if got := h.resolveModel("code"); got != DefaultRegistry()["code"] {
+		t.Errorf("code resolved to %q, want the default", got)
+	}
+
+	roles := h.Roles()
+	roles["code"] = "changed"
+	if got := h.resolveModel("code"); got == "changed" {
+		t.Error("Roles should return a copy")
+	}
+}
+
+func TestExecuteSubQueryDepth(t *testing.T) {
+	// "planner" always delegates to "calc", which answers directly
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Model string `json:"model"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		answer := "42"
+		if req.Model == "planner" {
+			answer = `It is <sub-query model="calc">6*7?</sub-query>.`
+		}
+		fmt.Fprintf(w, "{\"response\":%q,\"done\":true}\n", answer)
+	}))
+	defer srv.Close()
+
+	h := NewHandler(nil)
+	h.OllamaURL = srv.URL
+	q := SubQuery{Model: "planner", Query: "what is 6*7?"}
+
+	if r := h.ExecuteSubQuery(context.Background(), q, 0); r.Error != nil || !strings.Contains(r.Response, "<sub-query") {
+		t.Errorf("depth 1: got %q, %v; the tag should be left alone", r.Response, r.Error)
+	}
+
+	h.MaxDepth = 2
+	if r := h.ExecuteSubQuery(context.Background(), q, 0); r.Error != nil || r.Response != "It is [calc: 42]." {
+		t.Errorf("depth 2: got %q, %v", r.Response, r.Error)
+	}
+
+	if r := h.ExecuteSubQuery(context.Background(), q, 2); r.Error == nil {
+		t.Error("depth at MaxDepth should fail")
+	}
+}
+
+func TestStreamProcessorStreamsExperts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, tok := range []string{"forty", "-", "two"} {
+			fmt.Fprintf(w, "{\"response\":%q,\"done\":false}\n", tok)
+		}
+		fmt.Fprint(w, "{\"response\":\"\",\"done\":true}\n")
+	}))
+	defer srv.Close()
+
+	h := NewHandler(nil)
+	h.OllamaURL = srv.URL
+	input := make(chan string, 1)
+	p := NewStreamProcessor(context.Background(), h, input)
+	p.StreamExperts = true
+	out := p.Start()
+
+	input <- `The answer is <sub-query model="math">6*7?</sub-query> indeed.`
+	close(input)
+
+	var got []string
+	for chunk := range out {
+		got = append(got, chunk)
+	}
+	want := []string{
+		"The answer is ",
+		ExpertStartPrefix + "math",
+		ExpertChunkPrefix + "forty",
+		ExpertChunkPrefix + "-",
+		ExpertChunkPrefix + "two",
+	}
+	if len(got) != len(want)+2 {
+		t.Fatalf("got %q", got)
+	}
+	for i, w := range want {
+		if got[i] != w {
+			t.Errorf("chunk %d = %q, want %q", i, got[i], w)
+		}
+	}
+	if !strings.HasPrefix(got[len(want)], "\n───── END EXPERT") || got[len(want)+1] != " indeed." {
+		t.Errorf("tail = %q", got[len(want):])
+	}
+}
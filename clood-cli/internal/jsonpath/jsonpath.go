@@ -0,0 +1,299 @@
+// Package jsonpath evaluates a small jq-style path language over decoded
+// JSON and YAML documents.
+//
+// Supported syntax:
+//
+//	.               the whole document
+//	.foo .foo.bar   object keys (letters, digits, _ and -)
+//	."a b" .["a b"] quoted keys
+//	.[0] .[-1]      array indices, negative counts from the end
+//	.[]             every element of an array or value of an object
+//	.foo?           accepted for jq compatibility; misses are never errors
+//
+// Paths that don't apply to a value (a key on an array, an index out of
+// range) simply produce no matches.
+package jsonpath
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Match is one value found by a path, with the concrete path to it
+// (iterators resolved, e.g. .services[].image → .services.web.image)
+type Match struct {
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+type stepKind int
+
+const (
+	stepKey stepKind = iota
+	stepIndex
+	stepIterate
+)
+
+type step struct {
+	kind  stepKind
+	key   string
+	index int
+}
+
+// Path is a compiled path expression
+type Path struct {
+	expr  string
+	steps []step
+}
+
+var identRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*`)
+
+// Compile parses a path expression
+func Compile(expr string) (*Path, error) {
+	p := &Path{expr: expr}
+	rest := strings.TrimSpace(expr)
+	if !strings.HasPrefix(rest, ".") {
+		return nil, fmt.Errorf("path %q must start with '.'", expr)
+	}
+
+	for rest != "" {
+		switch {
+		case rest[0] == '?':
+			rest = rest[1:]
+		case strings.HasPrefix(rest, "[") || strings.HasPrefix(rest, ".["):
+			rest = strings.TrimPrefix(rest, ".")
+			end := bracketEnd(rest)
+			if end < 0 {
+				return nil, fmt.Errorf("path %q: unclosed '['", expr)
+			}
+			inner := strings.TrimSpace(rest[1:end])
+			rest = rest[end+1:]
+			switch {
+			case inner == "":
+				p.steps = append(p.steps, step{kind: stepIterate})
+			case inner[0] == '"':
+				key, err := strconv.Unquote(inner)
+				if err != nil {
+					return nil, fmt.Errorf("path %q: bad key %s", expr, inner)
+				}
+				p.steps = append(p.steps, step{kind: stepKey, key: key})
+			default:
+				n, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, fmt.Errorf("path %q: bad index [%s]", expr, inner)
+				}
+				p.steps = append(p.steps, step{kind: stepIndex, index: n})
+			}
+		case rest[0] == '.':
+			rest = rest[1:]
+			if rest == "" || rest[0] == '.' {
+				// Only the identity path "." may end at a dot
+				if rest == "" && len(p.steps) == 0 {
+					break
+				}
+				return nil, fmt.Errorf("path %q: empty segment", expr)
+			}
+			if rest[0] == '"' {
+				key, n, err := unquotePrefix(rest)
+				if err != nil {
+					return nil, fmt.Errorf("path %q: %w", expr, err)
+				}
+				p.steps = append(p.steps, step{kind: stepKey, key: key})
+				rest = rest[n:]
+				continue
+			}
+			ident := identRe.FindString(rest)
+			if ident == "" {
+				return nil, fmt.Errorf("path %q: unexpected %q", expr, rest)
+			}
+			p.steps = append(p.steps, step{kind: stepKey, key: ident})
+			rest = rest[len(ident):]
+		default:
+			return nil, fmt.Errorf("path %q: unexpected %q", expr, rest)
+		}
+	}
+	return p, nil
+}
+
+// bracketEnd returns the index of the ']' that closes the '[' at the
+// start of s, skipping any inside a quoted key, or -1 if there is none
+func bracketEnd(s string) int {
+	inQuote := false
+	for i := 1; i < len(s); i++ {
+		switch {
+		case inQuote && s[i] == '\\':
+			i++
+		case s[i] == '"':
+			inQuote = !inQuote
+		case !inQuote && s[i] == ']':
+			return i
+		}
+	}
+	return -1
+}
+
+// unquotePrefix reads the quoted string at the start of s and returns it
+// with the number of bytes consumed
+func unquotePrefix(s string) (string, int, error) {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			key, err := strconv.Unquote(s[:i+1])
+			return key, i + 1, err
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated quoted key")
+}
+
+func (p *Path) String() string { return p.expr }
+
+// Find returns every value in doc the path reaches
+func (p *Path) Find(doc interface{}) []Match {
+	matches := []Match{{Path: "", Value: doc}}
+	for _, st := range p.steps {
+		var next []Match
+		for _, m := range matches {
+			next = append(next, apply(st, m)...)
+		}
+		matches = next
+	}
+	for i := range matches {
+		if matches[i].Path == "" {
+			matches[i].Path = "."
+		}
+	}
+	return matches
+}
+
+func apply(st step, m Match) []Match {
+	switch st.kind {
+	case stepKey:
+		obj, ok := m.Value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v, ok := obj[st.key]
+		if !ok {
+			return nil
+		}
+		return []Match{{Path: m.Path + keyPath(st.key), Value: v}}
+
+	case stepIndex:
+		arr, ok := m.Value.([]interface{})
+		if !ok {
+			return nil
+		}
+		i := st.index
+		if i < 0 {
+			i += len(arr)
+		}
+		if i < 0 || i >= len(arr) {
+			return nil
+		}
+		return []Match{{Path: fmt.Sprintf("%s[%d]", m.Path, i), Value: arr[i]}}
+
+	case stepIterate:
+		switch v := m.Value.(type) {
+		case []interface{}:
+			out := make([]Match, len(v))
+			for i, elem := range v {
+				out[i] = Match{Path: fmt.Sprintf("%s[%d]", m.Path, i), Value: elem}
+			}
+			return out
+		case map[string]interface{}:
+			keys := make([]string, 0, len(v))
+			for k := range v {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			out := make([]Match, len(keys))
+			for i, k := range keys {
+				out[i] = Match{Path: m.Path + keyPath(k), Value: v[k]}
+			}
+			return out
+		}
+	}
+	return nil
+}
+
+// keyPath renders an object key as a path segment
+func keyPath(key string) string {
+	if identRe.FindString(key) == key {
+		return "." + key
+	}
+	return "[" + strconv.Quote(key) + "]"
+}
+
+// DecodeJSON decodes a JSON document into generic values
+func DecodeJSON(data []byte) (interface{}, error) {
+	var doc interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// DecodeYAML decodes every document in a YAML stream, converted to the
+// same shapes DecodeJSON produces so paths behave identically
+func DecodeYAML(data []byte) ([]interface{}, error) {
+	var docs []interface{}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc interface{}
+		err := dec.Decode(&doc)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// Round-trip through JSON so YAML's map[interface{}]… keys and
+		// native ints become the JSON types Find expects
+		raw, err := json.Marshal(yamlToJSON(doc))
+		if err != nil {
+			return nil, err
+		}
+		converted, err := DecodeJSON(raw)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, converted)
+	}
+	return docs, nil
+}
+
+// yamlToJSON stringifies non-string map keys, which encoding/json rejects
+func yamlToJSON(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			t[k] = yamlToJSON(val)
+		}
+		return t
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(t))
+		for k, val := range t {
+			out[fmt.Sprint(k)] = yamlToJSON(val)
+		}
+		return out
+	case []interface{}:
+		for i, val := range t {
+			t[i] = yamlToJSON(val)
+		}
+		return t
+	}
+	return v
+}
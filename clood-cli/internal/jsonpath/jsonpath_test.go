@@ -0,0 +1,143 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const compose = `
+services:
+  web:
+    image: nginx:latest
+    ports: ["80:80"]
+  db:
+    image: postgres:16
+    environment:
+      POSTGRES_DB: app
+x-meta:
+  1: one
+`
+
+func TestFindYAML(t *testing.T) {
+	docs, err := DecodeYAML([]byte(compose))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		expr string
+		want []Match
+	}{
+		{".services[].image", []Match{
+			{Path: ".services.db.image", Value: "postgres:16"},
+			{Path: ".services.web.image", Value: "nginx:latest"},
+		}},
+		{".services.web.ports[0]", []Match{{Path: ".services.web.ports[0]", Value: "80:80"}}},
+		{".services.web.ports[-1]", []Match{{Path: ".services.web.ports[0]", Value: "80:80"}}},
+		{`."x-meta"["1"]`, []Match{{Path: `.x-meta["1"]`, Value: "one"}}},
+		{".services.web.image.nope", nil},
+		{".services.web.ports[5]", nil},
+	}
+	for _, tt := range tests {
+		p, err := Compile(tt.expr)
+		if err != nil {
+			t.Fatalf("Compile(%q): %v", tt.expr, err)
+		}
+		got := p.Find(docs[0])
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.expr, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i].Path != tt.want[i].Path || got[i].Value != tt.want[i].Value {
+				t.Errorf("%s[%d]: got %+v, want %+v", tt.expr, i, got[i], tt.want[i])
+			}
+		}
+	}
+}
+
+func TestFindJSONKeepsNumbers(t *testing.T) {
+	doc, err := DecodeJSON([]byte(`{"ids": [9007199254740993, 2]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, _ := Compile(".ids[0]")
+	got := p.Find(doc)
+	if len(got) != 1 {
+		t.Fatalf("got %v", got)
+	}
+	b, _ := json.Marshal(got[0].Value)
+	if string(b) != "9007199254740993" {
+		t.Errorf("large int mangled: %s", b)
+	}
+
+	root, _ := Compile(".")
+	if m := root.Find(doc); len(m) != 1 || m[0].Path != "." {
+		t.Errorf("identity: %v", m)
+	}
+}
+
+func TestCompileQuotedBrackets(t *testing.T) {
+	tests := []struct {
+		expr string
+		keys []string
+	}{
+		{`.["a]b"]`, []string{"a]b"}},
+		{`.["a\"]"].c`, []string{`a"]`, "c"}},
+		{`.x["[]"][0]`, []string{"x", "[]", ""}},
+		{`. ["a b"]`, nil}, // no space allowed after the dot
+	}
+	for _, tt := range tests {
+		p, err := Compile(tt.expr)
+		if tt.keys == nil {
+			if err == nil {
+				t.Errorf("Compile(%q) should fail", tt.expr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Compile(%q): %v", tt.expr, err)
+			continue
+		}
+		var keys []string
+		for _, st := range p.steps {
+			keys = append(keys, st.key)
+		}
+		if !reflect.DeepEqual(keys, tt.keys) {
+			t.Errorf("Compile(%q) keys = %q; want %q", tt.expr, keys, tt.keys)
+		}
+	}
+
+	doc := map[string]interface{}{"a]b": "found"}
+	p, _ := Compile(`.["a]b"]`)
+	if got := p.Find(doc); len(got) != 1 || got[0].Value != "found" || got[0].Path != `["a]b"]` {
+		t.Errorf(`.["a]b"] found %+v`, got)
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		expr, want string
+	}{
+		{"services", "must start with '.'"},
+		{"", "must start with '.'"},
+		{".a[", "unclosed '['"},
+		{`.["a]`, "unclosed '['"},
+		{`.["a]b"`, "unclosed '['"},
+		{".a[x]", "bad index [x]"},
+		{`."open`, "unterminated quoted key"},
+		{"..", "empty segment"},
+		{"..foo", "empty segment"},
+		{".a..b", "empty segment"},
+		{".a.", "empty segment"},
+		{".a[0].", "empty segment"},
+	}
+	for _, tt := range tests {
+		_, err := Compile(tt.expr)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Compile(%q) = %v; want %q", tt.expr, err, tt.want)
+		}
+	}
+}
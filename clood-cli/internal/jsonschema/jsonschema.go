@@ -0,0 +1,294 @@
+// Package jsonschema loads and checks the subset of JSON Schema draft-07
+// that extraction prompts need: type, properties, required, items, enum.
+//
+// Schema files may be written as JSON Schema directly, or in the shorter
+// field-list form, which is converted to an equivalent draft-07 schema:
+//
+//	fields:
+//	  - name: email
+//	    type: string
+//	    description: Sender address
+//	    required: true
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/dirtybirdnj/clood/internal/jsonpath"
+)
+
+// Draft07 is the $schema URI written into converted schemas
+const Draft07 = "http://json-schema.org/draft-07/schema#"
+
+// Schema is a JSON Schema document, limited to the keywords Validate checks
+type Schema struct {
+	Schema      string             `json:"$schema,omitempty"`
+	Title       string             `json:"title,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Type        Types              `json:"type,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	Enum        []interface{}      `json:"enum,omitempty"`
+}
+
+// Types is the "type" keyword, which may be a single name or a list
+type Types []string
+
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+func (t *Types) UnmarshalJSON(data []byte) error {
+	var one string
+	if err := json.Unmarshal(data, &one); err == nil {
+		*t = Types{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return fmt.Errorf("type must be a string or list of strings")
+	}
+	*t = many
+	return nil
+}
+
+// Field is one entry of the field-list form
+type Field struct {
+	Name        string   `json:"name"`
+	Type        string   `json:"type,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Required    bool     `json:"required,omitempty"`
+	Enum        []string `json:"enum,omitempty"`
+}
+
+// Load reads a schema from a YAML or JSON file in either form
+func Load(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	docs, err := jsonpath.DecodeYAML(data)
+	if err != nil || len(docs) == 0 {
+		return nil, fmt.Errorf("parse schema %s: %v", path, err)
+	}
+
+	// Go through JSON so both forms decode with the same struct tags
+	raw, err := json.Marshal(docs[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var probe struct {
+		Fields []Field `json:"fields"`
+	}
+	if err := json.Unmarshal(raw, &probe); err == nil && len(probe.Fields) > 0 {
+		return FromFields(probe.Fields)
+	}
+
+	var s Schema
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, fmt.Errorf("parse schema %s: %w", path, err)
+	}
+	if len(s.Type) == 0 && len(s.Properties) == 0 && s.Items == nil {
+		return nil, fmt.Errorf("schema %s has no fields, type, properties or items", path)
+	}
+	return &s, nil
+}
+
+// FromFields converts the field-list form to a draft-07 object schema.
+// Optional fields also accept null, matching "use null for missing".
+func FromFields(fields []Field) (*Schema, error) {
+	s := &Schema{
+		Schema:     Draft07,
+		Type:       Types{"object"},
+		Properties: make(map[string]*Schema, len(fields)),
+	}
+	for i, f := range fields {
+		if f.Name == "" {
+			return nil, fmt.Errorf("field %d has no name", i+1)
+		}
+		typ := f.Type
+		if typ == "" {
+			typ = "string"
+		}
+		prop := &Schema{Type: Types{typ}, Description: f.Description}
+		for _, e := range f.Enum {
+			prop.Enum = append(prop.Enum, e)
+		}
+		if f.Required {
+			s.Required = append(s.Required, f.Name)
+		} else {
+			prop.Type = append(prop.Type, "null")
+			if len(prop.Enum) > 0 {
+				prop.Enum = append(prop.Enum, nil)
+			}
+		}
+		s.Properties[f.Name] = prop
+	}
+	return s, nil
+}
+
+// Record returns the schema for one extracted record: the items of an
+// array schema, or the schema itself
+func (s *Schema) Record() *Schema {
+	if s.Items != nil && (len(s.Type) == 0 || s.hasType("array")) {
+		return s.Items
+	}
+	return s
+}
+
+// FieldNames lists the record's property names, sorted
+func (s *Schema) FieldNames() []string {
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *Schema) hasType(t string) bool {
+	for _, have := range s.Type {
+		if have == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks v (decoded with encoding/json) against s and returns
+// every violation, each prefixed with its location such as "[1].email"
+func (s *Schema) Validate(v interface{}) []string {
+	var errs []string
+	s.validate(v, "", &errs)
+	return errs
+}
+
+func (s *Schema) validate(v interface{}, at string, errs *[]string) {
+	loc := at
+	if loc == "" {
+		loc = "value"
+	}
+
+	if len(s.Type) > 0 {
+		ok := false
+		for _, t := range s.Type {
+			if typeMatches(t, v) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			*errs = append(*errs, fmt.Sprintf("%s: expected %s, got %s", loc, strings.Join(s.Type, " or "), typeName(v)))
+			return
+		}
+	}
+
+	if len(s.Enum) > 0 {
+		ok := false
+		for _, e := range s.Enum {
+			if reflect.DeepEqual(normalize(e), normalize(v)) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			*errs = append(*errs, fmt.Sprintf("%s: %v is not one of %v", loc, v, s.Enum))
+		}
+	}
+
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := t[name]; !ok {
+				*errs = append(*errs, fmt.Sprintf("%s: missing required field %q", loc, name))
+			}
+		}
+		for _, name := range s.FieldNames() {
+			if val, ok := t[name]; ok {
+				s.Properties[name].validate(val, at+"."+name, errs)
+			}
+		}
+	case []interface{}:
+		if s.Items != nil {
+			for i, elem := range t {
+				s.Items.validate(elem, fmt.Sprintf("%s[%d]", at, i), errs)
+			}
+		}
+	}
+}
+
+func typeMatches(t string, v interface{}) bool {
+	switch t {
+	case "null":
+		return v == nil
+	case "boolean":
+		_, ok := v.(bool)
+		return ok
+	case "string":
+		_, ok := v.(string)
+		return ok
+	case "number":
+		_, ok := toFloat(v)
+		return ok
+	case "integer":
+		f, ok := toFloat(v)
+		return ok && f == float64(int64(f))
+	case "object":
+		_, ok := v.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := v.([]interface{})
+		return ok
+	}
+	return false
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	case int:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+func typeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	}
+	if _, ok := toFloat(v); ok {
+		return "number"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// normalize makes numbers comparable regardless of how they were decoded
+func normalize(v interface{}) interface{} {
+	if f, ok := toFloat(v); ok {
+		return f
+	}
+	return v
+}
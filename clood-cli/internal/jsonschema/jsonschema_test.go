@@ -0,0 +1,96 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeSchema(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func decode(t *testing.T, s string) interface{} {
+	t.Helper()
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestLoadFieldList(t *testing.T) {
+	path := writeSchema(t, "schema.yaml", `
+fields:
+  - name: email
+    type: string
+    required: true
+  - name: amount
+    type: number
+  - name: level
+    enum: [info, warn]
+`)
+	s, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Schema != Draft07 || len(s.Required) != 1 || s.Required[0] != "email" {
+		t.Errorf("converted schema = %+v", s)
+	}
+
+	records := decode(t, `[{"email": "a@b.c", "amount": 3.5, "level": "warn"}, {"email": "x@y.z", "amount": null, "level": null}]`)
+	if errs := (&Schema{Type: Types{"array"}, Items: s}).Validate(records); len(errs) != 0 {
+		t.Errorf("valid records rejected: %v", errs)
+	}
+
+	errs := s.Validate(decode(t, `{"amount": "lots", "level": "debug"}`))
+	want := []string{`missing required field "email"`, ".amount: expected number or null", ".level: debug is not one of"}
+	if len(errs) != len(want) {
+		t.Fatalf("errs = %v", errs)
+	}
+	for i, w := range want {
+		if !strings.Contains(errs[i], w) {
+			t.Errorf("errs[%d] = %q, want it to contain %q", i, errs[i], w)
+		}
+	}
+}
+
+func TestLoadDraft07(t *testing.T) {
+	path := writeSchema(t, "schema.json", `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "array",
+  "items": {
+    "type": "object",
+    "required": ["id"],
+    "properties": {
+      "id": {"type": "integer"},
+      "tags": {"type": "array", "items": {"type": "string"}}
+    }
+  }
+}`)
+	s, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := s.Record()
+	if names := rec.FieldNames(); len(names) != 2 || names[0] != "id" {
+		t.Errorf("record fields = %v", names)
+	}
+
+	errs := rec.Validate(decode(t, `{"id": 1.5, "tags": ["a", 2]}`))
+	if len(errs) != 2 || !strings.Contains(errs[0], ".id: expected integer") || !strings.Contains(errs[1], ".tags[1]: expected string") {
+		t.Errorf("errs = %v", errs)
+	}
+
+	out, _ := json.Marshal(rec.Properties["id"])
+	if string(out) != `{"type":"integer"}` {
+		t.Errorf("single type should marshal as a string: %s", out)
+	}
+}
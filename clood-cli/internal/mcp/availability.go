@@ -0,0 +1,93 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dirtybirdnj/clood/internal/config"
+	"github.com/dirtybirdnj/clood/internal/hosts"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// availabilityTimeout bounds the host check behind the clood_ask and
+// clood_inception descriptions, so an offline host doesn't hold up startup
+const availabilityTimeout = 2 * time.Second
+
+// refreshAvailability checks the configured hosts and stores what's online
+// for the model-facing tool descriptions
+func (s *Server) refreshAvailability(ctx context.Context) []*hosts.HostStatus {
+	mgr := hosts.NewManager(hosts.WithTimeout(availabilityTimeout))
+	mgr.AddHosts(s.config.Hosts)
+	ctx, cancel := context.WithTimeout(ctx, availabilityTimeout)
+	defer cancel()
+	statuses := mgr.CheckAllHostsContext(ctx)
+	s.availability = describeAvailability(statuses, time.Now())
+	return statuses
+}
+
+// describeAvailability is the "available right now" paragraph appended to
+// tool descriptions: each online host with its models, then offline hosts
+func describeAvailability(statuses []*hosts.HostStatus, checked time.Time) string {
+	var online, offline []string
+	for _, st := range statuses {
+		if st == nil || st.Host == nil {
+			continue
+		}
+		if !st.Online {
+			offline = append(offline, st.Host.Name)
+			continue
+		}
+		names := make([]string, len(st.Models))
+		for i, m := range st.Models {
+			names[i] = m.Name
+		}
+		models := "no models pulled"
+		if len(names) > 0 {
+			models = strings.Join(names, ", ")
+		}
+		online = append(online, fmt.Sprintf("- %s: %s", st.Host.Name, models))
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Available as of %s (clood_refresh_tools updates this):\n", checked.Format("15:04"))
+	if len(online) == 0 {
+		sb.WriteString("- no host online; run clood_hosts before calling\n")
+	} else {
+		sb.WriteString(strings.Join(online, "\n") + "\n")
+	}
+	if len(offline) > 0 {
+		fmt.Fprintf(&sb, "Offline: %s\n", strings.Join(offline, ", "))
+	}
+	return sb.String()
+}
+
+// withAvailability appends the availability paragraph to a description
+func (s *Server) withAvailability(description string) string {
+	if s.availability == "" {
+		return description
+	}
+	return description + "\n\n" + s.availability
+}
+
+func (s *Server) refreshToolsTool() mcp.Tool {
+	return mcp.NewTool("clood_refresh_tools",
+		mcp.WithDescription(`Re-check Ollama hosts and update the model lists in the clood_ask and
+clood_inception descriptions. Call it after pulling a model or starting a
+host, or when a model named in those descriptions fails.
+
+Cost: Local network only, ZERO tokens.`),
+	)
+}
+
+func (s *Server) refreshToolsHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if cfg, err := config.Load(); err == nil {
+		s.config = cfg
+	}
+	s.refreshAvailability(ctx)
+	// Re-adding replaces the tools and notifies clients the list changed
+	s.mcpServer.AddTool(s.askTool(), s.askHandler)
+	s.mcpServer.AddTool(s.inceptionTool(), s.inceptionHandler)
+	return mcp.NewToolResultText("Updated clood_ask and clood_inception.\n\n" + s.availability), nil
+}
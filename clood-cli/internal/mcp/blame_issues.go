@@ -0,0 +1,111 @@
+package mcp
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dirtybirdnj/clood/internal/git"
+)
+
+// blameIssueRe matches issue references in commit messages: #123,
+// fixes #456, owner/repo#789
+var blameIssueRe = regexp.MustCompile(`(?:^|[^\w/#])((?:[\w.-]+/[\w.-]+)?)#(\d+)\b`)
+
+// blameIssueTimeout bounds all GitHub lookups for one blame call
+const blameIssueTimeout = 20 * time.Second
+
+// BlameIssue is a GitHub issue referenced by the commit that last touched
+// a blamed line
+type BlameIssue struct {
+	Repo   string `json:"repo"`
+	Number int    `json:"number"`
+	Title  string `json:"title,omitempty"`
+	State  string `json:"state,omitempty"` // open or closed
+	URL    string `json:"url,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// blameLineWithIssues is a blame line plus the issues its commit mentions
+type blameLineWithIssues struct {
+	git.BlameLine
+	Issues []BlameIssue `json:"issues"`
+}
+
+// issueRefs returns the issues msg references, in order and without
+// duplicates. Bare #N refers to repo.
+func issueRefs(msg, repo string) []BlameIssue {
+	var refs []BlameIssue
+	seen := map[string]bool{}
+	for _, m := range blameIssueRe.FindAllStringSubmatch(msg, -1) {
+		r := m[1]
+		if r == "" {
+			r = repo
+		}
+		n, _ := strconv.Atoi(m[2])
+		key := r + "#" + m[2]
+		if r == "" || n == 0 || seen[key] {
+			continue
+		}
+		seen[key] = true
+		refs = append(refs, BlameIssue{Repo: r, Number: n})
+	}
+	return refs
+}
+
+// linkBlameIssues looks up the issues each line's commit references.
+// repo is owner/repo for bare #N references; GITHUB_TOKEN is used when
+// set, which private repos and the rate limit both want.
+func linkBlameIssues(ctx context.Context, path, repo string, lines []git.BlameLine) ([]blameLineWithIssues, error) {
+	var commits []string
+	seenCommit := map[string]bool{}
+	for _, l := range lines {
+		// All zeros is "not committed yet"
+		if strings.Trim(l.Commit, "0") != "" && !seenCommit[l.Commit] {
+			seenCommit[l.Commit] = true
+			commits = append(commits, l.Commit)
+		}
+	}
+
+	messages, err := git.CommitMessages(path, commits)
+	if err != nil {
+		return nil, err
+	}
+
+	client := git.GitHubClient(os.Getenv("GITHUB_TOKEN"))
+
+	ctx, cancel := context.WithTimeout(ctx, blameIssueTimeout)
+	defer cancel()
+
+	// Many lines share a commit and many commits share an issue
+	fetched := map[string]BlameIssue{}
+	fetch := func(ref BlameIssue) BlameIssue {
+		key := ref.Repo + "#" + strconv.Itoa(ref.Number)
+		if issue, ok := fetched[key]; ok {
+			return issue
+		}
+		owner, name, _ := strings.Cut(ref.Repo, "/")
+		issue, _, err := client.Issues.Get(ctx, owner, name, ref.Number)
+		if err != nil {
+			ref.Error = err.Error()
+		} else {
+			ref.Title = issue.GetTitle()
+			ref.State = issue.GetState()
+			ref.URL = issue.GetHTMLURL()
+		}
+		fetched[key] = ref
+		return ref
+	}
+
+	result := make([]blameLineWithIssues, len(lines))
+	for i, l := range lines {
+		result[i] = blameLineWithIssues{BlameLine: l, Issues: []BlameIssue{}}
+		for _, ref := range issueRefs(messages[l.Commit], repo) {
+			result[i].Issues = append(result[i].Issues, fetch(ref))
+		}
+	}
+	return result, nil
+}
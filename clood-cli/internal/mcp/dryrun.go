@@ -0,0 +1,33 @@
+package mcp
+
+import (
+	"encoding/json"
+
+	"github.com/dirtybirdnj/clood/internal/output"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// dryRunOption is the dry_run argument shared by tools that change things
+func dryRunOption() mcp.ToolOption {
+	return mcp.WithBoolean("dry_run", mcp.Description("Describe what would happen without doing it (always on when the server runs with --dry-run)"))
+}
+
+// isDryRun reports whether a tool call should only describe itself: the
+// server was started with --dry-run, or the call asked with dry_run
+func isDryRun(args map[string]interface{}) bool {
+	if output.DryRun {
+		return true
+	}
+	dry, _ := args["dry_run"].(bool)
+	return dry
+}
+
+// dryRunResult is the result of a tool call skipped by isDryRun
+func dryRunResult(action string, params map[string]interface{}) *mcp.CallToolResult {
+	data, _ := json.MarshalIndent(map[string]interface{}{
+		"status":          "dry_run",
+		"would_have_done": action,
+		"params":          params,
+	}, "", "  ")
+	return mcp.NewToolResultText(string(data))
+}
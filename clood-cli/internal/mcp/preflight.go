@@ -0,0 +1,80 @@
+package mcp
+
+import (
+	"encoding/json"
+	"math"
+	"os/exec"
+
+	"github.com/dirtybirdnj/clood/internal/hosts"
+	"github.com/dirtybirdnj/clood/internal/system"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// PreflightCapabilities is clood_preflight's json report: flat facts an
+// orchestrator's planning step can branch on without reading prose
+type PreflightCapabilities struct {
+	WorkingDirectory string          `json:"working_directory"`
+	LocalSearch      bool            `json:"local_search"` // clood_grep, clood_tree, clood_symbols, clood_imports
+	Ripgrep          bool            `json:"ripgrep"`      // clood_grep uses rg when it's installed
+	OllamaOnline     bool            `json:"ollama_online"`
+	Hosts            []PreflightHost `json:"hosts"`
+	Models           []string        `json:"models"`
+	GPUAvailable     bool            `json:"gpu_available"`
+	GPU              string          `json:"gpu,omitempty"`
+	VRAMGB           float64         `json:"vram_gb,omitempty"`
+	DiskFreeGB       float64         `json:"disk_free_gb"` // On the disk holding Ollama's models
+	Storage          []string        `json:"storage"`      // Gallery and memory store sizes, with warnings
+}
+
+// PreflightHost is one online Ollama host
+type PreflightHost struct {
+	Name      string   `json:"name"`
+	LatencyMs int64    `json:"latency_ms"`
+	Models    []string `json:"models"`
+}
+
+// preflightCapabilities builds the capability matrix from the host checks
+// the text report already ran
+func preflightCapabilities(cwd string, statuses []*hosts.HostStatus, storage []string) PreflightCapabilities {
+	_, rgErr := exec.LookPath("rg")
+	caps := PreflightCapabilities{
+		WorkingDirectory: cwd,
+		LocalSearch:      true, // built in; clood_grep falls back to its own walker without rg
+		Ripgrep:          rgErr == nil,
+		Hosts:            []PreflightHost{},
+		Models:           []string{},
+		Storage:          storage,
+	}
+
+	seen := map[string]bool{}
+	for _, st := range statuses {
+		if !st.Online {
+			continue
+		}
+		caps.OllamaOnline = true
+		h := PreflightHost{Name: st.Host.Name, LatencyMs: st.Latency.Milliseconds(), Models: []string{}}
+		for _, m := range st.Models {
+			h.Models = append(h.Models, m.Name)
+			if !seen[m.Name] {
+				seen[m.Name] = true
+				caps.Models = append(caps.Models, m.Name)
+			}
+		}
+		caps.Hosts = append(caps.Hosts, h)
+	}
+
+	if hw, err := system.DetectHardware(); err == nil {
+		if hw.GPU != nil && hw.GPU.Type != "" && hw.GPU.Type != "none" {
+			caps.GPUAvailable = true
+			caps.GPU = hw.GPU.Name
+			caps.VRAMGB = hw.OllamaVRAM
+		}
+		caps.DiskFreeGB = math.Round(hw.ModelsDiskFreeGB()*10) / 10
+	}
+	return caps
+}
+
+func preflightJSONResult(caps PreflightCapabilities) *mcp.CallToolResult {
+	data, _ := json.MarshalIndent(caps, "", "  ")
+	return mcp.NewToolResultText(string(data))
+}
@@ -0,0 +1,146 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// rateLimit is one mcp.rate_limits entry: a bucket of Burst calls that
+// refills at Burst per Period
+type rateLimit struct {
+	Spec   string
+	Burst  float64
+	Period time.Duration
+}
+
+// parseRateLimit reads "10/minute" (also second, hour, or s, m, h)
+func parseRateLimit(spec string) (rateLimit, error) {
+	count, unit, ok := strings.Cut(strings.TrimSpace(spec), "/")
+	n, err := strconv.Atoi(strings.TrimSpace(count))
+	if !ok || err != nil || n <= 0 {
+		return rateLimit{}, fmt.Errorf("rate limit %q: want calls/period, e.g. 10/minute", spec)
+	}
+	var period time.Duration
+	switch strings.ToLower(strings.TrimSpace(unit)) {
+	case "s", "sec", "second":
+		period = time.Second
+	case "m", "min", "minute":
+		period = time.Minute
+	case "h", "hour":
+		period = time.Hour
+	default:
+		return rateLimit{}, fmt.Errorf("rate limit %q: period must be second, minute or hour", spec)
+	}
+	return rateLimit{Spec: spec, Burst: float64(n), Period: period}, nil
+}
+
+// bucket is a token bucket as of last
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// rateLimiter enforces mcp.rate_limits per tool. Buckets are kept per MCP
+// session, so one client looping on clood_ask doesn't use up another's
+// calls; calls without a session share one bucket per tool.
+type rateLimiter struct {
+	mu      sync.Mutex
+	limits  map[string]rateLimit
+	buckets map[string]*bucket // tool + "\x00" + session ID
+	now     func() time.Time
+}
+
+// maxIdleBuckets is how many buckets are kept before full ones, whose
+// sessions have been quiet for a whole period, are dropped
+const maxIdleBuckets = 1000
+
+func newRateLimiter(specs map[string]string) (*rateLimiter, error) {
+	rl := &rateLimiter{
+		limits:  make(map[string]rateLimit, len(specs)),
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+	for tool, spec := range specs {
+		limit, err := parseRateLimit(spec)
+		if err != nil {
+			return nil, fmt.Errorf("mcp.rate_limits.%s: %w", tool, err)
+		}
+		rl.limits[tool] = limit
+	}
+	return rl, nil
+}
+
+// allow takes a token for a call to tool, or reports how long until one
+// is available
+func (rl *rateLimiter) allow(tool, session string) (bool, time.Duration) {
+	limit, ok := rl.limits[tool]
+	if !ok {
+		return true, 0
+	}
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := rl.now()
+	rate := limit.Burst / limit.Period.Seconds() // tokens per second
+	key := tool + "\x00" + session
+	b, ok := rl.buckets[key]
+	if !ok {
+		if len(rl.buckets) >= maxIdleBuckets {
+			rl.dropFull(now)
+		}
+		b = &bucket{tokens: limit.Burst, last: now}
+		rl.buckets[key] = b
+	}
+
+	b.tokens = math.Min(limit.Burst, b.tokens+now.Sub(b.last).Seconds()*rate)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) / rate * float64(time.Second))
+}
+
+// dropFull forgets buckets that would have refilled by now
+func (rl *rateLimiter) dropFull(now time.Time) {
+	for key, b := range rl.buckets {
+		tool, _, _ := strings.Cut(key, "\x00")
+		if now.Sub(b.last) >= rl.limits[tool].Period {
+			delete(rl.buckets, key)
+		}
+	}
+}
+
+// middleware rejects calls over their tool's limit with a rate_limited
+// error that says when to retry
+func (rl *rateLimiter) middleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			session := ""
+			if cs := server.ClientSessionFromContext(ctx); cs != nil {
+				session = cs.SessionID()
+			}
+			ok, wait := rl.allow(req.Params.Name, session)
+			if ok {
+				return next(ctx, req)
+			}
+			data, _ := json.MarshalIndent(map[string]interface{}{
+				"error":       "rate_limited",
+				"tool":        req.Params.Name,
+				"limit":       rl.limits[req.Params.Name].Spec,
+				"retry_after": int(math.Ceil(wait.Seconds())),
+			}, "", "  ")
+			return mcp.NewToolResultError(string(data)), nil
+		}
+	}
+}
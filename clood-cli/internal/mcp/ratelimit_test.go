@@ -0,0 +1,173 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// fakeClock is a rateLimiter clock moved by hand
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestLimiter(t *testing.T, specs map[string]string) (*rateLimiter, *fakeClock) {
+	t.Helper()
+	rl, err := newRateLimiter(specs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock := &fakeClock{t: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	rl.now = clock.now
+	return rl, clock
+}
+
+func TestParseRateLimit(t *testing.T) {
+	tests := []struct {
+		spec   string
+		burst  float64
+		period time.Duration
+	}{
+		{"10/minute", 10, time.Minute},
+		{" 5 / s ", 5, time.Second},
+		{"1/sec", 1, time.Second},
+		{"30/m", 30, time.Minute},
+		{"100/Hour", 100, time.Hour},
+	}
+	for _, tt := range tests {
+		got, err := parseRateLimit(tt.spec)
+		if err != nil || got.Burst != tt.burst || got.Period != tt.period {
+			t.Errorf("parseRateLimit(%q) = %+v, %v", tt.spec, got, err)
+		}
+	}
+	for _, bad := range []string{"", "10", "ten/minute", "0/minute", "-1/s", "10/day", "10/"} {
+		if _, err := parseRateLimit(bad); err == nil {
+			t.Errorf("parseRateLimit(%q) should fail", bad)
+		}
+	}
+	if _, err := newRateLimiter(map[string]string{"clood_ask": "often"}); err == nil {
+		t.Error("newRateLimiter should reject a bad spec")
+	}
+}
+
+func TestRateLimiterRefill(t *testing.T) {
+	rl, clock := newTestLimiter(t, map[string]string{"clood_ask": "3/minute"})
+
+	for i := 0; i < 3; i++ {
+		if ok, _ := rl.allow("clood_ask", ""); !ok {
+			t.Fatalf("call %d within the burst was limited", i+1)
+		}
+	}
+	ok, wait := rl.allow("clood_ask", "")
+	if ok || wait != 20*time.Second {
+		t.Fatalf("over the burst: ok=%v wait=%v, want a 20s wait", ok, wait)
+	}
+
+	// A token comes back every 20s; halfway there the wait halves
+	clock.advance(10 * time.Second)
+	if ok, wait := rl.allow("clood_ask", ""); ok || wait != 10*time.Second {
+		t.Errorf("after 10s: ok=%v wait=%v, want a 10s wait", ok, wait)
+	}
+	clock.advance(10 * time.Second)
+	if ok, _ := rl.allow("clood_ask", ""); !ok {
+		t.Error("after 20s a token should have refilled")
+	}
+
+	// Refill stops at the burst, however long the bucket sat idle
+	clock.advance(time.Hour)
+	allowed := 0
+	for i := 0; i < 5; i++ {
+		if ok, _ := rl.allow("clood_ask", ""); ok {
+			allowed++
+		}
+	}
+	if allowed != 3 {
+		t.Errorf("after an idle hour %d calls went through, want the burst of 3", allowed)
+	}
+
+	// Tools without a limit are never held back
+	for i := 0; i < 10; i++ {
+		if ok, _ := rl.allow("clood_hosts", ""); !ok {
+			t.Fatal("unlimited tool was limited")
+		}
+	}
+}
+
+func TestRateLimiterPerSession(t *testing.T) {
+	rl, _ := newTestLimiter(t, map[string]string{"clood_ask": "1/minute", "clood_grep": "1/minute"})
+
+	if ok, _ := rl.allow("clood_ask", "a"); !ok {
+		t.Fatal("session a's first call was limited")
+	}
+	if ok, _ := rl.allow("clood_ask", "a"); ok {
+		t.Error("session a's second call should be limited")
+	}
+	if ok, _ := rl.allow("clood_ask", "b"); !ok {
+		t.Error("session b shouldn't share session a's bucket")
+	}
+	if ok, _ := rl.allow("clood_grep", "a"); !ok {
+		t.Error("clood_grep shouldn't share clood_ask's bucket")
+	}
+}
+
+func TestRateLimiterDropsIdleBuckets(t *testing.T) {
+	rl, clock := newTestLimiter(t, map[string]string{"clood_ask": "1/minute"})
+	for i := 0; i < maxIdleBuckets; i++ {
+		rl.allow("clood_ask", fmt.Sprint(i))
+	}
+
+	// Still within the period: nothing has refilled, so nothing is dropped
+	rl.allow("clood_ask", "new")
+	if len(rl.buckets) != maxIdleBuckets+1 {
+		t.Fatalf("buckets = %d, want %d", len(rl.buckets), maxIdleBuckets+1)
+	}
+
+	clock.advance(time.Minute)
+	rl.allow("clood_ask", "newer")
+	if len(rl.buckets) != 1 {
+		t.Errorf("buckets = %d after a quiet period, want only the new one", len(rl.buckets))
+	}
+}
+
+func TestRateLimiterMiddleware(t *testing.T) {
+	rl, _ := newTestLimiter(t, map[string]string{"clood_ask": "2/minute"})
+	calls := 0
+	handler := rl.middleware()(func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		calls++
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	var req mcp.CallToolRequest
+	req.Params.Name = "clood_ask"
+	for i := 0; i < 3; i++ {
+		if _, err := handler(context.Background(), req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("handler ran %d times, want 2", calls)
+	}
+
+	res, _ := handler(context.Background(), req)
+	if !res.IsError || len(res.Content) == 0 {
+		t.Fatalf("limited call result = %+v", res)
+	}
+	text, _ := res.Content[0].(mcp.TextContent)
+	var body struct {
+		Error      string `json:"error"`
+		Tool       string `json:"tool"`
+		Limit      string `json:"limit"`
+		RetryAfter int    `json:"retry_after"`
+	}
+	if err := json.Unmarshal([]byte(text.Text), &body); err != nil {
+		t.Fatalf("%v: %q", err, text.Text)
+	}
+	if body.Error != "rate_limited" || body.Tool != "clood_ask" || body.Limit != "2/minute" || body.RetryAfter != 30 {
+		t.Errorf("limited call body = %+v", body)
+	}
+}
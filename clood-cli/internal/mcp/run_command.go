@@ -0,0 +1,305 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/dirtybirdnj/clood/internal/config"
+	"github.com/mark3labs/mcp-go/mcp"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// defaultRunTimeout applies when neither the call nor
+	// allowed_commands.yaml sets one
+	defaultRunTimeout = 60 * time.Second
+
+	// maxRunTimeout bounds the timeout a caller can ask for
+	maxRunTimeout = 10 * time.Minute
+
+	// runOutputLimit caps each of stdout and stderr in the result
+	runOutputLimit = 100 * 1024
+)
+
+// AllowedCommands is allowed_commands.yaml. Patterns are matched against
+// the whole command line, where * matches anything; a trailing " *" also
+// matches the command with no arguments. Deny wins over allow.
+//
+//	timeout: 2m
+//	allow:
+//	  - go test *
+//	  - cargo build *
+//	deny:
+//	  - go test -exec *
+type AllowedCommands struct {
+	Timeout string   `yaml:"timeout,omitempty"`
+	Allow   []string `yaml:"allow"`
+	Deny    []string `yaml:"deny,omitempty"`
+}
+
+// AllowedCommandsPath returns the path to the run_command allow-list
+func AllowedCommandsPath() string {
+	return filepath.Join(config.ConfigDir(), "allowed_commands.yaml")
+}
+
+// loadAllowedCommands reads the allow-list; a missing file allows nothing
+func loadAllowedCommands() (*AllowedCommands, error) {
+	ac := &AllowedCommands{}
+	data, err := os.ReadFile(AllowedCommandsPath())
+	if os.IsNotExist(err) {
+		return ac, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read allowed commands: %w", err)
+	}
+	if err := yaml.Unmarshal(data, ac); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", AllowedCommandsPath(), err)
+	}
+	return ac, nil
+}
+
+// DefaultTimeout is the configured timeout, or 60s
+func (ac *AllowedCommands) DefaultTimeout() (time.Duration, error) {
+	if ac.Timeout == "" {
+		return defaultRunTimeout, nil
+	}
+	d, err := time.ParseDuration(ac.Timeout)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid timeout %q in %s", ac.Timeout, AllowedCommandsPath())
+	}
+	return d, nil
+}
+
+// Check returns nil if argv may run, or an error naming the deny pattern
+// it matched, or saying it matched no allow pattern
+func (ac *AllowedCommands) Check(argv []string) error {
+	line := strings.Join(argv, " ")
+	for _, p := range ac.Deny {
+		if commandPatternMatch(p, line) {
+			return fmt.Errorf("denied: %q matches deny pattern %q", line, p)
+		}
+	}
+	for _, p := range ac.Allow {
+		if commandPatternMatch(p, line) {
+			return nil
+		}
+	}
+	if len(ac.Allow) == 0 {
+		return fmt.Errorf("denied: no commands are allowed (add patterns to %s)", AllowedCommandsPath())
+	}
+	return fmt.Errorf("denied: %q matches no pattern in %s", line, AllowedCommandsPath())
+}
+
+// commandPatternMatch reports whether line matches pattern, with * as a
+// wildcard and runs of spaces in the pattern treated as one
+func commandPatternMatch(pattern, line string) bool {
+	words := strings.Fields(pattern)
+	if len(words) == 0 {
+		return false
+	}
+	optionalArgs := len(words) > 1 && words[len(words)-1] == "*"
+	if optionalArgs {
+		words = words[:len(words)-1]
+	}
+
+	var quoted []string
+	for _, w := range words {
+		quoted = append(quoted, strings.ReplaceAll(regexp.QuoteMeta(w), `\*`, `.*`))
+	}
+	expr := "^" + strings.Join(quoted, " ")
+	if optionalArgs {
+		expr += "(?: .*)?"
+	}
+	re, err := regexp.Compile(expr + "$")
+	return err == nil && re.MatchString(line)
+}
+
+// splitCommand splits a command line into argv the way a shell would for
+// plain words and quotes. Nothing else is interpreted: there is no shell,
+// so pipes, ; and $VARS would reach the program as literal arguments and
+// are rejected instead.
+func splitCommand(line string) ([]string, error) {
+	var argv []string
+	var cur strings.Builder
+	inWord := false
+	var quote byte
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else if c == '\\' && quote == '"' && i+1 < len(line) && strings.IndexByte(`"\`, line[i+1]) >= 0 {
+				i++
+				cur.WriteByte(line[i])
+			} else {
+				cur.WriteByte(c)
+			}
+		case c == '\'' || c == '"':
+			quote, inWord = c, true
+		case c == '\\' && i+1 < len(line):
+			i++
+			cur.WriteByte(line[i])
+			inWord = true
+		case c == ' ' || c == '\t' || c == '\n':
+			if inWord {
+				argv = append(argv, cur.String())
+				cur.Reset()
+				inWord = false
+			}
+		case strings.IndexByte("|&;<>()$`", c) >= 0:
+			return nil, fmt.Errorf("shell syntax %q is not supported (commands run without a shell)", string(c))
+		default:
+			cur.WriteByte(c)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, errors.New("unterminated quote")
+	}
+	if inWord {
+		argv = append(argv, cur.String())
+	}
+	if len(argv) == 0 {
+		return nil, errors.New("command is empty")
+	}
+	return argv, nil
+}
+
+// RunCommandResult is what clood_run_command returns
+type RunCommandResult struct {
+	Command    string `json:"command"`
+	Dir        string `json:"dir"`
+	ExitCode   int    `json:"exit_code"`
+	Stdout     string `json:"stdout"`
+	Stderr     string `json:"stderr"`
+	DurationMs int64  `json:"duration_ms"`
+	TimedOut   bool   `json:"timed_out,omitempty"`
+	Truncated  bool   `json:"truncated,omitempty"`
+}
+
+func (s *Server) runCommandTool() mcp.Tool {
+	return mcp.NewTool("clood_run_command",
+		mcp.WithDescription(`▶️ Run a shell command from the allow-list.
+
+Runs a command such as "go test ./..." only if it matches a pattern in
+~/.config/clood/allowed_commands.yaml (e.g. "go test *", "cargo build *").
+Anything else is refused with the deny pattern it matched, or "denied".
+
+There is no shell: quotes work, but pipes, redirects, ; and $VARS don't.
+Returns stdout, stderr and exit_code as JSON. A non-zero exit is a normal
+result, not an error.
+
+Cost: ZERO tokens; runs for as long as the command does (default limit 60s).`),
+		mcp.WithString("command", mcp.Required(), mcp.Description("Command line to run (e.g., 'go test ./...')")),
+		mcp.WithString("dir", mcp.Description("Working directory (default: current directory)")),
+		mcp.WithNumber("timeout", mcp.Description("Timeout in seconds (default: allowed_commands.yaml's timeout, or 60; max 600)")),
+		dryRunOption(),
+	)
+}
+
+func (s *Server) runCommandHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+
+	line, ok := args["command"].(string)
+	if !ok || strings.TrimSpace(line) == "" {
+		return mcp.NewToolResultError("command is required"), nil
+	}
+	argv, err := splitCommand(line)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	allowed, err := loadAllowedCommands()
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if err := allowed.Check(argv); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	timeout, err := allowed.DefaultTimeout()
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if t, ok := args["timeout"].(float64); ok && t > 0 {
+		timeout = time.Duration(t * float64(time.Second))
+	}
+	if timeout > maxRunTimeout {
+		timeout = maxRunTimeout
+	}
+
+	dir, _ := args["dir"].(string)
+	if dir == "" {
+		dir = "."
+	}
+	if dir, err = filepath.Abs(dir); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("invalid dir: %v", err)), nil
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return mcp.NewToolResultError(fmt.Sprintf("dir is not a directory: %s", dir)), nil
+	}
+
+	if isDryRun(args) {
+		return dryRunResult("run "+strings.Join(argv, " "), map[string]interface{}{
+			"dir":         dir,
+			"timeout_sec": timeout.Seconds(),
+		}), nil
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(runCtx, argv[0], argv[1:]...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	start := time.Now()
+	err = cmd.Run()
+	result := RunCommandResult{
+		Command:    strings.Join(argv, " "),
+		Dir:        dir,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+
+	var exitErr *exec.ExitError
+	switch {
+	case runCtx.Err() == context.DeadlineExceeded:
+		result.TimedOut = true
+		result.ExitCode = -1
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitCode()
+	case err != nil:
+		return mcp.NewToolResultError(fmt.Sprintf("failed to run %s: %v", argv[0], err)), nil
+	}
+
+	result.Stdout, result.Truncated = limitRunOutput(stdout.String(), result.Truncated)
+	result.Stderr, result.Truncated = limitRunOutput(stderr.String(), result.Truncated)
+	if result.TimedOut {
+		result.Stderr += fmt.Sprintf("\n(killed after %s timeout)", timeout)
+	}
+
+	data, _ := json.MarshalIndent(result, "", "  ")
+	return mcp.NewToolResultText(string(data)), nil
+}
+
+// limitRunOutput keeps the tail of s, where test failures and build errors
+// usually end up, and ORs truncation into truncated
+func limitRunOutput(s string, truncated bool) (string, bool) {
+	if len(s) <= runOutputLimit {
+		return s, truncated
+	}
+	return "... (truncated)\n" + s[len(s)-runOutputLimit:], true
+}
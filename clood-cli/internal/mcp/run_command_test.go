@@ -0,0 +1,134 @@
+package mcp
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitCommand(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"go test ./...", []string{"go", "test", "./..."}},
+		{"  go   test\t-v  ", []string{"go", "test", "-v"}},
+		{`git commit -m "two words"`, []string{"git", "commit", "-m", "two words"}},
+		{`echo 'single $quoted; |'`, []string{"echo", "single $quoted; |"}},
+		{`echo "say \"hi\" \\ \n"`, []string{"echo", `say "hi" \ \n`}},
+		{`echo a\ b`, []string{"echo", "a b"}},
+		{`echo \;`, []string{"echo", ";"}},
+		{`echo ""`, []string{"echo", ""}},
+		{`echo pre"mid"post`, []string{"echo", "premidpost"}},
+	}
+	for _, tt := range tests {
+		got, err := splitCommand(tt.line)
+		if err != nil {
+			t.Errorf("splitCommand(%q): %v", tt.line, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitCommand(%q) = %q; want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestSplitCommandRejectsShellSyntax(t *testing.T) {
+	for _, line := range []string{
+		"go test | tee out",
+		"go test; rm -rf /",
+		"go test && rm -rf /",
+		"go test > out",
+		"cat < /etc/passwd",
+		"echo $(whoami)",
+		"echo `whoami`",
+		"echo $HOME",
+		"(go test)",
+		"go test &",
+		`echo "unterminated`,
+		"echo 'unterminated",
+		"",
+		"   ",
+	} {
+		if argv, err := splitCommand(line); err == nil {
+			t.Errorf("splitCommand(%q) = %q; want an error", line, argv)
+		}
+	}
+}
+
+func TestCommandPatternMatch(t *testing.T) {
+	tests := []struct {
+		pattern, line string
+		want          bool
+	}{
+		{"go test *", "go test ./...", true},
+		{"go test *", "go test", true}, // trailing " *" allows no arguments
+		{"go test *", "go testx", false},
+		{"go test *", "go vet ./...", false},
+		{"go test *", "sudo go test", false}, // anchored at the start
+		{"go  test   *", "go test -v", true},
+		{"go build", "go build", true},
+		{"go build", "go build ./...", false},
+		{"go test -run *", "go test -run TestX", true},
+		{"go test -run *", "go test -run", true},
+		{"go * ./...", "go vet ./...", true},
+		{"go * ./...", "go vet ./x", false},
+		{"make test*", "make tests", true},
+		{"make test*", "make test", true},
+		{"*", "anything at all", true},
+		{"go.test *", "goxtest", false}, // regexp metacharacters are literal
+		{"", "go test", false},
+		{"   ", "go test", false},
+	}
+	for _, tt := range tests {
+		if got := commandPatternMatch(tt.pattern, tt.line); got != tt.want {
+			t.Errorf("commandPatternMatch(%q, %q) = %v; want %v", tt.pattern, tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestAllowedCommandsCheck(t *testing.T) {
+	ac := &AllowedCommands{
+		Allow: []string{"go test *", "go vet *", "git status"},
+		Deny:  []string{"go test -exec *", "go vet -vettool *"},
+	}
+	tests := []struct {
+		argv    []string
+		allowed bool
+		reason  string
+	}{
+		{[]string{"go", "test", "./..."}, true, ""},
+		{[]string{"go", "test"}, true, ""},
+		{[]string{"git", "status"}, true, ""},
+		{[]string{"git", "status", "--short"}, false, "matches no pattern"},
+		{[]string{"go", "test", "-exec", "evil"}, false, "deny pattern"},
+		{[]string{"go", "test", "-exec"}, false, "deny pattern"},
+		{[]string{"go", "vet", "-vettool", "/tmp/x"}, false, "deny pattern"},
+		{[]string{"rm", "-rf", "/"}, false, "matches no pattern"},
+	}
+	for _, tt := range tests {
+		err := ac.Check(tt.argv)
+		if tt.allowed {
+			if err != nil {
+				t.Errorf("Check(%q): %v", tt.argv, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("Check(%q) allowed; want denied", tt.argv)
+		} else if !strings.Contains(err.Error(), tt.reason) {
+			t.Errorf("Check(%q) = %v; want %q", tt.argv, err, tt.reason)
+		}
+	}
+
+	// Deny wins even when the same line is allowed explicitly
+	both := &AllowedCommands{Allow: []string{"go test -exec *"}, Deny: []string{"go test -exec *"}}
+	if err := both.Check([]string{"go", "test", "-exec", "x"}); err == nil || !strings.Contains(err.Error(), "deny pattern") {
+		t.Errorf("deny over allow: %v", err)
+	}
+
+	// No allow-list allows nothing
+	if err := (&AllowedCommands{}).Check([]string{"go", "test"}); err == nil || !strings.Contains(err.Error(), "no commands are allowed") {
+		t.Errorf("empty allow-list: %v", err)
+	}
+}
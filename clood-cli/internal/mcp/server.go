@@ -7,20 +7,31 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/dirtybirdnj/clood/internal/agents"
 	"github.com/dirtybirdnj/clood/internal/analyze"
+	"github.com/dirtybirdnj/clood/internal/audit"
 	"github.com/dirtybirdnj/clood/internal/clipboard"
 	"github.com/dirtybirdnj/clood/internal/config"
+	projectctx "github.com/dirtybirdnj/clood/internal/context"
 	"github.com/dirtybirdnj/clood/internal/git"
+	"github.com/dirtybirdnj/clood/internal/gitignore"
 	"github.com/dirtybirdnj/clood/internal/hosts"
 	"github.com/dirtybirdnj/clood/internal/inception"
 	"github.com/dirtybirdnj/clood/internal/ollama"
@@ -36,6 +47,14 @@ type Server struct {
 	mcpServer *server.MCPServer
 	config    *config.Config
 	hostMgr   *hosts.Manager
+
+	// load counts in-flight requests across the host managers the
+	// handlers rebuild from config, for least-latency routing
+	load *hosts.RequestLoad
+
+	// availability lists the online hosts and their models for the
+	// clood_ask and clood_inception descriptions; see refreshAvailability
+	availability string
 }
 
 // NewServer creates a new clood MCP server with all tools registered
@@ -48,24 +67,34 @@ func NewServer() (*Server, error) {
 	}
 
 	// Create host manager
-	hostMgr := hosts.NewManager()
+	load := hosts.NewRequestLoad()
+	hostMgr := hosts.NewManager(hosts.WithRequestLoad(load))
 	hostMgr.AddHosts(cfg.Hosts)
 
 	// Create MCP server
-	mcpServer := server.NewMCPServer(
-		"clood",
-		"0.2.0",
+	opts := []server.ServerOption{
 		server.WithToolCapabilities(true),
 		server.WithLogging(),
-	)
+	}
+	if len(cfg.MCP.RateLimits) > 0 {
+		limiter, err := newRateLimiter(cfg.MCP.RateLimits)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, server.WithToolHandlerMiddleware(limiter.middleware()))
+	}
+	mcpServer := server.NewMCPServer("clood", "0.2.0", opts...)
 
 	s := &Server{
 		mcpServer: mcpServer,
 		config:    cfg,
 		hostMgr:   hostMgr,
+		load:      load,
 	}
 
-	// Register all tools
+	// Register all tools, with the models that are online right now in the
+	// descriptions of the ones that take a model
+	s.refreshAvailability(context.Background())
 	s.registerTools()
 
 	return s, nil
@@ -100,6 +129,7 @@ func (s *Server) registerTools() {
 
 	// The main event: ask local models
 	s.mcpServer.AddTool(s.askTool(), s.askHandler)
+	s.mcpServer.AddTool(s.refreshToolsTool(), s.refreshToolsHandler)
 
 	// INCEPTION: LLM-to-LLM sub-queries
 	s.mcpServer.AddTool(s.inceptionTool(), s.inceptionHandler)
@@ -122,12 +152,16 @@ func (s *Server) registerTools() {
 	s.mcpServer.AddTool(s.sqliteSchemaTool(), s.sqliteSchemaHandler)
 	s.mcpServer.AddTool(s.sqliteTablesTool(), s.sqliteTablesHandler)
 
+	// RUN: Allow-listed shell commands
+	s.mcpServer.AddTool(s.runCommandTool(), s.runCommandHandler)
+
 	// CLIPBOARD: System clipboard access
 	s.mcpServer.AddTool(s.clipboardReadTool(), s.clipboardReadHandler)
 	s.mcpServer.AddTool(s.clipboardWriteTool(), s.clipboardWriteHandler)
 
 	// CONDUCTOR: Remote orchestrator on ubuntu25
 	s.mcpServer.AddTool(s.conductorTool(), s.conductorHandler)
+	s.mcpServer.AddTool(s.workspaceReadTool(), s.workspaceReadHandler)
 
 	// THUNDERDOME: Parallel multi-host catfights
 	s.mcpServer.AddTool(s.thunderdomeTool(), s.thunderdomeHandler)
@@ -157,10 +191,16 @@ func (s *Server) preflightTool() mcp.Tool {
 Call this BEFORE doing any work. Returns:
 - Available local discovery tools (grep, tree, symbols, imports, context)
 - Online Ollama hosts and models
+- Disk usage of ~/.clood/gallery and the memory store (warns when large)
 - Recommended workflow for this session
 
 This ensures you use local resources before making network requests.
-Cost: ZERO network, ZERO tokens, instant.`),
+Cost: ZERO network, ZERO tokens, instant.
+
+With json: true, returns a capability matrix instead of prose:
+{"local_search", "ollama_online", "hosts", "models", "gpu_available",
+"disk_free_gb", ...} for planning which tools to call.`),
+		mcp.WithBoolean("json", mcp.Description("Return a machine-readable capability matrix instead of the text report")),
 	)
 }
 
@@ -187,6 +227,8 @@ func (s *Server) hostsTool() mcp.Tool {
 		mcp.WithDescription(`Check Ollama host status. ALWAYS call this before clood_ask.
 
 Returns online/offline status, latency, and available models for each host.
+model_details adds each model's size and an approximate vram_mb_estimate
+(file size plus ~30% for KV cache) for capacity planning.
 Use this to verify local LLM is available before querying.
 Cost: Local network only (no internet), ZERO tokens.`),
 	)
@@ -196,7 +238,9 @@ func (s *Server) modelsTool() mcp.Tool {
 	return mcp.NewTool("clood_models",
 		mcp.WithDescription(`List available models across all Ollama hosts.
 
-Shows which hosts have each model. Use to pick the right model for your task.
+Shows which hosts have each model, its disk size, parameter count, and an
+approximate vram_mb_estimate.
+Use to pick the right model for your task (and check it fits in VRAM).
 Cost: Local network only (no internet), ZERO tokens.`),
 		mcp.WithString("host", mcp.Description("Optional: filter to specific host")),
 	)
@@ -224,7 +268,7 @@ Cost: Local network only (no internet), ZERO tokens.`),
 
 func (s *Server) askTool() mcp.Tool {
 	return mcp.NewTool("clood_ask",
-		mcp.WithDescription(`Query LOCAL Ollama LLM. Use INSTEAD of cloud LLM APIs.
+		mcp.WithDescription(s.withAvailability(`Query LOCAL Ollama LLM. Use INSTEAD of cloud LLM APIs.
 
 ⚠️  BEFORE calling this: Run clood_hosts to verify a host is online.
 
@@ -233,33 +277,48 @@ Routes to best available local model. Use for:
 - Explaining code patterns
 - Best practices questions
 
-Cost: Local LLM tokens only, ZERO cloud API calls, ZERO internet.`),
+Only pass model= with a model listed below as available.
+
+Cost: Local LLM tokens only, ZERO cloud API calls, ZERO internet.`)),
 		mcp.WithString("prompt", mcp.Required(), mcp.Description("The prompt to send to the model")),
 		mcp.WithString("model", mcp.Description("Specific model to use (default: routes to best available)")),
 		mcp.WithString("host", mcp.Description("Specific host to use (default: fastest responding)")),
 		mcp.WithBoolean("dialogue", mcp.Description("If true, model will ask clarifying questions before implementing")),
+		mcp.WithBoolean("streaming", mcp.Description("If true, stream partial output as progress notifications (falls back to buffered if unsupported)")),
 	)
 }
 
 func (s *Server) inceptionTool() mcp.Tool {
-	return mcp.NewTool("clood_inception",
-		mcp.WithDescription(`🌀 INCEPTION: Query an expert LLM model mid-stream.
+	registry := inception.NewHandler(s.config.Inception.ExpertAliases).Registry
+	aliases := make([]string, 0, len(registry))
+	for alias := range registry {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+
+	var experts strings.Builder
+	for _, alias := range aliases {
+		fmt.Fprintf(&experts, "- %s → %s\n", alias, registry[alias])
+	}
 
-Use this when you need specialized knowledge from a different model:
-- science: Physics, chemistry, biology facts
-- math: Calculations, proofs, formulas
-- code: Code review, programming patterns
-- creative: Brainstorming, writing
+	return mcp.NewTool("clood_inception",
+		mcp.WithDescription(s.withAvailability(`🌀 INCEPTION: Query an expert LLM model mid-stream.
 
+Use this when you need specialized knowledge from a different model.
+Active expert aliases (add more in `+config.InceptionPath()+`):
+`+experts.String()+`
 Example: You're writing simulation code and need orbital velocity.
 Call: clood_inception expert="science" query="What is ISS orbital velocity?"
 Response: "7.66 km/s at 408km altitude"
 Continue your work with the expert knowledge.
 
-This is ONE-LEVEL deep - the expert cannot call other experts.
-Cost: Local LLM tokens only, ZERO cloud API.`),
+One level deep by default - the expert cannot call other experts.
+Pass depth=2 (or set max_depth in inception.yaml) to let it send its own
+sub-queries, answered inline.
+Cost: Local LLM tokens only, ZERO cloud API.`)),
 		mcp.WithString("query", mcp.Required(), mcp.Description("The question for the expert model")),
-		mcp.WithString("expert", mcp.Required(), mcp.Description("Expert type: science, math, code, creative, or model name")),
+		mcp.WithString("expert", mcp.Required(), mcp.Description("Expert alias from the list above, or a model name")),
+		mcp.WithNumber("depth", mcp.Description("Sub-query nesting limit (default: max_depth from inception.yaml, else 1)")),
 	)
 }
 
@@ -277,7 +336,7 @@ Replaces these web searches:
 - "what files contain Y" → clood_grep "Y"
 - "how does Z work in this project" → clood_grep "Z"
 
-Cost: ZERO network, ZERO tokens, instant.
+Cost: ZERO network, ZERO tokens, instant (uses ripgrep when installed).
 ALWAYS use this before considering WebSearch for code-related queries.`),
 		mcp.WithString("pattern", mcp.Required(), mcp.Description("Regex pattern to search for")),
 		mcp.WithString("path", mcp.Description("Directory to search in (default: current directory)")),
@@ -300,6 +359,7 @@ Respects .gitignore. Shows clean directory tree.
 Cost: ZERO network, ZERO tokens, instant.`),
 		mcp.WithString("path", mcp.Description("Directory to show (default: current directory)")),
 		mcp.WithNumber("depth", mcp.Description("Maximum depth to traverse (default: 3)")),
+		mcp.WithBoolean("no_gitignore", mcp.Description("Show files matched by the root .gitignore (default: false)")),
 	)
 }
 
@@ -312,11 +372,11 @@ Replaces these web searches:
 - "function signature for Foo"
 - "what types does this package define"
 
-Extracts functions, types, classes from Go, Python, JS/TS.
+Extracts functions, types, classes from Go, Python, JS/TS, Rust.
 Cost: ZERO network, ZERO tokens, instant.`),
 		mcp.WithString("path", mcp.Required(), mcp.Description("File or directory to analyze")),
 		mcp.WithBoolean("exported_only", mcp.Description("Only show exported/public symbols")),
-		mcp.WithString("kind", mcp.Description("Filter by kind: func, type, class, const, var")),
+		mcp.WithString("kind", mcp.Description("Filter by kind: func, type, class, const, var, trait, impl")),
 	)
 }
 
@@ -343,6 +403,7 @@ Creates a condensed context including:
 - README content
 - Project structure
 - Key files
+- Go package docs (go doc -short) for Go modules
 
 Use to quickly understand a project without reading every file.
 Cost: ZERO network, ZERO tokens, instant.`),
@@ -367,19 +428,21 @@ Cost: ZERO network, ZERO tokens, instant.`),
 
 func (s *Server) analyzeTool() mcp.Tool {
 	return mcp.NewTool("clood_analyze",
-		mcp.WithDescription(`🔬 Run static analysis on Go codebase (like "clood bcbc").
+		mcp.WithDescription(`🔬 Run static analysis on a Go or Python codebase (like "clood bcbc").
 
 Returns pre-computed analysis including:
-- Build status (pass/fail)
-- Go vet issues
+- Build status (pass/fail; compileall for Python)
+- Go vet issues (ruff or pylint for Python)
 - TODO/FIXME items
 - Recent commits and hot files
 - Symbol counts (funcs, types, methods)
 
 Use this to quickly understand codebase health before making changes.
-Cost: ZERO network, ZERO tokens (runs go build/vet locally).`),
+Cost: ZERO network, ZERO tokens (runs go build/vet or ruff/pylint locally).`),
 		mcp.WithString("path", mcp.Description("Directory to analyze (default: current directory)")),
 		mcp.WithBoolean("run_tests", mcp.Description("Also run tests (slower)")),
+		mcp.WithBoolean("check_dupes", mcp.Description("Also report near-identical Go functions (refactoring candidates)")),
+		mcp.WithString("lang", mcp.Description("Project language: go, python, or auto (default: auto-detect from go.mod/pyproject.toml/setup.py/requirements.txt)")),
 	)
 }
 
@@ -391,7 +454,7 @@ func (s *Server) hostsHandler(ctx context.Context, req mcp.CallToolRequest) (*mc
 	// Reload hosts from config
 	cfg, _ := config.Load()
 	if cfg != nil {
-		s.hostMgr = hosts.NewManager()
+		s.hostMgr = hosts.NewManager(hosts.WithRequestLoad(s.load))
 		s.hostMgr.AddHosts(cfg.Hosts)
 	}
 
@@ -399,13 +462,14 @@ func (s *Server) hostsHandler(ctx context.Context, req mcp.CallToolRequest) (*mc
 
 	// Build JSON response
 	type hostJSON struct {
-		Name    string   `json:"name"`
-		URL     string   `json:"url"`
-		Online  bool     `json:"online"`
-		Latency int64    `json:"latency_ms,omitempty"`
-		Version string   `json:"version,omitempty"`
-		Models  []string `json:"models,omitempty"`
-		Error   string   `json:"error,omitempty"`
+		Name    string        `json:"name"`
+		URL     string        `json:"url"`
+		Online  bool          `json:"online"`
+		Latency int64         `json:"latency_ms,omitempty"`
+		Version string        `json:"version,omitempty"`
+		Models  []string      `json:"models,omitempty"`
+		Details []hosts.Model `json:"model_details,omitempty"`
+		Error   string        `json:"error,omitempty"`
 	}
 
 	var result []hostJSON
@@ -421,6 +485,7 @@ func (s *Server) hostsHandler(ctx context.Context, req mcp.CallToolRequest) (*mc
 			for _, m := range st.Models {
 				h.Models = append(h.Models, m.Name)
 			}
+			h.Details = hosts.HostModels(st)
 		}
 		if st.Error != nil {
 			h.Error = st.Error.Error()
@@ -436,7 +501,7 @@ func (s *Server) modelsHandler(ctx context.Context, req mcp.CallToolRequest) (*m
 	// Reload config
 	cfg, _ := config.Load()
 	if cfg != nil {
-		s.hostMgr = hosts.NewManager()
+		s.hostMgr = hosts.NewManager(hosts.WithRequestLoad(s.load))
 		s.hostMgr.AddHosts(cfg.Hosts)
 	}
 
@@ -453,16 +518,13 @@ func (s *Server) modelsHandler(ctx context.Context, req mcp.CallToolRequest) (*m
 		if !status.Online {
 			return mcp.NewToolResultError(fmt.Sprintf("Host offline: %s", hostFilter)), nil
 		}
-		var models []string
-		for _, m := range status.Models {
-			models = append(models, m.Name)
-		}
+		models := hosts.HostModels(status)
 		data, _ := json.MarshalIndent(models, "", "  ")
 		return mcp.NewToolResultText(string(data)), nil
 	}
 
-	// All models across all hosts
-	allModels := s.hostMgr.GetAllModels()
+	// All models across all hosts, with sizes so callers can judge VRAM fit
+	allModels := s.hostMgr.GetModels()
 	data, _ := json.MarshalIndent(allModels, "", "  ")
 	return mcp.NewToolResultText(string(data)), nil
 }
@@ -486,7 +548,7 @@ func (s *Server) healthHandler(ctx context.Context, req mcp.CallToolRequest) (*m
 	}
 
 	if cfg != nil {
-		s.hostMgr = hosts.NewManager()
+		s.hostMgr = hosts.NewManager(hosts.WithRequestLoad(s.load))
 		s.hostMgr.AddHosts(cfg.Hosts)
 
 		statuses := s.hostMgr.CheckAllHosts()
@@ -552,6 +614,7 @@ func (s *Server) askHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.
 	modelPref, _ := args["model"].(string)
 	hostPref, _ := args["host"].(string)
 	dialogue, _ := args["dialogue"].(bool)
+	streaming, _ := args["streaming"].(bool)
 
 	// Add dialogue system prompt if requested
 	if dialogue {
@@ -561,7 +624,7 @@ func (s *Server) askHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.
 	// Reload config for latest host info
 	cfg, _ := config.Load()
 	if cfg != nil {
-		s.hostMgr = hosts.NewManager()
+		s.hostMgr = hosts.NewManager(hosts.WithRequestLoad(s.load))
 		s.hostMgr.AddHosts(cfg.Hosts)
 	}
 
@@ -587,31 +650,44 @@ func (s *Server) askHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.
 		}
 	}
 
-	// If no host specified, find first online host with the model
+	// If no host specified, pick among the online hosts with the model
+	// using routing.strategy
 	if targetHost == nil {
-		statuses := s.hostMgr.CheckAllHosts()
-		for _, st := range statuses {
+		var candidates []*hosts.HostStatus
+		for _, st := range s.hostMgr.CheckAllHosts() {
 			if !st.Online {
 				continue
 			}
 			for _, m := range st.Models {
 				if m.Name == targetModel || strings.HasPrefix(m.Name, targetModel) {
-					targetHost = st.Host
+					candidates = append(candidates, st)
 					break
 				}
 			}
-			if targetHost != nil {
-				break
-			}
+		}
+		var strategy hosts.BalanceStrategy
+		if cfg != nil {
+			strategy, _ = hosts.ParseBalanceStrategy(cfg.Routing.Strategy)
+		}
+		if pick := s.hostMgr.SelectHost(candidates, strategy); pick != nil {
+			targetHost = pick.Host
 		}
 	}
 
 	if targetHost == nil {
 		return mcp.NewToolResultError(fmt.Sprintf("No online host found with model: %s", targetModel)), nil
 	}
+	done := s.hostMgr.BeginRequest(targetHost.Name)
+	defer done()
 
-	// Call Ollama
-	response, err := callOllama(targetHost.URL, targetModel, prompt)
+	// Call Ollama (streamed as progress notifications when the client asked for it)
+	var response string
+	var err error
+	if streaming {
+		response, err = s.streamOllamaWithProgress(ctx, req, targetHost.URL, targetModel, prompt)
+	} else {
+		response, err = callOllama(targetHost.URL, targetModel, prompt)
+	}
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Ollama error: %v", err)), nil
 	}
@@ -635,7 +711,13 @@ func (s *Server) inceptionHandler(ctx context.Context, req mcp.CallToolRequest)
 	}
 
 	// Create inception handler
-	handler := inception.NewHandler()
+	handler := inception.NewHandler(s.config.Inception.ExpertAliases)
+	if s.config.Inception.MaxDepth > 0 {
+		handler.MaxDepth = s.config.Inception.MaxDepth
+	}
+	if d, ok := args["depth"].(float64); ok && d >= 1 {
+		handler.MaxDepth = int(d)
+	}
 
 	// Build sub-query
 	subQuery := inception.SubQuery{
@@ -644,7 +726,7 @@ func (s *Server) inceptionHandler(ctx context.Context, req mcp.CallToolRequest)
 	}
 
 	// Execute synchronously
-	result := handler.ExecuteSubQuery(ctx, subQuery)
+	result := handler.ExecuteSubQuery(ctx, subQuery, 0)
 
 	if result.Error != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Inception failed: %v", result.Error)), nil
@@ -697,21 +779,148 @@ func (s *Server) grepHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp
 		return mcp.NewToolResultError(fmt.Sprintf("Invalid regex: %v", err)), nil
 	}
 
-	type match struct {
-		File    string `json:"file"`
-		Line    int    `json:"line,omitempty"`
-		Content string `json:"content,omitempty"`
+	matches, err := grepRipgrep(ctx, pattern, searchPath, fileType, ignoreCase, filesOnly)
+	if err != nil {
+		// rg missing or failed - use the built-in walker
+		matches = grepWalk(re, searchPath, fileType, filesOnly)
+	}
+
+	data, _ := json.MarshalIndent(matches, "", "  ")
+	return mcp.NewToolResultText(string(data)), nil
+}
+
+// grepMatch is one clood_grep result
+type grepMatch struct {
+	File    string `json:"file"`
+	Line    int    `json:"line,omitempty"`
+	Content string `json:"content,omitempty"`
+}
+
+// errNoRipgrep signals that rg is not installed
+var errNoRipgrep = errors.New("rg not found")
+
+// grepSkipDirs are skipped by both searches, on top of hidden and
+// .gitignored paths
+var grepSkipDirs = []string{"vendor", "node_modules"}
+
+// rgTypeAliases maps extensions to ripgrep type names where they differ
+var rgTypeAliases = map[string]string{
+	"rs":  "rust",
+	"md":  "markdown",
+	"yml": "yaml",
+	"rb":  "ruby",
+}
+
+// grepRipgrep runs the search through rg --json when available. It returns
+// an error (and the caller falls back to grepWalk) if rg is missing or fails.
+func grepRipgrep(ctx context.Context, pattern, searchPath, fileType string, ignoreCase, filesOnly bool) ([]grepMatch, error) {
+	rg, err := exec.LookPath("rg")
+	if err != nil {
+		return nil, errNoRipgrep
+	}
+
+	args := ripgrepArgs(pattern, searchPath, fileType, ignoreCase)
+	out, err := exec.CommandContext(ctx, rg, args...).Output()
+	if err != nil {
+		// Exit status 1 means no matches; anything else is a real failure
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+			return nil, err
+		}
+	}
+
+	return parseRipgrepJSON(out, filesOnly), nil
+}
+
+// ripgrepArgs builds the rg command line. rg skips hidden and .gitignored
+// files itself; the type goes to -t, so it covers every extension rg
+// knows for it (-t yaml finds .yml too). A type rg doesn't know makes it
+// fail, and the walker's plain extension match is used instead.
+func ripgrepArgs(pattern, searchPath, fileType string, ignoreCase bool) []string {
+	args := []string{"--json", "--no-messages"}
+	for _, dir := range grepSkipDirs {
+		args = append(args, "-g", "!"+dir+"/")
+	}
+	if ignoreCase {
+		args = append(args, "-i")
+	}
+	if fileType != "" {
+		if t, ok := rgTypeAliases[fileType]; ok {
+			fileType = t
+		}
+		args = append(args, "-t", fileType)
+	}
+	return append(args, "-e", pattern, "--", searchPath)
+}
+
+// parseRipgrepJSON converts rg --json "match" events into grepMatches
+func parseRipgrepJSON(out []byte, filesOnly bool) []grepMatch {
+	var matches []grepMatch
+	seen := make(map[string]bool)
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		var event struct {
+			Type string `json:"type"`
+			Data struct {
+				Path struct {
+					Text string `json:"text"`
+				} `json:"path"`
+				Lines struct {
+					Text string `json:"text"`
+				} `json:"lines"`
+				LineNumber int `json:"line_number"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil || event.Type != "match" {
+			continue
+		}
+		if event.Data.Path.Text == "" {
+			continue // non-UTF-8 path, reported as bytes
+		}
+
+		file := filepath.Clean(event.Data.Path.Text)
+		if filesOnly {
+			if !seen[file] {
+				seen[file] = true
+				matches = append(matches, grepMatch{File: file})
+			}
+			continue
+		}
+		matches = append(matches, grepMatch{
+			File:    file,
+			Line:    event.Data.LineNumber,
+			Content: strings.TrimRight(event.Data.Lines.Text, "\r\n"),
+		})
 	}
+	return matches
+}
+
+// grepWalk is the pure-Go search used when rg is unavailable. Like rg it
+// skips hidden paths and what the root .gitignore ignores.
+func grepWalk(re *regexp.Regexp, searchPath, fileType string, filesOnly bool) []grepMatch {
+	ignore, _ := gitignore.Load(searchPath)
 
-	var matches []match
+	var matches []grepMatch
 	filepath.Walk(searchPath, func(path string, info os.FileInfo, err error) error {
-		if err != nil || info.IsDir() {
+		if err != nil {
 			return nil
 		}
-
-		// Skip hidden and vendor dirs
-		if strings.Contains(path, "/.") || strings.Contains(path, "/vendor/") ||
-			strings.Contains(path, "/node_modules/") || strings.Contains(path, "/.git/") {
+		skip := false
+		if path != searchPath {
+			skip = strings.HasPrefix(info.Name(), ".") || info.IsDir() && slices.Contains(grepSkipDirs, info.Name())
+			if rel, err := filepath.Rel(searchPath, path); err == nil && ignore.Match(rel, info.IsDir()) {
+				skip = true
+			}
+		}
+		if info.IsDir() {
+			if skip {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if skip {
 			return nil
 		}
 
@@ -740,11 +949,11 @@ func (s *Server) grepHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp
 			if re.MatchString(line) {
 				if filesOnly {
 					if !fileHasMatch {
-						matches = append(matches, match{File: path})
+						matches = append(matches, grepMatch{File: path})
 						fileHasMatch = true
 					}
 				} else {
-					matches = append(matches, match{
+					matches = append(matches, grepMatch{
 						File:    path,
 						Line:    lineNum,
 						Content: line,
@@ -754,9 +963,7 @@ func (s *Server) grepHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp
 		}
 		return nil
 	})
-
-	data, _ := json.MarshalIndent(matches, "", "  ")
-	return mcp.NewToolResultText(string(data)), nil
+	return matches
 }
 
 func (s *Server) treeHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -772,6 +979,11 @@ func (s *Server) treeHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp
 		maxDepth = int(d)
 	}
 
+	var ignore *gitignore.Matcher
+	if noGitignore, _ := args["no_gitignore"].(bool); !noGitignore {
+		ignore, _ = gitignore.Load(path)
+	}
+
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("Directory: %s\n\n", path))
 
@@ -796,6 +1008,9 @@ func (s *Server) treeHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp
 			if name == "node_modules" || name == "vendor" || name == "__pycache__" {
 				continue
 			}
+			if rel, err := filepath.Rel(path, filepath.Join(dir, name)); err == nil && ignore.Match(rel, e.IsDir()) {
+				continue
+			}
 			filtered = append(filtered, e)
 		}
 
@@ -859,12 +1074,14 @@ func (s *Server) symbolsHandler(ctx context.Context, req mcp.CallToolRequest) (*
 			return nil
 		}
 
-		if strings.Contains(p, "/.") || strings.Contains(p, "/vendor/") {
+		if strings.Contains(p, "/.") || strings.Contains(p, "/vendor/") || strings.Contains(p, "/node_modules/") {
 			return nil
 		}
 
 		ext := filepath.Ext(p)
-		if ext != ".go" && ext != ".py" {
+		switch ext {
+		case ".go", ".py", ".js", ".jsx", ".ts", ".tsx", ".rs":
+		default:
 			return nil
 		}
 
@@ -879,9 +1096,11 @@ func (s *Server) symbolsHandler(ctx context.Context, req mcp.CallToolRequest) (*
 
 		for scanner.Scan() {
 			lineNum++
-			line := strings.TrimSpace(scanner.Text())
+			raw := scanner.Text()
+			line := strings.TrimSpace(raw)
 
 			var name, kind string
+			var declExported bool
 
 			switch ext {
 			case ".go":
@@ -896,14 +1115,21 @@ func (s *Server) symbolsHandler(ctx context.Context, req mcp.CallToolRequest) (*
 				} else if m := pyClassPattern.FindStringSubmatch(line); m != nil {
 					name, kind = m[1], "class"
 				}
+			case ".js", ".jsx", ".ts", ".tsx":
+				name, kind, declExported, _ = analyze.JSSymbol(raw)
+			case ".rs":
+				name, kind, declExported, _ = analyze.RustSymbol(raw)
 			}
 
 			if name != "" {
 				exported := false
-				if ext == ".go" {
+				switch ext {
+				case ".go":
 					exported = name[0] >= 'A' && name[0] <= 'Z'
-				} else {
+				case ".py":
 					exported = !strings.HasPrefix(name, "_")
+				default:
+					exported = declExported
 				}
 
 				if exportedOnly && !exported {
@@ -1053,6 +1279,16 @@ func (s *Server) contextHandler(ctx context.Context, req mcp.CallToolRequest) (*
 
 	sb.WriteString(fmt.Sprintf("**Files:** %d files, %d directories\n\n", fileCount, dirCount))
 
+	// Team notes from CONTEXT.md ('clood context --save')
+	if notes := projectctx.ReadNotes(path); notes != "" {
+		if maxChars := maxTokens * 2; len(notes) > maxChars {
+			notes = notes[:maxChars] + "\n...(truncated)"
+		}
+		sb.WriteString("## Project Notes (" + projectctx.NotesFile + ")\n\n")
+		sb.WriteString(notes)
+		sb.WriteString("\n\n")
+	}
+
 	// Include README if present
 	readmeNames := []string{"README.md", "README", "readme.md"}
 	for _, name := range readmeNames {
@@ -1079,9 +1315,87 @@ func (s *Server) contextHandler(ctx context.Context, req mcp.CallToolRequest) (*
 		}
 	}
 
+	// Go package docs, in whatever budget the README left over
+	if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+		if budget := maxTokens*4 - sb.Len(); budget > 0 {
+			if docs := goPackageDocs(ctx, path, budget); docs != "" {
+				sb.WriteString("\n## Go Packages\n\n")
+				sb.WriteString(docs)
+			}
+		}
+	}
+
 	return mcp.NewToolResultText(sb.String()), nil
 }
 
+// goDocTimeout bounds the whole go list + go doc pass in clood_context
+const goDocTimeout = 15 * time.Second
+
+// goPackageDocs returns `go doc -short` output for each package in the
+// module at dir, truncated to maxChars. go doc only takes one package at a
+// time, so packages come from `go list ./...`. Errors yield what was
+// gathered so far.
+func goPackageDocs(ctx context.Context, dir string, maxChars int) string {
+	ctx, cancel := context.WithTimeout(ctx, goDocTimeout)
+	defer cancel()
+
+	list := exec.CommandContext(ctx, "go", "list", "-f", "{{.ImportPath}}\t{{.Dir}}\t{{.Doc}}", "./...")
+	list.Dir = dir
+	out, err := list.Output()
+	if err != nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		parts := strings.SplitN(line, "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		importPath, pkgDir, synopsis := parts[0], parts[1], parts[2]
+
+		var entry strings.Builder
+		entry.WriteString(fmt.Sprintf("### %s\n", importPath))
+		if synopsis != "" {
+			entry.WriteString(synopsis + "\n")
+		}
+		doc := exec.CommandContext(ctx, "go", "doc", "-short", pkgDir)
+		doc.Dir = dir
+		if symbols, err := doc.Output(); err == nil && len(symbols) > 0 {
+			entry.WriteString("```\n")
+			entry.Write(symbols)
+			entry.WriteString("```\n")
+		}
+		entry.WriteString("\n")
+
+		if sb.Len()+entry.Len() > maxChars {
+			remaining := maxChars - sb.Len()
+			if remaining > 0 {
+				sb.WriteString(truncateUTF8(entry.String(), remaining))
+			}
+			sb.WriteString("\n...(truncated)\n")
+			break
+		}
+		sb.WriteString(entry.String())
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return sb.String()
+}
+
+// truncateUTF8 cuts s to at most n bytes without splitting a character
+func truncateUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
 func (s *Server) capabilitiesHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	// Check if Ollama is available
 	ollamaAvailable := false
@@ -1106,7 +1420,7 @@ func (s *Server) capabilitiesHandler(ctx context.Context, req mcp.CallToolReques
 			"clood_imports - Dependency analysis (0 network, 0 tokens)",
 			"clood_context - Project summary (0 network, 0 tokens)",
 			"clood_system - Hardware detection (0 network, 0 tokens)",
-			"clood_analyze - Static analysis for Go projects (0 network, 0 tokens)",
+			"clood_analyze - Static analysis for Go and Python projects (0 network, 0 tokens)",
 		},
 		"local_ollama_tools": []string{
 			"clood_ask - Query local LLM",
@@ -1135,11 +1449,23 @@ func (s *Server) analyzeHandler(ctx context.Context, req mcp.CallToolRequest) (*
 		runTests = rt
 	}
 
+	lang := analyze.LangAuto
+	if l, ok := args["lang"].(string); ok {
+		parsed, err := analyze.ParseLanguage(l)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		lang = parsed
+	}
+
 	// Run static analysis
-	analysis, err := analyze.RunAnalysis(path, runTests)
+	analysis, err := analyze.RunAnalysisLang(path, lang, runTests)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Analysis failed: %v", err)), nil
 	}
+	if dupes, _ := args["check_dupes"].(bool); dupes {
+		analysis.CheckDuplicates(analyze.DefaultDupeMinLines)
+	}
 
 	// Return formatted for Claude consumption
 	return mcp.NewToolResultText(analysis.FormatForClaude()), nil
@@ -1158,11 +1484,12 @@ func (s *Server) preflightHandler(ctx context.Context, req mcp.CallToolRequest)
 	var onlineHosts []string
 	var availableModels []string
 
+	var statuses []*hosts.HostStatus
 	cfg, _ := config.Load()
 	if cfg != nil {
 		mgr := hosts.NewManager()
 		mgr.AddHosts(cfg.Hosts)
-		statuses := mgr.CheckAllHosts()
+		statuses = mgr.CheckAllHosts()
 		for _, st := range statuses {
 			if st.Online {
 				ollamaStatus = "ONLINE"
@@ -1174,6 +1501,14 @@ func (s *Server) preflightHandler(ctx context.Context, req mcp.CallToolRequest)
 		}
 	}
 
+	storage := config.DefaultConfig().Storage
+	if cfg != nil {
+		storage = cfg.Storage
+	}
+	if asJSON, _ := req.GetArguments()["json"].(bool); asJSON {
+		return preflightJSONResult(preflightCapabilities(cwd, statuses, cloodStorageReport(storage))), nil
+	}
+
 	// Build preflight report
 	var sb strings.Builder
 	sb.WriteString("🚀 CLOOD PREFLIGHT CHECK\n")
@@ -1202,6 +1537,12 @@ func (s *Server) preflightHandler(ctx context.Context, req mcp.CallToolRequest)
 	}
 	sb.WriteString("\n")
 
+	sb.WriteString("💾 CLOOD STORAGE:\n")
+	for _, line := range cloodStorageReport(storage) {
+		sb.WriteString("   " + line + "\n")
+	}
+	sb.WriteString("\n")
+
 	sb.WriteString("⚠️  BEFORE WEB SEARCH:\n")
 	sb.WriteString("   Call clood_should_search_web first - it will redirect to local tools if possible.\n\n")
 
@@ -1215,6 +1556,71 @@ func (s *Server) preflightHandler(ctx context.Context, req mcp.CallToolRequest)
 	return mcp.NewToolResultText(sb.String()), nil
 }
 
+// cloodStorageReport sizes the gallery and memory store under ~/.clood and
+// flags either one past its threshold, since a full disk makes image
+// generation fail without an obvious error
+func cloodStorageReport(limits config.StorageConfig) []string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return []string{"Unable to locate home directory: " + err.Error()}
+	}
+	cloodDir := filepath.Join(home, ".clood")
+
+	checks := []struct {
+		label string
+		path  string
+		limit int64
+	}{
+		{"Gallery", filepath.Join(cloodDir, "gallery"), int64(limits.GalleryWarnGB * (1 << 30))},
+		{"Memory", filepath.Join(cloodDir, "memory.db"), int64(limits.MemoryWarnMB * (1 << 20))},
+	}
+
+	var lines []string
+	for _, c := range checks {
+		size, err := pathSize(c.path)
+		if os.IsNotExist(err) {
+			lines = append(lines, fmt.Sprintf("%s: none (%s)", c.label, c.path))
+			continue
+		}
+		if err != nil {
+			lines = append(lines, fmt.Sprintf("%s: unreadable (%v)", c.label, err))
+			continue
+		}
+
+		line := fmt.Sprintf("%s: %s (%s)", c.label, hosts.FormatSize(size), c.path)
+		if c.limit > 0 && size > c.limit {
+			line = fmt.Sprintf("⚠️  %s: %s exceeds %s (%s) - clean up before generating more",
+				c.label, hosts.FormatSize(size), hosts.FormatSize(c.limit), c.path)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// pathSize returns the size of a file, or the total of all files under a
+// directory
+func pathSize(path string) (int64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+	if !info.IsDir() {
+		return info.Size(), nil
+	}
+
+	var total int64
+	err = filepath.Walk(path, func(_ string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return nil // Skip entries we can't read
+		}
+		if !fi.IsDir() {
+			total += fi.Size()
+		}
+		return nil
+	})
+	return total, err
+}
+
 func (s *Server) shouldSearchWebHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	args := req.GetArguments()
 
@@ -1274,6 +1680,7 @@ func (s *Server) shouldSearchWebHandler(ctx context.Context, req mcp.CallToolReq
 					"instruction": fmt.Sprintf("Instead of web search, use: %s", cp.tool),
 					"original_query": query,
 				}
+				logSearchGate(audit.SearchGateDecision{Query: query, Verdict: "USE_LOCAL_TOOL", Pattern: pattern, Tool: cp.tool, Reason: cp.reason})
 				data, _ := json.MarshalIndent(result, "", "  ")
 				return mcp.NewToolResultText(string(data)), nil
 			}
@@ -1310,6 +1717,7 @@ func (s *Server) shouldSearchWebHandler(ctx context.Context, req mcp.CallToolReq
 					"instruction": "Use clood_ask to query local Ollama instead of web search",
 					"original_query": query,
 				}
+				logSearchGate(audit.SearchGateDecision{Query: query, Verdict: "USE_LOCAL_LLM", Pattern: pattern, Tool: "clood_ask", Reason: "General coding question - local LLM can help"})
 				data, _ := json.MarshalIndent(result, "", "  ")
 				return mcp.NewToolResultText(string(data)), nil
 			}
@@ -1324,12 +1732,15 @@ func (s *Server) shouldSearchWebHandler(ctx context.Context, req mcp.CallToolReq
 	}
 
 	reason := "Query appears to need external/current information"
+	matched := ""
 	for _, pattern := range externalPatterns {
 		if strings.Contains(queryLower, pattern) {
 			reason = fmt.Sprintf("Query contains '%s' - likely needs external source", pattern)
+			matched = pattern
 			break
 		}
 	}
+	logSearchGate(audit.SearchGateDecision{Query: query, Verdict: "WEB_SEARCH_APPROVED", Pattern: matched, Reason: reason})
 
 	result := map[string]interface{}{
 		"verdict":     "WEB_SEARCH_APPROVED",
@@ -1341,6 +1752,14 @@ func (s *Server) shouldSearchWebHandler(ctx context.Context, req mcp.CallToolReq
 	return mcp.NewToolResultText(string(data)), nil
 }
 
+// logSearchGate records a should_search_web verdict for 'clood audit
+// search-gate'. Failures are ignored: the gate must answer regardless.
+func logSearchGate(d audit.SearchGateDecision) {
+	if path, err := audit.SearchGatePath(); err == nil {
+		audit.LogSearchGate(path, d)
+	}
+}
+
 // callOllama sends a prompt to Ollama and returns the response
 func callOllama(baseURL, model, prompt string) (string, error) {
 	reqBody := map[string]interface{}{
@@ -1380,6 +1799,99 @@ func callOllama(baseURL, model, prompt string) (string, error) {
 	return result.Response, nil
 }
 
+// streamOllamaWithProgress streams a generation and forwards each chunk to the
+// MCP client as a notifications/progress message. The full response is still
+// returned so the final tool result is identical to buffered mode.
+//
+// Progress is only sent when the caller supplied a progressToken and the
+// transport accepts notifications; otherwise it silently degrades to buffering.
+func (s *Server) streamOllamaWithProgress(ctx context.Context, req mcp.CallToolRequest, baseURL, model, prompt string) (string, error) {
+	var progressToken mcp.ProgressToken
+	if req.Params.Meta != nil {
+		progressToken = req.Params.Meta.ProgressToken
+	}
+	srv := server.ServerFromContext(ctx)
+	canNotify := progressToken != nil && srv != nil
+
+	var full strings.Builder
+	chunks := 0
+	err := streamOllama(ctx, baseURL, model, prompt, func(chunk string) {
+		full.WriteString(chunk)
+		chunks++
+		if !canNotify {
+			return
+		}
+		err := srv.SendNotificationToClient(ctx, "notifications/progress", map[string]any{
+			"progressToken": progressToken,
+			"progress":      chunks,
+			"message":       chunk,
+		})
+		if err != nil {
+			// Transport can't flush progressively - keep buffering
+			canNotify = false
+		}
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return full.String(), nil
+}
+
+// streamOllama sends a streaming prompt to Ollama, invoking onChunk for each
+// partial response until the model reports done or ctx is cancelled
+func streamOllama(ctx context.Context, baseURL, model, prompt string, onChunk func(string)) error {
+	reqBody := map[string]interface{}{
+		"model":  model,
+		"prompt": prompt,
+		"stream": true,
+	}
+
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/api/generate", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	// No client timeout - streams can legitimately run long; ctx handles cancellation
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("ollama returned status %d", resp.StatusCode)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	buf := make([]byte, 0, 64*1024)
+	scanner.Buffer(buf, 1024*1024)
+
+	for scanner.Scan() {
+		var chunk struct {
+			Response string `json:"response"`
+			Done     bool   `json:"done"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &chunk); err != nil {
+			continue
+		}
+		if chunk.Response != "" {
+			onChunk(chunk.Response)
+		}
+		if chunk.Done {
+			break
+		}
+	}
+
+	return scanner.Err()
+}
+
 // =============================================================================
 // MEMORY TOOLS - Persistent knowledge storage
 // =============================================================================
@@ -1398,6 +1910,7 @@ Cost: ZERO network, ZERO tokens (local JSON storage).`),
 		mcp.WithString("content", mcp.Required(), mcp.Description("The fact or note to remember")),
 		mcp.WithString("tags", mcp.Description("Comma-separated tags for organization (e.g., 'project,decision')")),
 		mcp.WithString("context", mcp.Description("Optional context (e.g., current project or file)")),
+		mcp.WithString("project", mcp.Description("Project scope so recall in other projects stays uncluttered (e.g., 'clood')")),
 	)
 }
 
@@ -1408,10 +1921,17 @@ func (s *Server) memoryRecallTool() mcp.Tool {
 Find previously stored facts and notes.
 Returns matching memories sorted by most recent.
 
-Cost: ZERO network, ZERO tokens, instant.`),
-		mcp.WithString("query", mcp.Description("Keyword to search in content")),
+With semantic=true, ranks memories by meaning instead of keywords using
+local Ollama embeddings (e.g. "auth bug" finds "login token expired").
+Embeddings are cached per memory, so only new memories are embedded.
+
+Cost: ZERO network, ZERO tokens, instant (semantic: LOCAL embeddings).`),
+		mcp.WithString("query", mcp.Description("Keyword to search in content (or natural-language query with semantic)")),
 		mcp.WithString("tag", mcp.Description("Filter by specific tag")),
+		mcp.WithString("project", mcp.Description("Only recall memories scoped to this project")),
 		mcp.WithNumber("limit", mcp.Description("Max results to return (default: 10)")),
+		mcp.WithBoolean("semantic", mcp.Description("Rank by embedding similarity instead of keyword match (default: false)")),
+		mcp.WithString("embed_model", mcp.Description("Ollama embedding model for semantic search (default: nomic-embed-text)")),
 	)
 }
 
@@ -1426,19 +1946,23 @@ Shows what's been remembered. Use to:
 
 Cost: ZERO network, ZERO tokens, instant.`),
 		mcp.WithString("tag", mcp.Description("Filter by specific tag")),
+		mcp.WithString("project", mcp.Description("Only list memories scoped to this project")),
 		mcp.WithNumber("limit", mcp.Description("Max results to return (default: 20)")),
 	)
 }
 
 func (s *Server) memoryForgetTool() mcp.Tool {
 	return mcp.NewTool("clood_memory_forget",
-		mcp.WithDescription(`🗑️ Remove a memory by ID.
+		mcp.WithDescription(`🗑️ Remove a memory by ID, or every memory with a tag.
 
-Permanently delete a stored memory.
-Use clood_memory_list to find memory IDs.
+Permanently delete stored memories.
+Use clood_memory_list to find memory IDs and tags.
+Pass tag to clear a finished project's memories in one call.
 
 Cost: ZERO network, ZERO tokens, instant.`),
-		mcp.WithString("id", mcp.Required(), mcp.Description("The memory ID to forget")),
+		mcp.WithString("id", mcp.Description("The memory ID to forget")),
+		mcp.WithString("tag", mcp.Description("Forget every memory with this tag instead of one ID")),
+		dryRunOption(),
 	)
 }
 
@@ -1464,14 +1988,16 @@ func (s *Server) memoryStoreHandler(ctx context.Context, req mcp.CallToolRequest
 	}
 
 	context, _ := args["context"].(string)
+	project, _ := args["project"].(string)
 
 	// Store the memory
 	store, err := memory.NewStore()
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to open memory store: %v", err)), nil
 	}
+	defer store.Close()
 
-	mem, err := store.Store(content, tags, context)
+	mem, err := store.Store(content, tags, context, project)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to store memory: %v", err)), nil
 	}
@@ -1481,6 +2007,7 @@ func (s *Server) memoryStoreHandler(ctx context.Context, req mcp.CallToolRequest
 		"id":      mem.ID,
 		"content": mem.Content,
 		"tags":    mem.Tags,
+		"project": mem.Project,
 		"total":   store.Count(),
 	}
 
@@ -1493,46 +2020,102 @@ func (s *Server) memoryRecallHandler(ctx context.Context, req mcp.CallToolReques
 
 	query, _ := args["query"].(string)
 	tag, _ := args["tag"].(string)
+	project, _ := args["project"].(string)
 
 	limit := 10
 	if l, ok := args["limit"].(float64); ok {
 		limit = int(l)
 	}
 
+	semantic, _ := args["semantic"].(bool)
+	embedModel, _ := args["embed_model"].(string)
+	if embedModel == "" {
+		embedModel = defaultEmbedModel
+	}
+
 	store, err := memory.NewStore()
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to open memory store: %v", err)), nil
 	}
-
-	memories := store.Recall(query, tag, limit)
+	defer store.Close()
 
 	type memResult struct {
 		ID        string   `json:"id"`
 		Content   string   `json:"content"`
 		Tags      []string `json:"tags,omitempty"`
 		Context   string   `json:"context,omitempty"`
+		Project   string   `json:"project,omitempty"`
 		CreatedAt string   `json:"created_at"`
+		Score     *float64 `json:"score,omitempty"`
 	}
 
 	var results []memResult
-	for _, m := range memories {
-		results = append(results, memResult{
-			ID:        m.ID,
-			Content:   m.Content,
-			Tags:      m.Tags,
-			Context:   m.Context,
-			CreatedAt: m.CreatedAt.Format(time.RFC3339),
+	if semantic {
+		client, model := s.findEmbedClient(embedModel)
+		if client == nil {
+			return mcp.NewToolResultError(fmt.Sprintf("No online host has embedding model %s (try: ollama pull %s)", embedModel, embedModel)), nil
+		}
+
+		scored, err := store.RecallSemantic(query, tag, project, model, limit, func(text string) ([]float64, error) {
+			return client.Embeddings(model, text)
 		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Semantic recall failed: %v", err)), nil
+		}
+
+		for _, m := range scored {
+			score := m.Score
+			results = append(results, memResult{
+				ID:        m.ID,
+				Content:   m.Content,
+				Tags:      m.Tags,
+				Context:   m.Context,
+				Project:   m.Project,
+				CreatedAt: m.CreatedAt.Format(time.RFC3339),
+				Score:     &score,
+			})
+		}
+	} else {
+		for _, m := range store.Recall(query, tag, project, limit) {
+			results = append(results, memResult{
+				ID:        m.ID,
+				Content:   m.Content,
+				Tags:      m.Tags,
+				Context:   m.Context,
+				Project:   m.Project,
+				CreatedAt: m.CreatedAt.Format(time.RFC3339),
+			})
+		}
 	}
 
 	data, _ := json.MarshalIndent(results, "", "  ")
 	return mcp.NewToolResultText(string(data)), nil
 }
 
+// defaultEmbedModel is the Ollama model used for semantic memory recall
+const defaultEmbedModel = "nomic-embed-text"
+
+// findEmbedClient returns a client for the best host serving model, along
+// with the model's name as that host reports it (Ollama appends ":latest").
+func (s *Server) findEmbedClient(model string) (*ollama.Client, string) {
+	candidates := []string{model}
+	if !strings.Contains(model, ":") {
+		candidates = append(candidates, model+":latest")
+	}
+
+	for _, name := range candidates {
+		if found := s.hostMgr.FindModel(name); len(found) > 0 {
+			return s.hostMgr.GetClient(found[0].Host.Name), name
+		}
+	}
+	return nil, ""
+}
+
 func (s *Server) memoryListHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	args := req.GetArguments()
 
 	tag, _ := args["tag"].(string)
+	project, _ := args["project"].(string)
 
 	limit := 20
 	if l, ok := args["limit"].(float64); ok {
@@ -1543,8 +2126,9 @@ func (s *Server) memoryListHandler(ctx context.Context, req mcp.CallToolRequest)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to open memory store: %v", err)), nil
 	}
+	defer store.Close()
 
-	memories := store.List(tag, limit)
+	memories := store.List(tag, project, limit)
 	allTags := store.Tags()
 
 	type memResult struct {
@@ -1552,6 +2136,7 @@ func (s *Server) memoryListHandler(ctx context.Context, req mcp.CallToolRequest)
 		Content   string   `json:"content"`
 		Tags      []string `json:"tags,omitempty"`
 		Context   string   `json:"context,omitempty"`
+		Project   string   `json:"project,omitempty"`
 		CreatedAt string   `json:"created_at"`
 	}
 
@@ -1562,6 +2147,7 @@ func (s *Server) memoryListHandler(ctx context.Context, req mcp.CallToolRequest)
 			Content:   m.Content,
 			Tags:      m.Tags,
 			Context:   m.Context,
+			Project:   m.Project,
 			CreatedAt: m.CreatedAt.Format(time.RFC3339),
 		})
 	}
@@ -1571,6 +2157,7 @@ func (s *Server) memoryListHandler(ctx context.Context, req mcp.CallToolRequest)
 		"count":      len(results),
 		"total":      store.Count(),
 		"all_tags":   allTags,
+		"projects":   store.Projects(),
 	}
 
 	data, _ := json.MarshalIndent(response, "", "  ")
@@ -1580,15 +2167,46 @@ func (s *Server) memoryListHandler(ctx context.Context, req mcp.CallToolRequest)
 func (s *Server) memoryForgetHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	args := req.GetArguments()
 
-	id, ok := args["id"].(string)
-	if !ok || id == "" {
-		return mcp.NewToolResultError("id is required"), nil
+	id, _ := args["id"].(string)
+	tag, _ := args["tag"].(string)
+	if (id == "") == (tag == "") {
+		return mcp.NewToolResultError("give exactly one of id or tag"), nil
 	}
 
 	store, err := memory.NewStore()
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to open memory store: %v", err)), nil
 	}
+	defer store.Close()
+
+	if isDryRun(args) {
+		var ids []string
+		for _, mem := range store.List(tag, "", 0) {
+			if id == "" || mem.ID == id {
+				ids = append(ids, mem.ID)
+			}
+		}
+		return dryRunResult(fmt.Sprintf("forget %d memories", len(ids)), map[string]interface{}{
+			"id":        id,
+			"tag":       tag,
+			"ids":       ids,
+			"remaining": store.Count() - len(ids),
+		}), nil
+	}
+
+	if tag != "" {
+		n, err := store.ForgetByTag(tag)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to forget: %v", err)), nil
+		}
+		data, _ := json.MarshalIndent(map[string]interface{}{
+			"status":    "forgotten",
+			"tag":       tag,
+			"count":     n,
+			"remaining": store.Count(),
+		}, "", "  ")
+		return mcp.NewToolResultText(string(data)), nil
+	}
 
 	if err := store.Forget(id); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to forget: %v", err)), nil
@@ -1613,12 +2231,14 @@ func (s *Server) gitDiffTool() mcp.Tool {
 		mcp.WithDescription(`📝 Show git diff for files, commits, or staged changes.
 
 View what has changed in the repository.
-Supports specific files, commits, staged vs unstaged.
+Supports specific files, commits, staged vs unstaged, and branch
+comparison (current...branch) for pre-PR review.
 
 Cost: ZERO network, ZERO tokens, instant.`),
 		mcp.WithString("path", mcp.Description("Repository path (default: current directory)")),
 		mcp.WithString("file", mcp.Description("Specific file to diff")),
 		mcp.WithString("commit", mcp.Description("Compare against specific commit (e.g., HEAD~1)")),
+		mcp.WithString("branch", mcp.Description("Show what merging this branch would change (git diff current...branch)")),
 		mcp.WithBoolean("staged", mcp.Description("Show only staged changes")),
 		mcp.WithBoolean("stat", mcp.Description("Show summary stats instead of full diff")),
 	)
@@ -1631,11 +2251,19 @@ func (s *Server) gitBlameTool() mcp.Tool {
 Shows who last modified each line, when, and in which commit.
 Useful for understanding code history and ownership.
 
-Cost: ZERO network, ZERO tokens, instant.`),
+With link_issues, issue references in each commit message (#123,
+fixes #456, owner/repo#789) are looked up on GitHub and returned as an
+issues array per line, with title and open/closed state: who changed the
+code and why. Uses GITHUB_TOKEN when set.
+
+Cost: ZERO tokens. Instant, or one GitHub request per referenced issue
+with link_issues.`),
 		mcp.WithString("file", mcp.Required(), mcp.Description("File to blame")),
 		mcp.WithString("path", mcp.Description("Repository path (default: current directory)")),
 		mcp.WithNumber("start_line", mcp.Description("Start line number")),
 		mcp.WithNumber("end_line", mcp.Description("End line number")),
+		mcp.WithBoolean("link_issues", mcp.Description("Look up GitHub issues referenced by each line's commit message")),
+		mcp.WithString("repo", mcp.Description("owner/repo for bare #N references (default: the origin remote)")),
 	)
 }
 
@@ -1646,13 +2274,24 @@ func (s *Server) gitLogTool() mcp.Tool {
 View recent commits with author, date, message.
 Filter by author, date range, or search in messages.
 
+Set format for extra parsing into each entry's "parsed" map:
+- coauthors: Co-authored-by trailers
+- merge-commits: merge commits only, with merged branch and PR number
+- conventional: conventional commit type/scope, validated
+
+Set changelog to get a Markdown CHANGELOG section instead, grouped by
+conventional commit type (non-conventional commits under "Other Changes").
+since may be a tag (e.g. 'v0.1.0') to take the commits after it.
+
 Cost: ZERO network, ZERO tokens, instant.`),
 		mcp.WithString("path", mcp.Description("Repository path (default: current directory)")),
 		mcp.WithNumber("count", mcp.Description("Number of commits to show (default: 20)")),
 		mcp.WithString("author", mcp.Description("Filter by author name/email")),
-		mcp.WithString("since", mcp.Description("Show commits since date (e.g., '2024-01-01')")),
+		mcp.WithString("since", mcp.Description("Show commits since date (e.g., '2024-01-01') or after a tag/commit (e.g., 'v0.1.0')")),
 		mcp.WithString("grep", mcp.Description("Search in commit messages")),
 		mcp.WithString("file", mcp.Description("Show only commits affecting this file")),
+		mcp.WithString("format", mcp.Description("Extra parsing: coauthors, merge-commits, or conventional")),
+		mcp.WithBoolean("changelog", mcp.Description("Return a Markdown CHANGELOG section (all matching commits unless count is set)")),
 	)
 }
 
@@ -1671,14 +2310,26 @@ Cost: ZERO network, ZERO tokens, instant.`),
 
 func (s *Server) gitStashTool() mcp.Tool {
 	return mcp.NewTool("clood_git_stash",
-		mcp.WithDescription(`📦 List or show stash entries.
+		mcp.WithDescription(`📦 List, show, create and restore stash entries.
+
+Actions:
+- list (default): stash entries as JSON
+- show: the diff of the stash at index
+- push: shelve the working tree's changes, with an optional message
+- pop / apply: restore the stash at index (pop also drops it)
 
-View stashed changes without applying them.
-Use index to show specific stash content.
+push, pop and apply change the working tree, so like run_command's
+allow-list they are off until enabled: set mcp.git_write_tools: true in
+~/.config/clood/config.yaml.
 
 Cost: ZERO network, ZERO tokens, instant.`),
 		mcp.WithString("path", mcp.Description("Repository path (default: current directory)")),
+		mcp.WithString("action", mcp.Description("list, show, push, pop or apply (default: list, or show when show is given)")),
+		mcp.WithNumber("index", mcp.Description("Stash index for show, pop and apply (default: 0)")),
 		mcp.WithNumber("show", mcp.Description("Show diff for stash at this index")),
+		mcp.WithString("message", mcp.Description("Message for push")),
+		mcp.WithBoolean("include_untracked", mcp.Description("push: also stash untracked files")),
+		dryRunOption(),
 	)
 }
 
@@ -1702,6 +2353,9 @@ func (s *Server) gitDiffHandler(ctx context.Context, req mcp.CallToolRequest) (*
 	if commit, ok := args["commit"].(string); ok {
 		opts.Commit = commit
 	}
+	if branch, ok := args["branch"].(string); ok {
+		opts.Branch = branch
+	}
 	if staged, ok := args["staged"].(bool); ok {
 		opts.Staged = staged
 	}
@@ -1751,6 +2405,20 @@ func (s *Server) gitBlameHandler(ctx context.Context, req mcp.CallToolRequest) (
 		return mcp.NewToolResultError(fmt.Sprintf("git blame failed: %v", err)), nil
 	}
 
+	if link, _ := args["link_issues"].(bool); link {
+		repo, _ := args["repo"].(string)
+		if repo == "" {
+			// Without a GitHub origin only owner/repo#N references resolve
+			repo, _ = git.GitHubRepo(path)
+		}
+		linked, err := linkBlameIssues(ctx, path, repo, lines)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("linking issues failed: %v", err)), nil
+		}
+		data, _ := json.MarshalIndent(linked, "", "  ")
+		return mcp.NewToolResultText(string(data)), nil
+	}
+
 	data, _ := json.MarshalIndent(lines, "", "  ")
 	return mcp.NewToolResultText(string(data)), nil
 }
@@ -1773,8 +2441,9 @@ func (s *Server) gitLogHandler(ctx context.Context, req mcp.CallToolRequest) (*m
 	if author, ok := args["author"].(string); ok {
 		opts.Author = author
 	}
-	if since, ok := args["since"].(string); ok {
-		opts.Since = since
+	since, _ := args["since"].(string)
+	if since != "" {
+		opts.SetSince(since)
 	}
 	if grep, ok := args["grep"].(string); ok {
 		opts.Grep = grep
@@ -1782,11 +2451,22 @@ func (s *Server) gitLogHandler(ctx context.Context, req mcp.CallToolRequest) (*m
 	if file, ok := args["file"].(string); ok {
 		opts.File = file
 	}
+	if format, ok := args["format"].(string); ok {
+		opts.Format = format
+	}
+
+	changelog, _ := args["changelog"].(bool)
+	if _, ok := args["count"]; changelog && !ok {
+		opts.Count = -1
+	}
 
 	entries, err := git.Log(opts)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("git log failed: %v", err)), nil
 	}
+	if changelog {
+		return mcp.NewToolResultText(git.GenerateChangelog(entries, since)), nil
+	}
 
 	data, _ := json.MarshalIndent(entries, "", "  ")
 	return mcp.NewToolResultText(string(data)), nil
@@ -1822,16 +2502,35 @@ func (s *Server) gitStashHandler(ctx context.Context, req mcp.CallToolRequest) (
 		path = p
 	}
 
-	// If "show" is specified, show that stash entry's diff
-	if showIdx, ok := args["show"].(float64); ok {
-		diff, err := git.StashShow(path, int(showIdx))
+	action, _ := args["action"].(string)
+	index := 0
+	if idx, ok := args["index"].(float64); ok {
+		index = int(idx)
+	}
+	// "show" predates action and still works on its own
+	if showIdx, ok := args["show"].(float64); ok && (action == "" || action == "show") {
+		action = "show"
+		index = int(showIdx)
+	}
+	if index < 0 {
+		return mcp.NewToolResultError("index must be 0 or more"), nil
+	}
+
+	switch action {
+	case "", "list":
+	case "show":
+		diff, err := git.StashShow(path, index)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("git stash show failed: %v", err)), nil
 		}
 		return mcp.NewToolResultText(diff), nil
+	case "push", "pop", "apply":
+		return gitStashWrite(args, path, action, index)
+	default:
+		return mcp.NewToolResultError("unknown action " + action + " (want list, show, push, pop or apply)"), nil
 	}
 
-	// Otherwise list all stashes
+	// List all stashes
 	entries, err := git.Stash(path)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("git stash list failed: %v", err)), nil
@@ -1845,6 +2544,46 @@ func (s *Server) gitStashHandler(ctx context.Context, req mcp.CallToolRequest) (
 	return mcp.NewToolResultText(string(data)), nil
 }
 
+// gitStashWrite runs the stash actions that change the working tree, once
+// config allows them
+func gitStashWrite(args map[string]interface{}, path, action string, index int) (*mcp.CallToolResult, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return mcp.NewToolResultError("Error loading config: " + err.Error()), nil
+	}
+	if !cfg.MCP.GitWriteTools {
+		return mcp.NewToolResultError(fmt.Sprintf("git stash %s is disabled: it changes the working tree. Set mcp.git_write_tools: true in %s to allow it.", action, config.ConfigPath())), nil
+	}
+
+	message, _ := args["message"].(string)
+	includeUntracked, _ := args["include_untracked"].(bool)
+	if isDryRun(args) {
+		params := map[string]interface{}{"path": path}
+		if action == "push" {
+			params["message"] = message
+			params["include_untracked"] = includeUntracked
+		} else {
+			params["index"] = index
+		}
+		return dryRunResult("git stash "+action, params), nil
+	}
+
+	var out string
+	if action == "push" {
+		out, err = git.StashPush(path, message, includeUntracked)
+	} else {
+		out, err = git.StashApply(path, index, action == "pop")
+	}
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	out = strings.TrimSpace(out)
+	if out == "" {
+		out = "git stash " + action + " done"
+	}
+	return mcp.NewToolResultText(out), nil
+}
+
 // =============================================================================
 // SQLITE TOOLS - Database query capabilities
 // =============================================================================
@@ -1856,9 +2595,14 @@ func (s *Server) sqliteQueryTool() mcp.Tool {
 Query local SQLite databases and get JSON results.
 Only SELECT, PRAGMA, and EXPLAIN queries are allowed (read-only).
 
+Bind values with ? placeholders and params instead of quoting them into
+the query: query "SELECT * FROM users WHERE name = ? AND age > ?" with
+params ["O'Brien", 30].
+
 Cost: ZERO network, ZERO tokens, instant.`),
 		mcp.WithString("database", mcp.Required(), mcp.Description("Path to the SQLite database file")),
-		mcp.WithString("query", mcp.Required(), mcp.Description("SQL SELECT query to execute")),
+		mcp.WithString("query", mcp.Required(), mcp.Description("SQL SELECT query to execute, with ? placeholders for params")),
+		mcp.WithArray("params", mcp.Description("Values bound to the query's ? placeholders, in order (strings, numbers, booleans or null)")),
 	)
 }
 
@@ -1902,7 +2646,20 @@ func (s *Server) sqliteQueryHandler(ctx context.Context, req mcp.CallToolRequest
 		return mcp.NewToolResultError("query is required"), nil
 	}
 
-	result, err := sqlite.Query(dbPath, query)
+	var params []interface{}
+	if raw, ok := args["params"]; ok && raw != nil {
+		list, ok := raw.([]interface{})
+		if !ok {
+			return mcp.NewToolResultError("params must be an array"), nil
+		}
+		var err error
+		if params, err = sqliteParams(list); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+
+	// sqlite.Query checks the read-only allow-list before binding anything
+	result, err := sqlite.Query(dbPath, query, params...)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("query failed: %v", err)), nil
 	}
@@ -1911,6 +2668,28 @@ func (s *Server) sqliteQueryHandler(ctx context.Context, req mcp.CallToolRequest
 	return mcp.NewToolResultText(string(data)), nil
 }
 
+// sqliteParams converts JSON-decoded params into driver values. JSON
+// numbers arrive as float64, so whole numbers become int64 to compare
+// equal to INTEGER columns and work in LIMIT.
+func sqliteParams(list []interface{}) ([]interface{}, error) {
+	params := make([]interface{}, len(list))
+	for i, v := range list {
+		switch v := v.(type) {
+		case nil, string, bool:
+			params[i] = v
+		case float64:
+			if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+				params[i] = int64(v)
+			} else {
+				params[i] = v
+			}
+		default:
+			return nil, fmt.Errorf("params[%d]: only strings, numbers, booleans and null can be bound", i)
+		}
+	}
+	return params, nil
+}
+
 func (s *Server) sqliteSchemaHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	args := req.GetArguments()
 
@@ -1986,6 +2765,7 @@ Useful for sharing code snippets, results, or prepared text.
 
 Cost: ZERO network, ZERO tokens, instant.`),
 		mcp.WithString("text", mcp.Required(), mcp.Description("Text to copy to clipboard")),
+		dryRunOption(),
 	)
 }
 
@@ -2012,6 +2792,12 @@ func (s *Server) clipboardWriteHandler(ctx context.Context, req mcp.CallToolRequ
 		return mcp.NewToolResultError("text is required"), nil
 	}
 
+	if isDryRun(args) {
+		return dryRunResult(fmt.Sprintf("copy %d characters to the clipboard", len(text)), map[string]interface{}{
+			"text": text,
+		}), nil
+	}
+
 	if err := clipboard.Write(text); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("clipboard write failed: %v", err)), nil
 	}
@@ -2034,6 +2820,7 @@ The Conductor is an orchestrator that:
 - Can write files to /data/repos/workspace/
 
 Use this to CREATE FILES on the server without manual SSH.
+Read them back with clood_workspace_read.
 
 Example: clood_conductor task="Create a todo list HTML file"
 
@@ -2102,6 +2889,61 @@ func (s *Server) conductorHandler(ctx context.Context, req mcp.CallToolRequest)
 	return mcp.NewToolResultText(result), nil
 }
 
+// conductorWorkspace is where the Conductor writes files on ubuntu25
+const conductorWorkspace = "/data/repos/workspace"
+
+// workspaceReadLimit caps how much of a workspace file is returned
+const workspaceReadLimit = 100 * 1024
+
+func (s *Server) workspaceReadTool() mcp.Tool {
+	return mcp.NewTool("clood_workspace_read",
+		mcp.WithDescription(`📄 Read a file the Conductor created on ubuntu25.
+
+Fetches a file from /data/repos/workspace/ over SSH so code generated
+with clood_conductor can be reviewed. Use the workspace listing in the
+conductor result to find file names.
+
+Files over 100KB are truncated with a warning.
+
+Cost: ZERO tokens, one SSH round trip.`),
+		mcp.WithString("filename", mcp.Required(), mcp.Description("Path relative to /data/repos/workspace/ (e.g., 'todo.html' or 'src/app.py')")),
+	)
+}
+
+func (s *Server) workspaceReadHandler(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+
+	filename, ok := args["filename"].(string)
+	if !ok || filename == "" {
+		return mcp.NewToolResultError("filename is required"), nil
+	}
+	clean := path.Clean(filename)
+	if path.IsAbs(clean) || clean == "." || clean == ".." || strings.HasPrefix(clean, "../") {
+		return mcp.NewToolResultError("filename must be inside " + conductorWorkspace), nil
+	}
+
+	// Size first so truncation can be reported, then at most the limit
+	remote := "'" + strings.ReplaceAll(conductorWorkspace+"/"+clean, "'", `'\''`) + "'"
+	sshCmd := fmt.Sprintf("wc -c < %s && head -c %d %s", remote, workspaceReadLimit, remote)
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "ssh", "ubuntu25", sshCmd)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Reading %s on ubuntu25 failed: %v\n%s", clean, err, strings.TrimSpace(stderr.String()))), nil
+	}
+
+	sizeLine, content, _ := bytes.Cut(out, []byte("\n"))
+	size, _ := strconv.Atoi(strings.TrimSpace(string(sizeLine)))
+
+	result := fmt.Sprintf("📄 %s/%s (%d bytes)\n", conductorWorkspace, clean, size)
+	if size > workspaceReadLimit {
+		result += fmt.Sprintf("⚠️ Truncated: showing the first %d of %d bytes\n", workspaceReadLimit, size)
+	}
+	return mcp.NewToolResultText(result + "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n" + string(content)), nil
+}
+
 // =============================================================================
 // THUNDERDOME TOOL - Parallel multi-host catfights
 // =============================================================================
@@ -2356,7 +3198,7 @@ Examples:
 - "Summarize this function" → delegate to analyst on mac-mini`),
 		mcp.WithString("task", mcp.Required(), mcp.Description("The task to delegate")),
 		mcp.WithString("host", mcp.Description("Target host (e.g., 'ubuntu25', 'mac-mini'). If not specified, uses best available.")),
-		mcp.WithString("agent", mcp.Description("Agent role: reviewer, coder, analyst, documenter. Each has specialized system prompts.")),
+		mcp.WithString("agent", mcp.Description("Agent role: reviewer, coder, analyst, documenter, or any agent from 'clood agents'. Each has its own system prompt and sampling settings.")),
 		mcp.WithString("model", mcp.Description("Model to use (e.g., 'qwen2.5-coder:7b'). If not specified, uses agent default or tier config.")),
 		mcp.WithString("context", mcp.Description("Additional context to include with the task (e.g., code snippet, file content)")),
 	)
@@ -2380,8 +3222,23 @@ func (s *Server) delegateHandler(ctx context.Context, req mcp.CallToolRequest) (
 		return mcp.NewToolResultError("Error loading config: " + err.Error()), nil
 	}
 
+	// A configured agent supplies its own host, model, system prompt and
+	// sampling options; explicit arguments still win
+	var agent *agents.Agent
+	if agentName != "" {
+		agent = agents.LoadConfigWithFallback().GetAgent(agentName)
+	}
+	if agent != nil {
+		if hostName == "" {
+			hostName = agent.Host
+		}
+		if model == "" {
+			model = agent.Model
+		}
+	}
+
 	// Setup host manager
-	mgr := hosts.NewManager()
+	mgr := hosts.NewManager(hosts.WithRequestLoad(s.load))
 	mgr.AddHosts(cfg.Hosts)
 
 	// Find host
@@ -2410,6 +3267,8 @@ func (s *Server) delegateHandler(ctx context.Context, req mcp.CallToolRequest) (
 	if client == nil {
 		return mcp.NewToolResultError("Could not get client for host: " + hostName), nil
 	}
+	done := mgr.BeginRequest(hostName)
+	defer done()
 
 	// Build system prompt based on agent
 	var systemPrompt string
@@ -2425,6 +3284,13 @@ func (s *Server) delegateHandler(ctx context.Context, req mcp.CallToolRequest) (
 	default:
 		systemPrompt = "You are a helpful AI assistant."
 	}
+	var options map[string]interface{}
+	if agent != nil {
+		if agent.System != "" {
+			systemPrompt = agent.System
+		}
+		options = agent.Options()
+	}
 
 	// Select model
 	if model == "" {
@@ -2454,7 +3320,7 @@ func (s *Server) delegateHandler(ctx context.Context, req mcp.CallToolRequest) (
 
 	// Execute
 	start := time.Now()
-	resp, err := client.GenerateWithSystem(model, systemPrompt, fullPrompt.String())
+	resp, err := client.GenerateWithSystemOptions(ctx, model, systemPrompt, fullPrompt.String(), options)
 	duration := time.Since(start)
 
 	if err != nil {
@@ -0,0 +1,239 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/dirtybirdnj/clood/internal/sqlite"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// grepTree builds a tree with ignored, hidden, skipped and look-alike
+// files, all containing "needle"
+func grepTree(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	for _, name := range []string{
+		".gitignore",
+		"main.go",
+		"ignored.go",
+		".hidden.go",
+		"sub/.config/settings.yml",
+		"sub/ci.yml",
+		"sub/ci.yaml",
+		".git/HEAD",
+		"vendor/dep.go",
+		"web/node_modules/pkg/index.js",
+	} {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		content := "needle\n"
+		if name == ".gitignore" {
+			content = "ignored.go\nneedle\n"
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func grepFiles(root string, matches []grepMatch) []string {
+	var files []string
+	for _, m := range matches {
+		rel, _ := filepath.Rel(root, m.File)
+		files = append(files, filepath.ToSlash(rel))
+	}
+	sort.Strings(files)
+	return files
+}
+
+func TestGrepWalk(t *testing.T) {
+	root := grepTree(t)
+	re := regexp.MustCompile("needle")
+
+	tests := []struct {
+		fileType string
+		want     []string
+	}{
+		{"", []string{"main.go", "sub/ci.yaml", "sub/ci.yml"}},
+		{"go", []string{"main.go"}},
+		{"yml", []string{"sub/ci.yml"}},
+	}
+	for _, tt := range tests {
+		got := grepFiles(root, grepWalk(re, root, tt.fileType, true))
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("grepWalk(type %q) = %q; want %q", tt.fileType, got, tt.want)
+		}
+	}
+
+	// A file given as the path is searched even though it's ignored
+	one := filepath.Join(root, "ignored.go")
+	if got := grepWalk(re, one, "", true); len(got) != 1 || got[0].File != one {
+		t.Errorf("grepWalk(%s) = %v", one, got)
+	}
+}
+
+// With rg installed, both searches must find the same files. Types with
+// more than one extension are rg's to define, so they aren't compared.
+func TestGrepRipgrepMatchesWalk(t *testing.T) {
+	if _, err := exec.LookPath("rg"); err != nil {
+		t.Skip("rg not installed")
+	}
+	root := grepTree(t)
+	re := regexp.MustCompile("needle")
+
+	for _, fileType := range []string{"", "go"} {
+		matches, err := grepRipgrep(context.Background(), "needle", root, fileType, false, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := grepFiles(root, matches)
+		want := grepFiles(root, grepWalk(re, root, fileType, false))
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("type %q: rg found %q; walker found %q", fileType, got, want)
+		}
+	}
+}
+
+func TestRipgrepArgs(t *testing.T) {
+	got := ripgrepArgs("a|b", "src", "yml", true)
+	want := []string{
+		"--json", "--no-messages",
+		"-g", "!vendor/", "-g", "!node_modules/",
+		"-i", "-t", "yaml", "-e", "a|b", "--", "src",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ripgrepArgs = %q; want %q", got, want)
+	}
+	if got := ripgrepArgs("x", ".", "go", false); !reflect.DeepEqual(got[len(got)-6:], []string{"-t", "go", "-e", "x", "--", "."}) {
+		t.Errorf("ripgrepArgs(type go) = %q", got)
+	}
+}
+
+func TestTruncateUTF8(t *testing.T) {
+	tests := []struct {
+		s    string
+		n    int
+		want string
+	}{
+		{"hello", 10, "hello"},
+		{"hello", 5, "hello"},
+		{"hello", 3, "hel"},
+		{"héllo", 2, "h"}, // é is 2 bytes; never half of it
+		{"héllo", 3, "hé"},
+		{"日本語", 4, "日"},
+		{"日本語", 6, "日本"},
+		{"🐈x", 3, ""},
+		{"🐈x", 4, "🐈"},
+		{"abc", 0, ""},
+	}
+	for _, tt := range tests {
+		if got := truncateUTF8(tt.s, tt.n); got != tt.want {
+			t.Errorf("truncateUTF8(%q, %d) = %q; want %q", tt.s, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestSqliteParams(t *testing.T) {
+	tests := []struct {
+		json string
+		want []interface{}
+		err  string
+	}{
+		{`[1, -42, 0]`, []interface{}{int64(1), int64(-42), int64(0)}, ""},
+		{`[1.5, -0.25]`, []interface{}{1.5, -0.25}, ""},
+		{`[1e20]`, []interface{}{1e20}, ""}, // past exact float64 integers: kept as a float
+		{`["it's", ""]`, []interface{}{"it's", ""}, ""},
+		{`[true, false, null]`, []interface{}{true, false, nil}, ""},
+		{`[]`, []interface{}{}, ""},
+		{`[1, [2, 3]]`, nil, "params[1]"},
+		{`[{"a": 1}]`, nil, "params[0]"},
+	}
+	for _, tt := range tests {
+		var list []interface{}
+		if err := json.Unmarshal([]byte(tt.json), &list); err != nil {
+			t.Fatal(err)
+		}
+		got, err := sqliteParams(list)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("sqliteParams(%s) = %v, %v; want an error about %s", tt.json, got, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("sqliteParams(%s) = %#v, %v; want %#v", tt.json, got, err, tt.want)
+		}
+	}
+}
+
+func TestSqliteQueryHandlerParams(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	db, err := sqlite.Open(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec(`CREATE TABLE users (id INTEGER, name TEXT);
+		INSERT INTO users VALUES (1, 'alice'), (2, 'o''brien'), (3, 'bob')`)
+	db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Server{}
+	query := func(sql, params string) (*mcp.CallToolResult, sqlite.QueryResult) {
+		t.Helper()
+		arguments := map[string]interface{}{"database": dbPath, "query": sql}
+		if params != "" {
+			var list interface{}
+			if err := json.Unmarshal([]byte(params), &list); err != nil {
+				t.Fatal(err)
+			}
+			arguments["params"] = list
+		}
+		var req mcp.CallToolRequest
+		req.Params.Arguments = arguments
+		res, err := s.sqliteQueryHandler(context.Background(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out sqlite.QueryResult
+		if !res.IsError {
+			text := res.Content[0].(mcp.TextContent).Text
+			if err := json.Unmarshal([]byte(text), &out); err != nil {
+				t.Fatalf("%v: %s", err, text)
+			}
+		}
+		return res, out
+	}
+
+	// A quote in a bound value is data, never SQL
+	if _, out := query(`SELECT id FROM users WHERE name = ?`, `["o'brien"]`); out.Count != 1 || out.Rows[0][0] != float64(2) {
+		t.Errorf("name = o'brien: %+v", out)
+	}
+	if _, out := query(`SELECT id FROM users WHERE name = ?`, `["x' OR '1'='1"]`); out.Count != 0 {
+		t.Errorf("injection attempt matched rows: %+v", out)
+	}
+
+	// Whole JSON numbers bind as integers, so they match INTEGER columns and work in LIMIT
+	if _, out := query(`SELECT name FROM users WHERE id > ? ORDER BY id LIMIT ?`, `[1, 1]`); out.Count != 1 || out.Rows[0][0] != "o'brien" {
+		t.Errorf("id > 1 LIMIT 1: %+v", out)
+	}
+
+	for _, params := range []string{`[[1]]`, `{"id": 1}`} {
+		if res, _ := query(`SELECT * FROM users WHERE id = ?`, params); !res.IsError {
+			t.Errorf("params %s should be rejected", params)
+		}
+	}
+}
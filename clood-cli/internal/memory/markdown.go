@@ -0,0 +1,194 @@
+package memory
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Section is one "## Heading" block of a Markdown notes file
+type Section struct {
+	Title    string   `json:"title"`
+	Content  string   `json:"content"`
+	Hashtags []string `json:"hashtags,omitempty"`
+}
+
+// hashtagRe matches Obsidian-style #tags, including nested ones like
+// #project/clood. A tag must start with a letter so "#1" and headings
+// ("# Title") aren't picked up.
+var hashtagRe = regexp.MustCompile(`(?:^|[\s(])#([A-Za-z][\w/-]*)`)
+
+// ParseMarkdownSections splits notes into sections at each "## " heading.
+// Text before the first heading and sections with no body are skipped;
+// headings inside fenced code blocks don't start a section.
+func ParseMarkdownSections(text string) []Section {
+	var sections []Section
+	var current *Section
+	var body []string
+	inFence := false
+
+	flush := func() {
+		if current == nil {
+			return
+		}
+		current.Content = strings.TrimSpace(strings.Join(body, "\n"))
+		if current.Content != "" {
+			current.Hashtags = findHashtags(current.Content)
+			sections = append(sections, *current)
+		}
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+		}
+		if !inFence && strings.HasPrefix(line, "## ") {
+			flush()
+			current = &Section{Title: strings.TrimSpace(strings.TrimPrefix(line, "## "))}
+			body = nil
+			continue
+		}
+		if current != nil {
+			body = append(body, line)
+		}
+	}
+	flush()
+
+	return sections
+}
+
+// findHashtags returns the distinct #tags in text outside fenced code,
+// without the #, in order of first appearance
+func findHashtags(text string) []string {
+	var tags []string
+	seen := map[string]bool{}
+	inFence := false
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		for _, m := range hashtagRe.FindAllStringSubmatch(line, -1) {
+			tag := strings.TrimRight(m[1], "/-")
+			if key := strings.ToLower(tag); tag != "" && !seen[key] {
+				seen[key] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
+
+// ImportResult describes what importing one section did (or would do)
+type ImportResult struct {
+	Action string `json:"action"` // created, updated, unchanged
+	Memory Memory `json:"memory"`
+}
+
+// Import stores each section as a memory titled by its heading, tagged with
+// tags plus the section's hashtags. A memory in the same project with the
+// same title is updated in place, so re-importing a file doesn't duplicate
+// it. With dryRun nothing is saved and new memories have no ID.
+func (s *Store) Import(sections []Section, tags []string, context, project string, dryRun bool) ([]ImportResult, error) {
+	var results []ImportResult
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	for _, sec := range sections {
+		memTags := mergeTags(tags, sec.Hashtags)
+
+		existing, err := findTitle(tx, sec.Title, project)
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil {
+			mem := Memory{
+				Title:     sec.Title,
+				Content:   sec.Content,
+				Tags:      memTags,
+				Context:   context,
+				Project:   project,
+				CreatedAt: time.Now(),
+			}
+			if !dryRun {
+				mem.ID = uuid.New().String()[:8]
+				if err := insertMemory(tx, mem); err != nil {
+					return nil, err
+				}
+			}
+			results = append(results, ImportResult{Action: "created", Memory: mem})
+			continue
+		}
+
+		mem := *existing
+		if mem.Content == sec.Content && sameTags(mem.Tags, memTags) {
+			results = append(results, ImportResult{Action: "unchanged", Memory: mem})
+			continue
+		}
+		if mem.Content != sec.Content {
+			mem.Embeddings = nil // stale for the new text
+		}
+		mem.Content = sec.Content
+		mem.Tags = memTags
+		mem.Context = context
+		if !dryRun {
+			if err := updateMemory(tx, mem); err != nil {
+				return nil, err
+			}
+		}
+		results = append(results, ImportResult{Action: "updated", Memory: mem})
+	}
+
+	if dryRun {
+		return results, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// findTitle returns the memory with title in project, or nil if there's none
+func findTitle(ex execer, title, project string) (*Memory, error) {
+	found, err := selectMemories(ex, `title != '' AND lower(title) = lower(?) AND lower(project) = lower(?)`, 1, title, project)
+	if err != nil || len(found) == 0 {
+		return nil, err
+	}
+	return &found[0], nil
+}
+
+func mergeTags(a, b []string) []string {
+	var tags []string
+	seen := map[string]bool{}
+	for _, t := range append(append([]string{}, a...), b...) {
+		t = strings.TrimPrefix(strings.TrimSpace(t), "#")
+		if key := strings.ToLower(t); t != "" && !seen[key] {
+			seen[key] = true
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+func sameTags(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !strings.EqualFold(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"reflect"
+	"testing"
+)
+
+const testNotes = `# Project notes
+
+Intro text that isn't a memory.
+
+## Deploys
+Ship from main only. #ops #release
+
+` + "```sh\n## not a heading\necho #notatag\n```" + `
+
+## Empty
+
+## Hosts
+The mac mini runs ollama (#infra, #ops).
+`
+
+func TestParseMarkdownSections(t *testing.T) {
+	sections := ParseMarkdownSections(testNotes)
+	if len(sections) != 2 {
+		t.Fatalf("got %d sections, want 2: %+v", len(sections), sections)
+	}
+
+	if sections[0].Title != "Deploys" {
+		t.Errorf("title = %q, want Deploys", sections[0].Title)
+	}
+	if !reflect.DeepEqual(sections[0].Hashtags, []string{"ops", "release"}) {
+		t.Errorf("hashtags = %v, want [ops release]", sections[0].Hashtags)
+	}
+	if !reflect.DeepEqual(sections[1].Hashtags, []string{"infra", "ops"}) {
+		t.Errorf("hashtags = %v, want [infra ops]", sections[1].Hashtags)
+	}
+}
+
+func TestImportIsIdempotent(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	store, err := NewStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sections := ParseMarkdownSections(testNotes)
+	if _, err := store.Import(sections, []string{"obsidian"}, "notes.md", "", false); err != nil {
+		t.Fatal(err)
+	}
+
+	sections[1].Content = "The mac mini and the laptop run ollama."
+	results, err := store.Import(sections, []string{"obsidian"}, "notes.md", "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if store.Count() != 2 {
+		t.Fatalf("Count() = %d after re-import, want 2", store.Count())
+	}
+	if results[0].Action != "unchanged" || results[1].Action != "updated" {
+		t.Errorf("actions = %s, %s; want unchanged, updated", results[0].Action, results[1].Action)
+	}
+	if got := results[0].Memory.Tags; !reflect.DeepEqual(got, []string{"obsidian", "ops", "release"}) {
+		t.Errorf("tags = %v", got)
+	}
+
+	if _, err := store.Import([]Section{{Title: "New", Content: "x"}}, nil, "", "", true); err != nil {
+		t.Fatal(err)
+	}
+	if store.Count() != 2 {
+		t.Errorf("dry run changed the store: Count() = %d", store.Count())
+	}
+}
@@ -1,35 +1,105 @@
 // Package memory provides persistent knowledge storage for clood.
-// Memories are stored locally in ~/.clood/memory.json
+// Memories are stored locally in ~/.clood/memory.db, a SQLite database
+// with an FTS5 index for keyword recall. A memory.json left by older
+// versions is imported the first time the store is opened. A team can
+// also share a database of the same schema on a network path.
 package memory
 
 import (
+	"database/sql"
 	"encoding/json"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
 	"time"
+	"unicode"
 
+	"github.com/dirtybirdnj/clood/internal/sqlite"
 	"github.com/google/uuid"
 )
 
 // Memory represents a single stored fact or note
 type Memory struct {
 	ID        string    `json:"id"`
+	Title     string    `json:"title,omitempty"` // set by imports; matched to update on re-import
 	Content   string    `json:"content"`
 	Tags      []string  `json:"tags,omitempty"`
 	Context   string    `json:"context,omitempty"` // project/file context when stored
+	Project   string    `json:"project,omitempty"` // workspace scope; empty means global
 	CreatedAt time.Time `json:"created_at"`
+	Shared    bool      `json:"shared,omitempty"` // read from a shared store
+
+	// Embeddings caches vectors per embedding model, filled lazily by
+	// RecallSemantic so repeat searches don't re-embed every memory.
+	Embeddings map[string][]float64 `json:"embeddings,omitempty"`
+}
+
+// Embedder turns text into a vector (e.g. via Ollama /api/embeddings)
+type Embedder func(text string) ([]float64, error)
+
+// ScoredMemory is a memory with its similarity to the query
+type ScoredMemory struct {
+	Memory
+	Score float64 `json:"score"`
+}
+
+// memoryMigrations is the versioned schema for memory.db.
+// Append new entries; never edit existing ones.
+//
+// memories_fts is an external-content FTS5 index over title, content and
+// context, kept in step by triggers. seq is the rowid it points at; it's
+// declared so VACUUM can't renumber it.
+var memoryMigrations = []string{
+	`CREATE TABLE memories (
+		seq        INTEGER PRIMARY KEY,
+		id         TEXT NOT NULL UNIQUE,
+		title      TEXT NOT NULL DEFAULT '',
+		content    TEXT NOT NULL,
+		tags       TEXT NOT NULL DEFAULT '[]',
+		context    TEXT NOT NULL DEFAULT '',
+		project    TEXT NOT NULL DEFAULT '',
+		created_at INTEGER NOT NULL,
+		embeddings TEXT NOT NULL DEFAULT 'null'
+	);
+	CREATE INDEX memories_created ON memories (created_at);
+	CREATE VIRTUAL TABLE memories_fts USING fts5 (
+		title, content, context, content='memories', content_rowid='seq'
+	);
+	CREATE TRIGGER memories_fts_insert AFTER INSERT ON memories BEGIN
+		INSERT INTO memories_fts (rowid, title, content, context)
+		VALUES (new.seq, new.title, new.content, new.context);
+	END;
+	CREATE TRIGGER memories_fts_delete AFTER DELETE ON memories BEGIN
+		INSERT INTO memories_fts (memories_fts, rowid, title, content, context)
+		VALUES ('delete', old.seq, old.title, old.content, old.context);
+	END;
+	CREATE TRIGGER memories_fts_update AFTER UPDATE OF title, content, context ON memories BEGIN
+		INSERT INTO memories_fts (memories_fts, rowid, title, content, context)
+		VALUES ('delete', old.seq, old.title, old.content, old.context);
+		INSERT INTO memories_fts (rowid, title, content, context)
+		VALUES (new.seq, new.title, new.content, new.context);
+	END;`,
 }
 
 // Store manages the memory storage
 type Store struct {
-	path     string
-	memories []Memory
+	db     *sql.DB
+	shared bool
+}
+
+// execer is what queries run on: the database or a transaction. The store
+// holds a single connection, so work inside a transaction must go through
+// it rather than the database.
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
 }
 
-// NewStore creates a new memory store, loading existing memories if present
+// NewStore opens the memory store, creating it if needed and importing a
+// legacy memory.json the first time. Close it when done.
 func NewStore() (*Store, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
@@ -41,134 +111,454 @@ func NewStore() (*Store, error) {
 		return nil, fmt.Errorf("failed to create .clood directory: %w", err)
 	}
 
-	s := &Store{
-		path:     filepath.Join(cloodDir, "memory.json"),
-		memories: []Memory{},
+	db, err := openDB(filepath.Join(cloodDir, "memory.db"), 5000)
+	if err != nil {
+		return nil, err
+	}
+	s := &Store{db: db}
+	if err := s.importJSON(filepath.Join(cloodDir, "memory.json")); err != nil {
+		db.Close()
+		return nil, err
 	}
+	return s, nil
+}
 
-	// Load existing memories if file exists
-	if data, err := os.ReadFile(s.path); err == nil {
-		if err := json.Unmarshal(data, &s.memories); err != nil {
-			return nil, fmt.Errorf("failed to parse memory file: %w", err)
-		}
+// OpenShared opens a memory database that several people write to, such
+// as one on a network mount. It uses the rollback journal, since WAL's
+// shared-memory index can't be shared between machines and would corrupt
+// the database, and waits longer for locks than the local store. The
+// directory must exist: a missing one usually means the share isn't
+// mounted, and creating it would hide that.
+func OpenShared(path string) (*Store, error) {
+	if info, err := os.Stat(filepath.Dir(path)); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("shared memory directory %s not found (is the share mounted?)", filepath.Dir(path))
+	}
+	db, err := openDB(path, 15000)
+	if err != nil {
+		return nil, err
 	}
+	var mode string
+	if err := db.QueryRow(`PRAGMA journal_mode = DELETE`).Scan(&mode); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("shared memory %s: %w", path, err)
+	}
+	if !strings.EqualFold(mode, "delete") {
+		db.Close()
+		return nil, fmt.Errorf("shared memory %s: can't switch to a rollback journal (journal mode is %s)", path, mode)
+	}
+	return &Store{db: db, shared: true}, nil
+}
 
-	return s, nil
+// openDB opens and migrates a memory database
+func openDB(path string, busyTimeoutMs int) (*sql.DB, error) {
+	db, err := sqlite.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	// One connection keeps the busy timeout in force and serializes the
+	// MCP server's concurrent tool calls
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(fmt.Sprintf(`PRAGMA busy_timeout = %d`, busyTimeoutMs)); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := sqlite.Migrate(db, memoryMigrations); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
 }
 
-// save persists memories to disk
-func (s *Store) save() error {
-	data, err := json.MarshalIndent(s.memories, "", "  ")
+// Close closes the database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// importJSON moves memories from the JSON file older versions kept into
+// the database, then renames the file to memory.json.migrated so it's
+// only read once. A missing file is nothing to do.
+func (s *Store) importJSON(path string) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
 	if err != nil {
-		return fmt.Errorf("failed to marshal memories: %w", err)
+		return err
 	}
 
-	if err := os.WriteFile(s.path, data, 0644); err != nil {
-		return fmt.Errorf("failed to write memory file: %w", err)
+	var memories []Memory
+	if err := json.Unmarshal(data, &memories); err != nil {
+		return fmt.Errorf("failed to parse memory file: %w", err)
 	}
 
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	for _, mem := range memories {
+		if _, err := tx.Exec(`DELETE FROM memories WHERE id = ?`, mem.ID); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := insertMemory(tx, mem); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("importing %s: %w", path, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return os.Rename(path, path+".migrated")
+}
+
+func insertMemory(ex execer, mem Memory) error {
+	tags, embeddings, err := encodeMemory(mem)
+	if err != nil {
+		return err
+	}
+	_, err = ex.Exec(`INSERT INTO memories (id, title, content, tags, context, project, created_at, embeddings)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		mem.ID, mem.Title, mem.Content, tags, mem.Context, mem.Project, mem.CreatedAt.UnixNano(), embeddings)
+	if err != nil {
+		return fmt.Errorf("failed to save memory: %w", err)
+	}
+	return nil
+}
+
+func updateMemory(ex execer, mem Memory) error {
+	tags, embeddings, err := encodeMemory(mem)
+	if err != nil {
+		return err
+	}
+	_, err = ex.Exec(`UPDATE memories SET title = ?, content = ?, tags = ?, context = ?, project = ?, embeddings = ?
+		WHERE id = ?`,
+		mem.Title, mem.Content, tags, mem.Context, mem.Project, embeddings, mem.ID)
+	if err != nil {
+		return fmt.Errorf("failed to save memory: %w", err)
+	}
 	return nil
 }
 
-// Store adds a new memory
-func (s *Store) Store(content string, tags []string, context string) (*Memory, error) {
+// encodeMemory returns the JSON columns for mem. Tags are never null so
+// json_each in the tag filter always sees an array.
+func encodeMemory(mem Memory) (string, string, error) {
+	if mem.Tags == nil {
+		mem.Tags = []string{}
+	}
+	tags, err := json.Marshal(mem.Tags)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal memory: %w", err)
+	}
+	embeddings, err := json.Marshal(mem.Embeddings)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal memory: %w", err)
+	}
+	return string(tags), string(embeddings), nil
+}
+
+// Store adds a new memory, scoped to project when it is non-empty
+func (s *Store) Store(content string, tags []string, context, project string) (*Memory, error) {
 	mem := Memory{
 		ID:        uuid.New().String()[:8], // Short ID for easy reference
 		Content:   content,
 		Tags:      tags,
 		Context:   context,
+		Project:   project,
 		CreatedAt: time.Now(),
 	}
 
-	s.memories = append(s.memories, mem)
-
-	if err := s.save(); err != nil {
+	if err := insertMemory(s.db, mem); err != nil {
 		return nil, err
 	}
+	mem.Shared = s.shared
 
 	return &mem, nil
 }
 
-// Recall searches memories by keyword or tag
-func (s *Store) Recall(query string, tag string, limit int) []Memory {
-	var results []Memory
+// Recall searches memories by keyword, tag, or project, most recent first.
+// Each word of query must appear in the title, content or context as a
+// word or the start of one ("deploy" finds "deploys"), case-insensitively.
+func (s *Store) Recall(query, tag, project string, limit int) []Memory {
+	where, args := memoryFilter(query, tag, project)
+	results, err := selectMemories(s.db, where, limit, args...)
+	if err != nil {
+		return nil
+	}
+	return s.mark(results)
+}
 
-	queryLower := strings.ToLower(query)
-
-	for _, mem := range s.memories {
-		// Filter by tag if specified
-		if tag != "" {
-			hasTag := false
-			for _, t := range mem.Tags {
-				if strings.EqualFold(t, tag) {
-					hasTag = true
-					break
-				}
-			}
-			if !hasTag {
-				continue
-			}
+// mark flags memories read from a shared store
+func (s *Store) mark(memories []Memory) []Memory {
+	if s.shared {
+		for i := range memories {
+			memories[i].Shared = true
 		}
+	}
+	return memories
+}
 
-		// Filter by query if specified
-		if query != "" {
-			contentLower := strings.ToLower(mem.Content)
-			contextLower := strings.ToLower(mem.Context)
-			if !strings.Contains(contentLower, queryLower) && !strings.Contains(contextLower, queryLower) {
-				continue
+// Merge combines memories from several stores, most recent first, keeping
+// the first copy of an ID seen. limit <= 0 keeps them all.
+func Merge(limit int, lists ...[]Memory) []Memory {
+	seen := map[string]bool{}
+	var merged []Memory
+	for _, list := range lists {
+		for _, mem := range list {
+			if !seen[mem.ID] {
+				seen[mem.ID] = true
+				merged = append(merged, mem)
 			}
 		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].CreatedAt.After(merged[j].CreatedAt)
+	})
+	if limit > 0 && len(merged) > limit {
+		merged = merged[:limit]
+	}
+	return merged
+}
+
+// memoryFilter builds the WHERE clause for Recall's filters
+func memoryFilter(query, tag, project string) (string, []interface{}) {
+	conds := []string{"1"}
+	var args []interface{}
+	if query != "" {
+		conds = append(conds, `seq IN (SELECT rowid FROM memories_fts WHERE memories_fts MATCH ?)`)
+		args = append(args, ftsQuery(query))
+	}
+	if tag != "" {
+		conds = append(conds, `EXISTS (SELECT 1 FROM json_each(memories.tags) WHERE lower(value) = lower(?))`)
+		args = append(args, tag)
+	}
+	if project != "" {
+		conds = append(conds, `lower(project) = lower(?)`)
+		args = append(args, project)
+	}
+	return strings.Join(conds, " AND "), args
+}
+
+// ftsQuery turns free text into an FTS5 query: each word quoted, so
+// punctuation like "project-x" or a stray quote can't be read as query
+// syntax, and made a prefix match. Words with no letters or digits are
+// dropped, since the tokenizer would index nothing for them.
+func ftsQuery(query string) string {
+	var terms []string
+	for _, word := range strings.Fields(query) {
+		if strings.IndexFunc(word, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) < 0 {
+			continue
+		}
+		terms = append(terms, `"`+strings.ReplaceAll(word, `"`, `""`)+`"*`)
+	}
+	if len(terms) == 0 {
+		return `""`
+	}
+	return strings.Join(terms, " ")
+}
+
+// selectMemories returns the memories matching where, most recent first
+func selectMemories(ex execer, where string, limit int, args ...interface{}) ([]Memory, error) {
+	q := `SELECT id, title, content, tags, context, project, created_at, embeddings
+		FROM memories WHERE ` + where + ` ORDER BY created_at DESC, seq DESC`
+	if limit > 0 {
+		q += fmt.Sprintf(" LIMIT %d", limit)
+	}
 
+	rows, err := ex.Query(q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read memories: %w", err)
+	}
+	defer rows.Close()
+
+	var results []Memory
+	for rows.Next() {
+		var mem Memory
+		var tags, embeddings string
+		var created int64
+		if err := rows.Scan(&mem.ID, &mem.Title, &mem.Content, &tags, &mem.Context, &mem.Project, &created, &embeddings); err != nil {
+			return nil, fmt.Errorf("failed to read memories: %w", err)
+		}
+		mem.CreatedAt = time.Unix(0, created)
+		if err := json.Unmarshal([]byte(tags), &mem.Tags); err != nil {
+			return nil, fmt.Errorf("memory %s: bad tags: %w", mem.ID, err)
+		}
+		if len(mem.Tags) == 0 {
+			mem.Tags = nil
+		}
+		if err := json.Unmarshal([]byte(embeddings), &mem.Embeddings); err != nil {
+			return nil, fmt.Errorf("memory %s: bad embeddings: %w", mem.ID, err)
+		}
 		results = append(results, mem)
 	}
+	return results, rows.Err()
+}
+
+// RecallSemantic ranks memories by cosine similarity between the query and
+// each memory's embedding under model. Missing embeddings are computed with
+// embed and saved, so only new memories cost an embedding call next time.
+func (s *Store) RecallSemantic(query, tag, project, model string, limit int, embed Embedder) ([]ScoredMemory, error) {
+	if query == "" {
+		return nil, fmt.Errorf("query is required for semantic recall")
+	}
+
+	queryVec, err := embed(query)
+	if err != nil {
+		return nil, fmt.Errorf("embedding query: %w", err)
+	}
+
+	where, args := memoryFilter("", tag, project)
+	memories, err := selectMemories(s.db, where, 0, args...)
+	if err != nil {
+		return nil, err
+	}
+	memories = s.mark(memories)
 
-	// Sort by most recent first
-	sort.Slice(results, func(i, j int) bool {
-		return results[i].CreatedAt.After(results[j].CreatedAt)
+	var results []ScoredMemory
+	for i := range memories {
+		mem := &memories[i]
+		vec, ok := mem.Embeddings[model]
+		if !ok {
+			text := mem.Content
+			if mem.Title != "" {
+				text = mem.Title + "\n" + text
+			}
+			if mem.Context != "" {
+				text += "\n" + mem.Context
+			}
+			vec, err = embed(text)
+			if err != nil {
+				return nil, fmt.Errorf("embedding memory %s: %w", mem.ID, err)
+			}
+			if mem.Embeddings == nil {
+				mem.Embeddings = make(map[string][]float64)
+			}
+			mem.Embeddings[model] = vec
+			if err := updateMemory(s.db, *mem); err != nil {
+				return nil, err
+			}
+		}
+
+		results = append(results, ScoredMemory{Memory: *mem, Score: CosineSimilarity(queryVec, vec)})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
 	})
 
-	// Apply limit
 	if limit > 0 && len(results) > limit {
 		results = results[:limit]
 	}
 
-	return results
+	return results, nil
+}
+
+// CosineSimilarity returns the cosine of the angle between a and b, or 0
+// when either is empty or their lengths differ
+func CosineSimilarity(a, b []float64) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
 }
 
-// List returns memories, optionally filtered by tag
-func (s *Store) List(tag string, limit int) []Memory {
-	return s.Recall("", tag, limit)
+// List returns memories, optionally filtered by tag and project
+func (s *Store) List(tag, project string, limit int) []Memory {
+	return s.Recall("", tag, project, limit)
 }
 
 // Forget removes a memory by ID
 func (s *Store) Forget(id string) error {
-	for i, mem := range s.memories {
-		if mem.ID == id {
-			s.memories = append(s.memories[:i], s.memories[i+1:]...)
-			return s.save()
+	res, err := s.db.Exec(`DELETE FROM memories WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete memory: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("memory not found: %s", id)
+	}
+	return nil
+}
+
+// ForgetByTag removes every memory tagged tag and returns how many went
+func (s *Store) ForgetByTag(tag string) (int, error) {
+	if tag == "" {
+		return 0, fmt.Errorf("tag is required")
+	}
+	removed, err := s.forgetWhere(memoryFilter("", tag, ""))
+	return len(removed), err
+}
+
+// ForgetByQuery removes every memory Recall would match for query and
+// returns them. Preview with Recall(query, "", "", 0) to confirm first.
+func (s *Store) ForgetByQuery(query string) ([]Memory, error) {
+	if query == "" {
+		return nil, fmt.Errorf("query is required")
+	}
+	return s.forgetWhere(memoryFilter(query, "", ""))
+}
+
+// forgetWhere deletes the memories matching where and returns them
+func (s *Store) forgetWhere(where string, args []interface{}) ([]Memory, error) {
+	removed, err := selectMemories(s.db, where, 0, args...)
+	if err != nil || len(removed) == 0 {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	for _, mem := range removed {
+		if _, err := tx.Exec(`DELETE FROM memories WHERE id = ?`, mem.ID); err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to delete memory: %w", err)
 		}
 	}
-	return fmt.Errorf("memory not found: %s", id)
+	return removed, tx.Commit()
 }
 
 // Count returns the total number of memories
 func (s *Store) Count() int {
-	return len(s.memories)
+	var n int
+	if err := s.db.QueryRow(`SELECT count(*) FROM memories`).Scan(&n); err != nil {
+		return 0
+	}
+	return n
 }
 
 // Tags returns all unique tags used
 func (s *Store) Tags() []string {
-	tagSet := make(map[string]bool)
-	for _, mem := range s.memories {
-		for _, tag := range mem.Tags {
-			tagSet[tag] = true
-		}
+	return s.distinct(`SELECT DISTINCT value FROM memories, json_each(memories.tags) ORDER BY value`)
+}
+
+// Projects returns all unique project scopes used
+func (s *Store) Projects() []string {
+	return s.distinct(`SELECT DISTINCT project FROM memories WHERE project != '' ORDER BY project`)
+}
+
+// distinct runs a one-column string query, returning nil on error
+func (s *Store) distinct(q string) []string {
+	rows, err := s.db.Query(q)
+	if err != nil {
+		return nil
 	}
+	defer rows.Close()
 
-	var tags []string
-	for tag := range tagSet {
-		tags = append(tags, tag)
+	var values []string
+	for rows.Next() {
+		var v string
+		if rows.Scan(&v) == nil {
+			values = append(values, v)
+		}
 	}
-	sort.Strings(tags)
-	return tags
+	return values
 }
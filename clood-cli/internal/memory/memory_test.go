@@ -0,0 +1,270 @@
+package memory
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCosineSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b []float64
+		want float64
+	}{
+		{[]float64{1, 0}, []float64{1, 0}, 1},
+		{[]float64{1, 0}, []float64{0, 1}, 0},
+		{[]float64{1, 0}, []float64{-1, 0}, -1},
+		{[]float64{1, 2}, []float64{1}, 0},
+		{[]float64{0, 0}, []float64{1, 1}, 0},
+	}
+	for _, tt := range tests {
+		if got := CosineSimilarity(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("CosineSimilarity(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestRecallSemanticCachesEmbeddings(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	store, err := NewStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.Store("login token expired after an hour", []string{"auth"}, "", "")
+	store.Store("ollama runs on the mac mini", []string{"infra"}, "", "")
+
+	calls := 0
+	embed := func(text string) ([]float64, error) {
+		calls++
+		if strings.Contains(text, "auth") || strings.Contains(text, "login") {
+			return []float64{1, 0.1}, nil
+		}
+		return []float64{0.1, 1}, nil
+	}
+
+	results, err := store.RecallSemantic("auth bug", "", "", "test-embed", 1, embed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || !strings.Contains(results[0].Content, "login") {
+		t.Fatalf("got %+v, want the login memory first", results)
+	}
+	if calls != 3 {
+		t.Errorf("first recall made %d embed calls, want 3", calls)
+	}
+
+	// Reopen: cached vectors should survive, leaving only the query to embed
+	store, err = NewStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls = 0
+	if _, err := store.RecallSemantic("auth bug", "", "", "test-embed", 1, embed); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Errorf("second recall made %d embed calls, want 1", calls)
+	}
+}
+
+func TestRecallFiltersByProject(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	store, err := NewStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.Store("use cobra for subcommands", nil, "", "clood")
+	store.Store("use click for subcommands", nil, "", "pytool")
+	store.Store("subcommands should have examples", nil, "", "")
+
+	got := store.Recall("subcommands", "", "Clood", 0)
+	if len(got) != 1 || got[0].Project != "clood" {
+		t.Fatalf("Recall with project = %+v, want only the clood memory", got)
+	}
+
+	if all := store.List("", "", 0); len(all) != 3 {
+		t.Errorf("List without project returned %d memories, want 3", len(all))
+	}
+
+	projects := store.Projects()
+	if len(projects) != 2 || projects[0] != "clood" || projects[1] != "pytool" {
+		t.Errorf("Projects() = %v, want [clood pytool]", projects)
+	}
+}
+
+func TestForgetByTagAndQuery(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	store, err := NewStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.Store("staging db is on port 5433", []string{"project-x"}, "", "")
+	store.Store("project-x deploys on fridays", []string{"Project-X", "ops"}, "", "")
+	store.Store("old project used make", []string{"build"}, "", "")
+	store.Store("keep this one", nil, "", "")
+
+	n, err := store.ForgetByTag("project-x")
+	if err != nil || n != 2 {
+		t.Fatalf("ForgetByTag = %d, %v; want 2", n, err)
+	}
+
+	removed, err := store.ForgetByQuery("OLD PROJECT")
+	if err != nil || len(removed) != 1 || removed[0].Content != "old project used make" {
+		t.Fatalf("ForgetByQuery = %+v, %v", removed, err)
+	}
+	if removed, _ := store.ForgetByQuery("nothing matches"); removed != nil {
+		t.Errorf("no-match ForgetByQuery removed %+v", removed)
+	}
+
+	// Deletions are saved
+	store, err = NewStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if all := store.List("", "", 0); len(all) != 1 || all[0].Content != "keep this one" {
+		t.Errorf("after forgetting: %+v", all)
+	}
+}
+
+func TestNewStoreImportsLegacyJSON(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	legacy := `[
+		{"id": "aaaa1111", "content": "tortoises hibernate in winter", "tags": ["pets"], "created_at": "2025-01-02T03:04:05Z"},
+		{"id": "bbbb2222", "title": "Deploys", "content": "ship on tuesdays", "project": "clood",
+		 "created_at": "2025-02-03T04:05:06Z", "embeddings": {"m": [1, 0]}}
+	]`
+	dir := filepath.Join(home, ".clood")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "memory.json"), []byte(legacy), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := NewStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	all := store.List("", "", 0)
+	if len(all) != 2 || all[0].ID != "bbbb2222" || all[1].ID != "aaaa1111" {
+		t.Fatalf("imported %+v, want both memories newest first", all)
+	}
+	if all[0].Title != "Deploys" || all[0].Project != "clood" || len(all[0].Embeddings["m"]) != 2 {
+		t.Errorf("fields lost in import: %+v", all[0])
+	}
+	if !all[1].CreatedAt.Equal(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("created_at = %v", all[1].CreatedAt)
+	}
+	if got := store.Recall("hibernate", "pets", "", 0); len(got) != 1 {
+		t.Errorf("imported memory isn't searchable: %+v", got)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "memory.json")); !os.IsNotExist(err) {
+		t.Error("memory.json should be renamed once imported")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "memory.json.migrated")); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRecallFullText(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	store, err := NewStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	store.Store("project-x deploys on fridays", nil, "", "")
+	store.Store(`the "fast path" skips validation`, nil, "ci notes", "")
+	store.Store("unrelated", nil, "", "")
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"deploy", 1},          // prefix of deploys
+		{"FRIDAYS project", 1}, // every word, any order, any case
+		{"project-x", 1},       // punctuation isn't query syntax
+		{`"fast`, 1},           // nor is a stray quote
+		{"ci", 1},              // context is indexed
+		{"deploys validation", 0},
+		{"--", 0},
+	}
+	for _, tt := range tests {
+		if got := store.Recall(tt.query, "", "", 0); len(got) != tt.want {
+			t.Errorf("Recall(%q) = %d memories, want %d", tt.query, len(got), tt.want)
+		}
+	}
+
+	// Forgetting drops the memory from the index too
+	if _, err := store.ForgetByQuery("fridays"); err != nil {
+		t.Fatal(err)
+	}
+	if got := store.Recall("deploys", "", "", 0); len(got) != 0 {
+		t.Errorf("forgotten memory still matches: %+v", got)
+	}
+}
+
+func TestOpenShared(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "team.db")
+
+	a, err := OpenShared(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer a.Close()
+	b, err := OpenShared(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+
+	var mode string
+	if err := a.db.QueryRow(`PRAGMA journal_mode`).Scan(&mode); err != nil || mode != "delete" {
+		t.Errorf("journal_mode = %q (%v), want delete", mode, err)
+	}
+
+	// Each connection sees what the other wrote
+	if _, err := a.Store("we deploy on fridays", []string{"team"}, "", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Store("never deploy on fridays", nil, "", ""); err != nil {
+		t.Fatal(err)
+	}
+	got := a.Recall("fridays", "", "", 0)
+	if len(got) != 2 || !got[0].Shared {
+		t.Errorf("recall = %+v, want both memories marked shared", got)
+	}
+
+	if _, err := OpenShared(filepath.Join(t.TempDir(), "missing", "team.db")); err == nil {
+		t.Error("OpenShared in a missing directory should fail")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	now := time.Now()
+	local := []Memory{{ID: "a", CreatedAt: now.Add(-2 * time.Hour)}, {ID: "b", CreatedAt: now}}
+	shared := []Memory{{ID: "c", CreatedAt: now.Add(-time.Hour), Shared: true}, {ID: "a", CreatedAt: now, Shared: true}}
+
+	var ids []string
+	for _, mem := range Merge(0, local, shared) {
+		ids = append(ids, mem.ID)
+	}
+	if strings.Join(ids, ",") != "b,c,a" {
+		t.Errorf("Merge = %v, want b,c,a", ids)
+	}
+	if got := Merge(2, local, shared); len(got) != 2 {
+		t.Errorf("Merge with limit 2 returned %d", len(got))
+	}
+}
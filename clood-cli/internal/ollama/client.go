@@ -3,6 +3,7 @@ package ollama
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -148,11 +149,24 @@ func (c *Client) GenerateWithOptions(model, prompt string, opts *GenerateOptions
 
 // GenerateWithSystem sends a prompt with a system prompt and returns the full response
 func (c *Client) GenerateWithSystem(model, system, prompt string) (*GenerateResponse, error) {
+	return c.GenerateWithSystemContext(context.Background(), model, system, prompt)
+}
+
+// GenerateWithSystemContext is GenerateWithSystem with cancellation/deadline support
+func (c *Client) GenerateWithSystemContext(ctx context.Context, model, system, prompt string) (*GenerateResponse, error) {
+	return c.GenerateWithSystemOptions(ctx, model, system, prompt, nil)
+}
+
+// GenerateWithSystemOptions is GenerateWithSystemContext with Ollama
+// request options (temperature, num_predict, stop, ...); nil uses the
+// model's defaults
+func (c *Client) GenerateWithSystemOptions(ctx context.Context, model, system, prompt string, options map[string]interface{}) (*GenerateResponse, error) {
 	req := GenerateRequest{
-		Model:  model,
-		System: system,
-		Prompt: prompt,
-		Stream: false,
+		Model:   model,
+		System:  system,
+		Prompt:  prompt,
+		Stream:  false,
+		Options: options,
 	}
 
 	body, err := json.Marshal(req)
@@ -160,7 +174,7 @@ func (c *Client) GenerateWithSystem(model, system, prompt string) (*GenerateResp
 		return nil, fmt.Errorf("marshal request: %w", err)
 	}
 
-	resp, err := c.HTTPClient.Post(c.BaseURL+"/api/generate", "application/json", bytes.NewReader(body))
+	resp, err := c.post(ctx, c.HTTPClient, "/api/generate", body)
 	if err != nil {
 		return nil, fmt.Errorf("post request: %w", err)
 	}
@@ -233,11 +247,25 @@ func (c *Client) GenerateStream(model, prompt string, callback func(chunk Genera
 
 // GenerateStreamWithSystem sends a prompt with system prompt and streams the response
 func (c *Client) GenerateStreamWithSystem(model, system, prompt string, callback func(chunk GenerateResponse)) (*GenerateResponse, error) {
+	return c.GenerateStreamWithSystemContext(context.Background(), model, system, prompt, callback)
+}
+
+// GenerateStreamWithSystemContext is GenerateStreamWithSystem with
+// cancellation/deadline support; ctx bounds the whole stream, not just
+// the time to first byte
+func (c *Client) GenerateStreamWithSystemContext(ctx context.Context, model, system, prompt string, callback func(chunk GenerateResponse)) (*GenerateResponse, error) {
+	return c.GenerateStreamWithSystemOptions(ctx, model, system, prompt, nil, callback)
+}
+
+// GenerateStreamWithSystemOptions is GenerateStreamWithSystemContext with
+// Ollama request options, as for GenerateWithSystemOptions
+func (c *Client) GenerateStreamWithSystemOptions(ctx context.Context, model, system, prompt string, options map[string]interface{}, callback func(chunk GenerateResponse)) (*GenerateResponse, error) {
 	req := GenerateRequest{
-		Model:  model,
-		System: system,
-		Prompt: prompt,
-		Stream: true,
+		Model:   model,
+		System:  system,
+		Prompt:  prompt,
+		Stream:  true,
+		Options: options,
 	}
 
 	body, err := json.Marshal(req)
@@ -247,7 +275,7 @@ func (c *Client) GenerateStreamWithSystem(model, system, prompt string, callback
 
 	// Use a client without timeout for streaming
 	streamClient := &http.Client{}
-	resp, err := streamClient.Post(c.BaseURL+"/api/generate", "application/json", bytes.NewReader(body))
+	resp, err := c.post(ctx, streamClient, "/api/generate", body)
 	if err != nil {
 		return nil, fmt.Errorf("post request: %w", err)
 	}
@@ -285,7 +313,12 @@ func (c *Client) GenerateStreamWithSystem(model, system, prompt string, callback
 
 // ListModels returns all models available on this Ollama instance
 func (c *Client) ListModels() ([]Model, error) {
-	resp, err := c.HTTPClient.Get(c.BaseURL + "/api/tags")
+	return c.ListModelsContext(context.Background())
+}
+
+// ListModelsContext is ListModels with cancellation/deadline support
+func (c *Client) ListModelsContext(ctx context.Context) ([]Model, error) {
+	resp, err := c.get(ctx, "/api/tags")
 	if err != nil {
 		return nil, fmt.Errorf("get tags: %w", err)
 	}
@@ -304,9 +337,53 @@ func (c *Client) ListModels() ([]Model, error) {
 	return result.Models, nil
 }
 
+// RunningModel is a model currently loaded in memory, from /api/ps
+type RunningModel struct {
+	Name      string    `json:"name"`
+	Size      int64     `json:"size"`       // total bytes in memory
+	SizeVRAM  int64     `json:"size_vram"`  // bytes offloaded to the GPU
+	ExpiresAt time.Time `json:"expires_at"` // when keep_alive unloads it
+}
+
+// PsResponse is the response from /api/ps
+type PsResponse struct {
+	Models []RunningModel `json:"models"`
+}
+
+// RunningModels lists models currently loaded by the server
+func (c *Client) RunningModels() ([]RunningModel, error) {
+	return c.RunningModelsContext(context.Background())
+}
+
+// RunningModelsContext is RunningModels with cancellation/deadline support
+func (c *Client) RunningModelsContext(ctx context.Context) ([]RunningModel, error) {
+	resp, err := c.get(ctx, "/api/ps")
+	if err != nil {
+		return nil, fmt.Errorf("get ps: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("ollama returned %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var result PsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	return result.Models, nil
+}
+
 // Version returns the Ollama version
 func (c *Client) Version() (string, error) {
-	resp, err := c.HTTPClient.Get(c.BaseURL + "/api/version")
+	return c.VersionContext(context.Background())
+}
+
+// VersionContext is Version with cancellation/deadline support
+func (c *Client) VersionContext(ctx context.Context) (string, error) {
+	resp, err := c.get(ctx, "/api/version")
 	if err != nil {
 		return "", fmt.Errorf("get version: %w", err)
 	}
@@ -326,9 +403,14 @@ func (c *Client) Version() (string, error) {
 
 // Ping checks if the Ollama server is reachable
 func (c *Client) Ping() (time.Duration, error) {
+	return c.PingContext(context.Background())
+}
+
+// PingContext is Ping with cancellation/deadline support
+func (c *Client) PingContext(ctx context.Context) (time.Duration, error) {
 	start := time.Now()
 
-	resp, err := c.HTTPClient.Get(c.BaseURL + "/api/tags")
+	resp, err := c.get(ctx, "/api/tags")
 	if err != nil {
 		return 0, err
 	}
@@ -341,6 +423,65 @@ func (c *Client) Ping() (time.Duration, error) {
 	return time.Since(start), nil
 }
 
+// get issues a GET against the API honoring ctx
+func (c *Client) get(ctx context.Context, path string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.HTTPClient.Do(req)
+}
+
+// post issues a JSON POST against the API with the given HTTP client, honoring ctx
+func (c *Client) post(ctx context.Context, client *http.Client, path string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return client.Do(req)
+}
+
+// EmbeddingsRequest is the request body for /api/embeddings
+type EmbeddingsRequest struct {
+	Model  string `json:"model"`
+	Prompt string `json:"prompt"`
+}
+
+// EmbeddingsResponse is the response from /api/embeddings
+type EmbeddingsResponse struct {
+	Embedding []float64 `json:"embedding"`
+}
+
+// Embeddings returns the embedding vector for prompt using an embedding
+// model such as nomic-embed-text
+func (c *Client) Embeddings(model, prompt string) ([]float64, error) {
+	body, err := json.Marshal(EmbeddingsRequest{Model: model, Prompt: prompt})
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+
+	resp, err := c.HTTPClient.Post(c.BaseURL+"/api/embeddings", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("post request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("ollama returned %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var result EmbeddingsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	if len(result.Embedding) == 0 {
+		return nil, fmt.Errorf("model %s returned no embedding (is it an embedding model?)", model)
+	}
+	return result.Embedding, nil
+}
+
 // HasModel checks if a specific model is available
 func (c *Client) HasModel(name string) (bool, error) {
 	models, err := c.ListModels()
@@ -368,6 +509,7 @@ type PullResponse struct {
 	Digest    string `json:"digest,omitempty"`
 	Total     int64  `json:"total,omitempty"`
 	Completed int64  `json:"completed,omitempty"`
+	Error     string `json:"error,omitempty"`
 }
 
 // Pull downloads a model, calling the callback for progress updates
@@ -404,6 +546,9 @@ func (c *Client) Pull(model string, callback func(status string, completed, tota
 		if err := json.Unmarshal(scanner.Bytes(), &chunk); err != nil {
 			continue
 		}
+		if chunk.Error != "" {
+			return fmt.Errorf("pull %s: %s", model, chunk.Error)
+		}
 		if callback != nil {
 			callback(chunk.Status, chunk.Completed, chunk.Total)
 		}
@@ -0,0 +1,44 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dirtybirdnj/clood/internal/tui"
+)
+
+// DryRun makes destructive or expensive commands describe what they would
+// do and return instead of doing it. Set via --dry-run on the root command;
+// commands with their own --dry-run flag keep their own meaning for it.
+var DryRun bool
+
+// WouldHaveDone reports an action skipped because of --dry-run: a "would
+// have done" line and its parameters, or a JSON object in JSON mode.
+func WouldHaveDone(action string, params map[string]interface{}) error {
+	if IsJSON() {
+		return JSON(map[string]interface{}{
+			"status":          "dry_run",
+			"would_have_done": action,
+			"params":          params,
+		})
+	}
+	fmt.Println(DescribeDryRun(action, params))
+	return nil
+}
+
+// DescribeDryRun formats an action and its parameters as plain text, one
+// parameter per line in name order
+func DescribeDryRun(action string, params map[string]interface{}) string {
+	var b strings.Builder
+	b.WriteString(tui.WarningStyle.Render("would have done: ") + action)
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&b, "\n  %s %v", tui.MutedStyle.Render(name+":"), params[name])
+	}
+	return b.String()
+}
@@ -6,17 +6,90 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 )
 
-// JSONMode indicates whether to output JSON instead of human-readable format.
-// Set via --json flag on root command.
-var JSONMode bool
+// Mode selects how JSON is written
+type Mode int
 
-// JSON outputs data as JSON to stdout.
+const (
+	// Off prints human-readable output
+	Off Mode = iota
+	// Pretty prints indented JSON documents
+	Pretty
+	// NDJSON prints each JSON value compactly on its own line, for
+	// streaming through jq and friends
+	NDJSON
+)
+
+// String returns the name --json accepts for m
+func (m Mode) String() string {
+	switch m {
+	case Pretty:
+		return "pretty"
+	case NDJSON:
+		return "ndjson"
+	default:
+		return "off"
+	}
+}
+
+// Set parses a --json value. It implements pflag.Value, so JSONMode can
+// back a flag directly.
+func (m *Mode) Set(s string) error {
+	switch s {
+	case "pretty", "true":
+		*m = Pretty
+	case "ndjson", "stream":
+		*m = NDJSON
+	case "off", "false":
+		*m = Off
+	default:
+		return fmt.Errorf("must be pretty, ndjson or off")
+	}
+	return nil
+}
+
+// Type is the value name shown in --help
+func (m *Mode) Type() string {
+	return "mode"
+}
+
+// StreamFlag backs a boolean --json-stream flag that switches JSONMode
+// to NDJSON
+type StreamFlag struct{}
+
+// Set implements pflag.Value
+func (StreamFlag) Set(s string) error {
+	on, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	if on {
+		JSONMode = NDJSON
+	} else if JSONMode == NDJSON {
+		JSONMode = Off
+	}
+	return nil
+}
+
+func (StreamFlag) String() string { return strconv.FormatBool(JSONMode == NDJSON) }
+
+// Type reports bool so --help shows the flag without a value
+func (StreamFlag) Type() string { return "bool" }
+
+// JSONMode selects JSON instead of human-readable output.
+// Set via --json (pretty), --json=ndjson or --json-stream on the root command.
+var JSONMode Mode
+
+// JSON outputs data as JSON to stdout in the current mode, indented
+// unless streaming NDJSON.
 // Returns error if marshaling fails.
 func JSON(v interface{}) error {
 	enc := json.NewEncoder(os.Stdout)
-	enc.SetIndent("", "  ")
+	if JSONMode != NDJSON {
+		enc.SetIndent("", "  ")
+	}
 	return enc.Encode(v)
 }
 
@@ -25,6 +98,15 @@ func JSONCompact(v interface{}) error {
 	return json.NewEncoder(os.Stdout).Encode(v)
 }
 
+// Marshal encodes v for stdout in the current mode: indented, or on one
+// line when streaming NDJSON
+func Marshal(v interface{}) ([]byte, error) {
+	if JSONMode == NDJSON {
+		return json.Marshal(v)
+	}
+	return json.MarshalIndent(v, "", "  ")
+}
+
 // MustJSON outputs data as JSON, panics on error.
 func MustJSON(v interface{}) {
 	if err := JSON(v); err != nil {
@@ -34,5 +116,5 @@ func MustJSON(v interface{}) {
 
 // IsJSON returns true if JSON output mode is enabled.
 func IsJSON() bool {
-	return JSONMode
+	return JSONMode != Off
 }
@@ -347,19 +347,29 @@ type RouteResult struct {
 type Router struct {
 	config  *config.Config
 	manager *hosts.Manager
+	balance hosts.BalanceStrategy
 }
 
-// NewRouter creates a new router with the given config
-func NewRouter(cfg *config.Config) *Router {
-	mgr := hosts.NewManager()
+// NewRouter creates a new router with the given config. Hosts that share a
+// model are chosen between using routing.strategy.
+func NewRouter(cfg *config.Config, opts ...hosts.ManagerOption) *Router {
+	mgr := hosts.NewManager(opts...)
 	mgr.AddHosts(cfg.Hosts)
 
+	balance, _ := hosts.ParseBalanceStrategy(cfg.Routing.Strategy)
+
 	return &Router{
 		config:  cfg,
 		manager: mgr,
+		balance: balance,
 	}
 }
 
+// SetBalance overrides the strategy from routing.strategy
+func (r *Router) SetBalance(strategy hosts.BalanceStrategy) {
+	r.balance = strategy
+}
+
 // Route determines the best host and model for a query
 func (r *Router) Route(query string, forceTier int, forceModel string) (*RouteResult, error) {
 	result := &RouteResult{}
@@ -380,7 +390,7 @@ func (r *Router) Route(query string, forceTier int, forceModel string) (*RouteRe
 	}
 
 	// Find the best host with this model
-	hostStatus := r.manager.GetHostWithModel(result.Model)
+	hostStatus := r.manager.GetHostWithModelBalanced(result.Model, r.balance)
 	if hostStatus != nil {
 		result.Host = hostStatus
 		result.Client = r.manager.GetClient(hostStatus.Host.Name)
@@ -391,7 +401,7 @@ func (r *Router) Route(query string, forceTier int, forceModel string) (*RouteRe
 	if r.config.Routing.Fallback {
 		fallbackModel := r.config.GetTierFallback(result.Tier)
 		if fallbackModel != "" {
-			hostStatus = r.manager.GetHostWithModel(fallbackModel)
+			hostStatus = r.manager.GetHostWithModelBalanced(fallbackModel, r.balance)
 			if hostStatus != nil {
 				result.Model = fallbackModel // Switch to fallback model
 				result.Host = hostStatus
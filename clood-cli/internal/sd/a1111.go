@@ -0,0 +1,146 @@
+package sd
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ParseLocalPNG reads the generation parameters AUTOMATIC1111 and Forge
+// embed in a PNG's "parameters" text chunk.
+func (p *MultiSourceParser) ParseLocalPNG(path string) (*ImageSource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	raw, ok := pngTextChunks(bytes.NewReader(data))["parameters"]
+	if !ok {
+		return nil, fmt.Errorf("%s has no A1111 generation parameters", path)
+	}
+
+	src, err := ParseA1111Parameters(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	src.URL = path
+	src.ImageURL = path
+	src.ID = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if src.Width == 0 || src.Height == 0 {
+		if cfg, err := png.DecodeConfig(bytes.NewReader(data)); err == nil {
+			src.Width, src.Height = cfg.Width, cfg.Height
+		}
+	}
+	return src, nil
+}
+
+var a1111LoRARe = regexp.MustCompile(`<lora:([^:>]+)(?::([-0-9.]+))?[^>]*>`)
+
+// ParseA1111Parameters parses A1111's infotext: the prompt, an optional
+// "Negative prompt:" section, and a last line of "Key: value" settings
+// starting with "Steps:". Inline <lora:name:weight> tags are moved out of
+// the prompt into LoRAs.
+func ParseA1111Parameters(text string) (*ImageSource, error) {
+	lines := strings.Split(strings.TrimSpace(strings.ReplaceAll(text, "\r\n", "\n")), "\n")
+	if len(lines) == 0 || lines[0] == "" {
+		return nil, fmt.Errorf("empty generation parameters")
+	}
+
+	var settings map[string]string
+	if last := lines[len(lines)-1]; strings.HasPrefix(last, "Steps:") {
+		settings = parseA1111Settings(last)
+		lines = lines[:len(lines)-1]
+	}
+
+	var prompt, negative []string
+	inNegative := false
+	for _, line := range lines {
+		if rest, ok := strings.CutPrefix(line, "Negative prompt:"); ok {
+			inNegative = true
+			line = strings.TrimSpace(rest)
+		}
+		if inNegative {
+			negative = append(negative, line)
+		} else {
+			prompt = append(prompt, line)
+		}
+	}
+
+	src := &ImageSource{
+		Source:   "a1111",
+		Prompt:   strings.TrimSpace(strings.Join(prompt, "\n")),
+		Negative: strings.TrimSpace(strings.Join(negative, "\n")),
+		Seed:     -1,
+	}
+	for _, m := range a1111LoRARe.FindAllStringSubmatch(src.Prompt, -1) {
+		weight := 1.0
+		if w, err := strconv.ParseFloat(m[2], 64); err == nil {
+			weight = w
+		}
+		src.LoRAs = append(src.LoRAs, LoRA{Name: m[1], Weight: weight})
+	}
+	if len(src.LoRAs) > 0 {
+		src.Prompt = strings.Join(strings.Fields(a1111LoRARe.ReplaceAllString(src.Prompt, "")), " ")
+		src.Prompt = strings.TrimSpace(strings.ReplaceAll(src.Prompt, " ,", ","))
+	}
+
+	src.Model = settings["Model"]
+	src.Sampler = settings["Sampler"]
+	if sched := settings["Schedule type"]; strings.EqualFold(sched, "karras") && !strings.HasSuffix(strings.ToLower(src.Sampler), " karras") {
+		// Forge reports the scheduler separately ("Sampler: DPM++ 2M,
+		// Schedule type: Karras"); fold it back into the A1111 name
+		src.Sampler += " Karras"
+	}
+	if v, err := strconv.ParseInt(settings["Seed"], 10, 64); err == nil {
+		src.Seed = v
+	}
+	if v, err := strconv.Atoi(settings["Steps"]); err == nil {
+		src.Steps = v
+	}
+	if v, err := strconv.ParseFloat(settings["CFG scale"], 64); err == nil {
+		src.CFGScale = v
+	}
+	if w, h, ok := parseSize(settings["Size"]); ok {
+		src.Width, src.Height = w, h
+	}
+	return src, nil
+}
+
+// parseA1111Settings splits "Steps: 20, Sampler: Euler a, Lora hashes:
+// "a: 1, b: 2"" into keys and values; quoted values may contain commas.
+func parseA1111Settings(line string) map[string]string {
+	settings := map[string]string{}
+	for len(line) > 0 {
+		key, rest, ok := strings.Cut(line, ":")
+		if !ok {
+			break
+		}
+		key = strings.TrimSpace(key)
+		rest = strings.TrimLeft(rest, " ")
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			if end := strings.Index(rest[1:], `"`); end >= 0 {
+				value, rest = rest[1:end+1], rest[end+2:]
+				if _, after, ok := strings.Cut(rest, ","); ok {
+					rest = after
+				} else {
+					rest = ""
+				}
+			} else {
+				value, rest = rest, ""
+			}
+		} else if v, after, ok := strings.Cut(rest, ","); ok {
+			value, rest = v, after
+		} else {
+			value, rest = rest, ""
+		}
+		settings[key] = strings.TrimSpace(value)
+		line = rest
+	}
+	return settings
+}
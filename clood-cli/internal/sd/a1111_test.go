@@ -0,0 +1,83 @@
+package sd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const a1111Fixture = `masterpiece, a lighthouse at dusk <lora:add_detail:0.6>, <lora:ghibli_style:1>
+dramatic sky
+Negative prompt: blurry, lowres
+Steps: 28, Sampler: DPM++ 2M, Schedule type: Karras, CFG scale: 6.5, Seed: 1234567890, Size: 832x1216, Model hash: 879db523c3, Model: dreamshaper_8, Lora hashes: "add_detail: 7c6bad76eb54, ghibli_style: 1a2b3c", Version: f0.0.17`
+
+func TestParseA1111Parameters(t *testing.T) {
+	src, err := ParseA1111Parameters(a1111Fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if src.Prompt != "masterpiece, a lighthouse at dusk, dramatic sky" {
+		t.Errorf("Prompt = %q", src.Prompt)
+	}
+	if src.Negative != "blurry, lowres" {
+		t.Errorf("Negative = %q", src.Negative)
+	}
+	if src.Steps != 28 || src.CFGScale != 6.5 || src.Seed != 1234567890 || src.Width != 832 || src.Height != 1216 {
+		t.Errorf("settings = %+v", src)
+	}
+	if src.Model != "dreamshaper_8" || src.Sampler != "DPM++ 2M Karras" {
+		t.Errorf("Model = %q, Sampler = %q", src.Model, src.Sampler)
+	}
+	if len(src.LoRAs) != 2 || src.LoRAs[0] != (LoRA{Name: "add_detail", Weight: 0.6}) || src.LoRAs[1].Name != "ghibli_style" {
+		t.Errorf("LoRAs = %+v", src.LoRAs)
+	}
+
+	cfg := src.WorkflowConfig("")
+	if cfg.Sampler != "dpmpp_2m" || cfg.Scheduler != "karras" || len(cfg.Prompt.LoRAs) != 2 {
+		t.Errorf("config = %+v, prompt = %+v", cfg, cfg.Prompt)
+	}
+
+	if _, err := ParseA1111Parameters("  "); err == nil {
+		t.Error("expected an error for empty parameters")
+	}
+}
+
+func TestParseA1111SettingsQuoted(t *testing.T) {
+	got := parseA1111Settings(`Steps: 20, Lora hashes: "a: 1, b: 2", Seed: 5`)
+	if got["Steps"] != "20" || got["Lora hashes"] != "a: 1, b: 2" || got["Seed"] != "5" {
+		t.Errorf("settings = %#v", got)
+	}
+}
+
+func TestParseLocalPNG(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "00042-1234.png")
+	if err := os.WriteFile(path, pngWithText(t, "parameters", "a cat\nSteps: 20, Sampler: Euler a, Seed: 7, Model: sdxl"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := NewMultiSourceParser().Parse(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if src.Source != "a1111" || src.ID != "00042-1234" || src.URL != path {
+		t.Errorf("source = %+v", src)
+	}
+	// No Size in the parameters, so it comes from the image header
+	if src.Prompt != "a cat" || src.Seed != 7 || src.Width != 4 || src.Height != 3 {
+		t.Errorf("source = %+v", src)
+	}
+
+	dest := filepath.Join(dir, "copy"+src.SourceImageExt())
+	if err := src.DownloadImage(nil, dest); err != nil {
+		t.Fatalf("DownloadImage: %v", err)
+	}
+
+	plain := filepath.Join(dir, "plain.png")
+	if err := os.WriteFile(plain, pngWithText(t, "Software", "x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewMultiSourceParser().ParseLocalPNG(plain); err == nil {
+		t.Error("expected an error for a PNG without parameters")
+	}
+}
@@ -0,0 +1,169 @@
+package sd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// RunBatch generates every variation in batch through ComfyUI, up to
+// batch.Parallel at a time, and downloads the results into batch.OutputDir.
+// Results keep the order of batch.Variations. Each result's OutputPath is
+// relative to OutputDir so the HTML gallery can live there.
+// onResult, if set, is called as each variation finishes (never concurrently).
+func RunBatch(client *Client, batch *BatchConfig, onResult func(VariationResult)) (*BatchResult, error) {
+	if err := os.MkdirAll(batch.OutputDir, 0755); err != nil {
+		return nil, fmt.Errorf("create output dir: %w", err)
+	}
+
+	timeout := batch.Timeout
+	if timeout <= 0 {
+		timeout = 5 * time.Minute
+	}
+	parallel := batch.Parallel
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	result := &BatchResult{
+		Config:    batch,
+		StartTime: time.Now(),
+		Results:   make([]VariationResult, len(batch.Variations)),
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	sem := make(chan struct{}, parallel)
+	for i, v := range batch.Variations {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, v Variation) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			vr := runVariation(client, batch, v, timeout)
+
+			mu.Lock()
+			defer mu.Unlock()
+			result.Results[i] = vr
+			if onResult != nil {
+				onResult(vr)
+			}
+		}(i, v)
+	}
+	wg.Wait()
+
+	result.EndTime = time.Now()
+	result.TotalTime = result.EndTime.Sub(result.StartTime)
+	return result, nil
+}
+
+func runVariation(client *Client, batch *BatchConfig, v Variation, timeout time.Duration) VariationResult {
+	cfg := variationConfig(batch.BasePrompt, v)
+	cfg.ControlNets = batch.ControlNets
+	cfg.LoRASchedules = batch.LoRASchedules
+	vr := VariationResult{
+		Variation: v,
+		Metadata: ImageMetadata{
+			Seed:       cfg.Prompt.Seed,
+			Steps:      cfg.Steps,
+			CFGScale:   cfg.CFGScale,
+			Sampler:    cfg.Sampler,
+			Checkpoint: cfg.Checkpoint,
+			LoRAs:      cfg.Prompt.LoRAs,
+			Width:      cfg.Width,
+			Height:     cfg.Height,
+			Prompt:     cfg.Prompt.FormatPositive(),
+			Original:   cfg.Prompt.Original,
+			Negative:   cfg.Prompt.Negative,
+		},
+	}
+
+	start := time.Now()
+	err := generateVariation(client, cfg, batch.OutputDir, timeout, &vr)
+	vr.GenerateTime = time.Since(start)
+	if err != nil {
+		vr.Error = err.Error()
+	} else {
+		vr.Success = true
+	}
+	return vr
+}
+
+func generateVariation(client *Client, cfg *WorkflowConfig, outDir string, timeout time.Duration, vr *VariationResult) error {
+	promptID, err := client.Generate(cfg)
+	if err != nil {
+		return err
+	}
+
+	images, err := client.Wait(promptID, timeout)
+	if err != nil {
+		return err
+	}
+	if len(images) == 0 {
+		return fmt.Errorf("prompt %s produced no images", promptID)
+	}
+
+	name := sanitizeVariationName(vr.Variation.Name) + filepath.Ext(images[0].Filename)
+	dest := filepath.Join(outDir, name)
+	if err := client.Download(images[0], dest); err != nil {
+		return err
+	}
+	vr.OutputPath = name
+
+	// The sidecar lets 'clood sd gallery' show how the image was made
+	vr.Metadata.CreatedAt = time.Now()
+	return WriteSidecar(dest, vr.Metadata)
+}
+
+// variationConfig applies a variation's overrides on top of the defaults
+func variationConfig(base *Prompt, v Variation) *WorkflowConfig {
+	prompt := *base
+	if v.Prompt != "" {
+		prompt.Positive = v.Prompt
+	}
+	if len(v.LoRAs) > 0 {
+		prompt.LoRAs = v.LoRAs
+	}
+
+	cfg := DefaultWorkflowConfig()
+	cfg.Prompt = &prompt
+	cfg.Checkpoint = v.Checkpoint
+	cfg.OutputPrefix = "clood_anvil"
+
+	native := NativeResolution(v.Checkpoint)
+	cfg.Width, cfg.Height = native, native
+	if v.Width != nil {
+		cfg.Width = *v.Width
+	}
+	if v.Height != nil {
+		cfg.Height = *v.Height
+	}
+	if v.Steps != nil {
+		cfg.Steps = *v.Steps
+	}
+	if v.CFGScale != nil {
+		cfg.CFGScale = *v.CFGScale
+	}
+	if v.Sampler != "" {
+		cfg.Sampler = v.Sampler
+	}
+	if v.Scheduler != "" {
+		cfg.Scheduler = v.Scheduler
+	}
+	return cfg
+}
+
+func sanitizeVariationName(name string) string {
+	out := []rune(name)
+	for i, r := range out {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+		default:
+			out[i] = '_'
+		}
+	}
+	return string(out)
+}
@@ -0,0 +1,92 @@
+package sd
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// attentionStep is what each bare pair of parentheses multiplies the
+// weight by, as in ComfyUI and A1111
+const attentionStep = 1.1
+
+// WeightedTerm is a stretch of prompt text whose attention weight isn't 1
+type WeightedTerm struct {
+	Text     string  `json:"text"`
+	Weight   float64 `json:"weight"`
+	Explicit bool    `json:"explicit"` // (text:1.3) rather than (text)
+}
+
+// AttentionTerms extracts the emphasis in a prompt the way ComfyUI's CLIP
+// encoder reads it: (text:1.3) sets a weight, bare (text) multiplies the
+// surrounding weight by 1.1, groups nest, and \( \) are literal. The
+// prompt itself is sent to ComfyUI unchanged; this is only for display.
+func AttentionTerms(prompt string) []WeightedTerm {
+	terms := []WeightedTerm{}
+	parseAttention(prompt, 1.0, false, &terms)
+	return terms
+}
+
+func parseAttention(s string, weight float64, explicit bool, terms *[]WeightedTerm) {
+	var plain strings.Builder
+	flush := func() {
+		text := strings.Trim(strings.TrimSpace(plain.String()), ",")
+		plain.Reset()
+		if text = strings.TrimSpace(text); text != "" && weight != 1.0 {
+			*terms = append(*terms, WeightedTerm{Text: text, Weight: roundWeight(weight), Explicit: explicit})
+		}
+	}
+
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\\':
+			if i+1 < len(s) {
+				i++
+				plain.WriteByte(s[i])
+			}
+		case '(':
+			end := matchingParen(s, i)
+			if end < 0 {
+				plain.WriteByte(c)
+				continue
+			}
+			flush()
+			inner := s[i+1 : end]
+			innerWeight, innerExplicit := weight*attentionStep, false
+			if k := strings.LastIndex(inner, ":"); k >= 0 {
+				if w, err := strconv.ParseFloat(strings.TrimSpace(inner[k+1:]), 64); err == nil {
+					inner, innerWeight, innerExplicit = inner[:k], w, true
+				}
+			}
+			parseAttention(inner, innerWeight, innerExplicit, terms)
+			i = end
+		default:
+			plain.WriteByte(c)
+		}
+	}
+	flush()
+}
+
+// matchingParen returns the index of the ) closing the ( at open, or -1
+func matchingParen(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// roundWeight drops the float noise from repeated 1.1 multiplication
+func roundWeight(w float64) float64 {
+	return math.Round(w*1000) / 1000
+}
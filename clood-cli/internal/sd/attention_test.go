@@ -0,0 +1,30 @@
+package sd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAttentionTerms(t *testing.T) {
+	tests := []struct {
+		prompt string
+		want   []WeightedTerm
+	}{
+		{"(tortoise:1.5), wearing (spectacles:0.8)", []WeightedTerm{
+			{Text: "tortoise", Weight: 1.5, Explicit: true},
+			{Text: "spectacles", Weight: 0.8, Explicit: true},
+		}},
+		{"a ((fox)) in snow", []WeightedTerm{{Text: "fox", Weight: 1.21}}},
+		{"(red panda, (scarf:1.3))", []WeightedTerm{
+			{Text: "red panda", Weight: 1.1},
+			{Text: "scarf", Weight: 1.3, Explicit: true},
+		}},
+		{`a \(literal\) paren, (unclosed`, []WeightedTerm{}},
+		{"plain prompt, no emphasis", []WeightedTerm{}},
+	}
+	for _, tt := range tests {
+		if got := AttentionTerms(tt.prompt); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("AttentionTerms(%q) = %+v, want %+v", tt.prompt, got, tt.want)
+		}
+	}
+}
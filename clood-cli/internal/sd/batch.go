@@ -9,23 +9,28 @@ import (
 // BatchConfig defines a set of variations to test against a single prompt.
 // This is the catfight arena configuration.
 type BatchConfig struct {
-	Name        string        `json:"name" yaml:"name"`
-	Description string        `json:"description,omitempty" yaml:"description,omitempty"`
-	BasePrompt  *Prompt       `json:"prompt" yaml:"prompt"`
-	Variations  []Variation   `json:"variations" yaml:"variations"`
-	OutputDir   string        `json:"output_dir" yaml:"output_dir"`
-	Timeout     time.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	Name        string             `json:"name" yaml:"name"`
+	Description string             `json:"description,omitempty" yaml:"description,omitempty"`
+	BasePrompt  *Prompt            `json:"prompt" yaml:"prompt"`
+	Variations  []Variation        `json:"variations" yaml:"variations"`
+	OutputDir   string             `json:"output_dir" yaml:"output_dir"`
+	Timeout     time.Duration      `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	Parallel    int                `json:"parallel,omitempty" yaml:"parallel,omitempty"`       // Variations in flight at once (default 1)
+	ControlNets []ControlNetConfig `json:"controlnets,omitempty" yaml:"controlnets,omitempty"` // Applied to every variation
+	LoRASchedules []LoRASchedule   `json:"lora_schedules,omitempty" yaml:"lora_schedules,omitempty"` // Applied to every variation
 }
 
 // Variation represents one contestant in the catfight.
 type Variation struct {
 	Name       string   `json:"name" yaml:"name"`             // e.g., "ghibli_high_weight"
 	Checkpoint string   `json:"checkpoint" yaml:"checkpoint"` // Override checkpoint
+	Prompt     string   `json:"prompt,omitempty" yaml:"prompt,omitempty"` // Override positive prompt
 	LoRAs      []LoRA   `json:"loras,omitempty" yaml:"loras,omitempty"`
 	LoRAWeight *float64 `json:"lora_weight,omitempty" yaml:"lora_weight,omitempty"` // Override base LoRA weight
 	Steps      *int     `json:"steps,omitempty" yaml:"steps,omitempty"`
 	CFGScale   *float64 `json:"cfg_scale,omitempty" yaml:"cfg_scale,omitempty"`
 	Sampler    string   `json:"sampler,omitempty" yaml:"sampler,omitempty"`
+	Scheduler  string   `json:"scheduler,omitempty" yaml:"scheduler,omitempty"`
 	Width      *int     `json:"width,omitempty" yaml:"width,omitempty"`
 	Height     *int     `json:"height,omitempty" yaml:"height,omitempty"`
 }
@@ -51,16 +56,19 @@ type VariationResult struct {
 
 // ImageMetadata stores info about the generated image.
 type ImageMetadata struct {
-	Seed       int64   `json:"seed"`
-	Steps      int     `json:"steps"`
-	CFGScale   float64 `json:"cfg_scale"`
-	Sampler    string  `json:"sampler"`
-	Checkpoint string  `json:"checkpoint"`
-	LoRAs      []LoRA  `json:"loras,omitempty"`
-	Width      int     `json:"width"`
-	Height     int     `json:"height"`
-	Prompt     string  `json:"prompt"`
-	Negative   string  `json:"negative"`
+	Seed       int64     `json:"seed"`
+	Steps      int       `json:"steps"`
+	CFGScale   float64   `json:"cfg_scale"`
+	Sampler    string    `json:"sampler"`
+	Checkpoint string    `json:"checkpoint"`
+	LoRAs      []LoRA    `json:"loras,omitempty"`
+	Width      int       `json:"width"`
+	Height     int       `json:"height"`
+	Prompt     string    `json:"prompt"`
+	Original   string    `json:"original_prompt,omitempty"` // before --enhance rewrote it
+	Negative   string    `json:"negative"`
+	CreatedAt  time.Time `json:"created_at,omitempty"`
+	Tags       []string  `json:"tags,omitempty"` // set with 'sd gallery tag'
 }
 
 // NewBatchConfig creates a batch configuration for catfighting.
@@ -0,0 +1,270 @@
+package sd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultCivitAIURL is the public CivitAI API. Public images need no auth.
+const DefaultCivitAIURL = "https://civitai.com/api/v1"
+
+// civitaiRetryDelay is the back-off before retrying a rate-limited request
+var civitaiRetryDelay = 5 * time.Second
+
+// ImageSource is generation metadata recovered from somewhere else,
+// ready to be remixed into a new workflow.
+type ImageSource struct {
+	Source   string  `json:"source"` // "civitai" or "a1111"
+	URL      string  `json:"url"`
+	ID       string  `json:"id,omitempty"`
+	ImageURL string  `json:"image_url,omitempty"`
+	Prompt   string  `json:"prompt"`
+	Negative string  `json:"negative,omitempty"`
+	Model    string  `json:"model,omitempty"`
+	Seed     int64   `json:"seed"`
+	Steps    int     `json:"steps,omitempty"`
+	CFGScale float64 `json:"cfg_scale,omitempty"`
+	Sampler  string  `json:"sampler,omitempty"` // as reported, e.g. "DPM++ 2M Karras"
+	Width    int     `json:"width,omitempty"`
+	Height   int     `json:"height,omitempty"`
+	LoRAs    []LoRA  `json:"loras,omitempty"`
+}
+
+// MultiSourceParser resolves image references into ImageSources.
+type MultiSourceParser struct {
+	CivitAIURL string
+	HTTPClient *http.Client
+}
+
+// NewMultiSourceParser creates a parser against the public APIs.
+func NewMultiSourceParser() *MultiSourceParser {
+	return &MultiSourceParser{
+		CivitAIURL: DefaultCivitAIURL,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Parse fetches the metadata behind ref: a CivitAI image page
+// (https://civitai.com/images/<id>) or a local PNG saved by A1111 or Forge.
+func (p *MultiSourceParser) Parse(ref string) (*ImageSource, error) {
+	if info, err := os.Stat(ref); err == nil && !info.IsDir() {
+		return p.ParseLocalPNG(ref)
+	}
+	id, ok := CivitAIImageID(ref)
+	if !ok {
+		return nil, fmt.Errorf("unsupported image source %q (expected a civitai.com/images/<id> URL or a local PNG)", ref)
+	}
+	src, err := p.fetchCivitAI(id)
+	if err != nil {
+		return nil, err
+	}
+	src.URL = ref
+	return src, nil
+}
+
+var civitaiImagePathRe = regexp.MustCompile(`^/images/(\d+)`)
+
+// CivitAIImageID extracts the image ID from a CivitAI image URL
+func CivitAIImageID(ref string) (string, bool) {
+	u, err := url.Parse(ref)
+	if err != nil || !strings.HasSuffix(u.Hostname(), "civitai.com") {
+		return "", false
+	}
+	if m := civitaiImagePathRe.FindStringSubmatch(u.Path); m != nil {
+		return m[1], true
+	}
+	if id := u.Query().Get("imageId"); id != "" {
+		if _, err := strconv.Atoi(id); err == nil {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+// civitaiMeta is the "meta" object CivitAI stores from the generator's PNG info.
+// Field names follow A1111's infotext, hence the mixed casing.
+type civitaiMeta struct {
+	Prompt         string      `json:"prompt"`
+	NegativePrompt string      `json:"negativePrompt"`
+	Model          string      `json:"Model"`
+	Seed           json.Number `json:"seed"`
+	Steps          json.Number `json:"steps"`
+	CFGScale       json.Number `json:"cfgScale"`
+	Sampler        string      `json:"sampler"`
+	Size           string      `json:"Size"` // "512x768"
+}
+
+type civitaiImage struct {
+	ID     int          `json:"id"`
+	URL    string       `json:"url"`
+	Width  int          `json:"width"`
+	Height int          `json:"height"`
+	Meta   *civitaiMeta `json:"meta"`
+}
+
+func (p *MultiSourceParser) fetchCivitAI(id string) (*ImageSource, error) {
+	resp, err := p.civitaiGet("/images?imageId=" + url.QueryEscape(id))
+	if err != nil {
+		return nil, fmt.Errorf("fetch civitai image %s: %w", id, err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Items []civitaiImage `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode civitai response: %w", err)
+	}
+	if len(result.Items) == 0 {
+		return nil, fmt.Errorf("civitai image %s not found (it may be private or removed)", id)
+	}
+
+	img := result.Items[0]
+	if img.Meta == nil || img.Meta.Prompt == "" {
+		return nil, fmt.Errorf("civitai image %s has no generation metadata", id)
+	}
+
+	src := &ImageSource{
+		Source:   "civitai",
+		ID:       id,
+		ImageURL: img.URL,
+		Prompt:   img.Meta.Prompt,
+		Negative: img.Meta.NegativePrompt,
+		Model:    img.Meta.Model,
+		Seed:     -1,
+		Sampler:  img.Meta.Sampler,
+		Width:    img.Width,
+		Height:   img.Height,
+	}
+	if seed, err := img.Meta.Seed.Int64(); err == nil {
+		src.Seed = seed
+	}
+	if steps, err := img.Meta.Steps.Int64(); err == nil {
+		src.Steps = int(steps)
+	}
+	if cfg, err := img.Meta.CFGScale.Float64(); err == nil {
+		src.CFGScale = cfg
+	}
+	if w, h, ok := parseSize(img.Meta.Size); ok {
+		src.Width, src.Height = w, h
+	}
+	return src, nil
+}
+
+// civitaiGet fetches path from the CivitAI API, retrying once when rate
+// limited. Any status other than 200 is returned as an error.
+func (p *MultiSourceParser) civitaiGet(path string) (*http.Response, error) {
+	endpoint := strings.TrimSuffix(p.CivitAIURL, "/") + path
+
+	resp, err := p.HTTPClient.Get(endpoint)
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		resp.Body.Close()
+		time.Sleep(civitaiRetryDelay)
+		resp, err = p.HTTPClient.Get(endpoint)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("civitai returned %d: %s", resp.StatusCode, string(b))
+	}
+	return resp, nil
+}
+
+func parseSize(s string) (int, int, bool) {
+	w, h, ok := strings.Cut(strings.ToLower(s), "x")
+	if !ok {
+		return 0, 0, false
+	}
+	width, err1 := strconv.Atoi(strings.TrimSpace(w))
+	height, err2 := strconv.Atoi(strings.TrimSpace(h))
+	if err1 != nil || err2 != nil {
+		return 0, 0, false
+	}
+	return width, height, true
+}
+
+// a1111Samplers maps A1111 sampler names (as CivitAI reports them) to
+// ComfyUI sampler names. "Karras" suffixes are handled separately.
+var a1111Samplers = map[string]string{
+	"euler":            "euler",
+	"euler a":          "euler_ancestral",
+	"heun":             "heun",
+	"dpm2":             "dpm_2",
+	"dpm2 a":           "dpm_2_ancestral",
+	"dpm++ 2s a":       "dpmpp_2s_ancestral",
+	"dpm++ 2m":         "dpmpp_2m",
+	"dpm++ sde":        "dpmpp_sde",
+	"dpm++ 2m sde":     "dpmpp_2m_sde",
+	"dpm++ 3m sde":     "dpmpp_3m_sde",
+	"lms":              "lms",
+	"ddim":             "ddim",
+	"uni_pc":           "uni_pc",
+	"unipc":            "uni_pc",
+	"lcm":              "lcm",
+	"dpm fast":         "dpm_fast",
+	"dpm adaptive":     "dpm_adaptive",
+	"dpm++ 2m sde gpu": "dpmpp_2m_sde_gpu",
+}
+
+// ComfySampler converts an A1111 sampler name into ComfyUI's sampler and
+// scheduler. Unknown names return ok=false.
+func ComfySampler(name string) (sampler, scheduler string, ok bool) {
+	n := strings.ToLower(strings.TrimSpace(name))
+	scheduler = "normal"
+	if strings.HasSuffix(n, " karras") {
+		n = strings.TrimSuffix(n, " karras")
+		scheduler = "karras"
+	}
+	sampler, ok = a1111Samplers[n]
+	return sampler, scheduler, ok
+}
+
+// WorkflowConfig builds a generation config that reproduces the source.
+// checkpoint overrides the source model; otherwise the model name is
+// assumed to be a .safetensors file in ComfyUI's checkpoints directory.
+func (s *ImageSource) WorkflowConfig(checkpoint string) *WorkflowConfig {
+	prompt := NewPrompt(s.Prompt).WithSeed(s.Seed)
+	if s.Negative != "" {
+		prompt.WithNegative(s.Negative)
+	}
+	for _, l := range s.LoRAs {
+		prompt.WithLoRA(l.Name, l.Weight)
+	}
+
+	cfg := DefaultWorkflowConfig()
+	cfg.Prompt = prompt
+	cfg.OutputPrefix = "clood_remix"
+
+	cfg.Checkpoint = checkpoint
+	if cfg.Checkpoint == "" && s.Model != "" {
+		cfg.Checkpoint = s.Model
+		if !strings.HasSuffix(cfg.Checkpoint, ".safetensors") && !strings.HasSuffix(cfg.Checkpoint, ".ckpt") {
+			cfg.Checkpoint += ".safetensors"
+		}
+	}
+	if s.Width > 0 && s.Height > 0 {
+		cfg.Width, cfg.Height = s.Width, s.Height
+	}
+	if s.Steps > 0 {
+		cfg.Steps = s.Steps
+	}
+	if s.CFGScale > 0 {
+		cfg.CFGScale = s.CFGScale
+	}
+	if sampler, scheduler, ok := ComfySampler(s.Sampler); ok {
+		cfg.Sampler, cfg.Scheduler = sampler, scheduler
+	}
+	return cfg
+}
@@ -0,0 +1,85 @@
+package sd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const civitaiFixture = `{"items":[{"id":123,"url":"https://image.civitai.com/x.png","width":512,"height":768,
+"meta":{"prompt":"a cat","negativePrompt":"blurry","Model":"dreamshaper_8","seed":42,"steps":30,
+"cfgScale":6.5,"sampler":"DPM++ 2M Karras","Size":"512x768"}}]}`
+
+func TestCivitAIImageID(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want string
+		ok   bool
+	}{
+		{"https://civitai.com/images/123", "123", true},
+		{"https://civitai.com/images/123?postId=9", "123", true},
+		{"https://civitai.com/posts/9?imageId=456", "456", true},
+		{"https://civitai.com/models/789", "", false},
+		{"https://example.com/images/123", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := CivitAIImageID(tt.ref)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("CivitAIImageID(%q) = %q, %v; want %q, %v", tt.ref, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestMultiSourceParserCivitAI(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Query().Get("imageId") != "123" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		if calls == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(civitaiFixture))
+	}))
+	defer srv.Close()
+
+	civitaiRetryDelay = 0
+	p := NewMultiSourceParser()
+	p.CivitAIURL = srv.URL
+
+	src, err := p.Parse("https://civitai.com/images/123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("expected one retry after 429, got %d calls", calls)
+	}
+	if src.Prompt != "a cat" || src.Negative != "blurry" || src.Model != "dreamshaper_8" {
+		t.Errorf("unexpected prompt metadata: %+v", src)
+	}
+	if src.Seed != 42 || src.Steps != 30 || src.CFGScale != 6.5 || src.Width != 512 || src.Height != 768 {
+		t.Errorf("unexpected numeric metadata: %+v", src)
+	}
+
+	cfg := src.WorkflowConfig("")
+	if cfg.Checkpoint != "dreamshaper_8.safetensors" || cfg.Sampler != "dpmpp_2m" || cfg.Scheduler != "karras" {
+		t.Errorf("unexpected workflow config: %+v", cfg)
+	}
+}
+
+func TestMultiSourceParserRateLimitedTwice(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	civitaiRetryDelay = 0
+	p := NewMultiSourceParser()
+	p.CivitAIURL = srv.URL
+
+	if _, err := p.Parse("https://civitai.com/images/1"); err == nil {
+		t.Error("expected an error after a second 429")
+	}
+}
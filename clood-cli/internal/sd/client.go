@@ -0,0 +1,297 @@
+package sd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	_ "image/jpeg" // register decoders for ImageSize
+	_ "image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultComfyURL is where ComfyUI listens out of the box.
+const DefaultComfyURL = "http://localhost:8188"
+
+// Client talks to a ComfyUI server's HTTP API.
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+	ClientID   string // sent with queued prompts; websocket events go to it
+
+	// TimingsPath is where Wait records how long each prompt ran, for the
+	// wait estimate in sd status. Empty records nothing.
+	TimingsPath string
+
+	// mu guards loadedCheckpoint, since sd paint --parallel generates on
+	// one client from several goroutines
+	mu               sync.Mutex
+	loadedCheckpoint string // see LoadedCheckpoint
+}
+
+// NewClient creates a ComfyUI client.
+func NewClient(baseURL string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultComfyURL
+	}
+	return &Client{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		HTTPClient: &http.Client{Timeout: 60 * time.Second},
+		ClientID:   "clood",
+	}
+}
+
+// UploadImage copies a local image into ComfyUI's input directory and
+// returns the name LoadImage nodes should reference.
+func (c *Client) UploadImage(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, err := mw.CreateFormFile("image", filepath.Base(path))
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(part, f); err != nil {
+		return "", err
+	}
+	mw.WriteField("overwrite", "true")
+	if err := mw.Close(); err != nil {
+		return "", err
+	}
+
+	resp, err := c.HTTPClient.Post(c.BaseURL+"/upload/image", mw.FormDataContentType(), &body)
+	if err != nil {
+		return "", fmt.Errorf("upload image: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("comfyui returned %d: %s", resp.StatusCode, string(b))
+	}
+
+	var result struct {
+		Name      string `json:"name"`
+		Subfolder string `json:"subfolder"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("decode upload response: %w", err)
+	}
+	if result.Subfolder != "" {
+		return result.Subfolder + "/" + result.Name, nil
+	}
+	return result.Name, nil
+}
+
+// Generate builds the workflow for cfg and queues it, returning ComfyUI's
+// prompt ID. Input, mask and ControlNet images are uploaded first.
+func (c *Client) Generate(cfg *WorkflowConfig) (string, error) {
+	workflow, err := BuildBasicWorkflow(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	if cfg.InputImagePath != "" {
+		name, err := c.UploadImage(cfg.InputImagePath)
+		if err != nil {
+			return "", err
+		}
+		workflow.Nodes["5"].Inputs["image"] = name
+	}
+	if cfg.InputImagePath != "" && cfg.MaskImagePath != "" {
+		name, err := c.UploadImage(cfg.MaskImagePath)
+		if err != nil {
+			return "", fmt.Errorf("mask image: %w", err)
+		}
+		workflow.Nodes["11"].Inputs["image"] = name
+	}
+	for i, cn := range cfg.ControlNets {
+		name, err := c.UploadImage(cn.ImagePath)
+		if err != nil {
+			return "", fmt.Errorf("controlnet image: %w", err)
+		}
+		workflow.Nodes[controlNetImageNode(i)].Inputs["image"] = name
+	}
+
+	promptID, err := c.Queue(workflow)
+	if err == nil {
+		c.setLoadedCheckpoint(cfg.Checkpoint)
+	}
+	return promptID, err
+}
+
+// Queue submits a workflow to /prompt.
+func (c *Client) Queue(workflow *ComfyWorkflow) (string, error) {
+	data, err := json.Marshal(workflow.ToAPIPayload(c.ClientID))
+	if err != nil {
+		return "", fmt.Errorf("marshal workflow: %w", err)
+	}
+
+	resp, err := c.HTTPClient.Post(c.BaseURL+"/prompt", "application/json", bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("queue prompt: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("comfyui returned %d: %s", resp.StatusCode, string(b))
+	}
+
+	var result struct {
+		PromptID string `json:"prompt_id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("decode prompt response: %w", err)
+	}
+	return result.PromptID, nil
+}
+
+// OutputImage identifies a file ComfyUI wrote, as reported by /history.
+type OutputImage struct {
+	Filename  string `json:"filename"`
+	Subfolder string `json:"subfolder"`
+	Type      string `json:"type"`
+}
+
+// Wait polls /history until promptID finishes and returns its output images.
+func (c *Client) Wait(promptID string, timeout time.Duration) ([]OutputImage, error) {
+	return c.wait(promptID, timeout, true)
+}
+
+// wait is Wait, optionally without recording the run in TimingsPath
+func (c *Client) wait(promptID string, timeout time.Duration, record bool) ([]OutputImage, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		images, ran, done, err := c.history(promptID)
+		if err != nil {
+			return nil, err
+		}
+		if done {
+			if record && c.TimingsPath != "" && ran > 0 {
+				c.recordTiming(ran)
+			}
+			return images, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("prompt %s not finished after %s", promptID, timeout)
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+// history returns the outputs of promptID, how long it ran (0 if
+// unknown) and whether it has finished. ComfyUI answers {} until the
+// prompt has run.
+func (c *Client) history(promptID string) ([]OutputImage, time.Duration, bool, error) {
+	resp, err := c.HTTPClient.Get(c.BaseURL + "/history/" + url.PathEscape(promptID))
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("get history: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return nil, 0, false, fmt.Errorf("comfyui returned %d: %s", resp.StatusCode, string(b))
+	}
+
+	var history map[string]struct {
+		Outputs map[string]struct {
+			Images []OutputImage `json:"images"`
+		} `json:"outputs"`
+		Status struct {
+			StatusStr string            `json:"status_str"`
+			Completed bool              `json:"completed"`
+			Messages  []json.RawMessage `json:"messages"`
+		} `json:"status"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&history); err != nil {
+		return nil, 0, false, fmt.Errorf("decode history: %w", err)
+	}
+
+	entry, ok := history[promptID]
+	if !ok {
+		return nil, 0, false, nil
+	}
+	if entry.Status.StatusStr == "error" {
+		return nil, 0, true, fmt.Errorf("prompt %s failed in ComfyUI", promptID)
+	}
+	if !entry.Status.Completed {
+		return nil, 0, false, nil
+	}
+
+	var images []OutputImage
+	for _, out := range entry.Outputs {
+		images = append(images, out.Images...)
+	}
+	return images, executionDuration(entry.Status.Messages), true, nil
+}
+
+// Download saves an output image from /view to dest.
+func (c *Client) Download(img OutputImage, dest string) error {
+	q := url.Values{}
+	q.Set("filename", img.Filename)
+	q.Set("subfolder", img.Subfolder)
+	q.Set("type", img.Type)
+
+	resp, err := c.HTTPClient.Get(c.BaseURL + "/view?" + q.Encode())
+	if err != nil {
+		return fmt.Errorf("download %s: %w", img.Filename, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("comfyui returned %d: %s", resp.StatusCode, string(b))
+	}
+
+	f, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ImageSize reads just the header of a PNG or JPEG to get its dimensions.
+func ImageSize(path string) (int, int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+
+	cfg, _, err := image.DecodeConfig(f)
+	if err != nil {
+		return 0, 0, fmt.Errorf("read image %s: %w", path, err)
+	}
+	return cfg.Width, cfg.Height, nil
+}
+
+// NativeResolution guesses the training resolution of a checkpoint from its
+// filename: 1024 for SDXL-family models, 512 otherwise.
+func NativeResolution(checkpoint string) int {
+	name := strings.ToLower(checkpoint)
+	for _, marker := range []string{"xl", "pony", "flux", "sd3"} {
+		if strings.Contains(name, marker) {
+			return 1024
+		}
+	}
+	return 512
+}
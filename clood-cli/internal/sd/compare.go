@@ -9,11 +9,13 @@ import (
 	"time"
 )
 
-// CompareGallery generates an HTML comparison view for batch results.
+// CompareGallery generates an HTML comparison view for batch results, or
+// a source vs. reproduction page when Remix is set.
 type CompareGallery struct {
 	Title       string
 	Description string
 	Results     *BatchResult
+	Remix       *RemixComparison
 	OutputPath  string
 }
 
@@ -27,8 +29,22 @@ func NewCompareGallery(results *BatchResult) *CompareGallery {
 	}
 }
 
+// NewRemixGallery creates the two-image layout for a remix, written to
+// compare.html in outDir beside the images.
+func NewRemixGallery(rc *RemixComparison, outDir string) *CompareGallery {
+	return &CompareGallery{
+		Title:      "Remix: " + rc.Source.URL,
+		Remix:      rc,
+		OutputPath: filepath.Join(outDir, "compare.html"),
+	}
+}
+
 // Render writes the HTML gallery to the given writer.
 func (g *CompareGallery) Render(w io.Writer) error {
+	if g.Remix != nil {
+		return g.renderRemix(w)
+	}
+
 	tmpl, err := template.New("gallery").Parse(galleryTemplate)
 	if err != nil {
 		return fmt.Errorf("parse template: %w", err)
@@ -57,6 +73,36 @@ func (g *CompareGallery) Render(w io.Writer) error {
 	return tmpl.Execute(w, data)
 }
 
+func (g *CompareGallery) renderRemix(w io.Writer) error {
+	tmpl, err := template.New("remix").Parse(remixTemplate)
+	if err != nil {
+		return fmt.Errorf("parse template: %w", err)
+	}
+
+	rc := g.Remix
+	data := struct {
+		Title        string
+		URL          string
+		SourceImage  string
+		Reproduction string
+		HasFidelity  bool
+		Fidelity     float64 // percent
+		Grid         int
+		Params       []ParamDiff
+	}{
+		Title:        g.Title,
+		URL:          rc.Source.URL,
+		SourceImage:  rc.SourceImage,
+		Reproduction: rc.Reproduction,
+		HasFidelity:  rc.Fidelity >= 0,
+		Fidelity:     rc.Fidelity * 100,
+		Grid:         fidelityGrid,
+		Params:       rc.ParamDiffs(),
+	}
+
+	return tmpl.Execute(w, data)
+}
+
 const galleryTemplate = `<!DOCTYPE html>
 <html lang="en">
 <head>
@@ -0,0 +1,200 @@
+package sd
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ControlNetConfig conditions generation on a reference image: the image is
+// run through the type's preprocessor and fed to a ControlNet model.
+type ControlNetConfig struct {
+	Type      string  `json:"type" yaml:"type"`         // canny, depth, pose, lineart, scribble, or none
+	ImagePath string  `json:"image" yaml:"image"`       // local reference image
+	Model     string  `json:"model" yaml:"model"`       // file in ComfyUI's models/controlnet
+	Strength  float64 `json:"strength" yaml:"strength"` // 0-1 (default 1)
+}
+
+// controlNetPreprocessor is the ComfyUI node that turns a reference image
+// into the hint a ControlNet type expects. Canny ships with ComfyUI; the
+// others come from the comfyui_controlnet_aux node pack.
+type controlNetPreprocessor struct {
+	ClassType string
+	Inputs    map[string]interface{}
+	Keywords  []string // substrings that identify matching ControlNet model files
+}
+
+var controlNetPreprocessors = map[string]controlNetPreprocessor{
+	"canny": {
+		ClassType: "Canny",
+		Inputs:    map[string]interface{}{"low_threshold": 0.4, "high_threshold": 0.8},
+		Keywords:  []string{"canny"},
+	},
+	"depth": {
+		ClassType: "MiDaS-DepthMapPreprocessor",
+		Inputs:    map[string]interface{}{"a": 6.28, "bg_threshold": 0.1},
+		Keywords:  []string{"depth"},
+	},
+	"pose": {
+		ClassType: "OpenposePreprocessor",
+		Inputs:    map[string]interface{}{"detect_hand": "enable", "detect_body": "enable", "detect_face": "enable"},
+		Keywords:  []string{"openpose", "pose"},
+	},
+	"lineart": {
+		ClassType: "LineArtPreprocessor",
+		Inputs:    map[string]interface{}{"coarse": "disable"},
+		Keywords:  []string{"lineart"},
+	},
+	"scribble": {
+		ClassType: "ScribblePreprocessor",
+		Inputs:    map[string]interface{}{},
+		Keywords:  []string{"scribble"},
+	},
+}
+
+// ControlNetTypes lists the supported --controlnet types. "none" passes an
+// already-preprocessed hint image straight to the ControlNet.
+func ControlNetTypes() []string {
+	types := []string{"none"}
+	for t := range controlNetPreprocessors {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// NormalizeControlNetType resolves aliases like "openpose" and "edges"
+func NormalizeControlNetType(t string) (string, error) {
+	t = strings.ToLower(strings.TrimSpace(t))
+	switch t {
+	case "openpose":
+		t = "pose"
+	case "edges", "edge":
+		t = "canny"
+	case "raw":
+		t = "none"
+	}
+	if _, ok := controlNetPreprocessors[t]; ok || t == "none" {
+		return t, nil
+	}
+	return "", fmt.Errorf("unknown controlnet type %q (supported: %s)", t, strings.Join(ControlNetTypes(), ", "))
+}
+
+// MatchControlNetModel picks the installed ControlNet model for a type by
+// name, e.g. "control_v11p_sd15_canny.pth" for canny. With "none" the type
+// can't be inferred, so exactly one model must be installed.
+func MatchControlNetModel(controlType string, installed []string) (string, error) {
+	if len(installed) == 0 {
+		return "", fmt.Errorf("no ControlNet models installed in ComfyUI's models/controlnet")
+	}
+
+	pre, ok := controlNetPreprocessors[controlType]
+	if !ok {
+		if len(installed) == 1 {
+			return installed[0], nil
+		}
+		return "", fmt.Errorf("several ControlNet models installed; choose one with --controlnet-model: %s", strings.Join(installed, ", "))
+	}
+
+	for _, kw := range pre.Keywords {
+		for _, name := range installed {
+			if strings.Contains(strings.ToLower(filepath.Base(name)), kw) {
+				return name, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no installed ControlNet model matches %q (installed: %s)", controlType, strings.Join(installed, ", "))
+}
+
+// ParseControlNetSpec parses "type=image" into a config
+func ParseControlNetSpec(spec string) (ControlNetConfig, error) {
+	typ, image, ok := strings.Cut(spec, "=")
+	if !ok || image == "" {
+		return ControlNetConfig{}, fmt.Errorf("controlnet %q needs a reference image (type=image.png)", spec)
+	}
+	typ, err := NormalizeControlNetType(typ)
+	if err != nil {
+		return ControlNetConfig{}, err
+	}
+	return ControlNetConfig{Type: typ, ImagePath: image}, nil
+}
+
+// controlNetImageNode is the ID of the LoadImage node for the i'th
+// ControlNet in a workflow built by BuildBasicWorkflow
+func controlNetImageNode(i int) string {
+	return strconv.Itoa(100 + i*4)
+}
+
+// addControlNets wires LoadImage -> preprocessor -> ControlNetApplyAdvanced
+// for each ControlNet between the sampler and the conditioning it was
+// given (the prompt encoders, or inpaint conditioning). Applies are
+// chained, so several ControlNets stack.
+func addControlNets(workflow *ComfyWorkflow, cfg *WorkflowConfig) error {
+	positive := workflow.Nodes["3"].Inputs["positive"]
+	negative := workflow.Nodes["3"].Inputs["negative"]
+
+	for i, cn := range cfg.ControlNets {
+		if cn.Model == "" {
+			return fmt.Errorf("controlnet %d (%s) has no model", i+1, cn.Type)
+		}
+		strength := cn.Strength
+		if strength <= 0 {
+			strength = 1.0
+		}
+
+		imageID := controlNetImageNode(i)
+		base, _ := strconv.Atoi(imageID)
+		preID := strconv.Itoa(base + 1)
+		loaderID := strconv.Itoa(base + 2)
+		applyID := strconv.Itoa(base + 3)
+
+		workflow.Nodes[imageID] = ComfyNode{
+			ClassType: "LoadImage",
+			Inputs: map[string]interface{}{
+				"image": filepath.Base(cn.ImagePath),
+			},
+		}
+
+		hint := []interface{}{imageID, 0}
+		if pre, ok := controlNetPreprocessors[cn.Type]; ok {
+			inputs := map[string]interface{}{"image": hint}
+			for k, v := range pre.Inputs {
+				inputs[k] = v
+			}
+			if pre.ClassType != "Canny" {
+				inputs["resolution"] = max(cfg.Width, cfg.Height)
+			}
+			workflow.Nodes[preID] = ComfyNode{ClassType: pre.ClassType, Inputs: inputs}
+			hint = []interface{}{preID, 0}
+		} else if cn.Type != "none" {
+			return fmt.Errorf("unknown controlnet type %q", cn.Type)
+		}
+
+		workflow.Nodes[loaderID] = ComfyNode{
+			ClassType: "ControlNetLoader",
+			Inputs: map[string]interface{}{
+				"control_net_name": cn.Model,
+			},
+		}
+		workflow.Nodes[applyID] = ComfyNode{
+			ClassType: "ControlNetApplyAdvanced",
+			Inputs: map[string]interface{}{
+				"positive":      positive,
+				"negative":      negative,
+				"control_net":   []interface{}{loaderID, 0},
+				"image":         hint,
+				"strength":      strength,
+				"start_percent": 0.0,
+				"end_percent":   1.0,
+			},
+		}
+		positive = []interface{}{applyID, 0}
+		negative = []interface{}{applyID, 1}
+	}
+
+	workflow.Nodes["3"].Inputs["positive"] = positive
+	workflow.Nodes["3"].Inputs["negative"] = negative
+	return nil
+}
@@ -0,0 +1,71 @@
+package sd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatchControlNetModel(t *testing.T) {
+	installed := []string{"control_v11f1p_sd15_depth.pth", "control_v11p_sd15_openpose.pth", "diffusers_xl_canny_mid.safetensors"}
+
+	for typ, want := range map[string]string{
+		"canny": "diffusers_xl_canny_mid.safetensors",
+		"depth": "control_v11f1p_sd15_depth.pth",
+		"pose":  "control_v11p_sd15_openpose.pth",
+	} {
+		if got, err := MatchControlNetModel(typ, installed); err != nil || got != want {
+			t.Errorf("MatchControlNetModel(%q) = %q, %v; want %q", typ, got, err, want)
+		}
+	}
+
+	if _, err := MatchControlNetModel("scribble", installed); err == nil {
+		t.Error("expected error when no model matches")
+	}
+	if _, err := MatchControlNetModel("none", installed); err == nil {
+		t.Error("expected error choosing a model for none among several")
+	}
+	if got, _ := MatchControlNetModel("none", installed[:1]); got != installed[0] {
+		t.Errorf("none with one model = %q", got)
+	}
+}
+
+func TestBuildWorkflowControlNets(t *testing.T) {
+	cfg := DefaultWorkflowConfig()
+	cfg.Prompt = NewPrompt("portrait")
+	cfg.Checkpoint = "sd_xl_base_1.0.safetensors"
+	cfg.ControlNets = []ControlNetConfig{
+		{Type: "canny", ImagePath: "/tmp/ref.png", Model: "canny.safetensors", Strength: 0.8},
+		{Type: "none", ImagePath: "/tmp/depth.png", Model: "depth.safetensors"},
+	}
+
+	w, err := BuildBasicWorkflow(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if w.Nodes["100"].Inputs["image"] != "ref.png" || w.Nodes["101"].ClassType != "Canny" {
+		t.Errorf("canny nodes: %+v %+v", w.Nodes["100"], w.Nodes["101"])
+	}
+	first := w.Nodes["103"]
+	if first.ClassType != "ControlNetApplyAdvanced" || first.Inputs["strength"] != 0.8 ||
+		!reflect.DeepEqual(first.Inputs["positive"], []interface{}{"6", 0}) ||
+		!reflect.DeepEqual(first.Inputs["image"], []interface{}{"101", 0}) {
+		t.Errorf("first apply: %+v", first.Inputs)
+	}
+
+	// none skips the preprocessor and chains off the first apply
+	if _, ok := w.Nodes["105"]; ok {
+		t.Error("none should not add a preprocessor")
+	}
+	second := w.Nodes["107"]
+	if !reflect.DeepEqual(second.Inputs["positive"], []interface{}{"103", 0}) ||
+		!reflect.DeepEqual(second.Inputs["negative"], []interface{}{"103", 1}) ||
+		!reflect.DeepEqual(second.Inputs["image"], []interface{}{"104", 0}) || second.Inputs["strength"] != 1.0 {
+		t.Errorf("second apply: %+v", second.Inputs)
+	}
+
+	sampler := w.Nodes["3"].Inputs
+	if !reflect.DeepEqual(sampler["positive"], []interface{}{"107", 0}) || !reflect.DeepEqual(sampler["negative"], []interface{}{"107", 1}) {
+		t.Errorf("sampler conditioning: %v %v", sampler["positive"], sampler["negative"])
+	}
+}
@@ -0,0 +1,157 @@
+package sd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// LoadWorkflowFile reads a workflow saved from ComfyUI with "Save (API
+// Format)": node IDs mapped to class_type, inputs and a _meta title. The
+// editor's own save format (a nodes array and links) can't be queued.
+func LoadWorkflowFile(path string) (*ComfyWorkflow, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if _, ok := raw["links"]; ok {
+		return nil, fmt.Errorf("%s is an editor workflow; export it from ComfyUI with Save (API Format)", path)
+	}
+	if prompt, ok := raw["prompt"]; ok {
+		// A saved /prompt payload
+		raw = nil
+		if err := json.Unmarshal(prompt, &raw); err != nil {
+			return nil, fmt.Errorf("%s: prompt: %w", path, err)
+		}
+	}
+
+	w := &ComfyWorkflow{Nodes: make(map[string]ComfyNode, len(raw))}
+	for id, msg := range raw {
+		// Numbers stay json.Number so 64-bit seeds round-trip exactly
+		var node ComfyNode
+		dec := json.NewDecoder(bytes.NewReader(msg))
+		dec.UseNumber()
+		if err := dec.Decode(&node); err != nil || node.ClassType == "" {
+			return nil, fmt.Errorf("%s: node %q has no class_type; is this an API-format workflow?", path, id)
+		}
+		if node.Inputs == nil {
+			node.Inputs = map[string]interface{}{}
+		}
+		w.Nodes[id] = node
+	}
+	if len(w.Nodes) == 0 {
+		return nil, fmt.Errorf("%s has no nodes", path)
+	}
+	return w, nil
+}
+
+// AppliedInput is one input-map entry as set on the workflow
+type AppliedInput struct {
+	Key    string      `json:"key"`
+	NodeID string      `json:"node"`
+	Input  string      `json:"input"`
+	Value  interface{} `json:"value"`
+}
+
+// ApplyInputMap sets node inputs from values. A key is "node.input" or
+// just "node", where node is an ID or a title (case doesn't matter). A
+// bare node sets its only literal input, like the text of a prompt node
+// titled positive_prompt; if it has several, the key must name one.
+func (w *ComfyWorkflow) ApplyInputMap(values map[string]interface{}) ([]AppliedInput, error) {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var applied []AppliedInput
+	for _, key := range keys {
+		id, input, err := w.resolveInputKey(key)
+		if err != nil {
+			return nil, err
+		}
+		w.Nodes[id].Inputs[input] = values[key]
+		applied = append(applied, AppliedInput{Key: key, NodeID: id, Input: input, Value: values[key]})
+	}
+	return applied, nil
+}
+
+// resolveInputKey finds the node ID and input name a key refers to
+func (w *ComfyWorkflow) resolveInputKey(key string) (string, string, error) {
+	id, err := w.findNode(key)
+	if err == nil {
+		inputs := w.Nodes[id].literalInputs()
+		if len(inputs) != 1 {
+			return "", "", fmt.Errorf("%s: node %s (%s) has inputs %s; use %s.<input>",
+				key, id, w.Nodes[id].ClassType, strings.Join(inputs, ", "), key)
+		}
+		return id, inputs[0], nil
+	}
+
+	dot := strings.LastIndex(key, ".")
+	if dot <= 0 || dot == len(key)-1 {
+		return "", "", err
+	}
+	ref, input := key[:dot], key[dot+1:]
+	if id, err = w.findNode(ref); err != nil {
+		return "", "", err
+	}
+	if _, ok := w.Nodes[id].Inputs[input]; !ok {
+		return "", "", fmt.Errorf("%s: node %s (%s) has no input %q; it has %s",
+			key, id, w.Nodes[id].ClassType, input, strings.Join(w.Nodes[id].inputNames(), ", "))
+	}
+	return id, input, nil
+}
+
+// findNode looks ref up as a node ID, then as a title
+func (w *ComfyWorkflow) findNode(ref string) (string, error) {
+	if _, ok := w.Nodes[ref]; ok {
+		return ref, nil
+	}
+	var matches []string
+	for id, node := range w.Nodes {
+		if node.Meta != nil && strings.EqualFold(node.Meta.Title, ref) {
+			matches = append(matches, id)
+		}
+	}
+	sort.Strings(matches)
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no node with ID or title %q", ref)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("title %q is on nodes %s; use a node ID", ref, strings.Join(matches, ", "))
+	}
+}
+
+// literalInputs are the inputs set to a value rather than linked to
+// another node's output ([node, index])
+func (n ComfyNode) literalInputs() []string {
+	var names []string
+	for name, v := range n.Inputs {
+		if link, ok := v.([]interface{}); ok && len(link) == 2 {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (n ComfyNode) inputNames() []string {
+	names := make([]string, 0, len(n.Inputs))
+	for name := range n.Inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
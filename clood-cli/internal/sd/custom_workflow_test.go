@@ -0,0 +1,83 @@
+package sd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const apiWorkflow = `{
+  "3": {"class_type": "KSampler", "inputs": {"seed": 1125899906842597, "steps": 20, "model": ["4", 0], "positive": ["6", 0], "negative": ["7", 0]}, "_meta": {"title": "KSampler"}},
+  "4": {"class_type": "CheckpointLoaderSimple", "inputs": {"ckpt_name": "sdxl.safetensors"}, "_meta": {"title": "Load Checkpoint"}},
+  "6": {"class_type": "CLIPTextEncode", "inputs": {"text": "", "clip": ["4", 1]}, "_meta": {"title": "positive_prompt"}},
+  "7": {"class_type": "CLIPTextEncode", "inputs": {"text": "blurry", "clip": ["4", 1]}, "_meta": {"title": "Negative"}},
+  "8": {"class_type": "CLIPTextEncode", "inputs": {"text": "", "clip": ["4", 1]}, "_meta": {"title": "Negative"}}
+}`
+
+func writeWorkflow(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "workflow.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadWorkflowFile(t *testing.T) {
+	w, err := LoadWorkflowFile(writeWorkflow(t, apiWorkflow))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(w.Nodes) != 5 || w.Nodes["6"].Meta.Title != "positive_prompt" {
+		t.Errorf("loaded %+v", w.Nodes)
+	}
+	data, _ := json.Marshal(w.ToAPIPayload(""))
+	if !strings.Contains(string(data), `"seed":1125899906842597`) {
+		t.Errorf("seed should round-trip exactly: %s", data)
+	}
+
+	payload := `{"client_id": "x", "prompt": ` + apiWorkflow + `}`
+	if w, err := LoadWorkflowFile(writeWorkflow(t, payload)); err != nil || len(w.Nodes) != 5 {
+		t.Errorf("saved /prompt payload: %v", err)
+	}
+
+	if _, err := LoadWorkflowFile(writeWorkflow(t, `{"nodes": [], "links": []}`)); err == nil || !strings.Contains(err.Error(), "API Format") {
+		t.Errorf("editor format: err = %v", err)
+	}
+}
+
+func TestApplyInputMap(t *testing.T) {
+	w, err := LoadWorkflowFile(writeWorkflow(t, apiWorkflow))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	applied, err := w.ApplyInputMap(map[string]interface{}{
+		"positive_prompt": "a tortoise",
+		"ksampler.steps":  30,
+		"4.ckpt_name":     "other.safetensors",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != 3 {
+		t.Errorf("applied = %+v", applied)
+	}
+	if w.Nodes["6"].Inputs["text"] != "a tortoise" || w.Nodes["3"].Inputs["steps"] != 30 ||
+		w.Nodes["4"].Inputs["ckpt_name"] != "other.safetensors" {
+		t.Errorf("inputs not set: %+v", w.Nodes)
+	}
+
+	for key, want := range map[string]string{
+		"KSampler":      "use KSampler.<input>",
+		"Negative.text": "use a node ID",
+		"6.txt":         `no input "txt"`,
+		"nothing":       `no node with ID or title "nothing"`,
+	} {
+		if _, err := w.ApplyInputMap(map[string]interface{}{key: 1}); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: err = %v, want %q", key, err, want)
+		}
+	}
+}
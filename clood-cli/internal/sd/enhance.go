@@ -0,0 +1,40 @@
+package sd
+
+import (
+	"regexp"
+	"strings"
+)
+
+// EnhanceSystemPrompt asks an LLM to turn a short idea into a full prompt
+const EnhanceSystemPrompt = `You write prompts for Stable Diffusion.
+Expand this into a detailed Stable Diffusion prompt with style descriptors,
+lighting, and quality tags. Keep the user's subject and intent; add the
+medium, composition, lighting, color palette and quality tags (masterpiece,
+highly detailed, sharp focus) that suit it.
+Reply with the prompt only: one line of comma-separated phrases, no
+explanation, no quotes, no negative prompt.`
+
+var (
+	thinkBlock  = regexp.MustCompile(`(?s)<think>.*?</think>`)
+	promptLabel = regexp.MustCompile(`(?i)^(?:positive\s+)?prompt\s*:\s*`)
+)
+
+// CleanEnhancedPrompt reduces an LLM's reply to the prompt itself: no
+// reasoning block, "Prompt:" label, wrapping quotes or line breaks
+func CleanEnhancedPrompt(reply string) string {
+	s := strings.TrimSpace(thinkBlock.ReplaceAllString(reply, ""))
+	s = strings.Trim(s, "`")
+	s = promptLabel.ReplaceAllString(strings.TrimSpace(s), "")
+	s = strings.Trim(strings.TrimSpace(s), `"'`)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// WithEnhanced replaces the positive prompt with an enhanced version,
+// keeping what was written in Original
+func (p *Prompt) WithEnhanced(enhanced string) *Prompt {
+	if p.Original == "" {
+		p.Original = p.Positive
+	}
+	p.Positive = enhanced
+	return p
+}
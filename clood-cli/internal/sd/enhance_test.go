@@ -0,0 +1,24 @@
+package sd
+
+import "testing"
+
+func TestCleanEnhancedPrompt(t *testing.T) {
+	tests := map[string]string{
+		"a tortoise, oil painting, soft light":                         "a tortoise, oil painting, soft light",
+		"<think>they want detail</think>\n\"a tortoise, golden hour\"": "a tortoise, golden hour",
+		"Prompt: a tortoise,\nvolumetric lighting":                     "a tortoise, volumetric lighting",
+		"```\npositive prompt: a tortoise\n```":                        "a tortoise",
+	}
+	for reply, want := range tests {
+		if got := CleanEnhancedPrompt(reply); got != want {
+			t.Errorf("CleanEnhancedPrompt(%q) = %q, want %q", reply, got, want)
+		}
+	}
+}
+
+func TestWithEnhanced(t *testing.T) {
+	p := NewPrompt("a tortoise").WithEnhanced("a tortoise, highly detailed")
+	if p.Positive != "a tortoise, highly detailed" || p.Original != "a tortoise" {
+		t.Errorf("got %q from %q", p.Positive, p.Original)
+	}
+}
@@ -0,0 +1,228 @@
+package sd
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/json"
+	"image"
+	_ "image/jpeg" // register decoders for image.DecodeConfig
+	_ "image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// GalleryImage is one generated image and what's known about how it was made
+type GalleryImage struct {
+	Path     string        `json:"path"`
+	Metadata ImageMetadata `json:"metadata"`
+	Source   string        `json:"source"` // sidecar, png, or none
+	Created  time.Time     `json:"created"`
+}
+
+// imageExts are the files LoadGallery lists
+var imageExts = map[string]bool{".png": true, ".jpg": true, ".jpeg": true, ".webp": true}
+
+// SidecarPath is where the metadata for imagePath is kept: the same name
+// with a .json extension
+func SidecarPath(imagePath string) string {
+	return strings.TrimSuffix(imagePath, filepath.Ext(imagePath)) + ".json"
+}
+
+// WriteSidecar saves meta next to imagePath
+func WriteSidecar(imagePath string, meta ImageMetadata) error {
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(SidecarPath(imagePath), data, 0644)
+}
+
+// LoadGallery finds the images under dir, newest first. Metadata comes from
+// the sidecar written at generation time, else from the workflow ComfyUI
+// embeds in its PNGs, else just the image dimensions.
+func LoadGallery(dir string) ([]GalleryImage, error) {
+	var images []GalleryImage
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !imageExts[strings.ToLower(filepath.Ext(path))] {
+			return nil
+		}
+		images = append(images, loadGalleryImage(path, info))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(images, func(i, j int) bool {
+		return images[i].Created.After(images[j].Created)
+	})
+	return images, nil
+}
+
+func loadGalleryImage(path string, info os.FileInfo) GalleryImage {
+	img := GalleryImage{Path: path, Source: "none", Created: info.ModTime()}
+
+	if data, err := os.ReadFile(SidecarPath(path)); err == nil {
+		if json.Unmarshal(data, &img.Metadata) == nil {
+			img.Source = "sidecar"
+			if !img.Metadata.CreatedAt.IsZero() {
+				img.Created = img.Metadata.CreatedAt
+			}
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return img
+	}
+	defer f.Close()
+
+	if img.Source == "none" && strings.EqualFold(filepath.Ext(path), ".png") {
+		if meta, ok := ParsePNGMetadata(f); ok {
+			img.Metadata = meta
+			img.Source = "png"
+		}
+		f.Seek(0, io.SeekStart)
+	}
+
+	if img.Metadata.Width == 0 || img.Metadata.Height == 0 {
+		if cfg, _, err := image.DecodeConfig(f); err == nil {
+			img.Metadata.Width, img.Metadata.Height = cfg.Width, cfg.Height
+		}
+	}
+	return img
+}
+
+// ParsePNGMetadata reads the API-format workflow ComfyUI stores in a PNG's
+// "prompt" text chunk and pulls the generation settings out of its nodes
+func ParsePNGMetadata(r io.Reader) (ImageMetadata, bool) {
+	text := pngTextChunks(r)
+	raw, ok := text["prompt"]
+	if !ok {
+		return ImageMetadata{}, false
+	}
+
+	var nodes map[string]struct {
+		ClassType string                 `json:"class_type"`
+		Inputs    map[string]interface{} `json:"inputs"`
+	}
+	if err := json.Unmarshal([]byte(raw), &nodes); err != nil {
+		return ImageMetadata{}, false
+	}
+
+	var meta ImageMetadata
+	textOf := func(ref interface{}) string {
+		link, ok := ref.([]interface{})
+		if !ok || len(link) == 0 {
+			return ""
+		}
+		id, _ := link[0].(string)
+		s, _ := nodes[id].Inputs["text"].(string)
+		return s
+	}
+
+	for _, n := range nodes {
+		switch n.ClassType {
+		case "CheckpointLoaderSimple":
+			meta.Checkpoint, _ = n.Inputs["ckpt_name"].(string)
+		case "EmptyLatentImage":
+			meta.Width = jsonInt(n.Inputs["width"])
+			meta.Height = jsonInt(n.Inputs["height"])
+		case "KSampler":
+			meta.Seed = int64(jsonFloat(n.Inputs["seed"]))
+			meta.Steps = jsonInt(n.Inputs["steps"])
+			meta.CFGScale = jsonFloat(n.Inputs["cfg"])
+			meta.Sampler, _ = n.Inputs["sampler_name"].(string)
+			meta.Prompt = textOf(n.Inputs["positive"])
+			meta.Negative = textOf(n.Inputs["negative"])
+		case "LoraLoader":
+			name, _ := n.Inputs["lora_name"].(string)
+			meta.LoRAs = append(meta.LoRAs, LoRA{Name: name, Weight: jsonFloat(n.Inputs["strength_model"])})
+		}
+	}
+	return meta, true
+}
+
+// pngTextChunks returns the tEXt and iTXt chunks of a PNG, stopping at the
+// image data. Forge writes long parameters as (possibly compressed) iTXt.
+func pngTextChunks(r io.Reader) map[string]string {
+	chunks := map[string]string{}
+
+	sig := make([]byte, 8)
+	if _, err := io.ReadFull(r, sig); err != nil || !bytes.Equal(sig, []byte("\x89PNG\r\n\x1a\n")) {
+		return chunks
+	}
+
+	for {
+		var header [8]byte
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			return chunks
+		}
+		length := binary.BigEndian.Uint32(header[:4])
+		kind := string(header[4:])
+		if kind == "IDAT" || kind == "IEND" || length > 64<<20 {
+			return chunks
+		}
+
+		data := make([]byte, length+4) // chunk data plus CRC
+		if _, err := io.ReadFull(r, data); err != nil {
+			return chunks
+		}
+		switch kind {
+		case "tEXt":
+			if key, value, ok := bytes.Cut(data[:length], []byte{0}); ok {
+				chunks[string(key)] = string(value)
+			}
+		case "iTXt":
+			if key, value, ok := parseITXt(data[:length]); ok {
+				chunks[key] = value
+			}
+		}
+	}
+}
+
+// parseITXt decodes an iTXt chunk: keyword, compression flag and method,
+// language tag and translated keyword, then the UTF-8 text
+func parseITXt(data []byte) (string, string, bool) {
+	key, rest, ok := bytes.Cut(data, []byte{0})
+	if !ok || len(rest) < 2 {
+		return "", "", false
+	}
+	compressed := rest[0] == 1
+	_, rest, ok = bytes.Cut(rest[2:], []byte{0}) // language tag
+	if !ok {
+		return "", "", false
+	}
+	_, text, ok := bytes.Cut(rest, []byte{0}) // translated keyword
+	if !ok {
+		return "", "", false
+	}
+	if compressed {
+		zr, err := zlib.NewReader(bytes.NewReader(text))
+		if err != nil {
+			return "", "", false
+		}
+		defer zr.Close()
+		if text, err = io.ReadAll(io.LimitReader(zr, 16<<20)); err != nil {
+			return "", "", false
+		}
+	}
+	return string(key), string(text), true
+}
+
+func jsonFloat(v interface{}) float64 {
+	f, _ := v.(float64)
+	return f
+}
+
+func jsonInt(v interface{}) int {
+	return int(jsonFloat(v))
+}
@@ -0,0 +1,151 @@
+package sd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ParseTags splits a comma-separated tag list. Tags are trimmed and
+// lowercased so "Ghibli" and "ghibli" are the same tag.
+func ParseTags(s string) []string {
+	var tags []string
+	for _, t := range strings.Split(s, ",") {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+// TagImage adds tags to imagePath's sidecar, or with remove takes them
+// off, and returns the image's tags afterwards. An image without a
+// sidecar gets one, seeded with what its PNG workflow records.
+func TagImage(imagePath string, tags []string, remove bool) ([]string, error) {
+	info, err := os.Stat(imagePath)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("%s is a directory", imagePath)
+	}
+
+	// Edit the sidecar as raw JSON so fields this version doesn't know
+	// about survive the rewrite
+	sidecar := map[string]interface{}{}
+	if data, err := os.ReadFile(SidecarPath(imagePath)); err == nil {
+		if err := json.Unmarshal(data, &sidecar); err != nil {
+			return nil, fmt.Errorf("%s: %w", SidecarPath(imagePath), err)
+		}
+	} else if os.IsNotExist(err) {
+		data, err := json.Marshal(loadGalleryImage(imagePath, info).Metadata)
+		if err != nil {
+			return nil, err
+		}
+		json.Unmarshal(data, &sidecar)
+	} else {
+		return nil, err
+	}
+
+	var current []string
+	if list, ok := sidecar["tags"].([]interface{}); ok {
+		for _, t := range list {
+			if s, ok := t.(string); ok {
+				current = append(current, s)
+			}
+		}
+	}
+	current = mergeTags(current, tags, remove)
+	if len(current) == 0 {
+		delete(sidecar, "tags")
+	} else {
+		sidecar["tags"] = current
+	}
+
+	data, err := json.MarshalIndent(sidecar, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(SidecarPath(imagePath), data, 0644); err != nil {
+		return nil, err
+	}
+	return current, nil
+}
+
+// mergeTags adds tags to current, keeping the order they were first
+// added in, or with remove drops them
+func mergeTags(current, tags []string, remove bool) []string {
+	drop := map[string]bool{}
+	if remove {
+		for _, t := range tags {
+			drop[strings.ToLower(t)] = true
+		}
+		tags = nil
+	}
+
+	seen := map[string]bool{}
+	var merged []string
+	for _, t := range append(current, tags...) {
+		t = strings.ToLower(t)
+		if t == "" || seen[t] || drop[t] {
+			continue
+		}
+		seen[t] = true
+		merged = append(merged, t)
+	}
+	return merged
+}
+
+// HasTag reports whether the image is tagged tag, ignoring case
+func (img GalleryImage) HasTag(tag string) bool {
+	for _, t := range img.Metadata.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterByTag keeps the images tagged tag
+func FilterByTag(images []GalleryImage, tag string) []GalleryImage {
+	var tagged []GalleryImage
+	for _, img := range images {
+		if img.HasTag(tag) {
+			tagged = append(tagged, img)
+		}
+	}
+	return tagged
+}
+
+// SearchGallery keeps the images whose prompt, checkpoint, LoRAs or tags
+// contain every word of query, ignoring case
+func SearchGallery(images []GalleryImage, query string) []GalleryImage {
+	words := strings.Fields(strings.ToLower(query))
+	var matches []GalleryImage
+	for _, img := range images {
+		text := img.searchText()
+		found := true
+		for _, w := range words {
+			if !strings.Contains(text, w) {
+				found = false
+				break
+			}
+		}
+		if found {
+			matches = append(matches, img)
+		}
+	}
+	return matches
+}
+
+// searchText is everything SearchGallery matches against, lowercased
+func (img GalleryImage) searchText() string {
+	m := img.Metadata
+	parts := []string{m.Prompt, m.Original, m.Checkpoint}
+	for _, l := range m.LoRAs {
+		parts = append(parts, l.Name)
+	}
+	parts = append(parts, m.Tags...)
+	return strings.ToLower(strings.Join(parts, "\n"))
+}
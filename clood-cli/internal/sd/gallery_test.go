@@ -0,0 +1,174 @@
+package sd
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// pngWithText encodes a small PNG and inserts a tEXt chunk after IHDR,
+// the way ComfyUI saves its workflow
+func pngWithText(t *testing.T, key, value string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 3))); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	payload := append([]byte(key+"\x00"), value...)
+	chunk := make([]byte, 8, 12+len(payload))
+	binary.BigEndian.PutUint32(chunk, uint32(len(payload)))
+	copy(chunk[4:], "tEXt")
+	chunk = append(chunk, payload...)
+	chunk = binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+
+	ihdrEnd := 8 + 8 + 13 + 4
+	return append(append(append([]byte{}, data[:ihdrEnd]...), chunk...), data[ihdrEnd:]...)
+}
+
+func TestParsePNGMetadata(t *testing.T) {
+	workflow := `{
+		"4": {"class_type": "CheckpointLoaderSimple", "inputs": {"ckpt_name": "sdxl.safetensors"}},
+		"5": {"class_type": "EmptyLatentImage", "inputs": {"width": 1024, "height": 768}},
+		"6": {"class_type": "CLIPTextEncode", "inputs": {"text": "a lighthouse"}},
+		"7": {"class_type": "CLIPTextEncode", "inputs": {"text": "blurry"}},
+		"3": {"class_type": "KSampler", "inputs": {"seed": 42, "steps": 25, "cfg": 7.5,
+			"sampler_name": "euler", "positive": ["6", 0], "negative": ["7", 0]}}
+	}`
+
+	meta, ok := ParsePNGMetadata(bytes.NewReader(pngWithText(t, "prompt", workflow)))
+	if !ok {
+		t.Fatal("no metadata found")
+	}
+	if meta.Checkpoint != "sdxl.safetensors" || meta.Width != 1024 || meta.Height != 768 {
+		t.Errorf("meta = %+v", meta)
+	}
+	if meta.Seed != 42 || meta.Steps != 25 || meta.Prompt != "a lighthouse" || meta.Negative != "blurry" {
+		t.Errorf("sampler meta = %+v", meta)
+	}
+
+	if _, ok := ParsePNGMetadata(bytes.NewReader(pngWithText(t, "Software", "x"))); ok {
+		t.Error("found metadata in a PNG without a prompt chunk")
+	}
+}
+
+func TestLoadGalleryPrefersSidecar(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "paint.png")
+	if err := os.WriteFile(path, pngWithText(t, "Software", "x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "bare.png"), pngWithText(t, "Software", "x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteSidecar(path, ImageMetadata{Checkpoint: "sd15.ckpt", Prompt: "a cat"}); err != nil {
+		t.Fatal(err)
+	}
+
+	images, err := LoadGallery(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(images) != 2 {
+		t.Fatalf("got %d images, want 2", len(images))
+	}
+	for _, img := range images {
+		switch filepath.Base(img.Path) {
+		case "paint.png":
+			if img.Source != "sidecar" || img.Metadata.Checkpoint != "sd15.ckpt" || img.Metadata.Width != 4 {
+				t.Errorf("paint.png = %+v", img)
+			}
+		case "bare.png":
+			if img.Source != "none" || img.Metadata.Width != 4 || img.Metadata.Height != 3 {
+				t.Errorf("bare.png = %+v", img)
+			}
+		}
+	}
+}
+
+func TestTagImage(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "paint.png")
+	if err := os.WriteFile(path, pngWithText(t, "Software", "x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// A field clood doesn't write should survive tagging
+	sidecar := `{"checkpoint": "sd15.ckpt", "prompt": "a tortoise", "rating": 5}`
+	if err := os.WriteFile(SidecarPath(path), []byte(sidecar), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tags, err := TagImage(path, ParseTags("Ghibli, character,,ghibli"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(tags, ",") != "ghibli,character" {
+		t.Errorf("tags = %v, want [ghibli character]", tags)
+	}
+	if tags, _ = TagImage(path, []string{"character"}, true); strings.Join(tags, ",") != "ghibli" {
+		t.Errorf("after remove, tags = %v, want [ghibli]", tags)
+	}
+
+	data, _ := os.ReadFile(SidecarPath(path))
+	if !strings.Contains(string(data), `"rating": 5`) {
+		t.Errorf("unknown sidecar field lost:\n%s", data)
+	}
+	images, err := LoadGallery(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(images) != 1 || !images[0].HasTag("GHIBLI") || images[0].Metadata.Prompt != "a tortoise" {
+		t.Errorf("images = %+v", images)
+	}
+
+	// An image with no sidecar gets one
+	bare := filepath.Join(dir, "bare.png")
+	if err := os.WriteFile(bare, pngWithText(t, "Software", "x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := TagImage(bare, []string{"sketch"}, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(SidecarPath(bare)); err != nil {
+		t.Errorf("no sidecar written: %v", err)
+	}
+}
+
+func TestSearchGallery(t *testing.T) {
+	images := []GalleryImage{
+		{Path: "a.png", Metadata: ImageMetadata{Prompt: "A tortoise on a beach", Checkpoint: "sdxl.safetensors"}},
+		{Path: "b.png", Metadata: ImageMetadata{Prompt: "a lighthouse", Checkpoint: "dreamshaper.safetensors", Tags: []string{"ghibli"}}},
+		{Path: "c.png", Metadata: ImageMetadata{Prompt: "a castle", LoRAs: []LoRA{{Name: "ghibli_style"}}}},
+	}
+
+	names := func(imgs []GalleryImage) string {
+		var n []string
+		for _, img := range imgs {
+			n = append(n, img.Path)
+		}
+		return strings.Join(n, ",")
+	}
+	cases := map[string]string{
+		"tortoise":        "a.png",
+		"TORTOISE beach":  "a.png",
+		"tortoise sdxl":   "a.png",
+		"dreamshaper":     "b.png",
+		"ghibli":          "b.png,c.png",
+		"tortoise ghibli": "",
+	}
+	for query, want := range cases {
+		if got := names(SearchGallery(images, query)); got != want {
+			t.Errorf("SearchGallery(%q) = %q, want %q", query, got, want)
+		}
+	}
+	if got := names(FilterByTag(images, "Ghibli")); got != "b.png" {
+		t.Errorf("FilterByTag = %q, want b.png", got)
+	}
+}
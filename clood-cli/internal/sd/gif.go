@@ -0,0 +1,134 @@
+package sd
+
+import (
+	"fmt"
+	"image"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"io"
+	"os"
+	"time"
+)
+
+// CompareGIFDelay is how long each frame of the comparison GIF is shown.
+const CompareGIFDelay = 2 * time.Second
+
+// WriteCompareGIF encodes the images at paths as a looping GIF with one
+// frame per image. Frames are scaled to the smallest image's size so
+// mixed SD1.5/SDXL results line up, then dithered to a 256-color palette.
+func WriteCompareGIF(w io.Writer, paths []string, delay time.Duration) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("no images to encode")
+	}
+
+	var frames []image.Image
+	for _, path := range paths {
+		img, err := decodeImageFile(path)
+		if err != nil {
+			return err
+		}
+		frames = append(frames, img)
+	}
+
+	size := smallestSize(frames)
+	bounds := image.Rect(0, 0, size.X, size.Y)
+	centis := int(delay / (10 * time.Millisecond))
+
+	anim := &gif.GIF{LoopCount: 0}
+	for _, frame := range frames {
+		scaled := resizeBilinear(frame, size.X, size.Y)
+		paletted := image.NewPaletted(bounds, palette.Plan9)
+		draw.FloydSteinberg.Draw(paletted, bounds, scaled, image.Point{})
+		anim.Image = append(anim.Image, paletted)
+		anim.Delay = append(anim.Delay, centis)
+	}
+
+	return gif.EncodeAll(w, anim)
+}
+
+func decodeImageFile(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("decode %s: %w", path, err)
+	}
+	return img, nil
+}
+
+// smallestSize returns the dimensions of the image with the smallest area
+func smallestSize(images []image.Image) image.Point {
+	best := images[0].Bounds().Size()
+	for _, img := range images[1:] {
+		s := img.Bounds().Size()
+		if s.X*s.Y < best.X*best.Y {
+			best = s
+		}
+	}
+	return best
+}
+
+// resizeBilinear scales src to w x h. The stdlib has no resampler, and
+// bilinear is plenty for a preview GIF.
+func resizeBilinear(src image.Image, w, h int) *image.RGBA {
+	sb := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	if sb.Dx() == w && sb.Dy() == h {
+		draw.Draw(dst, dst.Bounds(), src, sb.Min, draw.Src)
+		return dst
+	}
+
+	xRatio := float64(sb.Dx()) / float64(w)
+	yRatio := float64(sb.Dy()) / float64(h)
+	for y := 0; y < h; y++ {
+		sy := (float64(y)+0.5)*yRatio - 0.5
+		y0 := clampInt(int(sy), 0, sb.Dy()-1)
+		y1 := clampInt(y0+1, 0, sb.Dy()-1)
+		fy := sy - float64(y0)
+		if fy < 0 {
+			fy = 0
+		}
+		for x := 0; x < w; x++ {
+			sx := (float64(x)+0.5)*xRatio - 0.5
+			x0 := clampInt(int(sx), 0, sb.Dx()-1)
+			x1 := clampInt(x0+1, 0, sb.Dx()-1)
+			fx := sx - float64(x0)
+			if fx < 0 {
+				fx = 0
+			}
+
+			r00, g00, b00, a00 := src.At(sb.Min.X+x0, sb.Min.Y+y0).RGBA()
+			r10, g10, b10, a10 := src.At(sb.Min.X+x1, sb.Min.Y+y0).RGBA()
+			r01, g01, b01, a01 := src.At(sb.Min.X+x0, sb.Min.Y+y1).RGBA()
+			r11, g11, b11, a11 := src.At(sb.Min.X+x1, sb.Min.Y+y1).RGBA()
+
+			lerp := func(c00, c10, c01, c11 uint32) uint8 {
+				top := float64(c00)*(1-fx) + float64(c10)*fx
+				bottom := float64(c01)*(1-fx) + float64(c11)*fx
+				return uint8((top*(1-fy) + bottom*fy) / 257)
+			}
+
+			i := dst.PixOffset(x, y)
+			dst.Pix[i+0] = lerp(r00, r10, r01, r11)
+			dst.Pix[i+1] = lerp(g00, g10, g01, g11)
+			dst.Pix[i+2] = lerp(b00, b10, b01, b11)
+			dst.Pix[i+3] = lerp(a00, a10, a01, a11)
+		}
+	}
+	return dst
+}
+
+func clampInt(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
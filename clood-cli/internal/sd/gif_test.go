@@ -0,0 +1,75 @@
+package sd
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeTestPNG(t *testing.T, dir, name string, w, h int, c color.RGBA) string {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	path := filepath.Join(dir, name)
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestWriteCompareGIF(t *testing.T) {
+	dir := t.TempDir()
+	paths := []string{
+		writeTestPNG(t, dir, "sdxl.png", 64, 64, color.RGBA{255, 0, 0, 255}),
+		writeTestPNG(t, dir, "sd15.png", 32, 32, color.RGBA{0, 0, 255, 255}),
+		writeTestPNG(t, dir, "wide.png", 48, 40, color.RGBA{0, 255, 0, 255}),
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCompareGIF(&buf, paths, CompareGIFDelay); err != nil {
+		t.Fatal(err)
+	}
+
+	anim, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(anim.Image) != 3 {
+		t.Fatalf("got %d frames, want 3", len(anim.Image))
+	}
+	for i, frame := range anim.Image {
+		if size := frame.Bounds().Size(); size != (image.Point{32, 32}) {
+			t.Errorf("frame %d is %v, want the smallest size 32x32", i, size)
+		}
+		if anim.Delay[i] != 200 {
+			t.Errorf("frame %d delay = %d, want 200 (2s)", i, anim.Delay[i])
+		}
+	}
+
+	// Solid colors should survive scaling and quantization
+	r, _, b, _ := anim.Image[0].At(16, 16).RGBA()
+	if r>>8 < 200 || b>>8 > 50 {
+		t.Errorf("frame 0 center should stay red, got r=%d b=%d", r>>8, b>>8)
+	}
+}
+
+func TestWriteCompareGIFEmpty(t *testing.T) {
+	if err := WriteCompareGIF(&bytes.Buffer{}, nil, time.Second); err == nil {
+		t.Error("expected an error for no images")
+	}
+}
@@ -0,0 +1,56 @@
+package sd
+
+import (
+	"fmt"
+	"image"
+	"os"
+)
+
+// MaskCoverage is the fraction of a mask's pixels that will be inpainted.
+// LoadImageMask reads the red channel, so like ComfyUI this counts pixels
+// whose red is at least half bright; for a grayscale mask that's the white
+// ones.
+func MaskCoverage(mask image.Image) float64 {
+	b := mask.Bounds()
+	if b.Empty() {
+		return 0
+	}
+	white := 0
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if r, _, _, _ := mask.At(x, y).RGBA(); r >= 0x8000 {
+				white++
+			}
+		}
+	}
+	return float64(white) / float64(b.Dx()*b.Dy())
+}
+
+// CheckInpaintInputs checks that maskPath can constrain an inpaint of
+// imagePath: it must be the same size and have some white in it. It
+// returns the image size and the mask's coverage.
+func CheckInpaintInputs(imagePath, maskPath string) (width, height int, coverage float64, err error) {
+	width, height, err = ImageSize(imagePath)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	f, err := os.Open(maskPath)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	mask, _, err := image.Decode(f)
+	f.Close()
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("read mask %s: %w", maskPath, err)
+	}
+
+	if b := mask.Bounds(); b.Dx() != width || b.Dy() != height {
+		return 0, 0, 0, fmt.Errorf("mask is %dx%d but the image is %dx%d; they must match", b.Dx(), b.Dy(), width, height)
+	}
+	coverage = MaskCoverage(mask)
+	if coverage == 0 {
+		return 0, 0, 0, fmt.Errorf("mask %s is all black, so nothing would be inpainted (white marks the area to change)", maskPath)
+	}
+	return width, height, coverage, nil
+}
@@ -0,0 +1,80 @@
+package sd
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMaskCoverage(t *testing.T) {
+	mask := image.NewGray(image.Rect(0, 0, 10, 10))
+	draw.Draw(mask, image.Rect(0, 0, 5, 10), image.White, image.Point{}, draw.Src)
+	mask.SetGray(9, 9, color.Gray{Y: 100}) // too dark to count
+	if got := MaskCoverage(mask); got != 0.5 {
+		t.Errorf("MaskCoverage = %v, want 0.5", got)
+	}
+}
+
+func TestCheckInpaintInputs(t *testing.T) {
+	dir := t.TempDir()
+	imagePath, err := writeTempPNG(dir, "image-*.png", image.NewRGBA(image.Rect(0, 0, 64, 32)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mask := image.NewGray(image.Rect(0, 0, 64, 32))
+	blackPath, _ := writeTempPNG(dir, "black-*.png", mask)
+	draw.Draw(mask, image.Rect(0, 0, 16, 32), image.White, image.Point{}, draw.Src)
+	maskPath, _ := writeTempPNG(dir, "mask-*.png", mask)
+	smallPath, _ := writeTempPNG(dir, "small-*.png", image.NewGray(image.Rect(0, 0, 32, 32)))
+
+	w, h, coverage, err := CheckInpaintInputs(imagePath, maskPath)
+	if err != nil || w != 64 || h != 32 || coverage != 0.25 {
+		t.Errorf("CheckInpaintInputs = %d, %d, %v, %v", w, h, coverage, err)
+	}
+	if _, _, _, err := CheckInpaintInputs(imagePath, smallPath); err == nil || !strings.Contains(err.Error(), "must match") {
+		t.Errorf("size mismatch: err = %v", err)
+	}
+	if _, _, _, err := CheckInpaintInputs(imagePath, blackPath); err == nil || !strings.Contains(err.Error(), "all black") {
+		t.Errorf("black mask: err = %v", err)
+	}
+}
+
+func TestBuildWorkflowInpaint(t *testing.T) {
+	cfg := DefaultWorkflowConfig()
+	cfg.Prompt = NewPrompt("ocean waves")
+	cfg.Checkpoint = "sd_xl_base_1.0.safetensors"
+	cfg.InputImagePath = "/tmp/base.png"
+	cfg.MaskImagePath = "/tmp/mask.png"
+	cfg.Denoise = 0.9
+	cfg.ControlNets = []ControlNetConfig{{Type: "canny", ImagePath: "/tmp/edges.png", Model: "canny.safetensors"}}
+
+	w, err := BuildBasicWorkflow(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if m := w.Nodes["11"]; m.ClassType != "LoadImageMask" || m.Inputs["image"] != "mask.png" {
+		t.Errorf("mask loader: %+v", m)
+	}
+	cond := w.Nodes["10"]
+	if cond.ClassType != "InpaintModelConditioning" || !reflect.DeepEqual(cond.Inputs["mask"], []interface{}{"11", 0}) ||
+		!reflect.DeepEqual(cond.Inputs["pixels"], []interface{}{"5", 0}) {
+		t.Errorf("inpaint conditioning: %+v", cond)
+	}
+
+	// The ControlNet applies to the inpaint conditioning, not the raw prompt
+	apply := w.Nodes["103"]
+	if !reflect.DeepEqual(apply.Inputs["positive"], []interface{}{"10", 0}) || !reflect.DeepEqual(apply.Inputs["negative"], []interface{}{"10", 1}) {
+		t.Errorf("controlnet apply reads %v / %v", apply.Inputs["positive"], apply.Inputs["negative"])
+	}
+
+	sampler := w.Nodes["3"]
+	if !reflect.DeepEqual(sampler.Inputs["latent_image"], []interface{}{"10", 2}) ||
+		!reflect.DeepEqual(sampler.Inputs["positive"], []interface{}{"103", 0}) || sampler.Inputs["denoise"] != 0.9 {
+		t.Errorf("sampler: %+v", sampler.Inputs)
+	}
+}
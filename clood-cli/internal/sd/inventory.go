@@ -0,0 +1,266 @@
+package sd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// InventoryFolders maps model types to the ComfyUI models/ folder that
+// holds them.
+var InventoryFolders = map[string]string{
+	"checkpoint": "checkpoints",
+	"lora":       "loras",
+	"vae":        "vae",
+	"embedding":  "embeddings",
+	"controlnet": "controlnet",
+	"upscaler":   "upscale_models",
+}
+
+// Inventory lists the model files ComfyUI can see, keyed by type.
+type Inventory map[string][]string
+
+// NormalizeModelType maps CivitAI and ComfyUI type names onto the keys of
+// InventoryFolders, e.g. "LORA" and "LoCon" both become "lora".
+func NormalizeModelType(t string) string {
+	switch strings.ToLower(strings.TrimSpace(t)) {
+	case "checkpoint", "checkpoints":
+		return "checkpoint"
+	case "lora", "loras", "locon", "lycoris", "dora":
+		return "lora"
+	case "vae":
+		return "vae"
+	case "embedding", "embeddings", "textualinversion":
+		return "embedding"
+	case "controlnet":
+		return "controlnet"
+	case "upscaler", "upscale_models":
+		return "upscaler"
+	}
+	return strings.ToLower(t)
+}
+
+// Models lists the files in one of ComfyUI's model folders via /models/<folder>.
+func (c *Client) Models(folder string) ([]string, error) {
+	resp, err := c.HTTPClient.Get(c.BaseURL + "/models/" + url.PathEscape(folder))
+	if err != nil {
+		return nil, fmt.Errorf("list %s: %w", folder, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("comfyui returned %d: %s", resp.StatusCode, string(b))
+	}
+
+	var files []string
+	if err := json.NewDecoder(resp.Body).Decode(&files); err != nil {
+		return nil, fmt.Errorf("decode %s list: %w", folder, err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// Inventory lists every folder in InventoryFolders. Folders this ComfyUI
+// doesn't have are left empty rather than failing the whole listing.
+func (c *Client) Inventory() (Inventory, error) {
+	inv := make(Inventory)
+	var firstErr error
+	for typ, folder := range InventoryFolders {
+		files, err := c.Models(folder)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		inv[typ] = files
+	}
+	if len(inv) == 0 && firstErr != nil {
+		return nil, firstErr
+	}
+	return inv, nil
+}
+
+// WishlistItem is one entry of a CivitAI wishlist file:
+// [{"id": 12345, "type": "checkpoint"}, ...]
+type WishlistItem struct {
+	ID   int    `json:"id"`
+	Type string `json:"type,omitempty"`
+}
+
+// LoadWishlist reads a wishlist JSON file.
+func LoadWishlist(path string) ([]WishlistItem, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var items []WishlistItem
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("parse wishlist %s: %w", path, err)
+	}
+	for i, item := range items {
+		if item.ID <= 0 {
+			return nil, fmt.Errorf("wishlist %s: entry %d has no id", path, i+1)
+		}
+	}
+	return items, nil
+}
+
+// CivitAIModel is the latest version of a CivitAI model and its primary file.
+type CivitAIModel struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Version     string `json:"version,omitempty"`
+	FileName    string `json:"file_name,omitempty"`
+	DownloadURL string `json:"download_url,omitempty"`
+}
+
+// CivitAIModel looks up a model by ID via /models/<id>.
+func (p *MultiSourceParser) CivitAIModel(id int) (*CivitAIModel, error) {
+	resp, err := p.civitaiGet("/models/" + strconv.Itoa(id))
+	if err != nil {
+		return nil, fmt.Errorf("fetch civitai model %d: %w", id, err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		ID            int    `json:"id"`
+		Name          string `json:"name"`
+		Type          string `json:"type"`
+		ModelVersions []struct {
+			Name        string `json:"name"`
+			DownloadURL string `json:"downloadUrl"`
+			Files       []struct {
+				Name        string `json:"name"`
+				Primary     bool   `json:"primary"`
+				DownloadURL string `json:"downloadUrl"`
+			} `json:"files"`
+		} `json:"modelVersions"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode civitai model %d: %w", id, err)
+	}
+
+	model := &CivitAIModel{ID: result.ID, Name: result.Name, Type: result.Type}
+	if len(result.ModelVersions) > 0 {
+		// Versions are newest first
+		v := result.ModelVersions[0]
+		model.Version = v.Name
+		model.DownloadURL = v.DownloadURL
+		for i, f := range v.Files {
+			if f.Primary || i == 0 {
+				model.FileName = f.Name
+				if f.DownloadURL != "" {
+					model.DownloadURL = f.DownloadURL
+				}
+			}
+		}
+	}
+	return model, nil
+}
+
+var (
+	// Version and precision suffixes that differ between a CivitAI file
+	// name and what people save it as: _v9, -V2.1, _fp16, -pruned, ...
+	modelVersionSuffixRe = regexp.MustCompile(`[-_. ]v\d.*$|[-_. ](fp16|fp32|bf16|pruned|emaonly|safetensors|ckpt)\b.*$`)
+	modelNonAlnumRe      = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// ModelStem reduces a model file or display name to a comparable stem:
+// "Juggernaut XL", "juggernautXL_v9Rdphoto2.safetensors" and
+// "juggernautxl-V10.ckpt" all become "juggernautxl".
+func ModelStem(name string) string {
+	name = strings.ToLower(path.Base(strings.ReplaceAll(name, `\`, "/")))
+	name = strings.TrimSuffix(name, path.Ext(name))
+	name = modelVersionSuffixRe.ReplaceAllString(name, "")
+	return modelNonAlnumRe.ReplaceAllString(name, "")
+}
+
+// MatchLocalModel finds the local file that fuzzy-matches any of names.
+// Stems match when equal, or when one is a prefix of the other and the
+// shorter is long enough not to be a coincidence.
+func MatchLocalModel(local []string, names ...string) (string, bool) {
+	const minPrefix = 6
+
+	for _, name := range names {
+		want := ModelStem(name)
+		if want == "" {
+			continue
+		}
+		for _, file := range local {
+			have := ModelStem(file)
+			if have == "" {
+				continue
+			}
+			if have == want {
+				return file, true
+			}
+			short, long := have, want
+			if len(short) > len(long) {
+				short, long = long, short
+			}
+			if len(short) >= minPrefix && strings.HasPrefix(long, short) {
+				return file, true
+			}
+		}
+	}
+	return "", false
+}
+
+// WishlistEntry is the outcome of checking one wishlist item.
+type WishlistEntry struct {
+	WishlistItem
+	Model     *CivitAIModel `json:"model,omitempty"`
+	LocalFile string        `json:"local_file,omitempty"`
+	Missing   bool          `json:"missing"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// DiffWishlist resolves each item with resolve and looks for it in inv.
+// Items whose type has no folder are checked against every folder.
+func DiffWishlist(items []WishlistItem, inv Inventory, resolve func(id int) (*CivitAIModel, error)) []WishlistEntry {
+	var all []string
+	for _, files := range inv {
+		all = append(all, files...)
+	}
+
+	entries := make([]WishlistEntry, 0, len(items))
+	for _, item := range items {
+		entry := WishlistEntry{WishlistItem: item}
+
+		model, err := resolve(item.ID)
+		if err != nil {
+			entry.Error = err.Error()
+			entries = append(entries, entry)
+			continue
+		}
+		entry.Model = model
+
+		typ := NormalizeModelType(item.Type)
+		if typ == "" {
+			typ = NormalizeModelType(model.Type)
+		}
+		local, ok := inv[typ]
+		if !ok {
+			local = all
+		}
+
+		if file, found := MatchLocalModel(local, model.FileName, model.Name); found {
+			entry.LocalFile = file
+		} else {
+			entry.Missing = true
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
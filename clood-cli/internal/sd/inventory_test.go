@@ -0,0 +1,86 @@
+package sd
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestModelStem(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Juggernaut XL", "juggernautxl"},
+		{"juggernautXL_v9Rdphoto2Lightning.safetensors", "juggernautxl"},
+		{"checkpoints/juggernautxl-V10.ckpt", "juggernautxl"},
+		{"dreamshaper_8_pruned.safetensors", "dreamshaper8"},
+		{"add-detail-xl.safetensors", "adddetailxl"},
+	}
+	for _, tt := range tests {
+		if got := ModelStem(tt.name); got != tt.want {
+			t.Errorf("ModelStem(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDiffWishlist(t *testing.T) {
+	inv := Inventory{
+		"checkpoint": {"juggernautXL_v10.safetensors", "dreamshaper_8.safetensors"},
+		"lora":       {"add-detail-xl.safetensors"},
+	}
+	models := map[int]*CivitAIModel{
+		1: {ID: 1, Name: "Juggernaut XL", Type: "Checkpoint", FileName: "juggernautXL_v9Rdphoto2Lightning.safetensors"},
+		2: {ID: 2, Name: "Pony Diffusion V6 XL", Type: "Checkpoint", FileName: "ponyDiffusionV6XL_v6StartWithThisOne.safetensors",
+			DownloadURL: "https://civitai.com/api/download/models/2"},
+		3: {ID: 3, Name: "Detail Tweaker XL", Type: "LORA", FileName: "add-detail-xl.safetensors"},
+	}
+	resolve := func(id int) (*CivitAIModel, error) {
+		if m, ok := models[id]; ok {
+			return m, nil
+		}
+		return nil, fmt.Errorf("model %d not found", id)
+	}
+
+	items := []WishlistItem{{ID: 1, Type: "checkpoint"}, {ID: 2, Type: "checkpoint"}, {ID: 3}, {ID: 4}}
+	entries := DiffWishlist(items, inv, resolve)
+
+	if entries[0].Missing || entries[0].LocalFile != "juggernautXL_v10.safetensors" {
+		t.Errorf("renamed download not matched: %+v", entries[0])
+	}
+	if !entries[1].Missing {
+		t.Errorf("pony should be missing: %+v", entries[1])
+	}
+	if entries[2].Missing {
+		t.Errorf("untyped LoRA should match by its CivitAI type: %+v", entries[2])
+	}
+	if entries[3].Error == "" {
+		t.Errorf("unresolvable id should report an error: %+v", entries[3])
+	}
+}
+
+func TestCivitAIModel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/models/42" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"id":42,"name":"DreamShaper","type":"Checkpoint","modelVersions":[
+{"name":"8","downloadUrl":"https://civitai.com/api/download/models/128713","files":[
+{"name":"dreamshaper_8.vae.pt","primary":false},
+{"name":"dreamshaper_8.safetensors","primary":true,"downloadUrl":"https://civitai.com/api/download/models/128713?type=Model"}]}]}`))
+	}))
+	defer srv.Close()
+
+	p := NewMultiSourceParser()
+	p.CivitAIURL = srv.URL
+
+	m, err := p.CivitAIModel(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Name != "DreamShaper" || m.Version != "8" || m.FileName != "dreamshaper_8.safetensors" ||
+		m.DownloadURL != "https://civitai.com/api/download/models/128713?type=Model" {
+		t.Errorf("unexpected model: %+v", m)
+	}
+}
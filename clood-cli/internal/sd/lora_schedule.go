@@ -0,0 +1,195 @@
+package sd
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultLoRASplit is the fraction of the steps a scheduled LoRA spends at
+// its early weight before switching to the late one
+const DefaultLoRASplit = 0.7
+
+// LoRASchedule applies a LoRA at one weight for the early steps, which set
+// composition, and another for the late steps, which refine detail.
+type LoRASchedule struct {
+	Name  string  `json:"name" yaml:"name"`
+	Early float64 `json:"early" yaml:"early"`
+	Late  float64 `json:"late" yaml:"late"`
+	Split float64 `json:"split,omitempty" yaml:"split,omitempty"` // 0-1, default DefaultLoRASplit
+}
+
+// ParseLoRASchedule parses "name:early=1.0,late=0.3[,split=0.6]", or
+// "name:weight" for the same weight throughout
+func ParseLoRASchedule(spec string) (LoRASchedule, error) {
+	name, weights, ok := strings.Cut(spec, ":")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" || weights == "" {
+		return LoRASchedule{}, fmt.Errorf("lora %q needs a weight (name:0.8 or name:early=1.0,late=0.3)", spec)
+	}
+	s := LoRASchedule{Name: name, Split: DefaultLoRASplit}
+
+	if w, err := strconv.ParseFloat(weights, 64); err == nil {
+		s.Early, s.Late = w, w
+		return s, nil
+	}
+
+	seen := map[string]bool{}
+	for _, part := range strings.Split(weights, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return LoRASchedule{}, fmt.Errorf("lora %q: expected key=value, got %q", spec, part)
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return LoRASchedule{}, fmt.Errorf("lora %q: invalid %s weight %q", spec, key, value)
+		}
+		switch key {
+		case "early":
+			s.Early = v
+		case "late":
+			s.Late = v
+		case "split":
+			if v <= 0 || v >= 1 {
+				return LoRASchedule{}, fmt.Errorf("lora %q: split must be between 0 and 1", spec)
+			}
+			s.Split = v
+		default:
+			return LoRASchedule{}, fmt.Errorf("lora %q: unknown key %q (early, late, split)", spec, key)
+		}
+		seen[key] = true
+	}
+	if !seen["early"] || !seen["late"] {
+		return LoRASchedule{}, fmt.Errorf("lora %q needs both early= and late= weights", spec)
+	}
+	return s, nil
+}
+
+// Constant reports whether the schedule keeps one weight for every step
+func (s LoRASchedule) Constant() bool {
+	return s.Early == s.Late
+}
+
+// loraScheduleNodes are the IDs of the i'th LoRA's early and late-step
+// loaders in a workflow built by BuildBasicWorkflow
+func loraScheduleNodes(i int) (early, late string) {
+	return strconv.Itoa(200 + i*2), strconv.Itoa(201 + i*2)
+}
+
+// loraFile names a LoRA in ComfyUI's models/loras, adding the extension
+// LoRALoaderNode assumes when there isn't one
+func loraFile(name string) string {
+	if strings.HasSuffix(name, ".safetensors") || strings.HasSuffix(name, ".pt") || strings.HasSuffix(name, ".ckpt") {
+		return name
+	}
+	return name + ".safetensors"
+}
+
+// addLoRASchedules chains a LoraLoader per LoRA between the checkpoint and
+// the sampler at its early weight. When any LoRA changes weight, the
+// KSampler is split into two KSamplerAdvanced stages over the same noise:
+// the first runs to the earliest split on the early-weight model and hands
+// its leftover noise to the second, which finishes on a model chained
+// through LoraLoaderModelOnly at the late weights. The prompt is encoded
+// once, with the early weights on CLIP.
+func addLoRASchedules(workflow *ComfyWorkflow, cfg *WorkflowConfig) error {
+	if cfg.VideoMode {
+		return fmt.Errorf("LoRA schedules are not supported in video mode")
+	}
+
+	earlyModel := []interface{}{"4", 0}
+	earlyClip := []interface{}{"4", 1}
+	lateModel := []interface{}{"4", 0}
+	split := 1.0
+	for i, l := range cfg.LoRASchedules {
+		earlyID, lateID := loraScheduleNodes(i)
+		workflow.Nodes[earlyID] = ComfyNode{
+			ClassType: "LoraLoader",
+			Inputs: map[string]interface{}{
+				"lora_name":      loraFile(l.Name),
+				"strength_model": l.Early,
+				"strength_clip":  l.Early,
+				"model":          earlyModel,
+				"clip":           earlyClip,
+			},
+		}
+		earlyModel = []interface{}{earlyID, 0}
+		earlyClip = []interface{}{earlyID, 1}
+
+		workflow.Nodes[lateID] = ComfyNode{
+			ClassType: "LoraLoaderModelOnly",
+			Inputs: map[string]interface{}{
+				"lora_name":      loraFile(l.Name),
+				"strength_model": l.Late,
+				"model":          lateModel,
+			},
+		}
+		lateModel = []interface{}{lateID, 0}
+
+		if !l.Constant() {
+			s := l.Split
+			if s <= 0 || s >= 1 {
+				s = DefaultLoRASplit
+			}
+			split = math.Min(split, s)
+		}
+	}
+	workflow.Nodes["6"].Inputs["clip"] = earlyClip
+	workflow.Nodes["7"].Inputs["clip"] = earlyClip
+
+	sampler := workflow.Nodes["3"]
+	if split == 1 {
+		// Every weight is constant: one sampler on the early chain is enough
+		for i := range cfg.LoRASchedules {
+			_, lateID := loraScheduleNodes(i)
+			delete(workflow.Nodes, lateID)
+		}
+		sampler.Inputs["model"] = earlyModel
+		return nil
+	}
+
+	// KSamplerAdvanced has no denoise; a partial denoise is the tail of a
+	// longer schedule, the way KSampler runs it
+	steps := cfg.Steps
+	if steps < 2 {
+		return fmt.Errorf("a LoRA weight schedule needs at least 2 steps")
+	}
+	total, start := steps, 0
+	if denoise, _ := sampler.Inputs["denoise"].(float64); denoise > 0 && denoise < 1 {
+		total = int(math.Round(float64(steps) / denoise))
+		start = total - steps
+	}
+	splitStep := start + int(math.Round(float64(steps)*split))
+	if splitStep <= start {
+		splitStep = start + 1
+	}
+	if splitStep >= total {
+		splitStep = total - 1
+	}
+
+	stage := func(model []interface{}, addNoise string, from, to int, leftover string, latent interface{}) ComfyNode {
+		return ComfyNode{
+			ClassType: "KSamplerAdvanced",
+			Inputs: map[string]interface{}{
+				"model":                      model,
+				"add_noise":                  addNoise,
+				"noise_seed":                 sampler.Inputs["seed"],
+				"steps":                      total,
+				"cfg":                        sampler.Inputs["cfg"],
+				"sampler_name":               sampler.Inputs["sampler_name"],
+				"scheduler":                  sampler.Inputs["scheduler"],
+				"positive":                   sampler.Inputs["positive"],
+				"negative":                   sampler.Inputs["negative"],
+				"latent_image":               latent,
+				"start_at_step":              from,
+				"end_at_step":                to,
+				"return_with_leftover_noise": leftover,
+			},
+		}
+	}
+	workflow.Nodes["3"] = stage(earlyModel, "enable", start, splitStep, "enable", sampler.Inputs["latent_image"])
+	workflow.Nodes["12"] = stage(lateModel, "disable", splitStep, total, "disable", []interface{}{"3", 0})
+	workflow.Nodes["8"].Inputs["samples"] = []interface{}{"12", 0}
+	return nil
+}
@@ -0,0 +1,93 @@
+package sd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLoRASchedule(t *testing.T) {
+	tests := []struct {
+		spec string
+		want LoRASchedule
+	}{
+		{"style:early=1.0,late=0.3", LoRASchedule{Name: "style", Early: 1, Late: 0.3, Split: DefaultLoRASplit}},
+		{"style:early=0.8, late=0.2, split=0.5", LoRASchedule{Name: "style", Early: 0.8, Late: 0.2, Split: 0.5}},
+		{"detail:0.6", LoRASchedule{Name: "detail", Early: 0.6, Late: 0.6, Split: DefaultLoRASplit}},
+	}
+	for _, tt := range tests {
+		got, err := ParseLoRASchedule(tt.spec)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseLoRASchedule(%q) = %+v, %v; want %+v", tt.spec, got, err, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"style", "style:early=1.0", "style:early=1,late=x", "style:early=1,late=0,split=1.5", "style:mid=0.5"} {
+		if _, err := ParseLoRASchedule(bad); err == nil {
+			t.Errorf("ParseLoRASchedule(%q): expected an error", bad)
+		}
+	}
+}
+
+func TestBuildWorkflowLoRASchedule(t *testing.T) {
+	cfg := DefaultWorkflowConfig()
+	cfg.Prompt = NewPrompt("a forest shrine").WithSeed(7)
+	cfg.Checkpoint = "sd_xl_base_1.0.safetensors"
+	cfg.Steps = 20
+	cfg.LoRASchedules = []LoRASchedule{
+		{Name: "ghibli_style", Early: 1.0, Late: 0.3, Split: 0.7},
+		{Name: "detail.safetensors", Early: 0.5, Late: 0.5},
+	}
+
+	w, err := BuildBasicWorkflow(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	early, second := w.Nodes["200"], w.Nodes["202"]
+	if early.ClassType != "LoraLoader" || early.Inputs["lora_name"] != "ghibli_style.safetensors" || early.Inputs["strength_model"] != 1.0 {
+		t.Errorf("early loader: %+v", early)
+	}
+	if second.Inputs["lora_name"] != "detail.safetensors" || !reflect.DeepEqual(second.Inputs["model"], []interface{}{"200", 0}) {
+		t.Errorf("second loader should chain off the first: %+v", second.Inputs)
+	}
+	if late := w.Nodes["203"]; late.ClassType != "LoraLoaderModelOnly" || !reflect.DeepEqual(late.Inputs["model"], []interface{}{"201", 0}) {
+		t.Errorf("late chain: %+v", late)
+	}
+	if !reflect.DeepEqual(w.Nodes["6"].Inputs["clip"], []interface{}{"202", 1}) {
+		t.Errorf("prompt clip = %v", w.Nodes["6"].Inputs["clip"])
+	}
+
+	first, last := w.Nodes["3"], w.Nodes["12"]
+	if first.ClassType != "KSamplerAdvanced" || first.Inputs["start_at_step"] != 0 || first.Inputs["end_at_step"] != 14 ||
+		first.Inputs["return_with_leftover_noise"] != "enable" || !reflect.DeepEqual(first.Inputs["model"], []interface{}{"202", 0}) {
+		t.Errorf("first stage: %+v", first.Inputs)
+	}
+	if last.Inputs["add_noise"] != "disable" || last.Inputs["start_at_step"] != 14 || last.Inputs["end_at_step"] != 20 ||
+		!reflect.DeepEqual(last.Inputs["latent_image"], []interface{}{"3", 0}) || !reflect.DeepEqual(last.Inputs["model"], []interface{}{"203", 0}) {
+		t.Errorf("second stage: %+v", last.Inputs)
+	}
+	if !reflect.DeepEqual(w.Nodes["8"].Inputs["samples"], []interface{}{"12", 0}) {
+		t.Errorf("decode reads %v", w.Nodes["8"].Inputs["samples"])
+	}
+}
+
+func TestBuildWorkflowConstantLoRA(t *testing.T) {
+	cfg := DefaultWorkflowConfig()
+	cfg.Prompt = NewPrompt("a forest shrine")
+	cfg.Checkpoint = "sd_xl_base_1.0.safetensors"
+	cfg.LoRASchedules = []LoRASchedule{{Name: "detail", Early: 0.6, Late: 0.6}}
+
+	w, err := BuildBasicWorkflow(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Nodes["3"].ClassType != "KSampler" || !reflect.DeepEqual(w.Nodes["3"].Inputs["model"], []interface{}{"200", 0}) {
+		t.Errorf("sampler: %+v", w.Nodes["3"])
+	}
+	if _, ok := w.Nodes["201"]; ok {
+		t.Error("a constant weight shouldn't add a late-step loader")
+	}
+	if _, ok := w.Nodes["12"]; ok {
+		t.Error("a constant weight shouldn't split the sampler")
+	}
+}
@@ -0,0 +1,79 @@
+package sd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultNegativePresets are the built-in named negative prompts. A
+// negatives file can override them or add more.
+func DefaultNegativePresets() map[string]string {
+	return map[string]string{
+		"photo-realistic": "cartoon, anime, illustration, painting, drawing, 3d render, cgi, " +
+			"ugly, blurry, low quality, worst quality, jpeg artifacts, deformed, bad anatomy, " +
+			"bad hands, extra fingers, watermark, signature, text, nsfw",
+		"anime": "photo, realistic, 3d, lowres, blurry, low quality, worst quality, bad anatomy, " +
+			"bad hands, extra digits, fewer digits, cropped, watermark, signature, text, nsfw",
+		"cinematic": "flat lighting, overexposed, underexposed, oversaturated, amateur, snapshot, " +
+			"blurry, low quality, worst quality, deformed, disfigured, watermark, signature, " +
+			"text, frame, border, nsfw",
+	}
+}
+
+// LoadNegativePresets returns the built-in presets with the YAML file at
+// path (name: prompt) merged over them. A missing file is not an error.
+func LoadNegativePresets(path string) (map[string]string, error) {
+	presets := DefaultNegativePresets()
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return presets, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var custom map[string]string
+	if err := yaml.Unmarshal(data, &custom); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	for name, negative := range custom {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "" && strings.TrimSpace(negative) != "" {
+			presets[name] = strings.TrimSpace(negative)
+		}
+	}
+	return presets, nil
+}
+
+// PresetNames returns the preset names, sorted
+func PresetNames(presets map[string]string) []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ResolveNegative builds a negative prompt from a named preset and/or
+// literal text, joined with a comma when both are given. It returns ""
+// when neither is, leaving the prompt's default in place.
+func ResolveNegative(presets map[string]string, preset, negative string) (string, error) {
+	var parts []string
+	if preset != "" {
+		text, ok := presets[strings.ToLower(preset)]
+		if !ok {
+			return "", fmt.Errorf("unknown negative preset %q (have: %s)", preset, strings.Join(PresetNames(presets), ", "))
+		}
+		parts = append(parts, text)
+	}
+	if negative = strings.TrimSpace(negative); negative != "" {
+		parts = append(parts, negative)
+	}
+	return strings.Join(parts, ", "), nil
+}
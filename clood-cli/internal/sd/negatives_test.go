@@ -0,0 +1,54 @@
+package sd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadNegativePresets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sd_negatives.yaml")
+	presets, err := LoadNegativePresets(path)
+	if err != nil || len(presets) != len(DefaultNegativePresets()) {
+		t.Fatalf("missing file: %d presets, %v", len(presets), err)
+	}
+
+	os.WriteFile(path, []byte("Anime: \"sketch, lowres\"\nproduct: busy background\nempty: \"\"\n"), 0644)
+	presets, err = LoadNegativePresets(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if presets["anime"] != "sketch, lowres" {
+		t.Errorf("anime = %q, want the override", presets["anime"])
+	}
+	if presets["product"] != "busy background" || presets["cinematic"] == "" {
+		t.Errorf("file should add to the built-ins: %v", PresetNames(presets))
+	}
+	if _, ok := presets["empty"]; ok {
+		t.Error("empty preset should be ignored")
+	}
+}
+
+func TestResolveNegative(t *testing.T) {
+	presets := map[string]string{"photo": "cartoon, blurry"}
+
+	tests := []struct {
+		preset, negative, want string
+	}{
+		{"", "", ""},
+		{"photo", "", "cartoon, blurry"},
+		{"", "hats", "hats"},
+		{"Photo", " hats ", "cartoon, blurry, hats"},
+	}
+	for _, tt := range tests {
+		got, err := ResolveNegative(presets, tt.preset, tt.negative)
+		if err != nil || got != tt.want {
+			t.Errorf("ResolveNegative(%q, %q) = %q, %v; want %q", tt.preset, tt.negative, got, err, tt.want)
+		}
+	}
+
+	if _, err := ResolveNegative(presets, "nope", ""); err == nil || !strings.Contains(err.Error(), "photo") {
+		t.Errorf("unknown preset error should list the names, got %v", err)
+	}
+}
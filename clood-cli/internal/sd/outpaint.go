@@ -0,0 +1,118 @@
+package sd
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"os"
+	"strings"
+)
+
+// ExtendDirections are the sides an image can be outpainted towards
+var ExtendDirections = []string{"left", "right", "up", "down"}
+
+// NormalizeExtendDirection accepts a side name, with top and bottom as
+// aliases for up and down.
+func NormalizeExtendDirection(dir string) (string, error) {
+	d := strings.ToLower(strings.TrimSpace(dir))
+	switch d {
+	case "top":
+		d = "up"
+	case "bottom":
+		d = "down"
+	}
+	for _, valid := range ExtendDirections {
+		if d == valid {
+			return d, nil
+		}
+	}
+	return "", fmt.Errorf("unknown direction %q (want %s)", dir, strings.Join(ExtendDirections, ", "))
+}
+
+// ExtendCanvas pads src by pixels on one side. The canvas has src composited
+// unchanged with the new strip in neutral gray, and the mask is white over
+// the strip and black over the original. pixels is rounded up so the
+// padded side is a multiple of 8, which SD latents need.
+func ExtendCanvas(src image.Image, direction string, pixels int) (*image.RGBA, *image.Gray) {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	offset := image.Point{}
+	switch direction {
+	case "left", "right":
+		pixels = roundUp8(w+pixels) - w
+		w += pixels
+		if direction == "left" {
+			offset.X = pixels
+		}
+	default:
+		pixels = roundUp8(h+pixels) - h
+		h += pixels
+		if direction == "up" {
+			offset.Y = pixels
+		}
+	}
+
+	canvas := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(canvas, canvas.Bounds(), image.NewUniform(color.Gray{Y: 128}), image.Point{}, draw.Src)
+	original := image.Rectangle{Min: offset, Max: offset.Add(b.Size())}
+	draw.Draw(canvas, original, src, b.Min, draw.Src)
+
+	mask := image.NewGray(canvas.Bounds())
+	draw.Draw(mask, mask.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(mask, original, image.Black, image.Point{}, draw.Src)
+
+	return canvas, mask
+}
+
+func roundUp8(n int) int {
+	return (n + 7) / 8 * 8
+}
+
+// BuildOutpaintInputs writes the padded canvas and mask for imagePath as
+// PNGs in dir, ready for WorkflowConfig.InputImagePath and MaskImagePath.
+// The caller removes them once uploaded.
+func BuildOutpaintInputs(imagePath, direction string, pixels int, dir string) (canvasPath, maskPath string, width, height int, err error) {
+	if pixels <= 0 {
+		return "", "", 0, 0, fmt.Errorf("pixels must be positive, got %d", pixels)
+	}
+
+	f, err := os.Open(imagePath)
+	if err != nil {
+		return "", "", 0, 0, err
+	}
+	src, _, err := image.Decode(f)
+	f.Close()
+	if err != nil {
+		return "", "", 0, 0, fmt.Errorf("read image %s: %w", imagePath, err)
+	}
+
+	canvas, mask := ExtendCanvas(src, direction, pixels)
+	if canvasPath, err = writeTempPNG(dir, "clood-extend-*.png", canvas); err != nil {
+		return "", "", 0, 0, err
+	}
+	if maskPath, err = writeTempPNG(dir, "clood-extend-mask-*.png", mask); err != nil {
+		os.Remove(canvasPath)
+		return "", "", 0, 0, err
+	}
+	return canvasPath, maskPath, canvas.Bounds().Dx(), canvas.Bounds().Dy(), nil
+}
+
+func writeTempPNG(dir, pattern string, img image.Image) (string, error) {
+	f, err := os.CreateTemp(dir, pattern)
+	if err != nil {
+		return "", err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
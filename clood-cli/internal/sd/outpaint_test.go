@@ -0,0 +1,51 @@
+package sd
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestExtendCanvas(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 64, 40))
+	red := color.RGBA{R: 255, A: 255}
+	for y := 0; y < 40; y++ {
+		for x := 0; x < 64; x++ {
+			src.Set(x, y, red)
+		}
+	}
+
+	tests := []struct {
+		dir          string
+		pixels       int
+		w, h         int
+		inside, edge image.Point // a pixel of the original, and one of the new strip
+	}{
+		{"right", 32, 96, 40, image.Pt(0, 0), image.Pt(95, 0)},
+		{"left", 30, 96, 40, image.Pt(95, 0), image.Pt(0, 0)}, // rounded up to 32
+		{"up", 10, 64, 56, image.Pt(0, 55), image.Pt(0, 0)},   // 40+10 -> 56
+		{"down", 8, 64, 48, image.Pt(0, 0), image.Pt(0, 47)},
+	}
+	for _, tt := range tests {
+		canvas, mask := ExtendCanvas(src, tt.dir, tt.pixels)
+		if b := canvas.Bounds(); b.Dx() != tt.w || b.Dy() != tt.h {
+			t.Errorf("%s: canvas %dx%d, want %dx%d", tt.dir, b.Dx(), b.Dy(), tt.w, tt.h)
+			continue
+		}
+		if got := canvas.RGBAAt(tt.inside.X, tt.inside.Y); got != red {
+			t.Errorf("%s: original pixel at %v = %v", tt.dir, tt.inside, got)
+		}
+		if mask.GrayAt(tt.inside.X, tt.inside.Y).Y != 0 || mask.GrayAt(tt.edge.X, tt.edge.Y).Y != 255 {
+			t.Errorf("%s: mask should be black over the original and white over the strip", tt.dir)
+		}
+	}
+}
+
+func TestNormalizeExtendDirection(t *testing.T) {
+	if d, err := NormalizeExtendDirection("Bottom"); err != nil || d != "down" {
+		t.Errorf("Bottom = %q, %v", d, err)
+	}
+	if _, err := NormalizeExtendDirection("diagonal"); err == nil {
+		t.Error("diagonal should be rejected")
+	}
+}
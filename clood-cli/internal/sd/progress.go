@@ -0,0 +1,94 @@
+package sd
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Binary frames on ComfyUI's websocket start with a big-endian event type;
+// previews follow it with an image format (1 JPEG, 2 PNG) and the image.
+const (
+	wsPreviewImage = 1
+	wsPreviewPNG   = 2
+)
+
+// Progress is a sampling step reported by ComfyUI while a prompt runs.
+type Progress struct {
+	PromptID    string `json:"prompt_id"`
+	Node        string `json:"node"`
+	Step        int    `json:"step"`
+	Max         int    `json:"max"`
+	Preview     []byte `json:"-"` // latest latent preview since the last step, if any
+	PreviewType string `json:"-"` // image/jpeg or image/png
+}
+
+// ListenProgress connects to ComfyUI's websocket and calls onProgress for
+// each sampling step of prompts this client queues, until stop is called.
+// ComfyUI only reports progress to the client that queued the prompt, so
+// c.ClientID is replaced with a unique one; call it before Generate.
+// Previews need ComfyUI's --preview-method to be set.
+func (c *Client) ListenProgress(onProgress func(Progress)) (stop func(), err error) {
+	id := make([]byte, 8)
+	rand.Read(id)
+	c.ClientID = "clood-" + hex.EncodeToString(id)
+
+	wsURL := strings.Replace(c.BaseURL, "http", "ws", 1) + "/ws?clientId=" + c.ClientID
+	dialer := websocket.Dialer{HandshakeTimeout: 5 * time.Second}
+	conn, _, err := dialer.Dial(wsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("connect to comfyui websocket: %w", err)
+	}
+
+	go func() {
+		var preview []byte
+		var previewType string
+		for {
+			kind, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+
+			if kind == websocket.BinaryMessage {
+				if len(data) > 8 && binary.BigEndian.Uint32(data) == wsPreviewImage {
+					previewType = "image/jpeg"
+					if binary.BigEndian.Uint32(data[4:]) == wsPreviewPNG {
+						previewType = "image/png"
+					}
+					preview = data[8:]
+				}
+				continue
+			}
+
+			var msg struct {
+				Type string `json:"type"`
+				Data struct {
+					Value    int    `json:"value"`
+					Max      int    `json:"max"`
+					PromptID string `json:"prompt_id"`
+					Node     string `json:"node"`
+				} `json:"data"`
+			}
+			if json.Unmarshal(data, &msg) != nil || msg.Type != "progress" {
+				continue
+			}
+			onProgress(Progress{
+				PromptID:    msg.Data.PromptID,
+				Node:        msg.Data.Node,
+				Step:        msg.Data.Value,
+				Max:         msg.Data.Max,
+				Preview:     preview,
+				PreviewType: previewType,
+			})
+			preview = nil
+		}
+	}()
+
+	return func() { conn.Close() }, nil
+}
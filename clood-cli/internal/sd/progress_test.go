@@ -0,0 +1,56 @@
+package sd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestListenProgress(t *testing.T) {
+	clientIDs := make(chan string, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientIDs <- r.URL.Query().Get("clientId")
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"status","data":{"status":{}}}`))
+		conn.WriteMessage(websocket.BinaryMessage, []byte{0, 0, 0, 1, 0, 0, 0, 2, 'p', 'n', 'g'})
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"progress","data":{"value":3,"max":20,"prompt_id":"abc","node":"3"}}`))
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"progress","data":{"value":4,"max":20,"prompt_id":"abc","node":"3"}}`))
+		conn.ReadMessage() // hold the connection until the client stops
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	got := make(chan Progress, 2)
+	stop, err := client.ListenProgress(func(p Progress) { got <- p })
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+
+	if id := <-clientIDs; id != client.ClientID || id == "clood" {
+		t.Errorf("connected as %q, client queues as %q", id, client.ClientID)
+	}
+
+	var steps []Progress
+	for len(steps) < 2 {
+		select {
+		case p := <-got:
+			steps = append(steps, p)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("got %d progress events, want 2", len(steps))
+		}
+	}
+	if p := steps[0]; p.PromptID != "abc" || p.Step != 3 || p.Max != 20 || string(p.Preview) != "png" || p.PreviewType != "image/png" {
+		t.Errorf("first step = %+v", p)
+	}
+	if p := steps[1]; p.Step != 4 || p.Preview != nil {
+		t.Errorf("second step = %+v, preview should not repeat", p)
+	}
+}
@@ -14,6 +14,7 @@ type Prompt struct {
 	LoRAs    []LoRA   `json:"loras,omitempty" yaml:"loras,omitempty"`
 	Seed     int64    `json:"seed,omitempty" yaml:"seed,omitempty"`
 	Tags     []string `json:"tags,omitempty" yaml:"tags,omitempty"` // For organization/search
+	Original string   `json:"original,omitempty" yaml:"original,omitempty"` // Positive as written, when it was enhanced
 }
 
 // LoRA represents a LoRA model to apply with its weight.
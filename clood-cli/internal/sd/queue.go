@@ -0,0 +1,198 @@
+package sd
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// TimingWindow is how many recent generations the wait estimate averages
+const TimingWindow = 10
+
+// QueueStatus is how busy ComfyUI is, from /queue
+type QueueStatus struct {
+	Pending int `json:"queue_pending"`
+	Running int `json:"queue_running"`
+}
+
+// QueueStatus reports how many prompts are waiting and running
+func (c *Client) QueueStatus() (QueueStatus, error) {
+	var queue struct {
+		Running []json.RawMessage `json:"queue_running"`
+		Pending []json.RawMessage `json:"queue_pending"`
+	}
+	if err := c.getJSON("/queue", &queue); err != nil {
+		return QueueStatus{}, err
+	}
+	return QueueStatus{Pending: len(queue.Pending), Running: len(queue.Running)}, nil
+}
+
+// SystemStats is ComfyUI's /system_stats
+type SystemStats struct {
+	System struct {
+		OS             string `json:"os"`
+		ComfyUIVersion string `json:"comfyui_version,omitempty"`
+		PythonVersion  string `json:"python_version"`
+		PyTorchVersion string `json:"pytorch_version,omitempty"`
+		RAMTotal       int64  `json:"ram_total"`
+		RAMFree        int64  `json:"ram_free"`
+	} `json:"system"`
+	Devices []struct {
+		Name      string `json:"name"`
+		Type      string `json:"type"`
+		VRAMTotal int64  `json:"vram_total"`
+		VRAMFree  int64  `json:"vram_free"`
+	} `json:"devices"`
+}
+
+// SystemStats fetches the server's versions, RAM and GPUs
+func (c *Client) SystemStats() (*SystemStats, error) {
+	var stats SystemStats
+	if err := c.getJSON("/system_stats", &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// Timings is a moving window of recent generation times. Client.Wait adds
+// to it when the client has a TimingsPath.
+type Timings struct {
+	Recent []float64 `json:"recent_sec"` // oldest first, at most TimingWindow
+}
+
+// LoadTimings reads the timings file; a missing file is an empty window
+func LoadTimings(path string) (*Timings, error) {
+	t := &Timings{}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return t, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, t); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return t, nil
+}
+
+// Save writes t to path, creating its directory. The file is replaced
+// by a rename, so a reader never sees it half-written.
+func (t *Timings) Save(path string) error {
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Add records one generation, dropping the oldest beyond TimingWindow
+func (t *Timings) Add(d time.Duration) {
+	t.Recent = append(t.Recent, d.Seconds())
+	if len(t.Recent) > TimingWindow {
+		t.Recent = t.Recent[len(t.Recent)-TimingWindow:]
+	}
+}
+
+// Average is the mean of the window, or false if nothing is recorded
+func (t *Timings) Average() (time.Duration, bool) {
+	if len(t.Recent) == 0 {
+		return 0, false
+	}
+	var sum float64
+	for _, s := range t.Recent {
+		sum += s
+	}
+	return time.Duration(sum / float64(len(t.Recent)) * float64(time.Second)), true
+}
+
+// EstimateWait is how long a prompt queued now would wait before it
+// starts: everything running or pending, at avg each. The running prompt
+// is counted in full since how far along it is isn't known.
+func EstimateWait(q QueueStatus, avg time.Duration) time.Duration {
+	return time.Duration(q.Pending+q.Running) * avg
+}
+
+// ApproxDuration formats d for a status line: "~45s", "~2m", "~1h10m"
+func ApproxDuration(d time.Duration) string {
+	switch {
+	case d <= 0:
+		return "none"
+	case d < time.Minute:
+		return fmt.Sprintf("~%ds", int(math.Ceil(d.Seconds())))
+	case d < time.Hour:
+		return fmt.Sprintf("~%dm", int(math.Round(d.Minutes())))
+	default:
+		d = d.Round(time.Minute)
+		return fmt.Sprintf("~%dh%dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
+
+// timingsMu serializes recordTiming's load, add and save, which parallel
+// batch generations would otherwise interleave and lose records to
+var timingsMu sync.Mutex
+
+// recordTiming adds d to the timings file. It's best effort: a status
+// estimate isn't worth failing a finished generation over.
+func (c *Client) recordTiming(d time.Duration) {
+	timingsMu.Lock()
+	defer timingsMu.Unlock()
+	t, err := LoadTimings(c.TimingsPath)
+	if err != nil {
+		t = &Timings{}
+	}
+	t.Add(d)
+	_ = t.Save(c.TimingsPath)
+}
+
+// executionDuration reads how long a prompt ran from the execution_start
+// and execution_success messages in its /history status. Each message is
+// [event, {"prompt_id": ..., "timestamp": <unix ms>}].
+func executionDuration(messages []json.RawMessage) time.Duration {
+	var start, end int64
+	for _, raw := range messages {
+		var msg []json.RawMessage
+		if json.Unmarshal(raw, &msg) != nil || len(msg) < 2 {
+			continue
+		}
+		var event string
+		var data struct {
+			Timestamp int64 `json:"timestamp"`
+		}
+		if json.Unmarshal(msg[0], &event) != nil || json.Unmarshal(msg[1], &data) != nil {
+			continue
+		}
+		switch event {
+		case "execution_start":
+			start = data.Timestamp
+		case "execution_success":
+			end = data.Timestamp
+		}
+	}
+	if start == 0 || end <= start {
+		return 0
+	}
+	return time.Duration(end-start) * time.Millisecond
+}
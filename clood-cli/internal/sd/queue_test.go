@@ -0,0 +1,133 @@
+package sd
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestQueueStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"queue_running": [[1, "a", {}, {}, []]], "queue_pending": [[2, "b", {}, {}, []], [3, "c", {}, {}, []]]}`)
+	}))
+	defer srv.Close()
+
+	q, err := NewClient(srv.URL).QueueStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.Running != 1 || q.Pending != 2 {
+		t.Errorf("got %+v, want 1 running and 2 pending", q)
+	}
+}
+
+func TestTimingsWindow(t *testing.T) {
+	tm := &Timings{}
+	if _, ok := tm.Average(); ok {
+		t.Error("empty window has an average")
+	}
+	for i := 1; i <= TimingWindow+2; i++ {
+		tm.Add(time.Duration(i) * time.Second)
+	}
+	if len(tm.Recent) != TimingWindow || tm.Recent[0] != 3 {
+		t.Fatalf("window should keep the last %d, got %v", TimingWindow, tm.Recent)
+	}
+	// mean of 3..12
+	if avg, ok := tm.Average(); !ok || avg != 7500*time.Millisecond {
+		t.Errorf("average = %v, %v", avg, ok)
+	}
+
+	path := filepath.Join(t.TempDir(), "nested", "timings.json")
+	if err := tm.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadTimings(path)
+	if err != nil || len(loaded.Recent) != TimingWindow {
+		t.Errorf("round trip: %v, %v", loaded, err)
+	}
+	if missing, err := LoadTimings(filepath.Join(t.TempDir(), "none.json")); err != nil || len(missing.Recent) != 0 {
+		t.Errorf("missing file: %v, %v", missing, err)
+	}
+}
+
+func TestEstimateWait(t *testing.T) {
+	got := EstimateWait(QueueStatus{Pending: 3, Running: 1}, 30*time.Second)
+	if got != 2*time.Minute {
+		t.Errorf("EstimateWait = %v, want 2m", got)
+	}
+
+	cases := map[time.Duration]string{
+		0:                               "none",
+		1500 * time.Millisecond:         "~2s",
+		2 * time.Minute:                 "~2m",
+		150 * time.Second:               "~3m",
+		70*time.Minute + 20*time.Second: "~1h10m",
+	}
+	for d, want := range cases {
+		if got := ApproxDuration(d); got != want {
+			t.Errorf("ApproxDuration(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestWaitRecordsExecutionTime(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"p1": {"outputs": {"9": {"images": [{"filename": "a.png", "type": "output"}]}},
+			"status": {"status_str": "success", "completed": true, "messages": [
+				["execution_start", {"prompt_id": "p1", "timestamp": 1700000000000}],
+				["execution_cached", {"prompt_id": "p1", "timestamp": 1700000000100}],
+				["execution_success", {"prompt_id": "p1", "timestamp": 1700000012500}]]}}}`)
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	client.TimingsPath = filepath.Join(t.TempDir(), "timings.json")
+	if _, err := client.Wait("p1", time.Second); err != nil {
+		t.Fatal(err)
+	}
+	tm, err := LoadTimings(client.TimingsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tm.Recent) != 1 || tm.Recent[0] != 12.5 {
+		t.Errorf("recorded %v, want [12.5]", tm.Recent)
+	}
+
+	// Warmups load models; they'd skew the estimate
+	if _, err := client.wait("p1", time.Second, false); err != nil {
+		t.Fatal(err)
+	}
+	if tm, _ := LoadTimings(client.TimingsPath); len(tm.Recent) != 1 {
+		t.Errorf("unrecorded wait was recorded: %v", tm.Recent)
+	}
+}
+
+func TestRecordTimingConcurrent(t *testing.T) {
+	dir := t.TempDir()
+	client := NewClient("")
+	client.TimingsPath = filepath.Join(dir, "timings.json")
+
+	var wg sync.WaitGroup
+	for i := 1; i <= TimingWindow; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			client.recordTiming(time.Duration(i) * time.Second)
+		}(i)
+	}
+	wg.Wait()
+
+	tm, err := LoadTimings(client.TimingsPath)
+	if err != nil || len(tm.Recent) != TimingWindow {
+		t.Fatalf("recorded %v (%v), want all %d", tm, err, TimingWindow)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("temp files left behind: %v", entries)
+	}
+}
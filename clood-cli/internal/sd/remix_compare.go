@@ -0,0 +1,274 @@
+package sd
+
+import (
+	"fmt"
+	"image"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+)
+
+// fidelityGrid is the size both images are averaged down to before
+// comparing, which keeps the score about composition and colour rather
+// than fine detail the seed can't reproduce exactly
+const fidelityGrid = 32
+
+// RemixComparison pairs a source image with its local reproduction for
+// the side-by-side CompareGallery layout.
+type RemixComparison struct {
+	Source       *ImageSource
+	Config       *WorkflowConfig // what was actually run
+	SourceImage  string          // relative to the page; empty if it couldn't be fetched
+	Reproduction string          // relative to the page
+	Fidelity     float64         // 0-1 from ImageFidelity, or -1 when unknown
+}
+
+// ParamDiff is one generation setting as the source reported it and as it
+// was run locally
+type ParamDiff struct {
+	Name    string
+	Source  string
+	Local   string
+	Differs bool
+}
+
+// ParamDiffs lists the settings that decide how close a remix can get, with
+// the ones that changed (--checkpoint, --seed, --add, ...) flagged.
+func (rc *RemixComparison) ParamDiffs() []ParamDiff {
+	src, cfg := rc.Source, rc.Config
+	diff := func(name, source, local string) ParamDiff {
+		return ParamDiff{Name: name, Source: source, Local: local, Differs: source != local}
+	}
+
+	model := ParamDiff{Name: "Model", Source: src.Model, Local: cfg.Checkpoint}
+	model.Differs = trimCheckpointExt(src.Model) != trimCheckpointExt(cfg.Checkpoint)
+
+	sampler := ParamDiff{Name: "Sampler", Source: src.Sampler, Local: cfg.Sampler + " / " + cfg.Scheduler}
+	if s, sch, ok := ComfySampler(src.Sampler); ok {
+		sampler.Differs = s != cfg.Sampler || sch != cfg.Scheduler
+	} else {
+		sampler.Differs = true
+	}
+
+	// Sources that don't report a size fall back to the default, which
+	// isn't a change worth flagging
+	size := ParamDiff{Name: "Size", Local: fmt.Sprintf("%dx%d", cfg.Width, cfg.Height)}
+	if src.Width > 0 && src.Height > 0 {
+		size = diff("Size", fmt.Sprintf("%dx%d", src.Width, src.Height), size.Local)
+	}
+
+	return []ParamDiff{
+		diff("Prompt", src.Prompt, cfg.Prompt.Positive),
+		diff("Negative", src.Negative, cfg.Prompt.Negative),
+		model,
+		diff("Seed", fmt.Sprint(src.Seed), fmt.Sprint(cfg.Prompt.Seed)),
+		diff("Steps", fmt.Sprint(src.Steps), fmt.Sprint(cfg.Steps)),
+		diff("CFG", fmt.Sprintf("%.1f", src.CFGScale), fmt.Sprintf("%.1f", cfg.CFGScale)),
+		sampler,
+		size,
+	}
+}
+
+func trimCheckpointExt(name string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(name, ".safetensors"), ".ckpt")
+}
+
+// ImageFidelity scores how alike two images look, from 0 (opposite) to 1
+// (identical at fidelityGrid resolution). The images may differ in size.
+func ImageFidelity(a, b image.Image) float64 {
+	ga, gb := averageGrid(a), averageGrid(b)
+	var total float64
+	for i := range ga {
+		d := ga[i] - gb[i]
+		if d < 0 {
+			d = -d
+		}
+		total += d
+	}
+	return 1 - total/float64(len(ga))/0xffff
+}
+
+// averageGrid box-averages img into fidelityGrid x fidelityGrid RGB cells
+func averageGrid(img image.Image) []float64 {
+	b := img.Bounds()
+	cells := make([]float64, fidelityGrid*fidelityGrid*3)
+	counts := make([]float64, fidelityGrid*fidelityGrid)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		cy := (y - b.Min.Y) * fidelityGrid / b.Dy()
+		for x := b.Min.X; x < b.Max.X; x++ {
+			cx := (x - b.Min.X) * fidelityGrid / b.Dx()
+			r, g, bl, _ := img.At(x, y).RGBA()
+			i := cy*fidelityGrid + cx
+			cells[i*3] += float64(r)
+			cells[i*3+1] += float64(g)
+			cells[i*3+2] += float64(bl)
+			counts[i]++
+		}
+	}
+	for i, n := range counts {
+		if n > 0 {
+			cells[i*3] /= n
+			cells[i*3+1] /= n
+			cells[i*3+2] /= n
+		}
+	}
+	return cells
+}
+
+// DownloadImage saves the source's image to dest, copying it when the
+// source is a local file. The file extension follows the image URL, so
+// call SourceImageExt to name dest.
+func (s *ImageSource) DownloadImage(httpClient *http.Client, dest string) error {
+	if s.ImageURL == "" {
+		return fmt.Errorf("source has no image URL")
+	}
+	if !strings.Contains(s.ImageURL, "://") {
+		return copyFile(s.ImageURL, dest)
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Get(s.ImageURL)
+	if err != nil {
+		return fmt.Errorf("download source image: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("download source image: HTTP %d", resp.StatusCode)
+	}
+
+	f, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func copyFile(src, dest string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// SourceImageExt is the extension of the source image URL, .png if it has none
+func (s *ImageSource) SourceImageExt() string {
+	if ext := path.Ext(strings.SplitN(s.ImageURL, "?", 2)[0]); ext != "" && len(ext) <= 5 {
+		return ext
+	}
+	return ".png"
+}
+
+const remixTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>{{.Title}}</title>
+    <style>
+        * { box-sizing: border-box; margin: 0; padding: 0; }
+        body {
+            font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif;
+            background: #1a1a2e;
+            color: #eee;
+            padding: 2rem;
+        }
+        .header {
+            max-width: 1400px;
+            margin: 0 auto 2rem;
+            padding-bottom: 1rem;
+            border-bottom: 2px solid #e94560;
+            display: flex;
+            justify-content: space-between;
+            align-items: flex-end;
+        }
+        h1 { color: #e94560; font-size: 2rem; margin-bottom: 0.5rem; }
+        .subtitle a { color: #888; }
+        .fidelity { text-align: right; }
+        .fidelity .score { font-size: 2.5rem; font-weight: bold; color: #4ecca3; }
+        .fidelity .score.low { color: #ffaa00; }
+        .fidelity .label { color: #888; font-size: 0.85rem; }
+        .pair {
+            display: grid;
+            grid-template-columns: 1fr 1fr;
+            gap: 2rem;
+            max-width: 1400px;
+            margin: 0 auto 2rem;
+        }
+        .card { background: #16213e; border-radius: 12px; overflow: hidden; }
+        .card img { width: 100%; height: auto; display: block; }
+        .card-title { color: #e94560; font-size: 1.1rem; padding: 1rem; }
+        .missing { padding: 4rem 1rem; color: #888; font-style: italic; text-align: center; }
+        table {
+            max-width: 1400px;
+            width: 100%;
+            margin: 0 auto;
+            border-collapse: collapse;
+            background: #16213e;
+            border-radius: 8px;
+            overflow: hidden;
+            font-size: 0.9rem;
+        }
+        th, td { padding: 0.6rem 1rem; text-align: left; vertical-align: top; border-bottom: 1px solid #0f3460; }
+        th { color: #e94560; }
+        td.name { color: #888; width: 120px; }
+        tr.differs td { background: rgba(255, 170, 0, 0.12); }
+        tr.differs td.name { color: #ffaa00; font-weight: bold; }
+        .footer { text-align: center; margin-top: 3rem; padding-top: 1rem; border-top: 1px solid #333; color: #666; }
+    </style>
+</head>
+<body>
+    <div class="header">
+        <div>
+            <h1>Source vs. Reproduction</h1>
+            <p class="subtitle"><a href="{{.URL}}">{{.URL}}</a></p>
+        </div>
+        <div class="fidelity">
+            {{if .HasFidelity}}
+            <div class="score{{if lt .Fidelity 70.0}} low{{end}}">{{printf "%.0f" .Fidelity}}%</div>
+            {{else}}
+            <div class="score low">n/a</div>
+            {{end}}
+            <div class="label">fidelity (colour and layout at {{.Grid}}x{{.Grid}})</div>
+        </div>
+    </div>
+
+    <div class="pair">
+        <div class="card">
+            <div class="card-title">Source</div>
+            {{if .SourceImage}}<img src="{{.SourceImage}}" alt="Source image">{{else}}<div class="missing">Source image unavailable</div>{{end}}
+        </div>
+        <div class="card">
+            <div class="card-title">Local reproduction</div>
+            <img src="{{.Reproduction}}" alt="Local reproduction">
+        </div>
+    </div>
+
+    <table>
+        <tr><th></th><th>Source</th><th>Local</th></tr>
+        {{range .Params}}
+        <tr{{if .Differs}} class="differs"{{end}}><td class="name">{{.Name}}</td><td>{{.Source}}</td><td>{{.Local}}</td></tr>
+        {{end}}
+    </table>
+
+    <div class="footer">
+        Generated by clood sd remix --compare
+    </div>
+</body>
+</html>`
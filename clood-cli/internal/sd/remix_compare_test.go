@@ -0,0 +1,61 @@
+package sd
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+	"testing"
+)
+
+func solid(w, h int, c color.Color) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(img, img.Bounds(), image.NewUniform(c), image.Point{}, draw.Src)
+	return img
+}
+
+func TestImageFidelity(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+	if f := ImageFidelity(solid(64, 64, red), solid(100, 50, red)); f != 1 {
+		t.Errorf("same colour at different sizes = %v, want 1", f)
+	}
+	if f := ImageFidelity(solid(64, 64, color.Black), solid(64, 64, color.White)); f != 0 {
+		t.Errorf("black vs white = %v, want 0", f)
+	}
+
+	half := solid(64, 64, color.Black)
+	draw.Draw(half, image.Rect(0, 0, 32, 64), image.White, image.Point{}, draw.Src)
+	if f := ImageFidelity(half, solid(64, 64, color.Black)); f < 0.49 || f > 0.51 {
+		t.Errorf("half white vs black = %v, want 0.5", f)
+	}
+}
+
+func TestRemixGallery(t *testing.T) {
+	src := &ImageSource{
+		URL: "https://civitai.com/images/1", Prompt: "a fox", Model: "dreamshaper",
+		Seed: 42, Steps: 30, CFGScale: 7, Sampler: "Euler a", Width: 512, Height: 768,
+	}
+	cfg := src.WorkflowConfig("")
+	cfg.Prompt.Seed = 7
+
+	rc := &RemixComparison{Source: src, Config: cfg, Reproduction: "reproduction.png", Fidelity: -1}
+	differs := map[string]bool{}
+	for _, p := range rc.ParamDiffs() {
+		differs[p.Name] = p.Differs
+	}
+	if !differs["Seed"] || differs["Model"] || differs["Sampler"] || differs["Size"] || differs["Prompt"] {
+		t.Errorf("diffs = %v, want only Seed", differs)
+	}
+
+	var buf bytes.Buffer
+	if err := NewRemixGallery(rc, "out").Render(&buf); err != nil {
+		t.Fatal(err)
+	}
+	html := buf.String()
+	for _, want := range []string{"Source image unavailable", "reproduction.png", `class="differs"`, "n/a"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("page missing %q", want)
+		}
+	}
+}
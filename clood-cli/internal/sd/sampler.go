@@ -0,0 +1,110 @@
+package sd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ComfyUI's own KSampler defaults
+const (
+	DefaultSampler   = "euler"
+	DefaultScheduler = "normal"
+)
+
+// KnownSamplers are KSampler sampler_name values ComfyUI ships with.
+var KnownSamplers = []string{
+	"euler", "euler_ancestral", "heun", "heunpp2", "dpm_2", "dpm_2_ancestral",
+	"lms", "dpm_fast", "dpm_adaptive", "dpmpp_2s_ancestral", "dpmpp_sde",
+	"dpmpp_sde_gpu", "dpmpp_2m", "dpmpp_2m_sde", "dpmpp_2m_sde_gpu",
+	"dpmpp_3m_sde", "dpmpp_3m_sde_gpu", "ddpm", "lcm", "ipndm", "deis",
+	"ddim", "uni_pc", "uni_pc_bh2",
+}
+
+// KnownSchedulers are KSampler scheduler values ComfyUI ships with.
+var KnownSchedulers = []string{
+	"normal", "karras", "exponential", "sgm_uniform", "simple", "ddim_uniform", "beta",
+}
+
+// samplerAliases are shorthand names people type, mapped to ComfyUI names
+var samplerAliases = map[string]string{
+	"euler_a":    "euler_ancestral",
+	"euler-a":    "euler_ancestral",
+	"dpm++2m":    "dpmpp_2m",
+	"dpm++_2m":   "dpmpp_2m",
+	"dpm++sde":   "dpmpp_sde",
+	"dpm++2msde": "dpmpp_2m_sde",
+	"dpm++3msde": "dpmpp_3m_sde",
+	"dpm++2sa":   "dpmpp_2s_ancestral",
+	"unipc":      "uni_pc",
+}
+
+// ParseSampler resolves a --sampler value to ComfyUI's sampler name.
+// Besides ComfyUI names it accepts shorthands like euler_a and dpm++2m,
+// and A1111 names like "DPM++ 2M Karras". A "_karras" suffix (or A1111's
+// " Karras") also selects the karras scheduler; otherwise scheduler is "".
+func ParseSampler(name string) (sampler, scheduler string, err error) {
+	n := strings.ToLower(strings.TrimSpace(name))
+	if n == "" {
+		return DefaultSampler, "", nil
+	}
+
+	if s, sched, ok := ComfySampler(n); ok {
+		if sched == "karras" {
+			return s, sched, nil
+		}
+		return s, "", nil
+	}
+
+	if base, ok := strings.CutSuffix(n, "_karras"); ok {
+		s, _, err := ParseSampler(base)
+		return s, "karras", err
+	}
+
+	if alias, ok := samplerAliases[n]; ok {
+		n = alias
+	}
+	for _, known := range KnownSamplers {
+		if n == known {
+			return n, "", nil
+		}
+	}
+	return "", "", fmt.Errorf("unknown sampler %q (known: %s)", name, strings.Join(sortedCopy(KnownSamplers), ", "))
+}
+
+// ParseScheduler validates a --scheduler value
+func ParseScheduler(name string) (string, error) {
+	n := strings.ToLower(strings.TrimSpace(name))
+	if n == "" {
+		return DefaultScheduler, nil
+	}
+	for _, known := range KnownSchedulers {
+		if n == known {
+			return n, nil
+		}
+	}
+	return "", fmt.Errorf("unknown scheduler %q (known: %s)", name, strings.Join(KnownSchedulers, ", "))
+}
+
+// ResolveSampler combines --sampler and --scheduler. A scheduler implied by
+// the sampler name (dpm++2m_karras) applies unless --scheduler was set.
+func ResolveSampler(sampler, scheduler string, schedulerSet bool) (string, string, error) {
+	s, implied, err := ParseSampler(sampler)
+	if err != nil {
+		return "", "", err
+	}
+	if implied != "" && !schedulerSet {
+		return s, implied, nil
+	}
+	sched, err := ParseScheduler(scheduler)
+	if err != nil {
+		return "", "", err
+	}
+	return s, sched, nil
+}
+
+func sortedCopy(s []string) []string {
+	out := append([]string(nil), s...)
+	sort.Strings(out)
+	return out
+}
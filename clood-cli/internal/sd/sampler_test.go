@@ -0,0 +1,41 @@
+package sd
+
+import "testing"
+
+func TestResolveSampler(t *testing.T) {
+	tests := []struct {
+		sampler, scheduler string
+		schedulerSet       bool
+		wantSampler        string
+		wantScheduler      string
+	}{
+		{"", "", false, "euler", "normal"},
+		{"euler", "normal", false, "euler", "normal"},
+		{"euler_a", "normal", false, "euler_ancestral", "normal"},
+		{"dpm++2m", "normal", false, "dpmpp_2m", "normal"},
+		{"dpm++2m_karras", "normal", false, "dpmpp_2m", "karras"},
+		{"dpm++2m_karras", "exponential", true, "dpmpp_2m", "exponential"},
+		{"DPM++ 2M Karras", "normal", false, "dpmpp_2m", "karras"},
+		{"ddim", "karras", true, "ddim", "karras"},
+	}
+	for _, tt := range tests {
+		s, sched, err := ResolveSampler(tt.sampler, tt.scheduler, tt.schedulerSet)
+		if err != nil {
+			t.Errorf("ResolveSampler(%q, %q): %v", tt.sampler, tt.scheduler, err)
+			continue
+		}
+		if s != tt.wantSampler || sched != tt.wantScheduler {
+			t.Errorf("ResolveSampler(%q, %q) = %q, %q; want %q, %q",
+				tt.sampler, tt.scheduler, s, sched, tt.wantSampler, tt.wantScheduler)
+		}
+	}
+}
+
+func TestResolveSamplerRejectsUnknown(t *testing.T) {
+	if _, _, err := ResolveSampler("warp_drive", "normal", false); err == nil {
+		t.Error("expected an error for an unknown sampler")
+	}
+	if _, _, err := ResolveSampler("euler", "sideways", true); err == nil {
+		t.Error("expected an error for an unknown scheduler")
+	}
+}
@@ -0,0 +1,288 @@
+package sd
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultUpscaleDenoise redraws enough to add texture without
+	// changing what's in the picture
+	DefaultUpscaleDenoise = 0.3
+
+	// MaxUpscaleScale keeps a stray --scale from asking for a 40k image
+	MaxUpscaleScale = 8.0
+)
+
+// UpscaleConfig describes an upscale of an existing image. The upscale
+// model does the enlargement; with Detail set, UltimateSDUpscale then
+// redraws the result tile by tile through Checkpoint at low denoise.
+type UpscaleConfig struct {
+	InputImagePath string
+	Width          int // input size, from ImageSize
+	Height         int
+	Scale          float64 // final size relative to the input
+	Model          string  // file in models/upscale_models, e.g. "4x-UltraSharp.pth"
+
+	Detail     bool
+	Checkpoint string
+	Prompt     *Prompt
+	Steps      int
+	CFGScale   float64
+	Sampler    string
+	Scheduler  string
+	Denoise    float64
+	TileSize   int // 0 for the checkpoint's native resolution
+
+	OutputPrefix string
+}
+
+// TargetSize is the output size for cfg, rounded down to a multiple of 8
+func (cfg *UpscaleConfig) TargetSize() (int, int) {
+	w := int(float64(cfg.Width)*cfg.Scale) / 8 * 8
+	h := int(float64(cfg.Height)*cfg.Scale) / 8 * 8
+	return w, h
+}
+
+var upscaleFactorPattern = regexp.MustCompile(`(?i)(?:^|[^0-9])([1-8])x|x([1-8])(?:[^0-9]|$)`)
+
+// UpscaleModelFactor reads a model's native scale from its filename
+// ("4x-UltraSharp.pth", "RealESRGAN_x2plus.pth"), or 0 if it doesn't say
+func UpscaleModelFactor(model string) int {
+	m := upscaleFactorPattern.FindStringSubmatch(filepath.Base(model))
+	if m == nil {
+		return 0
+	}
+	digits := m[1]
+	if digits == "" {
+		digits = m[2]
+	}
+	n, _ := strconv.Atoi(digits)
+	return n
+}
+
+// BuildUpscaleWorkflow chains LoadImage -> ImageUpscaleWithModel -> an
+// exact resize to TargetSize, then UltimateSDUpscale at 1x to add detail
+// when cfg.Detail is set. The resize is needed because upscale models only
+// work at their native factor.
+//
+// UltimateSDUpscale comes from the ComfyUI_UltimateSDUpscale nodes.
+func BuildUpscaleWorkflow(cfg *UpscaleConfig) (*ComfyWorkflow, error) {
+	if cfg.InputImagePath == "" {
+		return nil, fmt.Errorf("input image is required")
+	}
+	if cfg.Model == "" {
+		return nil, fmt.Errorf("upscale model is required")
+	}
+	if cfg.Scale <= 1 || cfg.Scale > MaxUpscaleScale {
+		return nil, fmt.Errorf("scale must be above 1 and at most %g, got %g", MaxUpscaleScale, cfg.Scale)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, fmt.Errorf("input size is required")
+	}
+	width, height := cfg.TargetSize()
+
+	workflow := &ComfyWorkflow{Nodes: map[string]ComfyNode{
+		"1": {ClassType: "LoadImage", Inputs: map[string]interface{}{
+			"image": filepath.Base(cfg.InputImagePath),
+		}},
+		"2": {ClassType: "UpscaleModelLoader", Inputs: map[string]interface{}{
+			"model_name": cfg.Model,
+		}},
+		"3": {ClassType: "ImageUpscaleWithModel", Inputs: map[string]interface{}{
+			"upscale_model": []interface{}{"2", 0},
+			"image":         []interface{}{"1", 0},
+		}},
+		"4": {ClassType: "ImageScale", Inputs: map[string]interface{}{
+			"image":          []interface{}{"3", 0},
+			"upscale_method": "lanczos",
+			"width":          width,
+			"height":         height,
+			"crop":           "disabled",
+		}},
+		"9": {ClassType: "SaveImage", Inputs: map[string]interface{}{
+			"filename_prefix": cfg.OutputPrefix,
+			"images":          []interface{}{"4", 0},
+		}},
+	}}
+
+	if !cfg.Detail {
+		return workflow, nil
+	}
+	if cfg.Checkpoint == "" {
+		return nil, fmt.Errorf("checkpoint is required to add detail")
+	}
+	if cfg.Prompt == nil {
+		return nil, fmt.Errorf("prompt is required to add detail")
+	}
+
+	denoise := cfg.Denoise
+	if denoise <= 0 || denoise > 1 {
+		denoise = DefaultUpscaleDenoise
+	}
+	tile := cfg.TileSize
+	if tile <= 0 {
+		tile = NativeResolution(cfg.Checkpoint)
+	}
+	seed := cfg.Prompt.Seed
+	if seed < 0 {
+		seed = 0
+	}
+
+	workflow.Nodes["5"] = ComfyNode{ClassType: "CheckpointLoaderSimple", Inputs: map[string]interface{}{
+		"ckpt_name": cfg.Checkpoint,
+	}}
+	workflow.Nodes["6"] = ComfyNode{ClassType: "CLIPTextEncode", Inputs: map[string]interface{}{
+		"text": cfg.Prompt.FormatPositive(),
+		"clip": []interface{}{"5", 1},
+	}}
+	workflow.Nodes["7"] = ComfyNode{ClassType: "CLIPTextEncode", Inputs: map[string]interface{}{
+		"text": cfg.Prompt.Negative,
+		"clip": []interface{}{"5", 1},
+	}}
+	workflow.Nodes["8"] = ComfyNode{ClassType: "UltimateSDUpscale", Inputs: map[string]interface{}{
+		"image":         []interface{}{"4", 0},
+		"model":         []interface{}{"5", 0},
+		"positive":      []interface{}{"6", 0},
+		"negative":      []interface{}{"7", 0},
+		"vae":           []interface{}{"5", 2},
+		"upscale_model": []interface{}{"2", 0},
+		"upscale_by":    1.0,
+		"seed":          seed,
+		"steps":         cfg.Steps,
+		"cfg":           cfg.CFGScale,
+		"sampler_name":  cfg.Sampler,
+		"scheduler":     cfg.Scheduler,
+		"denoise":       denoise,
+		"mode_type":     "Linear",
+		"tile_width":    tile,
+		"tile_height":   tile,
+		"mask_blur":     8,
+		"tile_padding":  32,
+
+		"seam_fix_mode":       "None",
+		"seam_fix_denoise":    1.0,
+		"seam_fix_width":      64,
+		"seam_fix_mask_blur":  8,
+		"seam_fix_padding":    16,
+		"force_uniform_tiles": true,
+		"tiled_decode":        false,
+	}}
+	workflow.Nodes["9"].Inputs["images"] = []interface{}{"8", 0}
+	return workflow, nil
+}
+
+// Upscale uploads cfg's input image and queues the upscale workflow,
+// returning ComfyUI's prompt ID. Unlike Generate it starts from pixels, so
+// there is no latent or checkpoint unless cfg.Detail is set.
+func (c *Client) Upscale(cfg *UpscaleConfig) (string, error) {
+	workflow, err := BuildUpscaleWorkflow(cfg)
+	if err != nil {
+		return "", err
+	}
+	if cfg.Detail {
+		if ok, err := c.HasNode("UltimateSDUpscale"); err != nil {
+			return "", err
+		} else if !ok {
+			return "", fmt.Errorf("ComfyUI has no UltimateSDUpscale node (install ComfyUI_UltimateSDUpscale, or skip the detail pass)")
+		}
+	}
+
+	name, err := c.UploadImage(cfg.InputImagePath)
+	if err != nil {
+		return "", err
+	}
+	workflow.Nodes["1"].Inputs["image"] = name
+
+	promptID, err := c.Queue(workflow)
+	if err == nil && cfg.Detail {
+		c.setLoadedCheckpoint(cfg.Checkpoint)
+	}
+	return promptID, err
+}
+
+// nodeInfo is the part of /object_info used here. Each required input is
+// [type, options], where type is a list of choices for combo inputs.
+type nodeInfo struct {
+	Input struct {
+		Required map[string][]interface{} `json:"required"`
+	} `json:"input"`
+}
+
+// objectInfo fetches /object_info/<class>, ComfyUI's description of a
+// node's inputs. The result is empty when the node isn't installed.
+func (c *Client) objectInfo(class string) (map[string]nodeInfo, error) {
+	var info map[string]nodeInfo
+	if err := c.getJSON("/object_info/"+url.PathEscape(class), &info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// HasNode reports whether ComfyUI has a node class installed
+func (c *Client) HasNode(class string) (bool, error) {
+	info, err := c.objectInfo(class)
+	if err != nil {
+		return false, err
+	}
+	_, ok := info[class]
+	return ok, nil
+}
+
+// UpscaleModels lists the upscale model files ComfyUI offers, as the
+// choices of UpscaleModelLoader's model_name input in /object_info
+func (c *Client) UpscaleModels() ([]string, error) {
+	info, err := c.objectInfo("UpscaleModelLoader")
+	if err != nil {
+		return nil, err
+	}
+	node, ok := info["UpscaleModelLoader"]
+	if !ok {
+		return nil, fmt.Errorf("ComfyUI has no UpscaleModelLoader node")
+	}
+	input := node.Input.Required["model_name"]
+	if len(input) == 0 {
+		return nil, nil
+	}
+	choices, _ := input[0].([]interface{})
+	var models []string
+	for _, choice := range choices {
+		if name, ok := choice.(string); ok {
+			models = append(models, name)
+		}
+	}
+	return models, nil
+}
+
+// looseModelName ignores case and separators, so "4xUltraSharp.pth"
+// finds "4x-UltraSharp.pth"
+func looseModelName(name string) string {
+	return strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(name))
+}
+
+// PickUpscaleModel returns want if it's installed (ignoring case and
+// separators). With want empty it returns the first model whose native
+// factor matches scale, or failing that the first model.
+func PickUpscaleModel(models []string, want string, scale float64) (string, error) {
+	if len(models) == 0 {
+		return "", fmt.Errorf("no upscale models installed (put one in ComfyUI's models/upscale_models)")
+	}
+	if want != "" {
+		for _, m := range models {
+			if m == want || looseModelName(filepath.Base(m)) == looseModelName(want) {
+				return m, nil
+			}
+		}
+		return "", fmt.Errorf("upscale model %s not installed (have: %s)", want, strings.Join(models, ", "))
+	}
+	for _, m := range models {
+		if float64(UpscaleModelFactor(m)) == scale {
+			return m, nil
+		}
+	}
+	return models[0], nil
+}
@@ -0,0 +1,89 @@
+package sd
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestUpscaleModelFactor(t *testing.T) {
+	for name, want := range map[string]int{
+		"4x-UltraSharp.pth":            4,
+		"RealESRGAN_x2plus.pth":        2,
+		"4x_NMKD-Siax_200k.pth":        4,
+		"ESRGAN/8x_NMKD-Superscale.pt": 8,
+		"SwinIR.pth":                   0,
+	} {
+		if got := UpscaleModelFactor(name); got != want {
+			t.Errorf("UpscaleModelFactor(%q) = %d, want %d", name, got, want)
+		}
+	}
+}
+
+func TestBuildUpscaleWorkflow(t *testing.T) {
+	cfg := &UpscaleConfig{
+		InputImagePath: "sketch.png", Width: 512, Height: 520, Scale: 4,
+		Model: "4x-UltraSharp.pth", OutputPrefix: "up",
+	}
+	w, err := BuildUpscaleWorkflow(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := w.Nodes["4"].Inputs["width"]; got != 2048 {
+		t.Errorf("width = %v, want 2048", got)
+	}
+	if _, ok := w.Nodes["8"]; ok {
+		t.Error("plain upscale should not add UltimateSDUpscale")
+	}
+
+	cfg.Detail = true
+	cfg.Checkpoint = "sd_xl_base_1.0.safetensors"
+	cfg.Prompt = NewPrompt("detailed")
+	if w, err = BuildUpscaleWorkflow(cfg); err != nil {
+		t.Fatal(err)
+	}
+	usdu := w.Nodes["8"]
+	if usdu.ClassType != "UltimateSDUpscale" || usdu.Inputs["tile_width"] != 1024 || usdu.Inputs["denoise"] != DefaultUpscaleDenoise {
+		t.Errorf("detail node = %+v", usdu)
+	}
+	if got := w.Nodes["9"].Inputs["images"]; !reflect.DeepEqual(got, []interface{}{"8", 0}) {
+		t.Errorf("save input = %v", got)
+	}
+
+	cfg.Scale = 1
+	if _, err := BuildUpscaleWorkflow(cfg); err == nil {
+		t.Error("scale 1 should be rejected")
+	}
+}
+
+func TestUpscaleModels(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/object_info/UpscaleModelLoader":
+			fmt.Fprint(w, `{"UpscaleModelLoader": {"input": {"required": {"model_name": [["RealESRGAN_x2plus.pth", "4x-UltraSharp.pth"]]}}}}`)
+		default:
+			fmt.Fprint(w, `{}`)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	models, err := client.UpscaleModels()
+	if err != nil || len(models) != 2 {
+		t.Fatalf("models = %v, %v", models, err)
+	}
+	if m, _ := PickUpscaleModel(models, "4xUltraSharp.pth", 2); m != "4x-UltraSharp.pth" {
+		t.Errorf("picked %s for 4xUltraSharp.pth", m)
+	}
+	if m, _ := PickUpscaleModel(models, "", 4); m != "4x-UltraSharp.pth" {
+		t.Errorf("picked %s for 4x", m)
+	}
+	if _, err := PickUpscaleModel(models, "missing.pth", 4); err == nil {
+		t.Error("missing model should be an error")
+	}
+	if ok, err := client.HasNode("UltimateSDUpscale"); err != nil || ok {
+		t.Errorf("HasNode on {} = %v, %v", ok, err)
+	}
+}
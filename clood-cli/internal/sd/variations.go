@@ -0,0 +1,106 @@
+package sd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// placeholderRe matches {name} placeholders in a prompt template.
+var placeholderRe = regexp.MustCompile(`\{(\w+)\}`)
+
+// PromptVariant is one set of placeholder values, e.g. {"subject": "tortoise"}.
+type PromptVariant map[string]string
+
+// LoadPromptVariants reads a variants file: a JSON array of objects whose
+// values fill a prompt template's placeholders. Non-string values such as
+// numbers are used as written.
+func LoadPromptVariants(path string) ([]PromptVariant, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw []map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse variations %s: %w", path, err)
+	}
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("variations %s is empty", path)
+	}
+
+	variants := make([]PromptVariant, len(raw))
+	for i, r := range raw {
+		variants[i] = make(PromptVariant, len(r))
+		for k, v := range r {
+			variants[i][k] = fmt.Sprint(v)
+		}
+	}
+	return variants, nil
+}
+
+// Placeholders lists a template's placeholder names in order of first use.
+func Placeholders(template string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, m := range placeholderRe.FindAllStringSubmatch(template, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			names = append(names, m[1])
+		}
+	}
+	return names
+}
+
+// ExpandPrompt fills template's placeholders from v. A placeholder v has
+// no value for is an error rather than a literal "{subject}" in the image.
+func ExpandPrompt(template string, v PromptVariant) (string, error) {
+	var missing []string
+	out := placeholderRe.ReplaceAllStringFunc(template, func(m string) string {
+		name := m[1 : len(m)-1]
+		val, ok := v[name]
+		if !ok {
+			missing = append(missing, name)
+			return m
+		}
+		return val
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("no value for {%s}", strings.Join(missing, "}, {"))
+	}
+	return out, nil
+}
+
+// PromptVariations expands template once per variant. Each variation is
+// named after its placeholder values ("red_panda", "tortoise_watercolor")
+// so the values end up in the output filename; repeats get a numeric suffix.
+func PromptVariations(template string, variants []PromptVariant) ([]Variation, error) {
+	names := Placeholders(template)
+	if len(names) == 0 {
+		return nil, fmt.Errorf("prompt has no {placeholders} for the variations to fill")
+	}
+
+	used := make(map[string]int)
+	variations := make([]Variation, 0, len(variants))
+	for i, v := range variants {
+		prompt, err := ExpandPrompt(template, v)
+		if err != nil {
+			return nil, fmt.Errorf("variation %d: %w", i+1, err)
+		}
+
+		var parts []string
+		for _, n := range names {
+			parts = append(parts, strings.ReplaceAll(strings.TrimSpace(v[n]), " ", "_"))
+		}
+		name := sanitizeVariationName(strings.Join(parts, "_"))
+		used[name]++
+		if used[name] > 1 {
+			name = fmt.Sprintf("%s_%d", name, used[name])
+		}
+
+		variations = append(variations, Variation{Name: name, Prompt: prompt})
+	}
+	return variations, nil
+}
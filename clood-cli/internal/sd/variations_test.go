@@ -0,0 +1,46 @@
+package sd
+
+import "testing"
+
+func TestPromptVariations(t *testing.T) {
+	variants := []PromptVariant{
+		{"subject": "tortoise", "style": "ghibli"},
+		{"subject": "red panda", "style": "ghibli"},
+		{"subject": "tortoise", "style": "ghibli"},
+	}
+	got, err := PromptVariations("a {subject} in {style} style, {subject} centered", variants)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Variation{
+		{Name: "tortoise_ghibli", Prompt: "a tortoise in ghibli style, tortoise centered"},
+		{Name: "red_panda_ghibli", Prompt: "a red panda in ghibli style, red panda centered"},
+		{Name: "tortoise_ghibli_2", Prompt: "a tortoise in ghibli style, tortoise centered"},
+	}
+	for i := range want {
+		if got[i].Name != want[i].Name || got[i].Prompt != want[i].Prompt {
+			t.Errorf("variation %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestPromptVariationsErrors(t *testing.T) {
+	if _, err := PromptVariations("a cat", []PromptVariant{{"subject": "x"}}); err == nil {
+		t.Error("template without placeholders should fail")
+	}
+	if _, err := PromptVariations("a {subject} by {artist}", []PromptVariant{{"subject": "x"}}); err == nil {
+		t.Error("missing placeholder value should fail")
+	}
+}
+
+func TestVariationConfigPromptOverride(t *testing.T) {
+	base := NewPrompt("a {subject}").WithSeed(7)
+	cfg := variationConfig(base, Variation{Name: "cat", Checkpoint: "dreamshaper_8.safetensors", Prompt: "a cat"})
+	if cfg.Prompt.Positive != "a cat" || cfg.Prompt.Seed != 7 {
+		t.Errorf("prompt = %+v", cfg.Prompt)
+	}
+	if base.Positive != "a {subject}" {
+		t.Errorf("base prompt was modified: %q", base.Positive)
+	}
+}
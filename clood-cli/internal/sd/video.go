@@ -0,0 +1,138 @@
+package sd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	// DefaultMotionModel is the AnimateDiff v2 motion module for SD1.5
+	// checkpoints, which is what most AnimateDiff setups ship with
+	DefaultMotionModel = "mm_sd_v15_v2.ckpt"
+
+	DefaultVideoFrames = 16
+	DefaultVideoFPS    = 8
+
+	// MaxVideoFrames is AnimateDiff's context length without context
+	// options; longer clips need sliding-window nodes this graph doesn't add
+	MaxVideoFrames = 32
+
+	// videoMinCFG is where VideoLinearCFGGuidance starts its ramp: the
+	// first frame gets this CFG and the last gets cfg.CFGScale, which
+	// keeps later frames from burning out
+	videoMinCFG = 1.0
+)
+
+// addVideoNodes turns the txt2img graph into an AnimateDiff one. The empty
+// latent becomes a batch of cfg.Frames, and the checkpoint's model goes
+// through the motion module and VideoLinearCFGGuidance before the sampler.
+// SaveImage then writes one numbered image per frame.
+//
+// The loader is ADE_AnimateDiffLoaderGen1 from ComfyUI-AnimateDiff-Evolved.
+func addVideoNodes(workflow *ComfyWorkflow, cfg *WorkflowConfig) error {
+	if cfg.InputImagePath != "" {
+		return fmt.Errorf("video mode does not take an input image")
+	}
+	if cfg.Frames < 2 || cfg.Frames > MaxVideoFrames {
+		return fmt.Errorf("frames must be between 2 and %d, got %d", MaxVideoFrames, cfg.Frames)
+	}
+	motionModel := cfg.MotionModel
+	if motionModel == "" {
+		motionModel = DefaultMotionModel
+	}
+
+	workflow.Nodes["5"].Inputs["batch_size"] = cfg.Frames
+	workflow.Nodes["20"] = ComfyNode{
+		ClassType: "ADE_AnimateDiffLoaderGen1",
+		Inputs: map[string]interface{}{
+			"model":         []interface{}{"4", 0},
+			"model_name":    motionModel,
+			"beta_schedule": "autoselect",
+		},
+	}
+	workflow.Nodes["21"] = ComfyNode{
+		ClassType: "VideoLinearCFGGuidance",
+		Inputs: map[string]interface{}{
+			"model":   []interface{}{"20", 0},
+			"min_cfg": videoMinCFG,
+		},
+	}
+	workflow.Nodes["3"].Inputs["model"] = []interface{}{"21", 0}
+	return nil
+}
+
+// SortFrames orders a video's output images by filename. SaveImage numbers
+// a batch in frame order, but /history makes no promise about list order.
+func SortFrames(images []OutputImage) []OutputImage {
+	sorted := append([]OutputImage(nil), images...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Subfolder != sorted[j].Subfolder {
+			return sorted[i].Subfolder < sorted[j].Subfolder
+		}
+		return sorted[i].Filename < sorted[j].Filename
+	})
+	return sorted
+}
+
+// FramePattern is the printf pattern DownloadFrames names frames with,
+// which is also the input pattern handed to ffmpeg
+const FramePattern = "frame_%05d.png"
+
+// DownloadFrames saves images into dir as frame_00001.png, frame_00002.png,
+// ... in the order given, and returns the paths.
+func (c *Client) DownloadFrames(images []OutputImage, dir string) ([]string, error) {
+	var paths []string
+	for i, img := range images {
+		dest := filepath.Join(dir, fmt.Sprintf(FramePattern, i+1))
+		if err := c.Download(img, dest); err != nil {
+			return paths, fmt.Errorf("frame %d: %w", i+1, err)
+		}
+		paths = append(paths, dest)
+	}
+	return paths, nil
+}
+
+// FindFFmpeg returns the path to ffmpeg, or an error saying how to get it
+func FindFFmpeg() (string, error) {
+	path, err := exec.LookPath("ffmpeg")
+	if err != nil {
+		return "", fmt.Errorf("ffmpeg not found in PATH (install it with 'brew install ffmpeg' or your package manager)")
+	}
+	return path, nil
+}
+
+// FFmpegArgs are the arguments that encode dir's numbered frames as an
+// H.264 MP4 most players accept. The scale filter rounds odd sizes down to
+// even, which yuv420p requires.
+func FFmpegArgs(dir string, fps int, out string) []string {
+	return []string{
+		"-y", "-loglevel", "error",
+		"-framerate", fmt.Sprint(fps),
+		"-i", filepath.Join(dir, FramePattern),
+		"-vf", "scale=trunc(iw/2)*2:trunc(ih/2)*2",
+		"-c:v", "libx264",
+		"-pix_fmt", "yuv420p",
+		out,
+	}
+}
+
+// StitchFrames runs ffmpeg over the frames in dir to write out
+func StitchFrames(ffmpeg, dir string, fps int, out string) error {
+	if fps <= 0 {
+		return fmt.Errorf("fps must be positive, got %d", fps)
+	}
+	if outDir := filepath.Dir(out); outDir != "." {
+		if err := os.MkdirAll(outDir, 0755); err != nil {
+			return err
+		}
+	}
+	cmd := exec.Command(ffmpeg, FFmpegArgs(dir, fps, out)...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("ffmpeg: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package sd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestVideoWorkflow(t *testing.T) {
+	cfg := DefaultWorkflowConfig()
+	cfg.Prompt = NewPrompt("a tortoise walking")
+	cfg.Checkpoint = "v1-5-pruned-emaonly.safetensors"
+	cfg.VideoMode = true
+	cfg.Frames = 16
+
+	w, err := BuildBasicWorkflow(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := w.Nodes["5"].Inputs["batch_size"]; got != 16 {
+		t.Errorf("latent batch = %v, want 16", got)
+	}
+	if got := w.Nodes["20"].Inputs["model_name"]; got != DefaultMotionModel {
+		t.Errorf("motion model = %v", got)
+	}
+	if w.Nodes["21"].ClassType != "VideoLinearCFGGuidance" {
+		t.Errorf("node 21 = %s", w.Nodes["21"].ClassType)
+	}
+	if got := w.Nodes["3"].Inputs["model"]; !reflect.DeepEqual(got, []interface{}{"21", 0}) {
+		t.Errorf("sampler model = %v, want the CFG guidance node", got)
+	}
+
+	cfg.Frames = 1
+	if _, err := BuildBasicWorkflow(cfg); err == nil {
+		t.Error("one frame should be rejected")
+	}
+	cfg.Frames = 16
+	cfg.InputImagePath = "in.png"
+	if _, err := BuildBasicWorkflow(cfg); err == nil {
+		t.Error("video with an input image should be rejected")
+	}
+}
+
+func TestSortFrames(t *testing.T) {
+	images := []OutputImage{{Filename: "v_00003_.png"}, {Filename: "v_00001_.png"}, {Filename: "v_00002_.png"}}
+	sorted := SortFrames(images)
+	for i, want := range []string{"v_00001_.png", "v_00002_.png", "v_00003_.png"} {
+		if sorted[i].Filename != want {
+			t.Errorf("frame %d = %s, want %s", i, sorted[i].Filename, want)
+		}
+	}
+	if images[0].Filename != "v_00003_.png" {
+		t.Error("SortFrames modified its input")
+	}
+}
@@ -0,0 +1,159 @@
+package sd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// warmupSize is the latent edge used by the warmup workflow: big enough to
+// be valid, small enough that the single step costs nothing
+const warmupSize = 64
+
+// BuildWarmupWorkflow returns the smallest graph that makes ComfyUI load
+// checkpoint onto the GPU: one step on a tiny latent, sent to a preview
+// node so nothing is written to the output directory.
+func BuildWarmupWorkflow(checkpoint string) *ComfyWorkflow {
+	return &ComfyWorkflow{Nodes: map[string]ComfyNode{
+		"1": {ClassType: "CheckpointLoaderSimple", Inputs: map[string]interface{}{
+			"ckpt_name": checkpoint,
+		}},
+		"2": {ClassType: "CLIPTextEncode", Inputs: map[string]interface{}{
+			"text": "",
+			"clip": []interface{}{"1", 1},
+		}},
+		"3": {ClassType: "EmptyLatentImage", Inputs: map[string]interface{}{
+			"width":      warmupSize,
+			"height":     warmupSize,
+			"batch_size": 1,
+		}},
+		"4": {ClassType: "KSampler", Inputs: map[string]interface{}{
+			"seed":         0,
+			"steps":        1,
+			"cfg":          1.0,
+			"sampler_name": DefaultSampler,
+			"scheduler":    DefaultScheduler,
+			"denoise":      1.0,
+			"model":        []interface{}{"1", 0},
+			"positive":     []interface{}{"2", 0},
+			"negative":     []interface{}{"2", 0},
+			"latent_image": []interface{}{"3", 0},
+		}},
+		"5": {ClassType: "VAEDecode", Inputs: map[string]interface{}{
+			"samples": []interface{}{"4", 0},
+			"vae":     []interface{}{"1", 2},
+		}},
+		"6": {ClassType: "PreviewImage", Inputs: map[string]interface{}{
+			"images": []interface{}{"5", 0},
+		}},
+	}}
+}
+
+// LoadedCheckpoint reports the checkpoint ComfyUI most likely has in VRAM:
+// the one in the running prompt, or failing that the last one run. ComfyUI
+// keeps the last model loaded unless it runs short of memory, so this is a
+// good guess rather than a guarantee. The answer is cached on the client
+// and kept current by Warmup.
+func (c *Client) LoadedCheckpoint() (string, error) {
+	c.mu.Lock()
+	cached := c.loadedCheckpoint
+	c.mu.Unlock()
+	if cached != "" {
+		return cached, nil
+	}
+
+	var queue struct {
+		Running []json.RawMessage `json:"queue_running"`
+	}
+	if err := c.getJSON("/queue", &queue); err != nil {
+		return "", err
+	}
+	for _, item := range queue.Running {
+		if ckpt := promptCheckpoint(item); ckpt != "" {
+			c.setLoadedCheckpoint(ckpt)
+			return ckpt, nil
+		}
+	}
+
+	var history map[string]struct {
+		Prompt json.RawMessage `json:"prompt"`
+	}
+	if err := c.getJSON("/history?max_items=1", &history); err != nil {
+		return "", err
+	}
+	for _, entry := range history {
+		if ckpt := promptCheckpoint(entry.Prompt); ckpt != "" {
+			c.setLoadedCheckpoint(ckpt)
+			return ckpt, nil
+		}
+	}
+	return "", nil
+}
+
+// Warmup loads checkpoint so the next generation starts sampling straight
+// away. It returns false without queueing anything when the checkpoint is
+// already loaded; with wait set it blocks until the load finishes.
+func (c *Client) Warmup(checkpoint string, wait time.Duration) (bool, error) {
+	if loaded, err := c.LoadedCheckpoint(); err == nil && loaded == checkpoint {
+		return false, nil
+	}
+
+	promptID, err := c.Queue(BuildWarmupWorkflow(checkpoint))
+	if err != nil {
+		return false, err
+	}
+	if wait > 0 {
+		// A warmup is mostly model load, which would skew the wait estimate
+		if _, err := c.wait(promptID, wait, false); err != nil {
+			return true, err
+		}
+	}
+	c.setLoadedCheckpoint(checkpoint)
+	return true, nil
+}
+
+func (c *Client) setLoadedCheckpoint(checkpoint string) {
+	c.mu.Lock()
+	c.loadedCheckpoint = checkpoint
+	c.mu.Unlock()
+}
+
+// promptCheckpoint pulls the ckpt_name out of a queue or history item,
+// which ComfyUI reports as [number, prompt_id, graph, extra, outputs].
+func promptCheckpoint(item json.RawMessage) string {
+	var fields []json.RawMessage
+	if json.Unmarshal(item, &fields) != nil || len(fields) < 3 {
+		return ""
+	}
+	var graph map[string]ComfyNode
+	if json.Unmarshal(fields[2], &graph) != nil {
+		return ""
+	}
+	for _, node := range graph {
+		if node.ClassType == "CheckpointLoaderSimple" {
+			if name, ok := node.Inputs["ckpt_name"].(string); ok {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+func (c *Client) getJSON(path string, v interface{}) error {
+	resp, err := c.HTTPClient.Get(c.BaseURL + path)
+	if err != nil {
+		return fmt.Errorf("get %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("comfyui returned %d: %s", resp.StatusCode, string(b))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("decode %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package sd
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestWarmupSkipsLoadedCheckpoint(t *testing.T) {
+	running := ""
+	queued := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/queue":
+			fmt.Fprintf(w, `{"queue_running": [%s], "queue_pending": []}`, running)
+		case "/history":
+			fmt.Fprint(w, `{"old": {"prompt": [7, "old", {"4": {"class_type": "CheckpointLoaderSimple", "inputs": {"ckpt_name": "base.safetensors"}}}, {}, ["9"]]}}`)
+		case "/prompt":
+			queued++
+			fmt.Fprint(w, `{"prompt_id": "warm"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	if ckpt, err := client.LoadedCheckpoint(); err != nil || ckpt != "base.safetensors" {
+		t.Fatalf("from history: %q, %v", ckpt, err)
+	}
+	if warmed, err := client.Warmup("base.safetensors", 0); err != nil || warmed || queued != 0 {
+		t.Errorf("loaded checkpoint: warmed=%v err=%v queued=%d", warmed, err, queued)
+	}
+	if warmed, err := client.Warmup("other.safetensors", 0); err != nil || !warmed || queued != 1 {
+		t.Errorf("new checkpoint: warmed=%v err=%v queued=%d", warmed, err, queued)
+	}
+
+	// A running prompt wins over history
+	running = `[8, "now", {"1": {"class_type": "CheckpointLoaderSimple", "inputs": {"ckpt_name": "running.safetensors"}}}, {}, ["9"]]`
+	fresh := NewClient(srv.URL)
+	if ckpt, _ := fresh.LoadedCheckpoint(); ckpt != "running.safetensors" {
+		t.Errorf("from queue: %q", ckpt)
+	}
+}
+
+// sd paint --parallel shares one client between goroutines; run with -race
+func TestLoadedCheckpointConcurrent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/queue":
+			fmt.Fprint(w, `{"queue_running": [], "queue_pending": []}`)
+		case "/history":
+			fmt.Fprint(w, `{}`)
+		case "/prompt":
+			fmt.Fprint(w, `{"prompt_id": "warm"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := client.Warmup(fmt.Sprintf("model%d.safetensors", i%2), 0); err != nil {
+				t.Error(err)
+			}
+			client.LoadedCheckpoint()
+		}(i)
+	}
+	wg.Wait()
+	if ckpt, _ := client.LoadedCheckpoint(); ckpt != "model0.safetensors" && ckpt != "model1.safetensors" {
+		t.Errorf("loaded checkpoint = %q", ckpt)
+	}
+}
@@ -3,6 +3,7 @@ package sd
 import (
 	"encoding/json"
 	"fmt"
+	"path/filepath"
 )
 
 // ComfyWorkflow represents a ComfyUI workflow that can be executed via API.
@@ -15,6 +16,12 @@ type ComfyWorkflow struct {
 type ComfyNode struct {
 	ClassType string                 `json:"class_type"`
 	Inputs    map[string]interface{} `json:"inputs"`
+	Meta      *NodeMeta              `json:"_meta,omitempty"` // only in loaded workflows
+}
+
+// NodeMeta is the _meta ComfyUI's API-format export adds to each node
+type NodeMeta struct {
+	Title string `json:"title,omitempty"`
 }
 
 // WorkflowConfig contains all parameters needed to generate a workflow.
@@ -29,23 +36,52 @@ type WorkflowConfig struct {
 	Scheduler    string   `json:"scheduler"`     // e.g., "normal", "karras"
 	BatchSize    int      `json:"batch_size"`
 	OutputPrefix string   `json:"output_prefix"`
+
+	// img2img: when InputImagePath is set the image is VAE-encoded and used
+	// as the starting latent instead of an empty one.
+	InputImagePath string  `json:"input_image_path,omitempty"`
+	Denoise        float64 `json:"denoise,omitempty"` // 0-1, lower keeps more of the input
+
+	// Inpainting: with InputImagePath, only the white area of this mask is
+	// regenerated (see BuildOutpaintInputs)
+	MaskImagePath string `json:"mask_image_path,omitempty"`
+
+	// ControlNets condition the prompt on reference images (see addControlNets)
+	ControlNets []ControlNetConfig `json:"controlnets,omitempty"`
+
+	// LoRASchedules load LoRAs whose weight can change partway through
+	// sampling (see addLoRASchedules)
+	LoRASchedules []LoRASchedule `json:"lora_schedules,omitempty"`
+
+	// Video: Frames latents are sampled together through an AnimateDiff
+	// motion model and saved as numbered frames (see addVideoNodes)
+	VideoMode   bool   `json:"video_mode,omitempty"`
+	Frames      int    `json:"frames,omitempty"`
+	FPS         int    `json:"fps,omitempty"`
+	MotionModel string `json:"motion_model,omitempty"` // e.g., "mm_sd_v15_v2.ckpt"
 }
 
-// DefaultWorkflowConfig returns sensible defaults for SDXL.
+// DefaultWorkflowConfig returns sensible defaults for SDXL, with
+// ComfyUI's default sampler and scheduler.
 func DefaultWorkflowConfig() *WorkflowConfig {
 	return &WorkflowConfig{
 		Width:        1024,
 		Height:       1024,
 		Steps:        25,
 		CFGScale:     7.0,
-		Sampler:      "euler_ancestral",
-		Scheduler:    "normal",
+		Sampler:      DefaultSampler,
+		Scheduler:    DefaultScheduler,
 		BatchSize:    1,
 		OutputPrefix: "clood",
+		Denoise:      1.0,
 	}
 }
 
-// BuildBasicWorkflow creates a simple txt2img workflow for ComfyUI.
+// BuildBasicWorkflow creates a simple txt2img workflow for ComfyUI, or an
+// img2img workflow when cfg.InputImagePath is set (inpainting with a
+// mask), with any ControlNets applied to the conditioning and scheduled
+// LoRAs loaded, splitting the sampler in two when their weights change. With
+// cfg.VideoMode the sampler runs through AnimateDiff to produce frames.
 // This generates the JSON that ComfyUI's API accepts.
 func BuildBasicWorkflow(cfg *WorkflowConfig) (*ComfyWorkflow, error) {
 	if cfg.Prompt == nil {
@@ -60,6 +96,11 @@ func BuildBasicWorkflow(cfg *WorkflowConfig) (*ComfyWorkflow, error) {
 		seed = 0 // ComfyUI uses 0 for random
 	}
 
+	denoise := cfg.Denoise
+	if denoise <= 0 || denoise > 1 {
+		denoise = 1.0
+	}
+
 	workflow := &ComfyWorkflow{
 		Nodes: map[string]ComfyNode{
 			"3": {
@@ -70,7 +111,7 @@ func BuildBasicWorkflow(cfg *WorkflowConfig) (*ComfyWorkflow, error) {
 					"cfg":          cfg.CFGScale,
 					"sampler_name": cfg.Sampler,
 					"scheduler":    cfg.Scheduler,
-					"denoise":      1.0,
+					"denoise":      denoise,
 					"model":        []interface{}{"4", 0},
 					"positive":     []interface{}{"6", 0},
 					"negative":     []interface{}{"7", 0},
@@ -122,6 +163,71 @@ func BuildBasicWorkflow(cfg *WorkflowConfig) (*ComfyWorkflow, error) {
 		},
 	}
 
+	if cfg.InputImagePath != "" {
+		// Swap the empty latent for LoadImage -> VAEEncode. LoadImage refers
+		// to a file in ComfyUI's input directory (see Client.UploadImage).
+		workflow.Nodes["5"] = ComfyNode{
+			ClassType: "LoadImage",
+			Inputs: map[string]interface{}{
+				"image": filepath.Base(cfg.InputImagePath),
+			},
+		}
+		workflow.Nodes["10"] = ComfyNode{
+			ClassType: "VAEEncode",
+			Inputs: map[string]interface{}{
+				"pixels": []interface{}{"5", 0},
+				"vae":    []interface{}{"4", 2},
+			},
+		}
+		workflow.Nodes["3"].Inputs["latent_image"] = []interface{}{"10", 0}
+
+		if cfg.MaskImagePath != "" {
+			// InpaintModelConditioning encodes the image with the mask as a
+			// noise mask, so the sampler only changes the white area. Unlike
+			// VAEEncodeForInpaint it keeps the masked pixels, so a denoise
+			// below 1 still follows what was there.
+			workflow.Nodes["11"] = ComfyNode{
+				ClassType: "LoadImageMask",
+				Inputs: map[string]interface{}{
+					"image":   filepath.Base(cfg.MaskImagePath),
+					"channel": "red",
+				},
+			}
+			workflow.Nodes["10"] = ComfyNode{
+				ClassType: "InpaintModelConditioning",
+				Inputs: map[string]interface{}{
+					"positive":   []interface{}{"6", 0},
+					"negative":   []interface{}{"7", 0},
+					"vae":        []interface{}{"4", 2},
+					"pixels":     []interface{}{"5", 0},
+					"mask":       []interface{}{"11", 0},
+					"noise_mask": true,
+				},
+			}
+			workflow.Nodes["3"].Inputs["positive"] = []interface{}{"10", 0}
+			workflow.Nodes["3"].Inputs["negative"] = []interface{}{"10", 1}
+			workflow.Nodes["3"].Inputs["latent_image"] = []interface{}{"10", 2}
+		}
+	}
+
+	if len(cfg.ControlNets) > 0 {
+		if err := addControlNets(workflow, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(cfg.LoRASchedules) > 0 {
+		if err := addLoRASchedules(workflow, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.VideoMode {
+		if err := addVideoNodes(workflow, cfg); err != nil {
+			return nil, err
+		}
+	}
+
 	return workflow, nil
 }
 
@@ -0,0 +1,152 @@
+package system
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dirtybirdnj/clood/internal/sqlite"
+)
+
+// benchHistoryMigrations is the versioned schema for bench.db.
+// Append new entries; never edit existing ones.
+var benchHistoryMigrations = []string{
+	`CREATE TABLE bench_runs (
+		id            INTEGER PRIMARY KEY AUTOINCREMENT,
+		recorded_at   TIMESTAMP NOT NULL,
+		host          TEXT NOT NULL,
+		model         TEXT NOT NULL,
+		prompt_hash   TEXT NOT NULL,
+		prompt_tokens INTEGER NOT NULL DEFAULT 0,
+		tokens        INTEGER NOT NULL DEFAULT 0,
+		duration_ms   INTEGER NOT NULL DEFAULT 0,
+		tok_per_sec   REAL NOT NULL DEFAULT 0
+	);
+	CREATE INDEX idx_bench_runs_model_host ON bench_runs (model, host, recorded_at);`,
+	`CREATE TABLE bench_quality (
+		id          INTEGER PRIMARY KEY AUTOINCREMENT,
+		recorded_at TIMESTAMP NOT NULL,
+		host        TEXT NOT NULL,
+		model       TEXT NOT NULL,
+		prompt_set  TEXT NOT NULL,
+		total       INTEGER NOT NULL DEFAULT 0,
+		passed      INTEGER NOT NULL DEFAULT 0,
+		score       REAL NOT NULL DEFAULT 0,
+		duration_ms INTEGER NOT NULL DEFAULT 0,
+		results     TEXT NOT NULL DEFAULT '[]'
+	);
+	CREATE INDEX idx_bench_quality_model_host ON bench_quality (model, host, recorded_at);`,
+}
+
+// BenchRun is a single recorded benchmark run
+type BenchRun struct {
+	ID           int64     `json:"id"`
+	RecordedAt   time.Time `json:"recorded_at"`
+	Host         string    `json:"host"`
+	Model        string    `json:"model"`
+	PromptHash   string    `json:"prompt_hash"`
+	PromptTokens int       `json:"prompt_tokens"`
+	Tokens       int       `json:"tokens"`
+	DurationMs   int64     `json:"duration_ms"`
+	TokPerSec    float64   `json:"tok_per_sec"`
+}
+
+// BenchHistory is the persistent SQLite log of every `clood bench` run.
+// Unlike BenchmarkStore (best result only), it keeps every run for trends.
+type BenchHistory struct {
+	db *sql.DB
+}
+
+// BenchHistoryPath returns the default location, ~/.clood/bench.db
+func BenchHistoryPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".clood", "bench.db"), nil
+}
+
+// OpenBenchHistory opens (and migrates) the bench history database at path
+func OpenBenchHistory(path string) (*BenchHistory, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	db, err := sqlite.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sqlite.Migrate(db, benchHistoryMigrations); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &BenchHistory{db: db}, nil
+}
+
+// Close releases the database handle
+func (h *BenchHistory) Close() error {
+	return h.db.Close()
+}
+
+// Record stores a run. RecordedAt defaults to now.
+func (h *BenchHistory) Record(run BenchRun) error {
+	if run.RecordedAt.IsZero() {
+		run.RecordedAt = time.Now()
+	}
+	_, err := h.db.Exec(`INSERT INTO bench_runs
+		(recorded_at, host, model, prompt_hash, prompt_tokens, tokens, duration_ms, tok_per_sec)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		run.RecordedAt.UTC(), run.Host, run.Model, run.PromptHash,
+		run.PromptTokens, run.Tokens, run.DurationMs, run.TokPerSec)
+	return err
+}
+
+// Runs returns recorded runs oldest-first, optionally filtered by model and
+// host. limit keeps only the most recent N runs (0 = all).
+func (h *BenchHistory) Runs(model, host string, limit int) ([]BenchRun, error) {
+	query := `SELECT id, recorded_at, host, model, prompt_hash, prompt_tokens, tokens, duration_ms, tok_per_sec
+		FROM bench_runs WHERE (? = '' OR model = ?) AND (? = '' OR host = ?)
+		ORDER BY recorded_at DESC, id DESC`
+	args := []interface{}{model, model, host, host}
+	if limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, limit)
+	}
+
+	rows, err := h.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var runs []BenchRun
+	for rows.Next() {
+		var r BenchRun
+		if err := rows.Scan(&r.ID, &r.RecordedAt, &r.Host, &r.Model, &r.PromptHash,
+			&r.PromptTokens, &r.Tokens, &r.DurationMs, &r.TokPerSec); err != nil {
+			return nil, err
+		}
+		runs = append(runs, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Reverse to chronological order for trend display
+	for i, j := 0, len(runs)-1; i < j; i, j = i+1, j-1 {
+		runs[i], runs[j] = runs[j], runs[i]
+	}
+
+	return runs, nil
+}
+
+// HashPrompt returns a short stable hash so runs with the same prompt can be compared
+func HashPrompt(prompt string) string {
+	sum := sha256.Sum256([]byte(prompt))
+	return hex.EncodeToString(sum[:])[:12]
+}
@@ -0,0 +1,73 @@
+package system
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBenchHistoryRecordAndRuns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bench.db")
+
+	h, err := OpenBenchHistory(path)
+	if err != nil {
+		t.Fatalf("OpenBenchHistory failed: %v", err)
+	}
+
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i, tps := range []float64{40, 42, 38} {
+		err := h.Record(BenchRun{
+			RecordedAt: base.Add(time.Duration(i) * time.Hour),
+			Host:       "ubuntu25",
+			Model:      "qwen2.5-coder:3b",
+			PromptHash: HashPrompt("haiku"),
+			Tokens:     100,
+			TokPerSec:  tps,
+		})
+		if err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+	}
+	h.Record(BenchRun{RecordedAt: base, Host: "mac-mini", Model: "llama3.1:8b", TokPerSec: 20})
+	h.Close()
+
+	// Reopen to make sure migrations are idempotent
+	h, err = OpenBenchHistory(path)
+	if err != nil {
+		t.Fatalf("reopen failed: %v", err)
+	}
+	defer h.Close()
+
+	runs, err := h.Runs("qwen2.5-coder:3b", "", 0)
+	if err != nil {
+		t.Fatalf("Runs failed: %v", err)
+	}
+	if len(runs) != 3 {
+		t.Fatalf("expected 3 runs, got %d", len(runs))
+	}
+	if runs[0].TokPerSec != 40 || runs[2].TokPerSec != 38 {
+		t.Errorf("expected chronological order, got %v, %v", runs[0].TokPerSec, runs[2].TokPerSec)
+	}
+
+	recent, _ := h.Runs("qwen2.5-coder:3b", "ubuntu25", 2)
+	if len(recent) != 2 || recent[1].TokPerSec != 38 {
+		t.Errorf("limit should keep most recent runs, got %+v", recent)
+	}
+
+	all, _ := h.Runs("", "", 0)
+	if len(all) != 4 {
+		t.Errorf("expected 4 runs without filters, got %d", len(all))
+	}
+}
+
+func TestHashPromptStable(t *testing.T) {
+	if HashPrompt("a") != HashPrompt("a") {
+		t.Error("HashPrompt should be deterministic")
+	}
+	if HashPrompt("a") == HashPrompt("b") {
+		t.Error("different prompts should hash differently")
+	}
+	if len(HashPrompt("a")) != 12 {
+		t.Errorf("expected 12 char hash, got %d", len(HashPrompt("a")))
+	}
+}
@@ -0,0 +1,206 @@
+package system
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/dirtybirdnj/clood/internal/memory"
+)
+
+// Match modes for quality prompts
+const (
+	MatchContains = "contains" // case-insensitive substring (default)
+	MatchRegex    = "regex"    // Go regexp against the whole response
+	MatchSemantic = "semantic" // embedding cosine similarity >= threshold
+)
+
+// DefaultSemanticThreshold is the similarity a semantic match needs to pass
+const DefaultSemanticThreshold = 0.8
+
+// QualityPrompt is one entry of a `clood bench quality` prompt set
+type QualityPrompt struct {
+	Prompt   string `json:"prompt"`
+	Expected string `json:"expected"`
+	Match    string `json:"match,omitempty"`
+}
+
+// QualityResult is how a model did on one prompt
+type QualityResult struct {
+	Prompt   string  `json:"prompt"`
+	Match    string  `json:"match"`
+	Response string  `json:"response"`
+	Passed   bool    `json:"passed"`
+	Score    float64 `json:"score"` // 0/1, or the similarity for semantic
+	Error    string  `json:"error,omitempty"`
+}
+
+// LoadQualityPrompts reads and validates a prompt set:
+// [{"prompt": "...", "expected": "...", "match": "regex|contains|semantic"}]
+func LoadQualityPrompts(path string) ([]QualityPrompt, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var prompts []QualityPrompt
+	if err := json.Unmarshal(data, &prompts); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if len(prompts) == 0 {
+		return nil, fmt.Errorf("%s has no prompts", path)
+	}
+
+	for i := range prompts {
+		p := &prompts[i]
+		if p.Prompt == "" || p.Expected == "" {
+			return nil, fmt.Errorf("%s: prompt %d needs both prompt and expected", path, i+1)
+		}
+		p.Match = strings.ToLower(p.Match)
+		switch p.Match {
+		case "":
+			p.Match = MatchContains
+		case MatchContains, MatchSemantic:
+		case MatchRegex:
+			if _, err := regexp.Compile(p.Expected); err != nil {
+				return nil, fmt.Errorf("%s: prompt %d: %w", path, i+1, err)
+			}
+		default:
+			return nil, fmt.Errorf("%s: prompt %d: unknown match %q (use contains, regex, or semantic)", path, i+1, p.Match)
+		}
+	}
+	return prompts, nil
+}
+
+// ScoreResponse checks response against p. embed is only called for
+// semantic matches and may be nil otherwise.
+func ScoreResponse(p QualityPrompt, response string, embed memory.Embedder, threshold float64) QualityResult {
+	result := QualityResult{Prompt: p.Prompt, Match: p.Match, Response: response}
+
+	switch p.Match {
+	case MatchRegex:
+		re, err := regexp.Compile(p.Expected)
+		if err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		result.Passed = re.MatchString(response)
+	case MatchSemantic:
+		if embed == nil {
+			result.Error = "no embedding model for semantic match"
+			return result
+		}
+		want, err := embed(p.Expected)
+		if err != nil {
+			result.Error = "embedding expected: " + err.Error()
+			return result
+		}
+		got, err := embed(response)
+		if err != nil {
+			result.Error = "embedding response: " + err.Error()
+			return result
+		}
+		result.Score = memory.CosineSimilarity(want, got)
+		result.Passed = result.Score >= threshold
+		return result
+	default:
+		result.Passed = strings.Contains(strings.ToLower(response), strings.ToLower(p.Expected))
+	}
+
+	if result.Passed {
+		result.Score = 1
+	}
+	return result
+}
+
+// QualityRun is one recorded `clood bench quality` run
+type QualityRun struct {
+	ID         int64           `json:"id"`
+	RecordedAt time.Time       `json:"recorded_at"`
+	Host       string          `json:"host"`
+	Model      string          `json:"model"`
+	PromptSet  string          `json:"prompt_set"` // hash of the prompt file
+	Total      int             `json:"total"`
+	Passed     int             `json:"passed"`
+	Score      float64         `json:"score"` // passed / total
+	DurationMs int64           `json:"duration_ms"`
+	Results    []QualityResult `json:"results,omitempty"`
+}
+
+// NewQualityRun aggregates per-prompt results into a run
+func NewQualityRun(host, model, promptSet string, results []QualityResult, duration time.Duration) QualityRun {
+	run := QualityRun{
+		Host:       host,
+		Model:      model,
+		PromptSet:  promptSet,
+		Total:      len(results),
+		DurationMs: duration.Milliseconds(),
+		Results:    results,
+	}
+	for _, r := range results {
+		if r.Passed {
+			run.Passed++
+		}
+	}
+	if run.Total > 0 {
+		run.Score = float64(run.Passed) / float64(run.Total)
+	}
+	return run
+}
+
+// RecordQuality stores a quality run. RecordedAt defaults to now.
+func (h *BenchHistory) RecordQuality(run QualityRun) error {
+	if run.RecordedAt.IsZero() {
+		run.RecordedAt = time.Now()
+	}
+	results, err := json.Marshal(run.Results)
+	if err != nil {
+		return err
+	}
+	_, err = h.db.Exec(`INSERT INTO bench_quality
+		(recorded_at, host, model, prompt_set, total, passed, score, duration_ms, results)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		run.RecordedAt.UTC(), run.Host, run.Model, run.PromptSet,
+		run.Total, run.Passed, run.Score, run.DurationMs, string(results))
+	return err
+}
+
+// QualityRuns returns quality runs oldest-first, filtered like Runs.
+// Per-prompt results are not loaded.
+func (h *BenchHistory) QualityRuns(model, host string, limit int) ([]QualityRun, error) {
+	query := `SELECT id, recorded_at, host, model, prompt_set, total, passed, score, duration_ms
+		FROM bench_quality WHERE (? = '' OR model = ?) AND (? = '' OR host = ?)
+		ORDER BY recorded_at DESC, id DESC`
+	args := []interface{}{model, model, host, host}
+	if limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, limit)
+	}
+
+	rows, err := h.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var runs []QualityRun
+	for rows.Next() {
+		var r QualityRun
+		if err := rows.Scan(&r.ID, &r.RecordedAt, &r.Host, &r.Model, &r.PromptSet,
+			&r.Total, &r.Passed, &r.Score, &r.DurationMs); err != nil {
+			return nil, err
+		}
+		runs = append(runs, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, j := 0, len(runs)-1; i < j; i, j = i+1, j-1 {
+		runs[i], runs[j] = runs[j], runs[i]
+	}
+	return runs, nil
+}
@@ -0,0 +1,59 @@
+package system
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestScoreResponse(t *testing.T) {
+	embed := func(text string) ([]float64, error) {
+		if text == "Paris" || text == "The capital is Paris." {
+			return []float64{1, 0.1}, nil
+		}
+		return []float64{0.1, 1}, nil
+	}
+
+	tests := []struct {
+		prompt   QualityPrompt
+		response string
+		want     bool
+	}{
+		{QualityPrompt{Expected: "paris", Match: MatchContains}, "The capital is Paris.", true},
+		{QualityPrompt{Expected: "berlin", Match: MatchContains}, "The capital is Paris.", false},
+		{QualityPrompt{Expected: `\b4\b`, Match: MatchRegex}, "2+2 = 4", true},
+		{QualityPrompt{Expected: `^\d+$`, Match: MatchRegex}, "four", false},
+		{QualityPrompt{Expected: "Paris", Match: MatchSemantic}, "The capital is Paris.", true},
+		{QualityPrompt{Expected: "Paris", Match: MatchSemantic}, "I don't know", false},
+	}
+	for _, tt := range tests {
+		got := ScoreResponse(tt.prompt, tt.response, embed, DefaultSemanticThreshold)
+		if got.Passed != tt.want || got.Error != "" {
+			t.Errorf("ScoreResponse(%+v, %q) = %+v, want passed=%v", tt.prompt, tt.response, got, tt.want)
+		}
+	}
+}
+
+func TestBenchHistoryQualityRuns(t *testing.T) {
+	h, err := OpenBenchHistory(filepath.Join(t.TempDir(), "bench.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	results := []QualityResult{{Passed: true, Score: 1}, {Passed: false}, {Passed: true, Score: 0.9}}
+	run := NewQualityRun("ubuntu25", "qwen2.5-coder:3b", HashPrompt("set"), results, 0)
+	if run.Passed != 2 || run.Score != 2.0/3.0 {
+		t.Fatalf("aggregate = %d passed, score %v", run.Passed, run.Score)
+	}
+	if err := h.RecordQuality(run); err != nil {
+		t.Fatal(err)
+	}
+
+	runs, err := h.QualityRuns("qwen2.5-coder:3b", "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 1 || runs[0].Total != 3 || runs[0].Passed != 2 {
+		t.Errorf("QualityRuns = %+v", runs)
+	}
+}
@@ -0,0 +1,187 @@
+package system
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Task types scored by the capability matrix
+const (
+	TaskCode          = "code"
+	TaskReasoning     = "reasoning"
+	TaskMath          = "math"
+	TaskSummarization = "summarization"
+	TaskWriting       = "writing"
+	TaskVision        = "vision"
+	TaskChat          = "chat"
+)
+
+// taskKeywords maps words in a free-form task description to a task type.
+// Checked in order, so more specific tasks come first.
+var taskKeywords = []struct {
+	task     string
+	keywords []string
+}{
+	{TaskVision, []string{"image", "vision", "photo", "picture", "screenshot", "ocr"}},
+	{TaskMath, []string{"math", "arithmetic", "equation", "calculus", "algebra", "proof"}},
+	{TaskCode, []string{"code", "coding", "program", "function", "refactor", "debug", "bug", "review", "sql", "script"}},
+	{TaskSummarization, []string{"summar", "tldr", "digest", "condense", "document"}},
+	{TaskWriting, []string{"writ", "essay", "story", "blog", "copy", "prose", "poem", "haiku"}},
+	{TaskReasoning, []string{"reason", "logic", "analy", "plan", "think", "puzzle"}},
+	{TaskChat, []string{"chat", "convers", "assistant", "question"}},
+}
+
+// MatchTask maps a description like "document summarization" to a task type.
+// Unrecognised descriptions fall back to chat.
+func MatchTask(description string) string {
+	d := strings.ToLower(description)
+	for _, tk := range taskKeywords {
+		if tk.task == d {
+			return tk.task
+		}
+	}
+	for _, tk := range taskKeywords {
+		for _, kw := range tk.keywords {
+			if strings.Contains(d, kw) {
+				return tk.task
+			}
+		}
+	}
+	return TaskChat
+}
+
+// CapabilityMatrix scores model families (0-10) per task type.
+type CapabilityMatrix struct {
+	Models map[string]map[string]float64 `yaml:"models" json:"models"`
+}
+
+// DefaultCapabilityMatrix is the built-in matrix. Users can override any
+// entry via LoadCapabilityMatrix.
+func DefaultCapabilityMatrix() *CapabilityMatrix {
+	return &CapabilityMatrix{Models: map[string]map[string]float64{
+		"qwen2.5-coder":  {TaskCode: 9, TaskReasoning: 6, TaskMath: 6, TaskSummarization: 5, TaskWriting: 4, TaskChat: 5},
+		"deepseek-coder": {TaskCode: 8, TaskReasoning: 5, TaskMath: 5, TaskSummarization: 4, TaskWriting: 3, TaskChat: 4},
+		"codestral":      {TaskCode: 9, TaskReasoning: 6, TaskMath: 5, TaskSummarization: 5, TaskWriting: 4, TaskChat: 5},
+		"codellama":      {TaskCode: 7, TaskReasoning: 4, TaskMath: 4, TaskSummarization: 3, TaskWriting: 3, TaskChat: 3},
+		"starcoder":      {TaskCode: 7, TaskReasoning: 3, TaskMath: 3, TaskSummarization: 2, TaskWriting: 2, TaskChat: 2},
+		"codegemma":      {TaskCode: 7, TaskReasoning: 4, TaskMath: 4, TaskSummarization: 4, TaskWriting: 3, TaskChat: 4},
+		"deepseek-r1":    {TaskCode: 7, TaskReasoning: 9, TaskMath: 9, TaskSummarization: 6, TaskWriting: 6, TaskChat: 5},
+		"qwen2.5":        {TaskCode: 7, TaskReasoning: 8, TaskMath: 8, TaskSummarization: 8, TaskWriting: 7, TaskChat: 8},
+		"phi3":           {TaskCode: 5, TaskReasoning: 7, TaskMath: 7, TaskSummarization: 6, TaskWriting: 5, TaskChat: 6},
+		"llama3.1":       {TaskCode: 6, TaskReasoning: 8, TaskMath: 6, TaskSummarization: 8, TaskWriting: 8, TaskChat: 8},
+		"llama3.2":       {TaskCode: 5, TaskReasoning: 6, TaskMath: 5, TaskSummarization: 7, TaskWriting: 6, TaskChat: 7},
+		"llama3":         {TaskCode: 5, TaskReasoning: 7, TaskMath: 5, TaskSummarization: 7, TaskWriting: 7, TaskChat: 7},
+		"mistral":        {TaskCode: 5, TaskReasoning: 6, TaskMath: 5, TaskSummarization: 7, TaskWriting: 7, TaskChat: 7},
+		"mixtral":        {TaskCode: 7, TaskReasoning: 8, TaskMath: 7, TaskSummarization: 8, TaskWriting: 8, TaskChat: 8},
+		"gemma2":         {TaskCode: 5, TaskReasoning: 6, TaskMath: 5, TaskSummarization: 7, TaskWriting: 7, TaskChat: 7},
+		"gemma":          {TaskCode: 4, TaskReasoning: 5, TaskMath: 4, TaskSummarization: 6, TaskWriting: 6, TaskChat: 6},
+		"llava":          {TaskVision: 8, TaskChat: 4, TaskSummarization: 3},
+		"llava-phi3":     {TaskVision: 7, TaskChat: 4},
+		"moondream":      {TaskVision: 6, TaskChat: 2},
+		"bakllava":       {TaskVision: 7, TaskChat: 3},
+		"tinyllama":      {TaskCode: 2, TaskReasoning: 2, TaskMath: 1, TaskSummarization: 3, TaskWriting: 3, TaskChat: 4},
+	}}
+}
+
+// LoadCapabilityMatrix returns the built-in matrix with overrides from the
+// YAML file at path merged in per task. A missing file is not an error.
+func LoadCapabilityMatrix(path string) (*CapabilityMatrix, error) {
+	m := DefaultCapabilityMatrix()
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var custom CapabilityMatrix
+	if err := yaml.Unmarshal(data, &custom); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	for family, scores := range custom.Models {
+		family = strings.ToLower(family)
+		if m.Models[family] == nil {
+			m.Models[family] = make(map[string]float64)
+		}
+		for task, score := range scores {
+			m.Models[family][strings.ToLower(task)] = score
+		}
+	}
+	return m, nil
+}
+
+// Score returns a model's score for task and the matrix family it matched
+// (longest prefix wins, so "qwen2.5-coder" beats "qwen2.5"). Unknown
+// models return family "".
+func (m *CapabilityMatrix) Score(model, task string) (float64, string) {
+	base := strings.ToLower(model)
+	if idx := strings.Index(base, ":"); idx > 0 {
+		base = base[:idx]
+	}
+	// Strip registry/namespace prefixes like "hf.co/user/"
+	if idx := strings.LastIndex(base, "/"); idx >= 0 {
+		base = base[idx+1:]
+	}
+
+	family := ""
+	for f := range m.Models {
+		if strings.HasPrefix(base, f) && len(f) > len(family) {
+			family = f
+		}
+	}
+	if family == "" {
+		return 0, ""
+	}
+	return m.Models[family][task], family
+}
+
+// Recommendation is one ranked model for a task
+type Recommendation struct {
+	Model  string   `json:"model"`
+	Family string   `json:"family,omitempty"`
+	Score  float64  `json:"score"`
+	Reason string   `json:"reason"`
+	Hosts  []string `json:"hosts,omitempty"`
+}
+
+// Recommend ranks the available models (name -> hosts) for task, highest
+// score first. Ties go to the model on more hosts, then by name.
+func (m *CapabilityMatrix) Recommend(models map[string][]string, task string) []Recommendation {
+	var recs []Recommendation
+	for name, hosts := range models {
+		score, family := m.Score(name, task)
+		var reason string
+		switch {
+		case family == "":
+			reason = "not in the capability matrix"
+		case score == 0:
+			reason = fmt.Sprintf("%s has no %s score", family, task)
+		default:
+			reason = fmt.Sprintf("%s scores %.0f/10 for %s", family, score, task)
+		}
+		recs = append(recs, Recommendation{
+			Model:  name,
+			Family: family,
+			Score:  score,
+			Reason: reason,
+			Hosts:  hosts,
+		})
+	}
+
+	sort.Slice(recs, func(i, j int) bool {
+		if recs[i].Score != recs[j].Score {
+			return recs[i].Score > recs[j].Score
+		}
+		if len(recs[i].Hosts) != len(recs[j].Hosts) {
+			return len(recs[i].Hosts) > len(recs[j].Hosts)
+		}
+		return recs[i].Model < recs[j].Model
+	})
+	return recs
+}
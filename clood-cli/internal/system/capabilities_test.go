@@ -0,0 +1,85 @@
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMatchTask(t *testing.T) {
+	tests := map[string]string{
+		"code generation":        TaskCode,
+		"document summarization": TaskSummarization,
+		"math reasoning":         TaskMath,
+		"describe this image":    TaskVision,
+		"write a blog post":      TaskWriting,
+		"reasoning":              TaskReasoning,
+		"something else":         TaskChat,
+	}
+	for desc, want := range tests {
+		if got := MatchTask(desc); got != want {
+			t.Errorf("MatchTask(%q) = %q, want %q", desc, got, want)
+		}
+	}
+}
+
+func TestCapabilityScoreLongestPrefix(t *testing.T) {
+	m := DefaultCapabilityMatrix()
+	if _, family := m.Score("qwen2.5-coder:7b", TaskCode); family != "qwen2.5-coder" {
+		t.Errorf("family = %q, want qwen2.5-coder", family)
+	}
+	if _, family := m.Score("qwen2.5:14b", TaskCode); family != "qwen2.5" {
+		t.Errorf("family = %q, want qwen2.5", family)
+	}
+	if score, family := m.Score("mystery:1b", TaskCode); score != 0 || family != "" {
+		t.Errorf("unknown model scored %v (%q)", score, family)
+	}
+}
+
+func TestRecommendRanksByTask(t *testing.T) {
+	m := DefaultCapabilityMatrix()
+	models := map[string][]string{
+		"qwen2.5-coder:7b": {"local"},
+		"llama3.1:8b":      {"local", "ubuntu25"},
+		"mystery:1b":       {"local"},
+	}
+
+	code := m.Recommend(models, TaskCode)
+	if code[0].Model != "qwen2.5-coder:7b" {
+		t.Errorf("best for code = %s, want qwen2.5-coder:7b", code[0].Model)
+	}
+	if code[len(code)-1].Model != "mystery:1b" {
+		t.Errorf("unknown model should rank last, got %s", code[len(code)-1].Model)
+	}
+
+	reasoning := m.Recommend(models, TaskReasoning)
+	if reasoning[0].Model != "llama3.1:8b" {
+		t.Errorf("best for reasoning = %s, want llama3.1:8b", reasoning[0].Model)
+	}
+}
+
+func TestLoadCapabilityMatrixMergesOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "capabilities.yaml")
+	custom := "models:\n  mystery:\n    code: 10\n  llama3.1:\n    code: 1\n"
+	if err := os.WriteFile(path, []byte(custom), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := LoadCapabilityMatrix(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if score, _ := m.Score("mystery:1b", TaskCode); score != 10 {
+		t.Errorf("custom family score = %v, want 10", score)
+	}
+	if score, _ := m.Score("llama3.1:8b", TaskCode); score != 1 {
+		t.Errorf("overridden score = %v, want 1", score)
+	}
+	if score, _ := m.Score("llama3.1:8b", TaskReasoning); score != 8 {
+		t.Errorf("untouched built-in score = %v, want 8", score)
+	}
+
+	if _, err := LoadCapabilityMatrix(filepath.Join(t.TempDir(), "missing.yaml")); err != nil {
+		t.Errorf("missing file should fall back to defaults: %v", err)
+	}
+}
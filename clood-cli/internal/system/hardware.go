@@ -201,8 +201,11 @@ func detectLinux(info *HardwareInfo) {
 		}
 	}
 
-	// Try to detect NVIDIA GPU
+	// Try to detect NVIDIA GPU, then AMD
 	detectNvidiaGPU(info)
+	if info.GPU == nil {
+		detectAMDGPU(info)
+	}
 
 	// If no GPU, set OllamaVRAM based on system RAM for CPU inference
 	if info.GPU == nil && info.MemoryGB > 0 {
@@ -284,6 +287,34 @@ func detectNvidiaGPU(info *HardwareInfo) {
 	}
 }
 
+// detectAMDGPU finds an AMD GPU through the amdgpu driver's sysfs entries,
+// which exist whether or not ROCm is installed
+func detectAMDGPU(info *HardwareInfo) {
+	vendors, _ := filepath.Glob("/sys/class/drm/card[0-9]*/device/vendor")
+	for _, vendorPath := range vendors {
+		vendor, err := os.ReadFile(vendorPath)
+		if err != nil || strings.TrimSpace(string(vendor)) != "0x1002" {
+			continue
+		}
+
+		device := filepath.Dir(vendorPath)
+		info.GPU = &GPUInfo{
+			Name: "AMD GPU",
+			Type: "amd",
+		}
+		if name, err := os.ReadFile(filepath.Join(device, "product_name")); err == nil && strings.TrimSpace(string(name)) != "" {
+			info.GPU.Name = strings.TrimSpace(string(name))
+		}
+		if vram, err := os.ReadFile(filepath.Join(device, "mem_info_vram_total")); err == nil {
+			if bytes, err := strconv.ParseFloat(strings.TrimSpace(string(vram)), 64); err == nil {
+				info.GPU.VRAM = bytes / (1024 * 1024 * 1024)
+				info.OllamaVRAM = info.GPU.VRAM
+			}
+		}
+		return
+	}
+}
+
 // detectAllDisks detects all mounted filesystems
 func detectAllDisks(info *HardwareInfo) {
 	switch runtime.GOOS {
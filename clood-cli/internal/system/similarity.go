@@ -0,0 +1,82 @@
+package system
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var wordRe = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+// Rouge1 returns the ROUGE-1 F1 score of a and b: the harmonic mean of
+// unigram precision and recall, case-insensitive, with repeated words
+// counted as often as they appear in both. Two empty texts score 1.
+func Rouge1(a, b string) float64 {
+	wa := wordCounts(a)
+	wb := wordCounts(b)
+	na, nb := total(wa), total(wb)
+	if na == 0 && nb == 0 {
+		return 1
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+
+	overlap := 0
+	for w, ca := range wa {
+		overlap += min(ca, wb[w])
+	}
+	if overlap == 0 {
+		return 0
+	}
+
+	precision := float64(overlap) / float64(na)
+	recall := float64(overlap) / float64(nb)
+	return 2 * precision * recall / (precision + recall)
+}
+
+func wordCounts(s string) map[string]int {
+	counts := map[string]int{}
+	for _, w := range wordRe.FindAllString(strings.ToLower(s), -1) {
+		counts[w]++
+	}
+	return counts
+}
+
+func total(counts map[string]int) int {
+	n := 0
+	for _, c := range counts {
+		n += c
+	}
+	return n
+}
+
+// LoadPromptLines reads a prompt file with one prompt per line. Blank lines
+// and lines starting with # are skipped.
+func LoadPromptLines(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var prompts []string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		prompts = append(prompts, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	if len(prompts) == 0 {
+		return nil, fmt.Errorf("%s has no prompts", path)
+	}
+	return prompts, nil
+}
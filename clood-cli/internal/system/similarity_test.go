@@ -0,0 +1,25 @@
+package system
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRouge1(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"The cat sat", "the cat sat", 1},
+		{"the cat sat", "a dog ran", 0},
+		{"the cat sat", "the cat", 0.8},         // p=1, r=2/3
+		{"the the cat", "the cat cat", 2.0 / 3}, // overlap counts min per word
+		{"", "", 1},
+		{"words", "", 0},
+	}
+	for _, tt := range tests {
+		if got := Rouge1(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Rouge1(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 )
@@ -225,7 +226,7 @@ type StreamingLoader struct {
 // LoaderItem represents an item being loaded with its status
 type LoaderItem struct {
 	Name    string
-	Status  string // "pending", "checking", "online", "offline", "error"
+	Status  string // "pending", "checking", "online", "offline", "error", "pulling", "done"
 	Details string
 }
 
@@ -355,6 +356,15 @@ func (l *StreamingLoader) renderItem(item LoaderItem) {
 		if item.Details != "" {
 			statusText += " " + MutedStyle.Render(item.Details)
 		}
+	case "pulling":
+		indicator = AccentStyle.Render("◐")
+		statusText = item.Details
+	case "done":
+		indicator = SuccessStyle.Render("✓")
+		statusText = SuccessStyle.Render("done")
+		if item.Details != "" {
+			statusText += " " + MutedStyle.Render(item.Details)
+		}
 	case "offline":
 		indicator = ErrorStyle.Render("○")
 		statusText = ErrorStyle.Render("offline")
@@ -368,3 +378,19 @@ func (l *StreamingLoader) renderItem(item LoaderItem) {
 
 	fmt.Printf("  %s %s %s\n", indicator, item.Name, statusText)
 }
+
+// ProgressBar renders a fixed-width bar for completed/total
+func ProgressBar(completed, total int64, width int) string {
+	filled := 0
+	if total > 0 {
+		filled = int(completed * int64(width) / total)
+	}
+	if filled > width {
+		filled = width
+	}
+	if filled < 0 {
+		filled = 0
+	}
+	return AccentStyle.Render(strings.Repeat("█", filled)) +
+		MutedStyle.Render(strings.Repeat("░", width-filled))
+}